@@ -0,0 +1,135 @@
+// Command claude-reactor-agent runs inside claude-reactor containers and lets tools there talk
+// to the claude-reactor CLI on the host: desktop notifications, port forwarding, the host
+// clipboard, status reports, MCP servers run outside the container, and the tools used.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"claude-reactor/internal/reactor/agent"
+)
+
+const usage = `Usage: claude-reactor-agent <command> [arguments]
+
+Commands:
+  notify [-title TITLE] MESSAGE    Show a notification on the host
+  forward PORT [HOST_PORT]         Forward a host port to PORT in this container
+  clipboard                        Print the host clipboard (needs the clipboard bridge)
+  status STATE [MESSAGE]           Report what this container is doing, e.g. "waiting"
+  mcp NAME                         Run the MCP server NAME from a sidecar or the host (run by Claude)
+  missing COMMAND                  Report a command that was not found (run by the shell)
+  bridge                           Relay requests to the host (run by claude-reactor)
+  pipe SOCKET                      Connect stdin and stdout to SOCKET (run by claude-reactor)
+
+The host is only reachable while a claude-reactor session is attached.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err := run(os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "claude-reactor-agent: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(command string, args []string) error {
+	switch command {
+	case "notify":
+		flags := flag.NewFlagSet("notify", flag.ExitOnError)
+		title := flags.String("title", "claude-reactor", "Notification title")
+		flags.Parse(args)
+		if flags.NArg() == 0 {
+			return fmt.Errorf("notify needs a message")
+		}
+		params := agent.NotifyParams{Title: *title, Message: strings.Join(flags.Args(), " ")}
+		return agent.Call(agent.Socket(), agent.MethodNotify, params, nil)
+
+	case "forward":
+		if len(args) == 0 || len(args) > 2 {
+			return fmt.Errorf("usage: claude-reactor-agent forward PORT [HOST_PORT]")
+		}
+		var params agent.ForwardParams
+		var err error
+		if params.ContainerPort, err = strconv.Atoi(args[0]); err != nil {
+			return fmt.Errorf("invalid port: %s", args[0])
+		}
+		if len(args) == 2 {
+			if params.HostPort, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid host port: %s", args[1])
+			}
+		}
+		var result agent.ForwardResult
+		if err := agent.Call(agent.Socket(), agent.MethodForward, params, &result); err != nil {
+			return err
+		}
+		fmt.Printf("Forwarding %s on the host to port %d\n", result.Address, params.ContainerPort)
+		return nil
+
+	case "clipboard":
+		var result agent.ClipboardResult
+		if err := agent.Call(agent.Socket(), agent.MethodClipboardRead, struct{}{}, &result); err != nil {
+			return err
+		}
+		fmt.Print(result.Text)
+		return nil
+
+	case "status":
+		if len(args) == 0 {
+			return fmt.Errorf("status needs a state")
+		}
+		params := agent.Status{State: args[0], Message: strings.Join(args[1:], " ")}
+		return agent.Call(agent.Socket(), agent.MethodStatus, params, nil)
+
+	case "mcp":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: claude-reactor-agent mcp NAME")
+		}
+		return agent.ConnectMCP(args[0], os.Stdin, os.Stdout)
+
+	case "missing":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: claude-reactor-agent missing COMMAND")
+		}
+		return agent.Call(agent.Socket(), agent.MethodToolMissing, agent.ToolMissingParams{Name: args[0]}, nil)
+
+	case "pipe":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: claude-reactor-agent pipe SOCKET")
+		}
+		return agent.Pipe(args[0], os.Stdin, os.Stdout)
+
+	case "bridge":
+		listener, err := agent.Listen(agent.Socket())
+		if err != nil {
+			return err
+		}
+		defer os.Remove(agent.Socket())
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		bridge := agent.NewBridge(os.Stdout)
+		// Tool usage is best effort; images without the tool directories simply report nothing
+		go agent.WatchTools(ctx, agent.ToolDirs, agent.ToolReportInterval, func(tools map[string]int) {
+			bridge.Notify(agent.MethodToolsUsed, agent.ToolsUsedParams{Tools: tools})
+		})
+		return bridge.Run(ctx, listener, os.Stdin)
+
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", command, usage)
+	}
+}
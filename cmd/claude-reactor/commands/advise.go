@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/usage"
+	"claude-reactor/internal/reactor/variants"
+	"claude-reactor/pkg"
+)
+
+// adviseTopTools is how many of the most used tools advise names
+const adviseTopTools = 5
+
+// variantAdvice is the variant recommended for a project's tool usage
+type variantAdvice struct {
+	Current     string
+	Recommended string              // smallest built-in variant with every tool used or looked for; "" if none has them all
+	Needs       map[string][]string // built-in variant -> tools used or looked for that it adds
+}
+
+// NewAdviseCmd creates the advise command, which recommends a variant from the tools a project
+// actually uses
+func NewAdviseCmd(app *pkg.AppContainer) *cobra.Command {
+	adviseCmd := &cobra.Command{
+		Use:   "advise",
+		Short: "Recommend a variant from the tools the project uses",
+		Long: `Recommend the smallest built-in variant that has the tools the project uses.
+
+While a session is attached, the claude-reactor-agent in the container records
+which programs are run and which commands the shell could not find. The record
+stays on this machine, in the project's session directory. advise compares it
+with what each variant adds: a project that only runs go, git and make does not
+need the full variant's Rust and Java, and one that looked for helm needs k8s.
+
+Usage is recorded by images with the claude-reactor-agent, which the built-in
+variants include. Start over after switching variants with --reset.`,
+		Example: `# Which variant does this project need?
+claude-reactor advise
+
+# Forget the recorded usage
+claude-reactor advise --reset`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return runAdvise(cmd, app)
+		},
+	}
+
+	adviseCmd.Flags().Bool("reset", false, "Forget the project's recorded tool usage")
+
+	return adviseCmd
+}
+
+func runAdvise(cmd *cobra.Command, app *pkg.AppContainer) error {
+	reset, _ := cmd.Flags().GetBool("reset")
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	_, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath)
+
+	if reset {
+		if err := usage.Reset(sessionDir); err != nil {
+			return err
+		}
+		fmt.Fprintln(i18n.Stdout, "🧹 Forgot the tool usage recorded for this project")
+		return nil
+	}
+
+	recorded, err := usage.Load(sessionDir)
+	if err != nil {
+		return err
+	}
+	if len(recorded.Tools) == 0 && len(recorded.Missing) == 0 {
+		fmt.Fprintln(i18n.Stdout, "No tool usage recorded for this project yet")
+		fmt.Fprintln(i18n.Stdout, "💡 Usage is recorded while a session is attached: claude-reactor run")
+		return nil
+	}
+
+	used := usage.Sorted(recorded.Tools)
+	fmt.Fprintf(i18n.Stdout, "🔧 Tools used since %s: %s\n", recorded.Since.Format("2006-01-02"), toolCounts(used, 10))
+
+	advice := adviseVariant(config.Variant, recorded)
+	fmt.Fprintln(i18n.Stdout, adviceMessage(advice, used))
+
+	if len(recorded.Missing) > 0 {
+		fmt.Fprintln(i18n.Stdout, "\n⚠️  Commands not found:")
+		for _, tool := range usage.Sorted(recorded.Missing) {
+			where := "not in any built-in variant; add it with an external variant or a custom image"
+			if variant := variants.ToolVariant(tool.Name); variant != "" {
+				where = fmt.Sprintf("included in the %s variant", variant)
+			}
+			fmt.Fprintf(i18n.Stdout, "   %s (%d times, last %s): %s\n", tool.Name, tool.Count, tool.LastUsed.Format("2006-01-02"), where)
+		}
+	}
+	return nil
+}
+
+// adviseVariant finds the smallest built-in variant with every tool the project used or
+// looked for
+func adviseVariant(current string, recorded *usage.Usage) variantAdvice {
+	advice := variantAdvice{Current: current, Needs: map[string][]string{}}
+	for _, tools := range []map[string]usage.Tool{recorded.Tools, recorded.Missing} {
+		for _, tool := range usage.Sorted(tools) {
+			if variant := variants.ToolVariant(tool.Name); variant != "" {
+				advice.Needs[variant] = append(advice.Needs[variant], tool.Name)
+			}
+		}
+	}
+
+	candidates := append([]string{}, variants.Builtin...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return variants.BuiltinSizes[candidates[i]] < variants.BuiltinSizes[candidates[j]]
+	})
+	for _, candidate := range candidates {
+		fits := true
+		for needed := range advice.Needs {
+			fits = fits && variants.Includes(candidate, needed)
+		}
+		if fits {
+			advice.Recommended = candidate
+			break
+		}
+	}
+	return advice
+}
+
+// adviceMessage explains the advice, naming the project's most used tools
+func adviceMessage(advice variantAdvice, used []usage.Tool) string {
+	var needed []string
+	for _, variant := range variants.Builtin {
+		for _, tool := range advice.Needs[variant] {
+			needed = append(needed, fmt.Sprintf("%s (%s)", tool, variant))
+		}
+	}
+
+	switch {
+	case advice.Recommended == "":
+		return fmt.Sprintf("⚠️  No built-in variant has all the tools this project uses: %s\n💡 Combine them in an external variant or a custom image", strings.Join(needed, ", "))
+
+	case advice.Recommended == advice.Current:
+		if len(needed) == 0 {
+			return fmt.Sprintf("✅ The %s variant fits this project", advice.Current)
+		}
+		return fmt.Sprintf("✅ The %s variant fits: it is the smallest with %s", advice.Current, strings.Join(needed, ", "))
+
+	case !variants.IsBuiltin(advice.Current):
+		return fmt.Sprintf("💡 The smallest built-in variant with the tools used is %s (about %s)\n   claude-reactor config set variant %s",
+			advice.Recommended, formatSize(variants.BuiltinSizes[advice.Recommended]), advice.Recommended)
+
+	case variants.Includes(advice.Current, advice.Recommended):
+		var names []string
+		for i := 0; i < len(used) && i < adviseTopTools; i++ {
+			names = append(names, used[i].Name)
+		}
+		saving := variants.BuiltinSizes[advice.Current] - variants.BuiltinSizes[advice.Recommended]
+		return fmt.Sprintf("💡 You used only %s — switch from %s to %s and save about %s\n   claude-reactor config set variant %s",
+			strings.Join(names, ", "), advice.Current, advice.Recommended, formatSize(saving), advice.Recommended)
+
+	default:
+		return fmt.Sprintf("💡 Switch from %s to %s, which has %s\n   claude-reactor config set variant %s",
+			advice.Current, advice.Recommended, strings.Join(needed, ", "), advice.Recommended)
+	}
+}
+
+// toolCounts lists up to limit tools with how often each ran
+func toolCounts(tools []usage.Tool, limit int) string {
+	var counts []string
+	for i, tool := range tools {
+		if i == limit {
+			counts = append(counts, fmt.Sprintf("and %d more", len(tools)-limit))
+			break
+		}
+		counts = append(counts, fmt.Sprintf("%s (%d)", tool.Name, tool.Count))
+	}
+	if len(counts) == 0 {
+		return "none"
+	}
+	return strings.Join(counts, ", ")
+}
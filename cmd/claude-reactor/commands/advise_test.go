@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"claude-reactor/internal/reactor/usage"
+)
+
+func recordedUsage(tools map[string]int, missing ...string) *usage.Usage {
+	recorded := &usage.Usage{Since: time.Now(), Tools: map[string]usage.Tool{}, Missing: map[string]usage.Tool{}}
+	for name, count := range tools {
+		recorded.Tools[name] = usage.Tool{Count: count}
+	}
+	for _, name := range missing {
+		recorded.Missing[name] = usage.Tool{Count: 1}
+	}
+	return recorded
+}
+
+func TestAdviseVariant(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     string
+		recorded    *usage.Usage
+		recommended string
+		message     string
+	}{
+		{
+			name:        "smaller variant suffices",
+			current:     "full",
+			recorded:    recordedUsage(map[string]int{"go": 40, "git": 30, "make": 10}),
+			recommended: "go",
+			message:     "💡 You used only go, git, make — switch from full to go and save about 400.0 MB\n   claude-reactor config set variant go",
+		},
+		{
+			name:        "current variant fits",
+			current:     "full",
+			recorded:    recordedUsage(map[string]int{"cargo": 5, "go": 2}),
+			recommended: "full",
+			message:     "✅ The full variant fits: it is the smallest with go (go), cargo (full)",
+		},
+		{
+			name:        "base tools only",
+			current:     "base",
+			recorded:    recordedUsage(map[string]int{"git": 5, "node": 2}),
+			recommended: "base",
+			message:     "✅ The base variant fits this project",
+		},
+		{
+			name:        "missing tool needs a larger variant",
+			current:     "go",
+			recorded:    recordedUsage(map[string]int{"go": 5}, "helm"),
+			recommended: "k8s",
+			message:     "💡 Switch from go to k8s, which has go (go), helm (k8s)\n   claude-reactor config set variant k8s",
+		},
+		{
+			name:        "no built-in variant has everything",
+			current:     "cloud",
+			recorded:    recordedUsage(map[string]int{"terraform": 5, "helm": 1}),
+			recommended: "",
+			message:     "⚠️  No built-in variant has all the tools this project uses: terraform (cloud), helm (k8s)\n💡 Combine them in an external variant or a custom image",
+		},
+		{
+			name:        "custom image",
+			current:     "ghcr.io/my-org/dev:latest",
+			recorded:    recordedUsage(map[string]int{"go": 5}),
+			recommended: "go",
+			message:     "💡 The smallest built-in variant with the tools used is go (about 800.0 MB)\n   claude-reactor config set variant go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			advice := adviseVariant(tt.current, tt.recorded)
+			assert.Equal(t, tt.recommended, advice.Recommended)
+			assert.Equal(t, tt.message, adviceMessage(advice, usage.Sorted(tt.recorded.Tools)))
+		})
+	}
+}
+
+func TestToolCounts(t *testing.T) {
+	tools := usage.Sorted(map[string]usage.Tool{"go": {Count: 3}, "git": {Count: 2}, "make": {Count: 1}})
+	assert.Equal(t, "go (3), git (2), make (1)", toolCounts(tools, 10))
+	assert.Equal(t, "go (3), git (2), and 1 more", toolCounts(tools, 2))
+	assert.Equal(t, "none", toolCounts(nil, 10))
+}
@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"claude-reactor/internal/reactor/agent"
+	"claude-reactor/internal/reactor/clipboard"
+	"claude-reactor/internal/reactor/notify"
+	"claude-reactor/internal/reactor/rpc"
+	"claude-reactor/internal/reactor/usage"
+	"claude-reactor/pkg"
+)
+
+// agentStatusFile holds the last status reported by the container, in the project session directory
+const agentStatusFile = "agent-status.json"
+
+// startAgentHost answers requests from the claude-reactor-agent in a container until ctx ends.
+// Containers whose image has no agent are left alone.
+func startAgentHost(ctx context.Context, app *pkg.AppContainer, containerName string, config *pkg.Config, notifier *notify.Notifier) {
+	go func() {
+		if _, exitCode, err := app.DockerMgr.ExecCommand(ctx, containerName, []string{"test", "-x", agent.BinaryPath}); err != nil || exitCode != 0 {
+			app.Logger.Debugf("No claude-reactor-agent in %s; host integration unavailable", containerName)
+			return
+		}
+
+		server := rpc.NewServer(app.Logger)
+		registerAgentMethods(ctx, server, app, containerName, config, notifier)
+
+		// The bridge's stdin and stdout carry the requests; the server sees them as one connection
+		hostEnd, containerEnd := net.Pipe()
+		go server.ServeConn(ctx, hostEnd)
+		defer containerEnd.Close()
+
+		app.Logger.Debugf("Starting claude-reactor-agent bridge in %s", containerName)
+		if err := app.DockerMgr.ExecPipe(ctx, containerName, []string{agent.BinaryPath, "bridge"}, containerEnd, containerEnd); err != nil {
+			app.Logger.Debugf("claude-reactor-agent bridge ended: %v", err)
+		}
+	}()
+}
+
+// registerAgentMethods adds the methods the in-container agent can call
+func registerAgentMethods(ctx context.Context, server *rpc.Server, app *pkg.AppContainer, containerName string, config *pkg.Config, notifier *notify.Notifier) {
+	sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath)
+
+	server.Register(agent.MethodNotify, func(_ context.Context, params json.RawMessage) (interface{}, error) {
+		var p agent.NotifyParams
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.Title == "" {
+			p.Title = "claude-reactor"
+		}
+		return nil, notify.Send(p.Title, p.Message)
+	})
+
+	forwarder := &agentForwarder{app: app, containerName: containerName, dir: filepath.Join(sessionDir, "forwards")}
+	server.Register(agent.MethodForward, func(_ context.Context, params json.RawMessage) (interface{}, error) {
+		var p agent.ForwardParams
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.HostPort == 0 {
+			p.HostPort = p.ContainerPort
+		}
+		address, err := forwarder.forward(ctx, pkg.PortMapping{HostPort: p.HostPort, ContainerPort: p.ContainerPort})
+		if err != nil {
+			return nil, err
+		}
+		return agent.ForwardResult{Address: address}, nil
+	})
+
+	server.Register(agent.MethodClipboardRead, func(_ context.Context, params json.RawMessage) (interface{}, error) {
+		if !config.Clipboard {
+			return nil, fmt.Errorf("host clipboard access is disabled; enable it with: claude-reactor run --clipboard")
+		}
+		text, err := clipboard.ReadFromHost()
+		if err != nil {
+			return nil, err
+		}
+		return agent.ClipboardResult{Text: string(text)}, nil
+	})
+
+	server.Register(agent.MethodMCPConnect, func(_ context.Context, params json.RawMessage) (interface{}, error) {
+		var p agent.MCPConnectParams
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, connectMCP(ctx, app, containerName, config, p)
+	})
+
+	taskEnded := taskNotifier(app, notifier, config)
+	server.Register(agent.MethodStatus, func(_ context.Context, params json.RawMessage) (interface{}, error) {
+		var status agent.Status
+		if err := rpc.DecodeParams(params, &status); err != nil {
+			return nil, err
+		}
+		if status.State == "" {
+			return nil, &rpc.Error{Code: rpc.InvalidParams, Message: "state is required"}
+		}
+		status.Time = time.Now()
+		taskEnded(status.State)
+		return nil, writeAgentStatus(sessionDir, &status)
+	})
+
+	server.Register(agent.MethodToolsUsed, func(_ context.Context, params json.RawMessage) (interface{}, error) {
+		var p agent.ToolsUsedParams
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return nil, usage.RecordRuns(sessionDir, p.Tools, time.Now())
+	})
+
+	server.Register(agent.MethodToolMissing, func(_ context.Context, params json.RawMessage) (interface{}, error) {
+		var p agent.ToolMissingParams
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.Name == "" {
+			return nil, &rpc.Error{Code: rpc.InvalidParams, Message: "name is required"}
+		}
+		// Paths such as ./build.sh are not tools a variant could provide
+		if strings.ContainsRune(p.Name, '/') {
+			return nil, nil
+		}
+		return nil, usage.RecordMissing(sessionDir, p.Name, time.Now())
+	})
+}
+
+// agentForwarder serves port forwards requested from inside the container for the session's lifetime
+type agentForwarder struct {
+	app           *pkg.AppContainer
+	containerName string
+	dir           string
+
+	mu     sync.Mutex
+	active map[int]int // host port -> container port
+}
+
+// forward listens on a localhost port and relays connections into the container. Asking again
+// for an active forward returns the existing one.
+func (f *agentForwarder) forward(ctx context.Context, mapping pkg.PortMapping) (string, error) {
+	if err := validatePort(mapping.ContainerPort); err != nil {
+		return "", &rpc.Error{Code: rpc.InvalidParams, Message: err.Error()}
+	}
+	if err := validatePort(mapping.HostPort); err != nil {
+		return "", &rpc.Error{Code: rpc.InvalidParams, Message: err.Error()}
+	}
+
+	address := net.JoinHostPort("127.0.0.1", strconv.Itoa(mapping.HostPort))
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if containerPort, ok := f.active[mapping.HostPort]; ok {
+		if containerPort != mapping.ContainerPort {
+			return "", fmt.Errorf("host port %d already forwards to container port %d", mapping.HostPort, containerPort)
+		}
+		return address, nil
+	}
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+	if f.active == nil {
+		f.active = make(map[int]int)
+	}
+	f.active[mapping.HostPort] = mapping.ContainerPort
+	if err := recordForward(f.dir, mapping); err != nil {
+		f.app.Logger.Debugf("Failed to record forward: %v", err)
+	}
+	f.app.Logger.Debugf("🔀 Agent forwarding %s -> %s:%d", address, f.containerName, mapping.ContainerPort)
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+		removeForward(f.dir, mapping)
+	}()
+	go acceptConnections(ctx, f.app, listener, f.containerName, mapping.ContainerPort)
+	return address, nil
+}
+
+// validatePort checks a TCP port number
+func validatePort(port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("'%d' is not a valid port (1-65535)", port)
+	}
+	return nil
+}
+
+// writeAgentStatus stores the status the container reported
+func writeAgentStatus(sessionDir string, status *agent.Status) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	return os.WriteFile(filepath.Join(sessionDir, agentStatusFile), data, 0644)
+}
+
+// readAgentStatus returns the last status the container reported, or nil if there is none
+func readAgentStatus(sessionDir string) *agent.Status {
+	data, err := os.ReadFile(filepath.Join(sessionDir, agentStatusFile))
+	if err != nil {
+		return nil
+	}
+	var status agent.Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil
+	}
+	return &status
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/agent"
+	"claude-reactor/internal/reactor/rpc"
+	"claude-reactor/internal/reactor/usage"
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+// callAgentMethod sends one request to the agent methods and returns the raw response
+func callAgentMethod(t *testing.T, config *pkg.Config, sessionDir, method string, params interface{}) map[string]json.RawMessage {
+	authMgr := &mocks.MockAuthManager{}
+	authMgr.On("GetProjectSessionDir", config.Account, config.ProjectPath).Return(sessionDir)
+	app := createMockApp()
+	app.AuthMgr = authMgr
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := rpc.NewServer(app.Logger)
+	registerAgentMethods(ctx, server, app, "claude-reactor-base-amd64-1a2b3c4d-default", config, nil)
+
+	hostEnd, containerEnd := net.Pipe()
+	defer containerEnd.Close()
+	go server.ServeConn(ctx, hostEnd)
+
+	encoded, err := json.Marshal(params)
+	require.NoError(t, err)
+	request, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "method": method, "params": json.RawMessage(encoded)})
+	require.NoError(t, err)
+	_, err = containerEnd.Write(append(request, '\n'))
+	require.NoError(t, err)
+
+	scanner := bufio.NewScanner(containerEnd)
+	require.True(t, scanner.Scan())
+	var resp map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &resp))
+	return resp
+}
+
+func TestAgentStatusMethod(t *testing.T) {
+	sessionDir := t.TempDir()
+	config := &pkg.Config{Account: "default", ProjectPath: "/work/app"}
+
+	resp := callAgentMethod(t, config, sessionDir, agent.MethodStatus, agent.Status{State: "waiting", Message: "needs review"})
+	assert.NotContains(t, resp, "error")
+
+	status := readAgentStatus(sessionDir)
+	require.NotNil(t, status)
+	assert.Equal(t, "waiting", status.State)
+	assert.Equal(t, "needs review", status.Message)
+	assert.False(t, status.Time.IsZero())
+
+	resp = callAgentMethod(t, config, sessionDir, agent.MethodStatus, agent.Status{})
+	assert.Contains(t, string(resp["error"]), "state is required")
+}
+
+func TestAgentClipboardReadNeedsClipboardBridge(t *testing.T) {
+	config := &pkg.Config{Account: "default", ProjectPath: "/work/app"}
+
+	resp := callAgentMethod(t, config, t.TempDir(), agent.MethodClipboardRead, struct{}{})
+	assert.Contains(t, string(resp["error"]), "host clipboard access is disabled")
+}
+
+func TestAgentForwardValidatesPorts(t *testing.T) {
+	config := &pkg.Config{Account: "default", ProjectPath: "/work/app"}
+
+	resp := callAgentMethod(t, config, t.TempDir(), agent.MethodForward, agent.ForwardParams{ContainerPort: 70000})
+	assert.Contains(t, string(resp["error"]), "not a valid port")
+}
+
+func TestAgentToolUsageMethods(t *testing.T) {
+	sessionDir := t.TempDir()
+	config := &pkg.Config{Account: "default", ProjectPath: "/work/app"}
+
+	resp := callAgentMethod(t, config, sessionDir, agent.MethodToolsUsed, agent.ToolsUsedParams{Tools: map[string]int{"go": 4, "git": 2}})
+	assert.NotContains(t, resp, "error")
+	resp = callAgentMethod(t, config, sessionDir, agent.MethodToolMissing, agent.ToolMissingParams{Name: "cargo"})
+	assert.NotContains(t, resp, "error")
+	// Scripts run by path are not tools
+	resp = callAgentMethod(t, config, sessionDir, agent.MethodToolMissing, agent.ToolMissingParams{Name: "./build.sh"})
+	assert.NotContains(t, resp, "error")
+	resp = callAgentMethod(t, config, sessionDir, agent.MethodToolMissing, agent.ToolMissingParams{})
+	assert.Contains(t, string(resp["error"]), "name is required")
+
+	recorded, err := usage.Load(sessionDir)
+	require.NoError(t, err)
+	assert.Equal(t, 4, recorded.Tools["go"].Count)
+	assert.Equal(t, 2, recorded.Tools["git"].Count)
+	assert.Len(t, recorded.Missing, 1)
+	assert.Equal(t, 1, recorded.Missing["cargo"].Count)
+}
+
+func TestReadAgentStatusMissing(t *testing.T) {
+	assert.Nil(t, readAgentStatus(t.TempDir()))
+}
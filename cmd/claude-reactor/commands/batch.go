@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/batch"
+	"claude-reactor/pkg"
+)
+
+// NewBatchCmd creates the batch command for running prompts across many repositories
+func NewBatchCmd(app *pkg.AppContainer) *cobra.Command {
+	var batchCmd = &cobra.Command{
+		Use:   "batch",
+		Short: "Run prompts across many repositories from a manifest",
+		Long: `Run prompts across many repositories and collect the responses in one report.
+
+Each job runs 'claude-reactor prompt' in its repository, so every repo gets its
+own auto-detected container and configuration. Jobs run with bounded
+parallelism and the report is written as Markdown, or JSON for a .json path.
+
+Manifest format:
+  parallel: 4                     # jobs at once (default 2)
+  prompt: "Update the README"     # default prompt for jobs without one
+  image: go                       # optional default image
+  account: work                   # optional default account
+  jobs:
+    - path: ../service-a          # relative to the manifest
+    - name: billing
+      path: ~/src/billing
+      prompt: "Bump the Go version to 1.23"`,
+		Example: `# Run all jobs and write a Markdown report
+claude-reactor batch --manifest jobs.yaml
+
+# Override parallelism and write JSON
+claude-reactor batch --manifest jobs.yaml --parallel 8 --report results.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return runBatch(cmd, app)
+		},
+	}
+
+	batchCmd.Flags().StringP("manifest", "", "", "YAML manifest listing repositories and prompts")
+	batchCmd.Flags().IntP("parallel", "p", 0, "Maximum jobs to run at once (overrides the manifest)")
+	batchCmd.Flags().StringP("report", "r", "batch-report.md", "Report file (.json for JSON, otherwise Markdown)")
+	batchCmd.MarkFlagRequired("manifest")
+
+	return batchCmd
+}
+
+// runBatch loads the manifest, runs every job, and writes the consolidated report
+func runBatch(cmd *cobra.Command, app *pkg.AppContainer) error {
+	manifestPath, _ := cmd.Flags().GetString("manifest")
+	parallel, _ := cmd.Flags().GetInt("parallel")
+	reportPath, _ := cmd.Flags().GetString("report")
+
+	manifest, err := batch.LoadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	if parallel > 0 {
+		manifest.Parallel = parallel
+	}
+
+	for _, job := range manifest.Jobs {
+		if info, err := os.Stat(job.Path); err != nil || !info.IsDir() {
+			return fmt.Errorf("job '%s': repository path %s is not a directory", job.Name, job.Path)
+		}
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate claude-reactor executable: %w", err)
+	}
+
+	app.Logger.Infof("📦 Running %d jobs (%d at a time)...", len(manifest.Jobs), manifest.Parallel)
+	results := batch.Run(cmd.Context(), manifest.Jobs, manifest.Parallel, promptRunner(executable), func(r batch.Result) {
+		if r.Success {
+			app.Logger.Infof("✅ %s (%s)", r.Job.Name, r.Duration)
+		} else {
+			app.Logger.Errorf("❌ %s: %s", r.Job.Name, r.Error)
+		}
+	})
+
+	if err := batch.WriteReport(reportPath, results); err != nil {
+		return err
+	}
+	app.Logger.Infof("📄 Report written to %s", reportPath)
+
+	if failed := batch.Failed(results); failed > 0 {
+		return pkg.NewError(pkg.CodeBatchFailed, nil, "%d of %d jobs failed", failed, len(results)).
+			WithRemediation("See " + reportPath + " for details")
+	}
+	return nil
+}
+
+// promptRunner runs each job as a 'claude-reactor prompt' subprocess in the job's repository
+func promptRunner(executable string) batch.Runner {
+	return func(ctx context.Context, job batch.Job) (string, error) {
+		args := []string{"prompt", job.Prompt}
+		if job.Image != "" {
+			args = append(args, "--image", job.Image)
+		}
+		if job.Account != "" {
+			args = append(args, "--account", job.Account)
+		}
+
+		var stdout, stderr bytes.Buffer
+		command := exec.CommandContext(ctx, executable, args...)
+		command.Dir = job.Path
+		command.Stdout = &stdout
+		command.Stderr = &stderr
+
+		if err := command.Run(); err != nil {
+			// The last log lines usually carry the reason
+			lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+			if len(lines) > 3 {
+				lines = lines[len(lines)-3:]
+			}
+			return stdout.String(), fmt.Errorf("%w: %s", err, strings.Join(lines, " | "))
+		}
+		return stdout.String(), nil
+	}
+}
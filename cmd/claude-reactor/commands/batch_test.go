@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBatchCmd(t *testing.T) {
+	t.Run("create batch command", func(t *testing.T) {
+		cmd := NewBatchCmd(createMockApp())
+
+		assert.Equal(t, "batch", cmd.Use)
+		assert.NotNil(t, cmd.Flags().Lookup("manifest"))
+		assert.NotNil(t, cmd.Flags().Lookup("parallel"))
+		assert.Equal(t, "batch-report.md", cmd.Flags().Lookup("report").DefValue)
+	})
+
+	t.Run("batch command with nil app shows help", func(t *testing.T) {
+		cmd := NewBatchCmd(nil)
+
+		err := cmd.RunE(cmd, []string{})
+		assert.NoError(t, err)
+	})
+}
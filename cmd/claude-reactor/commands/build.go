@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/architecture"
+	"claude-reactor/internal/reactor/docker"
+	"claude-reactor/pkg"
+)
+
+// NewBuildCmd creates the build command for building variant images locally
+func NewBuildCmd(app *pkg.AppContainer) *cobra.Command {
+	var buildCmd = &cobra.Command{
+		Use:   "build",
+		Short: "Build an image variant locally",
+		Long: `Build a built-in image variant (base, go, full, cloud, k8s) from the
+claude-reactor Dockerfile, or an external variant defined in
+~/.claude-reactor/variants.d from its own Dockerfile.
+
+Use --platform to build for another architecture, for example linux/amd64 on an
+Apple Silicon Mac for projects that depend on amd64-only binaries. Images are
+tagged with their architecture, so host and emulated images live side by side.
+Emulated builds use Rosetta when Docker Desktop has it enabled, QEMU otherwise.
+
+Use --push to publish the build to a private registry as <registry>/<variant>:<tag>-<arch>,
+and to point <registry>/<variant>:<tag> at every architecture pushed under that tag, so
+machines of any architecture can pull it. Log in with docker login first.`,
+		Example: `# Build the detected or configured variant for this machine
+claude-reactor build
+
+# Build the go variant for amd64
+claude-reactor build --image go --platform linux/amd64
+
+# Remove the existing image and build from scratch
+claude-reactor build --force
+
+# Build the go variant and push it to a private registry
+claude-reactor build --image go --push --registry my.registry/claude-reactor`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return buildImage(cmd, app)
+		},
+	}
+
+	buildCmd.Flags().StringP("image", "", "", "Variant to build (default: configured or auto-detected)")
+	buildCmd.Flags().StringP("platform", "", "", "Target platform, e.g. linux/amd64 (default: configured or host platform)")
+	buildCmd.Flags().BoolP("force", "f", false, "Remove the existing image before building")
+	buildCmd.Flags().BoolP("push", "", false, "Push the built image to --registry")
+	buildCmd.Flags().StringP("registry", "", "", "Repository prefix to push to, e.g. my.registry/claude-reactor (default: $CLAUDE_REACTOR_REGISTRY)")
+	buildCmd.Flags().StringP("tag", "", "", "Tag to push (default: $CLAUDE_REACTOR_TAG or latest)")
+
+	return buildCmd
+}
+
+// buildImage builds the requested variant for the requested platform
+func buildImage(cmd *cobra.Command, app *pkg.AppContainer) error {
+	variant, _ := cmd.Flags().GetString("image")
+	platform, _ := cmd.Flags().GetString("platform")
+	force, _ := cmd.Flags().GetBool("force")
+	push, _ := cmd.Flags().GetBool("push")
+
+	// Check where to push before spending time on the build
+	repository, tag, err := pushTarget(cmd, push)
+	if err != nil {
+		return err
+	}
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+
+	config, err := app.ConfigMgr.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if variant == "" {
+		variant = config.Variant
+	}
+	if variant == "" {
+		variant, _ = app.ConfigMgr.AutoDetectVariant("")
+	}
+	if platform == "" {
+		platform = config.Platform
+	}
+
+	hostPlatform, err := app.ArchDetector.GetDockerPlatform()
+	if err != nil {
+		return fmt.Errorf("failed to get Docker platform: %w", err)
+	}
+	if platform == "" {
+		platform = hostPlatform
+	}
+	if err := architecture.ValidatePlatform(platform); err != nil {
+		return err
+	}
+
+	emulation, err := architecture.DetectEmulation(hostPlatform, platform)
+	if err != nil {
+		return err
+	}
+	if warning := architecture.EmulationWarning(platform, emulation); warning != "" {
+		app.Logger.Warnf("⚠️  %s", warning)
+	}
+
+	app.Logger.Infof("🔨 Building %s image for %s...", variant, platform)
+	started := time.Now()
+	imageName, err := buildVariant(cmd.Context(), app.DockerMgr, variant, platform, force)
+	notifyBuild(app, projectNotifier(app, config), variant, started, err)
+	if err != nil {
+		return err
+	}
+	app.Logger.Infof("✅ Built %s", imageName)
+
+	if push {
+		return pushVariant(cmd.Context(), app.DockerMgr, app.Logger, variant, platform, repository, tag)
+	}
+	return nil
+}
+
+// buildVariant builds variant for platform, from scratch with force, and returns the image name
+func buildVariant(ctx context.Context, images pkg.ImageService, variant, platform string, force bool) (string, error) {
+	if err := images.RebuildImage(ctx, variant, platform, force); err != nil {
+		return "", fmt.Errorf("failed to build image: %w", err)
+	}
+	return images.GetImageName(variant, architecture.PlatformArch(platform)), nil
+}
+
+// pushVariant pushes the built variant to repository, logging each reference pushed, including
+// those pushed before a failure
+func pushVariant(ctx context.Context, registry pkg.RegistryService, logger pkg.Logger, variant, platform, repository, tag string) error {
+	pushed, err := registry.PushImage(ctx, variant, platform, repository, tag)
+	for _, ref := range pushed {
+		logger.Infof("✅ Pushed %s", ref)
+	}
+	return err
+}
+
+// pushTarget returns the repository and tag to push to, from flags or the registry environment
+func pushTarget(cmd *cobra.Command, push bool) (string, string, error) {
+	repository, _ := cmd.Flags().GetString("registry")
+	tag, _ := cmd.Flags().GetString("tag")
+	if !push {
+		if cmd.Flags().Changed("registry") || cmd.Flags().Changed("tag") {
+			return "", "", fmt.Errorf("--registry and --tag only apply with --push")
+		}
+		return "", "", nil
+	}
+
+	if repository == "" {
+		repository = os.Getenv("CLAUDE_REACTOR_REGISTRY")
+	}
+	if repository == "" {
+		return "", "", pkg.NewError(pkg.CodeInvalidUsage, nil, "--push needs a registry to push to").
+			WithRemediation("Use --registry my.registry/claude-reactor or set CLAUDE_REACTOR_REGISTRY")
+	}
+	if err := docker.ValidateRepository(repository); err != nil {
+		return "", "", err
+	}
+
+	if tag == "" {
+		tag = os.Getenv("CLAUDE_REACTOR_TAG")
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+	return repository, tag, nil
+}
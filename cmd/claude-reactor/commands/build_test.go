@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg/mocks"
+)
+
+func TestNewBuildCmd(t *testing.T) {
+	t.Run("create build command", func(t *testing.T) {
+		app := createMockApp()
+		cmd := NewBuildCmd(app)
+
+		assert.Equal(t, "build", cmd.Use)
+		assert.NotNil(t, cmd.Flags().Lookup("image"))
+		assert.NotNil(t, cmd.Flags().Lookup("platform"))
+		assert.NotNil(t, cmd.Flags().Lookup("force"))
+	})
+
+	t.Run("build command with nil app shows help", func(t *testing.T) {
+		cmd := NewBuildCmd(nil)
+
+		err := cmd.RunE(cmd, []string{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestPushTarget(t *testing.T) {
+	t.Setenv("CLAUDE_REACTOR_REGISTRY", "")
+	t.Setenv("CLAUDE_REACTOR_TAG", "")
+
+	t.Run("flags set the repository and tag", func(t *testing.T) {
+		cmd := NewBuildCmd(createMockApp())
+		require.NoError(t, cmd.ParseFlags([]string{"--push", "--registry", "my.registry/team", "--tag", "v1"}))
+		repository, tag, err := pushTarget(cmd, true)
+		require.NoError(t, err)
+		assert.Equal(t, "my.registry/team", repository)
+		assert.Equal(t, "v1", tag)
+	})
+
+	t.Run("environment provides defaults", func(t *testing.T) {
+		t.Setenv("CLAUDE_REACTOR_REGISTRY", "env.registry/team")
+		cmd := NewBuildCmd(createMockApp())
+		repository, tag, err := pushTarget(cmd, true)
+		require.NoError(t, err)
+		assert.Equal(t, "env.registry/team", repository)
+		assert.Equal(t, "latest", tag)
+	})
+
+	t.Run("push needs a registry", func(t *testing.T) {
+		cmd := NewBuildCmd(createMockApp())
+		_, _, err := pushTarget(cmd, true)
+		assert.ErrorContains(t, err, "--push needs a registry")
+	})
+
+	t.Run("registry without push is rejected", func(t *testing.T) {
+		cmd := NewBuildCmd(createMockApp())
+		require.NoError(t, cmd.ParseFlags([]string{"--registry", "my.registry/team"}))
+		_, _, err := pushTarget(cmd, false)
+		assert.ErrorContains(t, err, "only apply with --push")
+	})
+}
+
+func TestBuildVariant(t *testing.T) {
+	t.Run("names the image for the platform built", func(t *testing.T) {
+		images := &mocks.MockImageService{}
+		images.On("RebuildImage", mock.Anything, "go", "linux/amd64", true).Return(nil)
+		images.On("GetImageName", "go", "amd64").Return("claude-reactor-go-amd64")
+
+		imageName, err := buildVariant(context.Background(), images, "go", "linux/amd64", true)
+		require.NoError(t, err)
+		assert.Equal(t, "claude-reactor-go-amd64", imageName)
+		images.AssertExpectations(t)
+	})
+
+	t.Run("build failure", func(t *testing.T) {
+		images := &mocks.MockImageService{}
+		images.On("RebuildImage", mock.Anything, "go", "linux/arm64", false).Return(errors.New("no space left on device"))
+
+		_, err := buildVariant(context.Background(), images, "go", "linux/arm64", false)
+		assert.EqualError(t, err, "failed to build image: no space left on device")
+		images.AssertNotCalled(t, "GetImageName", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPushVariant(t *testing.T) {
+	registry := &mocks.MockRegistryService{}
+	registry.On("PushImage", mock.Anything, "go", "linux/amd64", "my.registry/team", "v1").
+		Return([]string{"my.registry/team/go:v1-amd64"}, errors.New("manifest create failed"))
+	logger := &captureLogger{}
+
+	err := pushVariant(context.Background(), registry, logger, "go", "linux/amd64", "my.registry/team", "v1")
+	assert.EqualError(t, err, "manifest create failed")
+	assert.Contains(t, logger.messages, "✅ Pushed %s", "references pushed before the failure are reported")
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/changes"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// changeLabels describe git's name-status letters
+var changeLabels = map[string]string{
+	"A": "added",
+	"M": "modified",
+	"D": "deleted",
+	"T": "type changed",
+}
+
+// snapshotProject records the project files before a session so its changes can be summarized
+// afterwards. Projects outside git have no snapshot.
+func snapshotProject(app *pkg.AppContainer, projectDir string) *changes.Snapshot {
+	snapshot, err := changes.Take(projectDir)
+	if err != nil {
+		app.Logger.Debugf("No change summary for this session: %v", err)
+		return nil
+	}
+	return snapshot
+}
+
+// summarizeChanges prints the files the session added, modified and deleted in the project,
+// and with review lets the user pick changes to revert. The session is over, so problems are
+// reported rather than returned.
+func summarizeChanges(cmd *cobra.Command, app *pkg.AppContainer, snapshot *changes.Snapshot, review bool) {
+	if snapshot == nil {
+		return
+	}
+	changed, stat, err := snapshot.Changes()
+	if err != nil {
+		app.Logger.Warnf("Failed to summarize session changes: %v", err)
+		return
+	}
+	out := i18n.Writer(cmd.OutOrStdout())
+	if len(changed) == 0 {
+		app.Logger.Info("📝 The session changed no project files")
+		return
+	}
+
+	app.Logger.Infof("📝 Session changes: %s", stat)
+	for i, change := range changed {
+		label := changeLabels[change.Status]
+		if label == "" {
+			label = change.Status
+		}
+		fmt.Fprintf(out, "  %3d. %-12s %s\n", i+1, label, change.Path)
+	}
+	if !review {
+		app.Logger.Info("💡 Pick changes to revert next time with: claude-reactor run --review")
+		return
+	}
+
+	input := bufio.NewReader(cmd.InOrStdin())
+	var selected []changes.Change
+	for {
+		fmt.Fprint(out, "Revert which changes? (numbers such as 1,3-5, 'all', or Enter to keep them all): ")
+		answer, readErr := input.ReadString('\n')
+		if selected, err = selectEntries(answer, changed); err == nil || readErr != nil {
+			break
+		}
+		fmt.Fprintf(out, "%v\n", err)
+	}
+	if len(selected) == 0 {
+		app.Logger.Info("✅ Kept all session changes")
+		return
+	}
+	reverted := 0
+	for _, change := range selected {
+		if err := snapshot.Revert(change); err != nil {
+			app.Logger.Warnf("%v", err)
+			continue
+		}
+		reverted++
+		fmt.Fprintf(out, "  ↩️  reverted %s\n", change.Path)
+	}
+	app.Logger.Infof("✅ Reverted %d of %d changes", reverted, len(changed))
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeChanges(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.txt"), []byte("b\n"), 0644))
+	output, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	app := createMockApp()
+	snapshot := snapshotProject(app, dir)
+	require.NotNil(t, snapshot)
+	assert.Nil(t, snapshotProject(app, t.TempDir()), "no summary outside git")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed\n"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(dir, "b.txt")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.txt"), []byte("c\n"), 0644))
+
+	// An invalid answer is asked again; then the second and third changes are reverted
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetIn(strings.NewReader("9\n2-3\n"))
+	summarizeChanges(cmd, app, snapshot, true)
+
+	assert.Contains(t, out.String(), "1. modified     a.txt")
+	assert.Contains(t, out.String(), "2. deleted      b.txt")
+	assert.Contains(t, out.String(), "3. added        c.txt")
+	assert.Contains(t, out.String(), "invalid selection")
+	assert.FileExists(t, filepath.Join(dir, "b.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "c.txt"))
+	content, err := os.ReadFile(filepath.Join(dir, "a.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "changed\n", string(content), "not selected, so kept")
+}
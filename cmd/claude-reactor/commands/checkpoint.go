@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/checkpoint"
+	"claude-reactor/internal/reactor/filesync"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// NewRestoreCmd creates the restore command for rolling a project back to a checkpoint
+func NewRestoreCmd(app *pkg.AppContainer) *cobra.Command {
+	var restoreCmd = &cobra.Command{
+		Use:   "restore",
+		Short: "Roll the project back to a checkpoint",
+		Long: `Roll the project files back to a checkpoint taken during a session.
+
+With 'claude-reactor config set checkpoints on', sessions back up the project
+files when they start and every 15 minutes while they run, whether or not the
+project uses git. Unchanged files are stored once, and the newest 30
+checkpoints of each project are kept under ~/.claude-reactor/checkpoints.
+
+Without --checkpoint the project's checkpoints are listed. Restoring puts back
+changed and deleted files and removes files added since the checkpoint; .git
+and ignored files are left alone. The current files are checkpointed first, so
+a restore can itself be undone.`,
+		Example: `# List the project's checkpoints
+claude-reactor restore
+
+# See what restoring would change, then do it
+claude-reactor restore --checkpoint 20260101-120000 --dry-run
+claude-reactor restore --checkpoint 20260101-120000`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			if err := reactor.EnsureDockerComponents(app); err != nil {
+				return err
+			}
+			if _, err := enterProjectRoot(app, true); err != nil {
+				return err
+			}
+			projectDir, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
+			}
+			store, err := checkpointStore(app, projectDir)
+			if err != nil {
+				return err
+			}
+			id, _ := cmd.Flags().GetString("checkpoint")
+			if id == "" {
+				return listCheckpoints(cmd, app, store)
+			}
+			return restoreCheckpoint(cmd, app, store, projectDir, id)
+		},
+	}
+
+	restoreCmd.Flags().StringP("checkpoint", "c", "", "ID of the checkpoint to restore (list them by leaving it out)")
+	restoreCmd.Flags().Bool("dry-run", false, "Show what restoring would change without writing anything")
+	restoreCmd.Flags().BoolP("force", "f", false, "Restore without confirmation")
+
+	return restoreCmd
+}
+
+// checkpointStore opens the checkpoint store of the project at projectDir
+func checkpointStore(app *pkg.AppContainer, projectDir string) (*checkpoint.Store, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return checkpoint.Open(filepath.Join(homeDir, ".claude-reactor", "checkpoints", app.DockerMgr.GenerateProjectHash(projectDir))), nil
+}
+
+// startCheckpoints takes a checkpoint of the project when the session starts and, when an
+// interval is configured, periodically until ctx ends. Checkpoints are a safety net, so
+// failures are logged rather than stopping the session.
+func startCheckpoints(ctx context.Context, app *pkg.AppContainer, config *pkg.Config) {
+	enabled, interval, err := checkpoint.ParseInterval(config.Checkpoints)
+	if err != nil {
+		app.Logger.Warnf("Checkpoints disabled: %v", err)
+		return
+	}
+	if !enabled {
+		return
+	}
+	projectDir := config.ProjectPath
+	store, err := checkpointStore(app, projectDir)
+	if err != nil {
+		app.Logger.Warnf("Checkpoints disabled: %v", err)
+		return
+	}
+	ignores := filesync.IgnorePatterns(projectDir)
+
+	take := func(reason string) *checkpoint.Checkpoint {
+		taken, created, err := store.Create(projectDir, reason, ignores)
+		if err != nil {
+			app.Logger.Warnf("Failed to take checkpoint: %v", err)
+			return nil
+		}
+		if created {
+			if err := store.Prune(checkpoint.Keep); err != nil {
+				app.Logger.Debugf("Failed to prune checkpoints: %v", err)
+			}
+		}
+		return taken
+	}
+
+	if taken := take("session start"); taken != nil {
+		app.Logger.Infof("📸 Checkpoint %s taken - roll back with: claude-reactor restore --checkpoint %s", taken.ID, taken.ID)
+	}
+	if interval == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Log quietly: the session owns the terminal
+				if taken := take("periodic"); taken != nil {
+					app.Logger.Debugf("Checkpoint %s taken", taken.ID)
+				}
+			}
+		}
+	}()
+}
+
+// listCheckpoints prints the project's checkpoints, newest first
+func listCheckpoints(cmd *cobra.Command, app *pkg.AppContainer, store *checkpoint.Store) error {
+	checkpoints, err := store.List()
+	if err != nil {
+		return err
+	}
+	if len(checkpoints) == 0 {
+		app.Logger.Info("📸 No checkpoints for this project - enable them with: claude-reactor config set checkpoints on")
+		return nil
+	}
+	out := i18n.Writer(cmd.OutOrStdout())
+	fmt.Fprintf(out, "%-20s %-20s %7s %9s  %s\n", "ID", "CREATED", "FILES", "SIZE", "REASON")
+	for _, c := range checkpoints {
+		fmt.Fprintf(out, "%-20s %-20s %7d %9s  %s\n", c.ID, c.Created.Local().Format("2006-01-02 15:04:05"), len(c.Files), formatSize(c.Size()), c.Reason)
+	}
+	return nil
+}
+
+// restoreCheckpoint shows what restoring the checkpoint changes, confirms, checkpoints the
+// current files so the restore can be undone, and restores
+func restoreCheckpoint(cmd *cobra.Command, app *pkg.AppContainer, store *checkpoint.Store, projectDir, id string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+
+	target, err := store.Load(id)
+	if err != nil {
+		return err
+	}
+	ignores := filesync.IgnorePatterns(projectDir)
+	planned, err := store.Restore(projectDir, target, ignores, true)
+	if err != nil {
+		return err
+	}
+	if len(planned) == 0 {
+		app.Logger.Infof("✅ The project already matches checkpoint %s", id)
+		return nil
+	}
+
+	out := i18n.Writer(cmd.OutOrStdout())
+	fmt.Fprintf(out, "Restoring checkpoint %s (%s) changes %d files:\n", target.ID, target.Reason, len(planned))
+	for _, change := range planned {
+		fmt.Fprintf(out, "  %-10s %s\n", change.Action, change.Path)
+	}
+	if dryRun {
+		app.Logger.Info("🔍 Dry run: nothing was changed")
+		return nil
+	}
+	if !force {
+		fmt.Fprint(out, "Restore? (y/N): ")
+		answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			app.Logger.Info("🚫 Restore cancelled")
+			return nil
+		}
+	}
+
+	backup, _, err := store.Create(projectDir, "before restoring "+target.ID, ignores)
+	if err != nil {
+		return fmt.Errorf("failed to checkpoint the current files, nothing was restored: %w", err)
+	}
+	if _, err := store.Restore(projectDir, target, ignores, false); err != nil {
+		return fmt.Errorf("%w (undo the partial restore with: claude-reactor restore --checkpoint %s)", err, backup.ID)
+	}
+	if err := store.Prune(checkpoint.Keep); err != nil {
+		app.Logger.Debugf("Failed to prune checkpoints: %v", err)
+	}
+	app.Logger.Infof("✅ Restored checkpoint %s - undo with: claude-reactor restore --checkpoint %s", target.ID, backup.ID)
+	return nil
+}
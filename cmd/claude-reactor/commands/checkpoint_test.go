@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/checkpoint"
+)
+
+// newRestoreTestCmd returns a command with the restore flags, the given input and its output
+func newRestoreTestCmd(input string, flags ...string) (*cobra.Command, *bytes.Buffer) {
+	cmd := NewRestoreCmd(nil)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(strings.NewReader(input))
+	cmd.Flags().Parse(flags)
+	return cmd, &out
+}
+
+func TestRestoreCheckpoint(t *testing.T) {
+	project := t.TempDir()
+	store := checkpoint.Open(t.TempDir())
+	app := createMockApp()
+	require.NoError(t, os.WriteFile(filepath.Join(project, "main.go"), []byte("package main\n"), 0644))
+	taken, _, err := store.Create(project, "session start", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(project, "main.go"), []byte("broken"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(project, "notes.txt"), []byte("new\n"), 0644))
+
+	// A dry run and a declined confirmation leave the files alone
+	cmd, out := newRestoreTestCmd("", "--dry-run")
+	require.NoError(t, restoreCheckpoint(cmd, app, store, project, taken.ID))
+	assert.Contains(t, out.String(), "restored   main.go")
+	assert.Contains(t, out.String(), "removed    notes.txt")
+	cmd, _ = newRestoreTestCmd("n\n")
+	require.NoError(t, restoreCheckpoint(cmd, app, store, project, taken.ID))
+	assert.FileExists(t, filepath.Join(project, "notes.txt"))
+
+	cmd, _ = newRestoreTestCmd("y\n")
+	require.NoError(t, restoreCheckpoint(cmd, app, store, project, taken.ID))
+	content, err := os.ReadFile(filepath.Join(project, "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(content))
+	assert.NoFileExists(t, filepath.Join(project, "notes.txt"))
+
+	// The files from before the restore were checkpointed, so it can be undone
+	checkpoints, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 2)
+	assert.Equal(t, "before restoring "+taken.ID, checkpoints[0].Reason)
+
+	cmd, out = newRestoreTestCmd("")
+	require.NoError(t, listCheckpoints(cmd, app, store))
+	assert.Contains(t, out.String(), taken.ID)
+	assert.Contains(t, out.String(), "session start")
+
+	cmd, _ = newRestoreTestCmd("", "--force")
+	assert.Error(t, restoreCheckpoint(cmd, app, store, project, "missing"))
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/ci"
+	"claude-reactor/pkg"
+)
+
+// NewCICmd creates the ci command for integrating claude-reactor with CI systems
+func NewCICmd(app *pkg.AppContainer) *cobra.Command {
+	var ciCmd = &cobra.Command{
+		Use:   "ci",
+		Short: "Integrate claude-reactor with CI systems",
+		Long: `Generate CI configuration that runs claude-reactor non-interactively.
+
+The generated workflows pull and cache the container image, authenticate to the
+image registry, run a prompt with 'claude-reactor prompt', and upload the
+response as a build artifact.`,
+	}
+
+	ciCmd.AddCommand(newCIGenerateGitHubCmd(app))
+
+	return ciCmd
+}
+
+func newCIGenerateGitHubCmd(app *pkg.AppContainer) *cobra.Command {
+	var generateCmd = &cobra.Command{
+		Use:   "generate-github",
+		Short: "Generate a GitHub Actions workflow that runs a prompt",
+		Long: `Generate a GitHub Actions workflow that runs a prompt with claude-reactor on
+every pull request.
+
+The workflow logs in to the image registry with GITHUB_TOKEN, caches the image
+between runs, and uploads Claude's response as the 'claude-reactor-transcript'
+artifact. Claude authenticates with the ANTHROPIC_API_KEY repository secret,
+which must be added before the workflow can run.
+
+With --annotate, findings in the response are reported as inline annotations on
+the pull request.`,
+		Example: `# Write .github/workflows/claude-reactor.yml
+claude-reactor ci generate-github --prompt "Review this pull request for bugs" --annotate
+
+# Only run for pull requests into main, using the go image
+claude-reactor ci generate-github --prompt "Check the tests cover new code" --image go --branch main
+
+# Print the workflow instead of writing it
+claude-reactor ci generate-github --prompt "Summarise the changes" --output -`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return generateGitHubWorkflow(cmd, app)
+		},
+	}
+
+	generateCmd.Flags().StringP("prompt", "", "", "Prompt to run on each pull request")
+	generateCmd.Flags().StringP("image", "", "", "Container image (defaults to the project's configured image, or base)")
+	generateCmd.Flags().StringP("name", "", "Claude Reactor", "Workflow name")
+	generateCmd.Flags().StringSliceP("branch", "b", []string{}, "Only run for pull requests into this branch (can be used multiple times)")
+	generateCmd.Flags().BoolP("annotate", "", false, "Report findings as pull request annotations")
+	generateCmd.Flags().StringP("output", "o", ci.DefaultWorkflowPath, "Workflow file to write ('-' for stdout)")
+	generateCmd.Flags().BoolP("force", "", false, "Overwrite an existing workflow file")
+	generateCmd.MarkFlagRequired("prompt")
+
+	generateCmd.RegisterFlagCompletionFunc("image", completeImages(app))
+
+	return generateCmd
+}
+
+// generateGitHubWorkflow renders the workflow and writes it to the requested location
+func generateGitHubWorkflow(cmd *cobra.Command, app *pkg.AppContainer) error {
+	prompt, _ := cmd.Flags().GetString("prompt")
+	image, _ := cmd.Flags().GetString("image")
+	name, _ := cmd.Flags().GetString("name")
+	branches, _ := cmd.Flags().GetStringSlice("branch")
+	annotate, _ := cmd.Flags().GetBool("annotate")
+	output, _ := cmd.Flags().GetString("output")
+	force, _ := cmd.Flags().GetBool("force")
+
+	if image == "" {
+		if config, err := app.ConfigMgr.LoadConfig(); err == nil {
+			image = config.Variant
+		}
+	}
+
+	workflow, err := ci.GenerateGitHubWorkflow(ci.GitHubWorkflowOptions{
+		Name:     name,
+		Prompt:   prompt,
+		Image:    image,
+		Registry: os.Getenv("CLAUDE_REACTOR_REGISTRY"),
+		Tag:      os.Getenv("CLAUDE_REACTOR_TAG"),
+		Branches: branches,
+		Annotate: annotate,
+	})
+	if err != nil {
+		return err
+	}
+
+	if output == "-" {
+		fmt.Fprint(cmd.OutOrStdout(), workflow)
+		return nil
+	}
+
+	if _, err := os.Stat(output); err == nil && !force {
+		return pkg.NewError(pkg.CodeInvalidUsage, nil, "%s already exists", output).WithRemediation("Use --force to overwrite it")
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return fmt.Errorf("failed to create workflow directory: %w", err)
+	}
+	if err := os.WriteFile(output, []byte(workflow), 0644); err != nil {
+		return fmt.Errorf("failed to write workflow to %s: %w", output, err)
+	}
+
+	app.Logger.Infof("✅ Workflow written to %s", output)
+	app.Logger.Info("💡 Add an ANTHROPIC_API_KEY repository secret so Claude can authenticate in CI")
+	return nil
+}
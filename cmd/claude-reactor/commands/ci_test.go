@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCICmd(t *testing.T) {
+	t.Run("create ci command", func(t *testing.T) {
+		cmd := NewCICmd(createMockApp())
+
+		assert.Equal(t, "ci", cmd.Use)
+		require.True(t, cmd.HasSubCommands())
+		assert.Equal(t, "generate-github", cmd.Commands()[0].Use)
+	})
+
+	t.Run("generate-github with nil app shows help", func(t *testing.T) {
+		cmd := newCIGenerateGitHubCmd(nil)
+
+		err := cmd.RunE(cmd, []string{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestGenerateGitHubWorkflow(t *testing.T) {
+	t.Run("writes workflow to stdout", func(t *testing.T) {
+		cmd := newCIGenerateGitHubCmd(createMockApp())
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		require.NoError(t, cmd.Flags().Set("prompt", "Review this"))
+		require.NoError(t, cmd.Flags().Set("image", "go"))
+		require.NoError(t, cmd.Flags().Set("output", "-"))
+
+		require.NoError(t, cmd.RunE(cmd, []string{}))
+		assert.Contains(t, out.String(), `--image "go"`)
+	})
+
+	t.Run("refuses to overwrite without force", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "workflows", "claude.yml")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("existing"), 0644))
+
+		cmd := newCIGenerateGitHubCmd(createMockApp())
+		require.NoError(t, cmd.Flags().Set("prompt", "Review this"))
+		require.NoError(t, cmd.Flags().Set("image", "go"))
+		require.NoError(t, cmd.Flags().Set("output", path))
+		assert.Error(t, cmd.RunE(cmd, []string{}))
+
+		require.NoError(t, cmd.Flags().Set("force", "true"))
+		require.NoError(t, cmd.RunE(cmd, []string{}))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "actions/upload-artifact")
+	})
+}
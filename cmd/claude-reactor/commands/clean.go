@@ -1,14 +1,14 @@
 package commands
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
 
-	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/auth"
+	"claude-reactor/internal/reactor/i18n"
 	"claude-reactor/pkg"
 )
 
@@ -22,6 +22,9 @@ func NewCleanCmd(app *pkg.AppContainer) *cobra.Command {
 Scope:
   clean                     Current project only (default)
   clean --global            All projects and accounts
+  clean --project <path>    Another project directory
+  clean --account <name>    Only resources belonging to this account
+  clean --older-than 7d     Only resources not used for this long (e.g. 12h, 7d, 2w)
 
 Cleanup Levels:
   clean                     Containers only (default)
@@ -29,9 +32,16 @@ Cleanup Levels:
   clean --auth              Containers + session data + credentials
   clean --all               Everything including global cache
 
+Resource Selectors (replace the default of containers only):
+  --containers              Remove containers
+  --volumes                 Remove file sync, sandbox and npm cache volumes
+  --images                  Remove Docker images (shared by all projects)
+
 Additional Options:
-  --images                  Also remove Docker images
-  --cache                   Clear image validation cache`,
+  --cache                   Clear image validation cache
+  --dry-run                 Show what would be removed and how much space it frees
+
+Use 'clean report' to see reclaimable disk space per project before deleting anything.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Handle help case when app is nil
 			if app == nil {
@@ -43,16 +53,27 @@ Additional Options:
 
 	// Scope flags
 	cleanCmd.Flags().BoolP("global", "g", false, "Clean all projects and accounts (default: current project only)")
+	cleanCmd.Flags().String("project", "", "Clean the project at this path instead of the current directory")
+	cleanCmd.Flags().String("account", "", "Only clean resources belonging to this account")
+	cleanCmd.Flags().String("older-than", "", "Only clean resources older than this age (e.g. 12h, 7d, 2w)")
+	cleanCmd.MarkFlagsMutuallyExclusive("global", "project")
 	
 	// Cleanup level flags (mutually exclusive)
 	cleanCmd.Flags().BoolP("sessions", "s", false, "Remove containers + session data")
 	cleanCmd.Flags().BoolP("auth", "", false, "Remove containers + session data + credentials")
 	cleanCmd.Flags().BoolP("all", "a", false, "Remove everything including global cache")
 	
+	// Resource selectors
+	cleanCmd.Flags().Bool("containers", false, "Remove containers")
+	cleanCmd.Flags().Bool("volumes", false, "Remove file sync, sandbox and npm cache volumes")
+	cleanCmd.Flags().BoolP("images", "i", false, "Remove Docker images")
+
 	// Additional cleanup flags  
-	cleanCmd.Flags().BoolP("images", "i", false, "Remove Docker images as well")
 	cleanCmd.Flags().BoolP("cache", "c", false, "Clear image validation cache")
 	cleanCmd.Flags().BoolP("force", "f", false, "Force removal without confirmation")
+	cleanCmd.Flags().Bool("dry-run", false, "Show what would be removed without removing anything")
+
+	cleanCmd.AddCommand(newCleanReportCmd(app))
 
 	return cleanCmd
 }
@@ -62,13 +83,15 @@ func cleanContainers(cmd *cobra.Command, app *pkg.AppContainer) error {
 	ctx := cmd.Context()
 
 	// Parse scope and cleanup level flags
-	global, _ := cmd.Flags().GetBool("global")
 	sessions, _ := cmd.Flags().GetBool("sessions")
 	auth, _ := cmd.Flags().GetBool("auth")
 	all, _ := cmd.Flags().GetBool("all")
+	containers, _ := cmd.Flags().GetBool("containers")
+	volumes, _ := cmd.Flags().GetBool("volumes")
 	images, _ := cmd.Flags().GetBool("images")
 	cache, _ := cmd.Flags().GetBool("cache")
 	force, _ := cmd.Flags().GetBool("force")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 	// Determine cleanup level (highest level wins)
 	cleanupLevel := "containers"
@@ -84,212 +107,178 @@ func cleanContainers(cmd *cobra.Command, app *pkg.AppContainer) error {
 		// Use --global explicitly to clean all projects/accounts
 	}
 
-	// Show what will be cleaned and ask for confirmation if not forced
-	if !force {
-		if err := showCleanupPlan(cleanupLevel, global, images, cache, app); err != nil {
-			return err
-		}
-		
-		fmt.Print("Do you want to continue? (y/N): ")
-		var response string
-		fmt.Scanln(&response)
-		if response != "y" && response != "Y" && response != "yes" {
-			app.Logger.Info("🚫 Cleanup cancelled")
-			return nil
-		}
+	filter, err := cleanFilter(cmd, app)
+	if err != nil {
+		return err
 	}
 
-	// Ensure Docker components are initialized for container cleanup
-	if err := reactor.EnsureDockerComponents(app); err != nil {
-		return fmt.Errorf("docker not available: %w", err)
+	// Selectors choose Docker resources; without any, only containers are removed
+	if !containers && !volumes && !images {
+		containers = true
+	}
+	filter.kinds = map[string]bool{
+		pkg.ResourceContainer: containers,
+		pkg.ResourceVolume:    volumes,
+		pkg.ResourceImage:     images,
+		pkg.ResourceSession:   cleanupLevel != "containers",
 	}
 
-	app.Logger.Infof("🧹 Starting cleanup (level: %s)...", cleanupLevel)
-
-	// Step 1: Clean containers (all levels include this)
-	if err := cleanContainersLevel(ctx, app, global); err != nil {
+	resources, err := collectResources(ctx, app, filter.kinds)
+	if err != nil {
 		return err
 	}
+	selected := filter.apply(resources)
 
-	// Step 2: Clean session data (sessions, auth, all levels)
-	if cleanupLevel == "sessions" || cleanupLevel == "auth" || cleanupLevel == "all" {
-		if err := cleanSessionsLevel(app, global); err != nil {
-			return err
+	showCleanupPlan(app, cleanupLevel, filter, selected, cache)
+
+	if dryRun {
+		app.Logger.Info(i18n.T("clean.dry_run"))
+		return nil
+	}
+
+	// Ask for confirmation if not forced
+	if !force {
+		fmt.Fprint(i18n.Stdout, i18n.T("clean.confirm"))
+		var response string
+		fmt.Scanln(&response)
+		if response != "y" && response != "Y" && response != "yes" {
+			app.Logger.Info(i18n.T("clean.cancelled"))
+			return nil
 		}
 	}
 
-	// Step 3: Clean authentication data (auth, all levels)
-	if cleanupLevel == "auth" || cleanupLevel == "all" {
-		if err := cleanAuthLevel(app, global); err != nil {
-			return err
+	app.Logger.Info(i18n.T("clean.starting", cleanupLevel))
+
+	// Step 1: Remove selected containers, volumes, sessions, and images
+	var freed int64
+	removed := 0
+	for _, resource := range selected {
+		if err := removeResource(ctx, app, resource); err != nil {
+			app.Logger.Warn(i18n.T("clean.remove_failed", resource.Kind, resource.Name, err))
+			continue
 		}
+		removed++
+		if resource.Size > 0 {
+			freed += resource.Size
+		}
+	}
+	if len(selected) > 0 {
+		app.Logger.Info(i18n.T("clean.removed", removed, len(selected), formatSize(freed)))
 	}
 
-	// Step 4: Clean images if requested
-	if images {
-		if err := cleanImagesLevel(ctx, app, global); err != nil {
+	// Step 2: Clean authentication data (auth, all levels)
+	if cleanupLevel == "auth" || cleanupLevel == "all" {
+		if err := cleanAuthLevel(app, filter.account); err != nil {
 			return err
 		}
 	}
 
-	// Step 5: Clean cache (cache flag or all level)
+	// Step 3: Clean cache (cache flag or all level)
 	if cache || cleanupLevel == "all" {
 		if err := cleanCacheLevel(app); err != nil {
 			return err
 		}
 	}
 
-	app.Logger.Info("✅ Cleanup completed successfully!")
+	app.Logger.Info(i18n.T("clean.done"))
 	return nil
 }
 
-// showCleanupPlan displays what will be cleaned and asks for confirmation
-func showCleanupPlan(cleanupLevel string, global bool, images, cache bool, app *pkg.AppContainer) error {
-	app.Logger.Info("🧹 Cleanup Plan:")
-	
-	// Show scope
-	if global {
-		app.Logger.Info("  📍 Scope: All projects and accounts")
-	} else {
-		app.Logger.Info("  📍 Scope: Current project only")
-	}
-	
-	switch cleanupLevel {
-	case "containers":
-		app.Logger.Info("  • 🐳 Remove claude-reactor containers")
-	case "sessions":
-		app.Logger.Info("  • 🐳 Remove claude-reactor containers")
-		app.Logger.Info("  • 📁 Remove session data (conversation history, shell snapshots)")
-	case "auth":
-		app.Logger.Info("  • 🐳 Remove claude-reactor containers")
-		app.Logger.Info("  • 📁 Remove session data (conversation history, shell snapshots)")
-		app.Logger.Info("  • 🔑 Remove authentication data (Claude configs, API keys)")
-	case "all":
-		app.Logger.Info("  • 🐳 Remove claude-reactor containers")
-		app.Logger.Info("  • 📁 Remove session data (conversation history, shell snapshots)")
-		app.Logger.Info("  • 🔑 Remove authentication data (Claude configs, API keys)")
-		app.Logger.Info("  • 🗄️ Clear global cache and validation data")
-	}
+// cleanFilter builds the project, account, and age filter from the scope flags. Without
+// --global, cleanup is limited to one project and, unless --account is given, the account
+// configured for it.
+func cleanFilter(cmd *cobra.Command, app *pkg.AppContainer) (resourceFilter, error) {
+	global, _ := cmd.Flags().GetBool("global")
+	project, _ := cmd.Flags().GetString("project")
+	account, _ := cmd.Flags().GetString("account")
+	olderThan, _ := cmd.Flags().GetString("older-than")
 
-	if images {
-		app.Logger.Info("  • 📦 Remove Docker images")
+	filter := resourceFilter{account: account}
+	if olderThan != "" {
+		age, err := parseAge(olderThan)
+		if err != nil {
+			return filter, err
+		}
+		filter.olderThan = age
 	}
-	if cache && cleanupLevel != "all" {
-		app.Logger.Info("  • 🗄️ Clear validation cache")
+	if global {
+		return filter, nil
 	}
 
-	app.Logger.Info("")
-	return nil
-}
-
-// cleanContainersLevel removes containers
-func cleanContainersLevel(ctx context.Context, app *pkg.AppContainer, global bool) error {
-	if global {
-		app.Logger.Info("🗑️ Removing all claude-reactor containers...")
-		err := app.DockerMgr.CleanAllContainers(ctx)
+	projectDir := project
+	if projectDir == "" {
+		cwd, err := os.Getwd()
 		if err != nil {
-			return fmt.Errorf("failed to clean all containers: %w", err)
+			return filter, fmt.Errorf("failed to get current directory: %w", err)
 		}
-		app.Logger.Info("✅ All containers removed")
+		projectDir = cwd
 	} else {
-		// Clean only current project container
-		config, err := app.ConfigMgr.LoadConfig()
+		absPath, err := filepath.Abs(projectDir)
 		if err != nil {
-			app.Logger.Info("🗑️ No current project configuration found, skipping container cleanup")
-			return nil
-		}
-
-		// Normalize account using auth manager
-		if config.Account == "" {
-			config.Account = app.AuthMgr.GetDefaultAccount()
+			return filter, fmt.Errorf("failed to resolve project path %s: %w", projectDir, err)
 		}
+		projectDir = absPath
+	}
+	filter.projectHash = auth.GenerateProjectHash(projectDir)
 
-		arch, err := app.ArchDetector.GetHostArchitecture()
-		if err != nil {
-			return fmt.Errorf("failed to detect architecture: %w", err)
-		}
-
-		// Get current working directory for project hash
-		projectDir, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
-		}
-
-		containerName := app.DockerMgr.GenerateContainerName(projectDir, config.Variant, arch, config.Account)
-		app.Logger.Infof("🗑️ Removing container: %s", containerName)
-
-		err = app.DockerMgr.CleanContainer(ctx, containerName)
-		if err != nil {
-			app.Logger.Warnf("Failed to clean container %s: %v", containerName, err)
-			// Don't fail completely, container might not exist
+	if filter.account == "" {
+		if config, err := app.ConfigMgr.LoadConfig(); err == nil && config.Account != "" {
+			filter.account = config.Account
 		} else {
-			app.Logger.Info("✅ Project container removed")
+			filter.account = app.AuthMgr.GetDefaultAccount()
 		}
 	}
-	return nil
+	return filter, nil
 }
 
-// cleanSessionsLevel removes session data (conversation history, etc.)
-func cleanSessionsLevel(app *pkg.AppContainer, global bool) error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+// showCleanupPlan displays what will be cleaned and how much space it frees
+func showCleanupPlan(app *pkg.AppContainer, cleanupLevel string, filter resourceFilter, selected []pkg.Resource, cache bool) {
+	app.Logger.Info(i18n.T("clean.plan"))
+	
+	// Show scope
+	switch {
+	case filter.projectHash != "":
+		app.Logger.Info(i18n.T("clean.scope_project", filter.projectHash, filter.account))
+	case filter.account != "":
+		app.Logger.Info(i18n.T("clean.scope_account", filter.account))
+	default:
+		app.Logger.Info(i18n.T("clean.scope_all"))
+	}
+	if filter.olderThan > 0 {
+		app.Logger.Info(i18n.T("clean.older_than", filter.olderThan))
 	}
 
-	claudeReactorDir := filepath.Join(homeDir, ".claude-reactor")
-
-	if global {
-		app.Logger.Info("🗂️ Removing all session data...")
-		
-		// Remove all account session directories but preserve auth files
-		accounts, err := getAccountDirectories(claudeReactorDir)
-		if err != nil {
-			app.Logger.Warnf("Failed to get account directories: %v", err)
-			return nil
-		}
-
-		for _, account := range accounts {
-			accountDir := filepath.Join(claudeReactorDir, account)
-			app.Logger.Infof("🗂️ Cleaning sessions for account: %s", account)
-			
-			if err := os.RemoveAll(accountDir); err != nil {
-				app.Logger.Warnf("Failed to remove session directory %s: %v", accountDir, err)
-			}
-		}
-		app.Logger.Info("✅ All session data removed")
-	} else {
-		// Remove only current project session
-		config, err := app.ConfigMgr.LoadConfig()
-		if err != nil {
-			app.Logger.Info("🗂️ No current project configuration found, skipping session cleanup")
-			return nil
-		}
-
-		// Normalize account using auth manager
-		if config.Account == "" {
-			config.Account = app.AuthMgr.GetDefaultAccount()
-		}
-
-		projectDir, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("failed to get current directory: %w", err)
+	icons := map[string]string{
+		pkg.ResourceContainer: "🐳",
+		pkg.ResourceVolume:    "💾",
+		pkg.ResourceSession:   "📁",
+		pkg.ResourceImage:     "📦",
+	}
+	if len(selected) == 0 {
+		app.Logger.Info(i18n.T("clean.plan_empty"))
+	}
+	for _, resource := range selected {
+		status := ""
+		if resource.Running {
+			status = i18n.T("clean.running")
 		}
+		app.Logger.Info(i18n.T("clean.plan_remove", icons[resource.Kind], resource.Kind, resource.Name, status, formatSize(resource.Size)))
+	}
 
-		// Calculate project session directory
-		sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, projectDir)
-		
-		app.Logger.Infof("🗂️ Removing session data: %s", sessionDir)
-		if err := os.RemoveAll(sessionDir); err != nil {
-			app.Logger.Warnf("Failed to remove session directory: %v", err)
-		} else {
-			app.Logger.Info("✅ Project session data removed")
-		}
+	if cleanupLevel == "auth" || cleanupLevel == "all" {
+		app.Logger.Info(i18n.T("clean.plan_auth"))
 	}
-	return nil
+	if cache || cleanupLevel == "all" {
+		app.Logger.Info(i18n.T("clean.plan_cache"))
+	}
+
+	app.Logger.Info(i18n.T("clean.plan_reclaimable", formatSize(totalSize(selected))))
+	app.Logger.Info("")
 }
 
-// cleanAuthLevel removes authentication data (Claude configs, API keys)
-func cleanAuthLevel(app *pkg.AppContainer, global bool) error {
+// cleanAuthLevel removes authentication data (Claude configs, API keys) for an account, or
+// for every account when account is empty
+func cleanAuthLevel(app *pkg.AppContainer, account string) error {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return fmt.Errorf("failed to get home directory: %w", err)
@@ -297,13 +286,13 @@ func cleanAuthLevel(app *pkg.AppContainer, global bool) error {
 
 	claudeReactorDir := filepath.Join(homeDir, ".claude-reactor")
 
-	if global {
-		app.Logger.Info("🔑 Removing all authentication data...")
+	if account == "" {
+		app.Logger.Info(i18n.T("clean.auth_all"))
 		
 		// Remove all .{account}-claude.json and .claude-reactor-{account}-env files
 		entries, err := os.ReadDir(claudeReactorDir)
 		if err != nil {
-			app.Logger.Warnf("Failed to read claude-reactor directory: %v", err)
+			app.Logger.Warn(i18n.T("clean.auth_unreadable", err))
 			return nil
 		}
 
@@ -316,61 +305,36 @@ func cleanAuthLevel(app *pkg.AppContainer, global bool) error {
 			if (name != ".claude.json" && 
 				(name[:1] == "." && (name[len(name)-12:] == "-claude.json" || name[:15] == ".claude-reactor-"))) {
 				filePath := filepath.Join(claudeReactorDir, name)
-				app.Logger.Infof("🔑 Removing auth file: %s", name)
+				app.Logger.Info(i18n.T("clean.auth_file", name))
 				if err := os.Remove(filePath); err != nil {
-					app.Logger.Warnf("Failed to remove auth file %s: %v", filePath, err)
+					app.Logger.Warn(i18n.T("clean.auth_file_failed", filePath, err))
 				}
 			}
 		}
-		app.Logger.Info("✅ All authentication data removed")
+		app.Logger.Info(i18n.T("clean.auth_all_done"))
 	} else {
-		// Remove only current account auth
-		config, err := app.ConfigMgr.LoadConfig()
-		if err != nil {
-			app.Logger.Info("🔑 No current project configuration found, skipping auth cleanup")
-			return nil
-		}
-
-		// Normalize account using auth manager
-		if config.Account == "" {
-			config.Account = app.AuthMgr.GetDefaultAccount()
-		}
-
 		// Remove account-specific auth files
-		authConfigPath := app.AuthMgr.GetAccountConfigPath(config.Account)
-		apiKeyPath := app.AuthMgr.GetAPIKeyFile(config.Account)
+		authConfigPath := app.AuthMgr.GetAccountConfigPath(account)
+		apiKeyPath := app.AuthMgr.GetAPIKeyFile(account)
 
-		app.Logger.Infof("🔑 Removing auth for account: %s", config.Account)
+		app.Logger.Info(i18n.T("clean.auth_account", account))
 		
 		if err := os.Remove(authConfigPath); err != nil && !os.IsNotExist(err) {
-			app.Logger.Warnf("Failed to remove auth config: %v", err)
+			app.Logger.Warn(i18n.T("clean.auth_config_failed", err))
 		}
 		
 		if err := os.Remove(apiKeyPath); err != nil && !os.IsNotExist(err) {
-			app.Logger.Warnf("Failed to remove API key file: %v", err)
+			app.Logger.Warn(i18n.T("clean.auth_key_failed", err))
 		}
 		
-		app.Logger.Info("✅ Account authentication data removed")
+		app.Logger.Info(i18n.T("clean.auth_account_done"))
 	}
 	return nil
 }
 
-// cleanImagesLevel removes Docker images
-func cleanImagesLevel(ctx context.Context, app *pkg.AppContainer, global bool) error {
-	app.Logger.Info("📦 Removing Docker images...")
-	
-	err := app.DockerMgr.CleanImages(ctx, global)
-	if err != nil {
-		return fmt.Errorf("failed to clean images: %w", err)
-	}
-	
-	app.Logger.Info("✅ Docker images removed")
-	return nil
-}
-
 // cleanCacheLevel clears validation cache
 func cleanCacheLevel(app *pkg.AppContainer) error {
-	app.Logger.Info("🗄️ Clearing validation cache...")
+	app.Logger.Info(i18n.T("clean.cache"))
 	
 	if app.ImageValidator != nil {
 		err := app.ImageValidator.ClearCache()
@@ -380,7 +344,7 @@ func cleanCacheLevel(app *pkg.AppContainer) error {
 		app.ImageValidator.ClearSessionWarnings()
 	}
 	
-	app.Logger.Info("✅ Validation cache cleared")
+	app.Logger.Info(i18n.T("clean.cache_done"))
 	return nil
 }
 
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// ProjectUsage is the disk space held by one project's containers, volumes, and sessions
+type ProjectUsage struct {
+	Account     string    `json:"account"`
+	ProjectHash string    `json:"project_hash"`
+	ProjectPath string    `json:"project_path,omitempty"`
+	Containers  int       `json:"containers"`
+	Running     int       `json:"running"`
+	Volumes     int       `json:"volumes"`
+	Sessions    int       `json:"sessions"`
+	Size        int64     `json:"size"`
+	LastUsed    time.Time `json:"last_used"`
+}
+
+// CleanReport summarizes reclaimable disk space
+type CleanReport struct {
+	Projects  []ProjectUsage `json:"projects"`
+	Images    []pkg.Resource `json:"images"`
+	ImageSize int64          `json:"image_size"`
+	Total     int64          `json:"total"`
+}
+
+// newCleanReportCmd creates the clean report subcommand
+func newCleanReportCmd(app *pkg.AppContainer) *cobra.Command {
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Show reclaimable disk space per project",
+		Long: `Show the disk space used by claude-reactor containers, sync volumes, session data,
+and images, grouped by project. Nothing is removed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return runCleanReport(cmd, app)
+		},
+	}
+
+	reportCmd.Flags().String("account", "", "Only report projects belonging to this account")
+	reportCmd.Flags().BoolP("json", "j", false, "Output in JSON format for scripting")
+
+	return reportCmd
+}
+
+// runCleanReport lists all resources and prints them grouped by project
+func runCleanReport(cmd *cobra.Command, app *pkg.AppContainer) error {
+	account, _ := cmd.Flags().GetString("account")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	filter := resourceFilter{
+		account: account,
+		kinds: map[string]bool{
+			pkg.ResourceContainer: true,
+			pkg.ResourceVolume:    true,
+			pkg.ResourceImage:     true,
+			pkg.ResourceSession:   true,
+		},
+	}
+	resources, err := collectResources(cmd.Context(), app, filter.kinds)
+	if err != nil {
+		return err
+	}
+	report := buildCleanReport(filter.apply(resources))
+
+	if jsonOutput {
+		return outputJSON(report)
+	}
+	outputCleanReport(report)
+	return nil
+}
+
+// buildCleanReport groups resources by account and project, largest first
+func buildCleanReport(resources []pkg.Resource) CleanReport {
+	report := CleanReport{Projects: []ProjectUsage{}, Images: []pkg.Resource{}}
+	projects := make(map[string]*ProjectUsage)
+
+	for _, resource := range resources {
+		size := max(resource.Size, 0)
+		report.Total += size
+		if resource.Kind == pkg.ResourceImage {
+			report.Images = append(report.Images, resource)
+			report.ImageSize += size
+			continue
+		}
+
+		key := resource.Account + "/" + resource.ProjectHash
+		usage, ok := projects[key]
+		if !ok {
+			usage = &ProjectUsage{Account: resource.Account, ProjectHash: resource.ProjectHash}
+			projects[key] = usage
+		}
+		if usage.ProjectPath == "" {
+			usage.ProjectPath = resource.ProjectPath
+		}
+		switch resource.Kind {
+		case pkg.ResourceContainer:
+			usage.Containers++
+			if resource.Running {
+				usage.Running++
+			}
+		case pkg.ResourceVolume:
+			usage.Volumes++
+		case pkg.ResourceSession:
+			usage.Sessions++
+		}
+		usage.Size += size
+		if resource.Created.After(usage.LastUsed) {
+			usage.LastUsed = resource.Created
+		}
+	}
+
+	for _, usage := range projects {
+		report.Projects = append(report.Projects, *usage)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool {
+		if report.Projects[i].Size != report.Projects[j].Size {
+			return report.Projects[i].Size > report.Projects[j].Size
+		}
+		return report.Projects[i].LastUsed.After(report.Projects[j].LastUsed)
+	})
+	return report
+}
+
+// outputCleanReport prints the report as tables
+func outputCleanReport(report CleanReport) {
+	if len(report.Projects) == 0 && len(report.Images) == 0 {
+		fmt.Fprintln(i18n.Stdout, i18n.T("clean.report_empty"))
+		return
+	}
+
+	if len(report.Projects) > 0 {
+		fmt.Fprintf(i18n.Stdout, "%-15s %-8s %-10s %-7s %-8s %-10s %-12s %s\n",
+			"ACCOUNT", "HASH", "CONTAINERS", "VOLUMES", "SESSIONS", "SIZE", "LAST USED", "PROJECT PATH")
+		fmt.Fprintf(i18n.Stdout, "%-15s %-8s %-10s %-7s %-8s %-10s %-12s %s\n",
+			strings.Repeat("-", 15),
+			strings.Repeat("-", 8),
+			strings.Repeat("-", 10),
+			strings.Repeat("-", 7),
+			strings.Repeat("-", 8),
+			strings.Repeat("-", 10),
+			strings.Repeat("-", 12),
+			strings.Repeat("-", 20))
+
+		for _, project := range report.Projects {
+			hash := project.ProjectHash
+			if hash == "" {
+				hash = "unknown"
+			}
+			containers := fmt.Sprintf("%d", project.Containers)
+			if project.Running > 0 {
+				containers = i18n.T("clean.report_up", project.Containers, project.Running)
+			}
+			lastUsed := i18n.T("list.never")
+			if !project.LastUsed.IsZero() {
+				lastUsed = formatRelativeTime(project.LastUsed)
+			}
+			fmt.Fprintf(i18n.Stdout, "%-15s %-8s %-10s %-7d %-8d %-10s %-12s %s\n",
+				truncate(project.Account, 15),
+				hash,
+				containers,
+				project.Volumes,
+				project.Sessions,
+				formatSize(project.Size),
+				lastUsed,
+				project.ProjectPath)
+		}
+	}
+
+	if len(report.Images) > 0 {
+		fmt.Fprintln(i18n.Stdout)
+		fmt.Fprintln(i18n.Stdout, i18n.T("clean.report_images", formatSize(report.ImageSize)))
+		for _, image := range report.Images {
+			fmt.Fprintf(i18n.Stdout, "  %-50s %s\n", image.Name, formatSize(image.Size))
+		}
+	}
+
+	fmt.Fprintln(i18n.Stdout)
+	fmt.Fprintln(i18n.Stdout, i18n.T("clean.report_reclaimable", formatSize(report.Total), len(report.Projects), len(report.Images)))
+	fmt.Fprintln(i18n.Stdout, i18n.T("clean.report_hint"))
+}
@@ -1,10 +1,20 @@
 package commands
 
 import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
 )
 
 func TestNewCleanCmd(t *testing.T) {
@@ -87,6 +97,189 @@ func TestCleanCommandFlags(t *testing.T) {
 	})
 }
 
+func TestCleanFilterFlags(t *testing.T) {
+	t.Run("filter and selector flags exist", func(t *testing.T) {
+		cmd := NewCleanCmd(createMockApp())
+		for _, name := range []string{"dry-run", "older-than", "project", "account", "containers", "volumes", "images"} {
+			assert.NotNil(t, cmd.Flags().Lookup(name), name)
+		}
+	})
+
+	t.Run("global and project are mutually exclusive", func(t *testing.T) {
+		cmd := NewCleanCmd(createMockApp())
+		cmd.SetArgs([]string{"--global", "--project", "/tmp", "--dry-run"})
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+		err := cmd.Execute()
+		assert.Error(t, err)
+	})
+
+	t.Run("project flag scopes to that project's hash", func(t *testing.T) {
+		cmd := NewCleanCmd(createMockApp())
+		require.NoError(t, cmd.ParseFlags([]string{"--project", "/work/app", "--account", "work"}))
+
+		filter, err := cleanFilter(cmd, createMockApp())
+		require.NoError(t, err)
+		assert.Equal(t, "work", filter.account)
+		assert.Len(t, filter.projectHash, 8)
+	})
+
+	t.Run("invalid older-than is rejected", func(t *testing.T) {
+		cmd := NewCleanCmd(createMockApp())
+		require.NoError(t, cmd.ParseFlags([]string{"--global", "--older-than", "soon"}))
+
+		_, err := cleanFilter(cmd, createMockApp())
+		assert.Error(t, err)
+	})
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"12h", 12 * time.Hour, false},
+		{"7d", 7 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"d", 0, true},
+		{"-1d", 0, true},
+		{"", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			age, err := parseAge(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, age)
+		})
+	}
+}
+
+func TestResourceFilter(t *testing.T) {
+	now := time.Now()
+	resources := []pkg.Resource{
+		{Kind: pkg.ResourceImage, Name: "claude-reactor-go", Created: now.Add(-30 * 24 * time.Hour)},
+		{Kind: pkg.ResourceVolume, Name: "old-sync", ProjectHash: "aaaaaaaa", Account: "default", Created: now.Add(-10 * 24 * time.Hour)},
+		{Kind: pkg.ResourceContainer, Name: "old", ProjectHash: "aaaaaaaa", Account: "default", Created: now.Add(-10 * 24 * time.Hour)},
+		{Kind: pkg.ResourceContainer, Name: "new", ProjectHash: "aaaaaaaa", Account: "default", Created: now},
+		{Kind: pkg.ResourceContainer, Name: "work", ProjectHash: "aaaaaaaa", Account: "work", Created: now},
+		{Kind: pkg.ResourceContainer, Name: "other", ProjectHash: "bbbbbbbb", Account: "default", Created: now},
+	}
+	names := func(resources []pkg.Resource) []string {
+		var names []string
+		for _, resource := range resources {
+			names = append(names, resource.Name)
+		}
+		return names
+	}
+	all := map[string]bool{pkg.ResourceContainer: true, pkg.ResourceVolume: true, pkg.ResourceImage: true}
+
+	t.Run("project and account filters skip shared images", func(t *testing.T) {
+		filter := resourceFilter{kinds: all, projectHash: "aaaaaaaa", account: "default"}
+		assert.Equal(t, []string{"old", "new", "old-sync", "claude-reactor-go"}, names(filter.apply(resources)))
+	})
+
+	t.Run("older-than keeps recently used resources", func(t *testing.T) {
+		filter := resourceFilter{kinds: all, olderThan: 7 * 24 * time.Hour}
+		assert.Equal(t, []string{"old", "old-sync", "claude-reactor-go"}, names(filter.apply(resources)))
+	})
+
+	t.Run("selectors limit kinds", func(t *testing.T) {
+		filter := resourceFilter{kinds: map[string]bool{pkg.ResourceVolume: true}}
+		assert.Equal(t, []string{"old-sync"}, names(filter.apply(resources)))
+	})
+}
+
+func TestScanSessionResources(t *testing.T) {
+	dir := t.TempDir()
+	sessionDir := filepath.Join(dir, "default", "my-app-1a2b3c4d")
+	require.NoError(t, os.MkdirAll(sessionDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sessionDir, ".claude-reactor"), []byte("project_path=/work/my-app\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "locks"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "default", "not-a-session"), 0755))
+
+	resources, err := scanSessionResources(dir)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, pkg.ResourceSession, resources[0].Kind)
+	assert.Equal(t, sessionDir, resources[0].ID)
+	assert.Equal(t, "1a2b3c4d", resources[0].ProjectHash)
+	assert.Equal(t, "default", resources[0].Account)
+	assert.Equal(t, "/work/my-app", resources[0].ProjectPath)
+	assert.Positive(t, resources[0].Size)
+
+	resources, err = scanSessionResources(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Empty(t, resources)
+}
+
+func TestCleanDryRun(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	dockerMgr := &mocks.MockDockerManager{}
+	dockerMgr.On("ListResources", mock.Anything).Return([]pkg.Resource{
+		{Kind: pkg.ResourceContainer, Name: "claude-reactor-go-amd64-1a2b3c4d-default", ProjectHash: "1a2b3c4d", Account: "default", Size: 2048},
+		{Kind: pkg.ResourceVolume, Name: "claude-reactor-go-amd64-1a2b3c4d-default-sync", ProjectHash: "1a2b3c4d", Account: "default", Size: 4096},
+	}, nil)
+	logger := &captureLogger{}
+	app := createMockApp()
+	app.DockerMgr = dockerMgr
+	app.Logger = logger
+
+	cmd := NewCleanCmd(app)
+	cmd.SetArgs([]string{"--global", "--volumes", "--dry-run"})
+	require.NoError(t, cmd.ExecuteContext(context.Background()))
+
+	dockerMgr.AssertNotCalled(t, "RemoveResource", mock.Anything, mock.Anything)
+	planned := 0
+	for _, message := range logger.messages {
+		if strings.HasPrefix(message, "  • ") && strings.Contains(message, " Remove ") {
+			planned++
+		}
+	}
+	assert.Equal(t, 1, planned, "only the selected volume is planned for removal")
+	assert.Contains(t, logger.messages, "  • 💾 Remove volume claude-reactor-go-amd64-1a2b3c4d-default-sync — 4.0 KB")
+	assert.Contains(t, logger.messages, "🔍 Dry run: nothing was removed")
+}
+
+func TestBuildCleanReport(t *testing.T) {
+	now := time.Now()
+	report := buildCleanReport([]pkg.Resource{
+		{Kind: pkg.ResourceContainer, ProjectHash: "aaaaaaaa", Account: "default", Size: 100, Running: true, Created: now.Add(-time.Hour)},
+		{Kind: pkg.ResourceSession, ProjectHash: "aaaaaaaa", Account: "default", ProjectPath: "/work/a", Size: 50, Created: now},
+		{Kind: pkg.ResourceVolume, ProjectHash: "bbbbbbbb", Account: "default", Size: -1},
+		{Kind: pkg.ResourceImage, Name: "claude-reactor-go", Size: 1000},
+	})
+
+	require.Len(t, report.Projects, 2)
+	first := report.Projects[0]
+	assert.Equal(t, "aaaaaaaa", first.ProjectHash)
+	assert.Equal(t, "/work/a", first.ProjectPath)
+	assert.Equal(t, 1, first.Containers)
+	assert.Equal(t, 1, first.Running)
+	assert.Equal(t, 1, first.Sessions)
+	assert.Equal(t, int64(150), first.Size)
+	assert.True(t, first.LastUsed.Equal(now))
+	assert.Equal(t, 1, report.Projects[1].Volumes)
+
+	assert.Len(t, report.Images, 1)
+	assert.Equal(t, int64(1000), report.ImageSize)
+	assert.Equal(t, int64(1150), report.Total)
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "unknown", formatSize(-1))
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.5 KB", formatSize(1536))
+	assert.Equal(t, "2.0 GB", formatSize(2<<30))
+}
+
 func TestCleanCommandHelp(t *testing.T) {
 	t.Run("clean command help message", func(t *testing.T) {
 		app := createMockApp()
@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/pkg"
+)
+
+// defaultCompareParallel is how many images 'info images' validates at once
+const defaultCompareParallel = 4
+
+// imageComparison is the validation of one candidate image
+type imageComparison struct {
+	Image  string
+	Result *pkg.ImageValidationResult
+	Err    error
+}
+
+// newCompareImagesCmd creates the 'info images' command comparing candidate base images
+func newCompareImagesCmd(app *pkg.AppContainer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "images IMAGE...",
+		Short: "Compare several images for compatibility",
+		Long: `Validate several candidate images at once and compare them side by side: size,
+platform, Claude CLI, and the tools they lack. Tools are those required and recommended by
+the image policy and the project, or the built-in recommended tools. Use
+'claude-reactor info image IMAGE' for the details of one image.`,
+		Example: `# Choose a base image for the team
+claude-reactor info images ubuntu:24.04 debian:bookworm python:3.12
+
+# Validate two at a time
+claude-reactor info images node:22 node:20 node:18 --parallel 2`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			if err := reactor.EnsureDockerComponents(app); err != nil {
+				cmdPrintf(cmd, "❌ Docker not available: %v\n", err)
+				return err
+			}
+			ctx := cmd.Context()
+
+			if config, err := app.ConfigMgr.LoadConfig(); err == nil {
+				configureImageCache(app, config, false)
+				configureRegistryMirror(app, config)
+				tools, err := toolRequirements(config)
+				if err != nil {
+					return err
+				}
+				app.ImageValidator.SetToolRequirements(tools)
+				defer app.ImageValidator.SetToolRequirements(nil)
+			}
+
+			parallel, _ := cmd.Flags().GetInt("parallel")
+			app.Logger.Infof("🔍 Validating %d images...", len(args))
+			comparisons := compareImages(ctx, app, args, parallel)
+			printImageComparison(cmd, comparisons)
+
+			for _, comparison := range comparisons {
+				if comparison.Err == nil {
+					return nil
+				}
+			}
+			return fmt.Errorf("no image could be validated")
+		},
+	}
+	cmd.Flags().Int("parallel", defaultCompareParallel, "How many images to validate at once")
+	cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return localImageNames(app), cobra.ShellCompDirectiveNoFileComp
+	}
+	return cmd
+}
+
+// compareImages validates images with at most parallel at once, pulling those not present
+// locally. Comparisons keep the order of images.
+func compareImages(ctx context.Context, app *pkg.AppContainer, images []string, parallel int) []imageComparison {
+	if parallel <= 0 {
+		parallel = defaultCompareParallel
+	}
+
+	comparisons := make([]imageComparison, len(images))
+	slots := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+
+	for i, imageName := range images {
+		wg.Add(1)
+		go func(i int, imageName string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			comparison := imageComparison{Image: imageName}
+			if ctx.Err() != nil {
+				comparison.Err = ctx.Err()
+			} else {
+				comparison.Result, comparison.Err = app.ImageValidator.ValidateImage(ctx, imageName, true)
+			}
+			comparisons[i] = comparison
+		}(i, imageName)
+	}
+
+	wg.Wait()
+	return comparisons
+}
+
+// printImageComparison shows a row per image, then why incompatible images fall short
+func printImageComparison(cmd *cobra.Command, comparisons []imageComparison) {
+	width := len("IMAGE")
+	for _, comparison := range comparisons {
+		width = max(width, len(comparison.Image))
+	}
+
+	cmdPrintf(cmd, "%-*s  %-9s  %-13s  %-6s  %-15s  %s\n", width, "IMAGE", "SIZE", "PLATFORM", "CLAUDE", "STATUS", "MISSING TOOLS")
+	for _, comparison := range comparisons {
+		result := comparison.Result
+		if comparison.Err != nil {
+			cmdPrintf(cmd, "%-*s  %-9s  %-13s  %-6s  %-15s  %s\n", width, comparison.Image, "-", "-", "-", "❌ failed", "-")
+			continue
+		}
+		claude := "no"
+		if result.HasClaude {
+			claude = "yes"
+		}
+		status := "✅ compatible"
+		if !result.Compatible {
+			status = "❌ incompatible"
+		}
+		missing := missingTools(result)
+		missingText := "none"
+		if len(missing) > 0 {
+			missingText = strings.Join(missing, ", ")
+		}
+		cmdPrintf(cmd, "%-*s  %-9s  %-13s  %-6s  %-15s  %s\n", width, comparison.Image, formatSize(result.Size),
+			result.Platform+"/"+result.Architecture, claude, status, missingText)
+	}
+
+	for _, comparison := range comparisons {
+		switch {
+		case comparison.Err != nil:
+			cmdPrintf(cmd, "\n❌ %s: %v\n", comparison.Image, comparison.Err)
+		case !comparison.Result.Compatible:
+			cmdPrintf(cmd, "\n❌ %s:\n", comparison.Image)
+			for _, errMsg := range comparison.Result.Errors {
+				cmdPrintf(cmd, "  - %s\n", errMsg)
+			}
+			if !comparison.Result.HasClaude {
+				cmdPrintf(cmd, "  - Claude CLI not found\n")
+			}
+		}
+	}
+}
+
+// missingTools lists the tools an image lacks: the configured tools that are missing or too
+// old, or else the built-in recommended tools it lacks
+func missingTools(result *pkg.ImageValidationResult) []string {
+	var missing []string
+	if len(result.Tools) > 0 {
+		for _, check := range failedTools(result.Tools, false) {
+			if check.Status == pkg.ToolOutdated {
+				missing = append(missing, fmt.Sprintf("%s<%s", check.Name, check.MinVersion))
+			} else {
+				missing = append(missing, check.Name)
+			}
+		}
+		return missing
+	}
+
+	packages, _ := result.Metadata["packages"].(map[string]interface{})
+	for _, key := range []string{"missing_high_priority", "missing_other"} {
+		// Results read back from the cache hold []interface{} rather than []string
+		switch names := packages[key].(type) {
+		case []string:
+			missing = append(missing, names...)
+		case []interface{}:
+			for _, name := range names {
+				if name, ok := name.(string); ok {
+					missing = append(missing, name)
+				}
+			}
+		}
+	}
+	return missing
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func TestCompareImages(t *testing.T) {
+	ctx := context.Background()
+	validator := &mocks.MockImageValidator{}
+	app := createMockApp()
+	app.ImageValidator = validator
+
+	ubuntu := &pkg.ImageValidationResult{
+		Compatible: true, HasClaude: true, IsLinux: true, Platform: "linux", Architecture: "amd64", Size: 80 * 1024 * 1024,
+		Tools: []pkg.ToolCheck{
+			{ToolRequirement: pkg.ToolRequirement{Name: "git", Required: true}, Status: pkg.ToolOK},
+			{ToolRequirement: pkg.ToolRequirement{Name: "jq", MinVersion: "1.6"}, Version: "1.5", Status: pkg.ToolOutdated},
+			{ToolRequirement: pkg.ToolRequirement{Name: "yq"}, Status: pkg.ToolMissing},
+		},
+	}
+	alpine := &pkg.ImageValidationResult{
+		IsLinux: true, Platform: "linux", Architecture: "arm64", Size: 8 * 1024 * 1024,
+		Errors: []string{"Node.js not found, which Claude CLI needs"},
+		Metadata: map[string]interface{}{"packages": map[string]interface{}{
+			"missing_high_priority": []interface{}{"git"},
+			"missing_other":         []interface{}{"make"},
+		}},
+	}
+	validator.On("ValidateImage", ctx, "ubuntu:24.04", true).Return(ubuntu, nil)
+	validator.On("ValidateImage", ctx, "alpine:3", true).Return(alpine, nil)
+	validator.On("ValidateImage", ctx, "nosuch:image", true).Return(nil, errors.New("failed to ensure image exists: not found"))
+
+	comparisons := compareImages(ctx, app, []string{"ubuntu:24.04", "alpine:3", "nosuch:image"}, 2)
+	require.Len(t, comparisons, 3)
+	assert.Equal(t, "ubuntu:24.04", comparisons[0].Image)
+	assert.Same(t, ubuntu, comparisons[0].Result)
+	assert.Equal(t, "alpine:3", comparisons[1].Image)
+	assert.Error(t, comparisons[2].Err)
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	printImageComparison(cmd, comparisons)
+
+	output := out.String()
+	assert.Contains(t, output, "IMAGE         SIZE")
+	assert.Contains(t, output, "ubuntu:24.04  80.0 MB    linux/amd64    yes     ✅ compatible     jq<1.6, yq")
+	assert.Contains(t, output, "alpine:3      8.0 MB     linux/arm64    no      ❌ incompatible   git, make")
+	assert.Contains(t, output, "❌ alpine:3:\n  - Node.js not found, which Claude CLI needs\n  - Claude CLI not found")
+	assert.Contains(t, output, "❌ nosuch:image: failed to ensure image exists: not found")
+}
+
+func TestMissingTools(t *testing.T) {
+	assert.Empty(t, missingTools(&pkg.ImageValidationResult{}))
+	assert.Equal(t, []string{"curl", "vim"}, missingTools(&pkg.ImageValidationResult{
+		Metadata: map[string]interface{}{"packages": map[string]interface{}{
+			"missing_high_priority": []string{"curl"},
+			"missing_other":         []string{"vim"},
+		}},
+	}))
+}
@@ -1,14 +1,29 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/docker/docker/api/types/image"
 	"github.com/spf13/cobra"
 
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/variants"
 	"claude-reactor/pkg"
 )
 
+// builtinImageDescriptions are offered first when completing --image
+var builtinImageDescriptions = []string{
+	"base\tNode.js, Python with pip + uv, basic development tools",
+	"go\tBase + Go toolchain and development utilities",
+	"full\tGo + Rust, Java, database clients",
+	"cloud\tFull + AWS/GCP/Azure CLIs",
+	"k8s\tFull + Enhanced Kubernetes tools",
+}
+
 // NewCompletionCmd creates the completion command with installation instructions
 func NewCompletionCmd(app *pkg.AppContainer) *cobra.Command {
 	completionCmd := &cobra.Command{
@@ -70,4 +85,69 @@ PowerShell:
 	}
 
 	return completionCmd
-}
\ No newline at end of file
+}
+
+// completeImages completes built-in and external variants and images present in the local Docker daemon
+func completeImages(app *pkg.AppContainer) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completions := append([]string{}, builtinImageDescriptions...)
+		definitions, _ := variants.LoadDir(variants.Dir())
+		for _, definition := range definitions {
+			completions = append(completions, definition.Name+"\t"+definition.Description)
+		}
+		completions = append(completions, localImageNames(app)...)
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeAccounts completes accounts that have session directories
+func completeAccounts(app *pkg.AppContainer) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if app == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		accounts, err := app.ConfigMgr.ListAccounts()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return accounts, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionTimeout bounds Docker queries made while completing, so the shell stays responsive
+const completionTimeout = time.Second
+
+// localImageNames lists repo:tag names of local images, skipping claude-reactor's own builds.
+// Completion must stay fast and silent, so any Docker error yields no results.
+func localImageNames(app *pkg.AppContainer) []string {
+	if app == nil {
+		return nil
+	}
+	// A stopped or starting daemon must not stall the shell
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	if reactor.EnsureDockerComponentsContext(ctx, app) != nil {
+		return nil
+	}
+	client := app.DockerMgr.GetClient()
+	if client == nil {
+		return nil
+	}
+
+	images, err := client.ImageList(ctx, image.ListOptions{})
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, img := range images {
+		for _, tag := range img.RepoTags {
+			if tag == "<none>:<none>" || strings.HasPrefix(tag, "claude-reactor-") {
+				continue
+			}
+			names = append(names, tag)
+		}
+	}
+	return names
+}
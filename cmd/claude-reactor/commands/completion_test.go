@@ -1,9 +1,13 @@
 package commands
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+
+	"claude-reactor/pkg/mocks"
 )
 
 func TestNewCompletionCmd(t *testing.T) {
@@ -27,4 +31,32 @@ func TestNewCompletionCmd(t *testing.T) {
 		assert.NotNil(t, cmd)
 		assert.Equal(t, "completion [bash|zsh|fish|powershell]", cmd.Use)
 	})
-}
\ No newline at end of file
+}
+func TestDynamicCompletion(t *testing.T) {
+	t.Run("image completion offers built-in variants without docker", func(t *testing.T) {
+		completions, directive := completeImages(nil)(nil, nil, "")
+
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+		assert.Len(t, completions, 5)
+		assert.True(t, strings.HasPrefix(completions[0], "base\t"))
+	})
+
+	t.Run("account completion uses configured accounts", func(t *testing.T) {
+		app := createMockApp()
+		app.ConfigMgr.(*mocks.MockConfigManager).On("ListAccounts").Return([]string{"work", "personal"}, nil)
+
+		completions, directive := completeAccounts(app)(nil, nil, "")
+
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+		assert.Equal(t, []string{"work", "personal"}, completions)
+	})
+
+	t.Run("run command registers flag completions", func(t *testing.T) {
+		cmd := NewRunCmd(createMockApp())
+
+		_, ok := cmd.GetFlagCompletionFunc("image")
+		assert.True(t, ok)
+		_, ok = cmd.GetFlagCompletionFunc("account")
+		assert.True(t, ok)
+	})
+}
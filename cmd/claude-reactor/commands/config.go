@@ -1,11 +1,26 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"claude-reactor/internal/reactor/architecture"
+	"claude-reactor/internal/reactor/checkpoint"
+	"claude-reactor/internal/reactor/claudeconfig"
+	"claude-reactor/internal/reactor/docker"
+	"claude-reactor/internal/reactor/dotfiles"
+	"claude-reactor/internal/reactor/docker/validation"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/notify"
+	"claude-reactor/internal/reactor/policy"
+	"claude-reactor/internal/reactor/registry"
+	"claude-reactor/internal/reactor/secrets"
 	"claude-reactor/pkg"
 )
 
@@ -29,7 +44,43 @@ Available configuration keys (use 'config set' to change):
   session_persistence  Enable/disable session persistence (true/false)
   last_session_id      Manually set the last session ID
   container_id         Manually set the container ID
-  project_path         Default project path`,
+  project_path         Default project path
+  toolchain_install    Install project-pinned toolchain versions via mise/asdf (true/false)
+  tools.required       Tools the image must have as name[:apt-package][>=version], comma-separated (none to clear)
+  tools.recommended    Tools the image should have, in place of the built-in list, comma-separated (none to clear)
+  tools_install        Install missing tools' apt packages in a derived image (true/false)
+  vuln_threshold       Refuse images with CVEs at or above this severity (low, medium, high, critical)
+  sync_mode            Sync project files into a volume with mutagen instead of bind mounting (true/false)
+  clipboard            Bridge clipboard copies in sessions to the host clipboard (true/false)
+  platform             Run containers for this platform, e.g. linux/amd64 (none for the host platform)
+  image_cache_ttl      How long image validation results are trusted, e.g. 168h (default 720h)
+  image_cache_size     Image validation results kept before the oldest are evicted (default 200)
+  image_cache_url      Validation results shared with the team, e.g. https://cache.example.com/reactor or s3://bucket/reactor (none to disable)
+  claude_cli_version   Install this exact Claude CLI version at container start, e.g. 1.0.58 (none to upgrade)
+  notifications        Desktop notifications for: build, task, unhealthy (comma-separated, all, or none)
+  notify_after         How long a Claude task must run to be notified when it finishes (default 1m)
+  registry_mirror      Pull published images through this mirror first, e.g. harbor.example.com/ghcr (none to disable)
+  claude_args          Extra Claude CLI arguments for every run, e.g. "--model opus --max-turns 20" (none to clear)
+  system_prompt        Prompt files seeded into Claude's user CLAUDE.md at each run, comma-separated (none to clear)
+  mounts               Extra mounts as src[:dst][:ro|rw][:cached|delegated], comma-separated (none to clear)
+  tmpfs                Container paths to mount a tmpfs at, comma-separated (none to clear)
+  checkpoints          Back up project files during sessions: on (every 15m), start, an interval such as 10m, or off
+  protected_paths      Project paths sessions cannot modify, comma-separated, e.g. .github/workflows (none to clear)
+  secret_scan          Check the project for secrets before mounting: warn, exclude, placeholder, or off
+  dns                  DNS servers for containers, comma-separated IP addresses (none to clear)
+  dns_search           DNS search domains for containers, comma-separated (none to clear)
+  add_hosts            Extra /etc/hosts entries as host:ip, comma-separated (none to clear)
+  dotfiles             Dotfiles git URL or local directory applied in the container home (none to clear)
+  dotfiles_install     Command installing the dotfiles, run in their directory (none to use install.sh etc.)
+  hostname             Container hostname; {project}, {variant} and {account} are replaced (none for project-variant-account)
+  prompt               Shell prompt label, with the same placeholders (off to hide, none for claude-reactor:{project}@{account})
+  packages.apt         Extra apt packages installed on the variant, comma-separated (none to clear)
+  packages.pip         Extra pip packages installed on the variant, comma-separated (none to clear)
+  packages.npm         Extra global npm packages installed on the variant, comma-separated (none to clear)
+  packages.cargo       Extra cargo crates installed on the variant, comma-separated (none to clear)
+  task.NAME            Command run by 'claude-reactor task NAME' (none to remove the task)
+  task.NAME.env        Environment for the task as KEY=VALUE, comma-separated (none to clear)
+  task.NAME.workdir    Project directory the task runs in (none for the project root)`,
 	}
 
 	configCmd.AddCommand(
@@ -87,7 +138,43 @@ Available configuration keys:
   session_persistence  Enable/disable session persistence (true/false)
   last_session_id      Manually set the last session ID
   container_id         Manually set the container ID
-  project_path         Default project path`,
+  project_path         Default project path
+  toolchain_install    Install project-pinned toolchain versions via mise/asdf (true/false)
+  tools.required       Tools the image must have as name[:apt-package][>=version], comma-separated (none to clear)
+  tools.recommended    Tools the image should have, in place of the built-in list, comma-separated (none to clear)
+  tools_install        Install missing tools' apt packages in a derived image (true/false)
+  vuln_threshold       Refuse images with CVEs at or above this severity (low, medium, high, critical)
+  sync_mode            Sync project files into a volume with mutagen instead of bind mounting (true/false)
+  clipboard            Bridge clipboard copies in sessions to the host clipboard (true/false)
+  platform             Run containers for this platform, e.g. linux/amd64 (none for the host platform)
+  image_cache_ttl      How long image validation results are trusted, e.g. 168h (default 720h)
+  image_cache_size     Image validation results kept before the oldest are evicted (default 200)
+  image_cache_url      Validation results shared with the team, e.g. https://cache.example.com/reactor or s3://bucket/reactor (none to disable)
+  claude_cli_version   Install this exact Claude CLI version at container start, e.g. 1.0.58 (none to upgrade)
+  notifications        Desktop notifications for: build, task, unhealthy (comma-separated, all, or none)
+  notify_after         How long a Claude task must run to be notified when it finishes (default 1m)
+  registry_mirror      Pull published images through this mirror first, e.g. harbor.example.com/ghcr (none to disable)
+  claude_args          Extra Claude CLI arguments for every run, e.g. "--model opus --max-turns 20" (none to clear)
+  system_prompt        Prompt files seeded into Claude's user CLAUDE.md at each run, comma-separated (none to clear)
+  mounts               Extra mounts as src[:dst][:ro|rw][:cached|delegated], comma-separated (none to clear)
+  tmpfs                Container paths to mount a tmpfs at, comma-separated (none to clear)
+  checkpoints          Back up project files during sessions: on (every 15m), start, an interval such as 10m, or off
+  protected_paths      Project paths sessions cannot modify, comma-separated, e.g. .github/workflows (none to clear)
+  secret_scan          Check the project for secrets before mounting: warn, exclude, placeholder, or off
+  dns                  DNS servers for containers, comma-separated IP addresses (none to clear)
+  dns_search           DNS search domains for containers, comma-separated (none to clear)
+  add_hosts            Extra /etc/hosts entries as host:ip, comma-separated (none to clear)
+  dotfiles             Dotfiles git URL or local directory applied in the container home (none to clear)
+  dotfiles_install     Command installing the dotfiles, run in their directory (none to use install.sh etc.)
+  hostname             Container hostname; {project}, {variant} and {account} are replaced (none for project-variant-account)
+  prompt               Shell prompt label, with the same placeholders (off to hide, none for claude-reactor:{project}@{account})
+  packages.apt         Extra apt packages installed on the variant, comma-separated (none to clear)
+  packages.pip         Extra pip packages installed on the variant, comma-separated (none to clear)
+  packages.npm         Extra global npm packages installed on the variant, comma-separated (none to clear)
+  packages.cargo       Extra cargo crates installed on the variant, comma-separated (none to clear)
+  task.NAME            Command run by 'claude-reactor task NAME' (none to remove the task)
+  task.NAME.env        Environment for the task as KEY=VALUE, comma-separated (none to clear)
+  task.NAME.workdir    Project directory the task runs in (none for the project root)`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Handle help case when app is nil
@@ -99,44 +186,187 @@ Available configuration keys:
 	}
 }
 
+// registryMirrorStatus describes a registry mirror and whether it is reachable
+func registryMirrorStatus(ctx context.Context, value string) string {
+	mirror, err := registry.ParseMirror(value)
+	if err != nil {
+		return i18n.T("config.mirror_invalid", value, err)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := mirror.Check(ctx); err != nil {
+		return i18n.T("config.mirror_unreachable", mirror, registry.DefaultRegistry)
+	}
+	return i18n.T("config.mirror_healthy", mirror)
+}
+
 // showEnhancedConfig displays the current configuration
 func showEnhancedConfig(cmd *cobra.Command, app *pkg.AppContainer) error {
 	config, err := app.ConfigMgr.LoadConfig()
 	if err != nil {
-		app.Logger.Warnf("Could not load config: %v", err)
+		app.Logger.Warn(i18n.T("config.load_failed", err))
 		config = app.ConfigMgr.GetDefaultConfig()
 	}
 
-	fmt.Printf("📋 Claude-Reactor Configuration\n")
-	fmt.Printf("═══════════════════════════════\n\n")
+	fmt.Fprintln(i18n.Stdout, i18n.T("config.title"))
+	fmt.Fprintf(i18n.Stdout, "═══════════════════════════════\n\n")
 
-	fmt.Printf("🖼️  Image/Variant: %s\n", getDisplayValue(config.Variant, "auto-detect"))
-	fmt.Printf("👤 Account: %s\n", getDisplayValue(config.Account, "default"))
-	fmt.Printf("🔥 Danger Mode: %t\n", config.DangerMode)
-	fmt.Printf("🐳 Host Docker: %t\n", config.HostDocker)
+	fmt.Fprintln(i18n.Stdout, i18n.T("config.image_variant", getDisplayValue(config.Variant, i18n.T("config.auto_detect"))))
+	fmt.Fprintln(i18n.Stdout, i18n.T("config.account", getDisplayValue(config.Account, "default")))
+	fmt.Fprintln(i18n.Stdout, i18n.T("config.danger_mode", config.DangerMode))
+	fmt.Fprintln(i18n.Stdout, i18n.T("config.host_docker", config.HostDocker))
 	if config.HostDocker {
-		fmt.Printf("⏰ Host Docker Timeout: %s\n", getDisplayValue(config.HostDockerTimeout, "5m"))
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.host_docker_timeout", getDisplayValue(config.HostDockerTimeout, "5m")))
 	}
-	fmt.Printf("🔑 SSH Agent: %t\n", config.SSHAgent)
+	fmt.Fprintln(i18n.Stdout, i18n.T("config.ssh_agent", config.SSHAgent))
 	if config.SSHAgent && config.SSHAgentSocket != "" {
-		fmt.Printf("🔌 SSH Socket: %s\n", config.SSHAgentSocket)
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.ssh_socket", config.SSHAgentSocket))
 	}
-	fmt.Printf("💾 Session Persistence: %t\n", config.SessionPersistence)
+	fmt.Fprintln(i18n.Stdout, i18n.T("config.session_persistence", config.SessionPersistence))
 	if config.SessionPersistence {
-		fmt.Printf("🔗 Last Session ID: %s\n", getDisplayValue(config.LastSessionID, "none"))
-		fmt.Printf("📦 Container ID: %s\n", getDisplayValue(config.ContainerID, "none"))
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.last_session_id", getDisplayValue(config.LastSessionID, i18n.T("config.none"))))
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.container_id", getDisplayValue(config.ContainerID, i18n.T("config.none"))))
+	}
+	if config.ToolchainInstall {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.toolchain_install", config.ToolchainInstall))
+	}
+	if len(config.RequiredTools) > 0 {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.required_tools", strings.Join(config.RequiredTools, ", ")))
+	}
+	if len(config.RecommendedTools) > 0 {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.recommended_tools", strings.Join(config.RecommendedTools, ", ")))
+	}
+	if config.ToolsInstall {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.tools_install", config.ToolsInstall))
+	}
+	if config.VulnThreshold != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.vulnerability_threshold", config.VulnThreshold))
+	}
+	if config.SyncMode {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.sync_mode", config.SyncMode))
+	}
+	if config.Clipboard {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.clipboard_bridge", config.Clipboard))
+	}
+	if config.Platform != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.platform", config.Platform))
+	}
+	if config.ImageCacheTTL != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.image_cache_ttl", config.ImageCacheTTL))
+	}
+	if config.ImageCacheSize > 0 {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.image_cache_size", config.ImageCacheSize))
+	}
+	if config.ImageCacheURL != "" {
+		shared := config.ImageCacheURL
+		if parsed, err := url.Parse(shared); err == nil {
+			shared = parsed.Redacted()
+		}
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.shared_image_cache", shared))
+	}
+	if config.ClaudeCLIVersion != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.claude_cli_version", config.ClaudeCLIVersion))
+	}
+	if config.Notifications != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.notifications", config.Notifications))
+	}
+	if config.NotifyAfter != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.notify_after", config.NotifyAfter))
+	}
+	if mirror := registry.Resolve(config.RegistryMirror); mirror != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.registry_mirror", secrets.Redact(registryMirrorStatus(cmd.Context(), mirror))))
+	}
+	if config.ClaudeArgs != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.claude_args", secrets.Redact(config.ClaudeArgs)))
+	}
+	if config.SystemPrompt != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.system_prompt", config.SystemPrompt))
+	}
+	if config.Mounts != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.mounts", config.Mounts))
+	}
+	if config.Tmpfs != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.tmpfs", config.Tmpfs))
+	}
+	if config.Checkpoints != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.checkpoints", config.Checkpoints))
+	}
+	if config.ProtectedPaths != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.protected_paths", config.ProtectedPaths))
+	}
+	if config.SecretScan != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.secret_scan", config.SecretScan))
+	}
+	if config.DNS != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.dns", config.DNS))
+	}
+	if config.DNSSearch != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.dns_search", config.DNSSearch))
+	}
+	if config.AddHosts != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.extra_hosts", config.AddHosts))
+	}
+	if config.Dotfiles != "" {
+		install := config.DotfilesInstall
+		if install == "" {
+			install = i18n.T("config.dotfiles_default")
+		}
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.dotfiles", config.Dotfiles, install))
+	}
+	if config.Hostname != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.hostname", config.Hostname))
+	}
+	if config.Prompt != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.prompt", config.Prompt))
+	}
+	if !config.Packages.Empty() {
+		for _, manager := range pkg.PackageManagers {
+			if list := *config.Packages.List(manager); len(list) > 0 {
+				fmt.Fprintln(i18n.Stdout, i18n.T("config.packages", manager, strings.Join(list, ", ")))
+			}
+		}
+	}
+	if len(config.Secrets) > 0 {
+		names := make([]string, 0, len(config.Secrets))
+		for _, secret := range config.Secrets {
+			names = append(names, secret.Name)
+		}
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.secrets", strings.Join(names, ", ")))
+	}
+	if len(config.Tasks) > 0 {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.tasks", strings.Join(taskNames(config), ", ")))
+	}
+	if os.Getenv("ANTHROPIC_API_KEY") != "" {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.anthropic_api_key", secrets.Redacted))
+	}
+	if imagePolicy, err := policy.Load(); err != nil {
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.image_policy_error", err))
+	} else if imagePolicy.Source != "" {
+		allowed := i18n.T("config.any_image")
+		if len(imagePolicy.AllowedImages) > 0 {
+			allowed = strings.Join(imagePolicy.AllowedImages, ", ")
+		}
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.image_policy", imagePolicy.Source, allowed, imagePolicy.RequireDigest))
+		if signatures := imagePolicy.Signatures; signatures.Configured() {
+			fmt.Fprintln(i18n.Stdout, i18n.T("config.image_signatures", len(signatures.Keys), len(signatures.Identities), signatures.Required))
+		}
+		if len(imagePolicy.RequiredTools) > 0 || len(imagePolicy.RecommendedTools) > 0 {
+			fmt.Fprintln(i18n.Stdout, i18n.T("config.policy_tools", toolList(imagePolicy.RequiredTools), toolList(imagePolicy.RecommendedTools)))
+		}
 	}
 
 	// Show current directory and project detection
-	fmt.Printf("\n📁 Current Directory: %s\n", getCurrentDir())
+	fmt.Fprintln(i18n.Stdout)
+	fmt.Fprintln(i18n.Stdout, i18n.T("config.current_directory", getCurrentDir()))
 	if config.ProjectPath != "" {
-		fmt.Printf("📂 Configured Project Path: %s\n", config.ProjectPath)
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.configured_project_path", config.ProjectPath))
 	}
 
 	// Show auto-detected variant
 	detectedVariant, err := app.ConfigMgr.AutoDetectVariant("")
 	if err == nil {
-		fmt.Printf("🔍 Auto-detected: %s\n", detectedVariant)
+		fmt.Fprintln(i18n.Stdout, i18n.T("config.auto_detected", detectedVariant))
 	}
 
 	return nil
@@ -153,7 +383,7 @@ func getCurrentDir() string {
 	if dir, err := os.Getwd(); err == nil {
 		return dir
 	}
-	return "<unknown>"
+	return "<" + i18n.T("config.unknown") + ">"
 }
 
 // validateConfig validates the current configuration
@@ -167,7 +397,7 @@ func validateConfig(cmd *cobra.Command, app *pkg.AppContainer) error {
 		return fmt.Errorf("configuration validation failed: %w", err)
 	}
 
-	fmt.Println("Configuration is valid ✓")
+	fmt.Fprintln(i18n.Stdout, i18n.T("config.valid"))
 	return nil
 }
 
@@ -176,6 +406,15 @@ func setConfigValue(cmd *cobra.Command, args []string, app *pkg.AppContainer) er
 	key := args[0]
 	value := args[1]
 
+	// Don't interleave with a run that is saving this project's configuration
+	if projectDir, err := os.Getwd(); err == nil {
+		projectLock, err := lockProject(cmd.Context(), app, projectDir)
+		if err != nil {
+			return err
+		}
+		defer projectLock.Release()
+	}
+
 	// Load current config
 	config, err := app.ConfigMgr.LoadConfig()
 	if err != nil {
@@ -225,8 +464,249 @@ func setConfigValue(cmd *cobra.Command, args []string, app *pkg.AppContainer) er
 		config.ContainerID = value
 	case "last_session_id":
 		config.LastSessionID = value
+	case "toolchain_install":
+		config.ToolchainInstall = value == "true" || value == "1" || value == "on"
+	case "tools.required", "tools.recommended":
+		if value == "none" {
+			value = ""
+		}
+		specs := configList(value)
+		for _, spec := range specs {
+			if _, err := validation.ParseToolSpec(spec, true); err != nil {
+				return err
+			}
+		}
+		if key == "tools.required" {
+			config.RequiredTools = specs
+		} else {
+			config.RecommendedTools = specs
+		}
+	case "tools_install":
+		config.ToolsInstall = value == "true" || value == "1" || value == "on"
+	case "vuln_threshold":
+		if value != "" && value != "none" && !validation.ValidSeverity(value) {
+			return fmt.Errorf("invalid vuln_threshold: %s (use low, medium, high, critical, or none)", value)
+		}
+		if value == "none" {
+			value = ""
+		}
+		config.VulnThreshold = value
+	case "sync_mode":
+		config.SyncMode = value == "true" || value == "1" || value == "on"
+	case "clipboard":
+		config.Clipboard = value == "true" || value == "1" || value == "on"
+	case "platform":
+		if value == "none" {
+			value = ""
+		}
+		if value != "" {
+			if err := architecture.ValidatePlatform(value); err != nil {
+				return err
+			}
+		}
+		config.Platform = value
+	case "image_cache_ttl":
+		if value == "none" || value == "default" {
+			value = ""
+		}
+		config.ImageCacheTTL = value
+		if _, err := imageCachePolicy(config); err != nil {
+			return err
+		}
+	case "image_cache_size":
+		if value == "none" || value == "default" {
+			config.ImageCacheSize = 0
+			break
+		}
+		size, err := strconv.Atoi(value)
+		if err != nil || size <= 0 {
+			return fmt.Errorf("invalid image_cache_size: %s (use a positive number of entries)", value)
+		}
+		config.ImageCacheSize = size
+	case "image_cache_url":
+		if value == "none" {
+			value = ""
+		}
+		if err := validation.ValidateSharedCache(value); err != nil {
+			return err
+		}
+		config.ImageCacheURL = value
+	case "claude_cli_version":
+		if value == "none" || value == "latest" {
+			value = ""
+		}
+		if value != "" && !docker.ValidClaudeCLIVersion(value) {
+			return fmt.Errorf("invalid claude_cli_version: %s (use an exact version such as 1.0.58, or none)", value)
+		}
+		config.ClaudeCLIVersion = value
+	case "notifications":
+		events, err := notify.ParseEvents(value)
+		if err != nil {
+			return err
+		}
+		value = strings.Join(events, ",")
+		config.Notifications = value
+	case "notify_after":
+		if value == "none" || value == "default" {
+			value = ""
+		}
+		config.NotifyAfter = value
+		if _, err := notifyAfter(config); err != nil {
+			return err
+		}
+	case "registry_mirror":
+		if value == "none" {
+			value = ""
+		}
+		if _, err := registry.ParseMirror(value); err != nil {
+			return err
+		}
+		config.RegistryMirror = value
+	case "claude_args":
+		if value == "none" {
+			value = ""
+		}
+		if _, err := claudeconfig.SplitArgs(value); err != nil {
+			return fmt.Errorf("invalid claude_args: %w", err)
+		}
+		config.ClaudeArgs = value
+	case "system_prompt":
+		if value == "none" {
+			value = ""
+		}
+		config.SystemPrompt = value
+	case "mounts":
+		if value == "none" {
+			value = ""
+		}
+		for _, spec := range configList(value) {
+			if _, err := app.MountMgr.ParseMountSpec(spec); err != nil {
+				return err
+			}
+		}
+		config.Mounts = value
+	case "tmpfs":
+		if value == "none" {
+			value = ""
+		}
+		for _, target := range configList(value) {
+			if _, err := app.MountMgr.ParseTmpfsSpec(target); err != nil {
+				return err
+			}
+		}
+		config.Tmpfs = value
+	case "checkpoints":
+		if value == "none" {
+			value = ""
+		}
+		if _, _, err := checkpoint.ParseInterval(value); err != nil {
+			return err
+		}
+		config.Checkpoints = value
+	case "protected_paths":
+		if value == "none" {
+			value = ""
+		}
+		if _, err := protectedPaths(value); err != nil {
+			return err
+		}
+		config.ProtectedPaths = value
+	case "secret_scan":
+		if value == "none" {
+			value = ""
+		}
+		if _, err := secrets.ParseMode(value); err != nil {
+			return err
+		}
+		config.SecretScan = value
+	case "dns":
+		if value == "none" {
+			value = ""
+		}
+		for _, server := range configList(value) {
+			if err := docker.ValidateDNSServer(server); err != nil {
+				return err
+			}
+		}
+		config.DNS = value
+	case "dns_search":
+		if value == "none" {
+			value = ""
+		}
+		for _, domain := range configList(value) {
+			if err := docker.ValidateDNSSearch(domain); err != nil {
+				return err
+			}
+		}
+		config.DNSSearch = value
+	case "add_hosts":
+		if value == "none" {
+			value = ""
+		}
+		for _, spec := range configList(value) {
+			if _, err := docker.ParseHostSpec(spec); err != nil {
+				return err
+			}
+		}
+		config.AddHosts = value
+	case "dotfiles":
+		if value == "none" {
+			value = ""
+		}
+		if value != "" && !dotfiles.IsRemote(value) {
+			if _, err := app.MountMgr.ValidateMountPath(value); err != nil {
+				return fmt.Errorf("invalid dotfiles '%s': not a git URL or a readable directory: %w", value, err)
+			}
+		}
+		config.Dotfiles = value
+	case "hostname":
+		if value == "none" {
+			value = ""
+		}
+		if value != "" {
+			if err := docker.ValidateHostname(value); err != nil {
+				return err
+			}
+		}
+		config.Hostname = value
+	case "prompt":
+		if value == "none" {
+			value = ""
+		}
+		if strings.ContainsAny(value, "\n\r") {
+			return fmt.Errorf("invalid prompt: must be a single line")
+		}
+		config.Prompt = value
+	case "dotfiles_install":
+		if value == "none" {
+			value = ""
+		}
+		config.DotfilesInstall = value
 	default:
-		return fmt.Errorf("unknown configuration key: %s", key)
+		if manager, ok := strings.CutPrefix(key, "packages."); ok {
+			list := config.Packages.List(manager)
+			if list == nil {
+				return fmt.Errorf("unknown package manager '%s' (must be one of: %s)", manager, strings.Join(pkg.PackageManagers, ", "))
+			}
+			if value == "none" {
+				value = ""
+			}
+			packages := configList(value)
+			for _, name := range packages {
+				if err := docker.ValidatePackage(name); err != nil {
+					return err
+				}
+			}
+			*list = packages
+			break
+		}
+		name, ok := strings.CutPrefix(key, "task.")
+		if !ok {
+			return fmt.Errorf("unknown configuration key: %s", key)
+		}
+		if err := setTask(config, name, value); err != nil {
+			return err
+		}
 	}
 
 	// Save the updated configuration
@@ -234,6 +714,6 @@ func setConfigValue(cmd *cobra.Command, args []string, app *pkg.AppContainer) er
 		return fmt.Errorf("failed to save configuration: %w", err)
 	}
 
-	app.Logger.Infof("Set %s = %s", key, value)
+	app.Logger.Info(i18n.T("config.set", key, value))
 	return nil
 }
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/conversation"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// NewConversationCmd creates the conversation command for the project's named Claude conversations
+func NewConversationCmd(app *pkg.AppContainer) *cobra.Command {
+	var conversationCmd = &cobra.Command{
+		Use:   "conversation",
+		Short: "Manage named Claude conversations for the project",
+		Long: `Manage named Claude conversations for the project.
+
+'claude-reactor run --conversation NAME' starts a conversation with its own
+Claude session the first time, and continues it on later runs, so a project
+can keep several threads going side by side.`,
+		Example: `# Keep separate threads for separate work
+claude-reactor run --conversation refactor
+claude-reactor run --conversation docs
+
+# See the project's conversations
+claude-reactor conversation list`,
+	}
+
+	conversationCmd.AddCommand(newConversationListCmd(app))
+
+	return conversationCmd
+}
+
+func newConversationListCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the project's named conversations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return listConversations(app)
+		},
+	}
+}
+
+// listConversations prints the project's conversations, most recently used first
+func listConversations(app *pkg.AppContainer) error {
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	_, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath)
+	conversations, err := conversation.Load(sessionDir)
+	if err != nil {
+		return err
+	}
+	if len(conversations) == 0 {
+		fmt.Fprintln(i18n.Stdout, "No named conversations for this project")
+		fmt.Fprintln(i18n.Stdout, "💡 Start one with: claude-reactor run --conversation NAME")
+		return nil
+	}
+
+	fmt.Fprintf(i18n.Stdout, "%-20s %-36s %-16s %s\n", "NAME", "SESSION", "LAST USED", "MESSAGES")
+	for _, c := range conversations {
+		messages := "yes"
+		if conversation.Transcript(sessionDir, c.ID) == "" {
+			messages = "none yet"
+		}
+		fmt.Fprintf(i18n.Stdout, "%-20s %-36s %-16s %s\n", c.Name, c.ID, c.LastUsed.Format("2006-01-02 15:04"), messages)
+	}
+	return nil
+}
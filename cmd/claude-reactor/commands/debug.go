@@ -1,12 +1,17 @@
 package commands
 
 import (
+	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/timing"
 	"claude-reactor/pkg"
 )
 
@@ -33,193 +38,260 @@ func NewInfoCmd(app *pkg.AppContainer) *cobra.Command {
 		Example: `# Show system information
 claude-reactor info
 
+# Include how long startup took
+claude-reactor info info --timing
+
 # Test custom image compatibility
 claude-reactor info image ubuntu:22.04
 
+# Compare candidate base images
+claude-reactor info images ubuntu:24.04 debian:bookworm python:3.12
+
 # Clear validation cache
 claude-reactor info cache clear
 
 # Show cache statistics
-claude-reactor info cache info`,
+claude-reactor info cache stats
+
+# Share validation results with a teammate
+claude-reactor info cache export reactor-cache.json
+claude-reactor info cache import reactor-cache.json`,
 	}
 
-	infoCmd.AddCommand(
-		&cobra.Command{
-			Use:   "status",
-			Short: "Show debug status",
-			Long:  "Display current debug mode and logging configuration.",
-			RunE: func(cmd *cobra.Command, args []string) error {
-				// Handle help case when app is nil
-				if app == nil {
-					return cmd.Help()
-				}
-				cmd.Printf("Debug Mode: %v\n", app.Debug)
+	imageCmd := &cobra.Command{
+		Use:   "image [image-name]",
+		Short: "Test custom image compatibility",
+		Long: `Test a custom Docker image for claude-reactor compatibility.
+This will validate platform support, Claude CLI availability, and recommended packages.
+Results are the same as what you'd see during normal container startup.`,
+		Example: `# Test Ubuntu image
+claude-reactor debug image ubuntu:22.04
 
-				// Try to get log level through interface or fallback
-				if logger, ok := app.Logger.(interface{ GetLevel() logrus.Level }); ok {
-					cmd.Printf("Log Level: %s\n", logger.GetLevel().String())
-				} else {
-					cmd.Printf("Log Level: unable to determine\n")
-				}
-				return nil
-			},
-		},
-		&cobra.Command{
-			Use:   "info",
-			Short: "Show system information",
-			Long:  "Display comprehensive system information including Docker connectivity, architecture, and version details.",
-			RunE: func(cmd *cobra.Command, args []string) error {
-				// Handle help case when app is nil
-				if app == nil {
-					return cmd.Help()
-				}
-				app.Logger.Info("=== Claude-Reactor Debug Info ===")
+# Test with detailed output
+claude-reactor debug image python:3.11 --verbose
 
-				// Architecture information
-				arch, err := app.ArchDetector.GetHostArchitecture()
-				if err != nil {
-					app.Logger.Errorf("Failed to detect architecture: %v", err)
-				} else {
-					cmd.Printf("Host Architecture: %s\n", arch)
-				}
+# Test registry image
+claude-reactor debug image ghcr.io/user/project:latest
 
-				platform, err := app.ArchDetector.GetDockerPlatform()
-				if err != nil {
-					app.Logger.Errorf("Failed to get Docker platform: %v", err)
-				} else {
-					cmd.Printf("Docker Platform: %s\n", platform)
-				}
+# Include a CVE scan (requires trivy or grype)
+claude-reactor info image python:3.11 --scan`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			imageName := args[0]
+			ctx := cmd.Context()
 
-				cmd.Printf("Multi-arch Support: %t\n", app.ArchDetector.IsMultiArchSupported())
+			// Ensure Docker components are initialized
+			if err := reactor.EnsureDockerComponents(app); err != nil {
+				cmdPrintln(cmd, i18n.T("info.docker_unavailable", err))
+				return err
+			}
 
-				// Version information
-				cmd.Printf("Version: %s\n", debugVersion)
-				cmd.Printf("Git Commit: %s\n", debugGitCommit)
-				cmd.Printf("Build Date: %s\n", debugBuildDate)
+			app.Logger.Info(i18n.T("info.testing_image", imageName))
 
-				// Docker connectivity test - try to initialize Docker
-				ctx := cmd.Context()
-				if err := reactor.EnsureDockerComponents(app); err != nil {
-					cmd.Printf("Docker Connection: ❌ Failed (%v)\n", err)
-				} else {
-					_, dockerErr := app.DockerMgr.IsContainerRunning(ctx, "test-connection")
-					if dockerErr != nil {
-						cmd.Printf("Docker Connection: ❌ Failed (%v)\n", dockerErr)
-					} else {
-						cmd.Printf("Docker Connection: ✅ Connected\n")
+			// Test image validation
+			result, err := app.ImageValidator.ValidateImage(ctx, imageName, true)
+			if err != nil {
+				cmdPrintln(cmd, i18n.T("info.validation_failed", err))
+				return err
+			}
+
+			cmdPrintln(cmd)
+			cmdPrintln(cmd, i18n.T("info.image_results"))
+			cmdPrintln(cmd, i18n.T("info.image", imageName))
+			cmdPrintln(cmd, i18n.T("info.digest", result.Digest))
+			cmdPrintln(cmd, i18n.T("info.architecture", result.Architecture))
+			cmdPrintln(cmd, i18n.T("info.platform", result.Platform))
+			cmdPrintln(cmd, i18n.T("info.size", float64(result.Size)/(1024*1024)))
+			cmdPrintln(cmd, i18n.T("info.compatible", result.Compatible))
+			cmdPrintln(cmd, i18n.T("info.has_claude", result.HasClaude))
+			cmdPrintln(cmd, i18n.T("info.is_linux", result.IsLinux))
+
+			if len(result.Warnings) > 0 {
+				cmdPrintln(cmd)
+				cmdPrintln(cmd, i18n.T("info.warnings"))
+				for _, warning := range result.Warnings {
+					cmdPrintf(cmd, "  - %s\n", warning)
+				}
+			}
+
+			if len(result.Errors) > 0 {
+				cmdPrintln(cmd)
+				cmdPrintln(cmd, i18n.T("info.errors"))
+				for _, errMsg := range result.Errors {
+					cmdPrintf(cmd, "  - %s\n", errMsg)
+				}
+			}
+
+			// Show package analysis if available
+			if packages, ok := result.Metadata["packages"].(map[string]interface{}); ok {
+				cmdPrintln(cmd)
+				cmdPrintln(cmd, i18n.T("info.packages"))
+				if available, ok := packages["available"].([]string); ok {
+					cmdPrintln(cmd, i18n.T("info.available_tools", len(available), strings.Join(available, ", ")))
+				}
+				if missing, ok := packages["missing_high_priority"].([]string); ok && len(missing) > 0 {
+					cmdPrintln(cmd, i18n.T("info.missing_tools", strings.Join(missing, ", ")))
+				}
+				if totalChecked, ok := packages["total_checked"].(int); ok {
+					if totalAvailable, ok := packages["total_available"].(int); ok {
+						cmdPrintln(cmd, i18n.T("info.coverage", totalAvailable, totalChecked))
 					}
 				}
+			}
 
-				return nil
-			},
+			if scan, _ := cmd.Flags().GetBool("scan"); scan {
+				printImageScan(cmd, app, imageName)
+			}
+
+			if result.Compatible {
+				cmdPrintln(cmd)
+				cmdPrintln(cmd, i18n.T("info.image_compatible"))
+			} else {
+				cmdPrintln(cmd)
+				cmdPrintln(cmd, i18n.T("info.image_incompatible"))
+			}
+
+			return nil
 		},
-		&cobra.Command{
-			Use:   "image [image-name]",
-			Short: "Test custom image compatibility",
-			Long: `Test a custom Docker image for claude-reactor compatibility.
-This will validate platform support, Claude CLI availability, and recommended packages.
-Results are the same as what you'd see during normal container startup.`,
-			Example: `# Test Ubuntu image
-claude-reactor debug image ubuntu:22.04
+	}
+	imageCmd.Flags().Bool("scan", false, "Also run a CVE scan with trivy or grype")
+	imageCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return localImageNames(app), cobra.ShellCompDirectiveNoFileComp
+	}
 
-# Test with detailed output
-claude-reactor debug image python:3.11 --verbose
+	systemInfoCmd := &cobra.Command{
+		Use:   "info",
+		Short: "Show system information",
+		Long:  "Display comprehensive system information including Docker connectivity, architecture, and version details.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			app.Logger.Info(i18n.T("info.title"))
+
+			// Architecture information
+			arch, err := app.ArchDetector.GetHostArchitecture()
+			if err != nil {
+				app.Logger.Error(i18n.T("info.arch_failed", err))
+			} else {
+				cmdPrintln(cmd, i18n.T("info.host_arch", arch))
+			}
 
-# Test registry image
-claude-reactor debug image ghcr.io/user/project:latest`,
-			Args: cobra.ExactArgs(1),
-			RunE: func(cmd *cobra.Command, args []string) error {
-				// Handle help case when app is nil
-				if app == nil {
-					return cmd.Help()
-				}
-				imageName := args[0]
-				ctx := cmd.Context()
+			platform, err := app.ArchDetector.GetDockerPlatform()
+			if err != nil {
+				app.Logger.Error(i18n.T("info.platform_failed", err))
+			} else {
+				cmdPrintln(cmd, i18n.T("info.docker_platform", platform))
+			}
 
-				// Ensure Docker components are initialized
-				if err := reactor.EnsureDockerComponents(app); err != nil {
-					cmd.Printf("❌ Docker not available: %v\n", err)
-					return err
-				}
+			cmdPrintln(cmd, i18n.T("info.multi_arch", app.ArchDetector.IsMultiArchSupported()))
 
-				app.Logger.Infof("🔍 Testing image compatibility: %s", imageName)
+			// Project detection with per-language confidence
+			if detection, err := app.ConfigMgr.DetectProject(""); err != nil {
+				app.Logger.Error(i18n.T("info.detect_failed", err))
+			} else {
+				printProjectDetection(cmd, detection)
+			}
 
-				// Test image validation
-				result, err := app.ImageValidator.ValidateImage(ctx, imageName, true)
-				if err != nil {
-					cmd.Printf("❌ Validation failed: %v\n", err)
-					return err
+			// Version information
+			cmdPrintln(cmd, i18n.T("info.version", debugVersion))
+			cmdPrintln(cmd, i18n.T("info.git_commit", debugGitCommit))
+			cmdPrintln(cmd, i18n.T("info.build_date", debugBuildDate))
+
+			// Docker connectivity test - try to initialize Docker
+			ctx := cmd.Context()
+			if err := reactor.EnsureDockerComponents(app); err != nil {
+				cmdPrintln(cmd, i18n.T("info.docker_failed", err))
+			} else {
+				_, dockerErr := app.DockerMgr.IsContainerRunning(ctx, "test-connection")
+				if dockerErr != nil {
+					cmdPrintln(cmd, i18n.T("info.docker_failed", dockerErr))
+				} else {
+					cmdPrintln(cmd, i18n.T("info.docker_connected"))
 				}
+			}
 
-				cmd.Printf("\n=== Image Validation Results ===\n")
-				cmd.Printf("Image: %s\n", imageName)
-				cmd.Printf("Digest: %s\n", result.Digest)
-				cmd.Printf("Architecture: %s\n", result.Architecture)
-				cmd.Printf("Platform: %s\n", result.Platform)
-				cmd.Printf("Size: %.2f MB\n", float64(result.Size)/(1024*1024))
-				cmd.Printf("Compatible: %t\n", result.Compatible)
-				cmd.Printf("Has Claude CLI: %t\n", result.HasClaude)
-				cmd.Printf("Is Linux: %t\n", result.IsLinux)
-
-				if len(result.Warnings) > 0 {
-					cmd.Printf("\n⚠️ Warnings:\n")
-					for _, warning := range result.Warnings {
-						cmd.Printf("  - %s\n", warning)
-					}
-				}
+			if showTiming, _ := cmd.Flags().GetBool("timing"); showTiming {
+				printStartupTiming(cmd)
+			}
 
-				if len(result.Errors) > 0 {
-					cmd.Printf("\n❌ Errors:\n")
-					for _, errMsg := range result.Errors {
-						cmd.Printf("  - %s\n", errMsg)
-					}
-				}
+			return nil
+		},
+	}
+	systemInfoCmd.Flags().Bool("timing", false, "Show how long each startup phase took")
 
-				// Show package analysis if available
-				if packages, ok := result.Metadata["packages"].(map[string]interface{}); ok {
-					cmd.Printf("\n📦 Package Analysis:\n")
-					if available, ok := packages["available"].([]string); ok {
-						cmd.Printf("Available tools (%d): %s\n", len(available), strings.Join(available, ", "))
-					}
-					if missing, ok := packages["missing_high_priority"].([]string); ok && len(missing) > 0 {
-						cmd.Printf("Missing high-priority tools: %s\n", strings.Join(missing, ", "))
-					}
-					if totalChecked, ok := packages["total_checked"].(int); ok {
-						if totalAvailable, ok := packages["total_available"].(int); ok {
-							cmd.Printf("Coverage: %d/%d recommended tools available\n", totalAvailable, totalChecked)
-						}
-					}
+	infoCmd.AddCommand(
+		&cobra.Command{
+			Use:   "status",
+			Short: "Show debug status",
+			Long:  "Display current debug mode and logging configuration.",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				// Handle help case when app is nil
+				if app == nil {
+					return cmd.Help()
 				}
+				cmdPrintln(cmd, i18n.T("info.debug_mode", app.Debug))
 
-				if result.Compatible {
-					cmd.Printf("\n✅ Image is compatible with claude-reactor!\n")
+				// Try to get log level through interface or fallback
+				if logger, ok := app.Logger.(interface{ GetLevel() logrus.Level }); ok {
+					cmdPrintln(cmd, i18n.T("info.log_level", logger.GetLevel().String()))
 				} else {
-					cmd.Printf("\n❌ Image is not compatible. See errors above.\n")
+					cmdPrintln(cmd, i18n.T("info.log_level_unknown"))
 				}
-
 				return nil
 			},
 		},
+		systemInfoCmd,
+		imageCmd,
+		newCompareImagesCmd(app),
 	)
 
 	// Create cache subcommand
 	cacheCmd := &cobra.Command{
 		Use:   "cache",
-		Short: "Show validation cache statistics",
-		Long:  "Display information about cached image validation results.",
+		Short: "Show, export or import validation cache results",
+		Long: `Display information about cached image validation results.
+
+Validation results are keyed by image digest and trusted for image_cache_ttl (default 720h).
+Once image_cache_size results (default 200) are stored, the oldest are evicted. Image name
+lookups are remembered for a few minutes so repeated runs skip redundant daemon calls;
+'claude-reactor run --revalidate' ignores both.
+
+To share results with teammates, 'export' writes them to FILE (stdout when FILE is - or
+omitted) and 'import' adds those in FILE (stdin for -), skipping results older than
+image_cache_ttl or than the cached result for the same image. Results are keyed by image ID,
+so they only apply to identical images. With image_cache_url set, results missing locally are
+looked up in a cache shared with the team, and new results are published to it.`,
+		Example: `claude-reactor info cache stats
+claude-reactor info cache export reactor-cache.json
+claude-reactor info cache import reactor-cache.json`,
+		// 'info cache stats' reads naturally, so accept it as a synonym
+		ValidArgs: []string{"stats", "export", "import"},
+		Args:      cacheArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Handle help case when app is nil
 			if app == nil {
 				return cmd.Help()
 			}
-
-			// Start checking cache info
-			// For now, just show a placeholder as per previous implementation
-			// In a real implementation, we would query the ImageValidator for stats
-			cmd.Printf("Cache directory: ~/.claude-reactor/image-cache/\n")
-			cmd.Printf("Cache duration: 30+ days (based on image digest)\n")
-			cmd.Printf("To clear cache, use: claude-reactor clean --cache\n")
-			return nil
+			switch {
+			case len(args) > 0 && args[0] == "export":
+				path := "-"
+				if len(args) > 1 {
+					path = args[1]
+				}
+				return exportImageCache(cmd, app, path)
+			case len(args) > 0 && args[0] == "import":
+				return importImageCache(cmd, app, args[1])
+			}
+			return showCacheStats(cmd, app)
 		},
 	}
 
@@ -227,3 +299,177 @@ claude-reactor debug image ghcr.io/user/project:latest`,
 
 	return infoCmd
 }
+
+// cacheArgs accepts 'info cache', 'info cache stats', 'info cache export [FILE]' and
+// 'info cache import FILE'
+func cacheArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+	switch args[0] {
+	case "stats":
+		return cobra.MaximumNArgs(1)(cmd, args)
+	case "export":
+		return cobra.MaximumNArgs(2)(cmd, args)
+	case "import":
+		if len(args) != 2 {
+			return fmt.Errorf("import takes the FILE to import, or - for stdin")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid argument %q for %q", args[0], cmd.CommandPath())
+	}
+}
+
+// prepareImageCache initializes the image validator with the configured cache policy
+func prepareImageCache(app *pkg.AppContainer) error {
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	if config, err := app.ConfigMgr.LoadConfig(); err == nil {
+		configureImageCache(app, config, false)
+		configureRegistryMirror(app, config)
+	}
+	return nil
+}
+
+// showCacheStats displays the image validation cache and its policy
+func showCacheStats(cmd *cobra.Command, app *pkg.AppContainer) error {
+	if err := prepareImageCache(app); err != nil {
+		return err
+	}
+
+	stats, err := app.ImageValidator.CacheStats()
+	if err != nil {
+		return fmt.Errorf("failed to read validation cache: %w", err)
+	}
+
+	cmdPrintln(cmd, i18n.T("cache.dir", stats.Dir))
+	cmdPrintln(cmd, i18n.T("cache.results", stats.Entries, stats.MaxEntries, float64(stats.Bytes)/1024))
+	if stats.Entries > 0 {
+		cmdPrintln(cmd, i18n.T("cache.oldest", stats.Oldest.Format(time.RFC3339)))
+		cmdPrintln(cmd, i18n.T("cache.newest", stats.Newest.Format(time.RFC3339)))
+	}
+	cmdPrintln(cmd, i18n.T("cache.ttl", stats.TTL))
+	cmdPrintln(cmd, i18n.T("cache.images", stats.Images, stats.ExistenceTTL))
+	cmdPrintln(cmd, i18n.T("cache.clear_hint"))
+	return nil
+}
+
+// exportImageCache writes the cached validation results to path, or stdout for -
+func exportImageCache(cmd *cobra.Command, app *pkg.AppContainer, path string) error {
+	if err := prepareImageCache(app); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		defer file.Close()
+		out = file
+	}
+	count, err := app.ImageValidator.ExportCache(out)
+	if err != nil {
+		return fmt.Errorf("failed to export validation cache: %w", err)
+	}
+	if path != "-" {
+		cmdPrintln(cmd, i18n.T("cache.exported", count, path))
+	}
+	return nil
+}
+
+// importImageCache adds the validation results in path, or stdin for -, to the cache
+func importImageCache(cmd *cobra.Command, app *pkg.AppContainer, path string) error {
+	if err := prepareImageCache(app); err != nil {
+		return err
+	}
+
+	in := cmd.InOrStdin()
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer file.Close()
+		in = file
+	}
+	count, err := app.ImageValidator.ImportCache(in)
+	if err != nil {
+		return fmt.Errorf("failed to import validation cache: %w", err)
+	}
+	cmdPrintln(cmd, i18n.T("cache.imported", count))
+	return nil
+}
+
+// printStartupTiming shows how long this process spent in each startup phase. Commands that
+// don't talk to Docker should reach dispatch in well under 50ms.
+func printStartupTiming(cmd *cobra.Command) {
+	cmdPrintln(cmd)
+	cmdPrintln(cmd, i18n.T("info.timing"))
+	for _, phase := range timing.Phases() {
+		suffix := ""
+		if phase.OnDemand {
+			suffix = i18n.T("info.on_demand")
+		}
+		cmdPrintf(cmd, "  %-16s %s%s\n", phase.Name, formatPhase(phase.Duration), suffix)
+	}
+	cmdPrintf(cmd, "  %-16s %s\n", i18n.T("info.startup_total"), formatPhase(timing.Startup()))
+}
+
+// formatPhase renders a duration in milliseconds
+func formatPhase(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
+
+// printImageScan runs a vulnerability scan and displays findings by severity
+func printImageScan(cmd *cobra.Command, app *pkg.AppContainer, imageName string) {
+	scan, err := app.ImageValidator.ScanImage(cmd.Context(), imageName)
+	if err != nil {
+		cmdPrintln(cmd)
+		cmdPrintln(cmd, i18n.T("info.scan_unavailable", err))
+		return
+	}
+
+	cmdPrintln(cmd)
+	cmdPrintln(cmd, i18n.T("info.scan", scan.Scanner))
+	for _, severity := range []string{"critical", "high", "medium", "low", "negligible"} {
+		if count := scan.Counts[severity]; count > 0 {
+			cmdPrintf(cmd, "  %s: %d\n", severity, count)
+		}
+	}
+	if len(scan.Counts) == 0 {
+		cmdPrintln(cmd, i18n.T("info.scan_clean"))
+	}
+}
+
+// printProjectDetection displays the detected project stack and confidence scores
+func printProjectDetection(cmd *cobra.Command, detection *pkg.ProjectDetectionResult) {
+	if detection.ProjectType == "unknown" {
+		cmdPrintln(cmd, i18n.T("info.detection_none", detection.Variant))
+		return
+	}
+
+	cmdPrintln(cmd, i18n.T("info.detection", detection.ProjectType, detection.Confidence, detection.Variant))
+
+	scores := make([]string, 0, len(detection.Languages))
+	for _, language := range detection.Languages {
+		scores = append(scores, fmt.Sprintf("%s (%s)", language, detection.Metadata["score."+language]))
+	}
+	cmdPrintln(cmd, i18n.T("info.languages", strings.Join(scores, ", ")))
+
+	if len(detection.Frameworks) > 0 {
+		cmdPrintln(cmd, i18n.T("info.frameworks", strings.Join(detection.Frameworks, ", ")))
+	}
+	if len(detection.Tools) > 0 {
+		cmdPrintln(cmd, i18n.T("info.tools", strings.Join(detection.Tools, ", ")))
+	}
+	if missing := detection.Metadata["missing_toolchains"]; missing != "" {
+		cmdPrintln(cmd, i18n.T("info.missing_toolchains", missing))
+	}
+	if uncovered := detection.Metadata["uncovered_variants"]; uncovered != "" {
+		cmdPrintln(cmd, i18n.T("info.uncovered_variants", uncovered, detection.Variant))
+	}
+}
@@ -1,10 +1,21 @@
 package commands
 
 import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
 )
 
 func TestSetVersionInfo(t *testing.T) {
@@ -58,6 +69,18 @@ func TestInfoSubcommands(t *testing.T) {
 	})
 }
 
+func TestInfoTimingFlag(t *testing.T) {
+	cmd := NewInfoCmd(createMockApp())
+	systemInfo, _, err := cmd.Find([]string{"info"})
+	assert.NoError(t, err)
+	assert.NotNil(t, systemInfo.Flags().Lookup("timing"))
+}
+
+func TestFormatPhase(t *testing.T) {
+	assert.Equal(t, "1.50ms", formatPhase(1500*time.Microsecond))
+	assert.Equal(t, "0.00ms", formatPhase(0))
+}
+
 func TestInfoStatusSubcommand(t *testing.T) {
 	t.Run("info status with nil app shows help", func(t *testing.T) {
 		app := createMockApp()
@@ -134,3 +157,61 @@ func TestDebugCommandStructure(t *testing.T) {
 		assert.Equal(t, "info", cmd.Use)
 	})
 }
+
+func TestInfoCacheExportImport(t *testing.T) {
+	newCmd := func() (*cobra.Command, *mocks.MockImageValidator, *bytes.Buffer) {
+		validator := &mocks.MockImageValidator{}
+		configMgr := &mocks.MockConfigManager{}
+		configMgr.On("LoadConfig").Return((*pkg.Config)(nil), errors.New("no config"))
+		app := createMockApp()
+		app.ConfigMgr = configMgr
+		app.DockerMgr = &mocks.MockDockerManager{}
+		app.ImageValidator = validator
+
+		var out bytes.Buffer
+		cmd := NewInfoCmd(app)
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		return cmd, validator, &out
+	}
+	path := filepath.Join(t.TempDir(), "reactor-cache.json")
+
+	t.Run("export to a file", func(t *testing.T) {
+		cmd, validator, out := newCmd()
+		validator.On("ExportCache", mock.Anything).Return(3, nil).Run(func(args mock.Arguments) {
+			args.Get(0).(io.Writer).Write([]byte("{}"))
+		})
+		cmd.SetArgs([]string{"cache", "export", path})
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, out.String(), "Exported 3 validation results to "+path)
+		assert.FileExists(t, path)
+	})
+
+	t.Run("import from a file", func(t *testing.T) {
+		cmd, validator, out := newCmd()
+		validator.On("ImportCache", mock.AnythingOfType("*os.File")).Return(2, nil)
+		cmd.SetArgs([]string{"cache", "import", path})
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, out.String(), "Imported 2 validation results")
+	})
+
+	t.Run("import needs a file", func(t *testing.T) {
+		cmd, _, _ := newCmd()
+		cmd.SetArgs([]string{"cache", "import"})
+		assert.ErrorContains(t, cmd.Execute(), "import takes the FILE")
+	})
+
+	t.Run("unknown actions are rejected", func(t *testing.T) {
+		cmd, _, _ := newCmd()
+		cmd.SetArgs([]string{"cache", "purge"})
+		assert.Error(t, cmd.Execute())
+	})
+
+	t.Run("missing files are reported", func(t *testing.T) {
+		cmd, _, _ := newCmd()
+		cmd.SetArgs([]string{"cache", "import", filepath.Join(t.TempDir(), "missing.json")})
+		err := cmd.Execute()
+		assert.ErrorContains(t, err, "failed to open")
+		assert.True(t, errors.Is(err, os.ErrNotExist))
+	})
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/docker"
+	"claude-reactor/pkg"
+)
+
+// nameResolution returns the container's DNS servers, search domains and /etc/hosts entries:
+// the project config's, followed by those from the --dns, --dns-search and --add-host flags
+func nameResolution(cmd *cobra.Command, config *pkg.Config) (dns, search, hosts []string, err error) {
+	dnsFlags, _ := cmd.Flags().GetStringSlice("dns")
+	searchFlags, _ := cmd.Flags().GetStringSlice("dns-search")
+	hostFlags, _ := cmd.Flags().GetStringSlice("add-host")
+
+	for _, server := range append(configList(config.DNS), dnsFlags...) {
+		if err := docker.ValidateDNSServer(server); err != nil {
+			return nil, nil, nil, err
+		}
+		if !slices.Contains(dns, server) {
+			dns = append(dns, server)
+		}
+	}
+	for _, domain := range append(configList(config.DNSSearch), searchFlags...) {
+		if err := docker.ValidateDNSSearch(domain); err != nil {
+			return nil, nil, nil, err
+		}
+		if !slices.Contains(search, domain) {
+			search = append(search, domain)
+		}
+	}
+	for _, spec := range append(configList(config.AddHosts), hostFlags...) {
+		host, err := docker.ParseHostSpec(spec)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return dns, search, hosts, nil
+}
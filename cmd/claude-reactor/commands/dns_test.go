@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func TestNameResolution(t *testing.T) {
+	cmd := NewRunCmd(createMockApp())
+	require.NoError(t, cmd.Flags().Set("dns", "10.0.0.3,10.0.0.2"))
+	require.NoError(t, cmd.Flags().Set("dns-search", "dev.example.com"))
+	require.NoError(t, cmd.Flags().Set("add-host", "registry=10.1.2.4"))
+	config := &pkg.Config{DNS: "10.0.0.2", DNSSearch: "corp.example.com", AddHosts: "git.corp:10.1.2.3"}
+
+	dns, search, hosts, err := nameResolution(cmd, config)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2", "10.0.0.3"}, dns, "config first, without duplicates")
+	assert.Equal(t, []string{"corp.example.com", "dev.example.com"}, search)
+	assert.Equal(t, []string{"git.corp:10.1.2.3", "registry:10.1.2.4"}, hosts)
+
+	// Commands without the flags use the config alone
+	dns, _, _, err = nameResolution(NewPromptCmd(nil), config)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2"}, dns)
+
+	_, _, _, err = nameResolution(NewRunCmd(nil), &pkg.Config{AddHosts: "git.corp"})
+	assert.Error(t, err)
+}
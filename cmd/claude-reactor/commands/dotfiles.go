@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"claude-reactor/internal/reactor/dotfiles"
+	"claude-reactor/pkg"
+)
+
+// mountDotfiles mounts local dotfiles read-only where they are applied from. Remote dotfiles
+// are cloned there once the container starts instead.
+func mountDotfiles(app *pkg.AppContainer, containerConfig *pkg.ContainerConfig, config *pkg.Config) error {
+	if config.Dotfiles == "" || dotfiles.IsRemote(config.Dotfiles) {
+		return nil
+	}
+	if err := app.MountMgr.AddMountToConfig(containerConfig, config.Dotfiles, dotfiles.Target); err != nil {
+		return fmt.Errorf("failed to mount dotfiles: %w", err)
+	}
+	for i := range containerConfig.Mounts {
+		if containerConfig.Mounts[i].Target == dotfiles.Target {
+			containerConfig.Mounts[i].ReadOnly = true
+		}
+	}
+	return nil
+}
+
+// applyDotfiles applies the project's dotfiles in the container unless they already are. A
+// failure only warns: the session works without them, and they are tried again next start.
+func applyDotfiles(ctx context.Context, app *pkg.AppContainer, containerName string, config *pkg.Config) {
+	if config.Dotfiles == "" {
+		return
+	}
+	if _, exitCode, err := app.DockerMgr.ExecCommand(ctx, containerName, dotfiles.AppliedCommand(config.Dotfiles, config.DotfilesInstall)); err == nil && exitCode == 0 {
+		app.Logger.Debugf("Dotfiles already applied")
+		return
+	}
+
+	app.Logger.Infof("🏠 Applying dotfiles from %s...", config.Dotfiles)
+	output, exitCode, err := app.DockerMgr.ExecCommand(ctx, containerName, dotfiles.ApplyCommand(config.Dotfiles, config.DotfilesInstall))
+	if err != nil {
+		app.Logger.Warnf("⚠️  Failed to apply dotfiles: %v", err)
+		return
+	}
+	if exitCode != 0 {
+		app.Logger.Warnf("⚠️  Applying dotfiles failed (exit %d): %s", exitCode, strings.TrimSpace(output))
+		return
+	}
+	app.Logger.Info("✅ Dotfiles applied")
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"claude-reactor/internal/reactor/dotfiles"
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func TestApplyDotfiles(t *testing.T) {
+	const containerName = "claude-reactor-base-amd64-1a2b3c4d-default"
+	config := &pkg.Config{Dotfiles: "https://github.com/me/dotfiles", DotfilesInstall: "make install"}
+	applied := dotfiles.AppliedCommand(config.Dotfiles, config.DotfilesInstall)
+	apply := dotfiles.ApplyCommand(config.Dotfiles, config.DotfilesInstall)
+
+	t.Run("applied once", func(t *testing.T) {
+		dockerMgr := &mocks.MockDockerManager{}
+		dockerMgr.On("ExecCommand", mock.Anything, containerName, applied).Return("", 1, nil)
+		dockerMgr.On("ExecCommand", mock.Anything, containerName, apply).Return("", 0, nil)
+		app := createMockApp()
+		app.DockerMgr = dockerMgr
+
+		applyDotfiles(context.Background(), app, containerName, config)
+		dockerMgr.AssertCalled(t, "ExecCommand", mock.Anything, containerName, apply)
+	})
+
+	t.Run("already applied", func(t *testing.T) {
+		dockerMgr := &mocks.MockDockerManager{}
+		dockerMgr.On("ExecCommand", mock.Anything, containerName, applied).Return("", 0, nil)
+		app := createMockApp()
+		app.DockerMgr = dockerMgr
+
+		applyDotfiles(context.Background(), app, containerName, config)
+		dockerMgr.AssertNotCalled(t, "ExecCommand", mock.Anything, containerName, apply)
+	})
+
+	t.Run("failure warns", func(t *testing.T) {
+		dockerMgr := &mocks.MockDockerManager{}
+		dockerMgr.On("ExecCommand", mock.Anything, containerName, applied).Return("", 1, nil)
+		dockerMgr.On("ExecCommand", mock.Anything, containerName, apply).Return("fatal: repository not found\n", 128, nil)
+		logger := &captureLogger{}
+		app := createMockApp()
+		app.DockerMgr = dockerMgr
+		app.Logger = logger
+
+		applyDotfiles(context.Background(), app, containerName, config)
+		assert.Contains(t, logger.messages, "⚠️  Applying dotfiles failed (exit %d): %s")
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		dockerMgr := &mocks.MockDockerManager{}
+		app := createMockApp()
+		app.DockerMgr = dockerMgr
+
+		applyDotfiles(context.Background(), app, containerName, &pkg.Config{})
+		dockerMgr.AssertNotCalled(t, "ExecCommand", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// DiskUsageItem is one variant, project session, or other entry within a category
+type DiskUsageItem struct {
+	Name        string `json:"name"`
+	Account     string `json:"account,omitempty"`
+	ProjectPath string `json:"project_path,omitempty"`
+	Count       int    `json:"count,omitempty"`
+	Size        int64  `json:"size"`
+}
+
+// DiskUsageCategory is the space used by one kind of claude-reactor data
+type DiskUsageCategory struct {
+	Name  string          `json:"name"`
+	Path  string          `json:"path,omitempty"`
+	Count int             `json:"count"`
+	Size  int64           `json:"size"`
+	Items []DiskUsageItem `json:"items,omitempty"`
+}
+
+// DiskUsageReport is the output of the du command
+type DiskUsageReport struct {
+	Categories []DiskUsageCategory `json:"categories"`
+	Total      int64               `json:"total"`
+}
+
+// NewDuCmd creates the du command for summarizing disk space used by claude-reactor
+func NewDuCmd(app *pkg.AppContainer) *cobra.Command {
+	var duCmd = &cobra.Command{
+		Use:   "du",
+		Short: "Show disk space used by images, sessions, and caches",
+		Long: `Summarize the disk space claude-reactor uses:
+
+  images             Docker images, per variant
+  containers         Container writable layers and file sync volumes
+  sessions           Conversation history in ~/.claude-reactor, per account and project
+  validation-cache   Cached image validation results
+  other              Everything else in ~/.claude-reactor (credentials, locks, variants)
+
+Image sizes include layers shared with other images, so the image total can exceed the
+space actually used. Docker usage is skipped when Docker is not available.
+
+Use 'clean report' and 'clean --dry-run' to see what can be removed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return runDu(cmd, app)
+		},
+	}
+
+	duCmd.Flags().String("sort", "size", "Sort entries by 'size' or 'name'")
+	duCmd.Flags().BoolP("json", "j", false, "Output in JSON format for scripting")
+
+	return duCmd
+}
+
+// runDu gathers disk usage and prints it
+func runDu(cmd *cobra.Command, app *pkg.AppContainer) error {
+	sortBy, _ := cmd.Flags().GetString("sort")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if sortBy != "size" && sortBy != "name" {
+		return fmt.Errorf("invalid --sort value %q: use 'size' or 'name'", sortBy)
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+	claudeReactorDir := filepath.Join(homeDir, ".claude-reactor")
+
+	resources, err := collectResources(cmd.Context(), app, map[string]bool{
+		pkg.ResourceContainer: true,
+		pkg.ResourceVolume:    true,
+		pkg.ResourceImage:     true,
+	})
+	if err != nil {
+		app.Logger.Warnf("Skipping Docker images and containers: %v", err)
+		resources = nil
+	}
+	sessions, err := scanSessionResources(claudeReactorDir)
+	if err != nil {
+		return err
+	}
+
+	report := buildDiskUsage(append(resources, sessions...), claudeReactorDir, sortBy)
+	if jsonOutput {
+		return outputJSON(report)
+	}
+	outputDiskUsage(report)
+	return nil
+}
+
+// buildDiskUsage groups resources and ~/.claude-reactor contents into categories
+func buildDiskUsage(resources []pkg.Resource, claudeReactorDir, sortBy string) DiskUsageReport {
+	images := DiskUsageCategory{Name: "images"}
+	containers := DiskUsageCategory{Name: "containers"}
+	sessions := DiskUsageCategory{Name: "sessions", Path: claudeReactorDir}
+
+	variants := make(map[string]*DiskUsageItem)
+	for _, resource := range resources {
+		size := max(resource.Size, 0)
+		switch resource.Kind {
+		case pkg.ResourceImage:
+			variant := imageVariant(resource.Name)
+			item, ok := variants[variant]
+			if !ok {
+				item = &DiskUsageItem{Name: variant}
+				variants[variant] = item
+			}
+			item.Count++
+			item.Size += size
+			images.Count++
+			images.Size += size
+		case pkg.ResourceContainer, pkg.ResourceVolume:
+			containers.Items = append(containers.Items, DiskUsageItem{Name: resource.Name, Account: resource.Account, ProjectPath: resource.ProjectPath, Size: size})
+			containers.Count++
+			containers.Size += size
+		case pkg.ResourceSession:
+			sessions.Items = append(sessions.Items, DiskUsageItem{Name: resource.Name, Account: resource.Account, ProjectPath: resource.ProjectPath, Size: size})
+			sessions.Count++
+			sessions.Size += size
+		}
+	}
+	for _, item := range variants {
+		images.Items = append(images.Items, *item)
+	}
+
+	cacheDir := filepath.Join(claudeReactorDir, "image-cache")
+	cache := DiskUsageCategory{Name: "validation-cache", Path: cacheDir, Size: dirSize(cacheDir)}
+	if entries, err := os.ReadDir(cacheDir); err == nil {
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), ".json") && !strings.HasSuffix(entry.Name(), ".scan.json") {
+				cache.Count++
+			}
+		}
+	}
+
+	// Whatever is left in ~/.claude-reactor: credentials, locks, variant definitions
+	other := DiskUsageCategory{Name: "other", Path: claudeReactorDir}
+	other.Size = max(dirSize(claudeReactorDir)-sessions.Size-cache.Size, 0)
+
+	report := DiskUsageReport{Categories: []DiskUsageCategory{images, containers, sessions, cache, other}}
+	for i := range report.Categories {
+		sortDiskUsageItems(report.Categories[i].Items, sortBy)
+		report.Total += report.Categories[i].Size
+	}
+	return report
+}
+
+// imageVariant returns the variant an image was built or pulled for, e.g. "go" for
+// claude-reactor-go-arm64:latest or ghcr.io/dyluth/claude-reactor/go:v1
+func imageVariant(imageName string) string {
+	name := imageName
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	if i := strings.Index(name, "/claude-reactor/"); i >= 0 {
+		return name[i+len("/claude-reactor/"):]
+	}
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimPrefix(name, "claude-reactor-")
+	for _, arch := range []string{"-amd64", "-arm64"} {
+		name = strings.TrimSuffix(name, arch)
+	}
+	return name
+}
+
+// sortDiskUsageItems orders items largest first, or alphabetically
+func sortDiskUsageItems(items []DiskUsageItem, sortBy string) {
+	sort.Slice(items, func(i, j int) bool {
+		if sortBy == "name" || items[i].Size == items[j].Size {
+			return items[i].Name < items[j].Name
+		}
+		return items[i].Size > items[j].Size
+	})
+}
+
+// outputDiskUsage prints the report as an indented summary
+func outputDiskUsage(report DiskUsageReport) {
+	for _, category := range report.Categories {
+		summary := formatSize(category.Size)
+		switch category.Name {
+		case "images", "containers", "sessions":
+			summary += fmt.Sprintf(" (%d)", category.Count)
+		case "validation-cache":
+			summary += fmt.Sprintf(" (%d entries)", category.Count)
+		}
+		fmt.Fprintf(i18n.Stdout, "%-20s %s\n", strings.ToUpper(category.Name), summary)
+
+		for _, item := range category.Items {
+			detail := item.ProjectPath
+			if item.Count == 1 {
+				detail = "1 image"
+			} else if item.Count > 1 {
+				detail = fmt.Sprintf("%d images", item.Count)
+			}
+			fmt.Fprintf(i18n.Stdout, "  %-48s %10s  %s\n", truncate(item.Name, 48), formatSize(item.Size), detail)
+		}
+	}
+	fmt.Fprintf(i18n.Stdout, "\n%-20s %s\n", "TOTAL", formatSize(report.Total))
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func TestNewDuCmd(t *testing.T) {
+	cmd := NewDuCmd(createMockApp())
+	assert.Equal(t, "du", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("json"))
+	assert.Equal(t, "size", cmd.Flags().Lookup("sort").DefValue)
+
+	t.Run("invalid sort is rejected", func(t *testing.T) {
+		cmd := NewDuCmd(createMockApp())
+		require.NoError(t, cmd.ParseFlags([]string{"--sort", "age"}))
+		err := runDu(cmd, createMockApp())
+		assert.ErrorContains(t, err, "invalid --sort")
+	})
+}
+
+func TestImageVariant(t *testing.T) {
+	assert.Equal(t, "go", imageVariant("claude-reactor-go-arm64:latest"))
+	assert.Equal(t, "full", imageVariant("claude-reactor-full-amd64"))
+	assert.Equal(t, "cloud", imageVariant("ghcr.io/dyluth/claude-reactor/cloud:v1.2"))
+	assert.Equal(t, "my-variant", imageVariant("localhost:5000/claude-reactor-my-variant-amd64:dev"))
+}
+
+func TestBuildDiskUsage(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "image-cache"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "image-cache", "sha256:a.json"), make([]byte, 100), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "image-cache", "sha256:a.scan.json"), make([]byte, 20), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".default-claude.json"), make([]byte, 30), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "default", "app-1a2b3c4d"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "default", "app-1a2b3c4d", "history"), make([]byte, 500), 0644))
+
+	report := buildDiskUsage([]pkg.Resource{
+		{Kind: pkg.ResourceImage, Name: "claude-reactor-go-amd64:latest", Size: 1000},
+		{Kind: pkg.ResourceImage, Name: "claude-reactor-go-arm64:latest", Size: 1200},
+		{Kind: pkg.ResourceImage, Name: "claude-reactor-base-amd64:latest", Size: 3000},
+		{Kind: pkg.ResourceVolume, Name: "claude-reactor-go-amd64-1a2b3c4d-default-sync", Size: -1},
+		{Kind: pkg.ResourceSession, Name: "default/app-1a2b3c4d", Size: 500},
+	}, dir, "size")
+
+	categories := make(map[string]DiskUsageCategory)
+	for _, category := range report.Categories {
+		categories[category.Name] = category
+	}
+
+	images := categories["images"]
+	assert.Equal(t, 3, images.Count)
+	assert.Equal(t, int64(5200), images.Size)
+	require.Len(t, images.Items, 2)
+	assert.Equal(t, "base", images.Items[0].Name, "largest variant first")
+	assert.Equal(t, 2, images.Items[1].Count)
+
+	assert.Equal(t, 1, categories["containers"].Count)
+	assert.Equal(t, int64(500), categories["sessions"].Size)
+	assert.Equal(t, 1, categories["validation-cache"].Count)
+	assert.Equal(t, int64(120), categories["validation-cache"].Size)
+	assert.Equal(t, int64(30), categories["other"].Size)
+	assert.Equal(t, int64(5200+500+120+30), report.Total)
+
+	byName := buildDiskUsage([]pkg.Resource{
+		{Kind: pkg.ResourceImage, Name: "claude-reactor-go-amd64", Size: 1000},
+		{Kind: pkg.ResourceImage, Name: "claude-reactor-base-amd64", Size: 1},
+	}, dir, "name")
+	assert.Equal(t, "base", byName.Categories[0].Items[0].Name)
+}
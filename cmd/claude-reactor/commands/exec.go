@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/history"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// NewExecCmd creates the exec command, which runs a command in the running project container
+// and records it so it can be repeated
+func NewExecCmd(app *pkg.AppContainer) *cobra.Command {
+	var execCmd = &cobra.Command{
+		Use:   "exec [flags] COMMAND [ARGS...]",
+		Short: "Run a command in the project container",
+		Args:  cobra.ArbitraryArgs,
+		Long: `Run a command in the running project container and stream its output.
+
+The command runs in the directory you are in, and its exit code becomes the
+exit code of claude-reactor. Each command is recorded with when it ran and how
+it exited, so common commands such as tests and linters are quick to repeat
+with --last, or to look up with 'claude-reactor exec history'.
+
+Start the container first with 'claude-reactor run'. To run a command that is
+itself called history, put it after --.`,
+		Example: `# Run the tests in the container
+claude-reactor exec go test ./...
+
+# Run them again
+claude-reactor exec --last
+
+# See what has been run in this project
+claude-reactor exec history`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return runExec(cmd, app, args)
+		},
+	}
+
+	execCmd.Flags().BoolP("last", "l", false, "Run the project's most recent command again")
+	// Flags after the command belong to it, e.g. 'exec go test -v'
+	execCmd.Flags().SetInterspersed(false)
+
+	execCmd.AddCommand(newExecHistoryCmd(app))
+
+	return execCmd
+}
+
+func newExecHistoryCmd(app *pkg.AppContainer) *cobra.Command {
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the commands run in the project container",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			limit, _ := cmd.Flags().GetInt("limit")
+			return listExecHistory(app, limit)
+		},
+	}
+	historyCmd.Flags().IntP("limit", "n", 20, "Number of recent commands to show (0 for all)")
+	return historyCmd
+}
+
+func runExec(cmd *cobra.Command, app *pkg.AppContainer, args []string) error {
+	last, _ := cmd.Flags().GetBool("last")
+	if last && len(args) > 0 {
+		return fmt.Errorf("--last runs the previous command again and takes no command")
+	}
+	if !last && len(args) == 0 {
+		return pkg.NewError(pkg.CodeInvalidUsage, nil, "no command given").
+			WithRemediation("Run one with: claude-reactor exec go test ./...")
+	}
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	subdir, err := enterProjectRoot(app, true)
+	if err != nil {
+		return err
+	}
+	containerName, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+
+	entry := history.Entry{Command: args, Workdir: subdir}
+	if last {
+		previous, err := history.Last(app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath))
+		if err != nil {
+			return err
+		}
+		entry = history.Entry{Command: previous.Command, Workdir: previous.Workdir, Task: previous.Task}
+		app.Logger.Infof("↩️  Running again: %s", strings.Join(entry.Command, " "))
+	}
+
+	return execInProject(cmd, app, containerName, config, entry)
+}
+
+// execInProject runs entry's command in the running project container, from its directory in
+// the project, records it in the exec history, and returns a non-zero exit code as a
+// pkg.ExitError
+func execInProject(cmd *cobra.Command, app *pkg.AppContainer, containerName string, config *pkg.Config, entry history.Entry) error {
+	ctx := context.Background()
+	running, err := app.DockerMgr.IsContainerRunning(ctx, containerName)
+	if err != nil || !running {
+		return pkg.NewError(pkg.CodeContainerNotRunning, nil, "container %s is not running", containerName)
+	}
+	if entry.Workdir != "" {
+		app.DockerMgr.SetWorkingDir(containerWorkdir(config.ProjectPath, entry.Workdir))
+	}
+	if err := injectSecrets(app, config); err != nil {
+		return err
+	}
+
+	entry.Started = time.Now()
+	runErr := app.DockerMgr.AttachToContainer(ctx, containerName, entry.Command, false)
+	var exitErr *pkg.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return fmt.Errorf("failed to run command: %w", runErr)
+	}
+
+	entry.Duration = time.Since(entry.Started)
+	if exitErr != nil {
+		entry.ExitCode = exitErr.Code
+	}
+	sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath)
+	if err := history.Record(sessionDir, entry); err != nil {
+		app.Logger.Warnf("Failed to record command in exec history: %v", err)
+	}
+
+	if exitErr != nil {
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return exitErr
+	}
+	return nil
+}
+
+// listExecHistory prints the project's most recent commands, oldest first like a shell history
+func listExecHistory(app *pkg.AppContainer, limit int) error {
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	_, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	entries, err := history.Load(app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath))
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(i18n.Stdout, "No commands have been run in this project yet")
+		fmt.Fprintln(i18n.Stdout, "💡 Run one with: claude-reactor exec go test ./...")
+		return nil
+	}
+
+	first := 0
+	if limit > 0 && len(entries) > limit {
+		first = len(entries) - limit
+	}
+	fmt.Fprintf(i18n.Stdout, "%-4s %-16s %-5s %-9s %s\n", "#", "STARTED", "EXIT", "DURATION", "COMMAND")
+	for i, entry := range entries[first:] {
+		command := strings.Join(entry.Command, " ")
+		if entry.Workdir != "" {
+			command = fmt.Sprintf("(%s) %s", entry.Workdir, command)
+		}
+		if entry.Task != "" {
+			command = fmt.Sprintf("[task %s] %s", entry.Task, command)
+		}
+		fmt.Fprintf(i18n.Stdout, "%-4d %-16s %-5d %-9s %s\n", first+i+1, entry.Started.Format("2006-01-02 15:04"), entry.ExitCode, entry.Duration.Round(100*time.Millisecond), command)
+	}
+	return nil
+}
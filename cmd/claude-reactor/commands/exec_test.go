@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecArgs(t *testing.T) {
+	cmd := NewExecCmd(createMockApp())
+	cmd.SetArgs([]string{"--last", "go", "test"})
+	assert.ErrorContains(t, cmd.Execute(), "takes no command")
+
+	cmd = NewExecCmd(createMockApp())
+	cmd.SetArgs([]string{})
+	assert.ErrorContains(t, cmd.Execute(), "no command given")
+}
+
+func TestExecFlagsBelongToCommand(t *testing.T) {
+	cmd := NewExecCmd(nil)
+	require.NoError(t, cmd.ParseFlags([]string{"go", "test", "-v", "--last"}))
+	last, _ := cmd.Flags().GetBool("last")
+	assert.False(t, last)
+	assert.Equal(t, []string{"go", "test", "-v", "--last"}, cmd.Flags().Args())
+}
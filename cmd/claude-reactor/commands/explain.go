@@ -0,0 +1,672 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/architecture"
+	"claude-reactor/internal/reactor/docker"
+	"claude-reactor/internal/reactor/dotfiles"
+	"claude-reactor/internal/reactor/filesync"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/sandbox"
+	"claude-reactor/internal/reactor/secrets"
+	"claude-reactor/internal/reactor/variants"
+	"claude-reactor/internal/reactor/worktree"
+	"claude-reactor/pkg"
+)
+
+// planNode is one line of an explained plan: a setting, its value, why it has that value,
+// and any details below it
+type planNode struct {
+	Label    string
+	Value    string
+	Reason   string
+	Children []*planNode
+}
+
+// add appends a child node and returns it
+func (n *planNode) add(label, value, reason string) *planNode {
+	child := &planNode{Label: label, Value: value, Reason: reason}
+	n.Children = append(n.Children, child)
+	return child
+}
+
+// line formats the node without its children
+func (n *planNode) line() string {
+	text := n.Label
+	if n.Value != "" {
+		text += ": " + n.Value
+	}
+	if n.Reason != "" {
+		text += "  (" + n.Reason + ")"
+	}
+	return text
+}
+
+// render writes the node and its children as a tree
+func (n *planNode) render(w io.Writer) {
+	fmt.Fprintln(w, n.line())
+	n.renderChildren(w, "")
+}
+
+func (n *planNode) renderChildren(w io.Writer, indent string) {
+	for i, child := range n.Children {
+		branch, next := "├── ", "│   "
+		if i == len(n.Children)-1 {
+			branch, next = "└── ", "    "
+		}
+		fmt.Fprintln(w, indent+branch+child.line())
+		child.renderChildren(w, indent+next)
+	}
+}
+
+// NewExplainCmd creates the explain command for showing what a command would do
+func NewExplainCmd(app *pkg.AppContainer) *cobra.Command {
+	var explainCmd = &cobra.Command{
+		Use:   "explain",
+		Short: "Show what a command would do, and why",
+		Long: `Show the fully resolved plan for a command without running it.
+
+Each setting is shown with where its value came from: a flag, the saved
+project configuration, auto-detection, or a default.`,
+	}
+
+	explainCmd.AddCommand(newExplainRunCmd(app))
+
+	return explainCmd
+}
+
+func newExplainRunCmd(app *pkg.AppContainer) *cobra.Command {
+	explainRunCmd := &cobra.Command{
+		Use:   "run",
+		Short: "Show the resolved plan for 'claude-reactor run'",
+		Long: `Show how 'claude-reactor run' would start the project container: the image
+variant and why it was chosen, the account, platform, mounts and their sources,
+environment, whether the image comes from a local build or the registry, and
+whether an existing container would be reused or recreated.
+
+Accepts the same flags as 'run'. Nothing is started, built, pulled, or saved.`,
+		Example: `# Explain the default run
+claude-reactor explain run
+
+# Explain a run with overrides
+claude-reactor explain run --image go --account work --mount ~/data`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			plan, err := explainRun(cmd.Context(), cmd, app)
+			if err != nil {
+				return err
+			}
+			plan.render(i18n.Writer(cmd.OutOrStdout()))
+			return nil
+		},
+	}
+
+	// Share run's flags so every run invocation can be explained as-is
+	explainRunCmd.Flags().AddFlagSet(NewRunCmd(nil).Flags())
+	explainRunCmd.RegisterFlagCompletionFunc("image", completeImages(app))
+	explainRunCmd.RegisterFlagCompletionFunc("account", completeAccounts(app))
+
+	return explainRunCmd
+}
+
+// explainRun resolves the plan 'run' would follow with the flags on cmd, without side effects
+func explainRun(ctx context.Context, cmd *cobra.Command, app *pkg.AppContainer) (*planNode, error) {
+	image, _ := cmd.Flags().GetString("image")
+	account, _ := cmd.Flags().GetString("account")
+	mounts, _ := cmd.Flags().GetStringSlice("mount")
+	tmpfs, _ := cmd.Flags().GetStringSlice("tmpfs")
+	readOnlyProject, _ := cmd.Flags().GetBool("read-only-project")
+	sandboxed, _ := cmd.Flags().GetBool("sandbox")
+	devices, _ := cmd.Flags().GetStringSlice("device")
+	shell, _ := cmd.Flags().GetBool("shell")
+	noPersist, _ := cmd.Flags().GetBool("no-persist")
+	ci, _ := cmd.Flags().GetBool("ci")
+	detachable, _ := cmd.Flags().GetBool("tmux")
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return nil, fmt.Errorf("docker not available: %w", err)
+	}
+
+	config, err := app.ConfigMgr.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	config.ProjectPath = projectDir
+	applyRunFlags(cmd, config)
+	revalidate, _ := cmd.Flags().GetBool("revalidate")
+	configureImageCache(app, config, revalidate)
+
+	plan := &planNode{Label: "claude-reactor run", Value: projectDir}
+
+	// Variant
+	variantNode := plan.add("variant", "", "")
+	switch {
+	case image != "":
+		config.Variant = image
+		variantNode.Reason = "--image flag"
+	case config.Variant != "":
+		variantNode.Reason = "saved in .claude-reactor"
+	default:
+		detection, err := app.ConfigMgr.DetectProject(projectDir)
+		if err != nil {
+			config.Variant = "base"
+			variantNode.Reason = fmt.Sprintf("auto-detection failed: %v", err)
+		} else {
+			config.Variant = detection.Variant
+			explainDetection(variantNode, detection)
+		}
+	}
+	variantNode.Value = config.Variant
+	if err := app.ConfigMgr.ValidateConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Account
+	accountReason := "saved in .claude-reactor"
+	if account != "" {
+		accountReason = "--account flag"
+	} else if config.Account == "" {
+		config.Account = app.AuthMgr.GetDefaultAccount()
+		accountReason = "default account"
+	}
+	accountNode := plan.add("account", config.Account, accountReason)
+	accountNode.add("claude config", app.AuthMgr.GetAccountConfigPath(config.Account), "")
+
+	// Platform and container name
+	hostPlatform, err := app.ArchDetector.GetDockerPlatform()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker platform: %w", err)
+	}
+	arch, err := projectArchitecture(app, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect architecture: %w", err)
+	}
+	platform := hostPlatform
+	if config.Platform == "" || config.Platform == hostPlatform {
+		plan.add("platform", hostPlatform, "host platform")
+	} else {
+		platform = config.Platform
+		platformNode := plan.add("platform", config.Platform, settingSource(cmd, "platform", true))
+		if emulation, err := architecture.DetectEmulation(hostPlatform, config.Platform); err != nil {
+			platformNode.add("emulation", "unavailable", err.Error())
+		} else {
+			platformNode.add("emulation", string(emulation), architecture.EmulationWarning(config.Platform, emulation))
+		}
+	}
+
+	containerName := app.DockerMgr.GenerateContainerName(projectDir, config.Variant, arch, config.Account)
+	imageName := app.DockerMgr.GetImageName(config.Variant, arch)
+
+	// Image and registry decision
+	imageName = explainImage(ctx, plan, app, config, imageName)
+
+	// Session command
+	command := "claude"
+	switch {
+	case ci:
+		command = strings.Join(cmd.Flags().Args(), " ")
+	case shell:
+		command = "/bin/bash"
+	case config.DangerMode:
+		command += " --dangerously-skip-permissions"
+	}
+	sessionNode := plan.add("command", command, "")
+	if detachable {
+		sessionNode.add("multiplexer", "tmux (or screen)", "--tmux flag; reattach with 'claude-reactor session attach'")
+	}
+	if config.Clipboard {
+		sessionNode.add("clipboard bridge", "on", settingSource(cmd, "clipboard", true))
+	}
+
+	// Security-relevant settings
+	settingsNode := plan.add("settings", "", "")
+	settingsNode.add("danger mode", onOff(config.DangerMode), settingSource(cmd, "danger", config.DangerMode))
+	settingsNode.add("host docker", onOff(config.HostDocker), settingSource(cmd, "host-docker", config.HostDocker))
+	settingsNode.add("ssh agent", onOff(config.SSHAgent), settingSource(cmd, "ssh-agent", config.SSHAgent))
+	settingsNode.add("file sync", onOff(config.SyncMode), settingSource(cmd, "sync", config.SyncMode))
+	if readOnlyProject {
+		settingsNode.add("read-only project", "on", "--read-only-project flag")
+	}
+	if sandboxed {
+		settingsNode.add("sandbox", "on", "--sandbox flag")
+	}
+
+	// Mounts
+	mountsNode := plan.add("mounts", "", "")
+	containerMounts, err := explainMounts(mountsNode, app, config, containerName, mounts, tmpfs, readOnlyProject, sandboxed)
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) > 0 {
+		devicesNode := plan.add("devices", "", "--device flag")
+		for _, device := range devices {
+			devicesNode.add(device, "", "")
+		}
+	}
+	dns, dnsSearch, extraHosts, err := nameResolution(cmd, config)
+	if err != nil {
+		return nil, err
+	}
+	if len(dns) > 0 || len(dnsSearch) > 0 || len(extraHosts) > 0 {
+		dnsNode := plan.add("name resolution", "", "dns, dns_search and add_hosts config, --dns, --dns-search and --add-host flags")
+		for _, server := range dns {
+			dnsNode.add("dns", server, "")
+		}
+		for _, domain := range dnsSearch {
+			dnsNode.add("search", domain, "")
+		}
+		for _, host := range extraHosts {
+			dnsNode.add("host", host, "")
+		}
+	}
+
+	// Environment
+	environment := make(map[string]string)
+	envNode := plan.add("environment", "", "")
+	if tz, source := hostTimezone(); tz != "" {
+		environment["TZ"] = tz
+		envNode.add("TZ", tz, "from "+source)
+	}
+	if apiKey := os.Getenv("ANTHROPIC_API_KEY"); apiKey != "" {
+		environment["ANTHROPIC_API_KEY"] = apiKey
+		envNode.add("ANTHROPIC_API_KEY", "(hidden)", "from host environment")
+	}
+	for key, value := range docker.ShellHistoryEnv {
+		environment[key] = value
+	}
+	envNode.add("HISTFILE", docker.ShellHistoryEnv["HISTFILE"], "shell history, kept in the project session directory")
+	hostname, prompt := containerBranding(config, config.ProjectPath)
+	if prompt != "" {
+		environment[docker.PromptEnv] = prompt
+		envNode.add(docker.PromptEnv, prompt, "shell prompt label, "+brandingSource(config.Prompt, "prompt"))
+	}
+	if len(envNode.Children) == 0 {
+		envNode.Value = "none"
+	}
+
+	// Container lifecycle, decided by comparing the configuration above with the existing container's
+	containerNode := plan.add("container", containerName, "")
+	containerNode.add("hostname", hostname, brandingSource(config.Hostname, "hostname"))
+	configHash := docker.ConfigHash(&pkg.ContainerConfig{
+		Image:       imageName,
+		Hostname:    hostname,
+		Platform:    platform,
+		Mounts:      containerMounts,
+		Environment: environment,
+		Devices:     devices,
+		DNS:         dns,
+		DNSSearch:   dnsSearch,
+		ExtraHosts:  extraHosts,
+		HostDocker:  config.HostDocker,
+		SSHAgent:    config.SSHAgent,
+		SyncMode:    config.SyncMode,
+	})
+	reuse, _ := cmd.Flags().GetBool("reuse")
+	recreate, _ := cmd.Flags().GetBool("recreate")
+	if reuse && recreate {
+		return nil, fmt.Errorf("--reuse and --recreate cannot be combined")
+	}
+	status, err := app.DockerMgr.GetContainerStatus(ctx, containerName)
+	if err != nil {
+		status = nil
+	}
+	action, reason := decideContainerAction(status, configHash, config.SessionPersistence, reuse, recreate)
+	containerNode.add("action", string(action), reason)
+
+	persist := !noPersist && !(ci && !cmd.Flags().Changed("no-persist"))
+	if persist {
+		containerNode.add("after exit", "keep running", "use 'claude-reactor clean' to stop")
+	} else {
+		containerNode.add("after exit", "stop", settingSource(cmd, "no-persist", false))
+	}
+
+	return plan, nil
+}
+
+// applyRunFlags applies run's persisted flags to config the way run does, without saving
+func applyRunFlags(cmd *cobra.Command, config *pkg.Config) {
+	flags := cmd.Flags()
+	if account, _ := flags.GetString("account"); account != "" {
+		config.Account = account
+	}
+	if flags.Changed("danger") {
+		config.DangerMode, _ = flags.GetBool("danger")
+	}
+	if flags.Changed("host-docker") {
+		config.HostDocker, _ = flags.GetBool("host-docker")
+	}
+	if flags.Changed("sync") {
+		config.SyncMode, _ = flags.GetBool("sync")
+	}
+	if flags.Changed("platform") {
+		config.Platform, _ = flags.GetString("platform")
+	}
+	if flags.Changed("clipboard") {
+		config.Clipboard, _ = flags.GetBool("clipboard")
+	}
+	if flags.Changed("ssh-agent") {
+		config.SSHAgent = true
+		config.SSHAgentSocket, _ = flags.GetString("ssh-agent")
+	}
+}
+
+// explainDetection records why auto-detection chose its variant
+func explainDetection(node *planNode, detection *pkg.ProjectDetectionResult) {
+	if detection.ProjectType == "unknown" {
+		node.Reason = "auto-detected: no project markers found"
+		return
+	}
+
+	node.Reason = fmt.Sprintf("auto-detected: %s project, confidence %.2f", detection.ProjectType, detection.Confidence)
+	if len(detection.Languages) > 1 {
+		node.Reason += "; smallest variant covering every detected stack"
+	}
+	for _, language := range detection.Languages {
+		node.add(language, detection.Metadata["score."+language], "")
+	}
+	if len(detection.Files) > 0 {
+		node.add("markers", strings.Join(detection.Files, ", "), "")
+	}
+}
+
+// explainImage records which image run would use and where it would come from, and returns it
+func explainImage(ctx context.Context, plan *planNode, app *pkg.AppContainer, config *pkg.Config, imageName string) string {
+	var imageNode *planNode
+	switch {
+	case variants.IsBuiltin(config.Variant):
+		if image, local := builtinImage(ctx, app, config.Variant, imageName); local {
+			imageNode = plan.add("image", image, "built-in variant; local build found")
+		} else {
+			imageNode = plan.add("image", image, fmt.Sprintf("built-in variant; no local build '%s', using registry", imageName))
+		}
+	case externalVariant(app, config.Variant) != nil:
+		definition := externalVariant(app, config.Variant)
+		if definition.Image != "" {
+			imageNode = plan.add("image", definition.Image, "prebuilt image of external variant, pulled if missing")
+		} else if _, err := app.ImageValidator.ValidateImage(ctx, imageName+":latest", false); err == nil {
+			imageNode = plan.add("image", imageName, "external variant; local build found")
+		} else {
+			imageNode = plan.add("image", imageName, "external variant; built from "+definition.Dockerfile+" before starting")
+		}
+		imageNode.add("definition", definition.Source, "")
+	default:
+		imageNode = plan.add("image", config.Variant, "custom image; validated, and pulled if missing, before starting")
+	}
+
+	image := imageNode.Value
+	if !config.Packages.Empty() {
+		var lists []string
+		for _, manager := range pkg.PackageManagers {
+			if list := *config.Packages.List(manager); len(list) > 0 {
+				lists = append(lists, manager+": "+strings.Join(list, ", "))
+			}
+		}
+		if derived, err := app.DockerMgr.PackagesImageName(ctx, image, config.Packages); err == nil {
+			image = derived
+			imageNode.add("packages", derived, strings.Join(lists, "; ")+"; built on the image if missing")
+		} else {
+			imageNode.add("packages", strings.Join(lists, "; "), "installed on the image in a derived image before starting")
+		}
+	}
+
+	if config.VulnThreshold != "" {
+		imageNode.add("vulnerability scan", "fail at "+config.VulnThreshold+" or above", "vuln_threshold in .claude-reactor")
+	}
+	return image
+}
+
+// explainMounts lists the mounts run would add, as AddMountsToContainer does, with the result
+// of checking each source. It returns the mounts the container would get.
+func explainMounts(node *planNode, app *pkg.AppContainer, config *pkg.Config, containerName string, userMounts, tmpfs []string, readOnlyProject, sandboxed bool) ([]pkg.Mount, error) {
+	type mountReason struct {
+		label   string
+		reason  string
+		created bool // run creates the source just before mounting
+	}
+	planned := &pkg.ContainerConfig{}
+	var reasons []mountReason
+	add := func(mount pkg.Mount, label, reason string, created bool) {
+		for _, existing := range planned.Mounts {
+			if existing.Source == mount.Source && existing.Target == mount.Target {
+				return
+			}
+		}
+		planned.Mounts = append(planned.Mounts, mount)
+		reasons = append(reasons, mountReason{label: label, reason: reason, created: created})
+	}
+	bind := func(source, target, reason string, optional, created bool) {
+		// Validate and expand the source as run does; sources run creates just before
+		// mounting may not exist yet
+		scratch := &pkg.ContainerConfig{}
+		if err := app.MountMgr.AddMountToConfig(scratch, source, target); err == nil && len(scratch.Mounts) == 1 {
+			source = scratch.Mounts[0].Source
+		}
+		add(pkg.Mount{Source: source, Target: target, Type: "bind", Optional: optional}, source+" -> "+target, reason, created)
+	}
+
+	projectDir := config.ProjectPath
+	target := projectMountTarget(projectDir)
+	var unprotected []string
+	if config.SyncMode {
+		volumeName := filesync.VolumeName(containerName)
+		add(pkg.Mount{Source: volumeName, Target: target, Type: "volume"}, volumeName+" -> "+target, "project volume, synced from "+projectDir, false)
+	} else if sandboxed {
+		volumeName := sandbox.VolumeName(containerName)
+		add(pkg.Mount{Source: volumeName, Target: target, Type: "volume"}, volumeName+" -> "+target, "project sandbox, copied from "+projectDir, false)
+	} else {
+		bind(projectDir, target, "project directory", false, false)
+		if readOnlyProject {
+			planned.Mounts[len(planned.Mounts)-1].ReadOnly = true
+			reasons[len(reasons)-1].reason += ", read-only"
+		}
+		if commonDir := worktree.CommonDir(projectDir); commonDir != "" && strings.HasPrefix(commonDir, "/") {
+			bind(commonDir, commonDir, "git directory of the repository this worktree belongs to", false, false)
+			if readOnlyProject {
+				planned.Mounts[len(planned.Mounts)-1].ReadOnly = true
+				reasons[len(reasons)-1].reason += ", read-only"
+			}
+		}
+		paths, err := protectedPaths(config.ProtectedPaths)
+		if err != nil {
+			return nil, err
+		}
+		var protected []pkg.Mount
+		protected, unprotected = protectedMounts(projectDir, target, paths)
+		for _, mount := range protected {
+			add(mount, mount.Source+" -> "+mount.Target, "protected path, read-only", false)
+		}
+	}
+
+	sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, projectDir)
+	bind(sessionDir, "/home/claude/.claude", "conversation history for this project and account", true, true)
+	npmCache := docker.NPMCacheVolume(config.Account)
+	add(pkg.Mount{Source: npmCache, Target: docker.NPMCacheTarget, Type: "volume"}, npmCache+" -> "+docker.NPMCacheTarget, "npm cache shared by the account's containers", false)
+	if readOnlyProject {
+		bind(filepath.Join(sessionDir, "scratch"), scratchTarget, "writable scratch for the read-only project", false, true)
+	}
+	if mode, err := secrets.ParseMode(config.SecretScan); err != nil {
+		return nil, err
+	} else if (mode == secrets.ModeExclude || mode == secrets.ModePlaceholder) && !config.SyncMode && !sandboxed {
+		findings, err := secrets.Scan(projectDir, filesync.IgnorePatterns(projectDir))
+		if err != nil {
+			return nil, err
+		}
+		for i, mount := range secretMounts(findings, target, sessionDir) {
+			add(mount, mount.Source+" -> "+mount.Target, "hides "+findings[i].Reason+", "+mode, true)
+		}
+	}
+
+	projectClaudeConfig := filepath.Join(sessionDir, ".claude.json")
+	if _, err := os.Stat(projectClaudeConfig); err == nil {
+		bind(projectClaudeConfig, "/home/claude/.claude.json", "project Claude config", true, false)
+	} else {
+		bind(projectClaudeConfig, "/home/claude/.claude.json", "project Claude config, created from the account config", true, true)
+	}
+
+	homeDir, homeErr := os.UserHomeDir()
+	if homeErr == nil {
+		credentials := filepath.Join(homeDir, ".claude", ".credentials.json")
+		if _, err := os.Stat(credentials); err == nil {
+			bind(credentials, "/home/claude/.claude/.credentials.json", "OAuth credentials", true, false)
+		}
+	}
+
+	if config.HostDocker {
+		dockerSock := "/var/run/docker.sock"
+		if runtime.GOOS == "windows" {
+			add(pkg.Mount{Source: dockerSock, Target: dockerSock, Type: "bind"}, "Docker Desktop -> "+dockerSock, "host docker", false)
+		} else {
+			bind(dockerSock, dockerSock, "host docker", false, false)
+		}
+	}
+
+	if config.SSHAgent {
+		socket := config.SSHAgentSocket
+		if socket == "" || socket == "auto" {
+			detected, err := app.ConfigMgr.DetectSSHAgent()
+			if err != nil {
+				node.add("ssh", "unavailable", err.Error())
+				socket = ""
+			} else {
+				socket = detected
+			}
+		}
+		if socket != "" {
+			sshMounts, err := app.ConfigMgr.PrepareSSHMounts(true, socket)
+			if err != nil {
+				node.add("ssh", "unavailable", err.Error())
+			}
+			for _, mount := range sshMounts {
+				bind(mount.Source, mount.Target, "ssh agent", true, false)
+			}
+		}
+	}
+
+	if homeErr == nil {
+		agents := filepath.Join(homeDir, ".claude", "agents")
+		if _, err := os.Stat(agents); err == nil {
+			bind(agents, "/home/claude/.claude/agents", "global subagents", true, false)
+		}
+	}
+
+	userMount := func(spec, reason string) error {
+		mount, err := app.MountMgr.ParseMountSpec(spec)
+		if err != nil {
+			return err
+		}
+		add(*mount, mount.Source+" -> "+mount.Target+mountModes(mount), reason, false)
+		return nil
+	}
+	userTmpfs := func(target, reason string) error {
+		mount, err := app.MountMgr.ParseTmpfsSpec(target)
+		if err != nil {
+			return err
+		}
+		add(*mount, "tmpfs -> "+mount.Target, reason, false)
+		return nil
+	}
+	for _, spec := range configList(config.Mounts) {
+		if err := userMount(spec, "mounts setting"); err != nil {
+			return nil, err
+		}
+	}
+	for _, spec := range userMounts {
+		if err := userMount(spec, "--mount flag"); err != nil {
+			return nil, err
+		}
+	}
+	for _, target := range configList(config.Tmpfs) {
+		if err := userTmpfs(target, "tmpfs setting"); err != nil {
+			return nil, err
+		}
+	}
+	for _, target := range tmpfs {
+		if err := userTmpfs(target, "--tmpfs flag"); err != nil {
+			return nil, err
+		}
+	}
+	if config.Dotfiles != "" && !dotfiles.IsRemote(config.Dotfiles) {
+		scratch := &pkg.ContainerConfig{}
+		if err := mountDotfiles(app, scratch, config); err != nil {
+			return nil, err
+		}
+		for _, mount := range scratch.Mounts {
+			add(mount, mount.Source+" -> "+mount.Target+mountModes(&mount), "dotfiles setting, applied once the container starts", false)
+		}
+	}
+
+	// Check every source the way run's pre-flight does
+	var mounts []pkg.Mount
+	for i, check := range docker.NewMountManager(app.Logger).CheckMounts(planned.Mounts) {
+		reason := reasons[i].reason
+		if check.Mount.Optional {
+			reason += ", optional"
+		}
+		status := "ok"
+		switch {
+		case check.Err == nil:
+		case reasons[i].created:
+			status = "created by run"
+		case check.Mount.Optional:
+			status = "skipped: " + firstLine(check.Err.Error())
+			node.add(reasons[i].label, status, reason)
+			continue
+		default:
+			status = "✗ " + firstLine(check.Err.Error()) + " (run will stop here)"
+		}
+		node.add(reasons[i].label, status, reason)
+		mounts = append(mounts, check.Mount)
+	}
+	for _, rel := range unprotected {
+		node.add(rel, "not protected", "protected path that does not exist")
+	}
+	return mounts, nil
+}
+
+// brandingSource describes where a hostname or prompt template came from
+func brandingSource(template, key string) string {
+	if template == "" {
+		return "default"
+	}
+	return key + " in .claude-reactor"
+}
+
+// firstLine returns s up to its first newline, dropping hints that follow
+func firstLine(s string) string {
+	return strings.SplitN(s, "\n", 2)[0]
+}
+
+// settingSource describes where a run setting's value came from
+func settingSource(cmd *cobra.Command, flag string, saved bool) string {
+	if cmd.Flags().Changed(flag) {
+		return "--" + flag + " flag"
+	}
+	if saved {
+		return "saved in .claude-reactor"
+	}
+	return "default"
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/mount"
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func TestNewExplainCmd(t *testing.T) {
+	cmd := NewExplainCmd(createMockApp())
+	assert.Equal(t, "explain", cmd.Use)
+	require.Len(t, cmd.Commands(), 1)
+
+	runCmd := cmd.Commands()[0]
+	assert.Equal(t, "run", runCmd.Name())
+	for _, flag := range []string{"image", "account", "mount", "device", "platform", "sync", "danger", "ssh-agent"} {
+		assert.NotNil(t, runCmd.Flags().Lookup(flag), flag)
+	}
+
+	t.Run("nil app shows help", func(t *testing.T) {
+		runCmd := NewExplainCmd(nil).Commands()[0]
+		runCmd.SetOut(&bytes.Buffer{})
+		assert.NoError(t, runCmd.RunE(runCmd, []string{}))
+	})
+}
+
+func TestPlanNodeRender(t *testing.T) {
+	plan := &planNode{Label: "claude-reactor run", Value: "/src/app"}
+	variant := plan.add("variant", "go", "auto-detected")
+	variant.add("go", "0.95", "")
+	variant.add("markers", "go.mod", "")
+	plan.add("account", "work", "--account flag")
+
+	var out bytes.Buffer
+	plan.render(&out)
+	assert.Equal(t, `claude-reactor run: /src/app
+├── variant: go  (auto-detected)
+│   ├── go: 0.95
+│   └── markers: go.mod
+└── account: work  (--account flag)
+`, out.String())
+}
+
+func TestExplainDetection(t *testing.T) {
+	t.Run("no markers", func(t *testing.T) {
+		node := &planNode{}
+		explainDetection(node, &pkg.ProjectDetectionResult{ProjectType: "unknown", Variant: "base"})
+		assert.Equal(t, "auto-detected: no project markers found", node.Reason)
+		assert.Empty(t, node.Children)
+	})
+
+	t.Run("mixed project", func(t *testing.T) {
+		node := &planNode{}
+		explainDetection(node, &pkg.ProjectDetectionResult{
+			ProjectType: "go",
+			Variant:     "full",
+			Languages:   []string{"go", "python"},
+			Files:       []string{"go.mod", "requirements.txt"},
+			Confidence:  0.95,
+			Metadata:    map[string]string{"score.go": "0.95", "score.python": "0.90"},
+		})
+		assert.Contains(t, node.Reason, "go project, confidence 0.95")
+		assert.Contains(t, node.Reason, "covering every detected stack")
+		require.Len(t, node.Children, 3)
+		assert.Equal(t, "python: 0.90", node.Children[1].line())
+		assert.Equal(t, "markers: go.mod, requirements.txt", node.Children[2].line())
+	})
+}
+
+func TestSettingSource(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("danger", false, "")
+	cmd.Flags().Bool("sync", false, "")
+	require.NoError(t, cmd.Flags().Set("danger", "true"))
+
+	assert.Equal(t, "--danger flag", settingSource(cmd, "danger", false))
+	assert.Equal(t, "saved in .claude-reactor", settingSource(cmd, "sync", true))
+	assert.Equal(t, "default", settingSource(cmd, "sync", false))
+}
+
+func TestApplyRunFlags(t *testing.T) {
+	cmd := NewExplainCmd(nil).Commands()[0]
+	require.NoError(t, cmd.Flags().Parse([]string{"--account", "work", "--sync", "--ssh-agent"}))
+
+	config := &pkg.Config{Account: "personal", DangerMode: true}
+	applyRunFlags(cmd, config)
+
+	assert.Equal(t, "work", config.Account)
+	assert.True(t, config.SyncMode)
+	assert.True(t, config.DangerMode, "unset flags keep saved values")
+	assert.True(t, config.SSHAgent)
+	assert.Equal(t, "auto", config.SSHAgentSocket)
+}
+
+func TestExplainMountsReadOnlyProject(t *testing.T) {
+	projectDir := t.TempDir()
+	sessionDir := filepath.Join(t.TempDir(), "session")
+	authMgr := &mocks.MockAuthManager{}
+	authMgr.On("GetProjectSessionDir", "work", projectDir).Return(sessionDir)
+	app := createMockApp()
+	app.AuthMgr = authMgr
+	app.MountMgr = mount.NewManager(app.Logger)
+
+	node := &planNode{}
+	config := &pkg.Config{Account: "work", ProjectPath: projectDir}
+	mounts, err := explainMounts(node, app, config, "claude-reactor-base", nil, nil, true, false)
+	require.NoError(t, err)
+
+	require.GreaterOrEqual(t, len(mounts), 4)
+	assert.Equal(t, "/app", mounts[0].Target)
+	assert.True(t, mounts[0].ReadOnly)
+	assert.Equal(t, pkg.Mount{Source: "claude-reactor-npm-cache-work", Target: "/home/claude/.npm", Type: "volume"}, mounts[2])
+	assert.Equal(t, pkg.Mount{Source: filepath.Join(sessionDir, "scratch"), Target: "/scratch", Type: "bind"}, mounts[3])
+	assert.Contains(t, node.Children[0].line(), "project directory, read-only")
+}
+
+func TestExplainMountsSandbox(t *testing.T) {
+	projectDir := t.TempDir()
+	authMgr := &mocks.MockAuthManager{}
+	authMgr.On("GetProjectSessionDir", "work", projectDir).Return(filepath.Join(t.TempDir(), "session"))
+	app := createMockApp()
+	app.AuthMgr = authMgr
+	app.MountMgr = mount.NewManager(app.Logger)
+
+	node := &planNode{}
+	config := &pkg.Config{Account: "work", ProjectPath: projectDir}
+	mounts, err := explainMounts(node, app, config, "claude-reactor-base", nil, nil, false, true)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, mounts)
+	assert.Equal(t, pkg.Mount{Source: "claude-reactor-base-sandbox", Target: "/app", Type: "volume"}, mounts[0])
+	assert.Contains(t, node.Children[0].line(), "project sandbox, copied from "+projectDir)
+}
+
+func TestExplainMountsProtectedPaths(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".github", "workflows"), 0755))
+	authMgr := &mocks.MockAuthManager{}
+	authMgr.On("GetProjectSessionDir", "work", projectDir).Return(filepath.Join(t.TempDir(), "session"))
+	app := createMockApp()
+	app.AuthMgr = authMgr
+	app.MountMgr = mount.NewManager(app.Logger)
+
+	node := &planNode{}
+	config := &pkg.Config{Account: "work", ProjectPath: projectDir, ProtectedPaths: ".github/workflows,infra/prod"}
+	mounts, err := explainMounts(node, app, config, "claude-reactor-base", nil, nil, false, false)
+	require.NoError(t, err)
+
+	assert.Contains(t, mounts, pkg.Mount{Source: filepath.Join(projectDir, ".github", "workflows"), Target: "/app/.github/workflows", Type: "bind", ReadOnly: true})
+	last := node.Children[len(node.Children)-1].line()
+	assert.Contains(t, last, filepath.Join("infra", "prod"))
+	assert.Contains(t, last, "not protected")
+}
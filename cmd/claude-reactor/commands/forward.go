@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/pkg"
+)
+
+// NewForwardCmd creates the forward command for reaching services inside the container
+func NewForwardCmd(app *pkg.AppContainer) *cobra.Command {
+	var forwardCmd = &cobra.Command{
+		Use:   "forward [host-port:]container-port...",
+		Short: "Forward host ports to services running in the container",
+		Long: `Forward host ports to services running in the project container.
+
+Servers started inside the container after it was created are not published
+to the host. 'forward' opens a listener on the host and relays each connection
+into the container with socat, so no container recreation or -p flags are needed.
+
+Forwarding runs in the foreground until interrupted with Ctrl+C.`,
+		Example: `# Forward host port 8080 to container port 3000
+claude-reactor forward 8080:3000
+
+# Forward the same port on both sides
+claude-reactor forward 5173
+
+# Forward several ports at once
+claude-reactor forward 3000 8080:80
+
+# Listen on all interfaces instead of localhost
+claude-reactor forward 3000 --address 0.0.0.0`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return forwardPorts(cmd, app, args)
+		},
+	}
+
+	forwardCmd.Flags().StringP("address", "", "127.0.0.1", "Host address to listen on")
+
+	return forwardCmd
+}
+
+// forwardPorts starts a listener for each mapping and relays connections until interrupted
+func forwardPorts(cmd *cobra.Command, app *pkg.AppContainer, args []string) error {
+	address, _ := cmd.Flags().GetString("address")
+
+	mappings := make([]pkg.PortMapping, 0, len(args))
+	for _, arg := range args {
+		mapping, err := ParsePortMapping(arg)
+		if err != nil {
+			return err
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+
+	containerName, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	forwardsDir := filepath.Join(app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath), "forwards")
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	running, err := app.DockerMgr.IsContainerRunning(ctx, containerName)
+	if err != nil || !running {
+		return pkg.NewError(pkg.CodeContainerNotRunning, nil, "container %s is not running", containerName).
+			WithRemediation("Start it first with: claude-reactor run")
+	}
+
+	var listeners []net.Listener
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, mapping := range mappings {
+		listenAddr := net.JoinHostPort(address, strconv.Itoa(mapping.HostPort))
+		listener, err := net.Listen("tcp", listenAddr)
+		if err != nil {
+			return pkg.NewError(pkg.CodePortInUse, err, "failed to listen on %s", listenAddr).
+				WithRemediation(fmt.Sprintf("Choose a different host port: claude-reactor forward <host-port>:%d", mapping.ContainerPort))
+		}
+		listeners = append(listeners, listener)
+		app.Logger.Infof("🔀 Forwarding %s -> %s:%d", listenAddr, containerName, mapping.ContainerPort)
+
+		// Record the forward so 'open' can find it; removed again when forwarding stops
+		if err := recordForward(forwardsDir, mapping); err != nil {
+			app.Logger.Debugf("Failed to record forward: %v", err)
+		}
+		defer removeForward(forwardsDir, mapping)
+
+		wg.Add(1)
+		go func(listener net.Listener, containerPort int) {
+			defer wg.Done()
+			acceptConnections(ctx, app, listener, containerName, containerPort)
+		}(listener, mapping.ContainerPort)
+	}
+
+	app.Logger.Info("💡 Press Ctrl+C to stop forwarding")
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	select {
+	case <-sigCh:
+	case <-ctx.Done():
+	}
+
+	app.Logger.Info("🛑 Stopping port forwarding...")
+	cancel()
+	for _, l := range listeners {
+		l.Close()
+	}
+	wg.Wait()
+	return nil
+}
+
+// acceptConnections relays each accepted connection into the container until the listener closes
+func acceptConnections(ctx context.Context, app *pkg.AppContainer, listener net.Listener, containerName string, containerPort int) {
+	command := []string{"socat", "-", fmt.Sprintf("TCP:127.0.0.1:%d", containerPort)}
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func(conn net.Conn) {
+			defer conn.Close()
+			app.Logger.Debugf("Forwarding connection from %s to container port %d", conn.RemoteAddr(), containerPort)
+			if err := app.DockerMgr.ExecPipe(ctx, containerName, command, conn, conn); err != nil {
+				app.Logger.Debugf("Forwarded connection ended: %v", err)
+			}
+		}(conn)
+	}
+}
+
+// recordForward writes a marker file for an active forward, named by host port
+func recordForward(dir string, mapping pkg.PortMapping) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, strconv.Itoa(mapping.HostPort)), []byte(strconv.Itoa(mapping.ContainerPort)), 0644)
+}
+
+// removeForward deletes the marker file for a forward
+func removeForward(dir string, mapping pkg.PortMapping) {
+	os.Remove(filepath.Join(dir, strconv.Itoa(mapping.HostPort)))
+}
+
+// activeForwards lists forwards recorded in the session directory
+func activeForwards(dir string) []pkg.PortMapping {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var mappings []pkg.PortMapping
+	for _, entry := range entries {
+		hostPort, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		containerPort, err := strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			continue
+		}
+		mappings = append(mappings, pkg.PortMapping{HostPort: hostPort, ContainerPort: containerPort})
+	}
+	return mappings
+}
+
+// ParsePortMapping parses "8080:3000" or "3000" into a port mapping
+func ParsePortMapping(spec string) (pkg.PortMapping, error) {
+	hostPart, containerPart, found := strings.Cut(spec, ":")
+	if !found {
+		containerPart = hostPart
+	}
+
+	hostPort, err := parsePort(hostPart)
+	if err != nil {
+		return pkg.PortMapping{}, pkg.NewError(pkg.CodeInvalidUsage, err, "invalid port mapping '%s'", spec).
+			WithRemediation("Use host-port:container-port, e.g. 8080:3000")
+	}
+	containerPort, err := parsePort(containerPart)
+	if err != nil {
+		return pkg.PortMapping{}, pkg.NewError(pkg.CodeInvalidUsage, err, "invalid port mapping '%s'", spec).
+			WithRemediation("Use host-port:container-port, e.g. 8080:3000")
+	}
+
+	return pkg.PortMapping{HostPort: hostPort, ContainerPort: containerPort}, nil
+}
+
+// parsePort validates a TCP port number
+func parsePort(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("'%s' is not a valid port (1-65535)", value)
+	}
+	return port, nil
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func TestNewForwardCmd(t *testing.T) {
+	t.Run("create forward command", func(t *testing.T) {
+		app := createMockApp()
+		cmd := NewForwardCmd(app)
+
+		assert.Equal(t, "forward [host-port:]container-port...", cmd.Use)
+		assert.Contains(t, cmd.Short, "Forward host ports")
+		assert.NotEmpty(t, cmd.Long)
+
+		address, _ := cmd.Flags().GetString("address")
+		assert.Equal(t, "127.0.0.1", address)
+	})
+
+	t.Run("forward command with nil app shows help", func(t *testing.T) {
+		cmd := NewForwardCmd(nil)
+
+		err := cmd.RunE(cmd, []string{"3000"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestParsePortMapping(t *testing.T) {
+	tests := []struct {
+		spec        string
+		expected    pkg.PortMapping
+		expectError bool
+	}{
+		{spec: "8080:3000", expected: pkg.PortMapping{HostPort: 8080, ContainerPort: 3000}},
+		{spec: "5173", expected: pkg.PortMapping{HostPort: 5173, ContainerPort: 5173}},
+		{spec: "abc", expectError: true},
+		{spec: "8080:", expectError: true},
+		{spec: "0:3000", expectError: true},
+		{spec: "8080:70000", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			mapping, err := ParsePortMapping(tt.spec)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mapping)
+		})
+	}
+}
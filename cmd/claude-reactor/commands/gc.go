@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// NewGCCmd creates the gc command for removing resources left behind by deleted projects
+func NewGCCmd(app *pkg.AppContainer) *cobra.Command {
+	var gcCmd = &cobra.Command{
+		Use:   "gc",
+		Short: "Remove resources left behind by deleted projects",
+		Long: `Find containers, sync volumes, and session data whose project directory no longer
+exists, plus cached validation results for images that have been removed, and offer
+to remove them.
+
+A project's path is read from container labels or the session's saved configuration.
+Resources whose project path cannot be determined are left alone.
+
+At the prompt, answer 'y' to remove everything listed, or give the numbers to remove
+(for example '1,3-5').`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return runGC(cmd, app)
+		},
+	}
+
+	gcCmd.Flags().Bool("dry-run", false, "List orphaned resources without removing anything")
+	gcCmd.Flags().BoolP("force", "f", false, "Remove all orphaned resources without confirmation")
+
+	return gcCmd
+}
+
+// runGC finds orphaned resources, confirms which to remove, and removes them
+func runGC(cmd *cobra.Command, app *pkg.AppContainer) error {
+	ctx := cmd.Context()
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+
+	resources, err := collectResources(ctx, app, map[string]bool{
+		pkg.ResourceContainer: true,
+		pkg.ResourceVolume:    true,
+		pkg.ResourceSession:   true,
+	})
+	if err != nil {
+		return err
+	}
+	orphans := findOrphans(resources)
+	if app.ImageValidator != nil {
+		entries, err := app.ImageValidator.OrphanedCacheEntries(ctx)
+		if err != nil {
+			app.Logger.Warnf("Failed to check validation cache: %v", err)
+		}
+		orphans = append(orphans, entries...)
+	}
+
+	if len(orphans) == 0 {
+		app.Logger.Info("✨ No orphaned resources found")
+		return nil
+	}
+
+	out := i18n.Writer(cmd.OutOrStdout())
+	fmt.Fprintf(out, "Found %d orphaned resources (%s):\n", len(orphans), formatSize(totalSize(orphans)))
+	for i, resource := range orphans {
+		fmt.Fprintf(out, "  %2d. %-9s %s  %s\n", i+1, resource.Kind, resource.Name, formatSize(resource.Size))
+		if resource.ProjectPath != "" {
+			status := "deleted"
+			if resource.Running {
+				status = "deleted, container still running"
+			}
+			fmt.Fprintf(out, "      project: %s (%s)\n", resource.ProjectPath, status)
+		}
+	}
+
+	if dryRun {
+		app.Logger.Info("🔍 Dry run: nothing was removed")
+		return nil
+	}
+
+	selected := orphans
+	if !force {
+		fmt.Fprint(out, "Remove these? (y/N, or numbers such as 1,3-5): ")
+		answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		selected, err = selectEntries(answer, orphans)
+		if err != nil {
+			return err
+		}
+		if len(selected) == 0 {
+			app.Logger.Info("🚫 Garbage collection cancelled")
+			return nil
+		}
+	}
+
+	return removeOrphans(ctx, app, selected, out)
+}
+
+// removeOrphans removes the selected resources, continuing past failures
+func removeOrphans(ctx context.Context, app *pkg.AppContainer, selected []pkg.Resource, out io.Writer) error {
+	var freed int64
+	removed := 0
+	for _, resource := range selected {
+		if err := removeResource(ctx, app, resource); err != nil {
+			app.Logger.Warnf("Failed to remove %s %s: %v", resource.Kind, resource.Name, err)
+			continue
+		}
+		removed++
+		freed += max(resource.Size, 0)
+	}
+	fmt.Fprintf(out, "✅ Removed %d of %d orphaned resources, freeing %s\n", removed, len(selected), formatSize(freed))
+	return nil
+}
+
+// findOrphans returns resources whose project directory no longer exists. Volumes and
+// unlabeled containers have no recorded path, so it is looked up by project hash from
+// resources that have one.
+func findOrphans(resources []pkg.Resource) []pkg.Resource {
+	paths := make(map[string]string)
+	for _, resource := range resources {
+		if resource.ProjectPath != "" && resource.ProjectHash != "" {
+			paths[resource.ProjectHash] = resource.ProjectPath
+		}
+	}
+
+	var orphans []pkg.Resource
+	for _, resource := range resources {
+		projectPath := resource.ProjectPath
+		if projectPath == "" {
+			projectPath = paths[resource.ProjectHash]
+		}
+		if projectPath == "" {
+			continue
+		}
+		if _, err := os.Stat(projectPath); !os.IsNotExist(err) {
+			continue
+		}
+		resource.ProjectPath = projectPath
+		orphans = append(orphans, resource)
+	}
+	return (resourceFilter{kinds: map[string]bool{
+		pkg.ResourceContainer: true,
+		pkg.ResourceVolume:    true,
+		pkg.ResourceSession:   true,
+	}}).apply(orphans)
+}
+
+// selectEntries interprets a confirmation answer: yes selects everything, an empty or
+// negative answer selects nothing, and a list of numbers and ranges selects those entries
+func selectEntries[T any](answer string, resources []T) ([]T, error) {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	switch answer {
+	case "y", "yes", "a", "all":
+		return resources, nil
+	case "", "n", "no":
+		return nil, nil
+	}
+
+	chosen := make(map[int]bool)
+	for _, part := range strings.Split(answer, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(first))
+		end := start
+		if err == nil && isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(last))
+		}
+		if err != nil || start < 1 || end > len(resources) || start > end {
+			return nil, fmt.Errorf("invalid selection %q: use numbers between 1 and %d", part, len(resources))
+		}
+		for i := start; i <= end; i++ {
+			chosen[i] = true
+		}
+	}
+
+	var selected []T
+	for i, resource := range resources {
+		if chosen[i+1] {
+			selected = append(selected, resource)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func TestNewGCCmd(t *testing.T) {
+	cmd := NewGCCmd(createMockApp())
+	assert.Equal(t, "gc", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("dry-run"))
+	assert.NotNil(t, cmd.Flags().Lookup("force"))
+
+	t.Run("nil app shows help", func(t *testing.T) {
+		cmd := NewGCCmd(nil)
+		assert.NoError(t, cmd.RunE(cmd, []string{}))
+	})
+}
+
+func TestFindOrphans(t *testing.T) {
+	existing := t.TempDir()
+	deleted := filepath.Join(t.TempDir(), "deleted")
+
+	orphans := findOrphans([]pkg.Resource{
+		{Kind: pkg.ResourceSession, Name: "live-session", ProjectHash: "aaaaaaaa", ProjectPath: existing},
+		{Kind: pkg.ResourceContainer, Name: "live", ProjectHash: "aaaaaaaa"},
+		{Kind: pkg.ResourceVolume, Name: "gone-sync", ProjectHash: "bbbbbbbb"},
+		{Kind: pkg.ResourceContainer, Name: "gone", ProjectHash: "bbbbbbbb", ProjectPath: deleted},
+		{Kind: pkg.ResourceContainer, Name: "unknown", ProjectHash: "cccccccc"},
+	})
+
+	require.Len(t, orphans, 2)
+	assert.Equal(t, "gone", orphans[0].Name)
+	assert.Equal(t, "gone-sync", orphans[1].Name)
+	assert.Equal(t, deleted, orphans[1].ProjectPath, "volume path is found through its project hash")
+}
+
+func TestSelectEntries(t *testing.T) {
+	resources := []pkg.Resource{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+	names := func(resources []pkg.Resource) string {
+		var names []string
+		for _, resource := range resources {
+			names = append(names, resource.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	tests := []struct {
+		answer   string
+		expected string
+		wantErr  bool
+	}{
+		{"y\n", "a,b,c,d", false},
+		{"ALL", "a,b,c,d", false},
+		{"\n", "", false},
+		{"n", "", false},
+		{"1,3", "a,c", false},
+		{" 2-4 ", "b,c,d", false},
+		{"4,1", "a,d", false},
+		{"5", "", true},
+		{"0", "", true},
+		{"3-2", "", true},
+		{"maybe", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.answer, func(t *testing.T) {
+			selected, err := selectEntries(tt.answer, resources)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, names(selected))
+		})
+	}
+}
+
+func TestRunGC(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	deleted := filepath.Join(t.TempDir(), "deleted-app")
+
+	sessionDir := filepath.Join(home, ".claude-reactor", "default", "deleted-app-1a2b3c4d")
+	require.NoError(t, os.MkdirAll(sessionDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sessionDir, ".claude-reactor"), []byte("project_path="+deleted+"\n"), 0644))
+
+	container := pkg.Resource{Kind: pkg.ResourceContainer, Name: "claude-reactor-base-amd64-1a2b3c4d-default", ProjectHash: "1a2b3c4d", Account: "default"}
+	newApp := func() (*pkg.AppContainer, *mocks.MockDockerManager) {
+		dockerMgr := &mocks.MockDockerManager{}
+		dockerMgr.On("ListResources", mock.Anything).Return([]pkg.Resource{container}, nil)
+		app := createMockApp()
+		app.DockerMgr = dockerMgr
+		return app, dockerMgr
+	}
+
+	t.Run("declining removes nothing", func(t *testing.T) {
+		app, dockerMgr := newApp()
+		cmd := NewGCCmd(app)
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetIn(strings.NewReader("n\n"))
+		cmd.SetArgs([]string{})
+
+		require.NoError(t, cmd.ExecuteContext(context.Background()))
+		assert.Contains(t, out.String(), "Found 2 orphaned resources")
+		assert.Contains(t, out.String(), deleted)
+		dockerMgr.AssertNotCalled(t, "RemoveResource", mock.Anything, mock.Anything)
+		assert.DirExists(t, sessionDir)
+	})
+
+	t.Run("selected resources are removed", func(t *testing.T) {
+		app, dockerMgr := newApp()
+		cmd := NewGCCmd(app)
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetIn(strings.NewReader("2\n"))
+		cmd.SetArgs([]string{})
+
+		require.NoError(t, cmd.ExecuteContext(context.Background()))
+		dockerMgr.AssertNotCalled(t, "RemoveResource", mock.Anything, mock.Anything)
+		assert.NoDirExists(t, sessionDir)
+		assert.Contains(t, out.String(), "Removed 1 of 1")
+	})
+
+	t.Run("force removes everything", func(t *testing.T) {
+		require.NoError(t, os.MkdirAll(sessionDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(sessionDir, ".claude-reactor"), []byte("project_path="+deleted+"\n"), 0644))
+
+		app, dockerMgr := newApp()
+		dockerMgr.On("RemoveResource", mock.Anything, mock.Anything).Return(nil)
+		cmd := NewGCCmd(app)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--force"})
+
+		require.NoError(t, cmd.ExecuteContext(context.Background()))
+		dockerMgr.AssertCalled(t, "RemoveResource", mock.Anything, mock.MatchedBy(func(r pkg.Resource) bool {
+			return r.Name == container.Name
+		}))
+		assert.NoDirExists(t, sessionDir)
+	})
+}
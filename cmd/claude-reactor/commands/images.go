@@ -0,0 +1,307 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/layers"
+	"claude-reactor/internal/reactor/variants"
+	"claude-reactor/pkg"
+)
+
+// ImageAnalysis is the output of 'images analyze'
+type ImageAnalysis struct {
+	Image       string            `json:"image"`
+	Size        int64             `json:"size"`
+	WastedSize  int64             `json:"wasted_size"`
+	Efficiency  float64           `json:"efficiency"` // percentage of the layer contents visible in the image
+	Layers      []layers.Layer    `json:"layers"`
+	Largest     []layers.PathSize `json:"largest"`
+	Wasted      []layers.Waste    `json:"wasted,omitempty"`
+	Suggestions []string          `json:"suggestions,omitempty"`
+}
+
+// toolchain is a large component of the built-in variants, and the variant below the one that
+// adds it
+type toolchain struct {
+	name    string
+	paths   []string
+	without string
+}
+
+// toolchains lists what each built-in variant adds to the one it builds on
+var toolchains = []toolchain{
+	{"Go toolchain", []string{"/usr/local/go", "/root/go"}, "base"},
+	{"Rust toolchain", []string{"/root/.rustup", "/root/.cargo"}, "go"},
+	{"Java", []string{"/usr/lib/jvm"}, "go"},
+	{"AWS CLI", []string{"/usr/local/aws-cli"}, "full"},
+	{"Google Cloud SDK", []string{"/usr/lib/google-cloud-sdk"}, "full"},
+	{"Azure CLI", []string{"/opt/az"}, "full"},
+}
+
+// cachePaths hold package caches and documentation that images rarely need
+var cachePaths = []string{
+	"/var/lib/apt/lists", "/var/cache/apt", "/root/.cache", "/root/.npm", "/tmp",
+	"/usr/share/doc", "/usr/share/man",
+}
+
+const (
+	// minSuggestionSize is the smallest toolchain or cache worth a suggestion
+	minSuggestionSize = 10 << 20
+	// wastedThreshold is the share of wasted space worth a suggestion, in percent
+	wastedThreshold = 10
+)
+
+// NewImagesCmd creates the images command for inspecting container images
+func NewImagesCmd(app *pkg.AppContainer) *cobra.Command {
+	imagesCmd := &cobra.Command{
+		Use:   "images",
+		Short: "Inspect container images",
+		Long:  "Inspect the container images of built-in variants, external variants and custom images.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	imagesCmd.AddCommand(newImagesAnalyzeCmd(app))
+
+	return imagesCmd
+}
+
+func newImagesAnalyzeCmd(app *pkg.AppContainer) *cobra.Command {
+	analyzeCmd := &cobra.Command{
+		Use:   "analyze VARIANT|IMAGE",
+		Short: "Break down an image's layers and where its space goes",
+		Long: `Break down the layers of a variant's image or any image: the size of each layer
+and the build step that created it, the largest paths in the image, and space
+wasted on files that later layers overwrite or remove.
+
+Suggestions point out toolchains a smaller variant leaves out, caches left in
+the image, and wasted space, to help choose between variants such as full,
+cloud and k8s, or to slim a custom image.
+
+Built-in variants use their local build when there is one and their registry
+image otherwise. Images that are not available locally are pulled.`,
+		Example: `# Where does the space in the full variant go?
+claude-reactor images analyze full
+
+# Show the largest paths three levels deep
+claude-reactor images analyze cloud --depth 3 --top 20
+
+# Analyze a custom image
+claude-reactor images analyze ghcr.io/my-org/dev-image:latest --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return runImagesAnalyze(cmd, app, args[0])
+		},
+		ValidArgsFunction: completeImages(app),
+	}
+
+	analyzeCmd.Flags().Int("depth", 2, "Directory depth of the largest paths, e.g. 2 for /usr/lib")
+	analyzeCmd.Flags().Int("top", 10, "Number of largest and wasted paths to show")
+	analyzeCmd.Flags().BoolP("json", "j", false, "Output in JSON format for scripting")
+
+	return analyzeCmd
+}
+
+func runImagesAnalyze(cmd *cobra.Command, app *pkg.AppContainer, ref string) error {
+	depth, _ := cmd.Flags().GetInt("depth")
+	top, _ := cmd.Flags().GetInt("top")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if depth < 1 || top < 1 {
+		return fmt.Errorf("--depth and --top must be at least 1")
+	}
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	ctx := context.Background()
+	image, err := imageToAnalyze(ctx, app, ref)
+	if err != nil {
+		return err
+	}
+
+	app.Logger.Infof("🔍 Analyzing layers of %s...", image)
+	archive, err := app.DockerMgr.GetClient().ImageSave(ctx, []string{image})
+	if err != nil {
+		return fmt.Errorf("failed to export image %s: %w", image, err)
+	}
+	defer archive.Close()
+	report, err := layers.Analyze(archive)
+	if err != nil {
+		return fmt.Errorf("failed to analyze image %s: %w", image, err)
+	}
+
+	analysis := buildImageAnalysis(image, report, depth, top)
+	if jsonOutput {
+		return outputJSON(analysis)
+	}
+	outputImageAnalysis(analysis)
+	return nil
+}
+
+// imageToAnalyze returns the image of a variant, as run would use it, or ref itself, making
+// sure the image is available locally
+func imageToAnalyze(ctx context.Context, app *pkg.AppContainer, ref string) (string, error) {
+	image := ref
+	switch {
+	case variants.IsBuiltin(ref):
+		arch, err := app.ArchDetector.GetHostArchitecture()
+		if err != nil {
+			return "", fmt.Errorf("failed to detect architecture: %w", err)
+		}
+		local := false
+		if image, local = builtinImage(ctx, app, ref, app.DockerMgr.GetImageName(ref, arch)); local {
+			return image, nil
+		}
+	case externalVariant(app, ref) != nil:
+		definition := externalVariant(app, ref)
+		if definition.Image == "" {
+			arch, err := app.ArchDetector.GetHostArchitecture()
+			if err != nil {
+				return "", fmt.Errorf("failed to detect architecture: %w", err)
+			}
+			image = app.DockerMgr.GetImageName(ref, arch)
+			if _, err := app.ImageValidator.ValidateImage(ctx, image+":latest", false); err != nil {
+				return "", pkg.NewError(pkg.CodeImageNotBuilt, nil, "variant '%s' has not been built yet", ref).
+					WithRemediation("Build it with: claude-reactor build --image " + ref)
+			}
+			return image, nil
+		}
+		image = definition.Image
+	}
+
+	if _, err := app.ImageValidator.ValidateImage(ctx, image, true); err != nil {
+		return "", fmt.Errorf("failed to get image %s: %w", image, err)
+	}
+	return image, nil
+}
+
+// buildImageAnalysis summarizes report, keeping the top largest and wasted paths
+func buildImageAnalysis(image string, report *layers.Report, depth, top int) ImageAnalysis {
+	analysis := ImageAnalysis{
+		Image:      image,
+		Size:       report.Size,
+		WastedSize: report.WastedSize(),
+		Efficiency: 100,
+		Layers:     report.Layers,
+		Largest:    report.Largest(depth, top),
+		Wasted:     report.Wasted,
+	}
+	if report.Size > 0 {
+		analysis.Efficiency = 100 * float64(report.Size-analysis.WastedSize) / float64(report.Size)
+	}
+	if len(analysis.Wasted) > top {
+		analysis.Wasted = analysis.Wasted[:top]
+	}
+	analysis.Suggestions = imageSuggestions(report)
+	return analysis
+}
+
+// imageSuggestions returns ways to slim the image: smaller variants that leave out toolchains
+// it holds, caches left in it, and wasted space
+func imageSuggestions(report *layers.Report) []string {
+	var suggestions []string
+
+	// Group toolchains by the variant that leaves them out, in variant order
+	var order []string
+	found := map[string][]string{}
+	sizes := map[string]int64{}
+	for _, tc := range toolchains {
+		var size int64
+		for _, p := range tc.paths {
+			size += report.SizeUnder(p)
+		}
+		if size < minSuggestionSize {
+			continue
+		}
+		if _, ok := found[tc.without]; !ok {
+			order = append(order, tc.without)
+		}
+		found[tc.without] = append(found[tc.without], fmt.Sprintf("%s (%s)", tc.name, formatSize(size)))
+		sizes[tc.without] += size
+	}
+	// Each variant also leaves out everything the variants above it add; suggest the nearest first
+	for i := len(order) - 1; i >= 0; i-- {
+		variant := order[i]
+		var saving int64
+		for _, v := range order[i:] {
+			saving += sizes[v]
+		}
+		var without []string
+		for _, v := range order[i:] {
+			without = append(without, found[v]...)
+		}
+		suggestions = append(suggestions, fmt.Sprintf("The '%s' variant leaves out %s, saving about %s for projects that don't need them",
+			variant, strings.Join(without, ", "), formatSize(saving)))
+	}
+
+	var caches []string
+	for _, p := range cachePaths {
+		if size := report.SizeUnder(p); size >= minSuggestionSize {
+			caches = append(caches, fmt.Sprintf("%s (%s)", p, formatSize(size)))
+		}
+	}
+	if len(caches) > 0 {
+		suggestions = append(suggestions, fmt.Sprintf("Caches and documentation left in the image: %s; remove them in the build step that creates them",
+			strings.Join(caches, ", ")))
+	}
+
+	if wasted := report.WastedSize(); report.Size > 0 && wasted*100/report.Size >= wastedThreshold {
+		suggestions = append(suggestions, fmt.Sprintf("%s (%d%%) is taken by files that later layers overwrite or remove; delete files in the same RUN step that creates them",
+			formatSize(wasted), wasted*100/report.Size))
+	}
+	return suggestions
+}
+
+// outputImageAnalysis prints the analysis as tables
+func outputImageAnalysis(analysis ImageAnalysis) {
+	fmt.Fprintf(i18n.Stdout, "Image:      %s\n", analysis.Image)
+	fmt.Fprintf(i18n.Stdout, "Size:       %s in %d layers\n", formatSize(analysis.Size), len(analysis.Layers))
+	fmt.Fprintf(i18n.Stdout, "Wasted:     %s\n", formatSize(analysis.WastedSize))
+	fmt.Fprintf(i18n.Stdout, "Efficiency: %.1f%%\n", analysis.Efficiency)
+
+	fmt.Fprintf(i18n.Stdout, "\n%-6s %-10s %-8s %s\n", "LAYER", "SIZE", "FILES", "CREATED BY")
+	for _, layer := range analysis.Layers {
+		createdBy := layer.CreatedBy
+		if len(createdBy) > 80 {
+			createdBy = createdBy[:77] + "..."
+		}
+		fmt.Fprintf(i18n.Stdout, "%-6d %-10s %-8d %s\n", layer.Index, formatSize(layer.Size), layer.Files, createdBy)
+	}
+
+	fmt.Fprintf(i18n.Stdout, "\n%-10s %s\n", "SIZE", "LARGEST PATHS")
+	for _, largest := range analysis.Largest {
+		fmt.Fprintf(i18n.Stdout, "%-10s %s\n", formatSize(largest.Size), largest.Path)
+	}
+
+	if len(analysis.Wasted) > 0 {
+		fmt.Fprintf(i18n.Stdout, "\n%-10s %-12s %s\n", "WASTED", "LAYERS", "PATH")
+		for _, waste := range analysis.Wasted {
+			layerList := make([]string, len(waste.Layers))
+			for i, layer := range waste.Layers {
+				layerList[i] = fmt.Sprint(layer)
+			}
+			path := waste.Path
+			if waste.Removed {
+				path += " (removed)"
+			}
+			fmt.Fprintf(i18n.Stdout, "%-10s %-12s %s\n", formatSize(waste.Size), strings.Join(layerList, ","), path)
+		}
+	}
+
+	if len(analysis.Suggestions) > 0 {
+		fmt.Fprintln(i18n.Stdout)
+		for _, suggestion := range analysis.Suggestions {
+			fmt.Fprintf(i18n.Stdout, "💡 %s\n", suggestion)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/layers"
+)
+
+// imageReport analyzes a 'docker save' archive whose layers hold files of the given sizes
+func imageReport(t *testing.T, imageLayers ...map[string]int64) *layers.Report {
+	t.Helper()
+	write := func(tw *tar.Writer, name string, data []byte) {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Size: int64(len(data)), Mode: 0644}))
+		_, err := tw.Write(data)
+		require.NoError(t, err)
+	}
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	manifest := `[{"Config":"config.json","Layers":[`
+	for i, files := range imageLayers {
+		var layer bytes.Buffer
+		lw := tar.NewWriter(&layer)
+		for name, size := range files {
+			require.NoError(t, lw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Size: size, Mode: 0644}))
+			_, err := lw.Write(make([]byte, size))
+			require.NoError(t, err)
+		}
+		require.NoError(t, lw.Close())
+		name := string(rune('a'+i)) + "/layer.tar"
+		write(tw, name, layer.Bytes())
+		if i > 0 {
+			manifest += ","
+		}
+		manifest += `"` + name + `"`
+	}
+	write(tw, "manifest.json", []byte(manifest+"]}]"))
+	require.NoError(t, tw.Close())
+
+	report, err := layers.Analyze(&archive)
+	require.NoError(t, err)
+	return report
+}
+
+func TestImageSuggestions(t *testing.T) {
+	report := imageReport(t,
+		map[string]int64{
+			"usr/local/go/bin/go":             20 << 20,
+			"root/.rustup/toolchains/rustc":   30 << 20,
+			"usr/lib/jvm/java-17/lib/modules": 15 << 20,
+			"usr/local/aws-cli/aws":           12 << 20,
+			"opt/az/bin/az":                   1 << 20,
+			"var/lib/apt/lists/main":          11 << 20,
+			"usr/share/doc/readme":            1 << 10,
+		},
+		map[string]int64{"usr/local/go/bin/go": 20 << 20},
+	)
+
+	assert.Equal(t, []string{
+		"The 'full' variant leaves out AWS CLI (12.0 MB), saving about 12.0 MB for projects that don't need them",
+		"The 'go' variant leaves out Rust toolchain (30.0 MB), Java (15.0 MB), AWS CLI (12.0 MB), saving about 57.0 MB for projects that don't need them",
+		"The 'base' variant leaves out Go toolchain (20.0 MB), Rust toolchain (30.0 MB), Java (15.0 MB), AWS CLI (12.0 MB), saving about 77.0 MB for projects that don't need them",
+		"Caches and documentation left in the image: /var/lib/apt/lists (11.0 MB); remove them in the build step that creates them",
+		"20.0 MB (18%) is taken by files that later layers overwrite or remove; delete files in the same RUN step that creates them",
+	}, imageSuggestions(report))
+}
+
+func TestImageSuggestionsSlimImage(t *testing.T) {
+	report := imageReport(t, map[string]int64{"usr/bin/app": 5 << 20, "usr/local/go/bin/go": 1 << 20})
+	assert.Empty(t, imageSuggestions(report))
+}
+
+func TestBuildImageAnalysis(t *testing.T) {
+	report := imageReport(t,
+		map[string]int64{"usr/bin/a": 100, "usr/bin/b": 300, "etc/config": 50},
+		map[string]int64{"usr/bin/a": 200, "etc/config": 10},
+	)
+
+	analysis := buildImageAnalysis("example:latest", report, 1, 1)
+	assert.Equal(t, "example:latest", analysis.Image)
+	assert.Equal(t, int64(660), analysis.Size)
+	assert.Equal(t, int64(150), analysis.WastedSize)
+	assert.InDelta(t, 77.3, analysis.Efficiency, 0.1)
+	assert.Len(t, analysis.Layers, 2)
+	assert.Equal(t, []layers.PathSize{{Path: "/usr", Size: 500}}, analysis.Largest)
+	// Only the top wasted paths are kept
+	assert.Equal(t, []layers.Waste{{Path: "/usr/bin/a", Size: 100, Layers: []int{0, 1}}}, analysis.Wasted)
+}
+
+func TestNewImagesCmd(t *testing.T) {
+	cmd := NewImagesCmd(nil)
+	analyze, _, err := cmd.Find([]string{"analyze"})
+	require.NoError(t, err)
+	assert.Equal(t, "analyze", analyze.Name())
+	assert.NotNil(t, analyze.Flags().Lookup("depth"))
+	assert.NotNil(t, analyze.Flags().Lookup("top"))
+	assert.NotNil(t, analyze.Flags().Lookup("json"))
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/variants"
 	"claude-reactor/pkg"
 )
 
@@ -135,7 +137,7 @@ func scanClaudeReactorDirectory(app *pkg.AppContainer) ([]ProjectInfo, error) {
 
 	// Check if directory exists
 	if _, err := os.Stat(claudeReactorDir); os.IsNotExist(err) {
-		app.Logger.Info("No ~/.claude-reactor directory found")
+		app.Logger.Info(i18n.T("list.no_directory"))
 		return []ProjectInfo{}, nil
 	}
 
@@ -159,7 +161,7 @@ func scanClaudeReactorDirectory(app *pkg.AppContainer) ([]ProjectInfo, error) {
 		// Read project directories within this account
 		projectEntries, err := os.ReadDir(accountDir)
 		if err != nil {
-			app.Logger.Warnf("Failed to read account directory %s: %v", accountDir, err)
+			app.Logger.Warn(i18n.T("list.account_unreadable", accountDir, err))
 			continue
 		}
 
@@ -180,18 +182,7 @@ func scanClaudeReactorDirectory(app *pkg.AppContainer) ([]ProjectInfo, error) {
 			}
 
 			// Try to read config from session directory to get project path
-			projectPath := ""
-			configFile := filepath.Join(sessionDir, ".claude-reactor")
-			if data, err := os.ReadFile(configFile); err == nil {
-				// Simple parsing to extract project_path
-				lines := strings.Split(string(data), "\n")
-				for _, line := range lines {
-					if strings.HasPrefix(line, "project_path=") {
-						projectPath = strings.TrimPrefix(line, "project_path=")
-						break
-					}
-				}
-			}
+			projectPath := readSessionProjectPath(sessionDir)
 
 			// I still empty, return "unknown" or leave empty to indicate it wasn't captured
 			if projectPath == "" {
@@ -276,12 +267,16 @@ func getAssociatedContainers(app *pkg.AppContainer, account, projectHash string)
 	// Pattern: claude-reactor-{variant}-{arch}-{projectHash}-{account}
 	// We need to check for all possible variants and architectures
 
-	variants := []string{"base", "go", "full", "cloud", "k8s"}
+	variantNames := append([]string{}, variants.Builtin...)
+	definitions, _ := variants.LoadDir(variants.Dir())
+	for _, definition := range definitions {
+		variantNames = append(variantNames, definition.Name)
+	}
 	architectures := []string{"arm64", "amd64"}
 
 	var containers []string
 
-	for _, variant := range variants {
+	for _, variant := range variantNames {
 		for _, arch := range architectures {
 			// Generate container name using the same pattern as run.go
 			containerName := fmt.Sprintf("claude-reactor-%s-%s-%s-%s",
@@ -311,21 +306,31 @@ func outputJSON(v interface{}) error {
 	return nil
 }
 
+// cmdPrintf is cmd.Printf without emoji while they are off
+func cmdPrintf(cmd *cobra.Command, format string, args ...interface{}) {
+	fmt.Fprintf(i18n.Writer(cmd.OutOrStderr()), format, args...)
+}
+
+// cmdPrintln is cmd.Println without emoji while they are off
+func cmdPrintln(cmd *cobra.Command, args ...interface{}) {
+	fmt.Fprintln(i18n.Writer(cmd.OutOrStderr()), args...)
+}
+
 // outputTable outputs the results in flat table format
 func outputTable(response ListResponse) error {
 	if len(response.Projects) == 0 {
-		fmt.Println("No projects found in ~/.claude-reactor/")
-		fmt.Printf("Summary: %d accounts, %d projects, %d containers\n",
+		fmt.Fprintln(i18n.Stdout, i18n.T("list.empty"))
+		fmt.Fprintln(i18n.Stdout, i18n.T("list.summary",
 			response.Summary.TotalAccounts,
 			response.Summary.TotalProjects,
-			response.Summary.TotalContainers)
+			response.Summary.TotalContainers))
 		return nil
 	}
 
 	// Print header
-	fmt.Printf("%-15s %-20s %-8s %-10s %-20s %s\n",
+	fmt.Fprintf(i18n.Stdout, "%-15s %-20s %-8s %-10s %-20s %s\n",
 		"ACCOUNT", "PROJECT", "HASH", "CONTAINERS", "LAST USED", "PROJECT PATH")
-	fmt.Printf("%-15s %-20s %-8s %-10s %-20s %s\n",
+	fmt.Fprintf(i18n.Stdout, "%-15s %-20s %-8s %-10s %-20s %s\n",
 		strings.Repeat("-", 15),
 		strings.Repeat("-", 20),
 		strings.Repeat("-", 8),
@@ -335,12 +340,12 @@ func outputTable(response ListResponse) error {
 
 	// Print projects
 	for _, project := range response.Projects {
-		lastUsedStr := "never"
+		lastUsedStr := i18n.T("list.never")
 		if !project.LastUsed.IsZero() {
 			lastUsedStr = formatRelativeTime(project.LastUsed)
 		}
 
-		fmt.Printf("%-15s %-20s %-8s %-10d %-20s %s\n",
+		fmt.Fprintf(i18n.Stdout, "%-15s %-20s %-8s %-10d %-20s %s\n",
 			truncate(project.Account, 15),
 			truncate(project.ProjectName, 20),
 			project.ProjectHash,
@@ -350,10 +355,11 @@ func outputTable(response ListResponse) error {
 	}
 
 	// Print summary
-	fmt.Printf("\nSummary: %d accounts, %d projects, %d containers\n",
+	fmt.Fprintln(i18n.Stdout)
+	fmt.Fprintln(i18n.Stdout, i18n.T("list.summary",
 		response.Summary.TotalAccounts,
 		response.Summary.TotalProjects,
-		response.Summary.TotalContainers)
+		response.Summary.TotalContainers))
 
 	return nil
 }
@@ -363,13 +369,13 @@ func formatRelativeTime(t time.Time) string {
 	duration := time.Since(t)
 
 	if duration < time.Minute {
-		return "just now"
+		return i18n.T("time.just_now")
 	} else if duration < time.Hour {
-		return fmt.Sprintf("%dm ago", int(duration.Minutes()))
+		return i18n.T("time.minutes_ago", int(duration.Minutes()))
 	} else if duration < 24*time.Hour {
-		return fmt.Sprintf("%dh ago", int(duration.Hours()))
+		return i18n.T("time.hours_ago", int(duration.Hours()))
 	} else if duration < 7*24*time.Hour {
-		return fmt.Sprintf("%dd ago", int(duration.Hours()/24))
+		return i18n.T("time.days_ago", int(duration.Hours()/24))
 	} else {
 		return t.Format("2006-01-02")
 	}
@@ -0,0 +1,353 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/agent"
+	"claude-reactor/internal/reactor/filesync"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/mcp"
+	"claude-reactor/pkg"
+)
+
+// NewMCPCmd creates the mcp command for managing MCP servers of the containerized Claude CLI
+func NewMCPCmd(app *pkg.AppContainer) *cobra.Command {
+	var mcpCmd = &cobra.Command{
+		Use:   "mcp",
+		Short: "Manage MCP servers for Claude in the project container",
+		Long: `Manage the MCP servers Claude CLI uses in the project container.
+
+Stdio servers run in a sidecar container by default, on a network shared with
+the project container and with the project mounted at the same path. Servers
+started with npx, uvx or python get a matching image automatically. Servers
+can instead run on the host (--host), reached through the attached session, or
+inside the project container itself (--in-container).
+
+Changes apply the next time Claude starts in the container.`,
+	}
+
+	mcpCmd.AddCommand(
+		newMCPAddCmd(app),
+		newMCPListCmd(app),
+		newMCPRemoveCmd(app),
+	)
+
+	return mcpCmd
+}
+
+func newMCPAddCmd(app *pkg.AppContainer) *cobra.Command {
+	addCmd := &cobra.Command{
+		Use:   "add NAME -- COMMAND [ARGS...]",
+		Short: "Add a stdio MCP server",
+		Example: `# Run in a node sidecar, chosen from the command
+claude-reactor mcp add filesystem -- npx -y @modelcontextprotocol/server-filesystem /app
+
+# Pick the sidecar image and pass a secret
+claude-reactor mcp add github --image node:22 -e GITHUB_TOKEN=$GITHUB_TOKEN -- npx -y @modelcontextprotocol/server-github
+
+# Run a server installed on the host, e.g. one that drives a desktop app
+claude-reactor mcp add notes --host -- /usr/local/bin/notes-mcp
+
+# Run a tool the container image already has
+claude-reactor mcp add git --in-container -- uvx mcp-server-git`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return addMCPServer(cmd, app, args)
+		},
+	}
+
+	addCmd.Flags().StringP("image", "", "", "Sidecar image to run the server in")
+	addCmd.Flags().BoolP("host", "", false, "Run the server on the host, connected while a session is attached")
+	addCmd.Flags().BoolP("in-container", "", false, "Run the server inside the project container")
+	addCmd.Flags().StringArrayP("env", "e", []string{}, "Environment variable for the server, as KEY=VALUE (can be used multiple times)")
+
+	return addCmd
+}
+
+func newMCPListCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the project's MCP servers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return listMCPServers(app)
+		},
+	}
+}
+
+func newMCPRemoveCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove NAME",
+		Short: "Remove an MCP server and its sidecar",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return removeMCPServer(cmd, app, args[0])
+		},
+	}
+}
+
+// parseMCPServer builds a server from the add command's arguments and flags
+func parseMCPServer(cmd *cobra.Command, args []string) (*mcp.Server, error) {
+	image, _ := cmd.Flags().GetString("image")
+	onHost, _ := cmd.Flags().GetBool("host")
+	inContainer, _ := cmd.Flags().GetBool("in-container")
+	envs, _ := cmd.Flags().GetStringArray("env")
+
+	server := &mcp.Server{Name: args[0], Mode: mcp.ModeSidecar, Image: image, Command: args[1:]}
+	if err := mcp.ValidateName(server.Name); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case onHost && inContainer, image != "" && (onHost || inContainer):
+		return nil, fmt.Errorf("choose one of --image, --host and --in-container")
+	case onHost:
+		server.Mode = mcp.ModeHost
+	case inContainer:
+		server.Mode = mcp.ModeContainer
+	case image == "":
+		server.Image = mcp.DefaultImage(server.Command[0])
+		if server.Image == "" {
+			return nil, pkg.NewError(pkg.CodeInvalidUsage, nil, "no sidecar image known for '%s'", server.Command[0]).
+				WithRemediation("Pick one with --image, or use --host or --in-container")
+		}
+	}
+
+	for _, env := range envs {
+		key, value, found := strings.Cut(env, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid environment variable '%s': use KEY=VALUE", env)
+		}
+		if server.Env == nil {
+			server.Env = make(map[string]string)
+		}
+		server.Env[key] = value
+	}
+	return server, nil
+}
+
+// addMCPServer records a server for the project and adds it to Claude's config
+func addMCPServer(cmd *cobra.Command, app *pkg.AppContainer, args []string) error {
+	server, err := parseMCPServer(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	containerName, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath)
+
+	servers, err := mcp.Load(sessionDir)
+	if err != nil {
+		return err
+	}
+	if existing := mcp.Find(servers, server.Name); existing != nil {
+		// The sidecar was created with the old image and environment
+		if err := app.DockerMgr.RemoveSidecar(cmd.Context(), containerName, mcpSidecarName(server.Name)); err != nil {
+			app.Logger.Warnf("Failed to remove the old sidecar: %v", err)
+		}
+		*existing = *server
+	} else {
+		servers = append(servers, *server)
+	}
+	if err := mcp.Save(sessionDir, servers); err != nil {
+		return err
+	}
+	syncMCPConfig(app, config, sessionDir)
+
+	switch server.Mode {
+	case mcp.ModeSidecar:
+		app.Logger.Infof("🔌 Added MCP server %s, running in a %s sidecar", server.Name, server.Image)
+	case mcp.ModeHost:
+		app.Logger.Infof("🔌 Added MCP server %s, running on the host while a session is attached", server.Name)
+	default:
+		app.Logger.Infof("🔌 Added MCP server %s, running in the project container", server.Name)
+	}
+	app.Logger.Info("💡 Restart Claude in the container to use it")
+	return nil
+}
+
+// listMCPServers prints the project's managed servers
+func listMCPServers(app *pkg.AppContainer) error {
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	_, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	servers, err := mcp.Load(app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath))
+	if err != nil {
+		return err
+	}
+	if len(servers) == 0 {
+		fmt.Fprintln(i18n.Stdout, "No MCP servers added for this project")
+		fmt.Fprintln(i18n.Stdout, "💡 Add one with: claude-reactor mcp add NAME -- COMMAND [ARGS...]")
+		return nil
+	}
+
+	for _, server := range servers {
+		where := server.Mode
+		if server.Mode == mcp.ModeSidecar {
+			where = "sidecar " + server.Image
+		}
+		fmt.Fprintf(i18n.Stdout, "%-20s %-40s %s\n", server.Name, where, strings.Join(server.Command, " "))
+	}
+	return nil
+}
+
+// removeMCPServer forgets a server, removes its sidecar, and drops it from Claude's config
+func removeMCPServer(cmd *cobra.Command, app *pkg.AppContainer, name string) error {
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	containerName, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath)
+
+	servers, err := mcp.Load(sessionDir)
+	if err != nil {
+		return err
+	}
+	server := mcp.Find(servers, name)
+	if server == nil {
+		return pkg.NewError(pkg.CodeNotDefined, nil, "no MCP server named '%s'", name).
+			WithRemediation("See the project's servers with: claude-reactor mcp list")
+	}
+	if server.Mode == mcp.ModeSidecar {
+		if err := app.DockerMgr.RemoveSidecar(cmd.Context(), containerName, mcpSidecarName(name)); err != nil {
+			return fmt.Errorf("failed to remove sidecar: %w", err)
+		}
+	}
+
+	remaining := make([]mcp.Server, 0, len(servers)-1)
+	for _, s := range servers {
+		if s.Name != name {
+			remaining = append(remaining, s)
+		}
+	}
+	if err := mcp.Save(sessionDir, remaining); err != nil {
+		return err
+	}
+	syncMCPConfig(app, config, sessionDir)
+
+	app.Logger.Infof("🗑️  Removed MCP server %s", name)
+	return nil
+}
+
+// syncMCPConfig rewrites the MCP servers in the container's Claude config, when it has one yet
+func syncMCPConfig(app *pkg.AppContainer, config *pkg.Config, sessionDir string) {
+	projectDir := config.ProjectPath
+	propagateClaudeSettings(app, sessionDir, filepath.Join(sessionDir, ".claude.json"), projectDir, projectMountTarget(projectDir))
+}
+
+// mcpSidecarName is the sidecar name of an MCP server, also its host name on the shared network
+func mcpSidecarName(name string) string {
+	return "mcp-" + name
+}
+
+// connectMCP starts a managed MCP server for the in-container agent and connects its stdin
+// and stdout to the agent's socket. It returns once the server is starting; the connection
+// lasts until either end closes or the session ends.
+func connectMCP(ctx context.Context, app *pkg.AppContainer, containerName string, config *pkg.Config, params agent.MCPConnectParams) error {
+	if params.Socket == "" {
+		return fmt.Errorf("socket is required")
+	}
+	servers, err := mcp.Load(app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath))
+	if err != nil {
+		return err
+	}
+	server := mcp.Find(servers, params.Name)
+	if server == nil || server.Mode == mcp.ModeContainer {
+		return fmt.Errorf("no MCP server named '%s' runs outside the container", params.Name)
+	}
+
+	// toContainer carries the server's output, fromContainer Claude's requests
+	toContainerReader, toContainerWriter := io.Pipe()
+	fromContainerReader, fromContainerWriter := io.Pipe()
+
+	switch server.Mode {
+	case mcp.ModeHost:
+		command := exec.CommandContext(ctx, server.Command[0], server.Command[1:]...)
+		command.Dir = config.ProjectPath
+		command.Env = os.Environ()
+		for key, value := range server.Env {
+			command.Env = append(command.Env, key+"="+value)
+		}
+		command.Stdin = fromContainerReader
+		command.Stdout = toContainerWriter
+		if err := command.Start(); err != nil {
+			return fmt.Errorf("failed to start MCP server %s: %w", server.Name, err)
+		}
+		go func() {
+			if err := command.Wait(); err != nil {
+				app.Logger.Debugf("MCP server %s exited: %v", server.Name, err)
+			}
+			toContainerWriter.Close()
+		}()
+
+	case mcp.ModeSidecar:
+		sidecar, err := app.DockerMgr.StartSidecar(ctx, containerName, mcpSidecar(server, containerName, config))
+		if err != nil {
+			return err
+		}
+		go func() {
+			if err := app.DockerMgr.ExecPipe(ctx, sidecar, server.Command, fromContainerReader, toContainerWriter); err != nil {
+				app.Logger.Debugf("MCP server %s exited: %v", server.Name, err)
+			}
+			toContainerWriter.Close()
+		}()
+	}
+
+	go func() {
+		if err := app.DockerMgr.ExecPipe(ctx, containerName, []string{agent.BinaryPath, "pipe", params.Socket}, toContainerReader, fromContainerWriter); err != nil {
+			app.Logger.Debugf("MCP connection for %s ended: %v", server.Name, err)
+		}
+		fromContainerWriter.Close()
+	}()
+	return nil
+}
+
+// mcpSidecar describes the sidecar of a server: the project is mounted where the project
+// container has it, so paths mean the same on both sides of the network
+func mcpSidecar(server *mcp.Server, containerName string, config *pkg.Config) *pkg.Sidecar {
+	target := projectMountTarget(config.ProjectPath)
+	projectMount := pkg.Mount{Source: config.ProjectPath, Target: target, Type: "bind"}
+	if config.SyncMode {
+		projectMount = pkg.Mount{Source: filesync.VolumeName(containerName), Target: target, Type: "volume"}
+	}
+	return &pkg.Sidecar{
+		Name:        mcpSidecarName(server.Name),
+		Image:       server.Image,
+		Mounts:      []pkg.Mount{projectMount},
+		WorkingDir:  target,
+		Environment: server.Env,
+	}
+}
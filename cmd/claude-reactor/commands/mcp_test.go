@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/mcp"
+	"claude-reactor/pkg"
+)
+
+func TestParseMCPServer(t *testing.T) {
+	parse := func(args ...string) (*mcp.Server, error) {
+		cmd := newMCPAddCmd(nil)
+		require.NoError(t, cmd.ParseFlags(args))
+		return parseMCPServer(cmd, cmd.Flags().Args())
+	}
+
+	server, err := parse("github", "-e", "GITHUB_TOKEN=a=b", "--", "npx", "-y", "server-github")
+	require.NoError(t, err)
+	assert.Equal(t, mcp.ModeSidecar, server.Mode)
+	assert.Equal(t, "node:22-slim", server.Image)
+	assert.Equal(t, []string{"npx", "-y", "server-github"}, server.Command)
+	assert.Equal(t, map[string]string{"GITHUB_TOKEN": "a=b"}, server.Env)
+
+	server, err = parse("notes", "--host", "--", "/usr/local/bin/notes-mcp")
+	require.NoError(t, err)
+	assert.Equal(t, mcp.ModeHost, server.Mode)
+	assert.Empty(t, server.Image)
+
+	server, err = parse("tool", "--image", "ghcr.io/me/tool", "--", "tool-mcp")
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/me/tool", server.Image)
+
+	_, err = parse("tool", "--", "./tool-mcp")
+	assert.ErrorContains(t, err, "no sidecar image known")
+
+	_, err = parse("tool", "--host", "--in-container", "--", "tool-mcp")
+	assert.ErrorContains(t, err, "choose one of")
+
+	_, err = parse("Bad Name", "--", "npx")
+	assert.ErrorContains(t, err, "invalid MCP server name")
+
+	_, err = parse("tool", "-e", "NOVALUE", "--", "npx")
+	assert.ErrorContains(t, err, "use KEY=VALUE")
+}
+
+func TestMCPSidecar(t *testing.T) {
+	server := &mcp.Server{Name: "github", Image: "node:22-slim", Env: map[string]string{"A": "b"}}
+
+	sidecar := mcpSidecar(server, "claude-reactor-go-arm64-abcd1234-me", &pkg.Config{ProjectPath: "/home/me/project"})
+	assert.Equal(t, "mcp-github", sidecar.Name)
+	assert.Equal(t, "/app", sidecar.WorkingDir)
+	assert.Equal(t, []pkg.Mount{{Source: "/home/me/project", Target: "/app", Type: "bind"}}, sidecar.Mounts)
+	assert.Equal(t, server.Env, sidecar.Environment)
+
+	sidecar = mcpSidecar(server, "claude-reactor-go-arm64-abcd1234-me", &pkg.Config{ProjectPath: "/home/me/project", SyncMode: true})
+	assert.Equal(t, "volume", sidecar.Mounts[0].Type)
+}
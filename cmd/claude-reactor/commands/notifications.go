@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"claude-reactor/internal/reactor/notify"
+	"claude-reactor/pkg"
+)
+
+// defaultNotifyAfter is how long a Claude task must run before its end is notified
+const defaultNotifyAfter = time.Minute
+
+// healthCheckInterval is how often the container of an attached session is checked
+const healthCheckInterval = 15 * time.Second
+
+// claudeTaskHooks makes Claude CLI report through the agent when it starts and stops working
+// on a prompt, so the host can tell when a long task has finished
+const claudeTaskHooks = `{"hooks":{` +
+	`"UserPromptSubmit":[{"hooks":[{"type":"command","command":"claude-reactor-agent status busy >/dev/null 2>&1 || true"}]}],` +
+	`"Stop":[{"hooks":[{"type":"command","command":"claude-reactor-agent status idle >/dev/null 2>&1 || true"}]}]}}`
+
+// projectNotifier returns the notifier for the event types enabled in config
+func projectNotifier(app *pkg.AppContainer, config *pkg.Config) *notify.Notifier {
+	events, err := notify.ParseEvents(config.Notifications)
+	if err != nil {
+		app.Logger.Warnf("%v; notifications disabled", err)
+	}
+	return notify.NewNotifier(events)
+}
+
+// notifyAfter returns the minimum task duration set by notify_after
+func notifyAfter(config *pkg.Config) (time.Duration, error) {
+	if config.NotifyAfter == "" {
+		return defaultNotifyAfter, nil
+	}
+	after, err := time.ParseDuration(config.NotifyAfter)
+	if err != nil || after < 0 {
+		return 0, fmt.Errorf("invalid notify_after '%s': use a duration such as 30s or 5m", config.NotifyAfter)
+	}
+	return after, nil
+}
+
+// notifyBuild reports a finished or failed image build
+func notifyBuild(app *pkg.AppContainer, notifier *notify.Notifier, variant string, started time.Time, buildErr error) {
+	message := fmt.Sprintf("Built %s in %s", variant, time.Since(started).Round(time.Second))
+	if buildErr != nil {
+		message = fmt.Sprintf("Building %s failed: %v", variant, buildErr)
+	}
+	if err := notifier.Notify(notify.EventBuild, "claude-reactor build", message); err != nil {
+		app.Logger.Debugf("Failed to send notification: %v", err)
+	}
+}
+
+// taskTracker times Claude tasks from the status reports of its hooks
+type taskTracker struct {
+	mu        sync.Mutex
+	busySince time.Time
+}
+
+// update records a reported state. It returns how long a task ran when the state ends one.
+func (t *taskTracker) update(state string, now time.Time) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if state == "busy" {
+		if t.busySince.IsZero() {
+			t.busySince = now
+		}
+		return 0, false
+	}
+	if t.busySince.IsZero() {
+		return 0, false
+	}
+	elapsed := now.Sub(t.busySince)
+	t.busySince = time.Time{}
+	return elapsed, true
+}
+
+// taskNotifier returns a callback for agent status reports that notifies when a task that
+// ran for at least notify_after finishes
+func taskNotifier(app *pkg.AppContainer, notifier *notify.Notifier, config *pkg.Config) func(state string) {
+	if !notifier.Enabled(notify.EventTask) {
+		return func(string) {}
+	}
+	after, err := notifyAfter(config)
+	if err != nil {
+		app.Logger.Warnf("%v; using %s", err, defaultNotifyAfter)
+		after = defaultNotifyAfter
+	}
+
+	tracker := &taskTracker{}
+	return func(state string) {
+		elapsed, finished := tracker.update(state, time.Now())
+		if !finished || elapsed < after {
+			return
+		}
+		message := fmt.Sprintf("Claude finished in %s after %s", filepath.Base(config.ProjectPath), elapsed.Round(time.Second))
+		if err := notifier.Notify(notify.EventTask, "claude-reactor", message); err != nil {
+			app.Logger.Debugf("Failed to send notification: %v", err)
+		}
+	}
+}
+
+// monitorContainer notifies when the container of an attached session stops or turns
+// unhealthy, until ctx ends
+func monitorContainer(ctx context.Context, app *pkg.AppContainer, notifier *notify.Notifier, containerName string) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	reported := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		status, err := app.DockerMgr.GetContainerStatus(ctx, containerName)
+		if err != nil || ctx.Err() != nil {
+			continue
+		}
+		message, unhealthy := containerHealthProblem(status)
+		if unhealthy && !reported {
+			if err := notifier.Notify(notify.EventUnhealthy, "claude-reactor", message); err != nil {
+				app.Logger.Debugf("Failed to send notification: %v", err)
+			}
+		}
+		reported = unhealthy
+		if !status.Running {
+			return
+		}
+	}
+}
+
+// containerHealthProblem describes what is wrong with a container, if anything
+func containerHealthProblem(status *pkg.ContainerStatus) (string, bool) {
+	switch {
+	case !status.Exists:
+		return fmt.Sprintf("Container %s was removed", status.Name), true
+	case !status.Running:
+		return fmt.Sprintf("Container %s stopped", status.Name), true
+	case status.Health == "unhealthy":
+		return fmt.Sprintf("Container %s is unhealthy", status.Name), true
+	}
+	return "", false
+}
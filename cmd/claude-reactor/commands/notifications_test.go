@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func TestNotifyAfter(t *testing.T) {
+	after, err := notifyAfter(&pkg.Config{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultNotifyAfter, after)
+
+	after, err = notifyAfter(&pkg.Config{NotifyAfter: "5m"})
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, after)
+
+	_, err = notifyAfter(&pkg.Config{NotifyAfter: "soon"})
+	assert.ErrorContains(t, err, "invalid notify_after 'soon'")
+
+	_, err = notifyAfter(&pkg.Config{NotifyAfter: "-1m"})
+	assert.Error(t, err)
+}
+
+func TestTaskTracker(t *testing.T) {
+	tracker := &taskTracker{}
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	_, finished := tracker.update("idle", start)
+	assert.False(t, finished, "idle without a running task ends nothing")
+
+	_, finished = tracker.update("busy", start)
+	assert.False(t, finished)
+	_, finished = tracker.update("busy", start.Add(time.Minute))
+	assert.False(t, finished, "a repeated busy report keeps the original start")
+
+	elapsed, finished := tracker.update("idle", start.Add(3*time.Minute))
+	assert.True(t, finished)
+	assert.Equal(t, 3*time.Minute, elapsed)
+
+	_, finished = tracker.update("idle", start.Add(4*time.Minute))
+	assert.False(t, finished)
+}
+
+func TestContainerHealthProblem(t *testing.T) {
+	_, unhealthy := containerHealthProblem(&pkg.ContainerStatus{Name: "c", Exists: true, Running: true, Health: "healthy"})
+	assert.False(t, unhealthy)
+
+	message, unhealthy := containerHealthProblem(&pkg.ContainerStatus{Name: "c", Exists: true, Running: true, Health: "unhealthy"})
+	assert.True(t, unhealthy)
+	assert.Equal(t, "Container c is unhealthy", message)
+
+	message, unhealthy = containerHealthProblem(&pkg.ContainerStatus{Name: "c", Exists: true})
+	assert.True(t, unhealthy)
+	assert.Equal(t, "Container c stopped", message)
+}
+
+func TestClaudeTaskHooksIsValidJSON(t *testing.T) {
+	var settings map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(claudeTaskHooks), &settings))
+	assert.Contains(t, settings, "hooks")
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/pkg"
+)
+
+// listeningURLPattern matches local server URLs printed by dev servers (vite, next, rails, etc.)
+var listeningURLPattern = regexp.MustCompile(`https?://(?:localhost|127\.0\.0\.1|0\.0\.0\.0|\[::\]|\[::1\]):(\d+)(/[^\s"'<>]*)?`)
+
+// NewOpenCmd creates the open command for launching container web servers in the host browser
+func NewOpenCmd(app *pkg.AppContainer) *cobra.Command {
+	var openCmd = &cobra.Command{
+		Use:   "open [container-port]",
+		Short: "Open a web server running in the container in your browser",
+		Long: `Open a web server running in the project container in the host browser.
+
+Looks at ports published by the container, ports forwarded with
+'claude-reactor forward', and listening URLs printed in recent container logs,
+then opens the best reachable match. Pass a container port to choose explicitly.`,
+		Example: `# Open the detected dev server
+claude-reactor open
+
+# Open whatever is reachable for container port 3000
+claude-reactor open 3000
+
+# Print the URL instead of opening a browser
+claude-reactor open --print`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return openContainerURL(cmd, app, args)
+		},
+	}
+
+	openCmd.Flags().BoolP("print", "p", false, "Print the URL instead of opening a browser")
+
+	return openCmd
+}
+
+// openContainerURL finds the best host URL for a container web server and opens it
+func openContainerURL(cmd *cobra.Command, app *pkg.AppContainer, args []string) error {
+	ctx := cmd.Context()
+	printOnly, _ := cmd.Flags().GetBool("print")
+
+	wantPort := 0
+	if len(args) == 1 {
+		port, err := parsePort(args[0])
+		if err != nil {
+			return err
+		}
+		wantPort = port
+	}
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+
+	containerName, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+
+	status, err := app.DockerMgr.GetContainerStatus(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("failed to get container status: %w", err)
+	}
+	if !status.Running {
+		return pkg.NewError(pkg.CodeContainerNotRunning, nil, "container %s is not running", containerName).
+			WithRemediation("Start it first with: claude-reactor run")
+	}
+
+	forwardsDir := filepath.Join(app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath), "forwards")
+	reachable := append(append([]pkg.PortMapping{}, status.Ports...), activeForwards(forwardsDir)...)
+
+	var logURLs []string
+	if logs, err := app.DockerMgr.GetContainerLogs(ctx, status.ID); err == nil && logs != nil {
+		data, _ := io.ReadAll(logs)
+		logs.Close()
+		logURLs = listeningURLPattern.FindAllString(string(data), -1)
+	}
+
+	url, ok := selectOpenURL(reachable, logURLs, wantPort, portReachable)
+	if !ok {
+		if wantPort != 0 {
+			return pkg.NewError(pkg.CodePortUnreachable, nil, "container port %d is not reachable from the host", wantPort).
+				WithRemediation(fmt.Sprintf("Forward it with: claude-reactor forward %d", wantPort))
+		}
+		return pkg.NewError(pkg.CodePortUnreachable, nil, "no reachable web server found for %s", containerName).
+			WithRemediation("Forward the server's port with: claude-reactor forward <port>")
+	}
+
+	if printOnly {
+		cmd.Println(url)
+		return nil
+	}
+
+	app.Logger.Infof("🌐 Opening %s", url)
+	if err := openBrowser(url); err != nil {
+		return pkg.NewError(pkg.CodeBrowserFailed, err, "failed to open browser").WithRemediation("Open " + url + " manually")
+	}
+	return nil
+}
+
+// selectOpenURL picks the best host URL. Log URLs win because they carry the server's path;
+// the most recently logged one is preferred. Otherwise the first reachable mapping is used.
+func selectOpenURL(reachable []pkg.PortMapping, logURLs []string, wantPort int, isReachable func(int) bool) (string, bool) {
+	hostPortFor := func(containerPort int) (int, bool) {
+		for _, m := range reachable {
+			if m.ContainerPort == containerPort && isReachable(m.HostPort) {
+				return m.HostPort, true
+			}
+		}
+		return 0, false
+	}
+
+	for i := len(logURLs) - 1; i >= 0; i-- {
+		match := listeningURLPattern.FindStringSubmatch(logURLs[i])
+		containerPort, _ := strconv.Atoi(match[1])
+		if wantPort != 0 && containerPort != wantPort {
+			continue
+		}
+		if hostPort, ok := hostPortFor(containerPort); ok {
+			scheme := "http"
+			if strings.HasPrefix(logURLs[i], "https") {
+				scheme = "https"
+			}
+			return fmt.Sprintf("%s://localhost:%d%s", scheme, hostPort, match[2]), true
+		}
+	}
+
+	for _, m := range reachable {
+		if wantPort != 0 && m.ContainerPort != wantPort {
+			continue
+		}
+		if isReachable(m.HostPort) {
+			return fmt.Sprintf("http://localhost:%d", m.HostPort), true
+		}
+	}
+
+	return "", false
+}
+
+// portReachable checks whether something is accepting connections on a host port
+func portReachable(port int) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)), 500*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// openBrowser opens a URL with the platform's default handler
+func openBrowser(url string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url).Start()
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start()
+	default:
+		return exec.Command("xdg-open", url).Start()
+	}
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"claude-reactor/pkg"
+)
+
+func TestNewOpenCmd(t *testing.T) {
+	t.Run("create open command", func(t *testing.T) {
+		app := createMockApp()
+		cmd := NewOpenCmd(app)
+
+		assert.Equal(t, "open [container-port]", cmd.Use)
+		assert.Contains(t, cmd.Short, "browser")
+		assert.NotNil(t, cmd.Flags().Lookup("print"))
+	})
+
+	t.Run("open command with nil app shows help", func(t *testing.T) {
+		cmd := NewOpenCmd(nil)
+
+		err := cmd.RunE(cmd, []string{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestSelectOpenURL(t *testing.T) {
+	allReachable := func(int) bool { return true }
+	noneReachable := func(int) bool { return false }
+
+	reachable := []pkg.PortMapping{
+		{HostPort: 8080, ContainerPort: 3000},
+		{HostPort: 5173, ContainerPort: 5173},
+	}
+
+	t.Run("log URL path is kept and mapped to host port", func(t *testing.T) {
+		logs := []string{"http://localhost:3000/app"}
+
+		url, ok := selectOpenURL(reachable, logs, 0, allReachable)
+		assert.True(t, ok)
+		assert.Equal(t, "http://localhost:8080/app", url)
+	})
+
+	t.Run("most recent log URL wins", func(t *testing.T) {
+		logs := []string{"http://localhost:3000/", "https://0.0.0.0:5173/"}
+
+		url, ok := selectOpenURL(reachable, logs, 0, allReachable)
+		assert.True(t, ok)
+		assert.Equal(t, "https://localhost:5173/", url)
+	})
+
+	t.Run("log URL for unreachable port falls back to mapping", func(t *testing.T) {
+		logs := []string{"http://localhost:9999/"}
+
+		url, ok := selectOpenURL(reachable, logs, 0, allReachable)
+		assert.True(t, ok)
+		assert.Equal(t, "http://localhost:8080", url)
+	})
+
+	t.Run("explicit container port", func(t *testing.T) {
+		url, ok := selectOpenURL(reachable, nil, 5173, allReachable)
+		assert.True(t, ok)
+		assert.Equal(t, "http://localhost:5173", url)
+	})
+
+	t.Run("nothing reachable", func(t *testing.T) {
+		_, ok := selectOpenURL(reachable, []string{"http://localhost:3000/"}, 0, noneReachable)
+		assert.False(t, ok)
+	})
+}
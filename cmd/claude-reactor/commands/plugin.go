@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/plugins"
+	"claude-reactor/pkg"
+)
+
+// NewPluginCmd creates the plugin command for inspecting installed plugins
+func NewPluginCmd(app *pkg.AppContainer) *cobra.Command {
+	var pluginCmd = &cobra.Command{
+		Use:   "plugin",
+		Short: "Inspect subcommand plugins",
+		Long: `Plugins add subcommands without changing claude-reactor itself.
+
+Any executable on PATH named claude-reactor-<name> runs as 'claude-reactor <name>',
+receiving the remaining arguments unchanged. CLAUDE_REACTOR_BIN points at the
+running claude-reactor binary so plugins can call back into it.
+
+An optional manifest next to the executable, claude-reactor-<name>.yaml, supplies
+help text and completions:
+
+  short: Deploy the project to an environment
+  long: |
+    Builds the project in its container and deploys it.
+  example: claude-reactor deploy staging --dry-run
+  args: [staging, production]
+  flags: [--dry-run, --force]
+
+Plugins cannot replace built-in commands.`,
+	}
+
+	pluginCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List plugins found on PATH",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listPlugins(cmd)
+		},
+	})
+
+	return pluginCmd
+}
+
+// listPlugins prints discovered plugins and any problems with them
+func listPlugins(cmd *cobra.Command) error {
+	discovered := plugins.Discover(os.Getenv("PATH"))
+	if len(discovered) == 0 {
+		fmt.Fprintln(i18n.Stdout, "No plugins found on PATH")
+		fmt.Fprintf(i18n.Stdout, "💡 Install an executable named %s<name> to add 'claude-reactor <name>'\n", plugins.Prefix)
+		return nil
+	}
+
+	root := cmd.Root()
+	for _, plugin := range discovered {
+		fmt.Fprintf(i18n.Stdout, "%-16s %s\n", plugin.Name, plugin.Path)
+		if builtinCommand(root, plugin.Name) {
+			fmt.Fprintf(i18n.Stdout, "  ⚠️  ignored: '%s' is a built-in command\n", plugin.Name)
+		}
+		if plugin.ManifestError != nil {
+			fmt.Fprintf(i18n.Stdout, "  ⚠️  %v\n", plugin.ManifestError)
+		}
+		for _, shadowed := range plugin.Shadowed {
+			fmt.Fprintf(i18n.Stdout, "  ⚠️  shadows %s\n", shadowed)
+		}
+	}
+	return nil
+}
+
+// AddPluginCommands registers a subcommand for each plugin on PATH that does not clash
+// with a built-in command
+func AddPluginCommands(root *cobra.Command, app *pkg.AppContainer) {
+	for _, plugin := range plugins.Discover(os.Getenv("PATH")) {
+		if builtinCommand(root, plugin.Name) {
+			if app != nil {
+				app.Logger.Debugf("Ignoring plugin %s: '%s' is a built-in command", plugin.Path, plugin.Name)
+			}
+			continue
+		}
+		root.AddCommand(newPluginCommand(plugin))
+	}
+}
+
+// builtinCommand reports whether name is already a command or alias of root
+func builtinCommand(root *cobra.Command, name string) bool {
+	for _, command := range root.Commands() {
+		if command.Annotations["plugin"] != "" {
+			continue
+		}
+		if command.Name() == name || command.HasAlias(name) {
+			return true
+		}
+	}
+	return name == "help"
+}
+
+// newPluginCommand wraps a plugin executable as a subcommand
+func newPluginCommand(plugin *plugins.Plugin) *cobra.Command {
+	short := "Plugin: " + plugin.Path
+	var long, example string
+	if plugin.Manifest != nil {
+		if plugin.Manifest.Short != "" {
+			short = plugin.Manifest.Short
+		}
+		long = strings.TrimSpace(plugin.Manifest.Long)
+		example = plugin.Manifest.Example
+	}
+
+	return &cobra.Command{
+		Use:                plugin.Name,
+		Short:              short,
+		Long:               long,
+		Example:            example,
+		Annotations:        map[string]string{"plugin": plugin.Path},
+		DisableFlagParsing: true, // Arguments, including --help, belong to the plugin
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return plugin.Manifest.Complete(args, toComplete), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlugin(plugin, args)
+		},
+	}
+}
+
+// runPlugin runs the plugin, passing its exit code through
+func runPlugin(plugin *plugins.Plugin, args []string) error {
+	err := plugin.Command(args).Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &pkg.ExitError{Code: exitErr.ExitCode()}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run plugin %s: %w", plugin.Path, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func TestNewPluginCmd(t *testing.T) {
+	cmd := NewPluginCmd(createMockApp())
+
+	assert.Equal(t, "plugin", cmd.Use)
+	require.Len(t, cmd.Commands(), 1)
+	assert.Equal(t, "list", cmd.Commands()[0].Name())
+}
+
+func TestAddPluginCommands(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test plugins are shell scripts")
+	}
+
+	dir := t.TempDir()
+	script := func(name, body string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "claude-reactor-"+name), []byte("#!/bin/sh\n"+body+"\n"), 0755))
+	}
+	script("hello", "exit 0")
+	script("fail", "exit 3")
+	script("run", "exit 0")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "claude-reactor-hello.yaml"), []byte("short: Say hello\nargs: [world]\n"), 0644))
+	t.Setenv("PATH", dir)
+
+	root := &cobra.Command{Use: "claude-reactor"}
+	root.AddCommand(NewRunCmd(nil))
+	AddPluginCommands(root, createMockApp())
+
+	commands := map[string]*cobra.Command{}
+	for _, command := range root.Commands() {
+		commands[command.Name()] = command
+	}
+
+	t.Run("built-in commands win", func(t *testing.T) {
+		assert.Len(t, commands, 3)
+		assert.Empty(t, commands["run"].Annotations["plugin"])
+	})
+
+	t.Run("manifest supplies help and completions", func(t *testing.T) {
+		hello := commands["hello"]
+		require.NotNil(t, hello)
+		assert.Equal(t, "Say hello", hello.Short)
+		assert.True(t, hello.DisableFlagParsing)
+
+		completions, _ := hello.ValidArgsFunction(hello, nil, "w")
+		assert.Equal(t, []string{"world"}, completions)
+	})
+
+	t.Run("exit codes pass through", func(t *testing.T) {
+		assert.NoError(t, commands["hello"].RunE(commands["hello"], []string{"--help"}))
+
+		err := commands["fail"].RunE(commands["fail"], nil)
+		var exitErr *pkg.ExitError
+		require.True(t, errors.As(err, &exitErr))
+		assert.Equal(t, 3, exitErr.Code)
+	})
+}
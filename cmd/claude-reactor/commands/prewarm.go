@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// NewPrewarmCmd creates the prewarm command, which prepares containers for recently used
+// projects so 'run' only has to start and attach
+func NewPrewarmCmd(app *pkg.AppContainer) *cobra.Command {
+	var prewarmCmd = &cobra.Command{
+		Use:   "prewarm",
+		Short: "Pre-create containers for recently used projects",
+		Long: `Prepare containers for your most recently used projects ahead of time.
+
+For each project, prewarm pulls the image if it is missing and creates the
+project container without starting it, using the project's saved configuration.
+The next 'claude-reactor run' in that project starts the waiting container
+instead of creating one. Projects whose container is up to date, or running,
+are left alone.
+
+With --watch, prewarm keeps running and repeats every --interval, e.g. from a
+login item or a systemd user service.`,
+		Example: `# Prepare the three most recently used projects
+claude-reactor prewarm
+
+# Show what would be prepared
+claude-reactor prewarm --limit 5 --dry-run
+
+# Keep containers warm in the background
+claude-reactor prewarm --watch --interval 30m`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return runPrewarm(cmd, app)
+		},
+	}
+
+	prewarmCmd.Flags().IntP("limit", "n", 3, "Number of recently used projects to prepare")
+	prewarmCmd.Flags().Duration("max-age", 7*24*time.Hour, "Skip projects not used within this long")
+	prewarmCmd.Flags().Bool("watch", false, "Keep running and prepare projects every --interval")
+	prewarmCmd.Flags().Duration("interval", time.Hour, "How often to prepare projects with --watch")
+	prewarmCmd.Flags().Bool("dry-run", false, "Show the projects that would be prepared")
+
+	return prewarmCmd
+}
+
+func runPrewarm(cmd *cobra.Command, app *pkg.AppContainer) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	maxAge, _ := cmd.Flags().GetDuration("max-age")
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if limit < 1 {
+		return fmt.Errorf("--limit must be at least 1")
+	}
+	if watch && interval < time.Minute {
+		return fmt.Errorf("--interval must be at least 1m")
+	}
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	for {
+		projects, err := scanClaudeReactorDirectory(app)
+		if err != nil {
+			return fmt.Errorf("failed to scan claude-reactor directory: %w", err)
+		}
+		candidates := recentProjects(projects, limit, maxAge, time.Now())
+		if len(candidates) == 0 {
+			fmt.Fprintln(i18n.Stdout, i18n.T("prewarm.none"))
+		}
+		if dryRun {
+			for _, project := range candidates {
+				fmt.Fprintf(i18n.Stdout, "%-15s %-20s %s\n", project.Account, formatRelativeTime(project.LastUsed), project.ProjectPath)
+			}
+			return nil
+		}
+		prewarmProjects(ctx, app, candidates)
+
+		if !watch {
+			return nil
+		}
+		app.Logger.Info(i18n.T("prewarm.next", interval))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// recentProjects returns up to limit projects used within maxAge whose directory still
+// exists, most recently used first
+func recentProjects(projects []ProjectInfo, limit int, maxAge time.Duration, now time.Time) []ProjectInfo {
+	var recent []ProjectInfo
+	for _, project := range projects {
+		if len(recent) == limit {
+			break
+		}
+		if project.LastUsed.IsZero() || now.Sub(project.LastUsed) > maxAge {
+			continue
+		}
+		if info, err := os.Stat(project.ProjectPath); err != nil || !info.IsDir() {
+			continue
+		}
+		recent = append(recent, project)
+	}
+	return recent
+}
+
+// prewarmProjects prepares each project's container from its directory, reporting failures
+// without stopping
+func prewarmProjects(ctx context.Context, app *pkg.AppContainer, projects []ProjectInfo) {
+	wd, err := os.Getwd()
+	if err != nil {
+		app.Logger.Error(i18n.T("prewarm.no_cwd", err))
+		return
+	}
+	defer os.Chdir(wd)
+
+	prepared := 0
+	for _, project := range projects {
+		if ctx.Err() != nil {
+			return
+		}
+		app.Logger.Info(i18n.T("prewarm.project", project.ProjectPath, project.Account))
+		if err := os.Chdir(project.ProjectPath); err != nil {
+			app.Logger.Warn(i18n.T("prewarm.skipping", project.ProjectPath, err))
+			continue
+		}
+
+		// prepareContainer reads its options from flags
+		flags := &cobra.Command{}
+		flags.Flags().String("account", project.Account, "")
+		flags.Flags().Bool("prewarm", true, "")
+		if _, err := prepareContainer(ctx, flags, app, true); err != nil {
+			app.Logger.Warn(i18n.T("prewarm.failed", project.ProjectPath, err))
+			continue
+		}
+		prepared++
+	}
+	app.Logger.Info(i18n.T("prewarm.done", prepared, len(projects)))
+}
+
+// prewarmContainer creates the project container without starting it, pulling its image if
+// needed. Containers that are running, or ready to resume, are left as they are.
+func prewarmContainer(ctx context.Context, app *pkg.AppContainer, containerConfig *pkg.ContainerConfig, config *pkg.Config, status *pkg.ContainerStatus, action containerAction) (*preparedContainer, error) {
+	prepared := &preparedContainer{Name: containerConfig.Name, Config: config, SyncMode: containerConfig.SyncMode}
+	switch {
+	case status != nil && status.Running:
+		app.Logger.Info(i18n.T("prewarm.running", containerConfig.Name))
+		prepared.ID = status.ID
+		return prepared, nil
+	case action == actionResume:
+		app.Logger.Info(i18n.T("prewarm.warm", containerConfig.Name))
+		prepared.ID = status.ID
+		return prepared, nil
+	case action == actionRecreate:
+		if err := app.DockerMgr.RemoveContainer(ctx, status.ID); err != nil {
+			return nil, fmt.Errorf("failed to remove outdated container: %w", err)
+		}
+	}
+
+	if _, err := app.ImageValidator.ValidateImage(ctx, containerConfig.Image, true); err != nil {
+		return nil, fmt.Errorf("failed to pull image %s: %w", containerConfig.Image, err)
+	}
+	containerID, err := app.DockerMgr.CreateContainer(ctx, containerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container: %w", err)
+	}
+	app.Logger.Info(i18n.T("prewarm.created", containerConfig.Name))
+	prepared.ID = containerID
+	return prepared, nil
+}
@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentProjects(t *testing.T) {
+	now := time.Now()
+	existing := t.TempDir()
+	projects := []ProjectInfo{
+		{ProjectName: "today", ProjectPath: existing, LastUsed: now.Add(-time.Hour)},
+		{ProjectName: "gone", ProjectPath: "/nonexistent/project", LastUsed: now.Add(-2 * time.Hour)},
+		{ProjectName: "unknown", ProjectPath: "(unknown)", LastUsed: now.Add(-3 * time.Hour)},
+		{ProjectName: "yesterday", ProjectPath: existing, LastUsed: now.Add(-24 * time.Hour)},
+		{ProjectName: "last month", ProjectPath: existing, LastUsed: now.Add(-30 * 24 * time.Hour)},
+	}
+
+	names := func(projects []ProjectInfo) []string {
+		var names []string
+		for _, project := range projects {
+			names = append(names, project.ProjectName)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"today", "yesterday"}, names(recentProjects(projects, 3, 7*24*time.Hour, now)))
+	assert.Equal(t, []string{"today"}, names(recentProjects(projects, 1, 7*24*time.Hour, now)))
+	assert.Equal(t, []string{"today", "yesterday", "last month"}, names(recentProjects(projects, 5, 60*24*time.Hour, now)))
+}
+
+func TestPrewarmLimitValidation(t *testing.T) {
+	cmd := NewPrewarmCmd(createMockApp())
+	cmd.SetArgs([]string{"--limit", "0"})
+	assert.ErrorContains(t, cmd.Execute(), "--limit")
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/ci"
+	"claude-reactor/internal/reactor/logging"
+	"claude-reactor/pkg"
+)
+
+// NewPromptCmd creates the prompt command for running Claude CLI headlessly
+func NewPromptCmd(app *pkg.AppContainer) *cobra.Command {
+	var promptCmd = &cobra.Command{
+		Use:   "prompt \"<prompt>\"",
+		Short: "Run a single prompt through Claude CLI and print the response",
+		Long: `Run a single prompt through Claude CLI in the project container without an
+interactive session.
+
+Starts the project container (or reuses a running one), runs 'claude -p' with
+the prompt, and writes the response to stdout or to --output. Log messages go
+to stderr so the response can be piped. Context files given with --file are
+prepended to the prompt.
+
+With --annotate, Claude is asked to report findings as 'path:line: severity:
+message' and each finding is also printed as a GitHub Actions annotation, so
+it shows up inline on the pull request.`,
+		Example: `# Ask a question about the project
+claude-reactor prompt "Summarise the architecture of this repository"
+
+# Include a context file and save the response
+claude-reactor prompt "Review this design" --file design.md --output review.md
+
+# Use in a pipeline
+claude-reactor prompt "List TODOs as JSON" | jq .
+
+# Review changes in GitHub Actions with inline annotations
+claude-reactor prompt "Review this branch for bugs" --output review.md --annotate`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return runPrompt(cmd, app, args[0])
+		},
+	}
+
+	promptCmd.Flags().StringSliceP("file", "f", []string{}, "Context file to include with the prompt (can be used multiple times)")
+	promptCmd.Flags().StringP("output", "o", "", "Write the response to a file instead of stdout")
+	promptCmd.Flags().StringP("image", "", "", "Container image (base, go, full, cloud, k8s, or custom Docker image)")
+	promptCmd.Flags().StringP("account", "", "", "Claude account to use")
+	promptCmd.Flags().BoolP("annotate", "", false, "Print findings as GitHub Actions annotations")
+
+	promptCmd.RegisterFlagCompletionFunc("image", completeImages(app))
+	promptCmd.RegisterFlagCompletionFunc("account", completeAccounts(app))
+
+	return promptCmd
+}
+
+// runPrompt prepares the project container and runs Claude CLI in print mode
+func runPrompt(cmd *cobra.Command, app *pkg.AppContainer, prompt string) error {
+	ctx := cmd.Context()
+	files, _ := cmd.Flags().GetStringSlice("file")
+	output, _ := cmd.Flags().GetString("output")
+	annotate, _ := cmd.Flags().GetBool("annotate")
+
+	fullPrompt, err := buildPrompt(prompt, files)
+	if err != nil {
+		return err
+	}
+	if annotate {
+		fullPrompt += "\n\n" + ci.FindingsInstruction
+	}
+
+	// Keep stdout for the response
+	logging.UseStderr(app.Logger)
+
+	prepared, err := prepareContainer(ctx, cmd, app, true)
+	if err != nil {
+		return err
+	}
+
+	command := []string{"claude", "-p", fullPrompt}
+	if prepared.Config.DangerMode {
+		command = append(command, "--dangerously-skip-permissions")
+	}
+
+	app.Logger.Info("🤖 Running prompt with Claude CLI...")
+	response, exitCode, err := app.DockerMgr.ExecCommand(ctx, prepared.Name, command)
+	if err != nil {
+		return fmt.Errorf("failed to run Claude CLI: %w", err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("claude exited with code %d: %s", exitCode, strings.TrimSpace(response))
+	}
+
+	if output == "" {
+		fmt.Fprint(cmd.OutOrStdout(), response)
+	} else {
+		if err := os.WriteFile(output, []byte(response), 0644); err != nil {
+			return fmt.Errorf("failed to write response to %s: %w", output, err)
+		}
+		app.Logger.Infof("✅ Response written to %s", output)
+	}
+
+	if annotate {
+		findings := ci.ParseFindings(response)
+		// Workflow commands must start a line, so keep them clear of the response text
+		fmt.Fprint(cmd.OutOrStdout(), "\n"+ci.FormatAnnotations(findings))
+		app.Logger.Infof("📝 Reported %d findings as annotations", len(findings))
+	}
+	return nil
+}
+
+// buildPrompt prepends the contents of context files to the prompt
+func buildPrompt(prompt string, files []string) (string, error) {
+	if strings.TrimSpace(prompt) == "" {
+		return "", fmt.Errorf("prompt cannot be empty")
+	}
+
+	var b strings.Builder
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read context file: %w", err)
+		}
+		fmt.Fprintf(&b, "<file path=%q>\n%s\n</file>\n\n", file, strings.TrimRight(string(data), "\n"))
+	}
+	b.WriteString(prompt)
+	return b.String(), nil
+}
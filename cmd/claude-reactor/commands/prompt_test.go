@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPromptCmd(t *testing.T) {
+	t.Run("create prompt command", func(t *testing.T) {
+		cmd := NewPromptCmd(createMockApp())
+
+		assert.Equal(t, "prompt \"<prompt>\"", cmd.Use)
+		assert.NotNil(t, cmd.Flags().Lookup("file"))
+		assert.NotNil(t, cmd.Flags().Lookup("output"))
+		assert.NotNil(t, cmd.Flags().Lookup("image"))
+		assert.NotNil(t, cmd.Flags().Lookup("account"))
+	})
+
+	t.Run("prompt command with nil app shows help", func(t *testing.T) {
+		cmd := NewPromptCmd(nil)
+
+		err := cmd.RunE(cmd, []string{"hello"})
+		assert.NoError(t, err)
+	})
+}
+
+func TestBuildPrompt(t *testing.T) {
+	t.Run("prompt only", func(t *testing.T) {
+		prompt, err := buildPrompt("Explain this", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Explain this", prompt)
+	})
+
+	t.Run("context files are prepended", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "context.md")
+		require.NoError(t, os.WriteFile(file, []byte("# Design\n"), 0644))
+
+		prompt, err := buildPrompt("Review it", []string{file})
+		require.NoError(t, err)
+		assert.Contains(t, prompt, "# Design\n</file>")
+		assert.True(t, len(prompt) > len("Review it"))
+		assert.Equal(t, "Review it", prompt[len(prompt)-len("Review it"):])
+	})
+
+	t.Run("empty prompt", func(t *testing.T) {
+		_, err := buildPrompt("  ", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing context file", func(t *testing.T) {
+		_, err := buildPrompt("Review it", []string{"/nonexistent/context.md"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"claude-reactor/pkg"
+)
+
+// protectedPaths parses the protected_paths setting: comma-separated paths inside the project
+// that sessions must not modify
+func protectedPaths(value string) ([]string, error) {
+	var paths []string
+	for _, item := range configList(value) {
+		cleaned := filepath.Clean(filepath.FromSlash(item))
+		if !filepath.IsLocal(cleaned) || cleaned == "." {
+			return nil, fmt.Errorf("invalid protected path '%s': use a path inside the project, such as .github/workflows", item)
+		}
+		paths = append(paths, cleaned)
+	}
+	return paths, nil
+}
+
+// protectedMounts returns read-only bind mounts of the protected paths over the project mounted
+// at target, so even danger-mode sessions cannot change them. A path that doesn't exist can't be
+// mounted and is returned in missing instead.
+func protectedMounts(projectDir, target string, paths []string) (mounts []pkg.Mount, missing []string) {
+	for _, rel := range paths {
+		source := filepath.Join(projectDir, rel)
+		if _, err := os.Lstat(source); err != nil {
+			missing = append(missing, rel)
+			continue
+		}
+		mounts = append(mounts, pkg.Mount{
+			Source:   source,
+			Target:   path.Join(target, filepath.ToSlash(rel)),
+			Type:     "bind",
+			ReadOnly: true,
+		})
+	}
+	return mounts, missing
+}
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func TestProtectedPaths(t *testing.T) {
+	paths, err := protectedPaths(" .github/workflows , infra/prod/,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(".github", "workflows"), filepath.Join("infra", "prod")}, paths)
+
+	for _, value := range []string{"/etc", "../outside", "infra/../..", "."} {
+		_, err := protectedPaths(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestProtectedMounts(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "infra", "prod"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "Makefile"), []byte("all:\n"), 0644))
+
+	mounts, missing := protectedMounts(projectDir, "/app", []string{filepath.Join("infra", "prod"), "Makefile", "deploy"})
+	assert.Equal(t, []pkg.Mount{
+		{Source: filepath.Join(projectDir, "infra", "prod"), Target: "/app/infra/prod", Type: "bind", ReadOnly: true},
+		{Source: filepath.Join(projectDir, "Makefile"), Target: "/app/Makefile", Type: "bind", ReadOnly: true},
+	}, mounts)
+	assert.Equal(t, []string{"deploy"}, missing)
+}
@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/pkg"
+)
+
+// resourceFilter selects which resources clean and gc act on
+type resourceFilter struct {
+	kinds       map[string]bool // resource kinds to include
+	projectHash string          // empty for all projects
+	account     string          // empty for all accounts
+	olderThan   time.Duration   // zero for any age
+}
+
+// matches reports whether a resource is selected. Images are shared between projects and
+// accounts, so only the age filter applies to them.
+func (f resourceFilter) matches(resource pkg.Resource, now time.Time) bool {
+	if !f.kinds[resource.Kind] {
+		return false
+	}
+	if resource.Kind != pkg.ResourceImage {
+		if f.projectHash != "" && resource.ProjectHash != f.projectHash {
+			return false
+		}
+		if f.account != "" && resource.Account != f.account {
+			return false
+		}
+	}
+	if f.olderThan > 0 && (resource.Created.IsZero() || now.Sub(resource.Created) < f.olderThan) {
+		return false
+	}
+	return true
+}
+
+// apply returns the selected resources in removal order: containers before the volumes they
+// use, then sessions, then images
+func (f resourceFilter) apply(resources []pkg.Resource) []pkg.Resource {
+	now := time.Now()
+	var selected []pkg.Resource
+	for _, resource := range resources {
+		if f.matches(resource, now) {
+			selected = append(selected, resource)
+		}
+	}
+	order := map[string]int{pkg.ResourceContainer: 0, pkg.ResourceVolume: 1, pkg.ResourceSession: 2, pkg.ResourceImage: 3}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return order[selected[i].Kind] < order[selected[j].Kind]
+	})
+	return selected
+}
+
+// parseAge parses an --older-than value. Days and weeks are accepted in addition to Go durations.
+func parseAge(value string) (time.Duration, error) {
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	if unit, ok := units[value[max(len(value)-1, 0):]]; ok {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q: use a duration such as 12h, 7d, or 2w", value)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age %q: use a duration such as 12h, 7d, or 2w", value)
+	}
+	return age, nil
+}
+
+// collectResources lists Docker resources and session directories of the requested kinds
+func collectResources(ctx context.Context, app *pkg.AppContainer, kinds map[string]bool) ([]pkg.Resource, error) {
+	var resources []pkg.Resource
+	if kinds[pkg.ResourceContainer] || kinds[pkg.ResourceVolume] || kinds[pkg.ResourceImage] {
+		if err := reactor.EnsureDockerComponents(app); err != nil {
+			return nil, fmt.Errorf("docker not available: %w", err)
+		}
+		dockerResources, err := app.DockerMgr.ListResources(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, dockerResources...)
+	}
+	if kinds[pkg.ResourceSession] {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		sessions, err := scanSessionResources(filepath.Join(homeDir, ".claude-reactor"))
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, sessions...)
+	}
+	return resources, nil
+}
+
+// scanSessionResources lists project session directories under ~/.claude-reactor/{account}/.
+// Directories that are not named {project}-{hash} (locks, caches, variants) are skipped.
+func scanSessionResources(claudeReactorDir string) ([]pkg.Resource, error) {
+	accounts, err := getAccountDirectories(claudeReactorDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read claude-reactor directory: %w", err)
+	}
+
+	var resources []pkg.Resource
+	for _, account := range accounts {
+		accountDir := filepath.Join(claudeReactorDir, account)
+		entries, err := os.ReadDir(accountDir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			_, projectHash, err := parseProjectDirName(entry.Name())
+			if err != nil || !isProjectHash(projectHash) {
+				continue
+			}
+			sessionDir := filepath.Join(accountDir, entry.Name())
+			resources = append(resources, pkg.Resource{
+				Kind:        pkg.ResourceSession,
+				ID:          sessionDir,
+				Name:        filepath.Join(account, entry.Name()),
+				ProjectPath: readSessionProjectPath(sessionDir),
+				ProjectHash: projectHash,
+				Account:     account,
+				Created:     getLastUsedTimestamp(sessionDir),
+				Size:        dirSize(sessionDir),
+			})
+		}
+	}
+	return resources, nil
+}
+
+// isProjectHash reports whether s is an 8-character hex project hash
+func isProjectHash(s string) bool {
+	_, err := hex.DecodeString(s)
+	return len(s) == 8 && err == nil && strings.ToLower(s) == s
+}
+
+// readSessionProjectPath returns the project path recorded in a session's .claude-reactor file
+func readSessionProjectPath(sessionDir string) string {
+	data, err := os.ReadFile(filepath.Join(sessionDir, ".claude-reactor"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "project_path=") {
+			return strings.TrimPrefix(line, "project_path=")
+		}
+	}
+	return ""
+}
+
+// dirSize sums the size of regular files below dir
+func dirSize(dir string) int64 {
+	var size int64
+	filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// removeResource deletes a session directory or cache entry, or asks Docker to remove anything else
+func removeResource(ctx context.Context, app *pkg.AppContainer, resource pkg.Resource) error {
+	switch resource.Kind {
+	case pkg.ResourceSession:
+		return os.RemoveAll(resource.ID)
+	case pkg.ResourceCache:
+		return app.ImageValidator.RemoveCacheEntry(resource.ID)
+	default:
+		return app.DockerMgr.RemoveResource(ctx, resource)
+	}
+}
+
+// formatSize formats a byte count for display
+func formatSize(bytes int64) string {
+	if bytes < 0 {
+		return "unknown"
+	}
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, suffix := float64(bytes)/unit, "KB"
+	for _, next := range []string{"MB", "GB", "TB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
+// totalSize sums known resource sizes
+func totalSize(resources []pkg.Resource) int64 {
+	var total int64
+	for _, resource := range resources {
+		if resource.Size > 0 {
+			total += resource.Size
+		}
+	}
+	return total
+}
@@ -2,15 +2,36 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/architecture"
+	"claude-reactor/internal/reactor/changes"
+	"claude-reactor/internal/reactor/claudeconfig"
+	"claude-reactor/internal/reactor/conversation"
+	"claude-reactor/internal/reactor/detection"
+	"claude-reactor/internal/reactor/docker"
+	"claude-reactor/internal/reactor/docker/validation"
+	"claude-reactor/internal/reactor/filesync"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/lock"
+	"claude-reactor/internal/reactor/logging"
+	"claude-reactor/internal/reactor/mcp"
+	"claude-reactor/internal/reactor/notify"
+	"claude-reactor/internal/reactor/policy"
+	"claude-reactor/internal/reactor/registry"
+	"claude-reactor/internal/reactor/sandbox"
+	"claude-reactor/internal/reactor/variants"
+	"claude-reactor/internal/reactor/worktree"
+	"claude-reactor/internal/reactor/wsl"
 	"claude-reactor/pkg"
 )
 
@@ -43,12 +64,28 @@ Examples:
   claude-reactor run --ssh-agent              # Enable SSH agent forwarding (auto-detect)
   claude-reactor run --ssh-agent=/tmp/ssh.sock # SSH agent with explicit socket path
   claude-reactor run --no-persist             # Remove container when finished
+  claude-reactor run --allow-vulnerable       # Run even if the image exceeds vuln_threshold
+  claude-reactor run --sync                   # Sync project into a volume (faster IO on macOS)
+  claude-reactor run --ci -- make test        # Run one command non-interactively (CI pipelines)
+  claude-reactor run --tmux                   # Run Claude in tmux; reattach with 'session attach'
+  claude-reactor run --clipboard              # Let copies in the session reach the host clipboard
+  claude-reactor run --device /dev/snd --device /dev/video0  # Pass audio and webcam devices through
+  claude-reactor run --platform linux/amd64   # Run an amd64 container (emulated on Apple Silicon)
+  claude-reactor run --recreate               # Start over with a fresh container
+  claude-reactor run --revalidate             # Re-check the image instead of trusting cached results
 
   # Registry control (v2 images)
   claude-reactor run --dev                    # Force local build (disable registry)
   claude-reactor run --registry-off           # Disable registry completely
   claude-reactor run --pull-latest            # Force pull latest from registry
   claude-reactor run --no-continue            # Disable conversation continuation
+  claude-reactor run --conversation refactor  # Start or continue a named conversation
+  claude-reactor run --resume <session-id>    # Resume a Claude session by ID
+  claude-reactor run -- --model opus          # Pass extra arguments to Claude CLI
+  claude-reactor run --workdir services/api   # Start in a sub-project, with the whole repo mounted
+  claude-reactor run -m ~/data:/data:ro       # Mount a host directory read-only at /data
+  claude-reactor run --tmpfs /tmp/work        # Scratch space in memory
+  claude-reactor run --read-only-project      # Review session: Claude cannot modify the project
 
 Custom Image Requirements:
   • Must be Linux-based (linux/amd64 or linux/arm64)
@@ -64,6 +101,46 @@ Host Docker Access (⚠️  SECURITY WARNING):
   • Default timeout: 5m (override with --host-docker-timeout)
   • Only enable for trusted workflows requiring Docker management
 
+Read-only Projects:
+  --read-only-project mounts the project read-only so Claude can analyze but not
+  modify it, and mounts a writable /scratch for its notes and reports. Scratch
+  files are kept with the project's session data on the host. Switching the
+  mode recreates the container.
+
+Sub-projects:
+  Run from a subdirectory, the project is the nearest directory above it with
+  a .claude-reactor file or .git (stopping below your home directory). The whole
+  project is mounted and Claude starts in the subdirectory. --workdir picks the
+  starting directory relative to the project root instead; --no-project-root
+  makes the current directory the project.
+
+Claude CLI Arguments:
+  Arguments after '--' are passed to Claude CLI, after those in the claude_args
+  setting ('claude-reactor config set claude_args "--model opus"'), so flags
+  such as --model, --max-turns or --permission-mode need no wrapper.
+
+CI Mode:
+  --ci runs the command after '--' without a TTY, logs JSON to stderr without
+  emoji, exits with the command's exit code, and removes the container afterwards
+  unless --no-persist=false is given.
+
+Container Reuse:
+  An existing project container is reused when it was created with the same
+  effective configuration (image, platform, mounts, environment, devices, and
+  host Docker, SSH agent, and sync settings) and recreated when any of these
+  changed. --reuse keeps the existing container regardless; --recreate always
+  starts a new one. 'claude-reactor explain run' shows which will happen.
+
+Detachable Sessions:
+  --tmux runs Claude CLI (or the --shell) inside a tmux session in the container,
+  falling back to screen if tmux is not installed. Losing the terminal only
+  detaches; reattach with 'claude-reactor session attach'.
+
+Clipboard Bridge:
+  --clipboard watches the session for OSC 52 clipboard sequences and copies
+  their contents to the host clipboard (pbcopy, wl-copy, xclip, xsel, or clip).
+  Inside the container, pipe text to 'cr-copy' to copy it.
+
 Troubleshooting:
   Use 'claude-reactor info' to check Docker connectivity
   Use 'claude-reactor info image <name>' to test custom images
@@ -87,8 +164,32 @@ Related Commands:
 	runCmd.Flags().StringP("apikey", "", "", "Set API key for this session (creates account-specific env file)")
 	runCmd.Flags().BoolP("interactive-login", "", false, "Force interactive authentication for account")
 	runCmd.Flags().BoolP("shell", "", false, "Launch shell instead of Claude CLI")
-	runCmd.Flags().StringSliceP("mount", "m", []string{}, "Additional mount points (can be used multiple times)")
+	runCmd.Flags().StringSliceP("mount", "m", []string{}, "Additional mount as src[:dst][:ro|rw][:cached|delegated] (default dst /mnt/<name>; can be used multiple times)")
+	runCmd.Flags().StringSliceP("tmpfs", "", []string{}, "Container path to mount a tmpfs at (can be used multiple times)")
+	runCmd.Flags().BoolP("read-only-project", "", false, "Mount the project read-only, with a writable "+scratchTarget+" for outputs (review sessions)")
+	runCmd.Flags().BoolP("review", "", false, "After the session, pick which of its changes to the project files to revert")
+	runCmd.Flags().BoolP("sandbox", "", false, "Work on a private copy of the project; review and apply edits with 'claude-reactor sandbox'")
+	runCmd.Flags().StringSliceP("device", "", []string{}, "Host device to pass through, as host[:container[:rwm]] (can be used multiple times)")
+	runCmd.Flags().StringSliceP("dns", "", []string{}, "DNS server for the container, added to the dns config (can be used multiple times)")
+	runCmd.Flags().StringSliceP("dns-search", "", []string{}, "DNS search domain for the container (can be used multiple times)")
+	runCmd.Flags().StringSliceP("add-host", "", []string{}, "Add a host:ip entry to the container's /etc/hosts (can be used multiple times)")
 	runCmd.Flags().BoolP("no-persist", "", false, "Remove container when finished (default: keep running)")
+	runCmd.Flags().BoolP("allow-vulnerable", "", false, "Run even if the image has CVEs above the configured vuln_threshold")
+	runCmd.Flags().BoolP("sync", "", false, "Sync project files into a volume with mutagen instead of a bind mount")
+	runCmd.Flags().BoolP("ci", "", false, "Non-interactive mode: run the command after '--' and exit with its code")
+	runCmd.Flags().BoolP("tmux", "", false, "Run inside a detachable tmux (or screen) session in the container")
+	runCmd.Flags().BoolP("clipboard", "", false, "Copy clipboard requests from the session to the host clipboard")
+	runCmd.Flags().StringP("platform", "", "", "Container platform, e.g. linux/amd64 (persisted; empty for the host platform)")
+	runCmd.Flags().BoolP("revalidate", "", false, "Ignore cached image lookups and validation results")
+	runCmd.Flags().BoolP("reuse", "", false, "Reuse the existing container even if its configuration changed")
+	runCmd.Flags().BoolP("recreate", "", false, "Remove the existing container and create a new one")
+	runCmd.Flags().BoolP("yes", "y", false, "Start without confirming settings that got riskier since the last run")
+	runCmd.Flags().BoolP("no-upgrade", "", false, "Don't upgrade the Claude CLI when the container starts")
+	runCmd.Flags().StringP("conversation", "", "", "Start or continue a named conversation (see 'claude-reactor conversation list')")
+	runCmd.Flags().StringP("resume", "", "", "Resume a Claude session by ID")
+	runCmd.Flags().StringP("workdir", "", "", "Directory to start in, relative to the project root")
+	runCmd.Flags().BoolP("no-project-root", "", false, "Use the current directory as the project, even inside a repository")
+	runCmd.Flags().StringP("branch", "", "", "Work on this git branch in a worktree of its own (created if needed; see 'session finish')")
 
 	// Advanced / Deprecated flags (use config instead)
 	runCmd.Flags().BoolP("danger", "", false, "Enable danger mode")
@@ -104,6 +205,10 @@ Related Commands:
 	runCmd.Flags().MarkHidden("ssh-agent")
 	runCmd.Flags().Lookup("ssh-agent").NoOptDefVal = "auto"
 
+	// Dynamic completion for image and account values
+	runCmd.RegisterFlagCompletionFunc("image", completeImages(app))
+	runCmd.RegisterFlagCompletionFunc("account", completeAccounts(app))
+
 	return runCmd
 }
 
@@ -116,6 +221,242 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 	ctx := cmd.Context()
 
 	// Parse command flags
+	shell, _ := cmd.Flags().GetBool("shell")
+	noPersist, _ := cmd.Flags().GetBool("no-persist")
+	ci, _ := cmd.Flags().GetBool("ci")
+	detachable, _ := cmd.Flags().GetBool("tmux")
+	conversationName, _ := cmd.Flags().GetString("conversation")
+	workdir, _ := cmd.Flags().GetString("workdir")
+	noProjectRoot, _ := cmd.Flags().GetBool("no-project-root")
+	resume, _ := cmd.Flags().GetString("resume")
+	branch, _ := cmd.Flags().GetString("branch")
+	review, _ := cmd.Flags().GetBool("review")
+	sandboxed, _ := cmd.Flags().GetBool("sandbox")
+	persist := !noPersist // Default to true, unless --no-persist is specified
+
+	if conversationName != "" && resume != "" {
+		return fmt.Errorf("--conversation and --resume cannot be combined")
+	}
+	if (conversationName != "" || resume != "") && (ci || shell) {
+		return fmt.Errorf("--conversation and --resume only apply when launching Claude, not with --ci or --shell")
+	}
+	if conversationName != "" {
+		if err := conversation.ValidateName(conversationName); err != nil {
+			return err
+		}
+	}
+
+	if detachable && ci {
+		return fmt.Errorf("--tmux cannot be combined with --ci")
+	}
+	if review && (ci || detachable) {
+		return fmt.Errorf("--review needs the session to end here, so it cannot be combined with --ci or --tmux")
+	}
+	if review && sandboxed {
+		return pkg.NewError(pkg.CodeInvalidUsage, nil, "--review cannot be combined with --sandbox: sandbox changes don't reach the project until applied").
+			WithRemediation("Review them with: claude-reactor sandbox diff")
+	}
+	if detachable && noPersist {
+		return fmt.Errorf("--tmux keeps the session running after you detach, so it cannot be combined with --no-persist")
+	}
+
+	var ciCommand, extraArgs []string
+	if len(cmd.Flags().Args()) > 0 && !ci {
+		if cmd.Flags().ArgsLenAtDash() != 0 {
+			return pkg.NewError(pkg.CodeInvalidUsage, nil, "unexpected arguments: %s", strings.Join(cmd.Flags().Args(), " ")).
+				WithRemediation("Pass Claude CLI arguments after '--': claude-reactor run -- --model opus")
+		}
+		if shell {
+			return fmt.Errorf("arguments after '--' are passed to Claude CLI and cannot be combined with --shell")
+		}
+		extraArgs = cmd.Flags().Args()
+	}
+	if ci {
+		ciCommand = cmd.Flags().Args()
+		if len(ciCommand) == 0 {
+			return pkg.NewError(pkg.CodeInvalidUsage, nil, "--ci requires a command to run").
+				WithRemediation("Usage: claude-reactor run --ci -- <command> [args...]")
+		}
+		logging.EnableCIMode(app.Logger)
+		// CI runs are one-shot; only keep the container if explicitly requested
+		if !cmd.Flags().Changed("no-persist") {
+			persist = false
+		}
+	}
+
+	// Run from a sub-project, mount the whole project and start where we are
+	if err := absoluteMountFlags(cmd); err != nil {
+		return err
+	}
+	subdir, err := enterProjectRoot(app, !noProjectRoot)
+	if err != nil {
+		return err
+	}
+	if workdir != "" {
+		if subdir, err = projectSubdir(workdir); err != nil {
+			return err
+		}
+	}
+	if branch != "" {
+		if err := enterWorktree(app, branch); err != nil {
+			return err
+		}
+	}
+
+	prepared, err := prepareContainer(ctx, cmd, app, persist)
+	if err != nil {
+		return err
+	}
+	config := prepared.Config
+	containerName := prepared.Name
+	containerID := prepared.ID
+	syncMode := prepared.SyncMode
+
+	if err := injectSecrets(app, config); err != nil {
+		return err
+	}
+	if subdir != "" {
+		dir := containerWorkdir(config.ProjectPath, subdir)
+		app.DockerMgr.SetWorkingDir(dir)
+		app.Logger.Info(i18n.T("run.working_directory", dir))
+	}
+
+	// Step 7: Attach to container
+	var command []string
+	if ci {
+		command = ciCommand
+		app.Logger.Info(i18n.T("run.command", strings.Join(command, " ")))
+	} else if shell {
+		command = []string{"/bin/bash"}
+		app.Logger.Info(i18n.T("run.launching_shell"))
+		app.Logger.Info(i18n.T("run.shell_hint"))
+	} else {
+		// Build Claude CLI command with flags
+		command = []string{"claude"}
+
+		if config.DangerMode {
+			command = append(command, "--dangerously-skip-permissions")
+			app.Logger.Info(i18n.T("run.launching_danger"))
+			app.Logger.Info(i18n.T("run.danger_warning"))
+		} else {
+			app.Logger.Info(i18n.T("run.launching_claude"))
+		}
+
+		// Conversation control
+		// TODO: Fix additional working directories issue before re-enabling --continue support
+		switch {
+		case conversationName != "":
+			sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath)
+			named, err := conversation.Use(sessionDir, conversationName)
+			if err != nil {
+				return err
+			}
+			command = append(command, conversation.ClaudeArgs(sessionDir, named)...)
+			app.Logger.Info(i18n.T("run.conversation", named.Name, named.ID))
+		case resume != "":
+			command = append(command, "--resume", resume)
+			app.Logger.Info(i18n.T("run.resuming_session", resume))
+		default:
+			app.Logger.Debug("💬 Conversation continuation temporarily disabled due to path issue")
+		}
+
+		if app.Debug {
+			command = append(command, "-d", "--verbose")
+		}
+
+		// Hooks report task progress through the agent so finished tasks can be notified
+		if prepared.Notifier.Enabled(notify.EventTask) {
+			command = append(command, "--settings", claudeTaskHooks)
+		}
+
+		// User arguments go last: the claude_args setting, then those after '--'
+		configArgs, err := claudeconfig.SplitArgs(config.ClaudeArgs)
+		if err != nil {
+			return fmt.Errorf("invalid claude_args: %w", err)
+		}
+		command = append(command, configArgs...)
+		command = append(command, extraArgs...)
+	}
+
+	if config.Clipboard {
+		app.DockerMgr.EnableClipboardBridge(true)
+		app.Logger.Info(i18n.T("run.clipboard_active"))
+	}
+
+	if detachable {
+		multiplexer, err := detectMultiplexer(ctx, app, containerName)
+		if err != nil {
+			return err
+		}
+		command = multiplexerCommand(multiplexer, command)
+		app.Logger.Info(i18n.T("run.detachable", multiplexer, detachableSessionName))
+	}
+
+	// Let tools in the container reach the host while the session is attached
+	sessionCtx, endSession := context.WithCancel(ctx)
+	defer endSession()
+	if !ci {
+		startAgentHost(sessionCtx, app, containerName, config, prepared.Notifier)
+		if prepared.Notifier.Enabled(notify.EventUnhealthy) {
+			go monitorContainer(sessionCtx, app, prepared.Notifier, containerName)
+		}
+	}
+
+	// Changes to a bind-mounted or synced project are summarized when the session ends
+	var snapshot *changes.Snapshot
+	if !ci && !detachable && !sandboxed {
+		snapshot = snapshotProject(app, config.ProjectPath)
+	}
+	// Opt-in checkpoints back up the project for 'claude-reactor restore'
+	if !sandboxed {
+		startCheckpoints(sessionCtx, app, config)
+	}
+
+	// Attach to container; CI mode runs without a TTY so the command's exit code can be propagated
+	attachErr := app.DockerMgr.AttachToContainer(ctx, containerName, command, !ci)
+	endSession()
+	var exitErr *pkg.ExitError
+	if attachErr != nil && !(ci && errors.As(attachErr, &exitErr)) {
+		return fmt.Errorf("failed to attach to container: %w. Try using 'docker exec -it %s %s' as fallback", attachErr, containerName, strings.Join(command, " "))
+	}
+	summarizeChanges(cmd, app, snapshot, review)
+
+	// Step 8: Handle container persistence
+	if !persist {
+		if syncMode {
+			if err := filesync.Stop(ctx, containerName); err != nil {
+				app.Logger.Warn(i18n.T("run.sync_stop_failed", err))
+			}
+		}
+		app.Logger.Info(i18n.T("run.stopping"))
+		if err := app.DockerMgr.StopContainer(ctx, containerID); err != nil {
+			app.Logger.Warn(i18n.T("run.stop_failed", err))
+		}
+	} else {
+		app.Logger.Info(i18n.T("run.remains_running"))
+	}
+
+	if exitErr != nil {
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return exitErr
+	}
+
+	return nil
+}
+
+// preparedContainer is a project container that is running and ready for commands
+type preparedContainer struct {
+	Name     string
+	ID       string
+	Config   *pkg.Config
+	SyncMode bool
+	Notifier *notify.Notifier
+}
+
+// prepareContainer resolves configuration from flags, validates the image, and starts or
+// reuses the project container. Flags not defined on cmd fall back to their zero values.
+func prepareContainer(ctx context.Context, cmd *cobra.Command, app *pkg.AppContainer, persist bool) (*preparedContainer, error) {
 	image, _ := cmd.Flags().GetString("image")
 	account, _ := cmd.Flags().GetString("account")
 	apikey, _ := cmd.Flags().GetString("apikey")
@@ -126,22 +467,51 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 	sshAgent, _ := cmd.Flags().GetString("ssh-agent")
 	shell, _ := cmd.Flags().GetBool("shell")
 	mounts, _ := cmd.Flags().GetStringSlice("mount")
-	noPersist, _ := cmd.Flags().GetBool("no-persist")
-	persist := !noPersist // Default to true, unless --no-persist is specified
+	tmpfs, _ := cmd.Flags().GetStringSlice("tmpfs")
+	devices, _ := cmd.Flags().GetStringSlice("device")
+	allowVulnerable, _ := cmd.Flags().GetBool("allow-vulnerable")
+	syncMode, _ := cmd.Flags().GetBool("sync")
+	readOnlyProject, _ := cmd.Flags().GetBool("read-only-project")
+	sandboxed, _ := cmd.Flags().GetBool("sandbox")
+	clipboardBridge, _ := cmd.Flags().GetBool("clipboard")
+	platformFlag, _ := cmd.Flags().GetString("platform")
+	reuse, _ := cmd.Flags().GetBool("reuse")
+	recreate, _ := cmd.Flags().GetBool("recreate")
+	revalidate, _ := cmd.Flags().GetBool("revalidate")
+	noUpgrade, _ := cmd.Flags().GetBool("no-upgrade")
+	prewarm, _ := cmd.Flags().GetBool("prewarm")
+
+	if reuse && recreate {
+		return nil, fmt.Errorf("--reuse and --recreate cannot be combined")
+	}
 
 	// Ensure Docker components are initialized
 	if err := reactor.EnsureDockerComponents(app); err != nil {
-		return fmt.Errorf("docker not available: %w", err)
+		return nil, fmt.Errorf("docker not available: %w", err)
 	}
 
-	app.Logger.Info("🚀 Starting Claude CLI container...")
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	// Concurrent invocations in this project take turns saving its configuration and starting its container
+	projectLock, err := lockProject(ctx, app, projectDir)
+	if err != nil {
+		return nil, err
+	}
+	defer projectLock.Release()
+
+	app.Logger.Info(i18n.T("run.starting"))
 
 	// Step 1: Load or create configuration
-	app.Logger.Info("📋 Loading configuration...")
+	app.Logger.Info(i18n.T("run.loading_configuration"))
 	config, err := app.ConfigMgr.LoadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w. Try running 'claude-reactor config validate' to check your setup", err)
+		return nil, fmt.Errorf("failed to load configuration: %w. Try running 'claude-reactor config validate' to check your setup", err)
 	}
+	configureImageCache(app, config, revalidate)
+	configureRegistryMirror(app, config)
 
 	// Override config with command-line flags
 	if image != "" {
@@ -158,7 +528,7 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 
 	// Ensure account config file exists before any container operations to prevent corruption
 	if err := app.AuthMgr.CopyMainConfigToAccount(config.Account); err != nil {
-		app.Logger.Warnf("Failed to ensure account config exists: %v", err)
+		app.Logger.Warn(i18n.T("run.account_config_failed", err))
 	}
 
 	// Handle danger mode with persistence logic
@@ -166,24 +536,24 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 	if cmd.Flags().Changed("danger") {
 		config.DangerMode = danger
 		if danger {
-			app.Logger.Info("🔥 Danger mode enabled and will be persisted")
+			app.Logger.Info(i18n.T("run.danger_enabled"))
 		} else {
-			app.Logger.Info("🛡️  Danger mode disabled and will be persisted")
+			app.Logger.Info(i18n.T("run.danger_disabled"))
 		}
 	} else if config.DangerMode {
-		app.Logger.Info("🔥 Using persistent danger mode setting")
+		app.Logger.Info(i18n.T("run.danger_persistent"))
 	}
 
 	// Handle host Docker configuration with persistence logic
 	if cmd.Flags().Changed("host-docker") {
 		config.HostDocker = hostDocker
 		if hostDocker {
-			app.Logger.Info("🐳 Host Docker access enabled and will be persisted")
+			app.Logger.Info(i18n.T("run.host_docker_enabled"))
 		} else {
-			app.Logger.Info("🔒 Host Docker access disabled and will be persisted")
+			app.Logger.Info(i18n.T("run.host_docker_disabled"))
 		}
 	} else if config.HostDocker {
-		app.Logger.Info("🐳 Using persistent host Docker setting")
+		app.Logger.Info(i18n.T("run.host_docker_persistent"))
 		hostDocker = true // Use saved setting
 	}
 
@@ -202,7 +572,8 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 	if hostDocker {
 		if hostDockerTimeout != "0" && hostDockerTimeout != "0s" {
 			if _, err := time.ParseDuration(hostDockerTimeout); err != nil {
-				return fmt.Errorf("invalid timeout format '%s': %w\n💡 Use Go duration format: 5m, 1h30m, 30s\n💡 Valid examples: 30s, 5m, 1h, 2h30m\n💡 Disable timeout: 0", hostDockerTimeout, err)
+				return nil, pkg.NewError(pkg.CodeInvalidUsage, err, "invalid timeout format '%s'", hostDockerTimeout).
+					WithRemediation("Use Go duration format: 5m, 1h30m, 30s", "Valid examples: 30s, 5m, 1h, 2h30m", "Disable timeout: 0")
 			}
 		}
 
@@ -210,6 +581,73 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 		displayHostDockerSecurityWarning(app.Logger, hostDockerTimeout)
 	}
 
+	// Handle sync mode with persistence logic
+	if cmd.Flags().Changed("sync") {
+		config.SyncMode = syncMode
+		if syncMode {
+			app.Logger.Info(i18n.T("run.sync_enabled"))
+		} else {
+			app.Logger.Info(i18n.T("run.sync_disabled"))
+		}
+	} else if config.SyncMode {
+		app.Logger.Info(i18n.T("run.sync_persistent"))
+		syncMode = true
+	}
+
+	if readOnlyProject && syncMode {
+		return nil, pkg.NewError(pkg.CodeInvalidUsage, nil, "--read-only-project mounts the project directly and cannot be combined with sync mode").
+			WithRemediation("Disable sync for this project with: claude-reactor run --sync=false --read-only-project")
+	}
+	if sandboxed && syncMode {
+		return nil, pkg.NewError(pkg.CodeInvalidUsage, nil, "--sandbox keeps edits out of the project and cannot be combined with sync mode").
+			WithRemediation("Disable sync for this project with: claude-reactor run --sync=false --sandbox")
+	}
+	if sandboxed && readOnlyProject {
+		return nil, fmt.Errorf("--sandbox and --read-only-project cannot be combined")
+	}
+	protected, err := protectedPaths(config.ProtectedPaths)
+	if err != nil {
+		return nil, err
+	}
+	dns, dnsSearch, extraHosts, err := nameResolution(cmd, config)
+	if err != nil {
+		return nil, err
+	}
+	if len(protected) > 0 && syncMode {
+		return nil, pkg.NewError(pkg.CodeInvalidUsage, nil, "protected_paths are mounted read-only over the project directory and cannot be combined with sync mode").
+			WithRemediation("Disable sync for this project with: claude-reactor run --sync=false")
+	}
+
+	// Windows drives are shared into WSL 2 over 9p, which is slow for bind mounts
+	if !syncMode && wsl.Detect() != nil {
+		if projectDir, err := os.Getwd(); err == nil && wsl.OnWindowsDrive(projectDir) {
+			app.Logger.Warn(i18n.T("run.windows_drive", projectDir))
+			app.Logger.Warn(i18n.T("run.windows_drive_hint"))
+		}
+	}
+
+	// Handle platform with persistence logic
+	if cmd.Flags().Changed("platform") {
+		config.Platform = platformFlag
+		if platformFlag != "" {
+			app.Logger.Info(i18n.T("run.platform_persisted", platformFlag))
+		} else {
+			app.Logger.Info(i18n.T("run.host_platform_persisted"))
+		}
+	} else if config.Platform != "" {
+		app.Logger.Info(i18n.T("run.platform_persistent", config.Platform))
+	}
+
+	// Handle clipboard bridge with persistence logic
+	if cmd.Flags().Changed("clipboard") {
+		config.Clipboard = clipboardBridge
+		if clipboardBridge {
+			app.Logger.Info(i18n.T("run.clipboard_enabled"))
+		} else {
+			app.Logger.Info(i18n.T("run.clipboard_disabled"))
+		}
+	}
+
 	// Handle SSH agent configuration with persistence logic
 	var sshAgentEnabled bool
 	var sshAgentSocket string
@@ -222,25 +660,25 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 			// Auto-detect SSH agent socket
 			detectedSocket, err := app.ConfigMgr.DetectSSHAgent()
 			if err != nil {
-				return fmt.Errorf("failed to detect SSH agent: %w", err)
+				return nil, fmt.Errorf("failed to detect SSH agent: %w", err)
 			}
 			sshAgentSocket = detectedSocket
 			config.SSHAgentSocket = "auto"
-			app.Logger.Info("🔑 SSH agent auto-detected and will be persisted")
+			app.Logger.Info(i18n.T("run.ssh_detected"))
 		} else {
 			// Explicit socket path provided
 			sshAgentSocket = sshAgent
 			config.SSHAgentSocket = sshAgent
-			app.Logger.Infof("🔑 SSH agent socket specified: %s (will be persisted)", sshAgent)
+			app.Logger.Info(i18n.T("run.ssh_socket", sshAgent))
 		}
 
 		// Validate SSH agent connectivity
 		if err := app.ConfigMgr.ValidateSSHAgent(sshAgentSocket); err != nil {
-			return fmt.Errorf("SSH agent validation failed: %w", err)
+			return nil, fmt.Errorf("SSH agent validation failed: %w", err)
 		}
 
 		config.SSHAgent = true
-		app.Logger.Info("✅ SSH agent validation passed")
+		app.Logger.Info(i18n.T("run.ssh_valid"))
 	} else if config.SSHAgent {
 		// Use persistent SSH agent setting
 		sshAgentEnabled = true
@@ -248,21 +686,21 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 			// Re-detect for auto mode
 			detectedSocket, err := app.ConfigMgr.DetectSSHAgent()
 			if err != nil {
-				app.Logger.Warnf("Failed to re-detect SSH agent, disabling: %v", err)
+				app.Logger.Warn(i18n.T("run.ssh_redetect_failed", err))
 				config.SSHAgent = false
 				sshAgentEnabled = false
 			} else {
 				sshAgentSocket = detectedSocket
-				app.Logger.Info("🔑 Using persistent SSH agent setting (auto-detected)")
+				app.Logger.Info(i18n.T("run.ssh_persistent_detected"))
 			}
 		} else {
 			// Use explicit socket from config
 			sshAgentSocket = config.SSHAgentSocket
-			app.Logger.Infof("🔑 Using persistent SSH agent setting: %s", sshAgentSocket)
+			app.Logger.Info(i18n.T("run.ssh_persistent", sshAgentSocket))
 
 			// Re-validate socket
 			if err := app.ConfigMgr.ValidateSSHAgent(sshAgentSocket); err != nil {
-				app.Logger.Warnf("Persistent SSH agent socket invalid, disabling: %v", err)
+				app.Logger.Warn(i18n.T("run.ssh_persistent_invalid", err))
 				config.SSHAgent = false
 				sshAgentEnabled = false
 			}
@@ -271,86 +709,81 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 
 	// Handle authentication flags
 	if apikey != "" {
-		app.Logger.Infof("🔑 Setting up API key for account: %s", config.Account)
+		app.Logger.Info(i18n.T("run.api_key", config.Account))
 		if err := app.AuthMgr.SetupAuth(config.Account, apikey); err != nil {
-			return fmt.Errorf("failed to setup API key authentication: %w", err)
+			return nil, fmt.Errorf("failed to setup API key authentication: %w", err)
 		}
-		app.Logger.Info("✅ API key authentication configured")
+		app.Logger.Info(i18n.T("run.api_key_done"))
 	}
 
 	if interactiveLogin {
-		app.Logger.Infof("🔐 Forcing interactive login for account: %s", config.Account)
+		app.Logger.Info(i18n.T("run.interactive_login", config.Account))
 		// Note: Interactive login is handled by the Claude CLI inside the container
 		// This flag will be passed to the container startup
 	}
 
 	// Auto-detect variant if not specified
 	if config.Variant == "" {
-		app.Logger.Info("🔍 Auto-detecting project type...")
+		app.Logger.Info(i18n.T("run.detecting"))
 		detectedVariant, err := app.ConfigMgr.AutoDetectVariant("")
 		if err != nil {
-			app.Logger.Warnf("Failed to auto-detect image: %v", err)
-			app.Logger.Info("💡 Defaulting to 'base' image. Use --image flag to specify manually")
+			app.Logger.Warn(i18n.T("run.detect_failed", err))
+			app.Logger.Info(i18n.T("run.detect_default"))
 			config.Variant = "base"
 		} else {
 			config.Variant = detectedVariant
-			app.Logger.Infof("✅ Auto-detected image: %s", config.Variant)
+			app.Logger.Info(i18n.T("run.detected", config.Variant))
 		}
 	}
 
 	// Validate configuration
-	app.Logger.Info("✅ Validating configuration...")
+	app.Logger.Info(i18n.T("run.validating_configuration"))
 	if err := app.ConfigMgr.ValidateConfig(config); err != nil {
-		return fmt.Errorf("invalid configuration: %w. Try using --image with one of: base, go, full, cloud, k8s, or a custom Docker image", err)
+		return nil, fmt.Errorf("invalid configuration: %w. Try using --image with one of: base, go, full, cloud, k8s, or a custom Docker image", err)
 	}
 
 	// Session persistence: respect saved configuration
 	// The user has explicitly configured session_persistence=true, so honor it
 	// (Session persistence setting is already loaded from configuration above)
 
-	// Save configuration to persist user preferences (including danger mode and session persistence)
-	if err := app.ConfigMgr.SaveConfig(config); err != nil {
-		app.Logger.Warnf("Failed to save configuration: %v", err)
-		// Don't fail the entire operation for this, just warn
+	// Step 1.5: Validate custom Docker images
+	isBuiltinVariant := variants.IsBuiltin(config.Variant)
+	var externalDefinition *pkg.VariantDefinition
+	if !isBuiltinVariant {
+		externalDefinition = externalVariant(app, config.Variant)
 	}
 
-	// Step 1.5: Validate custom Docker images
-	builtinVariants := []string{"base", "go", "full", "cloud", "k8s"}
-	isBuiltinVariant := false
-	for _, variant := range builtinVariants {
-		if config.Variant == variant {
-			isBuiltinVariant = true
-			break
+	if !isBuiltinVariant && externalDefinition == nil {
+		// Refuse images the policy does not allow before pulling anything
+		if err := checkImagePolicy(config.Variant); err != nil {
+			return nil, err
 		}
-	}
 
-	if !isBuiltinVariant {
-		app.Logger.Infof("🔍 Validating custom Docker image: %s (compatibility + package analysis)", config.Variant)
+		app.Logger.Info(i18n.T("run.validating_custom_image", config.Variant))
 
 		// Pull image if needed and validate it
 		validationResult, err := app.ImageValidator.ValidateImage(ctx, config.Variant, true)
 		if err != nil {
-			return fmt.Errorf("failed to validate custom image '%s': %w. Ensure the image exists and is accessible", config.Variant, err)
+			return nil, fmt.Errorf("failed to validate custom image '%s': %w. Ensure the image exists and is accessible", config.Variant, err)
 		}
 
 		if !validationResult.Compatible {
-			app.Logger.Error("❌ Custom image validation failed:")
+			app.Logger.Error(i18n.T("run.custom_image_invalid"))
 			for _, errMsg := range validationResult.Errors {
 				app.Logger.Errorf("  - %s", errMsg)
 			}
-			return fmt.Errorf("custom image '%s' is not compatible with claude-reactor. See errors above", config.Variant)
+			return nil, fmt.Errorf("custom image '%s' is not compatible with claude-reactor. See errors above", config.Variant)
 		}
 
 		// Show warnings if any
 		if len(validationResult.Warnings) > 0 {
-			app.Logger.Warn("⚠️ Custom image warnings:")
+			app.Logger.Warn(i18n.T("run.custom_image_warnings"))
 			for _, warning := range validationResult.Warnings {
 				app.Logger.Warnf("  - %s", warning)
 			}
 		}
 
-		app.Logger.Infof("✅ Custom image validated successfully: %s (digest: %.12s)",
-			config.Variant, validationResult.Digest)
+		app.Logger.Info(i18n.T("run.custom_image_valid", config.Variant, validationResult.Digest))
 
 		if validationResult.HasClaude {
 			app.Logger.Debug("✅ Claude CLI detected in custom image")
@@ -360,60 +793,107 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 		if packages, ok := validationResult.Metadata["packages"].(map[string]interface{}); ok {
 			if totalAvailable, ok := packages["total_available"].(int); ok {
 				if totalChecked, ok := packages["total_checked"].(int); ok {
-					app.Logger.Infof("📦 Package analysis: %d/%d recommended tools available", totalAvailable, totalChecked)
+					app.Logger.Info(i18n.T("run.package_analysis", totalAvailable, totalChecked))
 				}
 			}
 		}
 	}
 
 	if config.SessionPersistence {
-		app.Logger.Infof("📋 Configuration: image=%s, account=%s, danger=%t, shell=%t, persist=%t, session_persistence=%t",
-			config.Variant, config.Account, config.DangerMode, shell, persist, config.SessionPersistence)
+		app.Logger.Info(i18n.T("run.configuration_sessions",
+			config.Variant, config.Account, config.DangerMode, shell, persist, config.SessionPersistence))
 	} else {
-		app.Logger.Infof("📋 Configuration: image=%s, account=%s, danger=%t, shell=%t, persist=%t",
-			config.Variant, config.Account, config.DangerMode, shell, persist)
+		app.Logger.Info(i18n.T("run.configuration",
+			config.Variant, config.Account, config.DangerMode, shell, persist))
 	}
 
 	// Show which Claude config file will be mounted
 	claudeConfigPath := app.AuthMgr.GetAccountConfigPath(config.Account)
-	app.Logger.Infof("🔑 Claude config: %s", claudeConfigPath)
+	app.Logger.Info(i18n.T("run.claude_config", claudeConfigPath))
 
-	// Step 2: Get current project directory
-	projectDir, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
+	// Step 2: Record the project directory
 	config.ProjectPath = projectDir
 
 	// Step 3: Generate container and image names
-	app.Logger.Info("🔧 Detecting system architecture...")
-	arch, err := app.ArchDetector.GetHostArchitecture()
+	app.Logger.Info(i18n.T("run.detecting_architecture"))
+	arch, err := projectArchitecture(app, config)
 	if err != nil {
-		return fmt.Errorf("failed to detect architecture: %w. Your system may not be supported", err)
+		return nil, fmt.Errorf("failed to detect architecture: %w. Your system may not be supported", err)
 	}
 
 	containerName := app.DockerMgr.GenerateContainerName(projectDir, config.Variant, arch, config.Account)
-	app.Logger.Infof("🏷️ Container name: %s", containerName)
+	app.Logger.Info(i18n.T("run.container_name", containerName))
+
+	notifier := projectNotifier(app, config)
 
 	// Step 4: Resolve and Ensure Image
 	imageName := app.DockerMgr.GetImageName(config.Variant, arch)
+	// Only images pulled from a registry carry signatures; local builds are trusted as built
+	fromRegistry := true
 
 	if isBuiltinVariant {
-		// Check if local image exists by checking its validity without pull
-		if _, err := app.ImageValidator.ValidateImage(ctx, imageName, false); err != nil {
-			// Local image missing, switch to registry
-			registryImage := fmt.Sprintf("ghcr.io/dyluth/claude-reactor-%s:latest", config.Variant)
-			app.Logger.Infof("📦 Local image '%s' not found, using registry: %s", imageName, registryImage)
-			imageName = registryImage
+		if image, local := builtinImage(ctx, app, config.Variant, imageName); local {
+			app.Logger.Info(i18n.T("run.local_image", imageName))
+			fromRegistry = false
 		} else {
-			app.Logger.Infof("✅ Found local image: %s", imageName)
+			app.Logger.Info(i18n.T("run.registry_image", imageName, image))
+			imageName = image
+		}
+	} else if externalDefinition != nil {
+		imageName, err = externalVariantImage(ctx, app, app.DockerMgr, notifier, externalDefinition, imageName, config.Platform)
+		if err != nil {
+			return nil, err
+		}
+		fromRegistry = externalDefinition.Image != ""
+	} else {
+		// Custom images run as given, so a digest-pinned image is exactly the one validated
+		imageName = config.Variant
+	}
+
+	if fromRegistry {
+		if err := checkImageSignature(ctx, app, imageName); err != nil {
+			return nil, err
+		}
+	}
+
+	// Project packages go in a derived image, which the vulnerability scan then covers
+	baseImage := imageName
+	if !config.Packages.Empty() {
+		if imageName, err = packagesImage(ctx, app, app.DockerMgr, config, imageName, fromRegistry); err != nil {
+			return nil, err
 		}
 	}
 
-	app.Logger.Info("🐳 Preparing Docker environment...")
+	// Required and recommended tools are checked on the image sessions will run
+	tools, err := toolRequirements(config)
+	if err != nil {
+		return nil, err
+	}
+	if len(tools) > 0 {
+		if imageName, err = checkImageTools(ctx, app, config, tools, baseImage, imageName, fromRegistry); err != nil {
+			return nil, err
+		}
+	}
+
+	// Step 4.5: Vulnerability scan when a severity threshold is configured
+	if config.VulnThreshold != "" {
+		if err := checkImageVulnerabilities(ctx, app, imageName, config.VulnThreshold, allowVulnerable); err != nil {
+			return nil, err
+		}
+	}
+
+	app.Logger.Info(i18n.T("run.preparing_docker"))
 	platform, err := app.ArchDetector.GetDockerPlatform()
 	if err != nil {
-		return fmt.Errorf("failed to get Docker platform: %w. Architecture detection failed", err)
+		return nil, fmt.Errorf("failed to get Docker platform: %w. Architecture detection failed", err)
+	}
+	if config.Platform != "" && config.Platform != platform {
+		emulation, err := architecture.DetectEmulation(platform, config.Platform)
+		if err != nil {
+			return nil, err
+		}
+		app.Logger.Warnf("⚠️  %s", architecture.EmulationWarning(config.Platform, emulation))
+		platform = config.Platform
 	}
 
 	// Create Docker operation context with timeout if host Docker is enabled
@@ -423,7 +903,7 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 		var cancel context.CancelFunc
 		dockerCtx, cancel = context.WithTimeout(ctx, timeout)
 		defer cancel()
-		app.Logger.Infof("🕒 Docker operations timeout set to: %s", hostDockerTimeout)
+		app.Logger.Info(i18n.T("run.docker_timeout", hostDockerTimeout))
 	}
 
 	// Step 5: Create container configuration
@@ -434,85 +914,130 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 		Platform:          platform,
 		Interactive:       true,
 		TTY:               true,
-		Remove:            false, // Don't auto-remove - we manage lifecycle
-		RunClaudeUpgrade:  true,  // Run claude upgrade after container startup
+		Remove:            false,      // Don't auto-remove - we manage lifecycle
+		RunClaudeUpgrade:  !noUpgrade, // Run claude upgrade after container startup
+		ClaudeCLIVersion:  config.ClaudeCLIVersion,
 		HostDocker:        hostDocker,
 		HostDockerTimeout: hostDockerTimeout,
 		SSHAgent:          sshAgentEnabled,
 		SSHAgentSocket:    sshAgentSocket,
+		SyncMode:          syncMode,
+		ReadOnlyProject:   readOnlyProject,
+		Sandbox:           sandboxed,
+		ProtectedPaths:    protected,
+		Devices:           devices,
+		DNS:               dns,
+		DNSSearch:         dnsSearch,
+		ExtraHosts:        extraHosts,
 		Environment:       make(map[string]string),
+		Labels: map[string]string{
+			docker.ProjectLabel: projectDir,
+			docker.AccountLabel: config.Account,
+		},
+	}
+
+	if sandboxed {
+		containerConfig.Labels[sandbox.Label] = sandbox.VolumeName(containerName)
+	}
+
+	// A recognizable hostname and prompt tell which project and account a shell belongs to
+	hostname, prompt := containerBranding(config, projectDir)
+	containerConfig.Hostname = hostname
+	if prompt != "" {
+		containerConfig.Environment[docker.PromptEnv] = prompt
 	}
 
 	// Configure timezone to match host
 	// This ensures timestamps in container match the user's local time
-	if tz := os.Getenv("TZ"); tz != "" {
+	if tz, source := hostTimezone(); tz != "" {
 		containerConfig.Environment["TZ"] = tz
-		app.Logger.Debugf("🕐 Setting container timezone: %s", tz)
-	} else {
-		// Fallback: try to read /etc/timezone or /etc/localtime
-		if data, err := os.ReadFile("/etc/timezone"); err == nil {
-			tz := strings.TrimSpace(string(data))
-			if tz != "" {
-				containerConfig.Environment["TZ"] = tz
-				app.Logger.Debugf("🕐 Setting container timezone from /etc/timezone: %s", tz)
+		app.Logger.Debugf("🕐 Setting container timezone from %s: %s", source, tz)
+	}
+
+	// A pinned Claude CLI must not update itself mid-session
+	if config.ClaudeCLIVersion != "" {
+		containerConfig.Environment["DISABLE_AUTOUPDATER"] = "1"
+		app.Logger.Debugf("📌 Claude CLI pinned to %s", config.ClaudeCLIVersion)
+	}
+
+	// Pass an API key through so Claude CLI can authenticate without an account directory (e.g. in CI)
+	if apiKey := os.Getenv("ANTHROPIC_API_KEY"); apiKey != "" {
+		containerConfig.Environment["ANTHROPIC_API_KEY"] = apiKey
+		app.Logger.Debug("🔑 Passing ANTHROPIC_API_KEY to container")
+	}
+
+	// Add mounts
+	app.Logger.Info(i18n.T("run.configuring_mounts"))
+	// Project config mounts first; relative sources are in the project
+	mounts = append(configList(config.Mounts), mounts...)
+	tmpfs = append(configList(config.Tmpfs), tmpfs...)
+	// Opt-in secret scan, before the project is mounted
+	secretMode, secretFindings, err := scanSecrets(app, config, projectDir)
+	if err != nil {
+		return nil, err
+	}
+	err = AddMountsToContainer(app, containerConfig, config.Account, mounts, tmpfs, projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure mounts: %w. Check that source directories exist and are accessible", err)
+	}
+	if err := mountDotfiles(app, containerConfig, config); err != nil {
+		return nil, err
+	}
+	if len(secretFindings) > 0 {
+		if syncMode || sandboxed {
+			app.Logger.Warn(i18n.T("run.sync_secret_files", filesync.IgnoreFile))
+		} else {
+			sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, projectDir)
+			if err := hideSecrets(app, containerConfig, secretMode, secretFindings, projectDir, projectMountTarget(projectDir), sessionDir); err != nil {
+				return nil, err
 			}
 		}
 	}
+	seedSystemPrompt(app, config, projectDir)
 
-	// Add mounts
-	app.Logger.Info("📁 Configuring container mounts...")
-	err = AddMountsToContainer(app, containerConfig, config.Account, mounts, projectDir)
+	// Pre-flight: fail on a broken required mount now rather than with a daemon error later
+	containerConfig.Mounts, err = docker.NewMountManager(app.Logger).Preflight(containerConfig.Mounts)
 	if err != nil {
-		return fmt.Errorf("failed to configure mounts: %w. Check that source directories exist and are accessible", err)
+		return nil, err
 	}
 
-	// Step 6: Lifecycle Management
-	var containerID string
+	// Only run attaches a session, so only it summarizes and confirms the settings
+	if cmd.Flags().Lookup("yes") != nil {
+		confirmed, _ := cmd.Flags().GetBool("yes")
+		if err := confirmRunSettings(ctx, cmd, app, config, containerConfig, mounts, confirmed); err != nil {
+			return nil, err
+		}
+	}
+
+	// Save configuration to persist user preferences (including danger mode and session
+	// persistence), once any riskier settings have been confirmed
+	if err := app.ConfigMgr.SaveConfig(config); err != nil {
+		app.Logger.Warn(i18n.T("run.save_failed", err))
+		// Don't fail the entire operation for this, just warn
+	}
 
-	// Check if container already exists
-	containerExists, err := app.DockerMgr.IsContainerRunning(dockerCtx, containerName)
+	// Step 6: Lifecycle Management
+	status, err := app.DockerMgr.GetContainerStatus(dockerCtx, containerName)
 	if err != nil {
 		app.Logger.Debugf("Failed to check container status: %v", err)
-		containerExists = false
+		status = nil
 	}
 
-	if containerExists {
-		// Reuse existing
-		app.Logger.Info("♻️ Reusing existing container...")
-		status, err := app.DockerMgr.GetContainerStatus(dockerCtx, containerName)
-		if err != nil {
-			return fmt.Errorf("failed to get container status for reuse: %w", err)
-		}
-		containerID = status.ID
-	} else {
-		// Start new or resume stopped
-		status, err := app.DockerMgr.GetContainerStatus(dockerCtx, containerName)
-		if err != nil {
-			app.Logger.Debugf("Failed to get status for potentially stopped container: %v", err)
-		}
-
-		if config.SessionPersistence {
-			app.Logger.Info("🔄 Starting/Resuming container with session persistence...")
-			// StartOrRecoverContainer handles logic for resuming stopped containers
-			containerID, err = app.DockerMgr.StartOrRecoverContainer(dockerCtx, containerConfig, config)
-		} else {
-			app.Logger.Info("🏗️ Starting ephemeral container...")
-			// If ephemeral and exists (but stopped/dead), we must clean it first to avoid name conflict
-			if status != nil && status.Exists {
-				app.Logger.Debug("Removing stopped ephemeral container before recreation...")
-				if err := app.DockerMgr.RemoveContainer(dockerCtx, status.ID); err != nil {
-					app.Logger.Warnf("Failed to remove stopped container: %v", err)
-				}
-			}
-			containerID, err = app.DockerMgr.StartContainer(dockerCtx, containerConfig)
+	action, reason := decideContainerAction(status, docker.ConfigHash(containerConfig), config.SessionPersistence, reuse, recreate)
+	if prewarm {
+		return prewarmContainer(dockerCtx, app, containerConfig, config, status, action)
+	}
+	if action == actionRecreate && status.Running && syncMode {
+		if err := filesync.Stop(ctx, containerName); err != nil {
+			app.Logger.Debugf("Failed to stop file sync: %v", err)
 		}
-
-		if err != nil {
-			if dockerCtx.Err() == context.DeadlineExceeded {
-				return fmt.Errorf("Docker operation timed out after %s\n💡 For complex builds, increase timeout: --host-docker-timeout 15m", hostDockerTimeout)
-			}
-			return fmt.Errorf("failed to start container: %w. Check Docker daemon is running and try 'docker system prune'", err)
+	}
+	containerID, err := startProjectContainer(dockerCtx, app.DockerMgr, app.Logger, containerConfig, config, status, action, reason)
+	if err != nil {
+		if dockerCtx.Err() == context.DeadlineExceeded {
+			return nil, pkg.NewError(pkg.CodeDockerTimeout, nil, "Docker operation timed out after %s", hostDockerTimeout)
 		}
+		return nil, err
 	}
 
 	// Update session tracking for session persistence
@@ -521,7 +1046,7 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 	}
 	// Always save config to current directory for persistence
 	if err := app.ConfigMgr.SaveConfig(config); err != nil {
-		app.Logger.Warnf("Failed to save session configuration: %v", err)
+		app.Logger.Warn(i18n.T("run.save_session_failed", err))
 	}
 
 	// Also save config to session directory so 'list' command can read metadata
@@ -540,69 +1065,95 @@ func RunContainer(cmd *cobra.Command, app *pkg.AppContainer) error {
 		}
 	}
 
-	app.Logger.Info("✅ Container started successfully!")
-
-	// Step 7: Attach to container
-	var command []string
-	if shell {
-		command = []string{"/bin/bash"}
-		app.Logger.Info("🐚 Launching interactive shell in container...")
-		app.Logger.Info("💡 Type 'claude' to start Claude CLI, or 'exit' to leave the container")
-	} else {
-		// Build Claude CLI command with flags
-		command = []string{"claude"}
+	app.Logger.Info(i18n.T("run.started"))
 
-		if config.DangerMode {
-			command = append(command, "--dangerously-skip-permissions")
-			app.Logger.Info("🤖 Launching Claude CLI in DANGER MODE...")
-			app.Logger.Info("⚠️  Danger mode bypasses permission checks - use with caution!")
-		} else {
-			app.Logger.Info("🤖 Launching Claude CLI in container...")
+	if syncMode {
+		app.Logger.Info(i18n.T("run.syncing"))
+		if err := filesync.Start(ctx, projectDir, containerName, projectMountTarget(projectDir)); err != nil {
+			return nil, err
 		}
-
-		// Conversation control
-		// TODO: Fix additional working directories issue before re-enabling --continue support
-		app.Logger.Debug("💬 Conversation continuation temporarily disabled due to path issue")
-
-		if app.Debug {
-			command = append(command, "-d", "--verbose")
+		app.Logger.Info(i18n.T("run.sync_active"))
+	}
+	if sandboxed {
+		if err := seedSandbox(ctx, app, containerName, projectDir); err != nil {
+			return nil, err
 		}
 	}
 
-	// Attach to container
-	err = app.DockerMgr.AttachToContainer(ctx, containerName, command, true)
-	if err != nil {
-		return fmt.Errorf("failed to attach to container: %w. Try using 'docker exec -it %s %s' as fallback", err, containerName, strings.Join(command, " "))
-	}
+	applyDotfiles(dockerCtx, app, containerName, config)
 
-	// Step 8: Handle container persistence
-	if !persist {
-		app.Logger.Info("🧹 Stopping container due to --persist=false...")
-		if err := app.DockerMgr.StopContainer(ctx, containerID); err != nil {
-			app.Logger.Warnf("Failed to stop container: %v", err)
-		}
-	} else {
-		app.Logger.Info("💾 Container will remain running (use 'claude-reactor clean' to stop)")
-	}
+	// Compare project-pinned toolchain versions with what the image provides
+	checkToolchainVersions(dockerCtx, app.DockerMgr, app.Logger, containerName, projectDir, config.ToolchainInstall)
 
-	return nil
+	return &preparedContainer{
+		Name:     containerName,
+		ID:       containerID,
+		Config:   config,
+		SyncMode: syncMode,
+		Notifier: notifier,
+	}, nil
 }
 
+// scratchTarget is where a read-only project session can write its outputs
+const scratchTarget = "/scratch"
+
 // AddMountsToContainer adds mount points to container configuration
-func AddMountsToContainer(app *pkg.AppContainer, containerConfig *pkg.ContainerConfig, account string, userMounts []string, projectDir string) error {
+func AddMountsToContainer(app *pkg.AppContainer, containerConfig *pkg.ContainerConfig, account string, userMounts, tmpfs []string, projectDir string) error {
 	// Add default mounts (project directory, Claude config)
 
 	// Project mount - avoid circular mount if we're already in /app
-	targetPath := "/app"
-	if projectDir == "/app" {
-		targetPath = "/workspace" // Use different path to avoid circular mount
-	}
-
-	err := app.MountMgr.AddMountToConfig(containerConfig, projectDir, targetPath)
-	if err != nil {
-		return fmt.Errorf("failed to add project mount: %w", err)
+	targetPath := projectMountTarget(projectDir)
+
+	var err error
+	if containerConfig.SyncMode {
+		// Sync mode: project lives in a named volume, kept in sync with the host by mutagen
+		volumeName := filesync.VolumeName(containerConfig.Name)
+		containerConfig.Mounts = append(containerConfig.Mounts, pkg.Mount{
+			Source: volumeName,
+			Target: targetPath,
+			Type:   "volume",
+		})
+		app.Logger.Info(i18n.T("run.project_volume", volumeName, targetPath, projectDir))
+	} else if containerConfig.Sandbox {
+		// Sandbox: project is copied into a named volume once the container starts
+		volumeName := sandbox.VolumeName(containerConfig.Name)
+		containerConfig.Mounts = append(containerConfig.Mounts, pkg.Mount{
+			Source: volumeName,
+			Target: targetPath,
+			Type:   "volume",
+		})
+		app.Logger.Info(i18n.T("run.project_sandbox", volumeName, targetPath, projectDir))
+	} else {
+		err = app.MountMgr.AddMountToConfig(containerConfig, projectDir, targetPath)
+		if err != nil {
+			return fmt.Errorf("failed to add project mount: %w", err)
+		}
+		app.Logger.Info(i18n.T("run.project_mount", projectDir, targetPath))
+		// A linked worktree's .git points at the repository's git directory by host path
+		commonDir := worktree.CommonDir(projectDir)
+		if commonDir != "" && strings.HasPrefix(commonDir, "/") {
+			if err := app.MountMgr.AddMountToConfig(containerConfig, commonDir, commonDir); err != nil {
+				return fmt.Errorf("failed to add worktree git directory mount: %w", err)
+			}
+			app.Logger.Info(i18n.T("run.git_directory", commonDir))
+		}
+		if containerConfig.ReadOnlyProject {
+			for i := range containerConfig.Mounts {
+				if containerConfig.Mounts[i].Target == targetPath || (commonDir != "" && containerConfig.Mounts[i].Target == commonDir) {
+					containerConfig.Mounts[i].ReadOnly = true
+				}
+			}
+		}
+		// Protected paths are mounted read-only over the project, so the session cannot change them
+		protected, missing := protectedMounts(projectDir, targetPath, containerConfig.ProtectedPaths)
+		containerConfig.Mounts = append(containerConfig.Mounts, protected...)
+		for _, mount := range protected {
+			app.Logger.Info(i18n.T("run.protected", mount.Target))
+		}
+		for _, rel := range missing {
+			app.Logger.Warn(i18n.T("run.protected_missing", rel))
+		}
 	}
-	app.Logger.Infof("📁 Project mount: %s -> %s", projectDir, targetPath)
 
 	// Claude session directory mount - use project-specific session directory
 	// This contains conversation history, shell snapshots, todos, etc.
@@ -611,14 +1162,46 @@ func AddMountsToContainer(app *pkg.AppContainer, containerConfig *pkg.ContainerC
 
 	// Ensure session directory exists (including parent account directory)
 	if err := os.MkdirAll(claudeSessionDir, 0755); err != nil {
-		app.Logger.Warnf("Failed to create Claude session directory: %v", err)
+		app.Logger.Warn(i18n.T("run.session_dir_failed", err))
 	} else {
-		err = app.MountMgr.AddMountToConfig(containerConfig, claudeSessionDir, "/home/claude/.claude")
+		err = addOptionalMount(app, containerConfig, claudeSessionDir, "/home/claude/.claude")
 		if err != nil {
-			app.Logger.Warnf("Failed to add Claude session mount: %v", err)
+			app.Logger.Warn(i18n.T("run.session_mount_failed", err))
 		} else {
-			app.Logger.Infof("📁 Claude session mount: %s -> /home/claude/.claude", claudeSessionDir)
+			app.Logger.Info(i18n.T("run.session_mount", claudeSessionDir))
+			// Shell history is kept with the session, per project and account
+			if err := os.MkdirAll(filepath.Join(claudeSessionDir, docker.ShellHistoryDir), 0755); err != nil {
+				app.Logger.Warn(i18n.T("run.history_dir_failed", err))
+			} else {
+				if containerConfig.Environment == nil {
+					containerConfig.Environment = make(map[string]string)
+				}
+				for key, value := range docker.ShellHistoryEnv {
+					containerConfig.Environment[key] = value
+				}
+			}
+		}
+	}
+
+	// Account-wide npm cache, so Claude CLI installs and upgrades don't re-download for every variant
+	npmCache := docker.NPMCacheVolume(account)
+	containerConfig.Mounts = append(containerConfig.Mounts, pkg.Mount{
+		Source: npmCache,
+		Target: docker.NPMCacheTarget,
+		Type:   "volume",
+	})
+	app.Logger.Debugf("📦 npm cache: %s -> %s", npmCache, docker.NPMCacheTarget)
+
+	// A read-only project leaves Claude a scratch directory for its outputs, kept with the session
+	if containerConfig.ReadOnlyProject {
+		scratchDir := filepath.Join(claudeSessionDir, "scratch")
+		if err := os.MkdirAll(scratchDir, 0755); err != nil {
+			return fmt.Errorf("failed to create scratch directory: %w", err)
 		}
+		if err := app.MountMgr.AddMountToConfig(containerConfig, scratchDir, scratchTarget); err != nil {
+			return fmt.Errorf("failed to add scratch mount: %w", err)
+		}
+		app.Logger.Info(i18n.T("run.read_only", scratchTarget, scratchDir))
 	}
 
 	// Create project-specific .claude.json file if it doesn't exist
@@ -628,28 +1211,31 @@ func AddMountsToContainer(app *pkg.AppContainer, containerConfig *pkg.ContainerC
 		// Copy from account config as template
 		accountConfigPath := app.AuthMgr.GetAccountConfigPath(account)
 		if err := app.AuthMgr.CopyMainConfigToAccount(account); err != nil {
-			app.Logger.Warnf("Failed to ensure account config exists: %v", err)
+			app.Logger.Warn(i18n.T("run.account_config_failed", err))
 		}
 
 		if data, readErr := os.ReadFile(accountConfigPath); readErr == nil {
 			if writeErr := os.WriteFile(projectClaudeConfig, data, 0644); writeErr != nil {
-				app.Logger.Warnf("Failed to create project-specific .claude.json: %v", writeErr)
+				app.Logger.Warn(i18n.T("run.project_config_failed", writeErr))
 			} else {
-				app.Logger.Infof("📄 Created project-specific config: %s", projectClaudeConfig)
+				app.Logger.Info(i18n.T("run.project_config_created", projectClaudeConfig))
 			}
 		} else {
-			app.Logger.Warnf("Failed to read account config template: %v", readErr)
+			app.Logger.Warn(i18n.T("run.account_template_failed", readErr))
 		}
 	}
 
+	// Carry host settings and project MCP servers over, with host paths rewritten
+	propagateClaudeSettings(app, claudeSessionDir, projectClaudeConfig, projectDir, targetPath)
+
 	// Mount project-specific .claude.json instead of account-wide config
 	// This prevents config file conflicts between different projects
 	if _, err := os.Stat(projectClaudeConfig); err == nil {
-		err = app.MountMgr.AddMountToConfig(containerConfig, projectClaudeConfig, "/home/claude/.claude.json")
+		err = addOptionalMount(app, containerConfig, projectClaudeConfig, "/home/claude/.claude.json")
 		if err != nil {
-			app.Logger.Warnf("Failed to add project Claude config mount: %v", err)
+			app.Logger.Warn(i18n.T("run.config_mount_failed", err))
 		} else {
-			app.Logger.Infof("🔑 Claude config mount: %s -> /home/claude/.claude.json", projectClaudeConfig)
+			app.Logger.Info(i18n.T("run.config_mount", projectClaudeConfig))
 		}
 	}
 
@@ -658,11 +1244,11 @@ func AddMountsToContainer(app *pkg.AppContainer, containerConfig *pkg.ContainerC
 	if err == nil {
 		mainCredentialsPath := filepath.Join(homeDir, ".claude", ".credentials.json")
 		if _, err := os.Stat(mainCredentialsPath); err == nil {
-			err = app.MountMgr.AddMountToConfig(containerConfig, mainCredentialsPath, "/home/claude/.claude/.credentials.json")
+			err = addOptionalMount(app, containerConfig, mainCredentialsPath, "/home/claude/.claude/.credentials.json")
 			if err != nil {
-				app.Logger.Warnf("Failed to add credentials mount: %v", err)
+				app.Logger.Warn(i18n.T("run.credentials_mount_failed", err))
 			} else {
-				app.Logger.Infof("🔐 Credentials mount: %s -> /home/claude/.claude/.credentials.json", mainCredentialsPath)
+				app.Logger.Info(i18n.T("run.credentials_mount", mainCredentialsPath))
 			}
 		} else {
 			app.Logger.Debugf("Main credentials file not found: %s", mainCredentialsPath)
@@ -672,14 +1258,18 @@ func AddMountsToContainer(app *pkg.AppContainer, containerConfig *pkg.ContainerC
 	// Add Docker socket mount if host Docker access is enabled
 	if containerConfig.HostDocker {
 		dockerSock := "/var/run/docker.sock"
-		if _, err := os.Stat(dockerSock); err == nil {
+		if runtime.GOOS == "windows" {
+			// Docker Desktop serves the socket inside its VM, so there is no host file to check
+			containerConfig.Mounts = append(containerConfig.Mounts, pkg.Mount{Source: dockerSock, Target: dockerSock, Type: "bind"})
+			app.Logger.Info(i18n.T("run.docker_socket_desktop", dockerSock))
+		} else if _, err := os.Stat(dockerSock); err == nil {
 			err = app.MountMgr.AddMountToConfig(containerConfig, dockerSock, "/var/run/docker.sock")
 			if err != nil {
 				return fmt.Errorf("failed to add Docker socket mount: %w", err)
 			}
-			app.Logger.Infof("🐳 Host Docker socket mount: %s -> /var/run/docker.sock", dockerSock)
+			app.Logger.Info(i18n.T("run.docker_socket", dockerSock))
 		} else {
-			return fmt.Errorf("host Docker requested but socket not available at %s\n💡 Mount Docker socket: -v /var/run/docker.sock:/var/run/docker.sock\n💡 Add docker group: --group-add docker\n💡 See documentation: claude-reactor help docker-setup", dockerSock)
+			return pkg.NewError(pkg.CodeHostDockerSocket, nil, "host Docker requested but socket not available at %s", dockerSock)
 		}
 	}
 
@@ -691,11 +1281,11 @@ func AddMountsToContainer(app *pkg.AppContainer, containerConfig *pkg.ContainerC
 		}
 
 		for _, mount := range sshMounts {
-			err = app.MountMgr.AddMountToConfig(containerConfig, mount.Source, mount.Target)
+			err = addOptionalMount(app, containerConfig, mount.Source, mount.Target)
 			if err != nil {
-				app.Logger.Warnf("Failed to add SSH mount %s -> %s: %v", mount.Source, mount.Target, err)
+				app.Logger.Warn(i18n.T("run.ssh_mount_failed", mount.Source, mount.Target, err))
 			} else {
-				app.Logger.Infof("🔑 SSH mount: %s -> %s", mount.Source, mount.Target)
+				app.Logger.Info(i18n.T("run.ssh_mount", mount.Source, mount.Target))
 			}
 		}
 
@@ -704,7 +1294,7 @@ func AddMountsToContainer(app *pkg.AppContainer, containerConfig *pkg.ContainerC
 		if containerConfig.Environment == nil {
 			containerConfig.Environment = make(map[string]string)
 		}
-		app.Logger.Info("🔑 SSH keys configured for Git operations")
+		app.Logger.Info(i18n.T("run.ssh_configured"))
 	}
 
 	// Add global subagents mount if directory exists
@@ -715,13 +1305,13 @@ func AddMountsToContainer(app *pkg.AppContainer, containerConfig *pkg.ContainerC
 			// Ensure the target directory exists in the session directory
 			sessionSubagentsDir := filepath.Join(claudeSessionDir, "agents")
 			if err := os.MkdirAll(sessionSubagentsDir, 0755); err != nil {
-				app.Logger.Warnf("Failed to create session subagents directory: %v", err)
+				app.Logger.Warn(i18n.T("run.subagents_dir_failed", err))
 			} else {
-				err = app.MountMgr.AddMountToConfig(containerConfig, globalSubagentsDir, "/home/claude/.claude/agents")
+				err = addOptionalMount(app, containerConfig, globalSubagentsDir, "/home/claude/.claude/agents")
 				if err != nil {
-					app.Logger.Warnf("Failed to add global subagents mount: %v", err)
+					app.Logger.Warn(i18n.T("run.subagents_mount_failed", err))
 				} else {
-					app.Logger.Infof("🤖 Global subagents mount: %s -> /home/claude/.claude/agents", globalSubagentsDir)
+					app.Logger.Info(i18n.T("run.subagents_mount", globalSubagentsDir))
 				}
 			}
 		} else {
@@ -736,47 +1326,605 @@ func AddMountsToContainer(app *pkg.AppContainer, containerConfig *pkg.ContainerC
 		// The project directory is already mounted at /app or /workspace,
 		// so project subagents will be automatically available at /app/.claude/agents
 		// or /workspace/.claude/agents. We just log this for visibility.
-		app.Logger.Infof("🤖 Project subagents detected: %s (available via project mount)", projectSubagentsDir)
+		app.Logger.Info(i18n.T("run.project_subagents", projectSubagentsDir))
 	} else {
 		app.Logger.Debugf("Project-specific subagents directory not found: %s", projectSubagentsDir)
 	}
 
-	// Add user-specified mounts
-	for _, mountPath := range userMounts {
-		validatedPath, err := app.MountMgr.ValidateMountPath(mountPath)
+	// Add user-specified mounts, given as src[:dst][:ro|rw][:cached|delegated|consistent]
+	for _, spec := range userMounts {
+		mount, err := app.MountMgr.ParseMountSpec(spec)
 		if err != nil {
-			return fmt.Errorf("invalid mount path '%s': %w", mountPath, err)
+			return err
 		}
-
-		// Generate target path (mount to /mnt/basename)
-		targetPath := "/mnt/" + filepath.Base(validatedPath)
-		err = app.MountMgr.AddMountToConfig(containerConfig, validatedPath, targetPath)
+		if err := app.MountMgr.AddMount(containerConfig, mount); err != nil {
+			return fmt.Errorf("failed to add user mount '%s': %w", spec, err)
+		}
+		app.Logger.Info(i18n.T("run.added_mount", mount.Source, mount.Target, mountModes(mount)))
+	}
+	for _, target := range tmpfs {
+		mount, err := app.MountMgr.ParseTmpfsSpec(target)
 		if err != nil {
-			return fmt.Errorf("failed to add user mount '%s': %w", mountPath, err)
+			return err
+		}
+		if err := app.MountMgr.AddMount(containerConfig, mount); err != nil {
+			return fmt.Errorf("failed to add tmpfs mount '%s': %w", target, err)
+		}
+		app.Logger.Info(i18n.T("run.added_tmpfs", mount.Target))
+	}
+
+	return nil
+}
+
+// mountModes describes a user mount's non-default modes for logging
+func mountModes(mount *pkg.Mount) string {
+	var modes []string
+	if mount.ReadOnly {
+		modes = append(modes, "read-only")
+	}
+	if mount.Consistency != "" {
+		modes = append(modes, mount.Consistency)
+	}
+	if len(modes) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(modes, ", ") + ")"
+}
+
+// configList splits a comma-separated configuration value, dropping empty entries
+func configList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// propagateClaudeSettings copies the host's ~/.claude/settings.json and the project's MCP
+// servers into the session, so Claude behaves as it does on the host. Failures only warn.
+func propagateClaudeSettings(app *pkg.AppContainer, sessionDir, claudeConfig, projectDir, target string) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	paths := claudeconfig.Paths{HostHome: homeDir, HostProject: projectDir, ContainerProject: target}
+
+	if synced, err := claudeconfig.SyncSettings(paths, sessionDir); err != nil {
+		app.Logger.Warn(i18n.T("run.settings_failed", err))
+	} else if synced {
+		app.Logger.Info(i18n.T("run.claude_settings", filepath.Join(homeDir, ".claude", claudeconfig.SettingsFile)))
+	}
+
+	if _, err := os.Stat(claudeConfig); err != nil {
+		return
+	}
+	managed, err := mcp.Load(sessionDir)
+	if err != nil {
+		app.Logger.Warn(i18n.T("run.mcp_read_failed", err))
+	}
+	servers, err := claudeconfig.SyncMCPServers(paths, claudeConfig, mcp.ClaudeEntries(managed), app.Logger)
+	if err != nil {
+		app.Logger.Warn(i18n.T("run.mcp_copy_failed", err))
+	} else if len(servers) > 0 {
+		app.Logger.Info(i18n.T("run.mcp_servers", strings.Join(servers, ", ")))
+	}
+}
+
+// seedSystemPrompt refreshes the organization guidance in the session's CLAUDE.md from the
+// system_prompt files, so every repository starts from the same shared source. Relative paths
+// are resolved against the project. Failures only warn and keep the previous guidance.
+func seedSystemPrompt(app *pkg.AppContainer, config *pkg.Config, projectDir string) {
+	homeDir, _ := os.UserHomeDir()
+	var sources []string
+	for _, source := range strings.Split(config.SystemPrompt, ",") {
+		if source = strings.TrimSpace(source); source == "" {
+			continue
 		}
+		source = expandHome(source, homeDir)
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(projectDir, source)
+		}
+		sources = append(sources, source)
+	}
 
-		app.Logger.Infof("📁 Added mount: %s -> %s", validatedPath, targetPath)
+	sessionDir := app.AuthMgr.GetProjectSessionDir(config.Account, projectDir)
+	if err := claudeconfig.SeedMemory(sessionDir, sources); err != nil {
+		app.Logger.Warn(i18n.T("run.system_prompt_failed", err))
+	} else if len(sources) > 0 {
+		app.Logger.Info(i18n.T("run.system_prompt", strings.Join(sources, ", ")))
 	}
+}
 
+// addOptionalMount adds a mount the session can start without, such as credentials or
+// subagents, so a problem with it is a warning rather than a failed run
+func addOptionalMount(app *pkg.AppContainer, containerConfig *pkg.ContainerConfig, source, target string) error {
+	if err := app.MountMgr.AddMountToConfig(containerConfig, source, target); err != nil {
+		return err
+	}
+	for i := range containerConfig.Mounts {
+		if containerConfig.Mounts[i].Target == target {
+			containerConfig.Mounts[i].Optional = true
+		}
+	}
 	return nil
 }
 
+// projectLockTimeout bounds how long to wait for another invocation to finish starting the project
+const projectLockTimeout = 5 * time.Minute
+
+// lockProject takes the project's advisory lock, telling the user if another claude-reactor
+// holds it. The caller must release the lock.
+func lockProject(ctx context.Context, app *pkg.AppContainer, projectDir string) (*lock.Lock, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	path, err := lock.ProjectPath(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	projectLock, err := lock.Acquire(ctx, path, projectLockTimeout, func(holder string) {
+		if holder != "" {
+			app.Logger.Info(i18n.T("run.waiting_pid", holder))
+		} else {
+			app.Logger.Info(i18n.T("run.waiting"))
+		}
+	})
+	if errors.Is(err, lock.ErrTimeout) {
+		return nil, pkg.NewError(pkg.CodeProjectBusy, nil, "another claude-reactor is still starting this project after %s", projectLockTimeout)
+	}
+	return projectLock, err
+}
+
+// containerAction is what run does with the project container
+type containerAction string
+
+const (
+	actionCreate   containerAction = "create"   // no container exists
+	actionReuse    containerAction = "reuse"    // attach to the running container
+	actionResume   containerAction = "resume"   // start the stopped container again
+	actionRecreate containerAction = "recreate" // remove the container and create a new one
+)
+
+// decideContainerAction chooses what to do with an existing project container, returning the
+// action and why. A container is kept when it was created with the same configuration
+// (configHash) a new one would get; --reuse keeps it regardless and --recreate never does.
+// Containers created before configurations were recorded are kept.
+func decideContainerAction(status *pkg.ContainerStatus, configHash string, sessionPersistence, reuse, recreate bool) (containerAction, string) {
+	if status == nil || !status.Exists {
+		return actionCreate, "no existing container"
+	}
+	if recreate {
+		return actionRecreate, "--recreate flag"
+	}
+
+	changed := status.ConfigHash != "" && status.ConfigHash != configHash
+	if changed && !reuse {
+		return actionRecreate, "configuration changed since it was created; use --reuse to keep it"
+	}
+	if !status.Running && !sessionPersistence && !status.Fresh {
+		return actionRecreate, "stopped, and session persistence is off"
+	}
+
+	reason := "configuration unchanged"
+	switch {
+	case changed:
+		reason = "--reuse flag; configuration changed since it was created"
+	case status.ConfigHash == "":
+		reason = "created by an older version; use --recreate to apply configuration changes"
+	case status.Fresh:
+		reason = "pre-created by 'claude-reactor prewarm'"
+	}
+	if !status.Running {
+		return actionResume, reason
+	}
+	return actionReuse, reason
+}
+
+// startProjectContainer carries out action on the project container described by
+// containerConfig, whose current state is status, and returns the ID of the running container
+func startProjectContainer(ctx context.Context, containers pkg.ContainerService, logger pkg.Logger, containerConfig *pkg.ContainerConfig, config *pkg.Config, status *pkg.ContainerStatus, action containerAction, reason string) (string, error) {
+	var containerID string
+	var err error
+	switch {
+	case action == actionReuse:
+		logger.Info(i18n.T("run.reusing", reason))
+		return status.ID, nil
+	case action == actionResume && status.Fresh:
+		logger.Info(i18n.T("run.starting_precreated", reason))
+		containerID, err = containers.StartCreatedContainer(ctx, containerConfig, status.ID)
+	default:
+		if action == actionRecreate {
+			logger.Info(i18n.T("run.recreating", reason))
+			if err := containers.RemoveContainer(ctx, status.ID); err != nil {
+				return "", fmt.Errorf("failed to remove container for recreation: %w", err)
+			}
+		}
+
+		if config.SessionPersistence {
+			logger.Info(i18n.T("run.starting_persistent"))
+			// StartOrRecoverContainer handles logic for resuming stopped containers
+			containerID, err = containers.StartOrRecoverContainer(ctx, containerConfig, config)
+		} else {
+			logger.Info(i18n.T("run.starting_ephemeral"))
+			containerID, err = containers.StartContainer(ctx, containerConfig)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to start container: %w. Check Docker daemon is running and try 'docker system prune'", err)
+	}
+	return containerID, nil
+}
+
+// resolveProjectContainer returns the container name used by 'run' for the current directory,
+// changing to the root of its project as 'run' does, along with the project configuration (account normalised, ProjectPath set)
+func resolveProjectContainer(app *pkg.AppContainer) (string, *pkg.Config, error) {
+	if _, err := enterProjectRoot(app, true); err != nil {
+		return "", nil, err
+	}
+	config, err := app.ConfigMgr.LoadConfig()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	return projectContainer(app, config, projectDir, "")
+}
+
+// resolveProjectContainerAt is resolveProjectContainer for the project rooted at projectDir,
+// without changing the current directory
+func resolveProjectContainerAt(app *pkg.AppContainer, projectDir string) (string, *pkg.Config, error) {
+	config, err := app.ConfigMgr.LoadProjectConfig(projectDir)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return projectContainer(app, config, projectDir, projectDir)
+}
+
+// projectContainer normalises the configuration of the project in projectDir and returns its
+// container name; detectDir is where the variant is detected from ("" for the current directory)
+func projectContainer(app *pkg.AppContainer, config *pkg.Config, projectDir, detectDir string) (string, *pkg.Config, error) {
+	if config.Account == "" {
+		config.Account = app.AuthMgr.GetDefaultAccount()
+	}
+	if config.Variant == "" {
+		config.Variant, _ = app.ConfigMgr.AutoDetectVariant(detectDir)
+	}
+
+	arch, err := projectArchitecture(app, config)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to detect architecture: %w", err)
+	}
+	config.ProjectPath = projectDir
+
+	return app.DockerMgr.GenerateContainerName(projectDir, config.Variant, arch, config.Account), config, nil
+}
+
+// builtinImage returns the image used for a built-in variant: the local build if there is
+// one, otherwise the published registry image
+func builtinImage(ctx context.Context, app *pkg.AppContainer, variant, imageName string) (string, bool) {
+	// Check if local image exists by checking its validity without pull
+	if _, err := app.ImageValidator.ValidateImage(ctx, imageName, false); err != nil {
+		return fmt.Sprintf("ghcr.io/dyluth/claude-reactor-%s:latest", variant), false
+	}
+	return imageName, true
+}
+
+// checkImagePolicy refuses custom images the image policy does not allow. An unreadable
+// policy refuses every custom image rather than being ignored.
+func checkImagePolicy(image string) error {
+	p, err := policy.Load()
+	if err != nil {
+		return err
+	}
+	return p.Check(image)
+}
+
+// checkImageSignature verifies a pulled image against the signers in the image policy. A
+// failed verification refuses the image when signatures are required and warns otherwise.
+func checkImageSignature(ctx context.Context, app *pkg.AppContainer, imageName string) error {
+	p, err := policy.Load()
+	if err != nil {
+		return err
+	}
+	if !p.Signatures.Configured() {
+		return nil
+	}
+
+	app.Logger.Info(i18n.T("run.verifying_signature", imageName))
+	signer, err := app.ImageValidator.VerifySignature(ctx, imageName, p.Signatures)
+	if err != nil {
+		if p.Signatures.Required {
+			return fmt.Errorf("image '%s' failed signature verification required by %s: %w", imageName, p.Source, err)
+		}
+		app.Logger.Warn(i18n.T("run.signature_failed", err))
+		return nil
+	}
+	app.Logger.Info(i18n.T("run.signature_verified", signer))
+	return nil
+}
+
+// imageCachePolicy returns the image cache policy set by image_cache_ttl, image_cache_size
+// and image_cache_url
+func imageCachePolicy(config *pkg.Config) (pkg.ImageCachePolicy, error) {
+	policy := pkg.ImageCachePolicy{MaxEntries: config.ImageCacheSize}
+	if config.ImageCacheTTL != "" {
+		ttl, err := time.ParseDuration(config.ImageCacheTTL)
+		if err != nil || ttl <= 0 {
+			return policy, fmt.Errorf("invalid image_cache_ttl '%s': use a positive duration such as 168h", config.ImageCacheTTL)
+		}
+		policy.TTL = ttl
+	}
+	if err := validation.ValidateSharedCache(config.ImageCacheURL); err != nil {
+		return policy, err
+	}
+	policy.Shared = config.ImageCacheURL
+	return policy, nil
+}
+
+// configureImageCache applies the configured cache policy to the image validator
+func configureImageCache(app *pkg.AppContainer, config *pkg.Config, revalidate bool) {
+	if app.ImageValidator == nil {
+		return
+	}
+	policy, err := imageCachePolicy(config)
+	if err != nil {
+		app.Logger.Warn(i18n.T("run.mirror_default", err))
+	}
+	app.ImageValidator.SetCachePolicy(policy)
+	app.ImageValidator.SetRevalidate(revalidate)
+}
+
+// configureRegistryMirror routes pulls of published images through the configured mirror
+func configureRegistryMirror(app *pkg.AppContainer, config *pkg.Config) {
+	mirror := registry.Resolve(config.RegistryMirror)
+	if _, err := registry.ParseMirror(mirror); err != nil {
+		app.Logger.Warn(i18n.T("run.mirror_direct", err, registry.DefaultRegistry))
+		mirror = ""
+	}
+	if app.DockerMgr != nil {
+		app.DockerMgr.SetRegistryMirror(mirror)
+	}
+	if app.ImageValidator != nil {
+		app.ImageValidator.SetRegistryMirror(mirror)
+	}
+}
+
+// externalVariant returns the definition of variant if it comes from ~/.claude-reactor/variants.d
+func externalVariant(app *pkg.AppContainer, variant string) *pkg.VariantDefinition {
+	definitions, err := app.DockerMgr.ListVariants()
+	if err != nil {
+		return nil
+	}
+	for i := range definitions {
+		if definitions[i].Name == variant && definitions[i].Source != "" {
+			return &definitions[i]
+		}
+	}
+	return nil
+}
+
+// externalVariantImage returns the image for an external variant: its prebuilt image, pulled
+// if needed, or a local build of its Dockerfile made on first use
+func externalVariantImage(ctx context.Context, app *pkg.AppContainer, images pkg.ImageService, notifier *notify.Notifier, definition *pkg.VariantDefinition, imageName, platform string) (string, error) {
+	if definition.Image != "" {
+		if err := checkImagePolicy(definition.Image); err != nil {
+			return "", err
+		}
+		app.Logger.Info(i18n.T("run.variant_image", definition.Image, definition.Name))
+		if _, err := app.ImageValidator.ValidateImage(ctx, definition.Image, true); err != nil {
+			return "", fmt.Errorf("failed to get image for variant '%s': %w", definition.Name, err)
+		}
+		return definition.Image, nil
+	}
+
+	if _, err := app.ImageValidator.ValidateImage(ctx, imageName+":latest", false); err == nil {
+		app.Logger.Info(i18n.T("run.local_image", imageName))
+		return imageName, nil
+	}
+
+	if platform == "" {
+		var err error
+		if platform, err = app.ArchDetector.GetDockerPlatform(); err != nil {
+			return "", fmt.Errorf("failed to get Docker platform: %w", err)
+		}
+	}
+	app.Logger.Info(i18n.T("run.building_variant", definition.Name, definition.Dockerfile))
+	started := time.Now()
+	err := images.BuildImage(ctx, definition.Name, platform)
+	notifyBuild(app, notifier, definition.Name, started, err)
+	if err != nil {
+		return "", fmt.Errorf("failed to build variant '%s': %w", definition.Name, err)
+	}
+	return imageName, nil
+}
+
+// packagesImage returns the image with the project's packages installed on imageName, built
+// on first use and whenever the packages or the base image change. A registry base image is
+// pulled first, since the derived image is built from it locally.
+func packagesImage(ctx context.Context, app *pkg.AppContainer, images pkg.ImageService, config *pkg.Config, imageName string, fromRegistry bool) (string, error) {
+	if fromRegistry {
+		if _, err := app.ImageValidator.ValidateImage(ctx, imageName, true); err != nil {
+			return "", fmt.Errorf("failed to get image %s for project packages: %w", imageName, err)
+		}
+	}
+	platform := config.Platform
+	if platform == "" {
+		var err error
+		if platform, err = app.ArchDetector.GetDockerPlatform(); err != nil {
+			return "", fmt.Errorf("failed to get Docker platform: %w", err)
+		}
+	}
+
+	derived, err := images.BuildPackagesImage(ctx, imageName, config.Packages, platform)
+	if err != nil {
+		return "", fmt.Errorf("failed to install project packages: %w", err)
+	}
+	return derived, nil
+}
+
+// projectArchitecture returns the architecture containers are named for: the configured
+// platform's, or the host's. Containers for different platforms therefore never collide.
+func projectArchitecture(app *pkg.AppContainer, config *pkg.Config) (string, error) {
+	if config.Platform != "" {
+		if err := architecture.ValidatePlatform(config.Platform); err != nil {
+			return "", err
+		}
+		return architecture.PlatformArch(config.Platform), nil
+	}
+	return app.ArchDetector.GetHostArchitecture()
+}
+
+// containerBranding returns the hostname of a project's containers and the label their shell
+// prompt starts with, "" when the prompt label is turned off
+func containerBranding(config *pkg.Config, projectDir string) (string, string) {
+	hostname := config.Hostname
+	if hostname == "" {
+		hostname = docker.DefaultHostname
+	}
+	prompt := config.Prompt
+	switch prompt {
+	case "":
+		prompt = docker.DefaultPrompt
+	case "off":
+		prompt = ""
+	}
+	return docker.Hostname(docker.Branding(hostname, projectDir, config.Variant, config.Account)),
+		docker.Branding(prompt, projectDir, config.Variant, config.Account)
+}
+
+// hostTimezone returns the host's timezone and where it was read from, or "" if unknown
+func hostTimezone() (string, string) {
+	if tz := os.Getenv("TZ"); tz != "" {
+		return tz, "$TZ"
+	}
+	// Fallback: try to read /etc/timezone
+	if data, err := os.ReadFile("/etc/timezone"); err == nil {
+		if tz := strings.TrimSpace(string(data)); tz != "" {
+			return tz, "/etc/timezone"
+		}
+	}
+	return "", ""
+}
+
+// projectMountTarget returns where the project is mounted in the container
+func projectMountTarget(projectDir string) string {
+	if projectDir == "/app" {
+		return "/workspace" // Use different path to avoid circular mount
+	}
+	return "/app"
+}
+
 // displayHostDockerSecurityWarning shows a prominent security warning when host Docker access is enabled
 func displayHostDockerSecurityWarning(logger pkg.Logger, timeout string) {
 	logger.Info("")
-	logger.Info("⚠️  WARNING: HOST DOCKER ACCESS ENABLED")
-	logger.Info("🔒 This grants claude-reactor container HOST-LEVEL Docker privileges:")
-	logger.Info("   • Can create/manage ANY container on the host")
-	logger.Info("   • Can mount/access ANY host directory")
-	logger.Info("   • Can access host network and other containers")
-	logger.Info("   • Equivalent to ROOT access on the host system")
+	logger.Info(i18n.T("run.host_docker_warning"))
+	logger.Info(i18n.T("run.host_docker_grants"))
+	logger.Info(i18n.T("run.host_docker_containers"))
+	logger.Info(i18n.T("run.host_docker_directories"))
+	logger.Info(i18n.T("run.host_docker_network"))
+	logger.Info(i18n.T("run.host_docker_root"))
 
 	if timeout == "0" || timeout == "0s" {
-		logger.Info("⏰ Docker operations: UNLIMITED TIMEOUT (no timeout protection)")
+		logger.Info(i18n.T("run.host_docker_unlimited"))
 	} else {
-		logger.Info(fmt.Sprintf("⏰ Docker operations timeout: %s", timeout))
+		logger.Info(i18n.T("run.host_docker_timeout", timeout))
 	}
 
-	logger.Info("💡 Only enable for trusted workflows requiring Docker management")
+	logger.Info(i18n.T("run.host_docker_hint"))
 	logger.Info("")
 }
+
+// asdfPlugins maps toolchain names to their asdf plugin names where they differ from mise
+var asdfPlugins = map[string]string{
+	"go":   "golang",
+	"node": "nodejs",
+}
+
+// toolchainInstallScript installs version $2 of tool $1 with mise, or with asdf plugin $3.
+// The values come from project files, so they are passed as arguments rather than formatted
+// into the script.
+const toolchainInstallScript = `if command -v mise >/dev/null 2>&1; then mise use --global "$1@$2"; ` +
+	`elif command -v asdf >/dev/null 2>&1; then asdf plugin add "$3" >/dev/null 2>&1; asdf install "$3" "$2" && asdf global "$3" "$2"; ` +
+	`else echo 'neither mise nor asdf is installed'; exit 127; fi`
+
+// checkToolchainVersions warns when the container's toolchains don't match versions pinned
+// by the project, and optionally installs the requested versions with mise or asdf
+func checkToolchainVersions(ctx context.Context, execer pkg.ExecService, logger pkg.Logger, containerName, projectDir string, install bool) {
+	for _, req := range detection.DetectToolchainVersions(projectDir) {
+		output, exitCode, err := execer.ExecCommand(ctx, containerName, []string{"sh", "-c", detection.VersionCommand(req.Tool)})
+		if err != nil {
+			logger.Debugf("Failed to check %s version: %v", req.Tool, err)
+			continue
+		}
+
+		provided := ""
+		if exitCode == 0 {
+			provided = detection.ParseVersion(output)
+		}
+		if provided != "" && req.Satisfies(provided) {
+			logger.Debugf("%s %s satisfies %s from %s", req.Tool, provided, req.Version, req.Source)
+			continue
+		}
+
+		if provided == "" {
+			logger.Warn(i18n.T("run.toolchain_missing", req.Source, req.Tool, req.Version, req.Tool))
+		} else {
+			logger.Warn(i18n.T("run.toolchain_mismatch", req.Source, req.Tool, req.Version, provided))
+		}
+
+		if !install {
+			logger.Info(i18n.T("run.toolchain_install_hint"))
+			continue
+		}
+
+		logger.Info(i18n.T("run.toolchain_installing", req.Tool, req.Version))
+		plugin := req.Tool
+		if name, ok := asdfPlugins[req.Tool]; ok {
+			plugin = name
+		}
+		output, exitCode, err = execer.ExecCommand(ctx, containerName, []string{"sh", "-c", toolchainInstallScript, "sh", req.Tool, req.Version, plugin})
+		if err != nil || exitCode != 0 {
+			logger.Warn(i18n.T("run.toolchain_install_failed", req.Tool, req.Version, strings.TrimSpace(output)))
+			if exitCode == 127 {
+				logger.Info(i18n.T("run.toolchain_manager_hint"))
+			}
+			continue
+		}
+		logger.Info(i18n.T("run.toolchain_installed", req.Tool, req.Version))
+	}
+}
+
+// checkImageVulnerabilities scans an image and refuses to continue when it has findings
+// at or above the threshold, or cannot be scanned, unless the user explicitly allows
+// vulnerable images
+func checkImageVulnerabilities(ctx context.Context, app *pkg.AppContainer, imageName, threshold string, allowVulnerable bool) error {
+	app.Logger.Info(i18n.T("run.scanning", threshold))
+	scan, err := app.ImageValidator.ScanImage(ctx, imageName)
+	if err != nil {
+		// An image that can't be scanned is refused like one over the threshold
+		if allowVulnerable {
+			app.Logger.Warn(i18n.T("run.scan_failed_allowed", err))
+			return nil
+		}
+		return pkg.NewError(pkg.CodeImageScanFailed, err, "cannot check image '%s' against vuln_threshold %s", imageName, threshold)
+	}
+
+	found := validation.ExceedsThreshold(scan, threshold)
+	if found == 0 {
+		app.Logger.Info(i18n.T("run.scan_clean", threshold, scan.Scanner))
+		return nil
+	}
+
+	if allowVulnerable {
+		app.Logger.Warn(i18n.T("run.vulnerable_allowed", found, threshold))
+		return nil
+	}
+
+	info, _ := pkg.LookupError(pkg.CodeImageVulnerable)
+	return pkg.NewError(pkg.CodeImageVulnerable, nil, "image '%s' has %d vulnerabilities at %s severity or above", imageName, found, threshold).
+		WithRemediation(append([]string{fmt.Sprintf("Review with: %s image %s", scan.Scanner, imageName)}, info.Remediation...)...)
+}
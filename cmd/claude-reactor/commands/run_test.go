@@ -0,0 +1,320 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/mount"
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func TestRunCIFlag(t *testing.T) {
+	t.Run("ci flag exists", func(t *testing.T) {
+		cmd := NewRunCmd(createMockApp())
+
+		flag := cmd.Flags().Lookup("ci")
+		assert.NotNil(t, flag)
+		assert.Equal(t, "false", flag.DefValue)
+	})
+
+	t.Run("ci without a command fails", func(t *testing.T) {
+		app := createMockApp()
+		cmd := NewRunCmd(app)
+		cmd.SetArgs([]string{"--ci"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "--ci requires a command")
+	})
+}
+
+func TestRunTmuxFlag(t *testing.T) {
+	t.Run("tmux flag exists", func(t *testing.T) {
+		cmd := NewRunCmd(createMockApp())
+
+		flag := cmd.Flags().Lookup("tmux")
+		assert.NotNil(t, flag)
+		assert.Equal(t, "false", flag.DefValue)
+	})
+
+	t.Run("tmux with no-persist fails", func(t *testing.T) {
+		cmd := NewRunCmd(createMockApp())
+		cmd.SetArgs([]string{"--tmux", "--no-persist"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "--no-persist")
+	})
+}
+
+func TestRunDeviceFlag(t *testing.T) {
+	cmd := NewRunCmd(createMockApp())
+	require.NoError(t, cmd.Flags().Set("device", "/dev/snd"))
+	require.NoError(t, cmd.Flags().Set("device", "/dev/video0"))
+
+	devices, err := cmd.Flags().GetStringSlice("device")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/dev/snd", "/dev/video0"}, devices)
+}
+
+func TestProjectArchitecture(t *testing.T) {
+	app := createMockApp()
+
+	arch, err := projectArchitecture(app, &pkg.Config{Platform: "linux/amd64"})
+	require.NoError(t, err)
+	assert.Equal(t, "amd64", arch)
+
+	_, err = projectArchitecture(app, &pkg.Config{Platform: "linux/riscv64"})
+	assert.Error(t, err)
+}
+
+func TestPackagesImage(t *testing.T) {
+	packages := pkg.Packages{Apt: []string{"protobuf-compiler"}}
+
+	t.Run("builds on the configured platform", func(t *testing.T) {
+		images := &mocks.MockImageService{}
+		images.On("BuildPackagesImage", mock.Anything, "claude-reactor-go", packages, "linux/amd64").
+			Return("claude-reactor-packages:0123456789ab", nil)
+		app := createMockApp()
+
+		image, err := packagesImage(context.Background(), app, images, &pkg.Config{Platform: "linux/amd64", Packages: packages}, "claude-reactor-go", false)
+		require.NoError(t, err)
+		assert.Equal(t, "claude-reactor-packages:0123456789ab", image)
+	})
+
+	t.Run("defaults to the host platform", func(t *testing.T) {
+		images := &mocks.MockImageService{}
+		images.On("BuildPackagesImage", mock.Anything, "claude-reactor-go", packages, "linux/arm64").
+			Return("", errors.New("apt-get failed"))
+		archDetector := &mocks.MockArchDetector{}
+		archDetector.On("GetDockerPlatform").Return("linux/arm64", nil)
+		app := createMockApp()
+		app.ArchDetector = archDetector
+
+		_, err := packagesImage(context.Background(), app, images, &pkg.Config{Packages: packages}, "claude-reactor-go", false)
+		assert.ErrorContains(t, err, "failed to install project packages: apt-get failed")
+	})
+}
+
+func TestStartProjectContainer(t *testing.T) {
+	containerConfig := &pkg.ContainerConfig{Name: "claude-reactor-go-amd64-abc123-work"}
+	start := func(containers *mocks.MockContainerService, config *pkg.Config, status *pkg.ContainerStatus, action containerAction) (string, error) {
+		return startProjectContainer(context.Background(), containers, &captureLogger{}, containerConfig, config, status, action, "test")
+	}
+
+	t.Run("reuses the running container", func(t *testing.T) {
+		containers := &mocks.MockContainerService{}
+		id, err := start(containers, &pkg.Config{}, &pkg.ContainerStatus{Exists: true, Running: true, ID: "c1"}, actionReuse)
+		require.NoError(t, err)
+		assert.Equal(t, "c1", id)
+		containers.AssertExpectations(t)
+	})
+
+	t.Run("starts a pre-created container", func(t *testing.T) {
+		containers := &mocks.MockContainerService{}
+		containers.On("StartCreatedContainer", mock.Anything, containerConfig, "c1").Return("c1", nil)
+		id, err := start(containers, &pkg.Config{}, &pkg.ContainerStatus{Exists: true, Fresh: true, ID: "c1"}, actionResume)
+		require.NoError(t, err)
+		assert.Equal(t, "c1", id)
+		containers.AssertExpectations(t)
+	})
+
+	t.Run("recreates with session persistence", func(t *testing.T) {
+		config := &pkg.Config{SessionPersistence: true}
+		containers := &mocks.MockContainerService{}
+		containers.On("RemoveContainer", mock.Anything, "old").Return(nil)
+		containers.On("StartOrRecoverContainer", mock.Anything, containerConfig, config).Return("new", nil)
+		id, err := start(containers, config, &pkg.ContainerStatus{Exists: true, ID: "old"}, actionRecreate)
+		require.NoError(t, err)
+		assert.Equal(t, "new", id)
+		containers.AssertExpectations(t)
+	})
+
+	t.Run("creates an ephemeral container", func(t *testing.T) {
+		containers := &mocks.MockContainerService{}
+		containers.On("StartContainer", mock.Anything, containerConfig).Return("", errors.New("port is already allocated"))
+		_, err := start(containers, &pkg.Config{}, nil, actionCreate)
+		assert.ErrorContains(t, err, "failed to start container: port is already allocated")
+	})
+
+	t.Run("a failed removal stops recreation", func(t *testing.T) {
+		containers := &mocks.MockContainerService{}
+		containers.On("RemoveContainer", mock.Anything, "old").Return(errors.New("container is paused"))
+		_, err := start(containers, &pkg.Config{}, &pkg.ContainerStatus{Exists: true, ID: "old"}, actionRecreate)
+		assert.ErrorContains(t, err, "failed to remove container for recreation")
+		containers.AssertNotCalled(t, "StartContainer", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCheckToolchainVersions(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".nvmrc"), []byte("22\n"), 0644))
+	isInstall := mock.MatchedBy(func(command []string) bool { return command[2] == toolchainInstallScript })
+	isVersion := mock.MatchedBy(func(command []string) bool { return command[2] != toolchainInstallScript })
+
+	t.Run("a satisfied pin needs nothing installed", func(t *testing.T) {
+		execer := &mocks.MockExecService{}
+		execer.On("ExecCommand", mock.Anything, "app", isVersion).Return("v22.20.0", 0, nil)
+		checkToolchainVersions(context.Background(), execer, &captureLogger{}, "app", projectDir, true)
+		execer.AssertNotCalled(t, "ExecCommand", mock.Anything, "app", isInstall)
+	})
+
+	t.Run("a mismatch installs the pinned version", func(t *testing.T) {
+		execer := &mocks.MockExecService{}
+		execer.On("ExecCommand", mock.Anything, "app", isVersion).Return("v20.11.0", 0, nil)
+		execer.On("ExecCommand", mock.Anything, "app", isInstall).Return("", 0, nil)
+		logger := &captureLogger{}
+		checkToolchainVersions(context.Background(), execer, logger, "app", projectDir, true)
+		execer.AssertExpectations(t)
+		execer.AssertCalled(t, "ExecCommand", mock.Anything, "app", []string{"sh", "-c", toolchainInstallScript, "sh", "node", "22", "nodejs"})
+		assert.Contains(t, logger.messages, "✅ Installed node 22")
+	})
+
+	t.Run("a mismatch only warns without toolchain_install", func(t *testing.T) {
+		execer := &mocks.MockExecService{}
+		execer.On("ExecCommand", mock.Anything, "app", isVersion).Return("v20.11.0", 0, nil)
+		logger := &captureLogger{}
+		checkToolchainVersions(context.Background(), execer, logger, "app", projectDir, false)
+		execer.AssertNumberOfCalls(t, "ExecCommand", 1)
+		assert.Contains(t, logger.messages, "⚠️  .nvmrc pins node 22 but the image provides 20.11.0")
+	})
+}
+
+func TestCheckImageVulnerabilities(t *testing.T) {
+	newApp := func(scan *pkg.ImageScanResult, err error) *pkg.AppContainer {
+		validator := &mocks.MockImageValidator{}
+		validator.On("ScanImage", mock.Anything, "app:latest").Return(scan, err)
+		app := createMockApp()
+		app.ImageValidator = validator
+		return app
+	}
+	ctx := context.Background()
+	vulnerable := &pkg.ImageScanResult{Scanner: "trivy", Counts: map[string]int{"critical": 2, "low": 5}}
+
+	err := checkImageVulnerabilities(ctx, newApp(vulnerable, nil), "app:latest", "high", false)
+	var coded *pkg.Error
+	require.True(t, errors.As(err, &coded))
+	assert.Equal(t, pkg.CodeImageVulnerable, coded.Code)
+	assert.NoError(t, checkImageVulnerabilities(ctx, newApp(vulnerable, nil), "app:latest", "high", true))
+
+	t.Run("an image that cannot be scanned is refused", func(t *testing.T) {
+		scanErr := pkg.NewError(pkg.CodeHostToolMissing, nil, "no vulnerability scanner found")
+		err := checkImageVulnerabilities(ctx, newApp(nil, scanErr), "app:latest", "high", false)
+		var coded *pkg.Error
+		require.True(t, errors.As(err, &coded))
+		assert.Equal(t, pkg.CodeImageScanFailed, coded.Code)
+		assert.ErrorContains(t, err, "no vulnerability scanner found")
+
+		assert.NoError(t, checkImageVulnerabilities(ctx, newApp(nil, scanErr), "app:latest", "high", true), "--allow-vulnerable bypasses a failed scan")
+	})
+}
+
+func TestContainerBranding(t *testing.T) {
+	hostname, prompt := containerBranding(&pkg.Config{Variant: "go", Account: "work"}, "/home/me/src/My_App")
+	assert.Equal(t, "my-app-go-work", hostname)
+	assert.Equal(t, "claude-reactor:My_App@work", prompt)
+
+	hostname, prompt = containerBranding(&pkg.Config{Variant: "go", Account: "work", Hostname: "dev-{account}", Prompt: "{project} ({variant})"}, "/home/me/src/api")
+	assert.Equal(t, "dev-work", hostname)
+	assert.Equal(t, "api (go)", prompt)
+
+	_, prompt = containerBranding(&pkg.Config{Variant: "go", Prompt: "off"}, "/home/me/src/api")
+	assert.Empty(t, prompt)
+}
+
+func TestDecideContainerAction(t *testing.T) {
+	running := &pkg.ContainerStatus{Exists: true, Running: true, ID: "abc", ConfigHash: "same"}
+	stopped := &pkg.ContainerStatus{Exists: true, ID: "abc", ConfigHash: "same"}
+	legacy := &pkg.ContainerStatus{Exists: true, Running: true, ID: "abc"}
+	fresh := &pkg.ContainerStatus{Exists: true, Fresh: true, ID: "abc", ConfigHash: "same"}
+
+	tests := []struct {
+		name               string
+		status             *pkg.ContainerStatus
+		hash               string
+		sessionPersistence bool
+		reuse, recreate    bool
+		want               containerAction
+	}{
+		{"no container", nil, "same", false, false, false, actionCreate},
+		{"missing container", &pkg.ContainerStatus{}, "same", false, false, false, actionCreate},
+		{"unchanged running", running, "same", false, false, false, actionReuse},
+		{"changed running", running, "new", false, false, false, actionRecreate},
+		{"changed running with --reuse", running, "new", false, true, false, actionReuse},
+		{"unchanged running with --recreate", running, "same", false, false, true, actionRecreate},
+		{"unchanged stopped with persistence", stopped, "same", true, false, false, actionResume},
+		{"changed stopped with persistence", stopped, "new", true, false, false, actionRecreate},
+		{"stopped without persistence", stopped, "same", false, false, false, actionRecreate},
+		{"pre-created without persistence", fresh, "same", false, false, false, actionResume},
+		{"changed pre-created", fresh, "new", false, false, false, actionRecreate},
+		{"created by an older version", legacy, "new", false, false, false, actionReuse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, reason := decideContainerAction(tt.status, tt.hash, tt.sessionPersistence, tt.reuse, tt.recreate)
+			assert.Equal(t, tt.want, action)
+			assert.NotEmpty(t, reason)
+		})
+	}
+}
+
+func TestRunReuseFlagsConflict(t *testing.T) {
+	cmd := NewRunCmd(createMockApp())
+	require.NoError(t, cmd.Flags().Set("reuse", "true"))
+	require.NoError(t, cmd.Flags().Set("recreate", "true"))
+
+	_, err := prepareContainer(context.Background(), cmd, createMockApp(), true)
+	assert.ErrorContains(t, err, "cannot be combined")
+}
+
+func TestImageCachePolicy(t *testing.T) {
+	policy, err := imageCachePolicy(&pkg.Config{ImageCacheTTL: "168h", ImageCacheSize: 50})
+	require.NoError(t, err)
+	assert.Equal(t, 168*time.Hour, policy.TTL)
+	assert.Equal(t, 50, policy.MaxEntries)
+
+	policy, err = imageCachePolicy(&pkg.Config{})
+	require.NoError(t, err)
+	assert.Zero(t, policy.TTL, "unset values fall back to the validator defaults")
+
+	_, err = imageCachePolicy(&pkg.Config{ImageCacheTTL: "a week"})
+	assert.Error(t, err)
+	_, err = imageCachePolicy(&pkg.Config{ImageCacheTTL: "-1h"})
+	assert.Error(t, err)
+
+	policy, err = imageCachePolicy(&pkg.Config{ImageCacheURL: "s3://team-bucket/reactor"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3://team-bucket/reactor", policy.Shared)
+	_, err = imageCachePolicy(&pkg.Config{ImageCacheURL: "ftp://cache.example.com"})
+	assert.Error(t, err)
+}
+
+func TestAddOptionalMount(t *testing.T) {
+	app := createMockApp()
+	app.MountMgr = mount.NewManager(app.Logger)
+	source := t.TempDir()
+
+	config := &pkg.ContainerConfig{}
+	require.NoError(t, app.MountMgr.AddMountToConfig(config, source, "/app"))
+	require.NoError(t, addOptionalMount(app, config, source, "/home/claude/.claude/agents"))
+
+	require.Len(t, config.Mounts, 2)
+	assert.False(t, config.Mounts[0].Optional, "the project mount stays required")
+	assert.True(t, config.Mounts[1].Optional)
+
+	assert.Error(t, addOptionalMount(app, config, "relative/path", "/mnt/x"))
+}
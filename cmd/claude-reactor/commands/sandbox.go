@@ -0,0 +1,262 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/filesync"
+	"claude-reactor/internal/reactor/sandbox"
+	"claude-reactor/pkg"
+)
+
+// NewSandboxCmd creates the sandbox command for reviewing and applying a sandboxed session's edits
+func NewSandboxCmd(app *pkg.AppContainer) *cobra.Command {
+	var sandboxCmd = &cobra.Command{
+		Use:   "sandbox",
+		Short: "Review and apply the edits made in a project sandbox",
+		Long: `Review and apply the edits made in a project sandbox.
+
+'claude-reactor run --sandbox' copies the project into a volume private to the
+container, so Claude's edits don't touch the working tree. The sandbox keeps
+its changes across runs until they are applied. 'sandbox diff' shows them as a
+patch, and 'sandbox apply' applies it to the project on the host.`,
+		Example: `# Let Claude work on a private copy of the project
+claude-reactor run --sandbox
+
+# Review what changed, then bring it back
+claude-reactor sandbox diff
+claude-reactor sandbox apply
+
+# Keep the patch for later
+claude-reactor sandbox diff > changes.patch`,
+	}
+
+	sandboxCmd.AddCommand(newSandboxDiffCmd(app))
+	sandboxCmd.AddCommand(newSandboxApplyCmd(app))
+
+	return sandboxCmd
+}
+
+func newSandboxDiffCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff",
+		Short: "Print the sandbox changes as a patch",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return diffSandbox(cmd, app)
+		},
+	}
+}
+
+func newSandboxApplyCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "apply",
+		Short: "Apply the sandbox changes to the project",
+		Long: `Apply the sandbox changes to the project on the host.
+
+The patch is checked before anything is written, so a conflict with changes
+made on the host since the sandbox was created leaves the project untouched.
+Once applied, later diffs only show what changed in the sandbox since.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return applySandbox(cmd, app)
+		},
+	}
+}
+
+// projectSandbox is the sandbox of the running project container
+type projectSandbox struct {
+	Container string
+	GitDir    string
+	Target    string
+}
+
+// resolveSandbox finds the project container and checks that it is running with a sandbox
+func resolveSandbox(ctx context.Context, app *pkg.AppContainer) (*projectSandbox, error) {
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return nil, fmt.Errorf("docker not available: %w", err)
+	}
+	containerName, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return nil, err
+	}
+	status, err := app.DockerMgr.GetContainerStatus(ctx, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check container status: %w", err)
+	}
+	if !status.Running || status.Labels[sandbox.Label] == "" {
+		return nil, pkg.NewError(pkg.CodeSandboxMissing, nil, "no running sandbox for this project")
+	}
+	return &projectSandbox{
+		Container: containerName,
+		GitDir:    sandbox.GitDir(containerName),
+		Target:    projectMountTarget(config.ProjectPath),
+	}, nil
+}
+
+// patch returns the changes in the sandbox as a patch against the files it was created from
+func (s *projectSandbox) patch(ctx context.Context, app *pkg.AppContainer) (string, error) {
+	output, exitCode, err := app.DockerMgr.ExecCommand(ctx, s.Container, sandbox.StageCommand(s.GitDir, s.Target))
+	if err != nil {
+		return "", fmt.Errorf("failed to read sandbox changes: %w", err)
+	}
+	if exitCode != 0 {
+		return "", fmt.Errorf("failed to read sandbox changes: %s", strings.TrimSpace(output))
+	}
+	output, exitCode, err = app.DockerMgr.ExecCommand(ctx, s.Container, sandbox.DiffCommand(s.GitDir, s.Target))
+	if err != nil {
+		return "", fmt.Errorf("failed to diff sandbox: %w", err)
+	}
+	if exitCode != 0 {
+		return "", fmt.Errorf("failed to diff sandbox: %s", strings.TrimSpace(output))
+	}
+	// The diff went to a file, so warnings git printed above are not part of the patch
+	patch, exitCode, err := app.DockerMgr.ExecCommand(ctx, s.Container, sandbox.PatchCommand(s.GitDir))
+	if err != nil {
+		return "", fmt.Errorf("failed to read sandbox patch: %w", err)
+	}
+	if exitCode != 0 {
+		return "", fmt.Errorf("failed to read sandbox patch: %s", strings.TrimSpace(patch))
+	}
+	return patch, nil
+}
+
+// diffSandbox prints the changes in the project's sandbox
+func diffSandbox(cmd *cobra.Command, app *pkg.AppContainer) error {
+	ctx := cmd.Context()
+
+	s, err := resolveSandbox(ctx, app)
+	if err != nil {
+		return err
+	}
+	patch, err := s.patch(ctx, app)
+	if err != nil {
+		return err
+	}
+	if patch == "" {
+		app.Logger.Info("No changes in the sandbox")
+		return nil
+	}
+	fmt.Print(patch)
+	return nil
+}
+
+// applySandbox applies the changes in the project's sandbox to the project on the host, and
+// moves the sandbox baseline past them
+func applySandbox(cmd *cobra.Command, app *pkg.AppContainer) error {
+	ctx := cmd.Context()
+
+	s, err := resolveSandbox(ctx, app)
+	if err != nil {
+		return err
+	}
+	patch, err := s.patch(ctx, app)
+	if err != nil {
+		return err
+	}
+	if patch == "" {
+		app.Logger.Info("No changes in the sandbox to apply")
+		return nil
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return pkg.NewError(pkg.CodeHostToolMissing, nil, "applying sandbox changes requires git on the host").
+			WithRemediation("Save the patch instead with: claude-reactor sandbox diff > changes.patch")
+	}
+
+	// Patch paths are relative to the project root, the current directory by now; inside a
+	// larger repository git apply takes them relative to its top level
+	args := []string{"apply", "--whitespace=nowarn"}
+	if prefix, err := exec.Command("git", "rev-parse", "--show-prefix").Output(); err == nil && strings.TrimSpace(string(prefix)) != "" {
+		args = append(args, "--directory="+strings.TrimSuffix(strings.TrimSpace(string(prefix)), "/"))
+	}
+	if err := gitApply(append(args, "--check"), patch); err != nil {
+		return pkg.NewError(pkg.CodeSandboxConflict, err, "sandbox changes don't apply to the project")
+	}
+	if err := gitApply(args, patch); err != nil {
+		return fmt.Errorf("failed to apply sandbox changes: %w", err)
+	}
+
+	output, exitCode, err := app.DockerMgr.ExecCommand(ctx, s.Container, sandbox.AcceptCommand(s.GitDir, s.Target))
+	if err == nil && exitCode != 0 {
+		err = errors.New(strings.TrimSpace(output))
+	}
+	if err != nil {
+		app.Logger.Warnf("Failed to update the sandbox baseline, so the applied changes will show again: %v", err)
+	}
+	app.Logger.Info("✅ Sandbox changes applied to the project")
+	return nil
+}
+
+// gitApply runs git apply with args on the patch in the current directory
+func gitApply(args []string, patch string) error {
+	var stderr bytes.Buffer
+	apply := exec.Command("git", args...)
+	apply.Stdin = strings.NewReader(patch)
+	apply.Stderr = &stderr
+	if err := apply.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return errors.New(message)
+		}
+		return err
+	}
+	return nil
+}
+
+// seedSandbox copies the project into a fresh sandbox volume and records the copy as the
+// baseline its changes are diffed against. A sandbox that already has files is kept, so
+// unapplied edits survive restarts.
+func seedSandbox(ctx context.Context, app *pkg.AppContainer, containerName, projectDir string) error {
+	target := projectMountTarget(projectDir)
+	if _, exitCode, err := app.DockerMgr.ExecCommand(ctx, containerName, sandbox.EmptyCommand(target)); err != nil {
+		return fmt.Errorf("failed to check sandbox: %w", err)
+	} else if exitCode != 0 {
+		app.Logger.Info("🧪 Continuing in the existing sandbox - review its changes with: claude-reactor sandbox diff")
+		return nil
+	}
+
+	app.Logger.Info("🧪 Copying project into the sandbox...")
+	reader, writer := io.Pipe()
+	archived := make(chan error, 1)
+	go func() {
+		err := sandbox.Archive(projectDir, filesync.IgnorePatterns(projectDir), writer)
+		writer.CloseWithError(err)
+		archived <- err
+	}()
+	var output bytes.Buffer
+	err := app.DockerMgr.ExecPipe(ctx, containerName, sandbox.UnpackCommand(target), reader, &output)
+	// Stop archiving if the container stopped reading early
+	reader.CloseWithError(io.ErrClosedPipe)
+	if archiveErr := <-archived; archiveErr != nil && !errors.Is(archiveErr, io.ErrClosedPipe) {
+		return archiveErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy project into the sandbox: %w", err)
+	}
+	if message := strings.TrimSpace(output.String()); message != "" {
+		return fmt.Errorf("failed to copy project into the sandbox: %s", message)
+	}
+
+	result, exitCode, err := app.DockerMgr.ExecCommand(ctx, containerName, sandbox.BaselineCommand(sandbox.GitDir(containerName), target))
+	if err != nil {
+		return fmt.Errorf("failed to record sandbox baseline: %w", err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("failed to record sandbox baseline: %s", strings.TrimSpace(result))
+	}
+	app.Logger.Info("✅ Sandbox ready - edits stay in the container until 'claude-reactor sandbox apply'")
+	return nil
+}
@@ -0,0 +1,267 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/moby/term"
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/secretenv"
+	"claude-reactor/pkg"
+)
+
+// NewSecretsCmd creates the secrets command, which manages the project's environment secrets
+func NewSecretsCmd(app *pkg.AppContainer) *cobra.Command {
+	var secretsCmd = &cobra.Command{
+		Use:   "secrets",
+		Short: "Manage environment secrets injected into sessions",
+		Long: `Manage the project's environment secrets.
+
+A secret is an environment variable whose value is fetched on the host each
+time a session starts, from the OS keychain or from HashiCorp Vault, and given
+only to the processes of that session. The value is never written to the
+container's configuration, an image layer or the .claude-reactor file, which
+only records where to fetch it from.
+
+Keychain secrets use macOS's security tool or, on Linux, libsecret's
+secret-tool. Vault secrets are read with the vault CLI, using the host's
+VAULT_ADDR and token.`,
+		Example: `# Keep a token in the keychain, reading it from a prompt or stdin
+claude-reactor secrets set GITHUB_TOKEN
+gh auth token | claude-reactor secrets set GITHUB_TOKEN
+
+# Read a password from Vault at each session
+claude-reactor secrets set DB_PASSWORD --vault secret/data/app#password
+
+# See and remove the project's secrets
+claude-reactor secrets list
+claude-reactor secrets remove GITHUB_TOKEN`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	secretsCmd.AddCommand(
+		newSecretsSetCmd(app),
+		newSecretsListCmd(app),
+		newSecretsRemoveCmd(app),
+	)
+
+	return secretsCmd
+}
+
+func newSecretsSetCmd(app *pkg.AppContainer) *cobra.Command {
+	var vault string
+	cmd := &cobra.Command{
+		Use:   "set NAME",
+		Short: "Add or replace a secret",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return setSecret(cmd, app, args[0], vault)
+		},
+	}
+	cmd.Flags().StringVar(&vault, "vault", "", "Read the secret from Vault, as PATH#FIELD, instead of the keychain")
+	return cmd
+}
+
+func newSecretsListCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the project's secrets",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return listSecrets(cmd, app)
+		},
+	}
+}
+
+func newSecretsRemoveCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove NAME",
+		Aliases: []string{"rm"},
+		Short:   "Remove a secret",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return removeSecret(cmd, app, args[0])
+		},
+	}
+}
+
+// loadSecretsConfig loads the project configuration from the project root, holding the
+// project lock so a starting run doesn't overwrite the change. The caller must release it.
+func loadSecretsConfig(cmd *cobra.Command, app *pkg.AppContainer) (*pkg.Config, func(), error) {
+	if _, err := enterProjectRoot(app, true); err != nil {
+		return nil, nil, err
+	}
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	projectLock, err := lockProject(cmd.Context(), app, projectDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	config, err := app.ConfigMgr.LoadConfig()
+	if err != nil {
+		config = app.ConfigMgr.GetDefaultConfig()
+	}
+	config.ProjectPath = projectDir
+	return config, func() { projectLock.Release() }, nil
+}
+
+// setSecret records a secret in the project configuration, storing its value in the keychain
+// unless it comes from Vault
+func setSecret(cmd *cobra.Command, app *pkg.AppContainer, name, vault string) error {
+	if err := secretenv.ValidateName(name); err != nil {
+		return err
+	}
+	source := secretenv.Keychain
+	if vault != "" {
+		path, field, _ := strings.Cut(vault, "#")
+		source = secretenv.Vault(path, field)
+		if err := secretenv.ValidateSource(source); err != nil {
+			return err
+		}
+	}
+
+	config, release, err := loadSecretsConfig(cmd, app)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if source == secretenv.Keychain {
+		value, err := readSecretValue(cmd.InOrStdin(), cmd.ErrOrStderr(), name)
+		if err != nil {
+			return err
+		}
+		if err := secretenv.Store(config.ProjectPath, name, value); err != nil {
+			return fmt.Errorf("failed to store secret %s in the keychain: %w", name, err)
+		}
+	}
+
+	if secret := findSecret(config, name); secret != nil {
+		secret.Source = source
+	} else {
+		config.Secrets = append(config.Secrets, pkg.Secret{Name: name, Source: source})
+	}
+	if err := app.ConfigMgr.SaveConfig(config); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	app.Logger.Infof("🔐 Secret %s set (%s); it is injected when sessions start", name, source)
+	return nil
+}
+
+// listSecrets prints the project's secrets and where their values come from, never the values
+func listSecrets(cmd *cobra.Command, app *pkg.AppContainer) error {
+	config, release, err := loadSecretsConfig(cmd, app)
+	if err != nil {
+		return err
+	}
+	release()
+
+	out := i18n.Writer(cmd.OutOrStdout())
+	if len(config.Secrets) == 0 {
+		fmt.Fprintln(out, "No secrets defined for this project")
+		fmt.Fprintln(out, "💡 Add one with: claude-reactor secrets set NAME")
+		return nil
+	}
+	fmt.Fprintf(out, "%-30s %s\n", "NAME", "SOURCE")
+	for _, secret := range config.Secrets {
+		fmt.Fprintf(out, "%-30s %s\n", secret.Name, secret.Source)
+	}
+	return nil
+}
+
+// removeSecret drops a secret from the project configuration and its value from the keychain
+func removeSecret(cmd *cobra.Command, app *pkg.AppContainer, name string) error {
+	config, release, err := loadSecretsConfig(cmd, app)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	secret := findSecret(config, name)
+	if secret == nil {
+		return pkg.NewError(pkg.CodeNotDefined, nil, "no secret '%s' in this project", name).
+			WithRemediation("See the project's secrets with: claude-reactor secrets list")
+	}
+	if secret.Source == secretenv.Keychain {
+		if err := secretenv.Delete(config.ProjectPath, name); err != nil {
+			app.Logger.Warnf("⚠️  Failed to remove secret %s from the keychain: %v", name, err)
+		}
+	}
+	for i := range config.Secrets {
+		if config.Secrets[i].Name == name {
+			config.Secrets = append(config.Secrets[:i], config.Secrets[i+1:]...)
+			break
+		}
+	}
+	if err := app.ConfigMgr.SaveConfig(config); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	app.Logger.Infof("🔐 Secret %s removed", name)
+	return nil
+}
+
+// findSecret returns the project secret called name, or nil
+func findSecret(config *pkg.Config, name string) *pkg.Secret {
+	for i := range config.Secrets {
+		if config.Secrets[i].Name == name {
+			return &config.Secrets[i]
+		}
+	}
+	return nil
+}
+
+// readSecretValue reads a secret's value from in: prompted for without echo on a terminal,
+// otherwise the first line of piped input
+func readSecretValue(in io.Reader, prompt io.Writer, name string) (string, error) {
+	if file, ok := in.(*os.File); ok && term.IsTerminal(file.Fd()) {
+		fmt.Fprintf(prompt, "Value for %s: ", name)
+		if state, err := term.SaveState(file.Fd()); err == nil {
+			if err := term.DisableEcho(file.Fd(), state); err == nil {
+				defer fmt.Fprintln(prompt)
+				defer term.RestoreTerminal(file.Fd(), state)
+			}
+		}
+	}
+	value, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read the secret's value: %w", err)
+	}
+	value = strings.TrimRight(value, "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("no value given for secret %s", name)
+	}
+	return value, nil
+}
+
+// injectSecrets fetches the project's secrets and adds them to the environment of the sessions
+// attached next, without recording them in the container
+func injectSecrets(app *pkg.AppContainer, config *pkg.Config) error {
+	if len(config.Secrets) == 0 {
+		return nil
+	}
+	env, err := secretenv.Env(config.ProjectPath, config.Secrets)
+	if err != nil {
+		return pkg.NewError(pkg.CodeSecretUnavailable, err, "failed to read the project's secrets")
+	}
+	app.DockerMgr.SetSessionEnv(env)
+	app.Logger.Infof("🔐 Injected %d secret(s) into the session", len(env))
+	return nil
+}
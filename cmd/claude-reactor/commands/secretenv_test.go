@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func TestReadSecretValue(t *testing.T) {
+	value, err := readSecretValue(strings.NewReader("ghp_token\nignored\n"), &bytes.Buffer{}, "GITHUB_TOKEN")
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_token", value)
+
+	value, err = readSecretValue(strings.NewReader("no newline"), &bytes.Buffer{}, "GITHUB_TOKEN")
+	require.NoError(t, err)
+	assert.Equal(t, "no newline", value)
+
+	_, err = readSecretValue(strings.NewReader("\n"), &bytes.Buffer{}, "GITHUB_TOKEN")
+	assert.ErrorContains(t, err, "no value given for secret GITHUB_TOKEN")
+}
+
+func TestFindSecret(t *testing.T) {
+	config := &pkg.Config{Secrets: []pkg.Secret{{Name: "GITHUB_TOKEN", Source: "keychain"}}}
+	assert.Equal(t, "keychain", findSecret(config, "GITHUB_TOKEN").Source)
+	assert.Nil(t, findSecret(config, "NPM_TOKEN"))
+}
+
+func TestInjectSecrets(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake vault is a shell script")
+	}
+	// A fake vault CLI answering every lookup with the field it was asked for
+	bin := t.TempDir()
+	script := "#!/bin/sh\nfor arg; do case $arg in -field=*) echo \"value-of-${arg#-field=}\";; esac; done\n"
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "vault"), []byte(script), 0755))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	t.Run("injected into the session", func(t *testing.T) {
+		dockerMgr := &mocks.MockDockerManager{}
+		dockerMgr.On("SetSessionEnv", []string{"DB_PASSWORD=value-of-password"}).Return()
+		app := createMockApp()
+		app.DockerMgr = dockerMgr
+
+		config := &pkg.Config{ProjectPath: "/src/app", Secrets: []pkg.Secret{{Name: "DB_PASSWORD", Source: "vault:secret/data/app#password"}}}
+		require.NoError(t, injectSecrets(app, config))
+		dockerMgr.AssertExpectations(t)
+	})
+
+	t.Run("fetch failure", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(bin, "vault"), []byte("#!/bin/sh\necho 'permission denied' >&2\nexit 2\n"), 0755))
+		dockerMgr := &mocks.MockDockerManager{}
+		app := createMockApp()
+		app.DockerMgr = dockerMgr
+
+		config := &pkg.Config{ProjectPath: "/src/app", Secrets: []pkg.Secret{{Name: "DB_PASSWORD", Source: "vault:secret/data/app#password"}}}
+		err := injectSecrets(app, config)
+		assert.ErrorContains(t, err, "failed to fetch secret DB_PASSWORD")
+		assert.ErrorContains(t, err, "permission denied")
+		dockerMgr.AssertNotCalled(t, "SetSessionEnv", mock.Anything)
+	})
+
+	t.Run("no secrets", func(t *testing.T) {
+		dockerMgr := &mocks.MockDockerManager{}
+		app := createMockApp()
+		app.DockerMgr = dockerMgr
+
+		require.NoError(t, injectSecrets(app, &pkg.Config{}))
+		dockerMgr.AssertNotCalled(t, "SetSessionEnv", mock.Anything)
+	})
+}
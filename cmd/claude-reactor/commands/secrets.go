@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"claude-reactor/internal/reactor/filesync"
+	"claude-reactor/internal/reactor/secrets"
+	"claude-reactor/pkg"
+)
+
+// scanSecrets checks the project for files that likely hold secrets when secret_scan is on and
+// reports them. It returns the mode and the findings to hide; none when the mode only warns.
+func scanSecrets(app *pkg.AppContainer, config *pkg.Config, projectDir string) (string, []secrets.Finding, error) {
+	mode, err := secrets.ParseMode(config.SecretScan)
+	if err != nil || mode == secrets.ModeOff {
+		return mode, nil, err
+	}
+	findings, err := secrets.Scan(projectDir, filesync.IgnorePatterns(projectDir))
+	if err != nil {
+		return mode, nil, err
+	}
+	if len(findings) == 0 {
+		app.Logger.Debug("🕵️  No secrets found in the project")
+		return mode, nil, nil
+	}
+
+	app.Logger.Warnf("🕵️  %d project files likely contain secrets:", len(findings))
+	for _, finding := range findings {
+		app.Logger.Warnf("   %s (%s)", finding.Path, finding.Reason)
+	}
+	if mode == secrets.ModeWarn {
+		app.Logger.Info("💡 Hide them from the container with: claude-reactor config set secret_scan placeholder")
+		return mode, nil, nil
+	}
+	return mode, findings, nil
+}
+
+// secretMounts returns read-only mounts that hide each secret file behind a replacement kept
+// in the session's secrets directory
+func secretMounts(findings []secrets.Finding, target, sessionDir string) []pkg.Mount {
+	mounts := make([]pkg.Mount, 0, len(findings))
+	for i, finding := range findings {
+		mounts = append(mounts, pkg.Mount{
+			Source:   filepath.Join(sessionDir, "secrets", fmt.Sprintf("%03d-%s", i+1, filepath.Base(finding.Path))),
+			Target:   path.Join(target, filepath.ToSlash(finding.Path)),
+			Type:     "bind",
+			ReadOnly: true,
+		})
+	}
+	return mounts
+}
+
+// hideSecrets writes the replacements for the secret files, empty in exclude mode and
+// placeholders otherwise, and mounts them over the project
+func hideSecrets(app *pkg.AppContainer, containerConfig *pkg.ContainerConfig, mode string, findings []secrets.Finding, projectDir, target, sessionDir string) error {
+	// Start from scratch so replacements for files that are gone don't linger
+	secretsDir := filepath.Join(sessionDir, "secrets")
+	if err := os.RemoveAll(secretsDir); err != nil {
+		return fmt.Errorf("failed to clear secret placeholders: %w", err)
+	}
+	if err := os.MkdirAll(secretsDir, 0700); err != nil {
+		return fmt.Errorf("failed to create secret placeholders: %w", err)
+	}
+
+	hidden := 0
+	for i, mount := range secretMounts(findings, target, sessionDir) {
+		if hasMountTarget(containerConfig, mount.Target) {
+			continue
+		}
+		var content []byte
+		if mode == secrets.ModePlaceholder {
+			file, err := os.Open(filepath.Join(projectDir, findings[i].Path))
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", findings[i].Path, err)
+			}
+			content = secrets.Placeholder(filepath.Base(findings[i].Path), file)
+			file.Close()
+		}
+		if err := os.WriteFile(mount.Source, content, 0644); err != nil {
+			return fmt.Errorf("failed to write placeholder for %s: %w", findings[i].Path, err)
+		}
+		containerConfig.Mounts = append(containerConfig.Mounts, mount)
+		hidden++
+	}
+
+	replacement := "placeholders"
+	if mode == secrets.ModeExclude {
+		replacement = "empty files"
+	}
+	app.Logger.Infof("🕵️  Hid %d secret files from the container behind %s", hidden, replacement)
+	return nil
+}
+
+// hasMountTarget reports whether something is already mounted at target
+func hasMountTarget(containerConfig *pkg.ContainerConfig, target string) bool {
+	for _, mount := range containerConfig.Mounts {
+		if mount.Target == target {
+			return true
+		}
+	}
+	return false
+}
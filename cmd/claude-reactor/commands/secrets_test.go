@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/secrets"
+	"claude-reactor/pkg"
+)
+
+func TestScanSecrets(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".env"), []byte("API_TOKEN=abc\n"), 0644))
+	app := createMockApp()
+
+	mode, findings, err := scanSecrets(app, &pkg.Config{}, projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, secrets.ModeOff, mode)
+	assert.Empty(t, findings)
+
+	// Warn mode reports the files but hides nothing
+	mode, findings, err = scanSecrets(app, &pkg.Config{SecretScan: "warn"}, projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, secrets.ModeWarn, mode)
+	assert.Empty(t, findings)
+	assert.Contains(t, app.Logger.(*captureLogger).messages, "   %s (%s)")
+
+	_, findings, err = scanSecrets(app, &pkg.Config{SecretScan: "placeholder"}, projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []secrets.Finding{{Path: ".env", Reason: "environment file"}}, findings)
+
+	_, _, err = scanSecrets(app, &pkg.Config{SecretScan: "shred"}, projectDir)
+	assert.Error(t, err)
+}
+
+func TestHideSecrets(t *testing.T) {
+	projectDir := t.TempDir()
+	sessionDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "deploy"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "deploy", ".env"), []byte("API_TOKEN=abc\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "server.key"), []byte("secret"), 0644))
+	findings := []secrets.Finding{
+		{Path: filepath.Join("deploy", ".env"), Reason: "environment file"},
+		{Path: "server.key", Reason: "key file"},
+	}
+
+	// A protected path already mounted at the same target is left alone
+	containerConfig := &pkg.ContainerConfig{Mounts: []pkg.Mount{{Source: filepath.Join(projectDir, "server.key"), Target: "/app/server.key", Type: "bind", ReadOnly: true}}}
+	require.NoError(t, hideSecrets(createMockApp(), containerConfig, secrets.ModePlaceholder, findings, projectDir, "/app", sessionDir))
+
+	placeholder := filepath.Join(sessionDir, "secrets", "001-.env")
+	require.Len(t, containerConfig.Mounts, 2)
+	assert.Equal(t, pkg.Mount{Source: placeholder, Target: "/app/deploy/.env", Type: "bind", ReadOnly: true}, containerConfig.Mounts[1])
+	content, err := os.ReadFile(placeholder)
+	require.NoError(t, err)
+	assert.Equal(t, "API_TOKEN=[REDACTED]\n", string(content))
+
+	// Exclude mode hides the file behind an empty one
+	containerConfig = &pkg.ContainerConfig{}
+	require.NoError(t, hideSecrets(createMockApp(), containerConfig, secrets.ModeExclude, findings, projectDir, "/app", sessionDir))
+	require.Len(t, containerConfig.Mounts, 2)
+	content, err = os.ReadFile(containerConfig.Mounts[1].Source)
+	require.NoError(t, err)
+	assert.Empty(t, content)
+}
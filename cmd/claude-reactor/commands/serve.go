@@ -0,0 +1,382 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/agent"
+	"claude-reactor/internal/reactor/rpc"
+	"claude-reactor/pkg"
+)
+
+// serveStatus is the result of the status and start methods
+type serveStatus struct {
+	Project   string            `json:"project"`
+	Container string            `json:"container"`
+	Account   string            `json:"account"`
+	Image     string            `json:"image"`
+	Exists    bool              `json:"exists"`
+	Running   bool              `json:"running"`
+	ID        string            `json:"id,omitempty"`
+	Ports     []pkg.PortMapping `json:"ports,omitempty"`
+	Agent     *agent.Status     `json:"agent_status,omitempty"`
+}
+
+// NewServeCmd creates the serve command exposing a JSON-RPC API for editor integrations
+func NewServeCmd(app *pkg.AppContainer) *cobra.Command {
+	var serveCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a JSON-RPC API for editor integrations",
+		Long: `Serve a JSON-RPC 2.0 API on a unix socket so editor plugins can control the
+project container without parsing command output.
+
+One server can manage several projects: every method except version takes
+a required "project" parameter, the absolute path of a directory in the
+project (its root is found as 'run' finds it). Each request and response is
+a single line of JSON.
+
+Methods:
+  version    API and claude-reactor version
+  status     Project container state, image, and published ports
+             {"project": "/src/app"}
+  start      Start or reuse the project container
+             {"project": "/src/app", "image": "go", "account": "work"}
+  exec       Run a command and return its output
+             {"project": "/src/app", "command": ["go", "test", "./..."]}
+  attach     Command line for an interactive session
+             {"project": "/src/app", "shell": false}
+  logs       Recent container output
+             {"project": "/src/app", "tail": 200}
+  sessions   Accounts with saved sessions for the project
+             {"project": "/src/app"}`,
+		Example: `# Serve on the default socket
+claude-reactor serve
+
+# Serve for one workspace on its own socket
+claude-reactor serve --socket /tmp/my-project.sock
+
+# Query it from a shell
+echo '{"jsonrpc":"2.0","id":1,"method":"status","params":{"project":"'"$PWD"'"}}' | nc -U ~/.claude-reactor/ipc.sock`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return runServe(cmd, app)
+		},
+	}
+
+	serveCmd.Flags().StringP("socket", "s", "", "Unix socket path (default ~/.claude-reactor/ipc.sock)")
+
+	return serveCmd
+}
+
+// runServe listens on the socket and serves requests until interrupted
+func runServe(cmd *cobra.Command, app *pkg.AppContainer) error {
+	socket, _ := cmd.Flags().GetString("socket")
+	if socket == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		socket = filepath.Join(homeDir, ".claude-reactor", "ipc.sock")
+	}
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+
+	listener, err := rpc.Listen(socket)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(socket)
+
+	ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	server := rpc.NewServer(app.Logger)
+	registerServeMethods(server, app)
+
+	app.Logger.Infof("🔌 Serving JSON-RPC API on %s", socket)
+	app.Logger.Info("💡 Press Ctrl+C to stop")
+	if err := server.Serve(ctx, listener); err != nil {
+		return err
+	}
+	app.Logger.Info("🛑 Server stopped")
+	return nil
+}
+
+// registerServeMethods adds the editor API methods to the server
+func registerServeMethods(server *rpc.Server, app *pkg.AppContainer) {
+	server.Register("version", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{"api_version": rpc.APIVersion, "version": debugVersion}, nil
+	})
+
+	server.Register("status", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Project string `json:"project"`
+		}
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		root, err := serveProjectRoot(p.Project)
+		if err != nil {
+			return nil, err
+		}
+		return projectStatus(ctx, app, root)
+	})
+
+	server.Register("start", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Project string `json:"project"`
+			Image   string `json:"image"`
+			Account string `json:"account"`
+		}
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		root, err := serveProjectRoot(p.Project)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := startProject(ctx, root, p.Image, p.Account); err != nil {
+			return nil, err
+		}
+		return projectStatus(ctx, app, root)
+	})
+
+	server.Register("exec", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Project string   `json:"project"`
+			Command []string `json:"command"`
+		}
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		root, err := serveProjectRoot(p.Project)
+		if err != nil {
+			return nil, err
+		}
+		if len(p.Command) == 0 {
+			return nil, &rpc.Error{Code: rpc.InvalidParams, Message: "command is required"}
+		}
+
+		name, _, err := runningProjectContainer(ctx, app, root)
+		if err != nil {
+			return nil, err
+		}
+		output, exitCode, err := app.DockerMgr.ExecCommand(ctx, name, p.Command)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run command: %w", err)
+		}
+		return map[string]interface{}{"output": output, "exit_code": exitCode}, nil
+	})
+
+	server.Register("attach", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Project string `json:"project"`
+			Shell   bool   `json:"shell"`
+		}
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		root, err := serveProjectRoot(p.Project)
+		if err != nil {
+			return nil, err
+		}
+
+		name, config, err := runningProjectContainer(ctx, app, root)
+		if err != nil {
+			return nil, err
+		}
+		// Interactive sessions need a real terminal, so the editor runs this in its own
+		return map[string]interface{}{"command": attachCommand(name, p.Shell, config)}, nil
+	})
+
+	server.Register("logs", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		p := struct {
+			Project string `json:"project"`
+			Tail    int    `json:"tail"`
+		}{Tail: 200}
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		root, err := serveProjectRoot(p.Project)
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := projectStatus(ctx, app, root)
+		if err != nil {
+			return nil, err
+		}
+		if !status.Exists {
+			return nil, fmt.Errorf("container %s does not exist", status.Container)
+		}
+
+		logs, err := app.DockerMgr.GetContainerLogs(ctx, status.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get container logs: %w", err)
+		}
+		defer logs.Close()
+		data, err := io.ReadAll(logs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read container logs: %w", err)
+		}
+		return map[string]interface{}{"logs": tailLines(string(data), p.Tail)}, nil
+	})
+
+	server.Register("sessions", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Project string `json:"project"`
+		}
+		if err := rpc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		root, err := serveProjectRoot(p.Project)
+		if err != nil {
+			return nil, err
+		}
+
+		all, err := scanClaudeReactorDirectory(app)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan claude-reactor directory: %w", err)
+		}
+		projects := []ProjectInfo{}
+		for _, project := range all {
+			if project.ProjectPath == root {
+				projects = append(projects, project)
+			}
+		}
+		return map[string]interface{}{"projects": projects}, nil
+	})
+}
+
+// serveProjectRoot checks the project parameter of a request and returns the root of that
+// project, found the way 'run' finds it. Requests name their project because one server
+// may serve several, and changing directory per request would race between them.
+func serveProjectRoot(project string) (string, error) {
+	if project == "" {
+		return "", &rpc.Error{Code: rpc.InvalidParams, Message: "project is required"}
+	}
+	if !filepath.IsAbs(project) {
+		return "", &rpc.Error{Code: rpc.InvalidParams, Message: fmt.Sprintf("project must be an absolute path: %s", project)}
+	}
+	project = filepath.Clean(project)
+	if info, err := os.Stat(project); err != nil || !info.IsDir() {
+		return "", &rpc.Error{Code: rpc.InvalidParams, Message: fmt.Sprintf("project %s is not a directory", project)}
+	}
+	homeDir, _ := os.UserHomeDir()
+	return projectRoot(project, homeDir), nil
+}
+
+// startProject starts or reuses the container of the project rooted at root with a
+// 'claude-reactor run' subprocess in that directory, as starting reads the project from
+// the current directory
+func startProject(ctx context.Context, root, image, account string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate claude-reactor executable: %w", err)
+	}
+
+	args := []string{"run", "--ci", "--no-persist=false", "--yes", "--no-project-root"}
+	if image != "" {
+		args = append(args, "--image", image)
+	}
+	if account != "" {
+		args = append(args, "--account", account)
+	}
+	args = append(args, "--", "true")
+
+	var stderr bytes.Buffer
+	command := exec.CommandContext(ctx, executable, args...)
+	command.Dir = root
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		// The last log lines usually carry the reason
+		lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+		if len(lines) > 3 {
+			lines = lines[len(lines)-3:]
+		}
+		return fmt.Errorf("failed to start container: %w: %s", err, strings.Join(lines, " | "))
+	}
+	return nil
+}
+
+// projectStatus reports the state of the container of the project rooted at root
+func projectStatus(ctx context.Context, app *pkg.AppContainer, root string) (*serveStatus, error) {
+	name, config, err := resolveProjectContainerAt(app, root)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := app.DockerMgr.GetContainerStatus(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container status: %w", err)
+	}
+
+	return &serveStatus{
+		Project:   config.ProjectPath,
+		Container: name,
+		Account:   config.Account,
+		Image:     status.Image,
+		Exists:    status.Exists,
+		Running:   status.Running,
+		ID:        status.ID,
+		Ports:     status.Ports,
+		Agent:     readAgentStatus(app.AuthMgr.GetProjectSessionDir(config.Account, config.ProjectPath)),
+	}, nil
+}
+
+// runningProjectContainer returns the container name and configuration of the project rooted
+// at root, or an error if its container is not running
+func runningProjectContainer(ctx context.Context, app *pkg.AppContainer, root string) (string, *pkg.Config, error) {
+	name, config, err := resolveProjectContainerAt(app, root)
+	if err != nil {
+		return "", nil, err
+	}
+	running, err := app.DockerMgr.IsContainerRunning(ctx, name)
+	if err != nil || !running {
+		return "", nil, fmt.Errorf("container %s is not running; call start first", name)
+	}
+	return name, config, nil
+}
+
+// attachCommand builds the docker command line for an interactive Claude session or shell
+func attachCommand(containerName string, shell bool, config *pkg.Config) []string {
+	command := []string{"docker", "exec", "-it", containerName}
+	if shell {
+		return append(command, "/bin/bash")
+	}
+	command = append(command, "claude")
+	if config.DangerMode {
+		command = append(command, "--dangerously-skip-permissions")
+	}
+	return command
+}
+
+// tailLines returns the last n lines of s, or all of s when n is not positive
+func tailLines(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
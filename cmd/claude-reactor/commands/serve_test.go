@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/rpc"
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func TestNewServeCmd(t *testing.T) {
+	t.Run("create serve command", func(t *testing.T) {
+		cmd := NewServeCmd(createMockApp())
+
+		assert.Equal(t, "serve", cmd.Use)
+		assert.NotNil(t, cmd.Flags().Lookup("socket"))
+	})
+
+	t.Run("serve command with nil app shows help", func(t *testing.T) {
+		cmd := NewServeCmd(nil)
+
+		err := cmd.RunE(cmd, []string{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestAttachCommand(t *testing.T) {
+	command := attachCommand("claude-reactor-go-arm64-abc123-work", true, &pkg.Config{DangerMode: true})
+	assert.Equal(t, []string{"docker", "exec", "-it", "claude-reactor-go-arm64-abc123-work", "/bin/bash"}, command)
+
+	command = attachCommand("claude-reactor-go-arm64-abc123-work", false, &pkg.Config{DangerMode: true})
+	assert.Equal(t, []string{"docker", "exec", "-it", "claude-reactor-go-arm64-abc123-work", "claude", "--dangerously-skip-permissions"}, command)
+}
+
+func TestServeProjectRoot(t *testing.T) {
+	project := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(project, ".git"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(project, "api", "handlers"), 0755))
+
+	root, err := serveProjectRoot(filepath.Join(project, "api", "handlers"))
+	require.NoError(t, err)
+	assert.Equal(t, project, root)
+
+	for _, param := range []string{"", "relative/path", filepath.Join(project, "missing")} {
+		_, err := serveProjectRoot(param)
+		var rpcErr *rpc.Error
+		require.True(t, errors.As(err, &rpcErr), "project %q", param)
+		assert.Equal(t, rpc.InvalidParams, rpcErr.Code)
+	}
+}
+
+func TestServeMethodsUseProjectParam(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	project := t.TempDir()
+
+	configMgr := &mocks.MockConfigManager{}
+	configMgr.On("LoadProjectConfig", project).Return(&pkg.Config{Account: "work", Variant: "go"}, nil)
+	archDetector := &mocks.MockArchDetector{}
+	archDetector.On("GetHostArchitecture").Return("arm64", nil)
+	dockerMgr := &mocks.MockDockerManager{}
+	dockerMgr.On("GenerateContainerName", project, "go", "arm64", "work").Return("claude-reactor-go-arm64-abc123-work")
+	dockerMgr.On("GetContainerStatus", mock.Anything, "claude-reactor-go-arm64-abc123-work").Return(&pkg.ContainerStatus{Exists: true, Running: true, ID: "abc123"}, nil)
+	authMgr := &mocks.MockAuthManager{}
+	authMgr.On("GetProjectSessionDir", "work", project).Return(t.TempDir())
+
+	app := createMockApp()
+	app.ConfigMgr = configMgr
+	app.ArchDetector = archDetector
+	app.DockerMgr = dockerMgr
+	app.AuthMgr = authMgr
+
+	server := rpc.NewServer(app.Logger)
+	registerServeMethods(server, app)
+	conn, client := net.Pipe()
+	defer client.Close()
+	go server.ServeConn(context.Background(), conn)
+	responses := bufio.NewScanner(client)
+	call := func(request string) map[string]interface{} {
+		_, err := client.Write([]byte(request + "\n"))
+		require.NoError(t, err)
+		require.True(t, responses.Scan())
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(responses.Bytes(), &resp))
+		return resp
+	}
+
+	params, _ := json.Marshal(map[string]string{"project": project})
+	resp := call(`{"jsonrpc":"2.0","id":1,"method":"status","params":` + string(params) + `}`)
+	require.Nil(t, resp["error"])
+	status := resp["result"].(map[string]interface{})
+	assert.Equal(t, project, status["project"])
+	assert.Equal(t, "claude-reactor-go-arm64-abc123-work", status["container"])
+	assert.Equal(t, true, status["running"])
+
+	resp = call(`{"jsonrpc":"2.0","id":2,"method":"status"}`)
+	assert.Contains(t, resp["error"].(map[string]interface{})["message"], "project is required")
+
+	after, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, wd, after, "requests don't change the server's directory")
+}
+
+func TestTailLines(t *testing.T) {
+	logs := "one\ntwo\nthree\n"
+
+	assert.Equal(t, "two\nthree", tailLines(logs, 2))
+	assert.Equal(t, "one\ntwo\nthree", tailLines(logs, 10))
+	assert.Equal(t, logs, tailLines(logs, 0))
+}
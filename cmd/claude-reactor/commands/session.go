@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/notify"
+	"claude-reactor/pkg"
+)
+
+// detachableSessionName is the tmux/screen session 'run --tmux' starts Claude CLI in
+const detachableSessionName = "claude-reactor"
+
+// NewSessionCmd creates the session command for managing detachable Claude sessions
+func NewSessionCmd(app *pkg.AppContainer) *cobra.Command {
+	var sessionCmd = &cobra.Command{
+		Use:   "session",
+		Short: "Manage detachable and branch Claude sessions",
+		Long: `Manage Claude CLI sessions started with 'claude-reactor run --tmux'.
+
+Those sessions run inside tmux (or screen) in the container, so they keep going
+when the terminal disconnects and can be reattached later.
+
+Sessions started with 'claude-reactor run --branch' work in a git worktree of
+their own; 'session finish' commits and pushes them.`,
+	}
+
+	sessionCmd.AddCommand(newSessionAttachCmd(app))
+	sessionCmd.AddCommand(newSessionFinishCmd(app))
+
+	return sessionCmd
+}
+
+func newSessionAttachCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "attach",
+		Short: "Reattach to the project's detachable Claude session",
+		Long: `Reattach to the Claude CLI session started with 'claude-reactor run --tmux'
+in the project container. Detach again with the multiplexer's detach key
+(Ctrl+b d for tmux, Ctrl+a d for screen).`,
+		Example: `# Start a detachable session, then reattach after disconnecting
+claude-reactor run --tmux
+claude-reactor session attach`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return attachSession(cmd, app)
+		},
+	}
+}
+
+// attachSession reattaches to the detachable session in the running project container
+func attachSession(cmd *cobra.Command, app *pkg.AppContainer) error {
+	ctx := cmd.Context()
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+
+	containerName, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+
+	running, err := app.DockerMgr.IsContainerRunning(ctx, containerName)
+	if err != nil || !running {
+		return pkg.NewError(pkg.CodeContainerNotRunning, nil, "container %s is not running", containerName).
+			WithRemediation("Start a session with: claude-reactor run --tmux")
+	}
+
+	multiplexer, err := detectMultiplexer(ctx, app, containerName)
+	if err != nil {
+		return err
+	}
+
+	// has-session / -ls exit non-zero when there is nothing to attach to
+	check := []string{"tmux", "has-session", "-t", detachableSessionName}
+	if multiplexer == "screen" {
+		check = []string{"sh", "-c", "screen -ls " + detachableSessionName + " | grep -q " + detachableSessionName}
+	}
+	if _, exitCode, err := app.DockerMgr.ExecCommand(ctx, containerName, check); err != nil || exitCode != 0 {
+		return pkg.NewError(pkg.CodeSessionMissing, nil, "no detachable session in %s", containerName)
+	}
+
+	if config.Clipboard {
+		app.DockerMgr.EnableClipboardBridge(true)
+	}
+
+	notifier := projectNotifier(app, config)
+	sessionCtx, endSession := context.WithCancel(ctx)
+	defer endSession()
+	startAgentHost(sessionCtx, app, containerName, config, notifier)
+	if notifier.Enabled(notify.EventUnhealthy) {
+		go monitorContainer(sessionCtx, app, notifier, containerName)
+	}
+
+	app.Logger.Infof("🔗 Reattaching to %s session in %s...", multiplexer, containerName)
+	if err := app.DockerMgr.AttachToContainer(ctx, containerName, multiplexerAttachCommand(multiplexer), true); err != nil {
+		return fmt.Errorf("failed to attach to session: %w", err)
+	}
+	return nil
+}
+
+// detectMultiplexer returns "tmux" or "screen", preferring tmux, depending on what the container has
+func detectMultiplexer(ctx context.Context, app *pkg.AppContainer, containerName string) (string, error) {
+	for _, multiplexer := range []string{"tmux", "screen"} {
+		_, exitCode, err := app.DockerMgr.ExecCommand(ctx, containerName, []string{"sh", "-c", "command -v " + multiplexer})
+		if err == nil && exitCode == 0 {
+			return multiplexer, nil
+		}
+	}
+	return "", pkg.NewError(pkg.CodeMultiplexerMissing, nil, "neither tmux nor screen is installed in %s", containerName)
+}
+
+// multiplexerCommand wraps command so it runs in the named detachable session, attaching to
+// the session instead if it already exists
+func multiplexerCommand(multiplexer string, command []string) []string {
+	if multiplexer == "screen" {
+		return append([]string{"screen", "-xRR", "-S", detachableSessionName}, command...)
+	}
+	return []string{"tmux", "new-session", "-A", "-s", detachableSessionName, strings.Join(quoteArgs(command), " ")}
+}
+
+// multiplexerAttachCommand reattaches to the detachable session
+func multiplexerAttachCommand(multiplexer string) []string {
+	if multiplexer == "screen" {
+		return []string{"screen", "-x", detachableSessionName}
+	}
+	return []string{"tmux", "attach-session", "-t", detachableSessionName}
+}
+
+// quoteArgs single-quotes arguments containing shell metacharacters, since tmux passes its
+// command to the shell as one string
+func quoteArgs(args []string) []string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return quoted
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSessionCmd(t *testing.T) {
+	t.Run("create session command", func(t *testing.T) {
+		cmd := NewSessionCmd(createMockApp())
+
+		assert.Equal(t, "session", cmd.Use)
+		require.True(t, cmd.HasSubCommands())
+		assert.Equal(t, "attach", cmd.Commands()[0].Use)
+	})
+
+	t.Run("session attach with nil app shows help", func(t *testing.T) {
+		cmd := newSessionAttachCmd(nil)
+
+		err := cmd.RunE(cmd, []string{})
+		assert.NoError(t, err)
+	})
+}
+
+func TestMultiplexerCommand(t *testing.T) {
+	command := []string{"claude", "--append-system-prompt", "it's fine"}
+
+	t.Run("tmux runs the command through the shell", func(t *testing.T) {
+		assert.Equal(t,
+			[]string{"tmux", "new-session", "-A", "-s", "claude-reactor", `claude --append-system-prompt 'it'\''s fine'`},
+			multiplexerCommand("tmux", command))
+		assert.Equal(t, []string{"tmux", "attach-session", "-t", "claude-reactor"}, multiplexerAttachCommand("tmux"))
+	})
+
+	t.Run("screen passes arguments through", func(t *testing.T) {
+		assert.Equal(t,
+			[]string{"screen", "-xRR", "-S", "claude-reactor", "claude", "--append-system-prompt", "it's fine"},
+			multiplexerCommand("screen", command))
+		assert.Equal(t, []string{"screen", "-x", "claude-reactor"}, multiplexerAttachCommand("screen"))
+	})
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/buildstats"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// variantBuildStats compares a variant's local builds with its registry pulls
+type variantBuildStats struct {
+	Variant     string        `json:"variant"`
+	Builds      int           `json:"builds"`
+	AvgBuild    time.Duration `json:"avg_build,omitempty"`
+	BuildCached float64       `json:"build_cached,omitempty"` // share of build steps taken from the cache
+	Pulls       int           `json:"pulls"`
+	AvgPull     time.Duration `json:"avg_pull,omitempty"`
+}
+
+// NewStatsCmd creates the stats command for reviewing image build and pull times
+func NewStatsCmd(app *pkg.AppContainer) *cobra.Command {
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show how long image builds and registry pulls take",
+		Long: `Show how long images took to build locally or pull from the registry.
+
+Every build records how long each Dockerfile step took and whether it came from
+the layer cache, and every pull records how many layers were already present.
+stats averages them per variant, to show whether pulling published images or
+building them locally is faster on this machine. --history builds lists the
+individual builds and pulls.
+
+The newest 200 builds and pulls are kept in ~/.claude-reactor/build-history.json.`,
+		Example: `# Average build and pull times per variant
+claude-reactor stats
+
+# The last builds and pulls, with each build's slowest step
+claude-reactor stats --history builds`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return runStats(cmd)
+		},
+	}
+
+	statsCmd.Flags().String("history", "", "List individual records instead of averages: 'builds'")
+	statsCmd.Flags().IntP("limit", "n", 20, "Number of records to list with --history (0 for all)")
+	statsCmd.Flags().BoolP("json", "j", false, "Output in JSON format for scripting")
+
+	return statsCmd
+}
+
+func runStats(cmd *cobra.Command) error {
+	historyKind, _ := cmd.Flags().GetString("history")
+	limit, _ := cmd.Flags().GetInt("limit")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+	if historyKind != "" && historyKind != "builds" {
+		return fmt.Errorf("invalid --history value %q: use 'builds'", historyKind)
+	}
+
+	records, err := buildstats.Load(buildstats.Path())
+	if err != nil {
+		return err
+	}
+
+	if historyKind != "" {
+		if limit > 0 && len(records) > limit {
+			records = records[len(records)-limit:]
+		}
+		if jsonOutput {
+			return outputJSON(records)
+		}
+		outputBuildHistory(records)
+		return nil
+	}
+
+	summary := summarizeBuildStats(records)
+	if jsonOutput {
+		return outputJSON(summary)
+	}
+	if len(summary) == 0 {
+		fmt.Fprintln(i18n.Stdout, i18n.T("stats.empty"))
+		return nil
+	}
+	fmt.Fprintf(i18n.Stdout, "%-12s %-7s %-10s %-7s %-6s %s\n", "VARIANT", "BUILDS", "AVG BUILD", "CACHED", "PULLS", "AVG PULL")
+	for _, stats := range summary {
+		avgBuild, cached, avgPull := "-", "-", "-"
+		if stats.Builds > 0 {
+			avgBuild = buildstats.FormatDuration(stats.AvgBuild)
+			cached = fmt.Sprintf("%.0f%%", stats.BuildCached*100)
+		}
+		if stats.Pulls > 0 {
+			avgPull = buildstats.FormatDuration(stats.AvgPull)
+		}
+		fmt.Fprintf(i18n.Stdout, "%-12s %-7d %-10s %-7s %-6d %s\n", stats.Variant, stats.Builds, avgBuild, cached, stats.Pulls, avgPull)
+	}
+	fmt.Fprintln(i18n.Stdout, i18n.T("stats.history_hint"))
+	return nil
+}
+
+// outputBuildHistory prints builds and pulls, oldest first
+func outputBuildHistory(records []buildstats.Record) {
+	if len(records) == 0 {
+		fmt.Fprintln(i18n.Stdout, i18n.T("stats.empty"))
+		return
+	}
+	fmt.Fprintf(i18n.Stdout, "%-16s %-6s %-12s %-9s %-9s %s\n", "STARTED", "KIND", "VARIANT", "DURATION", "CACHED", "SLOWEST STEP")
+	for _, record := range records {
+		cached := "-"
+		if record.Layers > 0 {
+			cached = fmt.Sprintf("%d/%d", record.Cached, record.Layers)
+		}
+		slowest := ""
+		if step, ok := slowestStep(record.Steps); ok {
+			slowest = fmt.Sprintf("%s %s", buildstats.FormatDuration(step.Duration), truncate(step.Instruction, 50))
+		}
+		fmt.Fprintf(i18n.Stdout, "%-16s %-6s %-12s %-9s %-9s %s\n", record.Started.Format("2006-01-02 15:04"), record.Kind, record.Variant, buildstats.FormatDuration(record.Duration), cached, slowest)
+	}
+}
+
+// slowestStep returns the build step that took longest
+func slowestStep(steps []buildstats.Step) (buildstats.Step, bool) {
+	if len(steps) == 0 {
+		return buildstats.Step{}, false
+	}
+	slowest := steps[0]
+	for _, step := range steps[1:] {
+		if step.Duration > slowest.Duration {
+			slowest = step
+		}
+	}
+	return slowest, true
+}
+
+// summarizeBuildStats averages builds and pulls per variant, sorted by variant
+func summarizeBuildStats(records []buildstats.Record) []variantBuildStats {
+	byVariant := make(map[string]*variantBuildStats)
+	layers := make(map[string]int)
+	cached := make(map[string]int)
+	for _, record := range records {
+		stats, ok := byVariant[record.Variant]
+		if !ok {
+			stats = &variantBuildStats{Variant: record.Variant}
+			byVariant[record.Variant] = stats
+		}
+		switch record.Kind {
+		case buildstats.KindBuild:
+			stats.Builds++
+			stats.AvgBuild += record.Duration
+			layers[record.Variant] += record.Layers
+			cached[record.Variant] += record.Cached
+		case buildstats.KindPull:
+			stats.Pulls++
+			stats.AvgPull += record.Duration
+		}
+	}
+
+	summary := make([]variantBuildStats, 0, len(byVariant))
+	for variant, stats := range byVariant {
+		if stats.Builds > 0 {
+			stats.AvgBuild /= time.Duration(stats.Builds)
+		}
+		if stats.Pulls > 0 {
+			stats.AvgPull /= time.Duration(stats.Pulls)
+		}
+		if layers[variant] > 0 {
+			stats.BuildCached = float64(cached[variant]) / float64(layers[variant])
+		}
+		summary = append(summary, *stats)
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Variant < summary[j].Variant })
+	return summary
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"claude-reactor/internal/reactor/buildstats"
+)
+
+func TestSummarizeBuildStats(t *testing.T) {
+	records := []buildstats.Record{
+		{Kind: buildstats.KindBuild, Variant: "go", Duration: 90 * time.Second, Layers: 10, Cached: 2},
+		{Kind: buildstats.KindPull, Variant: "go", Duration: 20 * time.Second},
+		{Kind: buildstats.KindBuild, Variant: "go", Duration: 30 * time.Second, Layers: 10, Cached: 10},
+		{Kind: buildstats.KindPull, Variant: "base", Duration: 8 * time.Second, Layers: 4, Cached: 4},
+	}
+
+	assert.Equal(t, []variantBuildStats{
+		{Variant: "base", Pulls: 1, AvgPull: 8 * time.Second},
+		{Variant: "go", Builds: 2, AvgBuild: time.Minute, BuildCached: 0.6, Pulls: 1, AvgPull: 20 * time.Second},
+	}, summarizeBuildStats(records))
+	assert.Empty(t, summarizeBuildStats(nil))
+}
+
+func TestSlowestStep(t *testing.T) {
+	_, ok := slowestStep(nil)
+	assert.False(t, ok)
+
+	step, ok := slowestStep([]buildstats.Step{
+		{Instruction: "FROM debian", Duration: time.Second},
+		{Instruction: "RUN apt-get install -y golang", Duration: 40 * time.Second},
+		{Instruction: "COPY . .", Duration: 2 * time.Second},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "RUN apt-get install -y golang", step.Instruction)
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/moby/term"
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/safety"
+	"claude-reactor/pkg"
+)
+
+// runSettings collects the risky settings of a session in containerConfig. userMounts are the
+// mount specs from the mounts setting and --mount flags.
+func runSettings(ctx context.Context, app *pkg.AppContainer, config *pkg.Config, containerConfig *pkg.ContainerConfig, userMounts []string) (*safety.Settings, []pkg.Mount) {
+	settings := &safety.Settings{
+		Account:    config.Account,
+		Image:      containerConfig.Image,
+		Danger:     config.DangerMode,
+		HostDocker: containerConfig.HostDocker,
+	}
+	if app.ImageValidator != nil {
+		if result, err := app.ImageValidator.ValidateImage(ctx, containerConfig.Image, false); err == nil {
+			settings.Digest = result.Digest
+		}
+	}
+
+	var mounts []pkg.Mount
+	for _, spec := range userMounts {
+		mount, err := app.MountMgr.ParseMountSpec(spec)
+		if err != nil {
+			continue
+		}
+		mounts = append(mounts, *mount)
+		if safety.IsExternal(mount.Source, config.ProjectPath) {
+			settings.ExternalMounts = append(settings.ExternalMounts, mount.Source+" -> "+mount.Target)
+		}
+	}
+	return settings, mounts
+}
+
+// printRunSummary shows what a session can reach on one screen, highlighting mounts of host
+// paths outside the project
+func printRunSummary(out io.Writer, settings *safety.Settings, mounts []pkg.Mount, projectDir string) {
+	image := settings.Image
+	if digest := strings.TrimPrefix(settings.Digest, "sha256:"); digest != "" {
+		if len(digest) > 12 {
+			digest = digest[:12]
+		}
+		image += " (sha256:" + digest + ")"
+	} else {
+		image += " (" + i18n.T("summary.pulled") + ")"
+	}
+
+	fmt.Fprintln(out, i18n.T("summary.title"))
+	fmt.Fprintf(out, "   %-13s %s\n", i18n.T("summary.image"), image)
+	fmt.Fprintf(out, "   %-13s %s\n", i18n.T("summary.account"), settings.Account)
+	fmt.Fprintf(out, "   %-13s %s\n", i18n.T("summary.danger"), onOff(settings.Danger))
+	fmt.Fprintf(out, "   %-13s %s\n", i18n.T("summary.host_docker"), onOff(settings.HostDocker))
+	for i, mount := range mounts {
+		label := ""
+		if i == 0 {
+			label = i18n.T("summary.mounts")
+		}
+		line := fmt.Sprintf("%s -> %s%s", mount.Source, mount.Target, mountModes(&mount))
+		if safety.IsExternal(mount.Source, projectDir) {
+			line = i18n.T("summary.outside", line)
+		}
+		fmt.Fprintf(out, "   %-13s %s\n", label, line)
+	}
+}
+
+// confirmRunSettings shows the session summary and, when settings got riskier since the
+// project's last run, asks before starting. Without a terminal to ask on, it refuses unless
+// confirmed is set. The settings are recorded once the session may start. With --ci the
+// summary goes to stderr, keeping stdout for the command's output.
+func confirmRunSettings(ctx context.Context, cmd *cobra.Command, app *pkg.AppContainer, config *pkg.Config, containerConfig *pkg.ContainerConfig, userMounts []string, confirmed bool) error {
+	settings, mounts := runSettings(ctx, app, config, containerConfig, userMounts)
+	out := i18n.Writer(cmd.OutOrStdout())
+	if ci, _ := cmd.Flags().GetBool("ci"); ci {
+		out = i18n.Writer(cmd.ErrOrStderr())
+	}
+	printRunSummary(out, settings, mounts, config.ProjectPath)
+
+	path, err := safety.Path(config.ProjectPath)
+	if err != nil {
+		return err
+	}
+	previous, err := safety.Load(path)
+	if err != nil {
+		app.Logger.Debugf("Ignoring the last run's settings: %v", err)
+	}
+
+	if changes := safety.RiskyChanges(previous, settings); len(changes) > 0 && !confirmed {
+		fmt.Fprintln(out, i18n.T("summary.riskier"))
+		for _, change := range changes {
+			fmt.Fprintf(out, "   %s: %s → %s\n", change.Setting, change.From, change.To)
+		}
+		if !interactiveInput(cmd.InOrStdin()) {
+			return pkg.NewError(pkg.CodeNeedsConfirmation, nil, "settings changed since the last run need confirmation")
+		}
+		fmt.Fprint(out, i18n.T("summary.confirm"))
+		answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return fmt.Errorf("session cancelled")
+		}
+	}
+
+	if err := safety.Save(path, settings); err != nil {
+		app.Logger.Debugf("Failed to record the run's settings: %v", err)
+	}
+	return nil
+}
+
+// interactiveInput reports whether in can be asked for confirmation: a terminal, or input
+// that is not a file, such as a test's
+func interactiveInput(in io.Reader) bool {
+	file, ok := in.(*os.File)
+	return !ok || term.IsTerminal(file.Fd())
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/safety"
+	"claude-reactor/pkg"
+)
+
+func TestPrintRunSummary(t *testing.T) {
+	var out bytes.Buffer
+	printRunSummary(&out, &safety.Settings{
+		Account: "work",
+		Image:   "claude-reactor-go-amd64",
+		Digest:  "sha256:0123456789abcdef",
+		Danger:  true,
+	}, []pkg.Mount{
+		{Source: "/src/app/docs", Target: "/mnt/docs", ReadOnly: true},
+		{Source: "/etc", Target: "/mnt/etc"},
+	}, "/src/app")
+
+	assert.Equal(t, `📋 Session summary
+   Image:        claude-reactor-go-amd64 (sha256:0123456789ab)
+   Account:      work
+   Danger mode:  on
+   Host Docker:  off
+   Mounts:       /src/app/docs -> /mnt/docs (read-only)
+                 ⚠️  /etc -> /mnt/etc (outside the project)
+`, out.String())
+}
+
+func TestPrintRunSummaryJapaneseWithoutEmoji(t *testing.T) {
+	t.Setenv(i18n.NoEmojiEnv, "")
+	require.NoError(t, i18n.Setup("ja", true))
+	t.Cleanup(func() { _ = i18n.Setup("en", false) })
+
+	var out bytes.Buffer
+	printRunSummary(i18n.Writer(&out), &safety.Settings{Account: "work", Image: "claude-reactor-go-amd64"},
+		[]pkg.Mount{{Source: "/etc", Target: "/mnt/etc"}}, "/src/app")
+
+	assert.Equal(t, `セッションの概要
+   イメージ:         claude-reactor-go-amd64 (起動時に取得)
+   アカウント:        work
+   危険モード:        off
+   ホスト Docker:   off
+   マウント:         [!]  /etc -> /mnt/etc (プロジェクト外)
+`, out.String())
+}
+
+func TestConfirmRunSettings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	config := &pkg.Config{Account: "work", ProjectPath: "/src/app"}
+	containerConfig := &pkg.ContainerConfig{Image: "claude-reactor-go-amd64"}
+	confirm := func(input string, confirmed bool) (string, error) {
+		cmd := &cobra.Command{}
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetIn(strings.NewReader(input))
+		err := confirmRunSettings(context.Background(), cmd, createMockApp(), config, containerConfig, nil, confirmed)
+		return out.String(), err
+	}
+
+	// The first run has nothing to compare with
+	out, err := confirm("", false)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Session summary")
+	assert.NotContains(t, out, "Start the session?")
+
+	config.DangerMode = true
+	out, err = confirm("n\n", false)
+	assert.EqualError(t, err, "session cancelled")
+	assert.Contains(t, out, "danger mode: off → on")
+
+	// Declining records nothing, so the change is asked about again
+	out, err = confirm("y\n", false)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Start the session? (y/N): ")
+
+	config.Account = "personal"
+	out, err = confirm("", true)
+	require.NoError(t, err)
+	assert.NotContains(t, out, "Start the session?")
+
+	// Unchanged settings start without asking
+	out, err = confirm("", false)
+	require.NoError(t, err)
+	assert.NotContains(t, out, "Riskier")
+
+	// In CI mode stdout is left to the command
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("ci", true, "")
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	require.NoError(t, confirmRunSettings(context.Background(), cmd, createMockApp(), config, containerConfig, nil, false))
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), "Session summary")
+}
@@ -0,0 +1,270 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/config"
+	"claude-reactor/internal/reactor/history"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/targets"
+	"claude-reactor/pkg"
+)
+
+// taskNamePattern keeps task names easy to type and free of the '.' that separates task keys
+var taskNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// envNamePattern is a valid environment variable name
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// NewTaskCmd creates the task command, which runs the project's named commands in its container
+func NewTaskCmd(app *pkg.AppContainer) *cobra.Command {
+	var taskCmd = &cobra.Command{
+		Use:   "task NAME [-- ARGS...]",
+		Short: "Run a named project task in the container",
+		Long: `Run one of the project's tasks in the running project container.
+
+Tasks are named commands kept in the project configuration, so the team's
+test, lint and build commands run the same way for everyone without wrapper
+scripts around 'docker exec'. Each task runs with sh -c from the project root,
+or from its workdir, with its environment added. Arguments after -- are
+appended to the command.
+
+Targets the project already has are tasks too: Makefile targets run as
+make:TARGET, justfile recipes as just:RECIPE, and package.json scripts as
+npm:SCRIPT, using the package manager of the project's lockfile.
+
+Like 'claude-reactor exec', the task's exit code becomes the exit code of
+claude-reactor, and the run is recorded in 'claude-reactor exec history'.`,
+		Example: `# Define tasks
+claude-reactor config set task.test "go test ./..."
+claude-reactor config set task.test.env "GOFLAGS=-count=1"
+claude-reactor config set task.lint "golangci-lint run"
+claude-reactor config set task.lint.workdir api
+
+# Run them
+claude-reactor task test
+claude-reactor task test -- -run TestLogin
+
+# Run the project's existing automation
+claude-reactor task make:lint
+claude-reactor task npm:build
+
+# See the project's tasks
+claude-reactor task list`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return runTask(cmd, app, args[0], args[1:])
+		},
+		ValidArgsFunction: completeTasks(app),
+	}
+
+	taskCmd.AddCommand(newTaskListCmd(app))
+
+	return taskCmd
+}
+
+func newTaskListCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the project's tasks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return listTasks(app)
+		},
+	}
+}
+
+func runTask(cmd *cobra.Command, app *pkg.AppContainer, name string, args []string) error {
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	containerName, projectConfig, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	task := config.FindTask(projectConfig, name)
+	if task == nil || task.Command == "" {
+		// Targets of the project's Makefile, justfile and package.json run as make:NAME and so on
+		if target := targets.Find(projectConfig.ProjectPath, name); target != nil {
+			app.Logger.Infof("🧰 Running %s target: %s", target.Source, strings.Join(target.Command, " "))
+			return execInProject(cmd, app, containerName, projectConfig, history.Entry{
+				Command: append(target.Command, args...),
+				Task:    name,
+			})
+		}
+		return pkg.NewError(pkg.CodeNotDefined, nil, "no task '%s' in this project", name).
+			WithRemediation("Define it with: claude-reactor config set task."+name+" \"COMMAND\"", "See the available tasks with: claude-reactor task list")
+	}
+	if task.Workdir != "" {
+		if _, err := projectSubdir(task.Workdir); err != nil {
+			return fmt.Errorf("task %s: %w", name, err)
+		}
+	}
+
+	app.Logger.Infof("🧰 Running task %s: %s", name, task.Command)
+	return execInProject(cmd, app, containerName, projectConfig, history.Entry{
+		Command: taskCommand(task, args),
+		Workdir: task.Workdir,
+		Task:    name,
+	})
+}
+
+// taskCommand returns the command line that runs task with args appended
+func taskCommand(task *pkg.Task, args []string) []string {
+	var command []string
+	if len(task.Env) > 0 {
+		command = append([]string{"env"}, task.Env...)
+	}
+	if len(args) == 0 {
+		return append(command, "sh", "-c", task.Command)
+	}
+	// Arguments are passed as positional parameters so the shell never re-parses them
+	command = append(command, "sh", "-c", task.Command+` "$@"`, task.Name)
+	return append(command, args...)
+}
+
+// listTasks prints the project's tasks
+func listTasks(app *pkg.AppContainer) error {
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+	_, projectConfig, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	discovered := targets.Discover(projectConfig.ProjectPath)
+	if len(projectConfig.Tasks) == 0 && len(discovered) == 0 {
+		fmt.Fprintln(i18n.Stdout, "No tasks defined for this project")
+		fmt.Fprintln(i18n.Stdout, "💡 Define one with: claude-reactor config set task.test \"go test ./...\"")
+		return nil
+	}
+
+	fmt.Fprintf(i18n.Stdout, "%-20s %-12s %-12s %-30s %s\n", "TASK", "SOURCE", "WORKDIR", "ENV", "COMMAND")
+	for _, task := range projectConfig.Tasks {
+		workdir := task.Workdir
+		if workdir == "" {
+			workdir = "."
+		}
+		fmt.Fprintf(i18n.Stdout, "%-20s %-12s %-12s %-30s %s\n", task.Name, "config", workdir, strings.Join(task.Env, ","), task.Command)
+	}
+	for _, target := range discovered {
+		fmt.Fprintf(i18n.Stdout, "%-20s %-12s %-12s %-30s %s\n", target.Name, target.Source, ".", "", strings.Join(target.Command, " "))
+	}
+	return nil
+}
+
+// taskNames returns the names of the project's tasks
+func taskNames(projectConfig *pkg.Config) []string {
+	names := make([]string, 0, len(projectConfig.Tasks))
+	for _, task := range projectConfig.Tasks {
+		names = append(names, task.Name)
+	}
+	return names
+}
+
+// setTask applies a task.NAME, task.NAME.env or task.NAME.workdir setting; "none" removes the
+// task or clears the setting
+func setTask(projectConfig *pkg.Config, key, value string) error {
+	name, field, _ := strings.Cut(key, ".")
+	if !taskNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid task name '%s': use letters, digits, '-' and '_'", name)
+	}
+	task := config.FindTask(projectConfig, name)
+
+	if field == "" {
+		if value == "none" {
+			for i := range projectConfig.Tasks {
+				if projectConfig.Tasks[i].Name == name {
+					projectConfig.Tasks = append(projectConfig.Tasks[:i], projectConfig.Tasks[i+1:]...)
+					break
+				}
+			}
+			return nil
+		}
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("task %s needs a command", name)
+		}
+		if task == nil {
+			projectConfig.Tasks = append(projectConfig.Tasks, pkg.Task{Name: name})
+			task = &projectConfig.Tasks[len(projectConfig.Tasks)-1]
+		}
+		task.Command = value
+		return nil
+	}
+
+	if task == nil {
+		return pkg.NewError(pkg.CodeNotDefined, nil, "no task '%s' in this project", name).
+			WithRemediation("Define it first with: claude-reactor config set task." + name + " \"COMMAND\"")
+	}
+	switch field {
+	case "env":
+		var env []string
+		if value != "none" {
+			env = configList(value)
+		}
+		for _, entry := range env {
+			if key, _, ok := strings.Cut(entry, "="); !ok || !envNamePattern.MatchString(key) {
+				return fmt.Errorf("invalid environment entry '%s' for task %s: use KEY=VALUE", entry, name)
+			}
+		}
+		task.Env = env
+	case "workdir":
+		if value == "none" {
+			value = ""
+		}
+		if value != "" {
+			if !filepath.IsLocal(value) {
+				return fmt.Errorf("invalid workdir '%s' for task %s: use a path inside the project, relative to its root", value, name)
+			}
+			if value = filepath.ToSlash(filepath.Clean(value)); value == "." {
+				value = ""
+			}
+		}
+		task.Workdir = value
+	default:
+		return fmt.Errorf("unknown configuration key: task.%s", key)
+	}
+	return nil
+}
+
+// completeTasks completes the names of the project's tasks. Completion must stay fast and
+// silent, so any error yields no results.
+func completeTasks(app *pkg.AppContainer) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if app == nil || len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		dir, err := os.Getwd()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		homeDir, _ := os.UserHomeDir()
+		root := projectRoot(dir, homeDir)
+		if err := os.Chdir(root); err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var completions []string
+		if projectConfig, err := app.ConfigMgr.LoadConfig(); err == nil {
+			for _, task := range projectConfig.Tasks {
+				completions = append(completions, task.Name+"\t"+task.Command)
+			}
+		}
+		for _, target := range targets.Discover(root) {
+			completions = append(completions, target.Name+"\t"+target.Source)
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func TestTaskCommand(t *testing.T) {
+	task := &pkg.Task{Name: "test", Command: "go test ./..."}
+	assert.Equal(t, []string{"sh", "-c", "go test ./..."}, taskCommand(task, nil))
+	assert.Equal(t, []string{"sh", "-c", `go test ./... "$@"`, "test", "-run", "Test Login"}, taskCommand(task, []string{"-run", "Test Login"}))
+
+	task.Env = []string{"GOFLAGS=-count=1"}
+	assert.Equal(t, []string{"env", "GOFLAGS=-count=1", "sh", "-c", "go test ./..."}, taskCommand(task, nil))
+}
+
+func TestSetTask(t *testing.T) {
+	config := &pkg.Config{}
+
+	require.NoError(t, setTask(config, "test", "go test ./..."))
+	require.NoError(t, setTask(config, "test.env", "GOFLAGS=-count=1, CGO_ENABLED=0"))
+	require.NoError(t, setTask(config, "lint", "golangci-lint run"))
+	require.NoError(t, setTask(config, "lint.workdir", "./api/"))
+	assert.Equal(t, []pkg.Task{
+		{Name: "test", Command: "go test ./...", Env: []string{"GOFLAGS=-count=1", "CGO_ENABLED=0"}},
+		{Name: "lint", Command: "golangci-lint run", Workdir: "api"},
+	}, config.Tasks)
+	assert.Equal(t, []string{"test", "lint"}, taskNames(config))
+
+	require.NoError(t, setTask(config, "test", "go test -race ./..."))
+	require.NoError(t, setTask(config, "test.env", "none"))
+	require.NoError(t, setTask(config, "lint.workdir", "."))
+	assert.Equal(t, "go test -race ./...", config.Tasks[0].Command)
+	assert.Empty(t, config.Tasks[0].Env)
+	assert.Empty(t, config.Tasks[1].Workdir)
+
+	require.NoError(t, setTask(config, "lint", "none"))
+	assert.Equal(t, []string{"test"}, taskNames(config))
+
+	assert.Contains(t, pkg.Remediation(setTask(config, "build.env", "A=1")), `Define it first with: claude-reactor config set task.build "COMMAND"`)
+	assert.ErrorContains(t, setTask(config, "my task", "make"), "invalid task name")
+	assert.ErrorContains(t, setTask(config, "test", " "), "needs a command")
+	assert.ErrorContains(t, setTask(config, "test.env", "GOFLAGS"), "KEY=VALUE")
+	assert.ErrorContains(t, setTask(config, "test.workdir", "../other"), "inside the project")
+	assert.ErrorContains(t, setTask(config, "test.timeout", "5m"), "unknown configuration key")
+}
+
+func TestTaskRequiresName(t *testing.T) {
+	cmd := NewTaskCmd(createMockApp())
+	cmd.SetArgs([]string{})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestCompleteTasks(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(originalDir)
+	project := t.TempDir()
+	require.NoError(t, os.Chdir(project))
+	require.NoError(t, os.WriteFile("Makefile", []byte("test:\n\tgo test ./...\n"), 0644))
+
+	configMgr := &mocks.MockConfigManager{}
+	configMgr.On("LoadConfig").Return(&pkg.Config{Tasks: []pkg.Task{{Name: "lint", Command: "golangci-lint run"}}}, nil)
+	app := createMockApp()
+	app.ConfigMgr = configMgr
+
+	completions, directive := completeTasks(app)(NewTaskCmd(app), nil, "")
+	assert.Equal(t, []string{"lint\tgolangci-lint run", "make:test\tMakefile"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	completions, _ = completeTasks(app)(NewTaskCmd(app), []string{"lint"}, "")
+	assert.Empty(t, completions, "only the task name completes")
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"claude-reactor/internal/reactor/docker/validation"
+	"claude-reactor/internal/reactor/policy"
+	"claude-reactor/pkg"
+)
+
+// toolRequirements returns the tools the image policy and the project require and recommend.
+// The policy's come first and win over a project entry for the same tool, so projects can add
+// tools but not loosen the policy.
+func toolRequirements(config *pkg.Config) ([]pkg.ToolRequirement, error) {
+	p, err := policy.Load()
+	if err != nil {
+		return nil, err
+	}
+	tools, err := p.Tools()
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range []struct {
+		specs    []string
+		required bool
+	}{{config.RequiredTools, true}, {config.RecommendedTools, false}} {
+		for _, spec := range list.specs {
+			tool, err := validation.ParseToolSpec(spec, list.required)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.ContainsFunc(tools, func(t pkg.ToolRequirement) bool { return t.Name == tool.Name }) {
+				tools = append(tools, tool)
+			}
+		}
+	}
+	return tools, nil
+}
+
+// checkImageTools checks imageName for the required and recommended tools. With tools_install,
+// the apt packages of tools that fall short are added to the project packages installed on
+// baseImage, and the derived image is checked instead. A required tool still missing or
+// outdated stops the run; recommended ones are reported.
+func checkImageTools(ctx context.Context, app *pkg.AppContainer, config *pkg.Config, tools []pkg.ToolRequirement, baseImage, imageName string, fromRegistry bool) (string, error) {
+	app.ImageValidator.SetToolRequirements(tools)
+	defer app.ImageValidator.SetToolRequirements(nil)
+
+	checks, err := imageToolChecks(ctx, app, imageName, fromRegistry && imageName == baseImage)
+	if err != nil {
+		return "", err
+	}
+	if failed := failedTools(checks, false); len(failed) > 0 && config.ToolsInstall {
+		installConfig := *config
+		installConfig.Packages.Apt = slices.Clone(config.Packages.Apt)
+		var names []string
+		for _, check := range failed {
+			names = append(names, check.Name)
+			if !slices.Contains(installConfig.Packages.Apt, check.AptPackage()) {
+				installConfig.Packages.Apt = append(installConfig.Packages.Apt, check.AptPackage())
+			}
+		}
+		app.Logger.Infof("🧰 Installing missing tools: %s", strings.Join(names, ", "))
+		if imageName, err = packagesImage(ctx, app, app.DockerMgr, &installConfig, baseImage, fromRegistry); err != nil {
+			return "", err
+		}
+		if checks, err = imageToolChecks(ctx, app, imageName, false); err != nil {
+			return "", err
+		}
+	}
+
+	reportToolChecks(app, checks)
+	if missing := failedTools(checks, true); len(missing) > 0 {
+		var names []string
+		for _, check := range missing {
+			names = append(names, check.Name)
+		}
+		hint := "💡 Install them in a derived image with: claude-reactor config set tools_install true"
+		if config.ToolsInstall {
+			hint = "💡 Name the apt package providing a tool with NAME:PACKAGE, or use an image that has them"
+		}
+		return "", fmt.Errorf("image %s lacks required tools: %s\n%s", imageName, strings.Join(names, ", "), hint)
+	}
+	return imageName, nil
+}
+
+// imageToolChecks validates imageName against the tool requirements set on the validator
+func imageToolChecks(ctx context.Context, app *pkg.AppContainer, imageName string, pull bool) ([]pkg.ToolCheck, error) {
+	result, err := app.ImageValidator.ValidateImage(ctx, imageName, pull)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check tools in image %s: %w", imageName, err)
+	}
+	if len(result.Tools) == 0 {
+		return nil, fmt.Errorf("failed to check tools in image %s: %s", imageName, strings.Join(result.Errors, "; "))
+	}
+	return result.Tools, nil
+}
+
+// failedTools returns the checks of missing or outdated tools, only required ones if requiredOnly
+func failedTools(checks []pkg.ToolCheck, requiredOnly bool) []pkg.ToolCheck {
+	var failed []pkg.ToolCheck
+	for _, check := range checks {
+		if check.Status != pkg.ToolOK && (check.Required || !requiredOnly) {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// reportToolChecks summarizes the tool checks, listing each tool when any falls short
+func reportToolChecks(app *pkg.AppContainer, checks []pkg.ToolCheck) {
+	failed := failedTools(checks, false)
+	app.Logger.Infof("🧰 Tool check: %d/%d tools available", len(checks)-len(failed), len(checks))
+	for _, check := range checks {
+		level := "recommended"
+		if check.Required {
+			level = "required"
+		}
+		switch check.Status {
+		case pkg.ToolOK:
+			if len(failed) == 0 {
+				app.Logger.Debugf("   ✅ %s %s", check.Name, check.Version)
+			} else {
+				app.Logger.Infof("   ✅ %s %s", check.Name, check.Version)
+			}
+		case pkg.ToolMissing:
+			app.Logger.Warnf("   ❌ %s: missing (%s)", check.Name, level)
+		case pkg.ToolOutdated:
+			version := check.Version
+			if version == "" {
+				version = "of unknown version"
+			}
+			app.Logger.Warnf("   ⚠️  %s %s: needs %s or newer (%s)", check.Name, version, check.MinVersion, level)
+		}
+	}
+}
+
+// toolList joins tool specs for display
+func toolList(specs []string) string {
+	if len(specs) == 0 {
+		return "none"
+	}
+	return strings.Join(specs, ", ")
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func TestToolRequirements(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".claude-reactor"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".claude-reactor", "policy.yaml"), []byte("required_tools: [git]\n"), 0644))
+
+	tools, err := toolRequirements(&pkg.Config{
+		RequiredTools:    []string{"protoc:protobuf-compiler>=3.21"},
+		RecommendedTools: []string{"git", "jq"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []pkg.ToolRequirement{
+		{Name: "git", Required: true},
+		{Name: "protoc", Package: "protobuf-compiler", MinVersion: "3.21", Required: true},
+		{Name: "jq"},
+	}, tools, "the policy's git stays required")
+
+	_, err = toolRequirements(&pkg.Config{RequiredTools: []string{"jq>=latest"}})
+	assert.Error(t, err)
+}
+
+func TestCheckImageTools(t *testing.T) {
+	ctx := context.Background()
+	tools := []pkg.ToolRequirement{{Name: "git", Required: true}, {Name: "protoc", Package: "protobuf-compiler", Required: true}, {Name: "jq"}}
+	checks := func(protoc, jq string) *pkg.ImageValidationResult {
+		return &pkg.ImageValidationResult{Tools: []pkg.ToolCheck{
+			{ToolRequirement: tools[0], Version: "2.39.2", Status: pkg.ToolOK},
+			{ToolRequirement: tools[1], Status: protoc},
+			{ToolRequirement: tools[2], Status: jq},
+		}}
+	}
+	newApp := func() (*pkg.AppContainer, *mocks.MockImageValidator, *mocks.MockDockerManager) {
+		validator := &mocks.MockImageValidator{}
+		validator.On("SetToolRequirements", mock.Anything).Return()
+		dockerMgr := &mocks.MockDockerManager{}
+		archDetector := &mocks.MockArchDetector{}
+		archDetector.On("GetDockerPlatform").Return("linux/amd64", nil)
+		app := createMockApp()
+		app.ImageValidator = validator
+		app.DockerMgr = dockerMgr
+		app.ArchDetector = archDetector
+		return app, validator, dockerMgr
+	}
+
+	t.Run("all available", func(t *testing.T) {
+		app, validator, _ := newApp()
+		validator.On("ValidateImage", ctx, "claude-reactor-go-amd64", false).Return(checks(pkg.ToolOK, pkg.ToolOK), nil)
+
+		image, err := checkImageTools(ctx, app, &pkg.Config{}, tools, "claude-reactor-go-amd64", "claude-reactor-go-amd64", false)
+		require.NoError(t, err)
+		assert.Equal(t, "claude-reactor-go-amd64", image)
+		validator.AssertCalled(t, "SetToolRequirements", tools)
+		validator.AssertCalled(t, "SetToolRequirements", []pkg.ToolRequirement(nil))
+	})
+
+	t.Run("recommended missing", func(t *testing.T) {
+		app, validator, _ := newApp()
+		validator.On("ValidateImage", ctx, "ghcr.io/me/dev", true).Return(checks(pkg.ToolOK, pkg.ToolMissing), nil)
+
+		image, err := checkImageTools(ctx, app, &pkg.Config{}, tools, "ghcr.io/me/dev", "ghcr.io/me/dev", true)
+		require.NoError(t, err)
+		assert.Equal(t, "ghcr.io/me/dev", image)
+	})
+
+	t.Run("required missing", func(t *testing.T) {
+		app, validator, _ := newApp()
+		validator.On("ValidateImage", ctx, "claude-reactor-go-amd64", false).Return(checks(pkg.ToolMissing, pkg.ToolOK), nil)
+
+		_, err := checkImageTools(ctx, app, &pkg.Config{}, tools, "claude-reactor-go-amd64", "claude-reactor-go-amd64", false)
+		assert.ErrorContains(t, err, "lacks required tools: protoc")
+		assert.ErrorContains(t, err, "tools_install true")
+	})
+
+	t.Run("installed in a derived image", func(t *testing.T) {
+		app, validator, dockerMgr := newApp()
+		config := &pkg.Config{ToolsInstall: true, Packages: pkg.Packages{Apt: []string{"jq"}}}
+		validator.On("ValidateImage", ctx, "claude-reactor-go-amd64-packages-1", false).Return(checks(pkg.ToolMissing, pkg.ToolOK), nil)
+		dockerMgr.On("BuildPackagesImage", ctx, "claude-reactor-go-amd64", pkg.Packages{Apt: []string{"jq", "protobuf-compiler"}}, "linux/amd64").
+			Return("claude-reactor-go-amd64-packages-2", nil)
+		validator.On("ValidateImage", ctx, "claude-reactor-go-amd64-packages-2", false).Return(checks(pkg.ToolOK, pkg.ToolOK), nil)
+
+		image, err := checkImageTools(ctx, app, config, tools, "claude-reactor-go-amd64", "claude-reactor-go-amd64-packages-1", false)
+		require.NoError(t, err)
+		assert.Equal(t, "claude-reactor-go-amd64-packages-2", image)
+		assert.Equal(t, []string{"jq"}, config.Packages.Apt, "the project's packages are unchanged")
+	})
+
+	t.Run("check failed", func(t *testing.T) {
+		app, validator, _ := newApp()
+		validator.On("ValidateImage", ctx, "claude-reactor-go-amd64", false).Return(&pkg.ImageValidationResult{Errors: []string{"Failed to check tools: timed out after 30s"}}, nil)
+
+		_, err := checkImageTools(ctx, app, &pkg.Config{}, tools, "claude-reactor-go-amd64", "claude-reactor-go-amd64", false)
+		assert.ErrorContains(t, err, "timed out")
+	})
+}
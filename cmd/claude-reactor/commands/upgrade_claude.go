@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/docker"
+	"claude-reactor/pkg"
+)
+
+// NewUpgradeClaudeCmd creates the upgrade-claude command for explicit Claude CLI upgrades
+func NewUpgradeClaudeCmd(app *pkg.AppContainer) *cobra.Command {
+	var upgradeCmd = &cobra.Command{
+		Use:   "upgrade-claude [version]",
+		Short: "Upgrade the Claude CLI in the project's container",
+		Long: `Upgrade the Claude CLI in the running container for the current project, reporting the
+version before and after.
+
+Without a version the latest release is installed; with one (e.g. 1.0.58) that exact version
+is installed. Use --pin to keep the resulting version: it is saved as claude_cli_version and
+installed whenever the container starts, instead of upgrading.
+
+Examples:
+  claude-reactor upgrade-claude
+  claude-reactor upgrade-claude 1.0.58 --pin`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return upgradeClaude(cmd, app, args)
+		},
+	}
+
+	upgradeCmd.Flags().Bool("pin", false, "Save the installed version as claude_cli_version")
+
+	return upgradeCmd
+}
+
+// upgradeClaude installs the requested Claude CLI version in the project's container
+func upgradeClaude(cmd *cobra.Command, app *pkg.AppContainer, args []string) error {
+	ctx := cmd.Context()
+	pin, _ := cmd.Flags().GetBool("pin")
+
+	target := "latest"
+	if len(args) == 1 {
+		target = args[0]
+		if !docker.ValidClaudeCLIVersion(target) {
+			return fmt.Errorf("invalid Claude CLI version: %s (use an exact version such as 1.0.58)", target)
+		}
+	}
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+
+	containerName, config, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	running, err := app.DockerMgr.IsContainerRunning(ctx, containerName)
+	if err != nil || !running {
+		return pkg.NewError(pkg.CodeContainerNotRunning, nil, "container %s is not running", containerName)
+	}
+
+	before := claudeCLIVersion(ctx, app, containerName)
+	app.Logger.Infof("⬆️ Installing Claude CLI %s in %s...", target, containerName)
+	output, exitCode, err := app.DockerMgr.ExecCommand(ctx, containerName, docker.ClaudeInstallCommand(target))
+	if err != nil {
+		return fmt.Errorf("failed to upgrade Claude CLI: %w", err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("npm install exited with code %d: %s", exitCode, strings.TrimSpace(output))
+	}
+	after := claudeCLIVersion(ctx, app, containerName)
+
+	if before == after {
+		app.Logger.Infof("✅ Claude CLI is already at %s", getDisplayValue(after, "unknown"))
+	} else {
+		app.Logger.Infof("✅ Claude CLI upgraded: %s → %s", getDisplayValue(before, "not installed"), getDisplayValue(after, "unknown"))
+	}
+
+	switch {
+	case pin:
+		if !docker.ValidClaudeCLIVersion(after) {
+			return fmt.Errorf("cannot pin Claude CLI: installed version %q is not an exact version", after)
+		}
+		saved, err := app.ConfigMgr.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+		saved.ClaudeCLIVersion = after
+		if err := app.ConfigMgr.SaveConfig(saved); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+		app.Logger.Infof("📌 Pinned claude_cli_version = %s", after)
+	case config.ClaudeCLIVersion != "" && config.ClaudeCLIVersion != after:
+		app.Logger.Warnf("claude_cli_version pins %s, which is installed again when the container next starts", config.ClaudeCLIVersion)
+		app.Logger.Info("💡 Keep this version with --pin, or unpin with: claude-reactor config set claude_cli_version none")
+	}
+	return nil
+}
+
+// claudeCLIVersion returns the Claude CLI version installed in a container, or "" if unknown
+func claudeCLIVersion(ctx context.Context, app *pkg.AppContainer, containerName string) string {
+	output, exitCode, err := app.DockerMgr.ExecCommand(ctx, containerName, docker.ClaudeVersionCommand)
+	if err != nil || exitCode != 0 {
+		return ""
+	}
+	return docker.ParseClaudeVersion(output)
+}
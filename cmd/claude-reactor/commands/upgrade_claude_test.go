@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/docker"
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func TestNewUpgradeClaudeCmd(t *testing.T) {
+	cmd := NewUpgradeClaudeCmd(createMockApp())
+	assert.Equal(t, "upgrade-claude [version]", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("pin"))
+	assert.Error(t, cmd.Args(cmd, []string{"1.0.0", "1.0.1"}))
+
+	t.Run("invalid version is rejected", func(t *testing.T) {
+		cmd := NewUpgradeClaudeCmd(createMockApp())
+		err := upgradeClaude(cmd, createMockApp(), []string{"latest"})
+		assert.ErrorContains(t, err, "invalid Claude CLI version")
+	})
+}
+
+func TestRunNoUpgradeFlag(t *testing.T) {
+	cmd := NewRunCmd(createMockApp())
+	flag := cmd.Flags().Lookup("no-upgrade")
+	require.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+}
+
+func TestUpgradeClaude(t *testing.T) {
+	const containerName = "claude-reactor-base-amd64-1a2b3c4d-default"
+
+	// Resolving the project changes to its root; keep that out of this repository
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	setup := func(pinned string) (*pkg.AppContainer, *mocks.MockConfigManager, *captureLogger) {
+		configMgr := &mocks.MockConfigManager{}
+		configMgr.On("LoadConfig").Return(&pkg.Config{Variant: "base", Account: "default", ClaudeCLIVersion: pinned}, nil)
+		archDetector := &mocks.MockArchDetector{}
+		archDetector.On("GetHostArchitecture").Return("amd64", nil)
+
+		dockerMgr := &mocks.MockDockerManager{}
+		dockerMgr.On("GenerateContainerName", mock.Anything, "base", "amd64", "default").Return(containerName)
+		dockerMgr.On("IsContainerRunning", mock.Anything, containerName).Return(true, nil)
+		dockerMgr.On("ExecCommand", mock.Anything, containerName, docker.ClaudeVersionCommand).Return("1.0.50 (Claude Code)\n", 0, nil).Once()
+		dockerMgr.On("ExecCommand", mock.Anything, containerName, docker.ClaudeInstallCommand("1.0.58")).Return("", 0, nil)
+		dockerMgr.On("ExecCommand", mock.Anything, containerName, docker.ClaudeVersionCommand).Return("1.0.58 (Claude Code)\n", 0, nil).Once()
+
+		logger := &captureLogger{}
+		app := createMockApp()
+		app.ConfigMgr = configMgr
+		app.ArchDetector = archDetector
+		app.DockerMgr = dockerMgr
+		app.Logger = logger
+		return app, configMgr, logger
+	}
+
+	t.Run("reports versions and warns about a different pin", func(t *testing.T) {
+		app, _, logger := setup("1.0.50")
+
+		cmd := NewUpgradeClaudeCmd(app)
+		cmd.SetArgs([]string{"1.0.58"})
+		require.NoError(t, cmd.ExecuteContext(context.Background()))
+
+		assert.Contains(t, logger.messages, "✅ Claude CLI upgraded: %s → %s")
+		assert.Contains(t, logger.messages, "claude_cli_version pins %s, which is installed again when the container next starts")
+	})
+
+	t.Run("--pin saves the installed version", func(t *testing.T) {
+		app, configMgr, _ := setup("")
+		configMgr.On("SaveConfig", mock.MatchedBy(func(config *pkg.Config) bool {
+			return config.ClaudeCLIVersion == "1.0.58"
+		})).Return(nil)
+
+		cmd := NewUpgradeClaudeCmd(app)
+		cmd.SetArgs([]string{"1.0.58", "--pin"})
+		require.NoError(t, cmd.ExecuteContext(context.Background()))
+
+		configMgr.AssertCalled(t, "SaveConfig", mock.Anything)
+	})
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/pkg"
+)
+
+// projectRoot returns the project dir belongs to: the nearest directory at or above dir with a
+// .claude-reactor file or a .git entry. The search stops below the home directory, so a
+// dotfiles repository in home does not swallow every project. dir is its own project when
+// nothing is found.
+func projectRoot(dir, homeDir string) string {
+	for current := dir; ; {
+		if current == homeDir && current != dir {
+			return dir
+		}
+		for _, marker := range []string{".claude-reactor", ".git"} {
+			if _, err := os.Stat(filepath.Join(current, marker)); err == nil {
+				return current
+			}
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
+
+// enterProjectRoot changes to the root of the project containing the current directory, so the
+// configuration and container are the ones 'run' uses there, and returns the directory it was
+// started from relative to the root ("" at the root)
+func enterProjectRoot(app *pkg.AppContainer, detect bool) (string, error) {
+	if !detect {
+		return "", nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	homeDir, _ := os.UserHomeDir()
+	root := projectRoot(dir, homeDir)
+	if root == dir {
+		return "", nil
+	}
+	if err := os.Chdir(root); err != nil {
+		return "", fmt.Errorf("failed to change to project root %s: %w", root, err)
+	}
+	app.Logger.Infof("📂 Project root: %s", root)
+	return filepath.Rel(root, dir)
+}
+
+// projectSubdir checks that subdir names a directory inside the project in the current directory
+func projectSubdir(subdir string) (string, error) {
+	subdir = filepath.Clean(subdir)
+	if filepath.IsAbs(subdir) || subdir == ".." || strings.HasPrefix(subdir, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("--workdir must be a path inside the project, relative to its root: %s", subdir)
+	}
+	if info, err := os.Stat(subdir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("--workdir %s is not a directory in the project", subdir)
+	}
+	if subdir == "." {
+		return "", nil
+	}
+	return subdir, nil
+}
+
+// containerWorkdir returns where subdir of the project appears in the container
+func containerWorkdir(projectDir, subdir string) string {
+	return path.Join(projectMountTarget(projectDir), filepath.ToSlash(subdir))
+}
+
+// absoluteMountFlags resolves relative --mount paths against the current directory, before
+// enterProjectRoot changes it
+func absoluteMountFlags(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("mount")
+	if flag == nil || !flag.Changed {
+		return nil
+	}
+	mounts, err := cmd.Flags().GetStringSlice("mount")
+	if err != nil {
+		return err
+	}
+	for i, mount := range mounts {
+		// Only the source of src[:dst][:options] is a host path; C:\ starts a Windows one
+		source, rest, hasRest := strings.Cut(mount, ":")
+		windowsDrive := len(source) == 1 && (strings.HasPrefix(rest, `\`) || strings.HasPrefix(rest, "/"))
+		if source == "" || filepath.IsAbs(source) || strings.HasPrefix(source, "~") || windowsDrive {
+			continue
+		}
+		absolute, err := filepath.Abs(source)
+		if err != nil {
+			return fmt.Errorf("failed to resolve mount %s: %w", mount, err)
+		}
+		if hasRest {
+			absolute += ":" + rest
+		}
+		mounts[i] = absolute
+	}
+	return flag.Value.(interface{ Replace([]string) error }).Replace(mounts)
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectRoot(t *testing.T) {
+	home := t.TempDir()
+	repo := filepath.Join(home, "repo")
+	api := filepath.Join(repo, "services", "api")
+	require.NoError(t, os.MkdirAll(api, 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0755))
+
+	assert.Equal(t, repo, projectRoot(api, home))
+	assert.Equal(t, repo, projectRoot(repo, home))
+
+	// A sub-project configured on its own stays separate
+	require.NoError(t, os.WriteFile(filepath.Join(api, ".claude-reactor"), []byte("variant=go\n"), 0644))
+	assert.Equal(t, api, projectRoot(api, home))
+
+	// A repository in the home directory itself is not a project root
+	scratch := filepath.Join(home, "scratch")
+	require.NoError(t, os.Mkdir(scratch, 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(home, ".git"), 0755))
+	assert.Equal(t, scratch, projectRoot(scratch, home))
+}
+
+func TestProjectSubdir(t *testing.T) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	require.NoError(t, os.MkdirAll(filepath.Join("services", "api"), 0755))
+
+	subdir, err := projectSubdir("services/api/")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("services", "api"), subdir)
+
+	subdir, err = projectSubdir(".")
+	require.NoError(t, err)
+	assert.Equal(t, "", subdir)
+
+	_, err = projectSubdir("../elsewhere")
+	assert.Error(t, err)
+	_, err = projectSubdir("/etc")
+	assert.Error(t, err)
+	_, err = projectSubdir("missing")
+	assert.Error(t, err)
+
+	assert.Equal(t, "/app/services/api", containerWorkdir("/home/me/repo", filepath.Join("services", "api")))
+}
+
+func TestAbsoluteMountFlags(t *testing.T) {
+	cmd := NewRunCmd(createMockApp())
+	require.NoError(t, cmd.ParseFlags([]string{"-m", "data:/data:ro", "-m", "/srv/logs", "-m", "~/notes", "-m", `C:\Users\me:/win`}))
+	require.NoError(t, absoluteMountFlags(cmd))
+
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+	mounts, err := cmd.Flags().GetStringSlice("mount")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "data") + ":/data:ro", "/srv/logs", "~/notes", `C:\Users\me:/win`}, mounts)
+}
@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/worktree"
+	"claude-reactor/pkg"
+)
+
+// enterWorktree changes to the project in the worktree for branch, creating the worktree on
+// first use, so the session runs there as a project of its own
+func enterWorktree(app *pkg.AppContainer, branch string) error {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	root, err := worktree.MainRoot(projectDir)
+	if err != nil {
+		return fmt.Errorf("--branch needs a git repository: %w", err)
+	}
+	rel, err := worktree.Prefix(projectDir)
+	if err != nil {
+		return err
+	}
+
+	dir, created, err := worktree.Add(root, branch)
+	if err != nil {
+		return err
+	}
+	worktreeProject := filepath.Join(dir, rel)
+
+	// Run the session with the project's settings; an untracked sub-project is not checked out
+	if err := os.MkdirAll(worktreeProject, 0755); err != nil {
+		return fmt.Errorf("failed to create %s in the worktree: %w", rel, err)
+	}
+	config := filepath.Join(worktreeProject, worktree.ConfigFile)
+	if _, err := os.Stat(config); os.IsNotExist(err) {
+		if data, err := os.ReadFile(worktree.ConfigFile); err == nil {
+			if err := os.WriteFile(config, data, 0644); err != nil {
+				return fmt.Errorf("failed to copy project configuration to the worktree: %w", err)
+			}
+		}
+	}
+
+	if err := os.Chdir(worktreeProject); err != nil {
+		return fmt.Errorf("failed to change to worktree %s: %w", worktreeProject, err)
+	}
+	if created {
+		app.Logger.Infof("🌿 Created branch %s in worktree %s", branch, dir)
+	} else {
+		app.Logger.Infof("🌿 Continuing branch %s in worktree %s", branch, dir)
+	}
+	app.Logger.Infof("💡 Commit, push and remove the worktree with: claude-reactor session finish %s", branch)
+	return nil
+}
+
+func newSessionFinishCmd(app *pkg.AppContainer) *cobra.Command {
+	finishCmd := &cobra.Command{
+		Use:   "finish BRANCH",
+		Short: "Commit and push a 'run --branch' session and remove its worktree",
+		Long: `Finish a session started with 'claude-reactor run --branch BRANCH'.
+
+The session's container is removed, everything changed in its worktree is
+committed to the branch and pushed to origin, and the worktree is removed.
+The branch is kept, ready for a pull request. claude-reactor configuration
+and ignored files in the worktree are not committed.`,
+		Example: `# Work on a branch of its own, then hand it over for review
+claude-reactor run --branch feature/claude-login
+claude-reactor session finish feature/claude-login -m "Add login form"
+
+# Commit without pushing
+claude-reactor session finish feature/claude-login --no-push`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app == nil {
+				return cmd.Help()
+			}
+			return finishSession(cmd, app, args[0])
+		},
+	}
+
+	finishCmd.Flags().StringP("message", "m", "", "Commit message (default: \"Claude session on BRANCH\")")
+	finishCmd.Flags().Bool("no-push", false, "Commit without pushing the branch")
+
+	return finishCmd
+}
+
+// finishSession removes the container of the session in branch's worktree, commits and pushes
+// its changes, and removes the worktree
+func finishSession(cmd *cobra.Command, app *pkg.AppContainer, branch string) error {
+	ctx := cmd.Context()
+	message, _ := cmd.Flags().GetString("message")
+	noPush, _ := cmd.Flags().GetBool("no-push")
+	if message == "" {
+		message = "Claude session on " + branch
+	}
+
+	if err := reactor.EnsureDockerComponents(app); err != nil {
+		return fmt.Errorf("docker not available: %w", err)
+	}
+
+	// Find the project as run does, then its counterpart in the worktree
+	if _, err := enterProjectRoot(app, true); err != nil {
+		return err
+	}
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	root, err := worktree.MainRoot(projectDir)
+	if err != nil {
+		return err
+	}
+	rel, err := worktree.Prefix(projectDir)
+	if err != nil {
+		return err
+	}
+	dir := worktree.Path(root, branch)
+	if _, err := os.Stat(dir); err != nil {
+		return pkg.NewError(pkg.CodeWorktreeMissing, nil, "no worktree for branch %s (expected %s)", branch, dir).
+			WithRemediation("Start one with: claude-reactor run --branch " + branch)
+	}
+
+	if err := os.Chdir(filepath.Join(dir, rel)); err != nil {
+		return fmt.Errorf("failed to change to worktree: %w", err)
+	}
+	containerName, _, err := resolveProjectContainer(app)
+	if err != nil {
+		return err
+	}
+	app.Logger.Infof("🧹 Removing session container %s...", containerName)
+	if err := app.DockerMgr.CleanContainer(ctx, containerName); err != nil {
+		return fmt.Errorf("failed to remove session container: %w", err)
+	}
+	if err := os.Chdir(root); err != nil {
+		return fmt.Errorf("failed to change to repository: %w", err)
+	}
+
+	committed, err := worktree.Commit(dir, message)
+	if err != nil {
+		return err
+	}
+	if committed {
+		app.Logger.Infof("✅ Committed session changes to %s", branch)
+	} else {
+		app.Logger.Infof("No uncommitted changes in %s", branch)
+	}
+
+	if !noPush {
+		remote, err := worktree.Push(dir, branch)
+		if err != nil {
+			return pkg.NewError(pkg.CodeWorktreePush, err, "failed to push %s", branch).
+				WithRemediation(fmt.Sprintf("The worktree is kept at %s; push it yourself, then run: claude-reactor session finish %s --no-push", dir, branch))
+		}
+		if remote == "" {
+			app.Logger.Info("No origin remote to push to; the branch is kept locally")
+		} else {
+			app.Logger.Infof("⬆️  Pushed %s to %s", branch, remote)
+		}
+	}
+
+	if err := worktree.Remove(root, dir); err != nil {
+		return err
+	}
+	app.Logger.Infof("🌿 Removed worktree %s (branch %s is kept)", dir, branch)
+	return nil
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/worktree"
+)
+
+func TestEnterWorktree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(originalDir)
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	repo := filepath.Join(root, "repo")
+	api := filepath.Join(repo, "services", "api")
+	require.NoError(t, os.MkdirAll(api, 0755))
+	for _, args := range [][]string{{"init", "-q"}, {"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "initial"}} {
+		output, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(api, ".claude-reactor"), []byte("variant=go\n"), 0644))
+
+	// A sub-project runs in the same place in the worktree, with its configuration
+	require.NoError(t, os.Chdir(api))
+	require.NoError(t, enterWorktree(createMockApp(), "feature/api"))
+
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(worktree.Path(repo, "feature/api"), "services", "api"), dir)
+	config, err := os.ReadFile(".claude-reactor")
+	require.NoError(t, err)
+	assert.Equal(t, "variant=go\n", string(config))
+
+	require.NoError(t, os.Chdir(repo))
+	assert.Error(t, enterWorktree(createMockApp(), "bad..name"))
+
+	require.NoError(t, os.Chdir(t.TempDir()))
+	assert.Error(t, enterWorktree(createMockApp(), "feature/api"), "not a repository")
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/internal/reactor/wsl"
+	"claude-reactor/pkg"
+)
+
+// NewWSLCmd creates the wsl command for Windows Subsystem for Linux hosts
+func NewWSLCmd(app *pkg.AppContainer) *cobra.Command {
+	var wslCmd = &cobra.Command{
+		Use:   "wsl",
+		Short: "Inspect and tune WSL 2 hosts",
+		Long: `Helpers for running claude-reactor inside WSL 2 with Docker Desktop.
+
+Projects on Windows drives (/mnt/c/...) are shared into WSL over 9p, which makes
+file-heavy work such as builds and dependency installs much slower than on the
+distribution's own filesystem. 'wsl relocate' copies a project across.`,
+	}
+
+	wslCmd.AddCommand(newWSLStatusCmd(app), newWSLRelocateCmd(app))
+
+	return wslCmd
+}
+
+func newWSLStatusCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show WSL 2 and Docker Desktop integration status",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+
+			env := wsl.Detect()
+			if env == nil {
+				fmt.Fprintln(i18n.Stdout, "Not running under WSL 2")
+				return nil
+			}
+
+			fmt.Fprintf(i18n.Stdout, "Distribution:    %s\n", env.Distro)
+			if env.DockerDesktop {
+				fmt.Fprintln(i18n.Stdout, "Docker Desktop:  WSL integration enabled")
+			} else {
+				fmt.Fprintln(i18n.Stdout, "Docker Desktop:  WSL integration not detected")
+				fmt.Fprintln(i18n.Stdout, "💡 Enable it in Docker Desktop → Settings → Resources → WSL integration")
+			}
+
+			if dir, err := os.Getwd(); err == nil {
+				if wsl.OnWindowsDrive(dir) {
+					fmt.Fprintf(i18n.Stdout, "Project:         %s (Windows drive, slow)\n", dir)
+					fmt.Fprintln(i18n.Stdout, "💡 Move it to the WSL filesystem with: claude-reactor wsl relocate")
+				} else {
+					fmt.Fprintf(i18n.Stdout, "Project:         %s (WSL filesystem)\n", dir)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func newWSLRelocateCmd(app *pkg.AppContainer) *cobra.Command {
+	return &cobra.Command{
+		Use:   "relocate [destination]",
+		Short: "Copy the project from a Windows drive to the WSL filesystem",
+		Long: `Copy the current project from a Windows drive to the WSL filesystem, where
+containers can read and write it at native speed.
+
+The destination defaults to ~/projects/<project-name>. The original copy is left
+in place; remove it once you have switched over.`,
+		Example: `# Copy /mnt/c/Users/me/code/app to ~/projects/app
+claude-reactor wsl relocate
+
+# Choose the destination
+claude-reactor wsl relocate ~/src/app`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Handle help case when app is nil
+			if app == nil {
+				return cmd.Help()
+			}
+			return relocateProject(app, args)
+		},
+	}
+}
+
+// relocateProject copies the project off the Windows drive
+func relocateProject(app *pkg.AppContainer, args []string) error {
+	if wsl.Detect() == nil {
+		return fmt.Errorf("relocate is only needed under WSL 2")
+	}
+
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	if !wsl.OnWindowsDrive(projectDir) {
+		app.Logger.Infof("✅ %s is already on the WSL filesystem", projectDir)
+		return nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+	target := wsl.RelocationTarget(projectDir, homeDir)
+	if len(args) == 1 {
+		target, err = filepath.Abs(expandHome(args[0], homeDir))
+		if err != nil {
+			return fmt.Errorf("invalid destination: %w", err)
+		}
+	}
+
+	app.Logger.Infof("📦 Copying %s to %s...", projectDir, target)
+	if err := wsl.Relocate(projectDir, target); err != nil {
+		return fmt.Errorf("failed to relocate project: %w", err)
+	}
+
+	app.Logger.Infof("✅ Project copied to %s", target)
+	app.Logger.Infof("💡 Continue there with: cd %s && claude-reactor run", target)
+	return nil
+}
+
+// expandHome expands a leading ~ in path
+func expandHome(path, homeDir string) string {
+	if path == "~" {
+		return homeDir
+	}
+	if len(path) > 1 && path[:2] == "~/" {
+		return filepath.Join(homeDir, path[2:])
+	}
+	return path
+}
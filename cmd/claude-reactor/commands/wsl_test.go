@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWSLCmd(t *testing.T) {
+	t.Run("create wsl command", func(t *testing.T) {
+		app := createMockApp()
+		cmd := NewWSLCmd(app)
+
+		assert.Equal(t, "wsl", cmd.Use)
+		names := []string{}
+		for _, sub := range cmd.Commands() {
+			names = append(names, sub.Name())
+		}
+		assert.ElementsMatch(t, []string{"status", "relocate"}, names)
+	})
+
+	t.Run("subcommands with nil app show help", func(t *testing.T) {
+		cmd := NewWSLCmd(nil)
+		for _, sub := range cmd.Commands() {
+			assert.NoError(t, sub.RunE(sub, []string{}))
+		}
+	})
+}
+
+func TestExpandHome(t *testing.T) {
+	assert.Equal(t, "/home/me", expandHome("~", "/home/me"))
+	assert.Equal(t, "/home/me/src/app", expandHome("~/src/app", "/home/me"))
+	assert.Equal(t, "/opt/app", expandHome("/opt/app", "/home/me"))
+	assert.Equal(t, "~other/app", expandHome("~other/app", "/home/me"))
+}
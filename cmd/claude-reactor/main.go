@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strings"
@@ -11,7 +14,10 @@ import (
 
 	"claude-reactor/cmd/claude-reactor/commands"
 	"claude-reactor/internal/reactor"
+	"claude-reactor/internal/reactor/docker"
+	"claude-reactor/internal/reactor/i18n"
 	"claude-reactor/internal/reactor/logging"
+	"claude-reactor/internal/reactor/timing"
 	"claude-reactor/pkg"
 )
 
@@ -22,60 +28,132 @@ var (
 	BuildDate = "unknown"
 )
 
+// Errors are reported as JSON in CI mode, on stderr with the logs, and for commands run
+// with --json, on stdout where scripts read the result
+var (
+	jsonErrors  bool
+	errorOutput io.Writer = os.Stderr
+)
+
 func main() {
 	if err := Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		// Commands run inside the container report their own errors; just pass the exit code through
+		var exitErr *pkg.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+		printError(errorOutput, err, jsonErrors)
 		os.Exit(1)
 	}
 }
 
+// printError reports a failed command: as text, linking errors with a code to their
+// documentation, or as a JSON object with the code and remediation steps
+func printError(w io.Writer, err error, asJSON bool) {
+	if asJSON {
+		data, _ := json.Marshal(map[string]pkg.ErrorReport{"error": pkg.NewErrorReport(err)})
+		fmt.Fprintln(w, string(data))
+		return
+	}
+
+	fmt.Fprintln(w, i18n.T("error.prefix", err))
+	printRemediation(w, err)
+}
+
+// printRemediation follows the message of an error with a code with its remediation steps
+// and documentation link. Wrapping errors don't repeat the steps, so they are only shown here.
+func printRemediation(w io.Writer, err error) {
+	var coded *pkg.Error
+	if !errors.As(err, &coded) {
+		return
+	}
+	for _, step := range coded.Remediation {
+		fmt.Fprintln(w, i18n.T("error.hint", step))
+	}
+	fmt.Fprintln(w, i18n.T("error.docs", coded.Code, pkg.ErrorDocs(coded.Code)))
+}
+
 // Execute runs the root command
 func Execute() error {
 	ctx := context.Background()
 
-	// Special case: if user just wants help, create command without app initialization
-	for _, arg := range os.Args[1:] {
-		if arg == "--help" || arg == "-h" || arg == "help" {
-			rootCmd := newRootCmd(nil) // Create with nil app for help
-			return rootCmd.ExecuteContext(ctx)
-		}
-	}
-
-	// Parse flags to get debug/verbose/log-level for app initialization
+	// Parse flags to get debug/verbose/log-level and the language for app initialization
 	tempCmd := &cobra.Command{Use: "claude-reactor"}
 	tempCmd.PersistentFlags().Bool("debug", false, "Enable debug mode")
 	tempCmd.PersistentFlags().Bool("verbose", false, "Enable verbose output")
 	tempCmd.PersistentFlags().String("log-level", "info", "Set log level")
 	tempCmd.PersistentFlags().Bool("version", false, "Print version information")
+	tempCmd.PersistentFlags().Bool("ci", false, "Non-interactive CI mode")
+	tempCmd.PersistentFlags().String("chaos", "", "Inject simulated Docker failures")
+	tempCmd.PersistentFlags().String("lang", "", "Language of messages")
+	tempCmd.PersistentFlags().Bool("no-emoji", false, "Print output without emoji")
+	tempCmd.PersistentFlags().BoolP("help", "h", false, "Help")
 	tempCmd.SilenceErrors = true
 	tempCmd.SilenceUsage = true
 	// Ignore errors here as we might have other flags not defined in tempCmd
 	_ = tempCmd.ParseFlags(os.Args[1:])
+	timing.Mark("flags")
+
+	// Select the language before any command is built, so help is translated too
+	lang, _ := tempCmd.PersistentFlags().GetString("lang")
+	noEmoji, _ := tempCmd.PersistentFlags().GetBool("no-emoji")
+	if err := i18n.Setup(lang, noEmoji); err != nil {
+		return pkg.NewError(pkg.CodeInvalidUsage, nil, "%v", err)
+	}
+
+	// Special case: if user just wants help, create command without app initialization
+	for _, arg := range os.Args[1:] {
+		if arg == "--help" || arg == "-h" || arg == "help" {
+			rootCmd := newRootCmd(nil) // Create with nil app for help
+			return rootCmd.ExecuteContext(ctx)
+		}
+	}
 
 	debug, _ := tempCmd.PersistentFlags().GetBool("debug")
 	verbose, _ := tempCmd.PersistentFlags().GetBool("verbose")
 	logLevel, _ := tempCmd.PersistentFlags().GetString("log-level")
 
+	// The Docker manager reads chaos mode from the environment when it is created
+	if chaos, _ := tempCmd.PersistentFlags().GetString("chaos"); chaos != "" {
+		os.Setenv(docker.ChaosEnv, chaos)
+	}
+
 	// Initialize app upfront with parsed flags
 	app, err := reactor.NewAppContainer(debug, verbose, logLevel)
 	if err != nil {
 		return fmt.Errorf("failed to initialize application: %w", err)
 	}
 
-	// Create root command with initialized app
+	// Switch to machine-readable logs before any component logs, so CI output stays parseable
+	if ci, _ := tempCmd.PersistentFlags().GetBool("ci"); ci {
+		logging.EnableCIMode(app.Logger)
+		jsonErrors = true
+	}
+	if i18n.PlainOutput() {
+		logging.EnablePlainOutput(app.Logger)
+	}
+
+	// Create root command with initialized app; main reports its errors
 	rootCmd := newRootCmd(app)
-	return rootCmd.ExecuteContext(ctx)
+	rootCmd.SilenceErrors = true
+	cmd, err := rootCmd.ExecuteContextC(ctx)
+	if err != nil && cmd != nil {
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			jsonErrors = true
+			errorOutput = os.Stdout
+		}
+	}
+	return err
 }
 
 func newRootCmd(app *pkg.AppContainer) *cobra.Command {
 	var rootCmd = &cobra.Command{
 		Use:   "claude-reactor",
-		Short: "A simple, safe way to run Claude CLI in Docker containers with account isolation",
-		Long: `Claude-Reactor provides a secure way to run Claude CLI in Docker containers
-with proper account isolation. It offers multiple pre-built container variants
-for different development needs while maintaining security and simplicity.`,
+		Short: i18n.T("root.short"),
+		Long:  i18n.T("root.long"),
 		Version: fmt.Sprintf("%s (commit: %s, built: %s)", Version, GitCommit, BuildDate),
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			timing.Mark("dispatch")
 			if app != nil {
 				// Re-sync logger flags if they changed (e.g. from command line specific overrides)
 				debug, _ := cmd.Flags().GetBool("debug")
@@ -86,6 +164,9 @@ for different development needs while maintaining security and simplicity.`,
 				if debug != app.Debug {
 					app.Logger = logging.NewLoggerWithFlags(debug, verbose, logLevel)
 					app.Debug = debug
+					if i18n.PlainOutput() {
+						logging.EnablePlainOutput(app.Logger)
+					}
 					// Update config manager logger
 					if app.ConfigMgr != nil {
 						// We can't easily swap logger in existing manager without interface change/method
@@ -98,19 +179,19 @@ for different development needs while maintaining security and simplicity.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// Handle deprecated flags with clear migration guidance
 			if listVariants, _ := cmd.Flags().GetBool("list-variants"); listVariants {
-				fmt.Fprintf(os.Stderr, "❌ The --list-variants flag has been removed. Use:\n")
+				fmt.Fprintln(os.Stderr, i18n.T("removed.list_variants"))
 				fmt.Fprintf(os.Stderr, "   claude-reactor info\n")
 				os.Exit(1)
 			}
 
 			if variant, _ := cmd.Flags().GetString("variant"); variant != "" {
-				fmt.Fprintf(os.Stderr, "❌ The --variant flag has been removed. Use:\n")
+				fmt.Fprintln(os.Stderr, i18n.T("removed.variant"))
 				fmt.Fprintf(os.Stderr, "   claude-reactor run --image %s\n", variant)
 				os.Exit(1)
 			}
 
 			if showConfig, _ := cmd.Flags().GetBool("show-config"); showConfig {
-				fmt.Fprintf(os.Stderr, "❌ The --show-config flag has been removed. Use:\n")
+				fmt.Fprintln(os.Stderr, i18n.T("removed.show_config"))
 				fmt.Fprintf(os.Stderr, "   claude-reactor config show\n")
 				os.Exit(1)
 			}
@@ -120,10 +201,11 @@ for different development needs while maintaining security and simplicity.`,
 				config, err := app.ConfigMgr.LoadConfig()
 				if err == nil && (config.Variant != "" || config.Account != "") {
 					// Configuration exists, default to run command
-					app.Logger.Info("🚀 Found existing configuration, running container...")
+					app.Logger.Info(i18n.T("run.existing_config"))
 					runCmd := commands.NewRunCmd(app)
 					if runErr := runCmd.RunE(cmd, args); runErr != nil {
-						cmd.PrintErrf("Run failed: %v\n", runErr)
+						cmd.PrintErrln(i18n.T("run.failed", runErr))
+						printRemediation(cmd.ErrOrStderr(), runErr)
 						os.Exit(1)
 					}
 					return
@@ -136,9 +218,15 @@ for different development needs while maintaining security and simplicity.`,
 	}
 
 	// Global flags
-	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Enable debug mode")
-	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
-	rootCmd.PersistentFlags().String("log-level", "info", "Set log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().BoolP("debug", "d", false, i18n.T("flag.debug"))
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, i18n.T("flag.verbose"))
+	rootCmd.PersistentFlags().String("log-level", "info", i18n.T("flag.log_level"))
+	rootCmd.PersistentFlags().String("lang", "", i18n.T("flag.lang"))
+	rootCmd.PersistentFlags().Bool("no-emoji", false, i18n.T("flag.no_emoji"))
+
+	// Developer flag for exercising error handling (hidden)
+	rootCmd.PersistentFlags().String("chaos", "", "Inject simulated Docker failures: pull-timeout, create-conflict, exec-hang, each optionally FAULT:N, or all")
+	rootCmd.PersistentFlags().MarkHidden("chaos")
 
 	// Deprecated flags (hidden, show clear migration error)
 	rootCmd.Flags().Bool("list-variants", false, "Removed: use 'debug info'")
@@ -151,29 +239,60 @@ for different development needs while maintaining security and simplicity.`,
 	// Version command
 	var versionCmd = &cobra.Command{
 		Use:   "version",
-		Short: "Print version information",
+		Short: i18n.T("version.short"),
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("claude-reactor version %s\n", Version)
-			fmt.Printf("Git commit: %s\n", GitCommit)
-			fmt.Printf("Build date: %s\n", BuildDate)
-			fmt.Printf("Go version: %s\n", runtime.Version())
-			fmt.Printf("OS/Arch: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+			fmt.Println(i18n.T("version.version", Version))
+			fmt.Println(i18n.T("version.git_commit", GitCommit))
+			fmt.Println(i18n.T("version.build_date", BuildDate))
+			fmt.Println(i18n.T("version.go_version", runtime.Version()))
+			fmt.Println(i18n.T("version.os_arch", runtime.GOOS, runtime.GOARCH))
 		},
 	}
 	rootCmd.AddCommand(versionCmd)
 
 	// Set version information for commands that need it
 	commands.SetVersionInfo(Version, GitCommit, BuildDate)
+	docker.SetVersion(Version)
 
 	// Add subcommands from commands package
 	rootCmd.AddCommand(
 		commands.NewRunCmd(app),
+		commands.NewBuildCmd(app),
+		commands.NewImagesCmd(app),
 		commands.NewConfigCmd(app),
 		commands.NewCleanCmd(app),
+		commands.NewGCCmd(app),
+		commands.NewDuCmd(app),
+		commands.NewUpgradeClaudeCmd(app),
 		commands.NewInfoCmd(app),
 		commands.NewListCmd(app),
 		commands.NewCompletionCmd(app),
+		commands.NewForwardCmd(app),
+		commands.NewConversationCmd(app),
+		commands.NewMCPCmd(app),
+		commands.NewOpenCmd(app),
+		commands.NewPromptCmd(app),
+		commands.NewExecCmd(app),
+		commands.NewTaskCmd(app),
+		commands.NewSecretsCmd(app),
+		commands.NewBatchCmd(app),
+		commands.NewCICmd(app),
+		commands.NewServeCmd(app),
+		commands.NewSessionCmd(app),
+		commands.NewSandboxCmd(app),
+		commands.NewRestoreCmd(app),
+		commands.NewPrewarmCmd(app),
+		commands.NewWSLCmd(app),
+		commands.NewPluginCmd(app),
+		commands.NewExplainCmd(app),
+		commands.NewStatsCmd(app),
+		commands.NewAdviseCmd(app),
 	)
+	timing.Mark("commands")
+
+	// External claude-reactor-<name> executables on PATH, added last so built-ins take precedence
+	commands.AddPluginCommands(rootCmd, app)
+	timing.Mark("plugins")
 
 	return rootCmd
 }
@@ -237,15 +356,19 @@ func showEnhancedConfig(cmd *cobra.Command, app *pkg.AppContainer) error {
 		cmd.Printf("  Docker Platform: %s\n", platform)
 		cmd.Printf("  Multi-arch Support: %t\n", app.ArchDetector.IsMultiArchSupported())
 
-		// Container naming
-		containerName := app.DockerMgr.GenerateContainerName("", config.Variant, arch, config.Account)
-		cmd.Printf("  Container Name: %s\n", containerName)
+		// Container naming (the Docker manager is created on demand)
+		if err := reactor.EnsureDockerComponents(app); err != nil {
+			cmd.Printf("  Container Name: unavailable (Docker not connected)\n")
+		} else {
+			containerName := app.DockerMgr.GenerateContainerName("", config.Variant, arch, config.Account)
+			cmd.Printf("  Container Name: %s\n", containerName)
 
-		projectHash := app.DockerMgr.GenerateProjectHash("")
-		cmd.Printf("  Project Hash: %s\n", projectHash)
+			projectHash := app.DockerMgr.GenerateProjectHash("")
+			cmd.Printf("  Project Hash: %s\n", projectHash)
 
-		imageName := app.DockerMgr.GetImageName(config.Variant, arch)
-		cmd.Printf("  Image Name: %s\n", imageName)
+			imageName := app.DockerMgr.GetImageName(config.Variant, arch)
+			cmd.Printf("  Image Name: %s\n", imageName)
+		}
 
 		// Authentication paths
 		authPath := app.AuthMgr.GetAccountConfigPath(config.Account)
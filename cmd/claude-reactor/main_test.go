@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -9,8 +10,10 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"claude-reactor/cmd/claude-reactor/commands"
+	"claude-reactor/internal/reactor/i18n"
 	"claude-reactor/pkg"
 	"claude-reactor/pkg/mocks"
 )
@@ -357,3 +360,44 @@ func TestGetCurrentDir(t *testing.T) {
 		// We can't assert the exact path since it depends on where tests are run
 	})
 }
+
+func TestPrintError(t *testing.T) {
+	coded := fmt.Errorf("failed to start: %w", pkg.NewError(pkg.CodeContainerNotRunning, nil, "container %s is not running", "claude-reactor-base"))
+
+	t.Setenv("LC_ALL", "en_US.UTF-8")
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv(i18n.NoEmojiEnv, "")
+	t.Cleanup(func() { _ = i18n.Setup("", false) })
+
+	t.Run("text links coded errors to their docs", func(t *testing.T) {
+		require.NoError(t, i18n.Setup("", false))
+		var out bytes.Buffer
+		printError(&out, coded, false)
+		assert.Equal(t, "Error: failed to start: container claude-reactor-base is not running\n"+
+			"💡 Start it with: claude-reactor run\n"+
+			"📖 CR-CONTAINER-001: "+pkg.ErrorDocsURL+"#cr-container-001\n", out.String())
+
+		out.Reset()
+		printError(&out, fmt.Errorf("boom"), false)
+		assert.Equal(t, "Error: boom\n", out.String())
+	})
+
+	t.Run("japanese without emoji", func(t *testing.T) {
+		require.NoError(t, i18n.Setup("ja", true))
+		var out bytes.Buffer
+		printError(&out, coded, false)
+		assert.Equal(t, "エラー: failed to start: container claude-reactor-base is not running\n"+
+			"Hint: Start it with: claude-reactor run\n"+
+			"Docs: CR-CONTAINER-001: "+pkg.ErrorDocsURL+"#cr-container-001\n", out.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		printError(&out, coded, true)
+		var report map[string]pkg.ErrorReport
+		assert.NoError(t, json.Unmarshal(out.Bytes(), &report))
+		assert.Equal(t, pkg.CodeContainerNotRunning, report["error"].Code)
+		assert.Equal(t, "failed to start: container claude-reactor-base is not running", report["error"].Message)
+		assert.Equal(t, []string{"Start it with: claude-reactor run"}, report["error"].Remediation)
+	})
+}
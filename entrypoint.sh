@@ -58,6 +58,6 @@ echo "entrypoint: Executing main container command: $@"
 main_pid=$!
 
 # Wait for the main command's process to exit.
-# This keeps the entrypoint script alive as the main container process (PID 1),
+# This keeps the entrypoint script alive under claude-reactor-init (PID 1),
 # preventing the socat child process from being orphaned.
 wait $main_pid
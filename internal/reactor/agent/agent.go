@@ -0,0 +1,140 @@
+// Package agent connects tools inside a container to the claude-reactor CLI on the host.
+//
+// The claude-reactor-agent binary in the container listens on a unix socket. While a session
+// is attached, the host CLI runs 'claude-reactor-agent bridge' over docker exec and answers
+// the JSON-RPC 2.0 requests relayed through it, one JSON message per line. The package only
+// uses the standard library, so the agent builds without the CLI's dependencies.
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// BinaryPath is where images install the agent
+const BinaryPath = "/usr/local/bin/claude-reactor-agent"
+
+// SocketPath is where the agent listens inside the container
+const SocketPath = "/tmp/claude-reactor-agent.sock"
+
+// SocketEnv overrides SocketPath for agent clients and the bridge
+const SocketEnv = "CLAUDE_REACTOR_AGENT_SOCKET"
+
+// Methods answered by the host
+const (
+	MethodNotify        = "notify"
+	MethodForward       = "forward"
+	MethodClipboardRead = "clipboard.read"
+	MethodStatus        = "status"
+)
+
+// maxMessageSize bounds a single message line
+const maxMessageSize = 4 * 1024 * 1024
+
+// NotifyParams asks the host to show a desktop notification
+type NotifyParams struct {
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message"`
+}
+
+// ForwardParams asks the host to forward a host port to a container port. HostPort defaults
+// to ContainerPort.
+type ForwardParams struct {
+	ContainerPort int `json:"container_port"`
+	HostPort      int `json:"host_port,omitempty"`
+}
+
+// ForwardResult is the host address now forwarded into the container
+type ForwardResult struct {
+	Address string `json:"address"`
+}
+
+// ClipboardResult holds the host clipboard contents
+type ClipboardResult struct {
+	Text string `json:"text"`
+}
+
+// Status is what the container last reported about its work, e.g. "waiting" for input
+type Status struct {
+	State   string    `json:"state"`
+	Message string    `json:"message,omitempty"`
+	Time    time.Time `json:"time,omitempty"`
+}
+
+// message is a JSON-RPC 2.0 request or response as relayed by the bridge
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object returned by the host
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Socket returns the agent socket path, honouring SocketEnv
+func Socket() string {
+	if path := os.Getenv(SocketEnv); path != "" {
+		return path
+	}
+	return SocketPath
+}
+
+// Call sends one request to the agent socket and decodes the host's result into result,
+// which may be nil
+func Call(socketPath, method string, params, result interface{}) error {
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no claude-reactor session is attached to this container")
+		}
+		return fmt.Errorf("failed to connect to %s: %w", socketPath, err)
+	}
+	defer conn.Close()
+
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode params: %w", err)
+	}
+	req := message{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: method, Params: encoded}
+	if err := json.NewEncoder(conn).Encode(&req); err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+		return fmt.Errorf("the host disconnected before answering")
+	}
+
+	var resp message
+	if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result != nil && len(resp.Result) > 0 {
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return fmt.Errorf("invalid result: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// client is a connection to the agent socket; writes come from both the client's reader and
+// the response router
+type client struct {
+	conn net.Conn
+	mu   sync.Mutex
+}
+
+func (c *client) send(msg *message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	json.NewEncoder(c.conn).Encode(msg)
+}
+
+// pendingCall remembers who asked a relayed request and under which ID
+type pendingCall struct {
+	client *client
+	id     json.RawMessage
+}
+
+// Bridge relays requests from clients on the agent socket to the host and routes the host's
+// responses back. Request IDs are renumbered so requests from different clients cannot collide.
+type Bridge struct {
+	writeMu sync.Mutex
+	out     *json.Encoder
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]pendingCall
+}
+
+// NewBridge creates a bridge that sends requests to the host on out
+func NewBridge(out io.Writer) *Bridge {
+	return &Bridge{out: json.NewEncoder(out), pending: make(map[int64]pendingCall)}
+}
+
+// Listen creates the agent socket, replacing a stale one left by a bridge that did not shut
+// down cleanly. Only one bridge can serve a container at a time.
+func Listen(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another claude-reactor session is already connected on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// Run relays between clients accepted on listener and the host, reading host responses from
+// in, until the host disconnects or ctx is cancelled
+func (b *Bridge) Run(ctx context.Context, listener net.Listener, in io.Reader) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	// The host going away ends the bridge
+	go func() {
+		defer cancel()
+		b.routeResponses(in)
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.serveClient(ctx, &client{conn: conn})
+		}()
+	}
+}
+
+// serveClient relays one client's requests until it disconnects
+func (b *Bridge) serveClient(ctx context.Context, c *client) {
+	defer c.conn.Close()
+	go func() {
+		<-ctx.Done()
+		c.conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			c.send(&message{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &Error{Code: -32700, Message: "invalid JSON"}})
+			continue
+		}
+
+		// Notifications carry no ID and get no response
+		if len(msg.ID) > 0 {
+			id := b.track(c, msg.ID)
+			msg.ID = json.RawMessage(strconv.FormatInt(id, 10))
+		}
+		if err := b.send(&msg); err != nil {
+			return
+		}
+	}
+}
+
+// track records a request from a client and returns the ID it is relayed under
+func (b *Bridge) track(c *client, id json.RawMessage) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	b.pending[b.nextID] = pendingCall{client: c, id: id}
+	return b.nextID
+}
+
+// send writes one message to the host
+func (b *Bridge) send(msg *message) error {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+	return b.out.Encode(msg)
+}
+
+// routeResponses returns each host response to the client that asked, under its original ID
+func (b *Bridge) routeResponses(in io.Reader) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+		id, err := strconv.ParseInt(string(msg.ID), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		b.mu.Lock()
+		call, ok := b.pending[id]
+		delete(b.pending, id)
+		b.mu.Unlock()
+		if !ok {
+			continue
+		}
+		msg.ID = call.id
+		call.client.send(&msg)
+	}
+}
@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startBridge runs a bridge whose host echoes each request's method and params back as the
+// result, or fails the "fail" method
+func startBridge(t *testing.T) string {
+	dir, err := os.MkdirTemp("", "agent")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "agent.sock")
+
+	listener, err := Listen(socket)
+	require.NoError(t, err)
+
+	toHost, bridgeOut := io.Pipe()
+	bridgeIn, fromHost := io.Pipe()
+	go func() {
+		scanner := bufio.NewScanner(toHost)
+		for scanner.Scan() {
+			var req message
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &req))
+			resp := message{JSONRPC: "2.0", ID: req.ID}
+			if req.Method == "fail" {
+				resp.Error = &Error{Code: -32000, Message: "host failed"}
+			} else {
+				resp.Result, _ = json.Marshal(map[string]interface{}{"method": req.Method, "params": req.Params})
+			}
+			json.NewEncoder(fromHost).Encode(&resp)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewBridge(bridgeOut).Run(ctx, listener, bridgeIn)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		fromHost.Close()
+		<-done
+	})
+	return socket
+}
+
+func TestBridge_RelaysCalls(t *testing.T) {
+	socket := startBridge(t)
+
+	var result struct {
+		Method string       `json:"method"`
+		Params NotifyParams `json:"params"`
+	}
+	require.NoError(t, Call(socket, MethodNotify, NotifyParams{Title: "t", Message: "done"}, &result))
+	assert.Equal(t, MethodNotify, result.Method)
+	assert.Equal(t, "done", result.Params.Message)
+
+	err := Call(socket, "fail", struct{}{}, nil)
+	assert.EqualError(t, err, "host failed")
+}
+
+func TestBridge_ConcurrentClientsGetTheirOwnResponses(t *testing.T) {
+	socket := startBridge(t)
+
+	// Every client uses request ID 1; the bridge must not mix up their responses
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func(port int) {
+			defer wg.Done()
+			var result struct {
+				Params ForwardParams `json:"params"`
+			}
+			if assert.NoError(t, Call(socket, MethodForward, ForwardParams{ContainerPort: port}, &result)) {
+				assert.Equal(t, port, result.Params.ContainerPort)
+			}
+		}(3000 + i)
+	}
+	wg.Wait()
+}
+
+func TestListen_RejectsSecondBridge(t *testing.T) {
+	socket := startBridge(t)
+
+	_, err := Listen(socket)
+	assert.ErrorContains(t, err, "already connected")
+}
+
+func TestCall_WithoutBridge(t *testing.T) {
+	err := Call(filepath.Join(t.TempDir(), "missing.sock"), MethodStatus, Status{State: "idle"}, nil)
+	assert.ErrorContains(t, err, "no claude-reactor session is attached")
+}
+
+func TestSocket(t *testing.T) {
+	t.Setenv(SocketEnv, "")
+	assert.Equal(t, SocketPath, Socket())
+
+	t.Setenv(SocketEnv, "/run/agent.sock")
+	assert.Equal(t, "/run/agent.sock", Socket())
+}
@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MethodMCPConnect asks the host to start an MCP server and connect it to a socket
+const MethodMCPConnect = "mcp.connect"
+
+// mcpConnectTimeout bounds how long a stub waits for the host to start the server
+const mcpConnectTimeout = 2 * time.Minute
+
+// MCPConnectParams asks the host to start the MCP server Name, in a sidecar or on the host,
+// and connect its stdin and stdout to Socket in the container
+type MCPConnectParams struct {
+	Name   string `json:"name"`
+	Socket string `json:"socket"`
+}
+
+// ConnectMCP stands in for the stdio MCP server name: the host starts the server and
+// connects it back to this process, which relays in and out until either side closes.
+// Claude CLI runs it as the server's command.
+func ConnectMCP(name string, in io.Reader, out io.Writer) error {
+	dir, err := os.MkdirTemp("", "claude-reactor-mcp-")
+	if err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "mcp.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	defer listener.Close()
+
+	if err := Call(Socket(), MethodMCPConnect, MCPConnectParams{Name: name, Socket: path}, nil); err != nil {
+		return err
+	}
+
+	listener.(*net.UnixListener).SetDeadline(time.Now().Add(mcpConnectTimeout))
+	conn, err := listener.Accept()
+	if err != nil {
+		return fmt.Errorf("MCP server %s did not connect: %w", name, err)
+	}
+	return relay(conn.(*net.UnixConn), in, out)
+}
+
+// Pipe connects in and out to the unix socket at path. The host runs it to reach a waiting
+// ConnectMCP.
+func Pipe(path string, in io.Reader, out io.Writer) error {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", path, err)
+	}
+	return relay(conn.(*net.UnixConn), in, out)
+}
+
+// relay copies in to conn and conn to out. The end of in is passed on as a half-close, so the
+// other side sees EOF and can finish writing.
+func relay(conn *net.UnixConn, in io.Reader, out io.Writer) error {
+	defer conn.Close()
+	go func() {
+		io.Copy(conn, in)
+		conn.CloseWrite()
+	}()
+	_, err := io.Copy(out, conn)
+	return err
+}
@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startMCPHost answers mcp.connect like the host: it connects an upper-casing "server" to the
+// stub's socket with Pipe
+func startMCPHost(t *testing.T) {
+	dir, err := os.MkdirTemp("", "agent")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "agent.sock")
+	t.Setenv(SocketEnv, socket)
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var req message
+		scanner := bufio.NewScanner(conn)
+		if !scanner.Scan() || json.Unmarshal(scanner.Bytes(), &req) != nil {
+			return
+		}
+		var params MCPConnectParams
+		json.Unmarshal(req.Params, &params)
+		json.NewEncoder(conn).Encode(&message{JSONRPC: "2.0", ID: req.ID, Result: json.RawMessage("null")})
+
+		// The server reads requests until EOF and answers each line in upper case
+		serverIn, toServer := io.Pipe()
+		fromServer, serverOut := io.Pipe()
+		go func() {
+			lines := bufio.NewScanner(serverIn)
+			for lines.Scan() {
+				io.WriteString(serverOut, strings.ToUpper(lines.Text())+"\n")
+			}
+			serverOut.Close()
+		}()
+		Pipe(params.Socket, fromServer, toServer)
+		toServer.Close()
+	}()
+}
+
+func TestConnectMCP(t *testing.T) {
+	startMCPHost(t)
+
+	var out bytes.Buffer
+	err := ConnectMCP("github", strings.NewReader("{\"method\":\"initialize\"}\nping\n"), &out)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"METHOD\":\"INITIALIZE\"}\nPING\n", out.String())
+}
+
+func TestConnectMCPWithoutSession(t *testing.T) {
+	t.Setenv(SocketEnv, filepath.Join(t.TempDir(), "missing.sock"))
+
+	err := ConnectMCP("github", strings.NewReader(""), io.Discard)
+	assert.ErrorContains(t, err, "no claude-reactor session is attached")
+}
@@ -0,0 +1,87 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Methods reporting tool usage to the host, for 'claude-reactor advise'
+const (
+	MethodToolsUsed   = "tools.used"
+	MethodToolMissing = "tools.missing"
+)
+
+// ToolReportInterval is how often the bridge reports the tools run in the container
+const ToolReportInterval = time.Minute
+
+// ToolDirs are the directories whose programs are counted when run, as filepath.Glob patterns.
+// They cover the tools of the built-in variants.
+var ToolDirs = []string{
+	"/bin", "/usr/bin", "/usr/local/bin", "/usr/local/go/bin", "/root/.cargo/bin",
+	"/usr/local/nvm/versions/node/*/bin", "/usr/lib/jvm/*/bin", "/usr/local/aws-cli/v2/current/bin",
+	"/usr/lib/google-cloud-sdk/bin", "/opt/az/bin",
+}
+
+// ToolsUsedParams reports how many times each tool was run since the last report
+type ToolsUsedParams struct {
+	Tools map[string]int `json:"tools"`
+}
+
+// ToolMissingParams reports a command the shell could not find
+type ToolMissingParams struct {
+	Name string `json:"name"`
+}
+
+// Notify sends a notification, which gets no response, to the host
+func (b *Bridge) Notify(method string, params interface{}) error {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode params: %w", err)
+	}
+	return b.send(&message{JSONRPC: "2.0", Method: method, Params: encoded})
+}
+
+// WatchTools counts the programs run from the directories matching patterns until ctx ends,
+// passing the counts gathered to report every interval. Intervals without runs are not
+// reported.
+func WatchTools(ctx context.Context, patterns []string, interval time.Duration, report func(map[string]int)) error {
+	var dirs []string
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(pattern)
+		dirs = append(dirs, matches...)
+	}
+
+	var mu sync.Mutex
+	counts := map[string]int{}
+	flush := func() {
+		mu.Lock()
+		gathered := counts
+		counts = map[string]int{}
+		mu.Unlock()
+		if len(gathered) > 0 {
+			report(gathered)
+		}
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				flush()
+			}
+		}
+	}()
+
+	return watchExecs(ctx, dirs, func(name string) {
+		mu.Lock()
+		counts[name]++
+		mu.Unlock()
+	})
+}
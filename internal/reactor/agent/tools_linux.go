@@ -0,0 +1,62 @@
+//go:build linux
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// watchExecs calls found with the name of each program opened, which includes every program
+// run, in dirs until ctx ends
+func watchExecs(ctx context.Context, dirs []string, found func(name string)) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return fmt.Errorf("failed to start watching tools: %w", err)
+	}
+	// A non-blocking descriptor is served by the runtime poller, so closing it ends the read below
+	events := os.NewFile(uintptr(fd), "inotify")
+	defer events.Close()
+
+	watched := 0
+	for _, dir := range dirs {
+		if _, err := syscall.InotifyAddWatch(fd, dir, syscall.IN_OPEN); err == nil {
+			watched++
+		}
+	}
+	if watched == 0 {
+		return fmt.Errorf("none of the tool directories can be watched")
+	}
+
+	go func() {
+		<-ctx.Done()
+		events.Close()
+	}()
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := events.Read(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, os.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to read tool events: %w", err)
+		}
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= n; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			start := offset + syscall.SizeofInotifyEvent
+			offset = start + int(event.Len)
+			if event.Mask&syscall.IN_ISDIR != 0 || event.Len == 0 {
+				continue
+			}
+			if name := strings.TrimRight(string(buf[start:offset]), "\x00"); name != "" {
+				found(name)
+			}
+		}
+	}
+}
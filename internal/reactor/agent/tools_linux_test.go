@@ -0,0 +1,52 @@
+//go:build linux
+
+package agent
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchTools(t *testing.T) {
+	dir := t.TempDir()
+	tool := filepath.Join(dir, "mytool")
+	require.NoError(t, os.WriteFile(tool, []byte("#!/bin/sh\nexit 0\n"), 0755))
+
+	var mu sync.Mutex
+	reported := map[string]int{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- WatchTools(ctx, []string{dir, filepath.Join(dir, "missing-*")}, 10*time.Millisecond, func(tools map[string]int) {
+			mu.Lock()
+			defer mu.Unlock()
+			for name, count := range tools {
+				reported[name] += count
+			}
+		})
+	}()
+
+	// Runs before the watch starts go unseen, so keep running the tool until one is reported
+	assert.Eventually(t, func() bool {
+		require.NoError(t, exec.Command(tool).Run())
+		mu.Lock()
+		defer mu.Unlock()
+		return reported["mytool"] > 0
+	}, 5*time.Second, 20*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("WatchTools did not stop when its context ended")
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package agent
+
+import (
+	"context"
+	"fmt"
+)
+
+// watchExecs is only supported in Linux containers
+func watchExecs(ctx context.Context, dirs []string, found func(name string)) error {
+	return fmt.Errorf("tool usage is only tracked on Linux")
+}
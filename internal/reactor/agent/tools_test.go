@@ -0,0 +1,29 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridge_Notify(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, NewBridge(&out).Notify(MethodToolsUsed, ToolsUsedParams{Tools: map[string]int{"go": 2}}))
+
+	var msg message
+	require.NoError(t, json.Unmarshal(out.Bytes(), &msg))
+	assert.Equal(t, "2.0", msg.JSONRPC)
+	assert.Equal(t, MethodToolsUsed, msg.Method)
+	// Notifications carry no ID, so the host sends no response
+	assert.Empty(t, msg.ID)
+	assert.JSONEq(t, `{"tools":{"go":2}}`, string(msg.Params))
+}
+
+func TestWatchTools_NoDirectories(t *testing.T) {
+	err := WatchTools(context.Background(), []string{"/nonexistent-*"}, ToolReportInterval, func(map[string]int) {})
+	assert.Error(t, err)
+}
@@ -4,13 +4,22 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"sync"
 
 	"claude-reactor/pkg"
 )
 
-// detector implements the ArchitectureDetector interface
+// detector implements the ArchitectureDetector interface. Results are cached, since
+// the host never changes during a run and detection is asked for repeatedly.
 type detector struct {
 	logger pkg.Logger
+
+	archOnce     sync.Once
+	arch         string
+	archErr      error
+	platformOnce sync.Once
+	platform     string
+	platformErr  error
 }
 
 // NewDetector creates a new architecture detector
@@ -23,6 +32,13 @@ func NewDetector(logger pkg.Logger) pkg.ArchitectureDetector {
 // GetHostArchitecture returns the host system architecture
 // Replicates the detect_architecture() bash function
 func (d *detector) GetHostArchitecture() (string, error) {
+	d.archOnce.Do(func() {
+		d.arch, d.archErr = d.detectHostArchitecture()
+	})
+	return d.arch, d.archErr
+}
+
+func (d *detector) detectHostArchitecture() (string, error) {
 	arch := runtime.GOARCH
 	
 	d.logger.Debugf("Detected raw architecture: %s", arch)
@@ -44,6 +60,13 @@ func (d *detector) GetHostArchitecture() (string, error) {
 // GetDockerPlatform returns the Docker platform format
 // Replicates the get_docker_platform() bash function
 func (d *detector) GetDockerPlatform() (string, error) {
+	d.platformOnce.Do(func() {
+		d.platform, d.platformErr = d.detectDockerPlatform()
+	})
+	return d.platform, d.platformErr
+}
+
+func (d *detector) detectDockerPlatform() (string, error) {
 	arch, err := d.GetHostArchitecture()
 	if err != nil {
 		return "", fmt.Errorf("failed to detect host architecture: %w", err)
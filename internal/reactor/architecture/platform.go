@@ -0,0 +1,132 @@
+package architecture
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"claude-reactor/pkg"
+)
+
+// platformArchitectures maps supported Docker platforms to the architecture names used in
+// image and container names
+var platformArchitectures = map[string]string{
+	"linux/amd64":  "amd64",
+	"linux/arm64":  "arm64",
+	"linux/386":    "i386",
+	"linux/arm/v7": "arm",
+}
+
+// qemuBinfmt maps architectures to the binfmt_misc handler QEMU registers for them
+var qemuBinfmt = map[string]string{
+	"amd64": "qemu-x86_64",
+	"arm64": "qemu-aarch64",
+	"i386":  "qemu-i386",
+	"arm":   "qemu-arm",
+}
+
+// Emulation is how a container for a foreign platform is run
+type Emulation string
+
+const (
+	EmulationNone    Emulation = ""        // native platform
+	EmulationRosetta Emulation = "rosetta" // Docker Desktop on Apple Silicon with Rosetta enabled
+	EmulationQEMU    Emulation = "qemu"    // QEMU user-mode emulation via binfmt_misc
+)
+
+// ValidatePlatform checks that platform is one images can be built and run for
+func ValidatePlatform(platform string) error {
+	if _, ok := platformArchitectures[platform]; !ok {
+		return fmt.Errorf("unsupported platform '%s' (supported: linux/amd64, linux/arm64, linux/386, linux/arm/v7)", platform)
+	}
+	return nil
+}
+
+// Platforms lists the supported Docker platforms in a stable order
+func Platforms() []string {
+	platforms := make([]string, 0, len(platformArchitectures))
+	for platform := range platformArchitectures {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+	return platforms
+}
+
+// PlatformArch returns the architecture name for a Docker platform, e.g. amd64 for linux/amd64
+func PlatformArch(platform string) string {
+	return platformArchitectures[platform]
+}
+
+// DetectEmulation reports how a container for platform would run on this host. It returns
+// an error when the platform is foreign and no emulator is available.
+func DetectEmulation(hostPlatform, platform string) (Emulation, error) {
+	if platform == hostPlatform {
+		return EmulationNone, nil
+	}
+	arch := PlatformArch(platform)
+
+	switch runtime.GOOS {
+	case "darwin":
+		// Docker Desktop always ships QEMU; Rosetta is faster for amd64 when enabled
+		if arch == "amd64" && runtime.GOARCH == "arm64" && dockerDesktopRosettaEnabled() {
+			return EmulationRosetta, nil
+		}
+		return EmulationQEMU, nil
+	case "linux":
+		if _, err := os.Stat(filepath.Join("/proc/sys/fs/binfmt_misc", qemuBinfmt[arch])); err == nil {
+			return EmulationQEMU, nil
+		}
+		return EmulationNone, pkg.NewError(pkg.CodeEmulatorMissing, nil, "no emulator registered for %s", platform).
+			WithRemediation("Install QEMU handlers with: docker run --privileged --rm tonistiigi/binfmt --install " + strings.TrimPrefix(platform, "linux/"))
+	default:
+		return EmulationQEMU, nil
+	}
+}
+
+// EmulationWarning describes the performance cost of running platform under emulation
+func EmulationWarning(platform string, emulation Emulation) string {
+	switch emulation {
+	case EmulationRosetta:
+		return fmt.Sprintf("Running %s under Rosetta: expect somewhat slower builds and startup", platform)
+	case EmulationQEMU:
+		return fmt.Sprintf("Running %s under QEMU emulation: expect commands to be several times slower", platform)
+	default:
+		return ""
+	}
+}
+
+// dockerDesktopRosettaEnabled reads Docker Desktop's settings for the "Use Rosetta for
+// x86_64/amd64 emulation" option
+func dockerDesktopRosettaEnabled() bool {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return false
+	}
+	settingsDir := filepath.Join(homeDir, "Library", "Group Containers", "group.com.docker")
+	for _, name := range []string{"settings-store.json", "settings.json"} {
+		if data, err := os.ReadFile(filepath.Join(settingsDir, name)); err == nil {
+			return rosettaEnabled(data)
+		}
+	}
+	return false
+}
+
+// rosettaEnabled reports whether Docker Desktop settings turn on Rosetta. The key is
+// capitalised in settings-store.json and camel-cased in the older settings.json.
+func rosettaEnabled(settings []byte) bool {
+	var values map[string]interface{}
+	if err := json.Unmarshal(settings, &values); err != nil {
+		return false
+	}
+	for key, value := range values {
+		if strings.EqualFold(key, "useVirtualizationFrameworkRosetta") {
+			enabled, _ := value.(bool)
+			return enabled
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package architecture
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePlatform(t *testing.T) {
+	for _, platform := range []string{"linux/amd64", "linux/arm64", "linux/386", "linux/arm/v7"} {
+		assert.NoError(t, ValidatePlatform(platform), platform)
+	}
+	for _, platform := range []string{"", "amd64", "windows/amd64", "linux/riscv64"} {
+		assert.Error(t, ValidatePlatform(platform), platform)
+	}
+}
+
+func TestPlatformArch(t *testing.T) {
+	assert.Equal(t, "amd64", PlatformArch("linux/amd64"))
+	assert.Equal(t, "arm64", PlatformArch("linux/arm64"))
+	assert.Equal(t, "arm", PlatformArch("linux/arm/v7"))
+	assert.Equal(t, "", PlatformArch("linux/riscv64"))
+}
+
+func TestDetectEmulation(t *testing.T) {
+	t.Run("native platform needs no emulation", func(t *testing.T) {
+		emulation, err := DetectEmulation("linux/arm64", "linux/arm64")
+		assert.NoError(t, err)
+		assert.Equal(t, EmulationNone, emulation)
+	})
+}
+
+func TestEmulationWarning(t *testing.T) {
+	assert.Empty(t, EmulationWarning("linux/arm64", EmulationNone))
+	assert.Contains(t, EmulationWarning("linux/amd64", EmulationRosetta), "Rosetta")
+	assert.Contains(t, EmulationWarning("linux/amd64", EmulationQEMU), "QEMU")
+}
+
+func TestRosettaEnabled(t *testing.T) {
+	assert.True(t, rosettaEnabled([]byte(`{"UseVirtualizationFrameworkRosetta": true}`)), "settings-store.json")
+	assert.True(t, rosettaEnabled([]byte(`{"useVirtualizationFrameworkRosetta": true, "cpus": 8}`)), "settings.json")
+	assert.False(t, rosettaEnabled([]byte(`{"UseVirtualizationFrameworkRosetta": false}`)))
+	assert.False(t, rosettaEnabled([]byte(`{"cpus": 8}`)))
+	assert.False(t, rosettaEnabled([]byte(`not json`)))
+}
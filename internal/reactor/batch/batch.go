@@ -0,0 +1,203 @@
+// Package batch runs prompts across many repositories and collects the results.
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultParallel is the number of jobs run at once when the manifest does not say
+const DefaultParallel = 2
+
+// Job is a single prompt to run in a repository
+type Job struct {
+	Name    string `yaml:"name" json:"name"`
+	Path    string `yaml:"path" json:"path"`
+	Prompt  string `yaml:"prompt" json:"prompt"`
+	Image   string `yaml:"image,omitempty" json:"image,omitempty"`
+	Account string `yaml:"account,omitempty" json:"account,omitempty"`
+}
+
+// Manifest lists the jobs for a batch run. Prompt, Image and Account are defaults for
+// jobs that do not set their own.
+type Manifest struct {
+	Parallel int    `yaml:"parallel"`
+	Prompt   string `yaml:"prompt"`
+	Image    string `yaml:"image"`
+	Account  string `yaml:"account"`
+	Jobs     []Job  `yaml:"jobs"`
+}
+
+// Result is the outcome of one job
+type Result struct {
+	Job      Job           `json:"job"`
+	Success  bool          `json:"success"`
+	Output   string        `json:"output"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Runner executes a job and returns the response
+type Runner func(ctx context.Context, job Job) (string, error)
+
+// LoadManifest reads a manifest, applies defaults, and validates every job.
+// Relative job paths are resolved against the manifest's directory.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if len(manifest.Jobs) == 0 {
+		return nil, fmt.Errorf("manifest %s contains no jobs", path)
+	}
+	if manifest.Parallel <= 0 {
+		manifest.Parallel = DefaultParallel
+	}
+
+	baseDir := filepath.Dir(path)
+	home, _ := os.UserHomeDir()
+	for i := range manifest.Jobs {
+		job := &manifest.Jobs[i]
+		if job.Path == "" {
+			return nil, fmt.Errorf("job %d in %s has no path", i+1, path)
+		}
+		if strings.HasPrefix(job.Path, "~/") && home != "" {
+			job.Path = filepath.Join(home, job.Path[2:])
+		} else if !filepath.IsAbs(job.Path) {
+			job.Path = filepath.Join(baseDir, job.Path)
+		}
+		if job.Name == "" {
+			job.Name = filepath.Base(job.Path)
+		}
+		if job.Prompt == "" {
+			job.Prompt = manifest.Prompt
+		}
+		if job.Prompt == "" {
+			return nil, fmt.Errorf("job '%s' has no prompt and the manifest sets no default", job.Name)
+		}
+		if job.Image == "" {
+			job.Image = manifest.Image
+		}
+		if job.Account == "" {
+			job.Account = manifest.Account
+		}
+	}
+
+	return &manifest, nil
+}
+
+// Run executes jobs with at most parallel running at once. Results keep manifest order.
+func Run(ctx context.Context, jobs []Job, parallel int, run Runner, onDone func(Result)) []Result {
+	if parallel <= 0 {
+		parallel = DefaultParallel
+	}
+
+	results := make([]Result, len(jobs))
+	slots := make(chan struct{}, parallel)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job Job) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			start := time.Now()
+			result := Result{Job: job}
+			if ctx.Err() != nil {
+				result.Error = ctx.Err().Error()
+			} else if output, err := run(ctx, job); err != nil {
+				result.Output = output
+				result.Error = err.Error()
+			} else {
+				result.Output = output
+				result.Success = true
+			}
+			result.Duration = time.Since(start).Round(time.Millisecond)
+			results[i] = result
+
+			if onDone != nil {
+				mu.Lock()
+				onDone(result)
+				mu.Unlock()
+			}
+		}(i, job)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// Failed counts unsuccessful results
+func Failed(results []Result) int {
+	failed := 0
+	for _, r := range results {
+		if !r.Success {
+			failed++
+		}
+	}
+	return failed
+}
+
+// WriteReport writes results as JSON when path ends in .json, otherwise as Markdown
+func WriteReport(path string, results []Result) error {
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		encoded, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		data = append(encoded, '\n')
+	} else {
+		data = []byte(MarkdownReport(results))
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// MarkdownReport renders a summary table followed by each job's response
+func MarkdownReport(results []Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Batch Results\n\n%d jobs, %d succeeded, %d failed\n\n", len(results), len(results)-Failed(results), Failed(results))
+	b.WriteString("| Job | Path | Status | Duration |\n|-----|------|--------|----------|\n")
+	for _, r := range results {
+		status := "✅ success"
+		if !r.Success {
+			status = "❌ failed"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", r.Job.Name, r.Job.Path, status, r.Duration)
+	}
+
+	sorted := append([]Result{}, results...)
+	sort.SliceStable(sorted, func(i, j int) bool { return !sorted[i].Success && sorted[j].Success })
+	for _, r := range sorted {
+		fmt.Fprintf(&b, "\n## %s\n\n", r.Job.Name)
+		if r.Error != "" {
+			fmt.Fprintf(&b, "**Error:** %s\n\n", r.Error)
+		}
+		if output := strings.TrimSpace(r.Output); output != "" {
+			b.WriteString(output)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,122 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "jobs.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadManifest(t *testing.T) {
+	t.Run("defaults are applied", func(t *testing.T) {
+		path := writeManifest(t, `
+prompt: "Update the README"
+image: go
+jobs:
+  - path: repo-a
+  - name: billing
+    path: /src/billing
+    prompt: "Bump Go"
+    account: work
+`)
+		manifest, err := LoadManifest(path)
+		require.NoError(t, err)
+
+		assert.Equal(t, DefaultParallel, manifest.Parallel)
+		require.Len(t, manifest.Jobs, 2)
+
+		assert.Equal(t, "repo-a", manifest.Jobs[0].Name)
+		assert.Equal(t, filepath.Join(filepath.Dir(path), "repo-a"), manifest.Jobs[0].Path)
+		assert.Equal(t, "Update the README", manifest.Jobs[0].Prompt)
+		assert.Equal(t, "go", manifest.Jobs[0].Image)
+
+		assert.Equal(t, "billing", manifest.Jobs[1].Name)
+		assert.Equal(t, "/src/billing", manifest.Jobs[1].Path)
+		assert.Equal(t, "Bump Go", manifest.Jobs[1].Prompt)
+		assert.Equal(t, "work", manifest.Jobs[1].Account)
+	})
+
+	t.Run("job without prompt", func(t *testing.T) {
+		_, err := LoadManifest(writeManifest(t, "jobs:\n  - path: repo-a\n"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "has no prompt")
+	})
+
+	t.Run("no jobs", func(t *testing.T) {
+		_, err := LoadManifest(writeManifest(t, "parallel: 3\n"))
+		assert.Error(t, err)
+	})
+}
+
+func TestRun(t *testing.T) {
+	jobs := []Job{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+
+	var running, peak int32
+	runner := func(ctx context.Context, job Job) (string, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		if job.Name == "c" {
+			return "", errors.New("boom")
+		}
+		return "done " + job.Name, nil
+	}
+
+	var completed int
+	results := Run(context.Background(), jobs, 2, runner, func(Result) { completed++ })
+
+	require.Len(t, results, 4)
+	assert.Equal(t, 4, completed)
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+	assert.Equal(t, "done a", results[0].Output)
+	assert.False(t, results[2].Success)
+	assert.Equal(t, "boom", results[2].Error)
+	assert.Equal(t, 1, Failed(results))
+}
+
+func TestWriteReport(t *testing.T) {
+	results := []Result{
+		{Job: Job{Name: "ok", Path: "/a"}, Success: true, Output: "All good"},
+		{Job: Job{Name: "broken", Path: "/b"}, Error: "exit status 1"},
+	}
+	dir := t.TempDir()
+
+	t.Run("markdown", func(t *testing.T) {
+		path := filepath.Join(dir, "report.md")
+		require.NoError(t, WriteReport(path, results))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "2 jobs, 1 succeeded, 1 failed")
+		assert.Contains(t, string(data), "**Error:** exit status 1")
+		assert.Contains(t, string(data), "All good")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		path := filepath.Join(dir, "report.json")
+		require.NoError(t, WriteReport(path, results))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"success": false`)
+	})
+}
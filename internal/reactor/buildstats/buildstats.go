@@ -0,0 +1,171 @@
+// Package buildstats records how long image builds and registry pulls take and how many of
+// their layers were already cached, so 'claude-reactor stats' can show whether pulling or
+// building locally is faster on this machine. The history is stored in ~/.claude-reactor.
+package buildstats
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileName holds the build and pull history, in ~/.claude-reactor
+const FileName = "build-history.json"
+
+// MaxEntries is how many builds and pulls are kept; older ones are dropped
+const MaxEntries = 200
+
+// Kinds of record
+const (
+	KindBuild = "build"
+	KindPull  = "pull"
+)
+
+// Step is one Dockerfile instruction of a build
+type Step struct {
+	Instruction string        `json:"instruction"`
+	Duration    time.Duration `json:"duration"`
+	Cached      bool          `json:"cached,omitempty"`
+}
+
+// Record is one image build or pull. Layers counts build steps or pulled layers; Cached
+// counts those taken from the build cache or already present locally.
+type Record struct {
+	Kind     string        `json:"kind"`
+	Variant  string        `json:"variant"`
+	Image    string        `json:"image"`
+	Platform string        `json:"platform,omitempty"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	Layers   int           `json:"layers"`
+	Cached   int           `json:"cached"`
+	Steps    []Step        `json:"steps,omitempty"`
+}
+
+// Summary describes the record for the user, such as "built in 94s, 37/41 layers cached"
+func (r Record) Summary() string {
+	verb, cached := "built", "cached"
+	if r.Kind == KindPull {
+		verb, cached = "pulled", "already present"
+	}
+	summary := fmt.Sprintf("%s in %s", verb, FormatDuration(r.Duration))
+	if r.Layers > 0 {
+		summary += fmt.Sprintf(", %d/%d layers %s", r.Cached, r.Layers, cached)
+	}
+	return summary
+}
+
+// FormatDuration rounds d to whole seconds, or tenths below ten seconds
+func FormatDuration(d time.Duration) string {
+	if d < 10*time.Second {
+		return d.Round(100 * time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
+
+// Path returns the history file in ~/.claude-reactor, or "" without a home directory
+func Path() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".claude-reactor", FileName)
+}
+
+// mu serializes updates, which concurrent builds can make
+var mu sync.Mutex
+
+// Load returns the history at path, oldest first
+func Load(path string) ([]Record, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read build history: %w", err)
+	}
+	var records []Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// Append adds record to the history at path, keeping the newest MaxEntries
+func Append(path string, record Record) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	records, err := Load(path)
+	if err != nil {
+		return err
+	}
+	records = append(records, record)
+	if len(records) > MaxEntries {
+		records = records[len(records)-MaxEntries:]
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode build history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// stepLine matches the classic builder's "Step 3/41 : RUN apt-get update"
+var stepLine = regexp.MustCompile(`^Step \d+/\d+ : (.*)$`)
+
+// StepTracker times build steps from the builder's output. Each step lasts until the next
+// one starts, or the build finishes.
+type StepTracker struct {
+	steps []Step
+	start time.Time
+	open  bool
+}
+
+// Observe reads one line of build output, seen at now
+func (t *StepTracker) Observe(line string, now time.Time) {
+	line = strings.TrimSpace(line)
+	if match := stepLine.FindStringSubmatch(line); match != nil {
+		t.end(now)
+		t.steps = append(t.steps, Step{Instruction: match[1]})
+		t.start, t.open = now, true
+		return
+	}
+	if line == "---> Using cache" && len(t.steps) > 0 {
+		t.steps[len(t.steps)-1].Cached = true
+	}
+}
+
+// Finish ends the last step at now and returns all steps
+func (t *StepTracker) Finish(now time.Time) []Step {
+	t.end(now)
+	return t.steps
+}
+
+// end closes the current step at now
+func (t *StepTracker) end(now time.Time) {
+	if t.open {
+		t.steps[len(t.steps)-1].Duration = now.Sub(t.start)
+		t.open = false
+	}
+}
+
+// CountCached returns how many steps came from the build cache
+func CountCached(steps []Step) int {
+	cached := 0
+	for _, step := range steps {
+		if step.Cached {
+			cached++
+		}
+	}
+	return cached
+}
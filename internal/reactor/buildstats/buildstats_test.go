@@ -0,0 +1,57 @@
+package buildstats
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummary(t *testing.T) {
+	build := Record{Kind: KindBuild, Duration: 94*time.Second + 300*time.Millisecond, Layers: 41, Cached: 37}
+	assert.Equal(t, "built in 1m34s, 37/41 layers cached", build.Summary())
+
+	pull := Record{Kind: KindPull, Duration: 2340 * time.Millisecond, Layers: 8, Cached: 3}
+	assert.Equal(t, "pulled in 2.3s, 3/8 layers already present", pull.Summary())
+
+	pull.Layers = 0
+	assert.Equal(t, "pulled in 2.3s", pull.Summary(), "layers are left out when none were reported")
+}
+
+func TestStepTracker(t *testing.T) {
+	start := time.Now()
+	var tracker StepTracker
+	tracker.Observe("Step 1/3 : FROM debian:bookworm-slim AS base", start)
+	tracker.Observe(" ---> 4a1b2c3d4e5f", start)
+	tracker.Observe("Step 2/3 : RUN apt-get update", start.Add(time.Second))
+	tracker.Observe(" ---> Using cache", start.Add(time.Second))
+	tracker.Observe("Step 3/3 : COPY entrypoint.sh /usr/local/bin/", start.Add(2*time.Second))
+	tracker.Observe("Successfully built 9f8e7d6c5b4a", start.Add(5*time.Second))
+	steps := tracker.Finish(start.Add(6 * time.Second))
+
+	require.Len(t, steps, 3)
+	assert.Equal(t, Step{Instruction: "FROM debian:bookworm-slim AS base", Duration: time.Second}, steps[0])
+	assert.Equal(t, Step{Instruction: "RUN apt-get update", Duration: time.Second, Cached: true}, steps[1])
+	assert.Equal(t, Step{Instruction: "COPY entrypoint.sh /usr/local/bin/", Duration: 4 * time.Second}, steps[2])
+	assert.Equal(t, 1, CountCached(steps))
+	assert.Equal(t, steps, tracker.Finish(start.Add(time.Minute)), "finishing again does not stretch the last step")
+}
+
+func TestAppendKeepsNewest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+
+	records, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	for i := 0; i < MaxEntries+5; i++ {
+		require.NoError(t, Append(path, Record{Kind: KindBuild, Variant: "go", Layers: i}))
+	}
+	records, err = Load(path)
+	require.NoError(t, err)
+	require.Len(t, records, MaxEntries)
+	assert.Equal(t, 5, records[0].Layers)
+	assert.Equal(t, MaxEntries+4, records[len(records)-1].Layers)
+}
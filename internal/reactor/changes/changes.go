@@ -0,0 +1,132 @@
+// Package changes summarizes what a session changed in a git project, and reverts changes the
+// user rejects. Snapshots are git trees written from a private index, so the project's own
+// index, stash and history are never touched.
+package changes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Snapshot is the state of the files in a project at one point in time
+type Snapshot struct {
+	dir  string // project directory, which may be inside a larger repository
+	tree string // git tree of the repository's working tree, ignored files excluded
+}
+
+// Change is a file that differs from a snapshot
+type Change struct {
+	Status string // A (added), M (modified), D (deleted) or T (type changed)
+	Path   string // relative to the project directory
+}
+
+// Take records the files of the project at dir. It fails when dir is not in a git repository.
+func Take(dir string) (*Snapshot, error) {
+	tree, err := writeTree(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{dir: dir, tree: tree}, nil
+}
+
+// Changes returns the files in the project that differ from the snapshot, and the git diff
+// summary line for them ("" when nothing changed)
+func (s *Snapshot) Changes() ([]Change, string, error) {
+	tree, err := writeTree(s.dir)
+	if err != nil {
+		return nil, "", err
+	}
+	if tree == s.tree {
+		return nil, "", nil
+	}
+
+	output, err := git(s.dir, "diff", "--name-status", "--no-renames", "--relative", "-z", s.tree, tree)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to compare project files: %w", err)
+	}
+	var changes []Change
+	fields := strings.Split(strings.TrimSuffix(output, "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		changes = append(changes, Change{Status: fields[i], Path: filepath.FromSlash(fields[i+1])})
+	}
+	stat, err := git(s.dir, "diff", "--shortstat", "--no-renames", "--relative", s.tree, tree)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to summarize project changes: %w", err)
+	}
+	return changes, strings.TrimSpace(stat), nil
+}
+
+// Revert restores a changed file to its state in the snapshot: added files are deleted, and
+// modified or deleted files get their snapshot content back
+func (s *Snapshot) Revert(change Change) error {
+	if change.Status == "A" {
+		if err := os.Remove(filepath.Join(s.dir, change.Path)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", change.Path, err)
+		}
+		return nil
+	}
+	if _, err := git(s.dir, "restore", "--source="+s.tree, "--worktree", "--", ":(literal)"+filepath.ToSlash(change.Path)); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", change.Path, err)
+	}
+	return nil
+}
+
+// writeTree writes the repository's working tree, as git add -A would stage it, to a git tree
+// through a private copy of the index
+func writeTree(dir string) (string, error) {
+	indexPath, err := git(dir, "rev-parse", "--path-format=absolute", "--git-path", "index")
+	if err != nil {
+		return "", fmt.Errorf("%s is not in a git repository: %w", dir, err)
+	}
+	index, err := os.CreateTemp("", "claude-reactor-index-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create snapshot index: %w", err)
+	}
+	index.Close()
+	defer os.Remove(index.Name())
+	// Starting from the project's index lets git skip files it already knows are unchanged
+	if data, err := os.ReadFile(indexPath); err == nil {
+		if err := os.WriteFile(index.Name(), data, 0600); err != nil {
+			return "", fmt.Errorf("failed to create snapshot index: %w", err)
+		}
+	} else {
+		os.Remove(index.Name()) // a new repository has no index yet
+	}
+
+	env := []string{"GIT_INDEX_FILE=" + index.Name()}
+	if _, err := gitEnv(dir, env, "add", "-A", ":/"); err != nil {
+		return "", fmt.Errorf("failed to snapshot project files: %w", err)
+	}
+	tree, err := gitEnv(dir, env, "write-tree")
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot project files: %w", err)
+	}
+	return tree, nil
+}
+
+// git runs a git command in dir and returns its output, or an error with what git printed
+// on failure
+func git(dir string, args ...string) (string, error) {
+	return gitEnv(dir, nil, args...)
+}
+
+// gitEnv runs git like git, with extra environment variables
+func gitEnv(dir string, env []string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", errors.New(message)
+		}
+		return "", err
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
@@ -0,0 +1,123 @@
+package changes
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRepo creates a repository with committed, staged and ignored files
+func newRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	write(t, dir, ".gitignore", "build/\n")
+	write(t, dir, "keep.txt", "keep\n")
+	write(t, dir, "edit.txt", "before\n")
+	write(t, dir, "remove.txt", "remove\n")
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+	} {
+		_, err := git(dir, args...)
+		require.NoError(t, err)
+	}
+	// Uncommitted work from before the session is not a session change
+	write(t, dir, "draft.txt", "draft\n")
+	return dir
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func TestChanges(t *testing.T) {
+	dir := newRepo(t)
+	indexBefore, err := os.ReadFile(filepath.Join(dir, ".git", "index"))
+	require.NoError(t, err)
+
+	snapshot, err := Take(dir)
+	require.NoError(t, err)
+	changes, stat, err := snapshot.Changes()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Empty(t, stat)
+
+	write(t, dir, "edit.txt", "after\n")
+	write(t, dir, "src/new.go", "package src\n")
+	write(t, dir, "build/out.bin", "ignored")
+	require.NoError(t, os.Remove(filepath.Join(dir, "remove.txt")))
+
+	changes, stat, err = snapshot.Changes()
+	require.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Status: "M", Path: "edit.txt"},
+		{Status: "D", Path: "remove.txt"},
+		{Status: "A", Path: filepath.Join("src", "new.go")},
+	}, changes)
+	assert.Contains(t, stat, "3 files changed")
+
+	// The project's own index is left alone
+	indexAfter, err := os.ReadFile(filepath.Join(dir, ".git", "index"))
+	require.NoError(t, err)
+	assert.Equal(t, indexBefore, indexAfter)
+}
+
+func TestRevert(t *testing.T) {
+	dir := newRepo(t)
+	snapshot, err := Take(dir)
+	require.NoError(t, err)
+
+	write(t, dir, "edit.txt", "after\n")
+	write(t, dir, "draft.txt", "changed draft\n")
+	write(t, dir, "new.txt", "new\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "remove.txt")))
+
+	changes, _, err := snapshot.Changes()
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	for _, change := range changes {
+		require.NoError(t, snapshot.Revert(change))
+	}
+
+	changes, _, err = snapshot.Changes()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	content, err := os.ReadFile(filepath.Join(dir, "draft.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "draft\n", string(content), "restored to the snapshot, not the last commit")
+	assert.NoFileExists(t, filepath.Join(dir, "new.txt"))
+	assert.FileExists(t, filepath.Join(dir, "remove.txt"))
+}
+
+func TestSubdirectory(t *testing.T) {
+	dir := newRepo(t)
+	api := filepath.Join(dir, "services", "api")
+	write(t, api, "main.go", "package main\n")
+
+	snapshot, err := Take(api)
+	require.NoError(t, err)
+	write(t, api, "main.go", "package main\n\nfunc main() {}\n")
+	write(t, dir, "outside.txt", "not in the project\n")
+
+	changes, _, err := snapshot.Changes()
+	require.NoError(t, err)
+	assert.Equal(t, []Change{{Status: "M", Path: "main.go"}}, changes)
+}
+
+func TestTakeOutsideRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	_, err := Take(t.TempDir())
+	assert.Error(t, err)
+}
@@ -0,0 +1,474 @@
+// Package checkpoint keeps backups of a project's files taken during sessions, so unwanted
+// changes can be rolled back whether or not the project uses git.
+//
+// A project's store holds one JSON manifest per checkpoint and a content-addressed object
+// for each distinct file content, so files that don't change between checkpoints are stored
+// once. Files whose size and modification time match the previous checkpoint are not re-read.
+package checkpoint
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultInterval is how often checkpoints are taken during a session when enabled with "on"
+const DefaultInterval = 15 * time.Minute
+
+// Keep is how many checkpoints of a project sessions keep; older ones are pruned
+const Keep = 30
+
+// maxFileSize is the largest file included in checkpoints; bigger files are usually build
+// outputs or data, and would make every checkpoint slow
+const maxFileSize = 50 << 20
+
+// idFormat names checkpoints after the time they were taken
+const idFormat = "20060102-150405"
+
+// File is a file recorded in a checkpoint
+type File struct {
+	Hash    string      `json:"hash,omitempty"` // SHA-256 of the content; empty for symlinks
+	Link    string      `json:"link,omitempty"` // symlink target
+	Mode    fs.FileMode `json:"mode"`
+	Size    int64       `json:"size"`
+	ModTime time.Time   `json:"mod_time"`
+}
+
+// Checkpoint is the state of a project's files at one point in time
+type Checkpoint struct {
+	ID      string          `json:"id"`
+	Created time.Time       `json:"created"`
+	Reason  string          `json:"reason"` // why it was taken, e.g. "session start"
+	Files   map[string]File `json:"files"`  // by slash-separated path relative to the project
+}
+
+// Size returns the total size of the checkpoint's files
+func (c *Checkpoint) Size() int64 {
+	var size int64
+	for _, f := range c.Files {
+		size += f.Size
+	}
+	return size
+}
+
+// ParseInterval interprets the checkpoints setting: "" or "off" disables checkpoints, "start"
+// takes one at session start only, "on" also takes them every DefaultInterval, and a duration
+// of at least a minute sets the interval
+func ParseInterval(value string) (enabled bool, interval time.Duration, err error) {
+	switch value {
+	case "", "off", "none", "false":
+		return false, 0, nil
+	case "start":
+		return true, 0, nil
+	case "on", "true":
+		return true, DefaultInterval, nil
+	}
+	interval, err = time.ParseDuration(value)
+	if err != nil || interval < time.Minute {
+		return false, 0, fmt.Errorf("invalid checkpoints value '%s': use on, start, off or an interval of at least 1m such as 10m", value)
+	}
+	return true, interval, nil
+}
+
+// Store holds the checkpoints of one project
+type Store struct {
+	dir string
+}
+
+// Open returns the checkpoint store in dir, which is created when the first checkpoint is taken
+func Open(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Create records the files of the project at projectDir, skipping names matching ignores and
+// .git. When nothing changed since the latest checkpoint, that one is returned and created is
+// false. Old checkpoints are not pruned, so one about to be restored stays available.
+func (s *Store) Create(projectDir, reason string, ignores []string) (checkpoint *Checkpoint, created bool, err error) {
+	previous, err := s.latest()
+	if err != nil {
+		return nil, false, err
+	}
+
+	files := make(map[string]File)
+	err = walk(projectDir, ignores, func(rel string, info fs.FileInfo, file string) error {
+		entry := File{Mode: info.Mode(), Size: info.Size(), ModTime: info.ModTime().UTC()}
+		if info.Mode()&fs.ModeSymlink != 0 {
+			link, err := os.Readlink(file)
+			if err != nil {
+				return err
+			}
+			entry.Link, entry.Size = link, 0
+			files[rel] = entry
+			return nil
+		}
+		if old, ok := previous.file(rel); ok && old.Hash != "" && old.Size == entry.Size && old.ModTime.Equal(entry.ModTime) && s.hasObject(old.Hash) {
+			entry.Hash = old.Hash
+		} else if entry.Hash, err = s.storeObject(file); err != nil {
+			return err
+		}
+		files[rel] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to checkpoint project: %w", err)
+	}
+
+	if previous != nil && sameFiles(previous.Files, files) {
+		return previous, false, nil
+	}
+	checkpoint = &Checkpoint{ID: s.newID(), Created: time.Now(), Reason: reason, Files: files}
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	if err := os.WriteFile(s.manifest(checkpoint.ID), data, 0600); err != nil {
+		return nil, false, fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return checkpoint, true, nil
+}
+
+// List returns the project's checkpoints, newest first
+func (s *Store) List() ([]*Checkpoint, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoints: %w", err)
+	}
+	var checkpoints []*Checkpoint
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		checkpoint, err := s.Load(id)
+		if err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	sort.Slice(checkpoints, func(i, j int) bool { return checkpoints[i].Created.After(checkpoints[j].Created) })
+	return checkpoints, nil
+}
+
+// Load returns the checkpoint with the given ID
+func (s *Store) Load(id string) (*Checkpoint, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return nil, fmt.Errorf("invalid checkpoint ID '%s'", id)
+	}
+	data, err := os.ReadFile(s.manifest(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no checkpoint %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", id, err)
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", id, err)
+	}
+	return &checkpoint, nil
+}
+
+// Change is a file Restore puts back or removes
+type Change struct {
+	Action string // "restored", "recreated" or "removed"
+	Path   string // relative to the project
+}
+
+// Restore returns the project at projectDir to the checkpoint: changed and deleted files get
+// their checkpointed content back and files added since are removed. Ignored files are left
+// alone. With dryRun nothing is written, and the changes that would be made are returned.
+func (s *Store) Restore(projectDir string, checkpoint *Checkpoint, ignores []string, dryRun bool) ([]Change, error) {
+	current := make(map[string]fs.FileInfo)
+	err := walk(projectDir, ignores, func(rel string, info fs.FileInfo, file string) error {
+		current[rel] = info
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project files: %w", err)
+	}
+
+	var changes []Change
+	for rel, f := range checkpoint.Files {
+		file := filepath.Join(projectDir, filepath.FromSlash(rel))
+		info, exists := current[rel]
+		if exists && !s.differs(file, info, f) {
+			continue
+		}
+		action := "restored"
+		if !exists {
+			action = "recreated"
+		}
+		changes = append(changes, Change{Action: action, Path: rel})
+		if dryRun {
+			continue
+		}
+		if err := s.restoreFile(file, f); err != nil {
+			return changes, fmt.Errorf("failed to restore %s: %w", rel, err)
+		}
+	}
+	for rel := range current {
+		if _, ok := checkpoint.Files[rel]; ok {
+			continue
+		}
+		changes = append(changes, Change{Action: "removed", Path: rel})
+		if dryRun {
+			continue
+		}
+		if err := os.Remove(filepath.Join(projectDir, filepath.FromSlash(rel))); err != nil && !os.IsNotExist(err) {
+			return changes, fmt.Errorf("failed to remove %s: %w", rel, err)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// differs reports whether the project file no longer matches its checkpointed state
+func (s *Store) differs(file string, info fs.FileInfo, f File) bool {
+	if f.Link != "" || info.Mode()&fs.ModeSymlink != 0 {
+		link, err := os.Readlink(file)
+		return err != nil || link != f.Link
+	}
+	if info.Size() != f.Size || info.Mode().Perm() != f.Mode.Perm() {
+		return true
+	}
+	if info.ModTime().UTC().Equal(f.ModTime) {
+		return false
+	}
+	hash, err := hashFile(file)
+	return err != nil || hash != f.Hash
+}
+
+// restoreFile writes a checkpointed file back to the project
+func (s *Store) restoreFile(file string, f File) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	if f.Link != "" {
+		os.Remove(file)
+		return os.Symlink(f.Link, file)
+	}
+	object, err := os.Open(s.object(f.Hash))
+	if err != nil {
+		return fmt.Errorf("checkpoint content is missing: %w", err)
+	}
+	defer object.Close()
+	content, err := gzip.NewReader(object)
+	if err != nil {
+		return err
+	}
+	// Replace rather than truncate, so a file that was turned into a symlink is fixed too
+	os.Remove(file)
+	out, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, f.Mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, content); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	os.Chmod(file, f.Mode.Perm())
+	return os.Chtimes(file, f.ModTime, f.ModTime)
+}
+
+// latest returns the newest checkpoint, or nil when there are none
+func (s *Store) latest() (*Checkpoint, error) {
+	checkpoints, err := s.List()
+	if err != nil || len(checkpoints) == 0 {
+		return nil, err
+	}
+	return checkpoints[0], nil
+}
+
+// file returns a file recorded in the checkpoint; a nil checkpoint records nothing
+func (c *Checkpoint) file(rel string) (File, bool) {
+	if c == nil {
+		return File{}, false
+	}
+	f, ok := c.Files[rel]
+	return f, ok
+}
+
+// newID returns an unused checkpoint ID for the current time
+func (s *Store) newID() string {
+	base := time.Now().Format(idFormat)
+	id := base
+	for n := 2; ; n++ {
+		if _, err := os.Stat(s.manifest(id)); os.IsNotExist(err) {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// Prune removes all but the newest keep checkpoints, and the content only they referenced
+func (s *Store) Prune(keep int) error {
+	checkpoints, err := s.List()
+	if err != nil || len(checkpoints) <= keep {
+		return err
+	}
+	for _, old := range checkpoints[keep:] {
+		if err := os.Remove(s.manifest(old.ID)); err != nil {
+			return fmt.Errorf("failed to prune checkpoint %s: %w", old.ID, err)
+		}
+	}
+	referenced := make(map[string]bool)
+	for _, checkpoint := range checkpoints[:keep] {
+		for _, f := range checkpoint.Files {
+			referenced[f.Hash] = true
+		}
+	}
+	return filepath.WalkDir(filepath.Join(s.dir, "objects"), func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if !referenced[entry.Name()] {
+			return os.Remove(file)
+		}
+		return nil
+	})
+}
+
+// storeObject adds a file's content to the store and returns its hash
+func (s *Store) storeObject(file string) (string, error) {
+	hash, err := hashFile(file)
+	if err != nil {
+		return "", err
+	}
+	if s.hasObject(hash) {
+		return hash, nil
+	}
+
+	in, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+	if err := os.MkdirAll(filepath.Dir(s.object(hash)), 0700); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.object(hash)), ".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	zw := gzip.NewWriter(tmp)
+	_, err = io.Copy(zw, in)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	return hash, os.Rename(tmp.Name(), s.object(hash))
+}
+
+func (s *Store) hasObject(hash string) bool {
+	_, err := os.Stat(s.object(hash))
+	return err == nil
+}
+
+func (s *Store) object(hash string) string {
+	return filepath.Join(s.dir, "objects", hash[:2], hash)
+}
+
+func (s *Store) manifest(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+// hashFile returns the SHA-256 of a file's content
+func hashFile(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// sameFiles reports whether two checkpoints recorded the same files
+func sameFiles(a, b map[string]File) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for rel, f := range a {
+		other, ok := b[rel]
+		if !ok || other.Hash != f.Hash || other.Link != f.Link || other.Mode != f.Mode {
+			return false
+		}
+	}
+	return true
+}
+
+// walk calls fn for the regular files and symlinks of the project, by slash-separated
+// relative path, skipping .git, names matching ignores, and files too big to checkpoint
+func walk(projectDir string, ignores []string, fn func(rel string, info fs.FileInfo, file string) error) error {
+	return filepath.WalkDir(projectDir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrPermission) {
+				return nil
+			}
+			return err
+		}
+		if file == projectDir {
+			return nil
+		}
+		if entry.Name() == ".git" || ignored(entry.Name(), ignores) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() && info.Mode()&fs.ModeSymlink == 0 {
+			return nil
+		}
+		if info.Size() > maxFileSize {
+			return nil
+		}
+		rel, err := filepath.Rel(projectDir, file)
+		if err != nil {
+			return err
+		}
+		return fn(filepath.ToSlash(rel), info, file)
+	})
+}
+
+// ignored reports whether a file or directory name matches one of the ignore patterns
+func ignored(name string, ignores []string) bool {
+	for _, pattern := range ignores {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package checkpoint
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestParseInterval(t *testing.T) {
+	for value, expected := range map[string]struct {
+		enabled  bool
+		interval time.Duration
+	}{
+		"":      {false, 0},
+		"off":   {false, 0},
+		"start": {true, 0},
+		"on":    {true, DefaultInterval},
+		"5m":    {true, 5 * time.Minute},
+	} {
+		enabled, interval, err := ParseInterval(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected.enabled, enabled, value)
+		assert.Equal(t, expected.interval, interval, value)
+	}
+	for _, value := range []string{"10s", "often"} {
+		_, _, err := ParseInterval(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestCreateAndRestore(t *testing.T) {
+	project := t.TempDir()
+	store := Open(filepath.Join(t.TempDir(), "checkpoints"))
+	write(t, project, "main.go", "package main\n")
+	write(t, project, "docs/guide.md", "# Guide\n")
+	write(t, project, "node_modules/dep/index.js", "ignored")
+	write(t, project, ".git/HEAD", "ref: refs/heads/main\n")
+
+	first, created, err := store.Create(project, "session start", []string{"node_modules"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "session start", first.Reason)
+	assert.Len(t, first.Files, 2)
+	assert.Contains(t, first.Files, "docs/guide.md")
+
+	// Nothing changed, so no new checkpoint
+	again, created, err := store.Create(project, "periodic", []string{"node_modules"})
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, first.ID, again.ID)
+
+	// The session edits, deletes and adds files
+	write(t, project, "main.go", "package main\n\nfunc main() {}\n")
+	require.NoError(t, os.Remove(filepath.Join(project, "docs", "guide.md")))
+	write(t, project, "notes.txt", "new\n")
+	write(t, project, "node_modules/dep/index.js", "still ignored")
+	if runtime.GOOS != "windows" {
+		require.NoError(t, os.Symlink("main.go", filepath.Join(project, "link.go")))
+	}
+
+	second, created, err := store.Create(project, "periodic", []string{"node_modules"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.NotEqual(t, first.ID, second.ID)
+
+	checkpoints, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 2)
+	assert.Equal(t, second.ID, checkpoints[0].ID, "newest first")
+
+	// A dry run only reports
+	changes, err := store.Restore(project, first, []string{"node_modules"}, true)
+	require.NoError(t, err)
+	expected := []Change{
+		{Action: "recreated", Path: "docs/guide.md"},
+		{Action: "restored", Path: "main.go"},
+		{Action: "removed", Path: "notes.txt"},
+	}
+	if runtime.GOOS != "windows" {
+		expected = []Change{expected[0], {Action: "removed", Path: "link.go"}, expected[1], expected[2]}
+	}
+	assert.Equal(t, expected, changes)
+	assert.FileExists(t, filepath.Join(project, "notes.txt"))
+
+	changes, err = store.Restore(project, first, []string{"node_modules"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, expected, changes)
+	assert.Equal(t, "package main\n", read(t, project, "main.go"))
+	assert.Equal(t, "# Guide\n", read(t, project, "docs/guide.md"))
+	assert.NoFileExists(t, filepath.Join(project, "notes.txt"))
+	assert.Equal(t, "still ignored", read(t, project, "node_modules/dep/index.js"))
+	assert.Equal(t, "ref: refs/heads/main\n", read(t, project, ".git/HEAD"))
+
+	// Restored files match the checkpoint again
+	changes, err = store.Restore(project, first, []string{"node_modules"}, true)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// And the later checkpoint brings the session's work back
+	_, err = store.Restore(project, second, []string{"node_modules"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "new\n", read(t, project, "notes.txt"))
+}
+
+func TestLoad(t *testing.T) {
+	store := Open(t.TempDir())
+	_, err := store.Load("20260101-000000")
+	assert.Error(t, err)
+	_, err = store.Load("../escape")
+	assert.Error(t, err)
+
+	checkpoints, err := Open(filepath.Join(t.TempDir(), "missing")).List()
+	require.NoError(t, err)
+	assert.Empty(t, checkpoints)
+}
+
+func TestPrune(t *testing.T) {
+	project := t.TempDir()
+	store := Open(t.TempDir())
+	for _, content := range []string{"one", "two", "three"} {
+		write(t, project, "file.txt", content)
+		_, created, err := store.Create(project, "periodic", nil)
+		require.NoError(t, err)
+		require.True(t, created)
+	}
+
+	require.NoError(t, store.Prune(1))
+	checkpoints, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 1)
+
+	var objects []string
+	require.NoError(t, filepath.WalkDir(filepath.Join(store.dir, "objects"), func(file string, entry os.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			objects = append(objects, entry.Name())
+		}
+		return err
+	}))
+	assert.Equal(t, []string{checkpoints[0].Files["file.txt"].Hash}, objects)
+}
@@ -0,0 +1,221 @@
+// Package ci generates CI workflow files and converts Claude findings into CI annotations.
+package ci
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// DefaultWorkflowPath is where generate-github writes the workflow unless told otherwise
+const DefaultWorkflowPath = ".github/workflows/claude-reactor.yml"
+
+// DefaultTranscript is the file the workflow writes Claude's response to
+const DefaultTranscript = "claude-reactor-transcript.md"
+
+// GitHubWorkflowOptions controls the generated GitHub Actions workflow
+type GitHubWorkflowOptions struct {
+	Name     string   // Workflow name
+	Prompt   string   // Prompt run on every trigger
+	Image    string   // Container image (built-in variant or custom image)
+	Registry string   // Registry the built-in images are pulled from
+	Tag      string   // Image tag
+	Branches []string // Branches whose pull requests trigger the workflow (all when empty)
+	Annotate bool     // Report findings as pull request annotations
+}
+
+// workflowTemplate uses [[ ]] delimiters so GitHub's ${{ }} expressions pass through untouched
+var workflowTemplate = template.Must(template.New("workflow").Delims("[[", "]]").Parse(`# Generated by 'claude-reactor ci generate-github'
+name: [[ .Name ]]
+
+on:
+  pull_request:[[ if .Branches ]]
+    branches:[[ range .Branches ]]
+      - [[ . ]][[ end ]][[ end ]]
+  workflow_dispatch:
+
+permissions:
+  contents: read
+  packages: read
+
+jobs:
+  claude-reactor:
+    runs-on: ubuntu-latest
+    timeout-minutes: 30
+    env:
+      CLAUDE_REACTOR_REGISTRY: [[ .Registry ]]
+      CLAUDE_REACTOR_TAG: [[ .Tag ]]
+      ANTHROPIC_API_KEY: ${{ secrets.ANTHROPIC_API_KEY }}
+    steps:
+      - name: Checkout
+        uses: actions/checkout@v4
+
+      - name: Log in to container registry
+        uses: docker/login-action@v3
+        with:
+          registry: [[ .RegistryHost ]]
+          username: ${{ github.actor }}
+          password: ${{ secrets.GITHUB_TOKEN }}
+
+      - name: Restore image cache
+        id: image-cache
+        uses: actions/cache@v4
+        with:
+          path: /tmp/claude-reactor-image.tar
+          key: claude-reactor-[[ .CacheKey ]]-[[ .Tag ]]-${{ runner.arch }}
+
+      - name: Load cached image
+        if: steps.image-cache.outputs.cache-hit == 'true'
+        run: docker load -i /tmp/claude-reactor-image.tar
+
+      - name: Install claude-reactor
+        run: |
+          curl -fsSL https://raw.githubusercontent.com/dyluth/claude-reactor/main/install.sh | bash
+          echo "$HOME/.local/bin" >> "$GITHUB_PATH"
+
+      - name: Run prompt
+        run: |
+          claude-reactor prompt "$PROMPT" --image "[[ .Image ]]" --output [[ .Transcript ]][[ if .Annotate ]] --annotate[[ end ]]
+        env:
+          PROMPT: [[ .QuotedPrompt ]]
+
+      - name: Save image cache
+        if: steps.image-cache.outputs.cache-hit != 'true'
+        run: |
+          docker save -o /tmp/claude-reactor-image.tar $(docker images --format '{{.Repository}}:{{.Tag}}' | grep -E '^(claude-reactor-|[[ .Registry ]]/)[[ if .CustomImage ]]|^[[ .Image ]](:|$)[[ end ]]')
+
+      - name: Upload transcript
+        if: always()
+        uses: actions/upload-artifact@v4
+        with:
+          name: claude-reactor-transcript
+          path: [[ .Transcript ]]
+          if-no-files-found: ignore
+`))
+
+// builtinImages are the variants published to the claude-reactor registry
+var builtinImages = map[string]bool{"base": true, "go": true, "full": true, "cloud": true, "k8s": true}
+
+// GenerateGitHubWorkflow renders a GitHub Actions workflow that runs a prompt with claude-reactor
+func GenerateGitHubWorkflow(opts GitHubWorkflowOptions) (string, error) {
+	if strings.TrimSpace(opts.Prompt) == "" {
+		return "", fmt.Errorf("prompt cannot be empty")
+	}
+	if opts.Name == "" {
+		opts.Name = "Claude Reactor"
+	}
+	if opts.Image == "" {
+		opts.Image = "base"
+	}
+	if opts.Registry == "" {
+		opts.Registry = "ghcr.io/dyluth/claude-reactor"
+	}
+	if opts.Tag == "" {
+		opts.Tag = "latest"
+	}
+
+	data := struct {
+		GitHubWorkflowOptions
+		RegistryHost string
+		CacheKey     string
+		CustomImage  bool
+		Transcript   string
+		QuotedPrompt string
+	}{
+		GitHubWorkflowOptions: opts,
+		RegistryHost:          strings.SplitN(opts.Registry, "/", 2)[0],
+		CacheKey:              cacheKey(opts.Image),
+		CustomImage:           !builtinImages[opts.Image],
+		Transcript:            DefaultTranscript,
+		QuotedPrompt:          strconv.Quote(opts.Prompt),
+	}
+
+	var out bytes.Buffer
+	if err := workflowTemplate.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render workflow: %w", err)
+	}
+	return out.String(), nil
+}
+
+// cacheKey turns an image reference into a string safe for a cache key
+func cacheKey(image string) string {
+	return strings.NewReplacer("/", "-", ":", "-", "@", "-").Replace(image)
+}
+
+// Finding is a single issue reported by Claude against a file
+type Finding struct {
+	Level   string // error, warning, or notice
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// findingPattern matches "path:line[:col]: [level:] message", optionally as a list item
+var findingPattern = regexp.MustCompile(`^\s*(?:[-*]\s+)?` + "`?" + `([^\s:` + "`" + `]+):(\d+)(?::(\d+))?` + "`?" + `:?\s+(?:(?i)(error|warning|notice|note|info)\s*:\s*)?(.+)$`)
+
+// FindingsInstruction is appended to prompts so Claude reports findings in a parseable form
+const FindingsInstruction = "Report each finding on its own line in the form 'path:line: severity: message', " +
+	"where severity is error, warning, or notice and path is relative to the repository root."
+
+// ParseFindings extracts file findings from Claude's response. Lines that do not look like
+// findings are ignored.
+func ParseFindings(response string) []Finding {
+	var findings []Finding
+	scanner := bufio.NewScanner(strings.NewReader(response))
+	for scanner.Scan() {
+		match := findingPattern.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		line, _ := strconv.Atoi(match[2])
+		column, _ := strconv.Atoi(match[3])
+		findings = append(findings, Finding{
+			Level:   annotationLevel(match[4]),
+			File:    match[1],
+			Line:    line,
+			Column:  column,
+			Message: strings.TrimSpace(match[5]),
+		})
+	}
+	return findings
+}
+
+// annotationLevel maps a reported severity onto a GitHub annotation level
+func annotationLevel(severity string) string {
+	switch strings.ToLower(severity) {
+	case "error":
+		return "error"
+	case "notice", "note", "info":
+		return "notice"
+	default:
+		return "warning"
+	}
+}
+
+// FormatAnnotations renders findings as GitHub Actions workflow commands
+func FormatAnnotations(findings []Finding) string {
+	var b strings.Builder
+	for _, f := range findings {
+		fmt.Fprintf(&b, "::%s file=%s,line=%d", f.Level, escapeProperty(f.File), f.Line)
+		if f.Column > 0 {
+			fmt.Fprintf(&b, ",col=%d", f.Column)
+		}
+		fmt.Fprintf(&b, "::%s\n", escapeData(f.Message))
+	}
+	return b.String()
+}
+
+// escapeData escapes a workflow command message
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a workflow command property value
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
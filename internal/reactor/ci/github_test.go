@@ -0,0 +1,99 @@
+package ci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateGitHubWorkflow(t *testing.T) {
+	t.Run("renders valid workflow with defaults", func(t *testing.T) {
+		workflow, err := GenerateGitHubWorkflow(GitHubWorkflowOptions{Prompt: `Review "this" change`})
+		require.NoError(t, err)
+
+		var parsed map[string]interface{}
+		require.NoError(t, yaml.Unmarshal([]byte(workflow), &parsed))
+		assert.Equal(t, "Claude Reactor", parsed["name"])
+
+		assert.Contains(t, workflow, "registry: ghcr.io\n")
+		assert.Contains(t, workflow, "CLAUDE_REACTOR_REGISTRY: ghcr.io/dyluth/claude-reactor")
+		assert.Contains(t, workflow, `--image "base"`)
+		assert.Contains(t, workflow, "${{ secrets.ANTHROPIC_API_KEY }}")
+		assert.Contains(t, workflow, "'{{.Repository}}:{{.Tag}}'")
+		assert.NotContains(t, workflow, "--annotate")
+		assert.NotContains(t, workflow, "branches:")
+	})
+
+	t.Run("prompt is quoted", func(t *testing.T) {
+		workflow, err := GenerateGitHubWorkflow(GitHubWorkflowOptions{Prompt: "Say \"hi\"\nthen stop"})
+		require.NoError(t, err)
+
+		var parsed struct {
+			Jobs map[string]struct {
+				Steps []struct {
+					Name string            `yaml:"name"`
+					Env  map[string]string `yaml:"env"`
+				} `yaml:"steps"`
+			} `yaml:"jobs"`
+		}
+		require.NoError(t, yaml.Unmarshal([]byte(workflow), &parsed))
+		var prompt string
+		for _, step := range parsed.Jobs["claude-reactor"].Steps {
+			if step.Name == "Run prompt" {
+				prompt = step.Env["PROMPT"]
+			}
+		}
+		assert.Equal(t, "Say \"hi\"\nthen stop", prompt)
+	})
+
+	t.Run("branches, annotations and custom image", func(t *testing.T) {
+		workflow, err := GenerateGitHubWorkflow(GitHubWorkflowOptions{
+			Prompt:   "Review",
+			Image:    "myorg/dev:1.2",
+			Branches: []string{"main", "release"},
+			Annotate: true,
+		})
+		require.NoError(t, err)
+
+		var parsed map[string]interface{}
+		require.NoError(t, yaml.Unmarshal([]byte(workflow), &parsed))
+		assert.Contains(t, workflow, "      - main\n      - release\n")
+		assert.Contains(t, workflow, "--annotate")
+		assert.Contains(t, workflow, "key: claude-reactor-myorg-dev-1.2-latest-")
+		assert.Contains(t, workflow, "|^myorg/dev:1.2(:|$)")
+	})
+
+	t.Run("empty prompt", func(t *testing.T) {
+		_, err := GenerateGitHubWorkflow(GitHubWorkflowOptions{Prompt: " "})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseFindings(t *testing.T) {
+	response := `Here is my review.
+
+- internal/app/server.go:42: error: nil pointer dereference when config is missing
+* ` + "`cmd/main.go:7:3`" + `: unused import
+README.md:10: note: typo in heading
+Step 1: this is not a finding
+Overall the change looks good.`
+
+	findings := ParseFindings(response)
+	require.Len(t, findings, 3)
+
+	assert.Equal(t, Finding{Level: "error", File: "internal/app/server.go", Line: 42, Message: "nil pointer dereference when config is missing"}, findings[0])
+	assert.Equal(t, Finding{Level: "warning", File: "cmd/main.go", Line: 7, Column: 3, Message: "unused import"}, findings[1])
+	assert.Equal(t, Finding{Level: "notice", File: "README.md", Line: 10, Message: "typo in heading"}, findings[2])
+}
+
+func TestFormatAnnotations(t *testing.T) {
+	out := FormatAnnotations([]Finding{
+		{Level: "error", File: "a,b.go", Line: 3, Message: "100% broken"},
+		{Level: "warning", File: "main.go", Line: 7, Column: 2, Message: "unused"},
+	})
+
+	assert.Equal(t, "::error file=a%2Cb.go,line=3::100%25 broken\n::warning file=main.go,line=7,col=2::unused\n", out)
+	assert.Empty(t, FormatAnnotations(nil))
+}
@@ -0,0 +1,351 @@
+// Package claudeconfig carries the host's Claude CLI settings and MCP servers into containers,
+// rewriting host paths to where they appear in the container, so Claude behaves the same
+// inside and outside claude-reactor.
+package claudeconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"claude-reactor/pkg"
+)
+
+// ContainerHome is the home directory of the container user
+const ContainerHome = "/home/claude"
+
+// SettingsFile is Claude's settings file in ~/.claude
+const SettingsFile = "settings.json"
+
+// MemoryFile is Claude's user memory file in ~/.claude, loaded into every session
+const MemoryFile = "CLAUDE.md"
+
+// Markers around the part of MemoryFile that SeedMemory maintains
+const (
+	memoryStart = "<!-- claude-reactor: system prompt start (generated, edits are replaced) -->"
+	memoryEnd   = "<!-- claude-reactor: system prompt end -->"
+)
+
+// ProjectMCPFile is the project-scoped MCP server file Claude reads from the project root
+const ProjectMCPFile = ".mcp.json"
+
+// Paths maps host paths to where the container sees them
+type Paths struct {
+	HostHome         string // host home directory, becomes ContainerHome
+	HostProject      string // project directory on the host
+	ContainerProject string // project mount in the container, /app or /workspace
+}
+
+// Rewrite replaces host project and home paths in value with their container paths. Only
+// whole path components match, so /home/me/project2 is not rewritten as /home/me/project.
+func (p Paths) Rewrite(value string) string {
+	value = replacePath(value, p.HostProject, p.ContainerProject)
+	return replacePath(value, p.HostHome, ContainerHome)
+}
+
+// replacePath replaces prefix where it appears as a path followed by a separator or the end
+func replacePath(value, prefix, replacement string) string {
+	prefix = strings.TrimRight(prefix, `/\`)
+	if prefix == "" || !strings.Contains(value, prefix) {
+		return value
+	}
+	pattern := regexp.MustCompile(regexp.QuoteMeta(prefix) + `([/\\"'\s:;=]|$)`)
+	return pattern.ReplaceAllString(value, strings.ReplaceAll(replacement, "$", "$$")+"${1}")
+}
+
+// rewriteAll rewrites every string in a decoded JSON value
+func (p Paths) rewriteAll(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return p.Rewrite(v)
+	case []interface{}:
+		for i := range v {
+			v[i] = p.rewriteAll(v[i])
+		}
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = p.rewriteAll(v[key])
+		}
+	}
+	return value
+}
+
+// SyncSettings writes the host's ~/.claude/settings.json, with host paths rewritten, into
+// sessionDir, which the container mounts as ~/.claude. The host file is the source of truth and
+// replaces the copy on every start. It reports false when the host has no settings file.
+func SyncSettings(paths Paths, sessionDir string) (bool, error) {
+	hostSettings := filepath.Join(paths.HostHome, ".claude", SettingsFile)
+	settings, err := readJSON(hostSettings)
+	if err != nil || settings == nil {
+		return false, err
+	}
+	paths.rewriteAll(settings)
+	if err := writeJSON(filepath.Join(sessionDir, SettingsFile), settings); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SeedMemory writes the contents of the prompt files at sources into the CLAUDE.md in
+// sessionDir, which the container mounts as ~/.claude, so Claude follows them in every session
+// of the project. Only the marked section is replaced; anything else in the file is kept. With no
+// sources the section is removed. All files are read before anything is written, so a missing
+// file leaves the previous guidance in place.
+func SeedMemory(sessionDir string, sources []string) error {
+	parts := make([]string, 0, len(sources))
+	for _, source := range sources {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return fmt.Errorf("failed to read system prompt file: %w", err)
+		}
+		parts = append(parts, strings.TrimSpace(string(data)))
+	}
+
+	path := filepath.Join(sessionDir, MemoryFile)
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	rest := string(existing)
+	if start := strings.Index(rest, memoryStart); start >= 0 {
+		if end := strings.Index(rest[start:], memoryEnd); end >= 0 {
+			rest = rest[:start] + rest[start+end+len(memoryEnd):]
+		}
+	}
+	rest = strings.TrimSpace(rest)
+
+	var content string
+	if len(parts) > 0 {
+		content = memoryStart + "\n" + strings.Join(parts, "\n\n") + "\n" + memoryEnd + "\n"
+		if rest != "" {
+			content += "\n" + rest + "\n"
+		}
+	} else if rest != "" {
+		content = rest + "\n"
+	}
+
+	if content == string(existing) {
+		return nil
+	}
+	if content == "" {
+		return os.Remove(path)
+	}
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// SyncMCPServers brings the project's MCP servers into the container's Claude config at
+// configPath and returns the names of the servers it manages. Servers added on the host with
+// `claude mcp add` are copied with host paths rewritten. Servers from the project's .mcp.json
+// are already visible through the project mount; approvals given on the host carry over, and
+// approved stdio servers that refer to host paths get a rewritten copy that takes precedence.
+// Servers in managed, added with 'claude-reactor mcp add', are used as given and win over both.
+func SyncMCPServers(paths Paths, configPath string, managed map[string]interface{}, logger pkg.Logger) ([]string, error) {
+	hostConfig, err := readJSON(filepath.Join(paths.HostHome, ".claude.json"))
+	if err != nil {
+		return nil, err
+	}
+	hostProject := objectAt(objectAt(hostConfig, "projects"), paths.HostProject)
+	projectServers := objectAt(readProjectMCP(paths.HostProject, logger), "mcpServers")
+
+	servers := map[string]interface{}{}
+	for name, server := range objectAt(hostProject, "mcpServers") {
+		servers[name] = rewriteServer(paths, name, server, logger)
+	}
+	for name, server := range projectServers {
+		if _, local := servers[name]; local || !approved(hostProject, name) {
+			continue
+		}
+		rewritten := rewriteServer(paths, name, copyJSON(server), logger)
+		if !sameJSON(rewritten, server) {
+			servers[name] = rewritten
+		}
+	}
+	for name, server := range managed {
+		servers[name] = server
+	}
+
+	config, err := readJSON(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, fmt.Errorf("container Claude config %s not found", configPath)
+	}
+	projects := objectAt(config, "projects")
+	if projects == nil {
+		projects = map[string]interface{}{}
+		config["projects"] = projects
+	}
+	project := objectAt(projects, paths.ContainerProject)
+	if project == nil {
+		project = map[string]interface{}{}
+		projects[paths.ContainerProject] = project
+	}
+	project["mcpServers"] = servers
+	for _, key := range []string{"enabledMcpjsonServers", "disabledMcpjsonServers", "enableAllProjectMcpServers"} {
+		if value, ok := hostProject[key]; ok {
+			project[key] = value
+		}
+	}
+	if err := writeJSON(configPath, config); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(servers))
+	for name := range servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// rewriteServer rewrites host paths in a stdio server's command, arguments, environment and
+// working directory. Other transports connect by URL and are copied unchanged.
+func rewriteServer(paths Paths, name string, server interface{}, logger pkg.Logger) interface{} {
+	definition, ok := server.(map[string]interface{})
+	if !ok {
+		return server
+	}
+	if transport, _ := definition["type"].(string); transport != "" && transport != "stdio" {
+		return server
+	}
+	for _, key := range []string{"command", "args", "env", "cwd"} {
+		if value, ok := definition[key]; ok {
+			definition[key] = paths.rewriteAll(value)
+		}
+	}
+	// Only the project is mounted; tools installed in the host home are not
+	if command, _ := definition["command"].(string); strings.HasPrefix(command, ContainerHome+"/") {
+		logger.Warnf("⚠️  MCP server %s runs %s, which must also exist in the container image", name, command)
+	}
+	return definition
+}
+
+// approved reports whether the host user approved a server from the project's .mcp.json
+func approved(hostProject map[string]interface{}, name string) bool {
+	if all, _ := hostProject["enableAllProjectMcpServers"].(bool); all {
+		return true
+	}
+	enabled, _ := hostProject["enabledMcpjsonServers"].([]interface{})
+	for _, server := range enabled {
+		if server == name {
+			return true
+		}
+	}
+	return false
+}
+
+// readProjectMCP reads the project's .mcp.json; a broken file is left for Claude to report
+func readProjectMCP(projectDir string, logger pkg.Logger) map[string]interface{} {
+	mcp, err := readJSON(filepath.Join(projectDir, ProjectMCPFile))
+	if err != nil {
+		logger.Warnf("⚠️  Ignoring %s: %v", ProjectMCPFile, err)
+		return nil
+	}
+	return mcp
+}
+
+// objectAt returns the JSON object under key, or nil
+func objectAt(object map[string]interface{}, key string) map[string]interface{} {
+	value, _ := object[key].(map[string]interface{})
+	return value
+}
+
+// copyJSON deep-copies a decoded JSON value
+func copyJSON(value interface{}) interface{} {
+	data, _ := json.Marshal(value)
+	var copied interface{}
+	json.Unmarshal(data, &copied)
+	return copied
+}
+
+// sameJSON reports whether two decoded JSON values are equal
+func sameJSON(a, b interface{}) bool {
+	dataA, _ := json.Marshal(a)
+	dataB, _ := json.Marshal(b)
+	return string(dataA) == string(dataB)
+}
+
+// readJSON reads a JSON object from path; nil if the file does not exist
+func readJSON(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return object, nil
+}
+
+// writeJSON writes object to path in place. Containers bind-mount .claude.json as a single
+// file, which keeps pointing at the old file if it is replaced by a rename.
+func writeJSON(path string, object map[string]interface{}) error {
+	data, err := json.MarshalIndent(object, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// SplitArgs splits Claude CLI arguments written on one line, such as the claude_args setting,
+// the way a shell would: on whitespace, with single and double quotes and backslashes
+func SplitArgs(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				args = append(args, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape in %q", line)
+	}
+	if inWord {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,168 @@
+package claudeconfig
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/logging"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func readObject(t *testing.T, path string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var object map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &object))
+	return object
+}
+
+func TestRewrite(t *testing.T) {
+	paths := Paths{HostHome: "/home/me", HostProject: "/home/me/src/app", ContainerProject: "/app"}
+
+	assert.Equal(t, "/app", paths.Rewrite("/home/me/src/app"))
+	assert.Equal(t, "python /app/.claude/hooks/lint.py", paths.Rewrite("python /home/me/src/app/.claude/hooks/lint.py"))
+	assert.Equal(t, "/home/claude/bin/tool --config=/app/cfg", paths.Rewrite("/home/me/bin/tool --config=/home/me/src/app/cfg"))
+	assert.Equal(t, "/home/claude/src/app2", paths.Rewrite("/home/me/src/app2"), "only whole path components match")
+	assert.Equal(t, "/home/meadow", paths.Rewrite("/home/meadow"))
+	assert.Equal(t, "npx", paths.Rewrite("npx"))
+}
+
+func TestSyncSettings(t *testing.T) {
+	home := t.TempDir()
+	session := t.TempDir()
+	paths := Paths{HostHome: home, HostProject: filepath.Join(home, "project"), ContainerProject: "/app"}
+
+	synced, err := SyncSettings(paths, session)
+	require.NoError(t, err)
+	assert.False(t, synced, "no host settings")
+
+	writeFile(t, filepath.Join(home, ".claude", SettingsFile), `{
+  "model": "opus",
+  "hooks": {"PostToolUse": [{"hooks": [{"type": "command", "command": "`+home+`/bin/format.sh"}]}]}
+}`)
+	synced, err = SyncSettings(paths, session)
+	require.NoError(t, err)
+	assert.True(t, synced)
+
+	settings := readObject(t, filepath.Join(session, SettingsFile))
+	assert.Equal(t, "opus", settings["model"])
+	hook := settings["hooks"].(map[string]interface{})["PostToolUse"].([]interface{})[0].(map[string]interface{})["hooks"].([]interface{})[0]
+	assert.Equal(t, "/home/claude/bin/format.sh", hook.(map[string]interface{})["command"])
+}
+
+func TestSyncMCPServers(t *testing.T) {
+	home := t.TempDir()
+	project := filepath.Join(home, "project")
+	paths := Paths{HostHome: home, HostProject: project, ContainerProject: "/app"}
+	containerConfig := filepath.Join(t.TempDir(), ".claude.json")
+	writeFile(t, containerConfig, `{"userID": "abc", "projects": {"/app": {"hasTrustDialogAccepted": true}}}`)
+
+	hostConfig := map[string]interface{}{
+		"projects": map[string]interface{}{
+			project: map[string]interface{}{
+				"mcpServers": map[string]interface{}{
+					"db":   map[string]interface{}{"command": project + "/scripts/db-mcp", "args": []interface{}{"--root", project}},
+					"docs": map[string]interface{}{"type": "http", "url": "https://docs.example.com/mcp"},
+				},
+				"enabledMcpjsonServers": []interface{}{"search", "static"},
+			},
+		},
+	}
+	data, err := json.Marshal(hostConfig)
+	require.NoError(t, err)
+	writeFile(t, filepath.Join(home, ".claude.json"), string(data))
+	writeFile(t, filepath.Join(project, ProjectMCPFile), `{"mcpServers": {
+  "search": {"command": "node", "args": ["`+project+`/tools/search.js"]},
+  "static": {"command": "npx", "args": ["some-server"]},
+  "unapproved": {"command": "node", "args": ["`+project+`/tools/other.js"]}
+}}`)
+
+	managed := map[string]interface{}{"github": map[string]interface{}{"command": "claude-reactor-agent", "args": []interface{}{"mcp", "github"}}}
+	servers, err := SyncMCPServers(paths, containerConfig, managed, logging.NewLogger())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db", "docs", "github", "search"}, servers)
+
+	config := readObject(t, containerConfig)
+	assert.Equal(t, "abc", config["userID"])
+	app := config["projects"].(map[string]interface{})["/app"].(map[string]interface{})
+	assert.Equal(t, true, app["hasTrustDialogAccepted"])
+	assert.Equal(t, []interface{}{"search", "static"}, app["enabledMcpjsonServers"])
+
+	mcpServers := app["mcpServers"].(map[string]interface{})
+	db := mcpServers["db"].(map[string]interface{})
+	assert.Equal(t, "/app/scripts/db-mcp", db["command"])
+	assert.Equal(t, []interface{}{"--root", "/app"}, db["args"])
+	assert.Equal(t, "https://docs.example.com/mcp", mcpServers["docs"].(map[string]interface{})["url"])
+	assert.Equal(t, []interface{}{"/app/tools/search.js"}, mcpServers["search"].(map[string]interface{})["args"])
+	assert.Equal(t, managed["github"], mcpServers["github"])
+	assert.NotContains(t, mcpServers, "static", "servers without host paths work from the project mount")
+	assert.NotContains(t, mcpServers, "unapproved", "unapproved servers are left to Claude's approval prompt")
+}
+
+func TestSplitArgs(t *testing.T) {
+	args, err := SplitArgs(`--model opus  --max-turns 20 --append-system-prompt "Be brief, it's late" --allowedTools 'Bash(git *)' a\ b`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--model", "opus", "--max-turns", "20", "--append-system-prompt", "Be brief, it's late", "--allowedTools", "Bash(git *)", "a b"}, args)
+
+	args, err = SplitArgs(`  `)
+	require.NoError(t, err)
+	assert.Empty(t, args)
+
+	args, err = SplitArgs(`--flag ""`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--flag", ""}, args)
+
+	_, err = SplitArgs(`--model "opus`)
+	assert.Error(t, err)
+}
+
+func TestSeedMemory(t *testing.T) {
+	sources := t.TempDir()
+	sessionDir := t.TempDir()
+	org := filepath.Join(sources, "org.md")
+	team := filepath.Join(sources, "team.md")
+	writeFile(t, org, "# Org\nUse British spelling.\n")
+	writeFile(t, team, "Run make lint before committing.\n")
+	memory := filepath.Join(sessionDir, MemoryFile)
+
+	require.NoError(t, SeedMemory(sessionDir, []string{org, team}))
+	data, err := os.ReadFile(memory)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Org\nUse British spelling.\n\nRun make lint before committing.\n")
+
+	// Notes Claude added inside the container survive a refresh
+	writeFile(t, memory, string(data)+"\nRemember the staging database.\n")
+	writeFile(t, org, "# Org\nUse American spelling.\n")
+	require.NoError(t, SeedMemory(sessionDir, []string{org}))
+	data, err = os.ReadFile(memory)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Use American spelling.")
+	assert.NotContains(t, string(data), "British")
+	assert.NotContains(t, string(data), "make lint")
+	assert.Contains(t, string(data), "Remember the staging database.")
+
+	// A missing file keeps the previous guidance
+	assert.Error(t, SeedMemory(sessionDir, []string{filepath.Join(sources, "missing.md")}))
+	kept, err := os.ReadFile(memory)
+	require.NoError(t, err)
+	assert.Equal(t, data, kept)
+
+	// No sources removes the section and leaves the rest
+	require.NoError(t, SeedMemory(sessionDir, nil))
+	data, err = os.ReadFile(memory)
+	require.NoError(t, err)
+	assert.Equal(t, "Remember the staging database.\n", string(data))
+
+	require.NoError(t, SeedMemory(t.TempDir(), nil))
+}
@@ -0,0 +1,196 @@
+// Package clipboard bridges OSC 52 clipboard requests from container sessions to the host clipboard.
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// osc52Prefix starts an OSC 52 (manipulate selection data) sequence
+var osc52Prefix = []byte("\x1b]52;")
+
+// maxPending bounds how much of an unterminated sequence is buffered; larger copies are dropped
+const maxPending = 8 * 1024 * 1024
+
+// Bridge passes terminal output through unchanged while watching it for OSC 52 copy requests,
+// which it hands to a copy function. Sequences split across writes are reassembled.
+type Bridge struct {
+	out     io.Writer
+	copy    func([]byte) error
+	onError func(error)
+	pending []byte
+}
+
+// NewBridge wraps out. copyFn receives the decoded clipboard contents; onError, if set,
+// is told about copies that failed.
+func NewBridge(out io.Writer, copyFn func([]byte) error, onError func(error)) *Bridge {
+	return &Bridge{out: out, copy: copyFn, onError: onError}
+}
+
+// Write forwards p to the wrapped writer and processes any complete copy requests in it
+func (b *Bridge) Write(p []byte) (int, error) {
+	n, err := b.out.Write(p)
+	b.scan(p[:n])
+	return n, err
+}
+
+// scan looks for OSC 52 sequences in the buffered and newly written output
+func (b *Bridge) scan(p []byte) {
+	data := append(b.pending, p...)
+	b.pending = nil
+
+	for {
+		start := bytes.Index(data, osc52Prefix)
+		if start < 0 {
+			// Keep a trailing partial prefix for the next write
+			b.pending = append([]byte(nil), partialPrefix(data)...)
+			return
+		}
+
+		body := data[start+len(osc52Prefix):]
+		end, termLen := terminator(body)
+		if end < 0 {
+			if len(body) <= maxPending {
+				b.pending = append([]byte(nil), data[start:]...)
+			}
+			return
+		}
+
+		b.handle(body[:end])
+		data = body[end+termLen:]
+	}
+}
+
+// handle decodes a "selection;base64" payload and copies it
+func (b *Bridge) handle(payload []byte) {
+	parts := bytes.SplitN(payload, []byte(";"), 2)
+	if len(parts) != 2 || string(parts[1]) == "?" {
+		// Malformed, or a clipboard read request, which is never answered
+		return
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(string(parts[1]))
+	if err != nil {
+		b.report(fmt.Errorf("invalid clipboard data: %w", err))
+		return
+	}
+	if err := b.copy(decoded); err != nil {
+		b.report(err)
+	}
+}
+
+func (b *Bridge) report(err error) {
+	if b.onError != nil {
+		b.onError(err)
+	}
+}
+
+// terminator finds the BEL or ST (ESC \) ending an OSC sequence, returning its index and length
+func terminator(data []byte) (int, int) {
+	for i, c := range data {
+		switch {
+		case c == '\a':
+			return i, 1
+		case c == '\x1b' && i+1 < len(data) && data[i+1] == '\\':
+			return i, 2
+		}
+	}
+	return -1, 0
+}
+
+// partialPrefix returns the longest suffix of data that could begin an OSC 52 sequence
+func partialPrefix(data []byte) []byte {
+	for n := len(osc52Prefix) - 1; n > 0; n-- {
+		if len(data) >= n && bytes.Equal(data[len(data)-n:], osc52Prefix[:n]) {
+			return data[len(data)-n:]
+		}
+	}
+	return nil
+}
+
+// CopyToHost writes data to the host clipboard using the platform's clipboard tool
+func CopyToHost(data []byte) error {
+	name, args, err := hostCopyCommand()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = bytes.NewReader(data)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// hostCopyCommand picks the first available clipboard tool for this host
+func hostCopyCommand() (string, []string, error) {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		candidates = append(candidates,
+			[]string{"xclip", "-selection", "clipboard"},
+			[]string{"xsel", "--clipboard", "--input"},
+			[]string{"clip.exe"}, // WSL
+		)
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err == nil {
+			return candidate[0], candidate[1:], nil
+		}
+	}
+	return "", nil, fmt.Errorf("no clipboard tool found (install wl-clipboard, xclip, or xsel)")
+}
+
+// ReadFromHost returns the host clipboard contents using the platform's clipboard tool
+func ReadFromHost() ([]byte, error) {
+	name, args, err := hostPasteCommand()
+	if err != nil {
+		return nil, err
+	}
+	output, err := exec.Command(name, args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+	return output, nil
+}
+
+// hostPasteCommand picks the first available clipboard reading tool for this host
+func hostPasteCommand() (string, []string, error) {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbpaste"}}
+	case "windows":
+		candidates = [][]string{{"powershell", "-NoProfile", "-Command", "Get-Clipboard -Raw"}}
+	default:
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-paste", "--no-newline"})
+		}
+		candidates = append(candidates,
+			[]string{"xclip", "-selection", "clipboard", "-out"},
+			[]string{"xsel", "--clipboard", "--output"},
+			[]string{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard -Raw"}, // WSL
+		)
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err == nil {
+			return candidate[0], candidate[1:], nil
+		}
+	}
+	return "", nil, fmt.Errorf("no clipboard tool found (install wl-clipboard, xclip, or xsel)")
+}
@@ -0,0 +1,79 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func osc52(text, terminator string) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + terminator
+}
+
+func TestBridge(t *testing.T) {
+	t.Run("passes output through and copies", func(t *testing.T) {
+		var out bytes.Buffer
+		var copied []string
+		bridge := NewBridge(&out, func(data []byte) error {
+			copied = append(copied, string(data))
+			return nil
+		}, nil)
+
+		input := "before " + osc52("hello", "\a") + " middle " + osc52("world", "\x1b\\") + " after"
+		n, err := bridge.Write([]byte(input))
+
+		assert.NoError(t, err)
+		assert.Equal(t, len(input), n)
+		assert.Equal(t, input, out.String())
+		assert.Equal(t, []string{"hello", "world"}, copied)
+	})
+
+	t.Run("reassembles sequences split across writes", func(t *testing.T) {
+		var copied []string
+		bridge := NewBridge(&bytes.Buffer{}, func(data []byte) error {
+			copied = append(copied, string(data))
+			return nil
+		}, nil)
+
+		sequence := "text" + osc52("split copy", "\x1b\\")
+		for i := 0; i < len(sequence); i++ {
+			bridge.Write([]byte{sequence[i]})
+		}
+
+		assert.Equal(t, []string{"split copy"}, copied)
+	})
+
+	t.Run("ignores queries and reports bad data", func(t *testing.T) {
+		var errs []error
+		copies := 0
+		bridge := NewBridge(&bytes.Buffer{}, func(data []byte) error {
+			copies++
+			return nil
+		}, func(err error) { errs = append(errs, err) })
+
+		bridge.Write([]byte("\x1b]52;c;?\a\x1b]52;c;not base64!\a"))
+
+		assert.Zero(t, copies)
+		assert.Len(t, errs, 1)
+	})
+
+	t.Run("reports copy failures", func(t *testing.T) {
+		var errs []error
+		bridge := NewBridge(&bytes.Buffer{}, func(data []byte) error {
+			return errors.New("no clipboard")
+		}, func(err error) { errs = append(errs, err) })
+
+		bridge.Write([]byte(osc52("x", "\a")))
+
+		assert.Len(t, errs, 1)
+	})
+}
+
+func TestPartialPrefix(t *testing.T) {
+	assert.Equal(t, []byte("\x1b]5"), partialPrefix([]byte("abc\x1b]5")))
+	assert.Equal(t, []byte("\x1b"), partialPrefix([]byte("abc\x1b")))
+	assert.Nil(t, partialPrefix([]byte("abc")))
+}
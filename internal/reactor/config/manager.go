@@ -1,33 +1,62 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 
+	"claude-reactor/internal/reactor/detection"
+	"claude-reactor/internal/reactor/variants"
 	"claude-reactor/pkg"
 )
 
 // manager implements the ConfigManager interface
 type manager struct {
-	logger pkg.Logger
+	logger    pkg.Logger
+	detectors *detection.Registry
+	// external registers detectors for external variants on first use, keeping startup
+	// free of variants.d reads for commands that never detect projects
+	external sync.Once
 }
 
-// NewManager creates a new configuration manager
+// NewManager creates a new configuration manager. External variants with detection rules
+// from ~/.claude-reactor/variants.d are offered in auto-detection.
 func NewManager(logger pkg.Logger) pkg.ConfigManager {
 	return &manager{
-		logger: logger,
+		logger:    logger,
+		detectors: detection.NewRegistry(),
+	}
+}
+
+// registerExternalDetectors adds detectors for external variants that define detection rules
+func (m *manager) registerExternalDetectors() {
+	definitions, errs := variants.LoadDir(variants.Dir())
+	for _, err := range errs {
+		m.logger.Warnf("Skipping external variant: %v", err)
+	}
+	for _, definition := range definitions {
+		if definition.Detect != nil {
+			m.detectors.Register(detection.VariantDetector(definition))
+		}
 	}
 }
 
 // LoadConfig loads configuration from file or creates default
 func (m *manager) LoadConfig() (*pkg.Config, error) {
+	return m.LoadProjectConfig(".")
+}
+
+// LoadProjectConfig loads the configuration of the project in projectDir or creates default
+func (m *manager) LoadProjectConfig(projectDir string) (*pkg.Config, error) {
 	config := m.GetDefaultConfig()
 
 	// Try to read .claude-reactor file
-	if data, err := os.ReadFile(".claude-reactor"); err == nil {
+	if data, err := os.ReadFile(filepath.Join(projectDir, ".claude-reactor")); err == nil {
 		// Parse bash-style config file
 		lines := strings.Split(string(data), "\n")
 		for _, line := range lines {
@@ -67,6 +96,74 @@ func (m *manager) LoadConfig() (*pkg.Config, error) {
 				config.ContainerID = value
 			case "project_path":
 				config.ProjectPath = value
+			case "toolchain_install":
+				config.ToolchainInstall = value == "true"
+			case "tools.required":
+				config.RequiredTools = splitList(value)
+			case "tools.recommended":
+				config.RecommendedTools = splitList(value)
+			case "tools_install":
+				config.ToolsInstall = value == "true"
+			case "vuln_threshold":
+				config.VulnThreshold = value
+			case "sync_mode":
+				config.SyncMode = value == "true"
+			case "clipboard":
+				config.Clipboard = value == "true"
+			case "platform":
+				config.Platform = value
+			case "image_cache_ttl":
+				config.ImageCacheTTL = value
+			case "image_cache_size":
+				config.ImageCacheSize, _ = strconv.Atoi(value)
+			case "image_cache_url":
+				config.ImageCacheURL = value
+			case "claude_cli_version":
+				config.ClaudeCLIVersion = value
+			case "notifications":
+				config.Notifications = value
+			case "notify_after":
+				config.NotifyAfter = value
+			case "registry_mirror":
+				config.RegistryMirror = value
+			case "claude_args":
+				config.ClaudeArgs = value
+			case "system_prompt":
+				config.SystemPrompt = value
+			case "mounts":
+				config.Mounts = value
+			case "tmpfs":
+				config.Tmpfs = value
+			case "checkpoints":
+				config.Checkpoints = value
+			case "protected_paths":
+				config.ProtectedPaths = value
+			case "secret_scan":
+				config.SecretScan = value
+			case "dns":
+				config.DNS = value
+			case "dns_search":
+				config.DNSSearch = value
+			case "add_hosts":
+				config.AddHosts = value
+			case "dotfiles":
+				config.Dotfiles = value
+			case "dotfiles_install":
+				config.DotfilesInstall = value
+			case "hostname":
+				config.Hostname = value
+			case "prompt":
+				config.Prompt = value
+			default:
+				if name, ok := strings.CutPrefix(key, "task."); ok {
+					loadTask(config, name, value)
+				} else if name, ok := strings.CutPrefix(key, "secret."); ok {
+					config.Secrets = append(config.Secrets, pkg.Secret{Name: name, Source: value})
+				} else if manager, ok := strings.CutPrefix(key, "packages."); ok {
+					if list := config.Packages.List(manager); list != nil {
+						*list = splitList(value)
+					}
+				}
 			}
 		}
 
@@ -82,11 +179,7 @@ func (m *manager) LoadConfig() (*pkg.Config, error) {
 func (m *manager) SaveConfig(config *pkg.Config) error {
 	// Simple stub implementation for backward compatibility
 	// Write basic .claude-reactor file in bash script format
-	file, err := os.Create(".claude-reactor")
-	if err != nil {
-		return fmt.Errorf("failed to create config file: %w", err)
-	}
-	defer file.Close()
+	file := &bytes.Buffer{}
 
 	// Write configuration in bash script format for compatibility
 	fmt.Fprintf(file, "variant=%s\n", config.Variant)
@@ -120,11 +213,164 @@ func (m *manager) SaveConfig(config *pkg.Config) error {
 	if config.ProjectPath != "" {
 		fmt.Fprintf(file, "project_path=%s\n", config.ProjectPath)
 	}
+	if config.ToolchainInstall {
+		fmt.Fprintf(file, "toolchain_install=true\n")
+	}
+	if len(config.RequiredTools) > 0 {
+		fmt.Fprintf(file, "tools.required=%s\n", strings.Join(config.RequiredTools, ","))
+	}
+	if len(config.RecommendedTools) > 0 {
+		fmt.Fprintf(file, "tools.recommended=%s\n", strings.Join(config.RecommendedTools, ","))
+	}
+	if config.ToolsInstall {
+		fmt.Fprintf(file, "tools_install=true\n")
+	}
+	if config.VulnThreshold != "" {
+		fmt.Fprintf(file, "vuln_threshold=%s\n", config.VulnThreshold)
+	}
+	if config.SyncMode {
+		fmt.Fprintf(file, "sync_mode=true\n")
+	}
+	if config.Clipboard {
+		fmt.Fprintf(file, "clipboard=true\n")
+	}
+	if config.Platform != "" {
+		fmt.Fprintf(file, "platform=%s\n", config.Platform)
+	}
+	if config.ImageCacheTTL != "" {
+		fmt.Fprintf(file, "image_cache_ttl=%s\n", config.ImageCacheTTL)
+	}
+	if config.ImageCacheSize > 0 {
+		fmt.Fprintf(file, "image_cache_size=%d\n", config.ImageCacheSize)
+	}
+	if config.ImageCacheURL != "" {
+		fmt.Fprintf(file, "image_cache_url=%s\n", config.ImageCacheURL)
+	}
+	if config.ClaudeCLIVersion != "" {
+		fmt.Fprintf(file, "claude_cli_version=%s\n", config.ClaudeCLIVersion)
+	}
+	if config.Notifications != "" {
+		fmt.Fprintf(file, "notifications=%s\n", config.Notifications)
+	}
+	if config.NotifyAfter != "" {
+		fmt.Fprintf(file, "notify_after=%s\n", config.NotifyAfter)
+	}
+	if config.RegistryMirror != "" {
+		fmt.Fprintf(file, "registry_mirror=%s\n", config.RegistryMirror)
+	}
+	if config.ClaudeArgs != "" {
+		fmt.Fprintf(file, "claude_args=%s\n", config.ClaudeArgs)
+	}
+	if config.SystemPrompt != "" {
+		fmt.Fprintf(file, "system_prompt=%s\n", config.SystemPrompt)
+	}
+	if config.Mounts != "" {
+		fmt.Fprintf(file, "mounts=%s\n", config.Mounts)
+	}
+	if config.Tmpfs != "" {
+		fmt.Fprintf(file, "tmpfs=%s\n", config.Tmpfs)
+	}
+	if config.Checkpoints != "" {
+		fmt.Fprintf(file, "checkpoints=%s\n", config.Checkpoints)
+	}
+	if config.ProtectedPaths != "" {
+		fmt.Fprintf(file, "protected_paths=%s\n", config.ProtectedPaths)
+	}
+	if config.SecretScan != "" {
+		fmt.Fprintf(file, "secret_scan=%s\n", config.SecretScan)
+	}
+	if config.DNS != "" {
+		fmt.Fprintf(file, "dns=%s\n", config.DNS)
+	}
+	if config.DNSSearch != "" {
+		fmt.Fprintf(file, "dns_search=%s\n", config.DNSSearch)
+	}
+	if config.AddHosts != "" {
+		fmt.Fprintf(file, "add_hosts=%s\n", config.AddHosts)
+	}
+	if config.Dotfiles != "" {
+		fmt.Fprintf(file, "dotfiles=%s\n", config.Dotfiles)
+	}
+	if config.DotfilesInstall != "" {
+		fmt.Fprintf(file, "dotfiles_install=%s\n", config.DotfilesInstall)
+	}
+	if config.Hostname != "" {
+		fmt.Fprintf(file, "hostname=%s\n", config.Hostname)
+	}
+	if config.Prompt != "" {
+		fmt.Fprintf(file, "prompt=%s\n", config.Prompt)
+	}
+	for _, manager := range pkg.PackageManagers {
+		if list := *config.Packages.List(manager); len(list) > 0 {
+			fmt.Fprintf(file, "packages.%s=%s\n", manager, strings.Join(list, ","))
+		}
+	}
+	for _, secret := range config.Secrets {
+		fmt.Fprintf(file, "secret.%s=%s\n", secret.Name, secret.Source)
+	}
+	for _, task := range config.Tasks {
+		fmt.Fprintf(file, "task.%s=%s\n", task.Name, task.Command)
+		if len(task.Env) > 0 {
+			fmt.Fprintf(file, "task.%s.env=%s\n", task.Name, strings.Join(task.Env, ","))
+		}
+		if task.Workdir != "" {
+			fmt.Fprintf(file, "task.%s.workdir=%s\n", task.Name, task.Workdir)
+		}
+	}
+
+	// Replace the file atomically so concurrent readers never see a partial write
+	tmpPath := fmt.Sprintf(".claude-reactor.%d.tmp", os.Getpid())
+	if err := os.WriteFile(tmpPath, file.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to create config file: %w", err)
+	}
+	if err := os.Rename(tmpPath, ".claude-reactor"); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to save config file: %w", err)
+	}
 
 	m.logger.Infof("Configuration saved: variant=%s, account=%s, session_persistence=%t", config.Variant, config.Account, config.SessionPersistence)
 	return nil
 }
 
+// loadTask applies a task.NAME, task.NAME.env or task.NAME.workdir setting to the task it names
+func loadTask(config *pkg.Config, key, value string) {
+	name, field, _ := strings.Cut(key, ".")
+	task := FindTask(config, name)
+	if task == nil {
+		config.Tasks = append(config.Tasks, pkg.Task{Name: name})
+		task = &config.Tasks[len(config.Tasks)-1]
+	}
+	switch field {
+	case "":
+		task.Command = value
+	case "env":
+		task.Env = splitList(value)
+	case "workdir":
+		task.Workdir = value
+	}
+}
+
+// FindTask returns the project task called name, or nil
+func FindTask(config *pkg.Config, name string) *pkg.Task {
+	for i := range config.Tasks {
+		if config.Tasks[i].Name == name {
+			return &config.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// splitList splits a comma-separated setting, dropping empty entries
+func splitList(value string) []string {
+	var list []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
 // ValidateConfig validates configuration structure and values
 func (m *manager) ValidateConfig(config *pkg.Config) error {
 	if config == nil {
@@ -136,11 +382,8 @@ func (m *manager) ValidateConfig(config *pkg.Config) error {
 	}
 
 	// Check if it's a built-in variant
-	builtinVariants := []string{"base", "go", "full", "cloud", "k8s"}
-	for _, variant := range builtinVariants {
-		if config.Variant == variant {
-			return nil // Built-in variant is always valid
-		}
+	if variants.IsBuiltin(config.Variant) {
+		return nil // Built-in variant is always valid
 	}
 
 	// If not a built-in variant, treat as custom Docker image
@@ -164,70 +407,68 @@ func (m *manager) GetDefaultConfig() *pkg.Config {
 	}
 }
 
-// AutoDetectVariant performs basic project type auto-detection
+// AutoDetectVariant performs project type auto-detection using the detector registry
 func (m *manager) AutoDetectVariant(projectPath string) (string, error) {
-	if projectPath == "" {
-		var err error
-		projectPath, err = os.Getwd()
-		if err != nil {
-			return "base", err
-		}
+	result, err := m.DetectProject(projectPath)
+	if err != nil {
+		return "base", err
 	}
 
-	// Check project directory for project indicators
-	if _, err := os.Stat(filepath.Join(projectPath, "go.mod")); err == nil {
-		m.logger.Debug("Detected Go project (go.mod found)")
-		return "go", nil
+	if result.ProjectType == "unknown" {
+		m.logger.Debug("No project type detected, using base variant")
+	} else {
+		m.logger.Debug(fmt.Sprintf("Detected %s project (%s), using %s variant",
+			result.ProjectType, strings.Join(result.Files, ", "), result.Variant))
 	}
-
-	if _, err := os.Stat(filepath.Join(projectPath, "Cargo.toml")); err == nil {
-		m.logger.Debug("Detected Rust project (Cargo.toml found)")
-		return "full", nil // Rust is in the full variant
+	if uncovered := result.Metadata["uncovered_variants"]; uncovered != "" {
+		m.logger.Warnf("No built-in variant includes all of %s; using %s. Set a variant or custom image that covers the project.",
+			uncovered, result.Variant)
 	}
 
-	if _, err := os.Stat(filepath.Join(projectPath, "package.json")); err == nil {
-		m.logger.Debug("Detected Node.js project (package.json found)")
-		return "base", nil // Node.js is in base
-	}
+	return result.Variant, nil
+}
 
-	if _, err := os.Stat(filepath.Join(projectPath, "requirements.txt")); err == nil {
-		m.logger.Debug("Detected Python project (requirements.txt found)")
-		return "base", nil // Python is in base
+// DetectProject runs all registered detectors and returns the combined detection result
+func (m *manager) DetectProject(projectPath string) (*pkg.ProjectDetectionResult, error) {
+	if projectPath == "" {
+		var err error
+		projectPath, err = os.Getwd()
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if _, err := os.Stat(filepath.Join(projectPath, "pom.xml")); err == nil {
-		m.logger.Debug("Detected Java project (pom.xml found)")
-		return "full", nil // Java is in full
-	}
+	m.external.Do(m.registerExternalDetectors)
+	return m.detectors.Detect(projectPath), nil
+}
 
-	// Check for Kubernetes files
-	if _, err := os.Stat(filepath.Join(projectPath, "helm")); err == nil {
-		return "k8s", nil
-	}
-	if _, err := os.Stat(filepath.Join(projectPath, "k8s")); err == nil {
-		return "k8s", nil
-	}
-	if _, err := os.Stat(filepath.Join(projectPath, "kubernetes")); err == nil {
-		return "k8s", nil
+// ListAccounts returns available Claude accounts
+// Each account has a session directory under ~/.claude-reactor/{account}/
+func (m *manager) ListAccounts() ([]string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	// Check for cloud configuration files
-	if _, err := os.Stat(filepath.Join(projectPath, ".aws")); err == nil {
-		return "cloud", nil
+	entries, err := os.ReadDir(filepath.Join(homeDir, ".claude-reactor"))
+	if os.IsNotExist(err) {
+		return []string{}, nil
 	}
-	if _, err := os.Stat(filepath.Join(projectPath, "terraform")); err == nil {
-		return "cloud", nil
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accounts directory: %w", err)
 	}
 
-	m.logger.Debug("No project type detected, using base variant")
-	return "base", nil
-}
+	accounts := []string{}
+	for _, entry := range entries {
+		// Skip hidden entries and shared caches that live alongside account directories
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || entry.Name() == "image-cache" {
+			continue
+		}
+		accounts = append(accounts, entry.Name())
+	}
 
-// ListAccounts returns available Claude accounts
-func (m *manager) ListAccounts() ([]string, error) {
-	// TODO: Implement account listing from ~/.claude-reactor directory
-	m.logger.Debug("Listing Claude accounts (stub implementation)")
-	return []string{"default"}, nil
+	m.logger.Debug(fmt.Sprintf("Found %d Claude accounts", len(accounts)))
+	return accounts, nil
 }
 
 // isValidDockerImageName validates basic Docker image name format
@@ -278,7 +519,7 @@ func (m *manager) DetectSSHAgent() (string, error) {
 		m.logger.Debugf("SSH_AUTH_SOCK points to non-existent socket: %s", socketPath)
 	}
 
-	return "", fmt.Errorf("no SSH agent detected: SSH_AUTH_SOCK not set or socket not accessible\n💡 Start SSH agent: eval $(ssh-agent)\n💡 Add keys: ssh-add ~/.ssh/id_ed25519\n💡 Verify: ssh-add -l")
+	return "", pkg.NewError(pkg.CodeSSHAgentMissing, nil, "no SSH agent detected: SSH_AUTH_SOCK not set or socket not accessible")
 }
 
 // ValidateSSHAgent tests SSH agent connectivity
@@ -289,7 +530,7 @@ func (m *manager) ValidateSSHAgent(socketPath string) error {
 
 	// Check if socket file exists
 	if _, err := os.Stat(socketPath); err != nil {
-		return fmt.Errorf("SSH agent socket not accessible: %s\n💡 Ensure SSH agent is running: eval $(ssh-agent)\n💡 Check socket permissions", socketPath)
+		return pkg.NewError(pkg.CodeSSHAgentSocket, nil, "SSH agent socket not accessible: %s", socketPath)
 	}
 
 	// Test agent connectivity by setting SSH_AUTH_SOCK and running ssh-add -l
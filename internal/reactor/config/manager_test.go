@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"claude-reactor/pkg"
 )
@@ -162,6 +163,128 @@ func TestManager_SaveConfig_Minimal(t *testing.T) {
 	assert.NotContains(t, contentStr, "danger=", "Should not contain false danger mode")
 }
 
+func TestManager_Tasks(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	mockLogger := &MockLogger{}
+	mockLogger.On("Infof", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	mockLogger.On("Debug", mock.Anything).Maybe()
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Maybe()
+	manager := NewManager(mockLogger)
+
+	require.NoError(t, os.WriteFile(".claude-reactor", []byte("variant=go\n"+
+		"task.test=go test ./...\n"+
+		"task.test.env=GOFLAGS=-count=1, CGO_ENABLED=0\n"+
+		"task.lint.workdir=api\n"+
+		"task.lint=golangci-lint run\n"), 0644))
+
+	config, err := manager.LoadConfig()
+	require.NoError(t, err)
+	expected := []pkg.Task{
+		{Name: "test", Command: "go test ./...", Env: []string{"GOFLAGS=-count=1", "CGO_ENABLED=0"}},
+		{Name: "lint", Command: "golangci-lint run", Workdir: "api"},
+	}
+	assert.Equal(t, expected, config.Tasks)
+	assert.Equal(t, "golangci-lint run", FindTask(config, "lint").Command)
+	assert.Nil(t, FindTask(config, "build"))
+
+	require.NoError(t, manager.SaveConfig(config))
+	reloaded, err := manager.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, expected, reloaded.Tasks)
+}
+
+func TestManager_Packages(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	mockLogger := &MockLogger{}
+	mockLogger.On("Infof", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	mockLogger.On("Debug", mock.Anything).Maybe()
+	manager := NewManager(mockLogger)
+
+	require.NoError(t, os.WriteFile(".claude-reactor", []byte("variant=go\n"+
+		"packages.apt=protobuf-compiler, jq\n"+
+		"packages.npm=@bufbuild/buf\n"+
+		"packages.pip=\n"+
+		"packages.brew=wget\n"), 0644))
+
+	config, err := manager.LoadConfig()
+	require.NoError(t, err)
+	expected := pkg.Packages{Apt: []string{"protobuf-compiler", "jq"}, Npm: []string{"@bufbuild/buf"}}
+	assert.Equal(t, expected, config.Packages)
+
+	require.NoError(t, manager.SaveConfig(config))
+	content, err := os.ReadFile(".claude-reactor")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "packages.apt=protobuf-compiler,jq\n")
+	assert.NotContains(t, string(content), "packages.pip")
+
+	reloaded, err := manager.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, expected, reloaded.Packages)
+}
+
+func TestManager_Secrets(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	mockLogger := &MockLogger{}
+	mockLogger.On("Infof", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	mockLogger.On("Debug", mock.Anything).Maybe()
+	manager := NewManager(mockLogger)
+
+	require.NoError(t, os.WriteFile(".claude-reactor", []byte("variant=go\n"+
+		"secret.GITHUB_TOKEN=keychain\n"+
+		"secret.DB_PASSWORD=vault:secret/data/app#password\n"), 0644))
+
+	config, err := manager.LoadConfig()
+	require.NoError(t, err)
+	expected := []pkg.Secret{
+		{Name: "GITHUB_TOKEN", Source: "keychain"},
+		{Name: "DB_PASSWORD", Source: "vault:secret/data/app#password"},
+	}
+	assert.Equal(t, expected, config.Secrets)
+
+	require.NoError(t, manager.SaveConfig(config))
+	reloaded, err := manager.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, expected, reloaded.Secrets)
+}
+
+func TestManager_Tools(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	mockLogger := &MockLogger{}
+	mockLogger.On("Infof", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	mockLogger.On("Debug", mock.Anything).Maybe()
+	manager := NewManager(mockLogger)
+
+	require.NoError(t, os.WriteFile(".claude-reactor", []byte("variant=go\n"+
+		"tools.required=git, protoc:protobuf-compiler>=3.21\n"+
+		"tools.recommended=jq>=1.6\n"+
+		"tools_install=true\n"), 0644))
+
+	config, err := manager.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git", "protoc:protobuf-compiler>=3.21"}, config.RequiredTools)
+	assert.Equal(t, []string{"jq>=1.6"}, config.RecommendedTools)
+	assert.True(t, config.ToolsInstall)
+
+	require.NoError(t, manager.SaveConfig(config))
+	reloaded, err := manager.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, config.RequiredTools, reloaded.RequiredTools)
+	assert.Equal(t, config.RecommendedTools, reloaded.RecommendedTools)
+	assert.True(t, reloaded.ToolsInstall)
+}
+
 func TestManager_ValidateConfig(t *testing.T) {
 	mockLogger := &MockLogger{}
 	manager := NewManager(mockLogger)
@@ -427,4 +550,31 @@ func BenchmarkManager_ValidateConfig(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = manager.ValidateConfig(config)
 	}
-}
\ No newline at end of file
+}
+func TestManager_ListAccounts(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+
+	mockLogger := &MockLogger{}
+	mockLogger.On("Debug", mock.Anything).Maybe()
+
+	manager := NewManager(mockLogger)
+
+	t.Run("no claude-reactor directory", func(t *testing.T) {
+		accounts, err := manager.ListAccounts()
+		assert.NoError(t, err)
+		assert.Empty(t, accounts)
+	})
+
+	t.Run("lists account directories only", func(t *testing.T) {
+		reactorDir := filepath.Join(homeDir, ".claude-reactor")
+		for _, dir := range []string{"work", "personal", "image-cache", ".hidden"} {
+			require.NoError(t, os.MkdirAll(filepath.Join(reactorDir, dir), 0755))
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(reactorDir, ".work-claude.json"), []byte("{}"), 0644))
+
+		accounts, err := manager.ListAccounts()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"work", "personal"}, accounts)
+	})
+}
@@ -40,7 +40,7 @@ func TestDetectSSHAgent(t *testing.T) {
 		_, err := mgr.DetectSSHAgent()
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no SSH agent detected")
-		assert.Contains(t, err.Error(), "Start SSH agent: eval $(ssh-agent)")
+		assert.Contains(t, pkg.Remediation(err), "Start SSH agent: eval $(ssh-agent)")
 	})
 
 	t.Run("SSH_AUTH_SOCK points to non-existent file", func(t *testing.T) {
@@ -87,7 +87,7 @@ func TestValidateSSHAgent(t *testing.T) {
 		err := mgr.ValidateSSHAgent("/non/existent/socket")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "SSH agent socket not accessible")
-		assert.Contains(t, err.Error(), "Ensure SSH agent is running")
+		assert.Contains(t, pkg.Remediation(err), "Ensure SSH agent is running: eval $(ssh-agent)")
 	})
 
 	t.Run("existing socket file", func(t *testing.T) {
@@ -1,6 +1,9 @@
 package reactor
 
 import (
+	"context"
+	"time"
+
 	"claude-reactor/internal/reactor/architecture"
 	"claude-reactor/internal/reactor/auth"
 	"claude-reactor/internal/reactor/config"
@@ -8,10 +11,12 @@ import (
 	"claude-reactor/internal/reactor/docker/validation"
 	"claude-reactor/internal/reactor/logging"
 	"claude-reactor/internal/reactor/mount"
+	"claude-reactor/internal/reactor/timing"
 	"claude-reactor/pkg"
 )
 
-// NewAppContainer creates and initializes the application dependency container
+// NewAppContainer creates and initializes the application dependency container.
+// Nothing here touches Docker or the network, so commands that don't need Docker start quickly.
 func NewAppContainer(debug bool, verbose bool, logLevel string) (*pkg.AppContainer, error) {
 	// Initialize logger first with provided settings
 	logger := logging.NewLoggerWithFlags(debug, verbose, logLevel)
@@ -28,6 +33,8 @@ func NewAppContainer(debug bool, verbose bool, logLevel string) (*pkg.AppContain
 	// Initialize mount manager
 	mountMgr := mount.NewManager(logger)
 
+	timing.Mark("app container")
+
 	// Docker components are initialized lazily when needed
 	return &pkg.AppContainer{
 		ArchDetector:   archDetector,
@@ -43,17 +50,28 @@ func NewAppContainer(debug bool, verbose bool, logLevel string) (*pkg.AppContain
 
 // EnsureDockerComponents initializes Docker components lazily if they haven't been initialized yet
 func EnsureDockerComponents(app *pkg.AppContainer) error {
+	return EnsureDockerComponentsContext(context.Background(), app)
+}
+
+// EnsureDockerComponentsContext is EnsureDockerComponents with a context bounding how long
+// to wait for the daemon, for callers such as shell completion that must not hang
+func EnsureDockerComponentsContext(ctx context.Context, app *pkg.AppContainer) error {
 	if app.DockerMgr == nil {
+		start := time.Now()
+
 		// Initialize Docker manager
-		dockerMgr, err := docker.NewManager(app.Logger)
+		dockerMgr, err := docker.NewManagerContext(ctx, app.Logger)
 		if err != nil {
 			return err
 		}
 		app.DockerMgr = dockerMgr
 
-		// Initialize image validator
-		imageValidator := validation.NewImageValidator(dockerMgr.GetClient(), app.Logger)
+		// Initialize image validator, with chaos mode's faults applied to its pulls too
+		imageValidator := validation.NewImageValidator(docker.APIClient(dockerMgr), app.Logger)
 		app.ImageValidator = imageValidator
+		dockerMgr.SetImageCache(imageValidator)
+
+		timing.Track("docker connect", start)
 	}
 	return nil
 }
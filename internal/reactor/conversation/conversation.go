@@ -0,0 +1,131 @@
+// Package conversation names Claude CLI sessions, so one project can keep several threads
+// going. Names map to Claude session IDs, stored in the project's session directory.
+package conversation
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// FileName holds a project's named conversations, in its session directory
+const FileName = "conversations.json"
+
+// namePattern keeps conversation names easy to type
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Conversation is a named Claude session
+type Conversation struct {
+	Name     string    `json:"name"`
+	ID       string    `json:"id"` // Claude session ID
+	Created  time.Time `json:"created"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// ValidateName checks a conversation name
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid conversation name '%s': use letters, digits, '.', '-' and '_'", name)
+	}
+	return nil
+}
+
+// Load returns the named conversations of the project whose session directory is sessionDir,
+// most recently used first
+func Load(sessionDir string) ([]Conversation, error) {
+	data, err := os.ReadFile(filepath.Join(sessionDir, FileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read conversations: %w", err)
+	}
+	var conversations []Conversation
+	if err := json.Unmarshal(data, &conversations); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(sessionDir, FileName), err)
+	}
+	sort.Slice(conversations, func(i, j int) bool { return conversations[i].LastUsed.After(conversations[j].LastUsed) })
+	return conversations, nil
+}
+
+// save stores the project's named conversations
+func save(sessionDir string, conversations []Conversation) error {
+	data, err := json.MarshalIndent(conversations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode conversations: %w", err)
+	}
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	return os.WriteFile(filepath.Join(sessionDir, FileName), data, 0644)
+}
+
+// Use returns the conversation called name, creating it with a new session ID the first time,
+// and records that it was used
+func Use(sessionDir, name string) (*Conversation, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	conversations, err := Load(sessionDir)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var found *Conversation
+	for i := range conversations {
+		if conversations[i].Name == name {
+			found = &conversations[i]
+			break
+		}
+	}
+	if found == nil {
+		id, err := newSessionID()
+		if err != nil {
+			return nil, err
+		}
+		conversations = append(conversations, Conversation{Name: name, ID: id, Created: now})
+		found = &conversations[len(conversations)-1]
+	}
+	found.LastUsed = now
+	used := *found
+	if err := save(sessionDir, conversations); err != nil {
+		return nil, err
+	}
+	return &used, nil
+}
+
+// Transcript returns the file Claude CLI keeps the session's messages in, under sessionDir
+// (the container's ~/.claude), or "" when the session has no messages yet
+func Transcript(sessionDir, id string) string {
+	matches, _ := filepath.Glob(filepath.Join(sessionDir, "projects", "*", id+".jsonl"))
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[0]
+}
+
+// ClaudeArgs returns the Claude CLI arguments that continue the conversation, or start it
+// under its session ID when Claude has not recorded it yet
+func ClaudeArgs(sessionDir string, c *Conversation) []string {
+	if Transcript(sessionDir, c.ID) != "" {
+		return []string{"--resume", c.ID}
+	}
+	return []string{"--session-id", c.ID}
+}
+
+// newSessionID returns a random version 4 UUID, the form Claude CLI expects for session IDs
+func newSessionID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,65 @@
+package conversation
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateName(t *testing.T) {
+	assert.NoError(t, ValidateName("refactor"))
+	assert.NoError(t, ValidateName("Bug-123_v2.1"))
+	assert.Error(t, ValidateName(""))
+	assert.Error(t, ValidateName("-leading"))
+	assert.Error(t, ValidateName("has space"))
+	assert.Error(t, ValidateName("a/b"))
+}
+
+func TestUse(t *testing.T) {
+	dir := t.TempDir()
+
+	conversations, err := Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, conversations)
+
+	first, err := Use(dir, "refactor")
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), first.ID)
+
+	other, err := Use(dir, "docs")
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, other.ID)
+
+	again, err := Use(dir, "refactor")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+	assert.Equal(t, first.Created.Unix(), again.Created.Unix())
+
+	conversations, err = Load(dir)
+	require.NoError(t, err)
+	require.Len(t, conversations, 2)
+	assert.Equal(t, "refactor", conversations[0].Name, "most recently used first")
+
+	_, err = Use(dir, "bad name")
+	assert.Error(t, err)
+}
+
+func TestClaudeArgs(t *testing.T) {
+	dir := t.TempDir()
+	c, err := Use(dir, "refactor")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"--session-id", c.ID}, ClaudeArgs(dir, c))
+	assert.Equal(t, "", Transcript(dir, c.ID))
+
+	transcript := filepath.Join(dir, "projects", "-app", c.ID+".jsonl")
+	require.NoError(t, os.MkdirAll(filepath.Dir(transcript), 0755))
+	require.NoError(t, os.WriteFile(transcript, []byte("{}\n"), 0644))
+
+	assert.Equal(t, transcript, Transcript(dir, c.ID))
+	assert.Equal(t, []string{"--resume", c.ID}, ClaudeArgs(dir, c))
+}
@@ -0,0 +1,216 @@
+package detection
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"claude-reactor/pkg"
+)
+
+// Marker is a file or directory whose presence indicates a project type
+type Marker struct {
+	// Pattern is a glob relative to the project root (e.g. "go.mod", "*.csproj")
+	Pattern string
+	// Contains optionally requires the matched file to contain this substring
+	Contains string
+	// Confidence is the score contributed when the marker matches (0.0 - 1.0)
+	Confidence float64
+	// Framework and Tool are reported when the marker matches
+	Framework string
+	Tool      string
+}
+
+// MarkerDetector recognises a project type from a list of file markers
+type MarkerDetector struct {
+	ID        string
+	Language  string
+	Variant   string
+	Toolchain string
+	Markers   []Marker
+}
+
+// VariantDetector builds a detector from an external variant's detection rules
+func VariantDetector(definition *pkg.VariantDefinition) *MarkerDetector {
+	detector := &MarkerDetector{
+		ID:       "variant:" + definition.Name,
+		Language: definition.Detect.Language,
+		Variant:  definition.Name,
+	}
+	if detector.Language == "" {
+		detector.Language = definition.Name
+	}
+	for _, marker := range definition.Detect.Markers {
+		detector.Markers = append(detector.Markers, Marker{
+			Pattern:    marker.Pattern,
+			Contains:   marker.Contains,
+			Confidence: marker.Confidence,
+			Framework:  marker.Framework,
+			Tool:       marker.Tool,
+		})
+	}
+	return detector
+}
+
+// Name returns the detector identifier
+func (d *MarkerDetector) Name() string {
+	return d.ID
+}
+
+// Detect checks each marker and returns a match scored by the strongest marker found
+func (d *MarkerDetector) Detect(projectPath string) *Match {
+	var match *Match
+
+	for _, marker := range d.Markers {
+		files := matchMarker(projectPath, marker)
+		if len(files) == 0 {
+			continue
+		}
+
+		if match == nil {
+			match = &Match{
+				Language:  d.Language,
+				Variant:   d.Variant,
+				Toolchain: d.Toolchain,
+			}
+		}
+		if marker.Confidence > match.Confidence {
+			match.Confidence = marker.Confidence
+		}
+		match.Files = appendUnique(match.Files, files...)
+		match.Frameworks = appendUnique(match.Frameworks, marker.Framework)
+		match.Tools = appendUnique(match.Tools, marker.Tool)
+	}
+
+	return match
+}
+
+// matchMarker returns the project-relative paths matching a marker
+func matchMarker(projectPath string, marker Marker) []string {
+	paths, err := filepath.Glob(filepath.Join(projectPath, marker.Pattern))
+	if err != nil {
+		return nil
+	}
+
+	var matched []string
+	for _, path := range paths {
+		if marker.Contains != "" {
+			data, err := os.ReadFile(path)
+			if err != nil || !strings.Contains(string(data), marker.Contains) {
+				continue
+			}
+		}
+		if rel, err := filepath.Rel(projectPath, path); err == nil {
+			matched = append(matched, rel)
+		}
+	}
+	return matched
+}
+
+// builtinDetectors returns the detectors shipped with claude-reactor
+func builtinDetectors() []Detector {
+	return []Detector{
+		&MarkerDetector{
+			ID: "go", Language: "go", Variant: "go",
+			Markers: []Marker{
+				{Pattern: "go.mod", Confidence: 0.95, Tool: "go"},
+				{Pattern: "go.work", Confidence: 0.95, Framework: "go-workspace", Tool: "go"},
+			},
+		},
+		&MarkerDetector{
+			ID: "rust", Language: "rust", Variant: "full",
+			Markers: []Marker{
+				{Pattern: "Cargo.toml", Confidence: 0.95, Tool: "cargo"},
+				{Pattern: "Cargo.toml", Contains: "[workspace]", Confidence: 0.95, Framework: "cargo-workspace"},
+				{Pattern: "rust-toolchain.toml", Confidence: 0.8, Tool: "rustup"},
+			},
+		},
+		&MarkerDetector{
+			ID: "node", Language: "node", Variant: "base",
+			Markers: []Marker{
+				{Pattern: "package.json", Confidence: 0.9, Tool: "npm"},
+				{Pattern: "yarn.lock", Confidence: 0.9, Tool: "yarn"},
+				{Pattern: "pnpm-lock.yaml", Confidence: 0.9, Tool: "pnpm"},
+				{Pattern: "tsconfig.json", Confidence: 0.8, Framework: "typescript"},
+				{Pattern: "package.json", Contains: "\"next\"", Confidence: 0.9, Framework: "nextjs"},
+				{Pattern: "package.json", Contains: "\"react\"", Confidence: 0.9, Framework: "react"},
+				{Pattern: "package.json", Contains: "\"vue\"", Confidence: 0.9, Framework: "vue"},
+				{Pattern: "package.json", Contains: "\"express\"", Confidence: 0.9, Framework: "express"},
+			},
+		},
+		&MarkerDetector{
+			ID: "python", Language: "python", Variant: "base",
+			Markers: []Marker{
+				{Pattern: "requirements.txt", Confidence: 0.85, Tool: "pip"},
+				{Pattern: "setup.py", Confidence: 0.85, Tool: "pip"},
+				{Pattern: "pyproject.toml", Confidence: 0.9},
+				{Pattern: "pyproject.toml", Contains: "[tool.poetry]", Confidence: 0.9, Tool: "poetry"},
+				{Pattern: "poetry.lock", Confidence: 0.9, Tool: "poetry"},
+				{Pattern: "uv.lock", Confidence: 0.9, Tool: "uv"},
+				{Pattern: "Pipfile", Confidence: 0.85, Tool: "pipenv"},
+				{Pattern: "manage.py", Confidence: 0.8, Framework: "django"},
+			},
+		},
+		&MarkerDetector{
+			ID: "java", Language: "java", Variant: "full",
+			Markers: []Marker{
+				{Pattern: "pom.xml", Confidence: 0.95, Tool: "maven"},
+				{Pattern: "build.gradle", Confidence: 0.95, Tool: "gradle"},
+				{Pattern: "build.gradle.kts", Confidence: 0.95, Framework: "kotlin", Tool: "gradle"},
+				{Pattern: "settings.gradle*", Confidence: 0.9, Tool: "gradle"},
+				{Pattern: "pom.xml", Contains: "spring-boot", Confidence: 0.95, Framework: "spring-boot"},
+			},
+		},
+		&MarkerDetector{
+			ID: "dotnet", Language: "dotnet", Variant: "base", Toolchain: "dotnet",
+			Markers: []Marker{
+				{Pattern: "*.sln", Confidence: 0.95, Tool: "dotnet"},
+				{Pattern: "*.csproj", Confidence: 0.95, Framework: "csharp", Tool: "dotnet"},
+				{Pattern: "*.fsproj", Confidence: 0.95, Framework: "fsharp", Tool: "dotnet"},
+				{Pattern: "global.json", Confidence: 0.7, Tool: "dotnet"},
+			},
+		},
+		&MarkerDetector{
+			ID: "ruby", Language: "ruby", Variant: "base", Toolchain: "ruby",
+			Markers: []Marker{
+				{Pattern: "Gemfile", Confidence: 0.9, Tool: "bundler"},
+				{Pattern: ".ruby-version", Confidence: 0.7},
+				{Pattern: "config/application.rb", Confidence: 0.9, Framework: "rails"},
+			},
+		},
+		&MarkerDetector{
+			ID: "elixir", Language: "elixir", Variant: "base", Toolchain: "elixir",
+			Markers: []Marker{
+				{Pattern: "mix.exs", Confidence: 0.95, Tool: "mix"},
+				{Pattern: "mix.exs", Contains: ":phoenix", Confidence: 0.95, Framework: "phoenix"},
+			},
+		},
+		&MarkerDetector{
+			ID: "terraform", Language: "terraform", Variant: "cloud",
+			Markers: []Marker{
+				{Pattern: "*.tf", Confidence: 0.85, Tool: "terraform"},
+				{Pattern: "terraform", Confidence: 0.7, Tool: "terraform"},
+				{Pattern: ".terraform.lock.hcl", Confidence: 0.9, Tool: "terraform"},
+			},
+		},
+		&MarkerDetector{
+			ID: "cloud", Language: "cloud", Variant: "cloud",
+			Markers: []Marker{
+				{Pattern: ".aws", Confidence: 0.6, Tool: "aws"},
+				{Pattern: "cdk.json", Confidence: 0.8, Framework: "aws-cdk", Tool: "aws"},
+				{Pattern: "serverless.yml", Confidence: 0.8, Framework: "serverless"},
+			},
+		},
+		&MarkerDetector{
+			ID: "kubernetes", Language: "kubernetes", Variant: "k8s",
+			Markers: []Marker{
+				{Pattern: "helm", Confidence: 0.7, Tool: "helm"},
+				{Pattern: "k8s", Confidence: 0.7, Tool: "kubectl"},
+				{Pattern: "kubernetes", Confidence: 0.7, Tool: "kubectl"},
+				{Pattern: "Chart.yaml", Confidence: 0.9, Framework: "helm-chart", Tool: "helm"},
+				{Pattern: "skaffold.yaml", Confidence: 0.85, Tool: "skaffold"},
+				{Pattern: "kustomization.yaml", Confidence: 0.85, Tool: "kustomize"},
+			},
+		},
+	}
+}
@@ -0,0 +1,208 @@
+package detection
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"claude-reactor/pkg"
+)
+
+// Detector inspects a project directory and reports what it recognises
+type Detector interface {
+	// Name returns a short identifier for the detector (e.g. "go", "terraform")
+	Name() string
+
+	// Detect returns a match for the project, or nil if nothing was recognised
+	Detect(projectPath string) *Match
+}
+
+// Match describes what a single detector found in a project
+type Match struct {
+	Language   string
+	Variant    string
+	Confidence float64
+	Frameworks []string
+	Tools      []string
+	Files      []string
+	// Toolchain is set when the language needs tooling not shipped in any built-in variant
+	Toolchain string
+}
+
+// variantRank orders built-in variants by what they include. Each variant builds on
+// the previous stage, so a higher rank can run everything a lower rank can. Cloud and k8s
+// both build on full and neither includes the other, so they share a rank.
+var variantRank = map[string]int{
+	"base":  0,
+	"go":    1,
+	"full":  2,
+	"cloud": 3,
+	"k8s":   3,
+}
+
+// Registry holds the set of detectors used for project auto-detection
+type Registry struct {
+	mu        sync.RWMutex
+	detectors []Detector
+}
+
+// NewRegistry creates a registry pre-populated with the built-in detectors
+func NewRegistry() *Registry {
+	r := &Registry{}
+	for _, d := range builtinDetectors() {
+		r.Register(d)
+	}
+	return r
+}
+
+// Register adds a detector to the registry, replacing any detector with the same name
+func (r *Registry) Register(d Detector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.detectors {
+		if existing.Name() == d.Name() {
+			r.detectors[i] = d
+			return
+		}
+	}
+	r.detectors = append(r.detectors, d)
+}
+
+// Names returns the names of all registered detectors in registration order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.detectors))
+	for _, d := range r.detectors {
+		names = append(names, d.Name())
+	}
+	return names
+}
+
+// Detect runs every registered detector and combines the matches into a single result.
+// For mixed repositories the chosen variant is the smallest built-in variant that covers
+// every detected stack. When no single variant covers them all (cloud and k8s stacks together)
+// the result falls back to full and lists the variants it leaves out in the
+// "uncovered_variants" metadata.
+func (r *Registry) Detect(projectPath string) *pkg.ProjectDetectionResult {
+	r.mu.RLock()
+	detectors := make([]Detector, len(r.detectors))
+	copy(detectors, r.detectors)
+	r.mu.RUnlock()
+
+	var matches []*Match
+	for _, d := range detectors {
+		if m := d.Detect(projectPath); m != nil {
+			matches = append(matches, m)
+		}
+	}
+
+	result := &pkg.ProjectDetectionResult{
+		ProjectType: "unknown",
+		Variant:     "base",
+		Languages:   []string{},
+		Frameworks:  []string{},
+		Extensions:  []string{},
+		Features:    []string{},
+		Tools:       []string{},
+		Files:       []string{},
+		Metadata:    make(map[string]string),
+	}
+
+	if len(matches) == 0 {
+		return result
+	}
+
+	// Highest confidence first so the primary language leads the result
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+
+	variantScores := make(map[string]float64)
+	var toolchains []string
+	for _, m := range matches {
+		result.Languages = appendUnique(result.Languages, m.Language)
+		result.Frameworks = appendUnique(result.Frameworks, m.Frameworks...)
+		result.Tools = appendUnique(result.Tools, m.Tools...)
+		result.Files = appendUnique(result.Files, m.Files...)
+		result.Metadata["score."+m.Language] = fmt.Sprintf("%.2f", m.Confidence)
+		variantScores[m.Variant] += m.Confidence
+		if m.Toolchain != "" {
+			toolchains = appendUnique(toolchains, m.Toolchain)
+		}
+	}
+
+	result.ProjectType = matches[0].Language
+	result.Confidence = matches[0].Confidence
+	var uncovered []string
+	result.Variant, uncovered = coveringVariant(variantScores)
+	if len(uncovered) > 0 {
+		result.Metadata["uncovered_variants"] = strings.Join(uncovered, ",")
+	}
+	if len(toolchains) > 0 {
+		result.Metadata["missing_toolchains"] = strings.Join(toolchains, ",")
+	}
+
+	return result
+}
+
+// coveringVariant picks the smallest variant that includes every detected stack. If the
+// stacks need built-in variants that do not include each other, it returns full, which they
+// both build on, together with the variants it could not cover.
+func coveringVariant(scores map[string]float64) (string, []string) {
+	variants := make([]string, 0, len(scores))
+	for variant := range scores {
+		variants = append(variants, variant)
+	}
+	sort.Strings(variants)
+
+	best := "base"
+	for _, variant := range variants {
+		// Variants outside the built-in hierarchy are ranked alongside base, and preferred
+		// over it since they were installed specifically for the stacks they detect
+		rank := variantRank[variant]
+		bestRank := variantRank[best]
+		_, builtin := variantRank[variant]
+		if rank > bestRank || (rank == bestRank && (scores[variant] > scores[best] || (best == "base" && !builtin))) {
+			best = variant
+		}
+	}
+
+	var uncovered []string
+	if _, builtin := variantRank[best]; builtin {
+		for _, variant := range variants {
+			if rank, ok := variantRank[variant]; ok && rank == variantRank[best] && variant != best {
+				uncovered = append(uncovered, variant)
+			}
+		}
+	}
+	if len(uncovered) > 0 {
+		uncovered = append(uncovered, best)
+		sort.Strings(uncovered)
+		return "full", uncovered
+	}
+	return best, nil
+}
+
+// appendUnique appends values that are not already present in the slice
+func appendUnique(slice []string, values ...string) []string {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		found := false
+		for _, existing := range slice {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			slice = append(slice, v)
+		}
+	}
+	return slice
+}
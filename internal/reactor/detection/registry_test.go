@@ -0,0 +1,275 @@
+package detection
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestRegistry_Detect(t *testing.T) {
+	tests := []struct {
+		name              string
+		files             map[string]string
+		dirs              []string
+		expectedVariant   string
+		expectedType      string
+		expectedFramework string
+		expectedTool      string
+	}{
+		{
+			name:            "empty project",
+			expectedVariant: "base",
+			expectedType:    "unknown",
+		},
+		{
+			name:            "go module",
+			files:           map[string]string{"go.mod": "module example"},
+			expectedVariant: "go",
+			expectedType:    "go",
+		},
+		{
+			name:              "rust workspace",
+			files:             map[string]string{"Cargo.toml": "[workspace]\nmembers = []"},
+			expectedVariant:   "full",
+			expectedType:      "rust",
+			expectedFramework: "cargo-workspace",
+		},
+		{
+			name:            "python poetry",
+			files:           map[string]string{"pyproject.toml": "[tool.poetry]\nname = \"x\""},
+			expectedVariant: "base",
+			expectedType:    "python",
+			expectedTool:    "poetry",
+		},
+		{
+			name:            "python uv",
+			files:           map[string]string{"pyproject.toml": "[project]", "uv.lock": ""},
+			expectedVariant: "base",
+			expectedType:    "python",
+			expectedTool:    "uv",
+		},
+		{
+			name:            "java gradle",
+			files:           map[string]string{"build.gradle.kts": ""},
+			expectedVariant: "full",
+			expectedType:    "java",
+			expectedTool:    "gradle",
+		},
+		{
+			name:            "dotnet solution",
+			files:           map[string]string{"App.sln": "", "App.csproj": ""},
+			expectedVariant: "base",
+			expectedType:    "dotnet",
+		},
+		{
+			name:              "ruby on rails",
+			files:             map[string]string{"Gemfile": "", "config/application.rb": ""},
+			expectedVariant:   "base",
+			expectedType:      "ruby",
+			expectedFramework: "rails",
+		},
+		{
+			name:              "elixir phoenix",
+			files:             map[string]string{"mix.exs": "{:phoenix, \"~> 1.7\"}"},
+			expectedVariant:   "base",
+			expectedType:      "elixir",
+			expectedFramework: "phoenix",
+		},
+		{
+			name:            "terraform files",
+			files:           map[string]string{"main.tf": ""},
+			expectedVariant: "cloud",
+			expectedType:    "terraform",
+		},
+		{
+			name:            "mixed go and node picks covering variant",
+			files:           map[string]string{"go.mod": "", "package.json": "{}"},
+			expectedVariant: "go",
+			expectedType:    "go",
+		},
+		{
+			name:            "mixed go and terraform picks cloud",
+			files:           map[string]string{"go.mod": "", "infra.tf": ""},
+			expectedVariant: "cloud",
+			expectedType:    "go",
+		},
+		{
+			name:            "mixed terraform and helm falls back to full",
+			files:           map[string]string{"main.tf": ""},
+			dirs:            []string{"helm"},
+			expectedVariant: "full",
+			expectedType:    "terraform",
+		},
+		{
+			name:            "helm directory",
+			dirs:            []string{"helm"},
+			expectedVariant: "k8s",
+			expectedType:    "kubernetes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			for _, d := range tt.dirs {
+				require.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0755))
+			}
+
+			result := NewRegistry().Detect(dir)
+
+			assert.Equal(t, tt.expectedVariant, result.Variant)
+			assert.Equal(t, tt.expectedType, result.ProjectType)
+			if tt.expectedFramework != "" {
+				assert.Contains(t, result.Frameworks, tt.expectedFramework)
+			}
+			if tt.expectedTool != "" {
+				assert.Contains(t, result.Tools, tt.expectedTool)
+			}
+		})
+	}
+}
+
+func TestRegistry_DetectConfidence(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"go.mod": "", "package.json": "{}"})
+
+	result := NewRegistry().Detect(dir)
+
+	assert.Equal(t, []string{"go", "node"}, result.Languages)
+	assert.InDelta(t, 0.95, result.Confidence, 0.001)
+	assert.Equal(t, "0.95", result.Metadata["score.go"])
+	assert.Equal(t, "0.90", result.Metadata["score.node"])
+}
+
+func TestRegistry_MissingToolchains(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"Gemfile": ""})
+
+	result := NewRegistry().Detect(dir)
+
+	assert.Equal(t, "ruby", result.Metadata["missing_toolchains"])
+}
+
+func TestRegistry_UncoveredVariants(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"main.tf": "", "go.mod": ""})
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "helm"), 0755))
+
+	result := NewRegistry().Detect(dir)
+
+	assert.Equal(t, "full", result.Variant)
+	assert.Equal(t, "cloud,k8s", result.Metadata["uncovered_variants"])
+
+	// A single variant that covers everything leaves nothing uncovered
+	dir = t.TempDir()
+	writeFiles(t, dir, map[string]string{"main.tf": "", "go.mod": ""})
+	result = NewRegistry().Detect(dir)
+	assert.Equal(t, "cloud", result.Variant)
+	assert.Empty(t, result.Metadata["uncovered_variants"])
+}
+
+func TestCoveringVariant(t *testing.T) {
+	tests := []struct {
+		name              string
+		scores            map[string]float64
+		expectedVariant   string
+		expectedUncovered []string
+	}{
+		{"nothing detected", map[string]float64{}, "base", nil},
+		{"go and full", map[string]float64{"go": 0.9, "full": 0.8}, "full", nil},
+		{"full and cloud", map[string]float64{"full": 0.9, "cloud": 0.8}, "cloud", nil},
+		{"cloud and k8s", map[string]float64{"cloud": 0.8, "k8s": 0.9}, "full", []string{"cloud", "k8s"}},
+		{"custom variant", map[string]float64{"base": 0.9, "ruby": 0.5}, "ruby", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variant, uncovered := coveringVariant(tt.scores)
+			assert.Equal(t, tt.expectedVariant, variant)
+			assert.Equal(t, tt.expectedUncovered, uncovered)
+		})
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	t.Run("custom detector is used", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"BUILD.bazel": ""})
+
+		r := NewRegistry()
+		r.Register(&MarkerDetector{
+			ID: "bazel", Language: "bazel", Variant: "full",
+			Markers: []Marker{{Pattern: "BUILD.bazel", Confidence: 0.9}},
+		})
+
+		result := r.Detect(dir)
+		assert.Equal(t, "bazel", result.ProjectType)
+		assert.Equal(t, "full", result.Variant)
+	})
+
+	t.Run("registering same name replaces detector", func(t *testing.T) {
+		r := NewRegistry()
+		before := len(r.Names())
+
+		r.Register(&MarkerDetector{ID: "go", Language: "go", Variant: "go"})
+
+		assert.Len(t, r.Names(), before)
+	})
+}
+
+func TestVariantDetector(t *testing.T) {
+	definition := &pkg.VariantDefinition{
+		Name: "elixir",
+		Detect: &pkg.VariantDetection{
+			Language: "elixir",
+			Markers:  []pkg.VariantMarker{{Pattern: "mix.exs", Confidence: 0.9, Framework: "phoenix"}},
+		},
+	}
+
+	t.Run("external variant is offered for matching projects", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"mix.exs": ""})
+
+		r := NewRegistry()
+		r.Register(VariantDetector(definition))
+
+		result := r.Detect(dir)
+		assert.Equal(t, "elixir", result.ProjectType)
+		assert.Equal(t, "elixir", result.Variant)
+		assert.Contains(t, result.Frameworks, "phoenix")
+	})
+
+	t.Run("built-in stacks still need a covering variant", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"mix.exs": "", "go.mod": "module x\n"})
+
+		r := NewRegistry()
+		r.Register(VariantDetector(definition))
+
+		assert.Equal(t, "go", r.Detect(dir).Variant)
+	})
+
+	t.Run("language defaults to variant name", func(t *testing.T) {
+		detector := VariantDetector(&pkg.VariantDefinition{
+			Name:   "zig",
+			Detect: &pkg.VariantDetection{Markers: []pkg.VariantMarker{{Pattern: "build.zig", Confidence: 0.9}}},
+		})
+		assert.Equal(t, "variant:zig", detector.Name())
+		assert.Equal(t, "zig", detector.Language)
+	})
+}
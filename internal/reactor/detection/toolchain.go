@@ -0,0 +1,198 @@
+package detection
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ToolchainRequirement is a language toolchain version pinned by a project file
+type ToolchainRequirement struct {
+	// Tool is the mise/asdf plugin name (go, node, rust, python)
+	Tool string
+	// Version is the requested version as written in the project (e.g. "1.22", "20.11.1")
+	Version string
+	// Source is the project-relative file the version was read from
+	Source string
+	// Minimum is true when the version is a lower bound rather than an exact pin (go.mod)
+	Minimum bool
+}
+
+// versionCommands are run inside the container to discover the provided toolchain version
+var versionCommands = map[string]string{
+	"go":     "go version",
+	"node":   "node --version",
+	"rust":   "rustc --version",
+	"python": "python3 --version",
+}
+
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)*`)
+
+// pinPattern matches the versions read from project files that are used. Pins are passed to
+// mise or asdf inside the container, so anything else, such as "lts/*" or shell syntax from
+// an untrusted repository, is ignored.
+var pinPattern = regexp.MustCompile(`^[0-9A-Za-z._+-]+$`)
+
+// validPin returns version when it matches pinPattern, or ""
+func validPin(version string) string {
+	if !pinPattern.MatchString(version) {
+		return ""
+	}
+	return version
+}
+
+// VersionCommand returns the shell command that prints the installed version of a tool
+func VersionCommand(tool string) string {
+	return versionCommands[tool]
+}
+
+// DetectToolchainVersions reads version pins from well-known project files.
+// A go.mod "toolchain" directive takes precedence over its "go" directive.
+func DetectToolchainVersions(projectPath string) []ToolchainRequirement {
+	var requirements []ToolchainRequirement
+
+	if req := goModRequirement(projectPath); req != nil {
+		requirements = append(requirements, *req)
+	}
+
+	for _, file := range []string{".nvmrc", ".node-version"} {
+		if version := readVersionFile(projectPath, file); version != "" {
+			requirements = append(requirements, ToolchainRequirement{Tool: "node", Version: version, Source: file})
+			break
+		}
+	}
+
+	if version := rustToolchainVersion(projectPath); version != "" {
+		requirements = append(requirements, ToolchainRequirement{Tool: "rust", Version: version, Source: "rust-toolchain.toml"})
+	} else if version := readVersionFile(projectPath, "rust-toolchain"); version != "" {
+		requirements = append(requirements, ToolchainRequirement{Tool: "rust", Version: version, Source: "rust-toolchain"})
+	}
+
+	if version := readVersionFile(projectPath, ".python-version"); version != "" {
+		requirements = append(requirements, ToolchainRequirement{Tool: "python", Version: version, Source: ".python-version"})
+	}
+
+	return requirements
+}
+
+// ParseVersion extracts the first dotted version number from tool output
+// (e.g. "go version go1.24.7 linux/arm64" -> "1.24.7", "v22.20.0" -> "22.20.0")
+func ParseVersion(output string) string {
+	return versionPattern.FindString(output)
+}
+
+// Satisfies reports whether the provided version meets the requirement. Exact pins
+// match on the components the project specifies, so "20" accepts "20.11.1".
+func (r ToolchainRequirement) Satisfies(provided string) bool {
+	required := splitVersion(ParseVersion(r.Version))
+	actual := splitVersion(ParseVersion(provided))
+	if len(required) == 0 {
+		// Channels like "stable" or "lts/*" cannot be compared
+		return true
+	}
+	if len(actual) == 0 {
+		return false
+	}
+
+	if r.Minimum {
+		for i := 0; i < len(required); i++ {
+			a := 0
+			if i < len(actual) {
+				a = actual[i]
+			}
+			if a != required[i] {
+				return a > required[i]
+			}
+		}
+		return true
+	}
+
+	if len(actual) < len(required) {
+		return false
+	}
+	for i := range required {
+		if actual[i] != required[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// goModRequirement reads the go or toolchain directive from go.mod
+func goModRequirement(projectPath string) *ToolchainRequirement {
+	file, err := os.Open(filepath.Join(projectPath, "go.mod"))
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var req *ToolchainRequirement
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		switch fields[0] {
+		case "go":
+			if version := validPin(fields[1]); req == nil && version != "" {
+				req = &ToolchainRequirement{Tool: "go", Version: version, Source: "go.mod", Minimum: true}
+			}
+		case "toolchain":
+			if version := validPin(strings.TrimPrefix(fields[1], "go")); version != "" {
+				req = &ToolchainRequirement{Tool: "go", Version: version, Source: "go.mod", Minimum: true}
+			}
+		}
+	}
+	return req
+}
+
+// rustToolchainVersion reads the channel from rust-toolchain.toml
+func rustToolchainVersion(projectPath string) string {
+	data, err := os.ReadFile(filepath.Join(projectPath, "rust-toolchain.toml"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if found && strings.TrimSpace(key) == "channel" {
+			return validPin(strings.Trim(strings.TrimSpace(value), `"'`))
+		}
+	}
+	return ""
+}
+
+// readVersionFile returns the first non-comment line of a single-value version file, or ""
+// when it is not a valid pin
+func readVersionFile(projectPath, name string) string {
+	data, err := os.ReadFile(filepath.Join(projectPath, name))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return validPin(strings.TrimPrefix(line, "v"))
+		}
+	}
+	return ""
+}
+
+// splitVersion converts "1.22.3" into []int{1, 22, 3}
+func splitVersion(version string) []int {
+	if version == "" {
+		return nil
+	}
+	var parts []int
+	for _, p := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
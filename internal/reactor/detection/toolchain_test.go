@@ -0,0 +1,105 @@
+package detection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectToolchainVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected []ToolchainRequirement
+	}{
+		{
+			name:     "no version files",
+			expected: nil,
+		},
+		{
+			name:  "go directive",
+			files: map[string]string{"go.mod": "module example\n\ngo 1.22\n"},
+			expected: []ToolchainRequirement{
+				{Tool: "go", Version: "1.22", Source: "go.mod", Minimum: true},
+			},
+		},
+		{
+			name:  "toolchain directive wins over go directive",
+			files: map[string]string{"go.mod": "module example\n\ngo 1.22\n\ntoolchain go1.23.4\n"},
+			expected: []ToolchainRequirement{
+				{Tool: "go", Version: "1.23.4", Source: "go.mod", Minimum: true},
+			},
+		},
+		{
+			name:  "nvmrc with v prefix",
+			files: map[string]string{".nvmrc": "v20.11.1\n"},
+			expected: []ToolchainRequirement{
+				{Tool: "node", Version: "20.11.1", Source: ".nvmrc"},
+			},
+		},
+		{
+			name:  "node-version file",
+			files: map[string]string{".node-version": "18\n"},
+			expected: []ToolchainRequirement{
+				{Tool: "node", Version: "18", Source: ".node-version"},
+			},
+		},
+		{
+			name:  "rust toolchain toml",
+			files: map[string]string{"rust-toolchain.toml": "[toolchain]\nchannel = \"1.75.0\"\n"},
+			expected: []ToolchainRequirement{
+				{Tool: "rust", Version: "1.75.0", Source: "rust-toolchain.toml"},
+			},
+		},
+		{
+			name:     "shell syntax is ignored",
+			files:    map[string]string{".nvmrc": "20; curl x|sh\n", "go.mod": "module example\n\ngo 1.22\n\ntoolchain go$(id)\n"},
+			expected: []ToolchainRequirement{{Tool: "go", Version: "1.22", Source: "go.mod", Minimum: true}},
+		},
+		{
+			name:     "node aliases are ignored",
+			files:    map[string]string{".nvmrc": "lts/*\n", "rust-toolchain.toml": "[toolchain]\nchannel = \"stable; id\"\n"},
+			expected: nil,
+		},
+		{
+			name:  "python version with comment",
+			files: map[string]string{".python-version": "# pinned\n3.12\n"},
+			expected: []ToolchainRequirement{
+				{Tool: "python", Version: "3.12", Source: ".python-version"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			assert.Equal(t, tt.expected, DetectToolchainVersions(dir))
+		})
+	}
+}
+
+func TestToolchainRequirement_Satisfies(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      ToolchainRequirement
+		provided string
+		expected bool
+	}{
+		{"go minimum met", ToolchainRequirement{Tool: "go", Version: "1.22", Minimum: true}, "go version go1.24.7 linux/arm64", true},
+		{"go minimum exact", ToolchainRequirement{Tool: "go", Version: "1.22.0", Minimum: true}, "go version go1.22 linux/amd64", true},
+		{"go minimum not met", ToolchainRequirement{Tool: "go", Version: "1.25", Minimum: true}, "go version go1.24.7 linux/arm64", false},
+		{"node major pin", ToolchainRequirement{Tool: "node", Version: "22"}, "v22.20.0", true},
+		{"node major mismatch", ToolchainRequirement{Tool: "node", Version: "20"}, "v22.20.0", false},
+		{"python minor pin", ToolchainRequirement{Tool: "python", Version: "3.12"}, "Python 3.9.2", false},
+		{"rust channel not comparable", ToolchainRequirement{Tool: "rust", Version: "stable"}, "rustc 1.80.0", true},
+		{"missing toolchain", ToolchainRequirement{Tool: "node", Version: "20"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.req.Satisfies(tt.provided))
+		})
+	}
+}
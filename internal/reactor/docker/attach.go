@@ -0,0 +1,323 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/moby/term"
+
+	"claude-reactor/internal/reactor/clipboard"
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// DefaultReconnectConfig returns the backoff used to re-establish a dropped interactive session
+func DefaultReconnectConfig() *RecoveryConfig {
+	return &RecoveryConfig{
+		MaxRetries:    8,
+		InitialDelay:  500 * time.Millisecond,
+		MaxDelay:      15 * time.Second,
+		BackoffFactor: 2.0,
+	}
+}
+
+// nextDelay returns the delay after the given one under exponential backoff
+func nextDelay(delay time.Duration, config *RecoveryConfig) time.Duration {
+	delay = time.Duration(float64(delay) * config.BackoffFactor)
+	if delay > config.MaxDelay {
+		delay = config.MaxDelay
+	}
+	return delay
+}
+
+// EnableClipboardBridge copies OSC 52 clipboard requests from interactive sessions to the host clipboard
+func (m *manager) EnableClipboardBridge(enabled bool) {
+	m.clipboard = enabled
+}
+
+// SetWorkingDir starts attached sessions in dir, a container path; "" uses the image's working directory
+func (m *manager) SetWorkingDir(dir string) {
+	m.workdir = dir
+}
+
+// SetSessionEnv adds env, as KEY=VALUE, to attached sessions without recording it in the container
+func (m *manager) SetSessionEnv(env []string) {
+	m.sessionEnv = env
+}
+
+// isResumableCommand reports whether running command again reattaches to the same session
+// rather than starting over. tmux 'new-session -A', 'attach', and screen '-x'/'-r' do.
+func isResumableCommand(command []string) bool {
+	if len(command) == 0 {
+		return false
+	}
+	switch filepath.Base(command[0]) {
+	case "tmux":
+		for _, arg := range command[1:] {
+			if arg == "-A" || arg == "attach" || arg == "attach-session" || arg == "a" {
+				return true
+			}
+		}
+	case "screen":
+		for _, arg := range command[1:] {
+			if arg == "-x" || arg == "-xRR" || arg == "-r" || arg == "-R" || arg == "-RR" || arg == "-dr" || arg == "-DR" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sessionEnd describes why an interactive exec session stopped
+type sessionEnd int
+
+const (
+	sessionFinished    sessionEnd = iota // the command exited
+	sessionInputClosed                   // stdin reached EOF
+	sessionInterrupted                   // the user sent SIGINT/SIGTERM
+	sessionDropped                       // the connection was lost while the command was still running
+)
+
+// attachInteractive runs command with a TTY and relays the terminal to it. If the connection to
+// the daemon drops while the command is running and re-running it resumes the same session
+// (tmux/screen), it reconnects with exponential backoff, keeping the terminal in raw mode.
+func (m *manager) attachInteractive(ctx context.Context, containerID string, command []string) error {
+	fd := os.Stdin.Fd()
+	if term.IsTerminal(fd) {
+		oldState, err := term.MakeRaw(fd)
+		if err != nil {
+			m.logger.Warnf("Failed to set terminal to raw mode: %v", err)
+		} else {
+			// Ensure we restore terminal state on exit
+			defer term.RestoreTerminal(fd, oldState)
+		}
+	}
+
+	// Set up signal handling to properly restore terminal on interrupt
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	// Stdin reads cannot be cancelled, so one reader feeds every connection
+	input := make(chan []byte)
+	go func() {
+		defer close(input)
+		buf := make([]byte, 1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				chunk := make([]byte, n)
+				copy(chunk, buf[:n])
+				input <- chunk
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	// Resize whichever exec is currently attached
+	var execMu sync.Mutex
+	var currentExec string
+	if term.IsTerminal(fd) {
+		resized, stop := watchResize(fd)
+		defer stop()
+
+		go func() {
+			for range resized {
+				execMu.Lock()
+				execID := currentExec
+				execMu.Unlock()
+				if err := m.resizeContainerTTY(ctx, execID); err != nil {
+					m.logger.Debugf("Failed to resize container TTY: %v", err)
+				}
+			}
+		}()
+	}
+	setExec := func(execID string) {
+		execMu.Lock()
+		currentExec = execID
+		execMu.Unlock()
+	}
+
+	// Terminal output, optionally watched for clipboard copies made inside the session
+	var stdout io.Writer = os.Stdout
+	if m.clipboard {
+		stdout = clipboard.NewBridge(os.Stdout, clipboard.CopyToHost, func(err error) {
+			m.logger.Debugf("Clipboard copy failed: %v", err)
+		})
+	}
+
+	reconnect := DefaultReconnectConfig()
+	resumable := isResumableCommand(command)
+
+	end, err := m.runInteractiveExec(ctx, containerID, command, stdout, input, sigChan, setExec, true)
+	if err != nil {
+		return err
+	}
+
+	delay := reconnect.InitialDelay
+	for attempt := 1; end == sessionDropped; {
+		if !resumable {
+			return pkg.NewError(pkg.CodeSessionLost, nil, "lost connection to the container while the session was running")
+		}
+		if attempt > reconnect.MaxRetries {
+			return fmt.Errorf("lost connection to the container and could not reconnect after %d attempts", reconnect.MaxRetries)
+		}
+
+		// Raw mode needs explicit carriage returns
+		fmt.Fprintf(i18n.Stderr, "\r\n⚠️  Connection lost, reconnecting in %s (attempt %d/%d)...\r\n", delay, attempt, reconnect.MaxRetries)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-sigChan:
+			return fmt.Errorf("interrupted by user")
+		case <-time.After(delay):
+		}
+
+		end, err = m.runInteractiveExec(ctx, containerID, command, stdout, input, sigChan, setExec, false)
+		if err != nil {
+			// Daemon or container still unavailable; keep backing off
+			m.logger.Debugf("Reconnect attempt %d failed: %v", attempt, err)
+			end = sessionDropped
+			attempt++
+			delay = nextDelay(delay, reconnect)
+			continue
+		}
+		if end == sessionDropped {
+			// Connected, then dropped again; start the backoff over
+			attempt, delay = 1, reconnect.InitialDelay
+		}
+	}
+
+	if end == sessionInterrupted {
+		m.logger.Debug("Received interrupt signal, disconnecting...")
+		return fmt.Errorf("interrupted by user")
+	}
+
+	m.logger.Debug("Interactive session completed")
+	return nil
+}
+
+// runInteractiveExec creates and attaches one exec instance and relays I/O until it ends.
+// An error means the exec could not be started; once running, problems are reported as sessionDropped.
+func (m *manager) runInteractiveExec(ctx context.Context, containerID string, command []string, stdout io.Writer, input <-chan []byte, sigChan <-chan os.Signal, setExec func(string), first bool) (sessionEnd, error) {
+	m.logger.Debugf("Creating interactive exec for container %s", containerID[:12])
+
+	// Create exec configuration
+	execConfig := container.ExecOptions{
+		Cmd:          command,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          true,
+		WorkingDir:   m.workdir,
+		Env:          m.sessionEnv,
+	}
+
+	// Create exec instance
+	execResp, err := m.client.ContainerExecCreate(ctx, containerID, execConfig)
+	if err != nil {
+		return sessionFinished, fmt.Errorf("failed to create exec instance: %w", err)
+	}
+
+	// Start exec with hijacked connection for interactive I/O
+	execStartCheck := container.ExecStartOptions{
+		Tty: true,
+	}
+
+	hijackedResp, err := m.client.ContainerExecAttach(ctx, execResp.ID, execStartCheck)
+	if err != nil {
+		return sessionFinished, fmt.Errorf("failed to attach to exec instance: %w", err)
+	}
+	defer hijackedResp.Close()
+
+	// Start the exec instance
+	if err := m.client.ContainerExecStart(ctx, execResp.ID, execStartCheck); err != nil {
+		return sessionFinished, fmt.Errorf("failed to start exec instance: %w", err)
+	}
+
+	if first {
+		m.logger.Info("✅ Successfully attached to container - press Ctrl+C to disconnect")
+	} else {
+		fmt.Fprint(i18n.Stderr, "✅ Reconnected\r\n")
+	}
+
+	// Sync terminal size to prevent display issues
+	setExec(execResp.ID)
+	if err := m.resizeContainerTTY(ctx, execResp.ID); err != nil {
+		m.logger.Debugf("Failed to resize container TTY: %v", err)
+	}
+
+	// Copy output from container to stdout with improved buffering
+	outputDone := make(chan error, 1)
+	go func() {
+		// Use a buffered copy to reduce flickering
+		buf := make([]byte, 1024)
+		for {
+			n, err := hijackedResp.Reader.Read(buf)
+			if n > 0 {
+				if _, writeErr := stdout.Write(buf[:n]); writeErr != nil {
+					outputDone <- writeErr
+					return
+				}
+			}
+			if err != nil {
+				outputDone <- err
+				return
+			}
+		}
+	}()
+
+	// Copy input to the container until the output side ends
+	for {
+		select {
+		case <-sigChan:
+			return sessionInterrupted, nil
+
+		case chunk, ok := <-input:
+			if !ok {
+				m.logger.Debug("Input stream ended")
+				return sessionInputClosed, nil
+			}
+			if _, err := hijackedResp.Conn.Write(chunk); err != nil {
+				m.logger.Debugf("Failed to write to container: %v", err)
+				return m.execEnd(ctx, execResp.ID), nil
+			}
+
+		case err := <-outputDone:
+			if err != nil && !errors.Is(err, io.EOF) {
+				m.logger.Debugf("Output stream ended: %v", err)
+			}
+			return m.execEnd(ctx, execResp.ID), nil
+		}
+	}
+}
+
+// execEnd decides whether an exec whose stream closed has finished or was cut off. Output can
+// close slightly before the daemon marks the exec stopped, so a running exec is polled briefly.
+func (m *manager) execEnd(ctx context.Context, execID string) sessionEnd {
+	for i := 0; i < 5; i++ {
+		inspectCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		inspectResp, err := m.client.ContainerExecInspect(inspectCtx, execID)
+		cancel()
+		if err != nil {
+			m.logger.Debugf("Failed to inspect exec instance: %v", err)
+			return sessionDropped
+		}
+		if !inspectResp.Running {
+			return sessionFinished
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	return sessionDropped
+}
@@ -0,0 +1,47 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsResumableCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command []string
+		want    bool
+	}{
+		{"tmux new-session -A", []string{"tmux", "new-session", "-A", "-s", "claude-reactor", "claude"}, true},
+		{"tmux attach", []string{"tmux", "attach-session", "-t", "claude-reactor"}, true},
+		{"tmux new-session without -A", []string{"tmux", "new-session", "-s", "claude-reactor"}, false},
+		{"screen multi-attach", []string{"screen", "-xRR", "-S", "claude-reactor", "claude"}, true},
+		{"screen reattach", []string{"/usr/bin/screen", "-x", "claude-reactor"}, true},
+		{"plain claude", []string{"claude", "--dangerously-skip-permissions"}, false},
+		{"shell", []string{"/bin/bash"}, false},
+		{"empty", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isResumableCommand(tt.command))
+		})
+	}
+}
+
+func TestNextDelay(t *testing.T) {
+	config := DefaultReconnectConfig()
+
+	delay := config.InitialDelay
+	var delays []time.Duration
+	for i := 0; i < 7; i++ {
+		delay = nextDelay(delay, config)
+		delays = append(delays, delay)
+	}
+
+	assert.Equal(t, []time.Duration{
+		1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+		15 * time.Second, 15 * time.Second, 15 * time.Second,
+	}, delays)
+}
@@ -0,0 +1,142 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"claude-reactor/pkg"
+)
+
+// ChaosEnv makes the Docker manager inject simulated failures, so error handling and retries
+// can be exercised without a misbehaving daemon. It is a comma-separated list of faults, each
+// optionally limited to its first N occurrences with FAULT:N, or "all":
+//
+//	pull-timeout     image pulls time out
+//	create-conflict  container creation fails because the name is in use
+//	exec-hang        exec sessions hang until their context ends
+const ChaosEnv = "CLAUDE_REACTOR_CHAOS"
+
+// Faults injected in chaos mode
+const (
+	FaultPullTimeout    = "pull-timeout"
+	FaultCreateConflict = "create-conflict"
+	FaultExecHang       = "exec-hang"
+)
+
+// chaosFaults lists the faults chaos mode can inject
+var chaosFaults = []string{FaultPullTimeout, FaultCreateConflict, FaultExecHang}
+
+// parseChaos parses a ChaosEnv value into the number of times to inject each fault; -1
+// injects it every time
+func parseChaos(spec string) (map[string]int, error) {
+	faults := make(map[string]int)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, limit, limited := strings.Cut(entry, ":")
+		count := -1
+		if limited {
+			n, err := strconv.Atoi(limit)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid chaos fault '%s': the count must be a positive number", entry)
+			}
+			count = n
+		}
+
+		switch {
+		case name == "all":
+			for _, fault := range chaosFaults {
+				faults[fault] = count
+			}
+		case isChaosFault(name):
+			faults[name] = count
+		default:
+			return nil, fmt.Errorf("unknown chaos fault '%s': use %s or all", name, strings.Join(chaosFaults, ", "))
+		}
+	}
+	return faults, nil
+}
+
+func isChaosFault(name string) bool {
+	for _, fault := range chaosFaults {
+		if name == fault {
+			return true
+		}
+	}
+	return false
+}
+
+// chaosClient injects simulated failures into the Docker API calls behind its faults and
+// passes every other call to the real client
+type chaosClient struct {
+	client.APIClient
+	logger pkg.Logger
+
+	mu     sync.Mutex
+	faults map[string]int // injections left per fault; -1 is unlimited
+}
+
+func newChaosClient(cli client.APIClient, faults map[string]int, logger pkg.Logger) *chaosClient {
+	names := make([]string, 0, len(faults))
+	for fault, count := range faults {
+		if count > 0 {
+			fault += ":" + strconv.Itoa(count)
+		}
+		names = append(names, fault)
+	}
+	sort.Strings(names)
+	logger.Warnf("🐒 Chaos mode: injecting simulated Docker failures (%s)", strings.Join(names, ", "))
+	return &chaosClient{APIClient: cli, logger: logger, faults: faults}
+}
+
+// inject reports whether to fail this call with fault, using up one of its injections
+func (c *chaosClient) inject(fault string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	count, ok := c.faults[fault]
+	if !ok || count == 0 {
+		return false
+	}
+	if count > 0 {
+		c.faults[fault] = count - 1
+	}
+	c.logger.Debugf("🐒 Chaos mode: injecting %s", fault)
+	return true
+}
+
+func (c *chaosClient) ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error) {
+	if c.inject(FaultPullTimeout) {
+		return nil, fmt.Errorf("timeout pulling %s (injected by chaos mode): %w", ref, context.DeadlineExceeded)
+	}
+	return c.APIClient.ImagePull(ctx, ref, options)
+}
+
+func (c *chaosClient) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *ocispec.Platform, containerName string) (container.CreateResponse, error) {
+	if c.inject(FaultCreateConflict) {
+		return container.CreateResponse{}, errdefs.Conflict(fmt.Errorf("Conflict. The container name \"/%s\" is already in use (injected by chaos mode)", containerName))
+	}
+	return c.APIClient.ContainerCreate(ctx, config, hostConfig, networkingConfig, platform, containerName)
+}
+
+func (c *chaosClient) ContainerExecAttach(ctx context.Context, execID string, options container.ExecAttachOptions) (types.HijackedResponse, error) {
+	if c.inject(FaultExecHang) {
+		<-ctx.Done()
+		return types.HijackedResponse{}, fmt.Errorf("exec %s hung (injected by chaos mode): %w", execID, ctx.Err())
+	}
+	return c.APIClient.ContainerExecAttach(ctx, execID, options)
+}
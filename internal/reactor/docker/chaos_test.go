@@ -0,0 +1,119 @@
+package docker
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// healthyClient succeeds at every call chaos mode can break
+type healthyClient struct {
+	client.APIClient
+}
+
+func (c *healthyClient) ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("{}")), nil
+}
+
+func (c *healthyClient) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *ocispec.Platform, containerName string) (container.CreateResponse, error) {
+	return container.CreateResponse{ID: "created"}, nil
+}
+
+func (c *healthyClient) ContainerExecAttach(ctx context.Context, execID string, options container.ExecAttachOptions) (types.HijackedResponse, error) {
+	return types.HijackedResponse{}, nil
+}
+
+func newTestChaosClient(t *testing.T, spec string) *chaosClient {
+	faults, err := parseChaos(spec)
+	require.NoError(t, err)
+	logger := &MockLogger{}
+	logger.On("Warnf", mock.Anything, mock.Anything).Return()
+	logger.On("Debugf", mock.Anything, mock.Anything).Return()
+	return newChaosClient(&healthyClient{}, faults, logger)
+}
+
+func TestParseChaos(t *testing.T) {
+	faults, err := parseChaos("pull-timeout, create-conflict:2")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{FaultPullTimeout: -1, FaultCreateConflict: 2}, faults)
+
+	faults, err = parseChaos("all:1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{FaultPullTimeout: 1, FaultCreateConflict: 1, FaultExecHang: 1}, faults)
+
+	_, err = parseChaos("disk-full")
+	assert.ErrorContains(t, err, "unknown chaos fault 'disk-full'")
+	_, err = parseChaos("exec-hang:0")
+	assert.ErrorContains(t, err, "positive number")
+}
+
+func TestChaosClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pull timeout", func(t *testing.T) {
+		c := newTestChaosClient(t, "pull-timeout")
+		_, err := c.ImagePull(ctx, "alpine", image.PullOptions{})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "timeout")
+		// Unaffected calls reach the daemon
+		_, err = c.ContainerCreate(ctx, &container.Config{}, nil, nil, nil, "claude-reactor-test")
+		assert.NoError(t, err)
+	})
+
+	t.Run("create conflict is injected a limited number of times", func(t *testing.T) {
+		c := newTestChaosClient(t, "create-conflict:2")
+		for i := 0; i < 2; i++ {
+			_, err := c.ContainerCreate(ctx, &container.Config{}, nil, nil, nil, "claude-reactor-test")
+			assert.True(t, errdefs.IsConflict(err), "attempt %d: %v", i+1, err)
+			assert.Contains(t, err.Error(), `"/claude-reactor-test" is already in use`)
+		}
+		created, err := c.ContainerCreate(ctx, &container.Config{}, nil, nil, nil, "claude-reactor-test")
+		require.NoError(t, err)
+		assert.Equal(t, "created", created.ID)
+	})
+
+	t.Run("exec hangs until the context ends", func(t *testing.T) {
+		c := newTestChaosClient(t, "exec-hang")
+		hangCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := c.ContainerExecAttach(hangCtx, "exec", container.ExecAttachOptions{})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("injected failures reach the retry logic", func(t *testing.T) {
+		c := newTestChaosClient(t, "pull-timeout")
+		_, err := c.ImagePull(ctx, "alpine", image.PullOptions{})
+		rm := &RecoveryManager{}
+		assert.True(t, rm.isBuildRetryableError(err))
+		assert.True(t, rm.isRetryableError(err))
+	})
+}
+
+func TestGetClientUnwrapsChaos(t *testing.T) {
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://127.0.0.1:2375"))
+	require.NoError(t, err)
+	defer cli.Close()
+
+	m := &manager{client: &chaosClient{APIClient: cli}}
+	assert.Same(t, cli, m.GetClient())
+}
+
+func TestAPIClientKeepsChaos(t *testing.T) {
+	chaos := newTestChaosClient(t, "pull-timeout")
+	assert.Same(t, chaos, APIClient(&manager{client: chaos}))
+}
@@ -0,0 +1,129 @@
+#!/bin/sh
+# claude-reactor-init: PID 1 for claude-reactor containers.
+#
+# Prepares the container for the claude user (directories, seeded Claude config, ownership of
+# directories Docker created as root), runs lifecycle hooks, then runs the main command as a
+# child: signals are forwarded to it, orphaned processes are reaped while waiting, and its exit
+# status becomes the container's.
+#
+# Built-in images use it as their ENTRYPOINT; claude-reactor copies it into containers of
+# custom images and wraps their entrypoint with it.
+#
+# Hooks: executable files in /etc/claude-reactor/start.d run, in name order, before the main
+# command; those in /etc/claude-reactor/stop.d run when the container is asked to stop. A
+# failing hook is reported but does not stop the container.
+
+HOOKS_DIR="/etc/claude-reactor"
+
+# Created once setup and start hooks have finished; claude-reactor waits for it before
+# opening sessions
+READY_FILE="/tmp/claude-reactor-init.ready"
+
+log() {
+    echo "claude-reactor-init: $*"
+}
+
+# as_root runs a command as root, directly or through passwordless sudo
+as_root() {
+    if [ "$(id -u)" = "0" ]; then
+        "$@"
+    elif command -v sudo >/dev/null 2>&1; then
+        sudo -n "$@"
+    else
+        return 1
+    fi
+}
+
+# setup_directories creates the paths Claude CLI expects. Paths recorded on the host (additional
+# working directories, ~/.claude-reactor sessions) are made to resolve inside the container.
+setup_directories() {
+    mkdir -p "$HOME/.claude" 2>/dev/null
+    [ -e "$HOME/.claude-reactor" ] || ln -s "$HOME/.claude" "$HOME/.claude-reactor"
+
+    host_home="$CLAUDE_REACTOR_HOST_HOME"
+    case "$host_home" in
+        /*) ;;
+        *) return 0 ;;
+    esac
+    if [ "$host_home" != "$HOME" ] && [ ! -e "$host_home" ]; then
+        as_root mkdir -p "$(dirname "$host_home")" &&
+            as_root ln -s "$HOME" "$host_home" ||
+            log "could not link $host_home to $HOME"
+    fi
+}
+
+# seed_config installs the Claude config seed and tightens credential permissions
+seed_config() {
+    if [ -f /tmp/claude-config-seed.json ]; then
+        cp /tmp/claude-config-seed.json "$HOME/.claude.json" && chmod 644 "$HOME/.claude.json"
+        log "seeded $HOME/.claude.json"
+    fi
+    for credentials in "$HOME/.credentials.json" "$HOME/.claude/.credentials.json"; do
+        [ -f "$credentials" ] && chmod 600 "$credentials" 2>/dev/null
+    done
+    return 0
+}
+
+# fix_ownership gives the container user back directories in its home that Docker created as
+# root for nested mount targets. Mount points themselves belong to the host and are left alone.
+fix_ownership() {
+    root_dev="$(stat -c %d / 2>/dev/null)" || return 0
+    for dir in "$HOME" "$HOME/.claude" "$HOME/.config"; do
+        [ -d "$dir" ] && [ ! -L "$dir" ] || continue
+        [ "$(stat -c %d "$dir")" = "$root_dev" ] || continue
+        [ "$(stat -c %u "$dir")" = "$(id -u)" ] && continue
+        as_root chown "$(id -u):$(id -g)" "$dir" || log "could not take ownership of $dir"
+    done
+}
+
+# fix_cache_ownership gives the container user the shared npm cache volume when Docker created
+# it empty and owned by root, as it does for images without a ~/.npm to copy from
+fix_cache_ownership() {
+    cache="$HOME/.npm"
+    [ -d "$cache" ] && [ "$(id -u)" != "0" ] && [ "$(stat -c %u "$cache")" = "0" ] || return 0
+    as_root chown "$(id -u):$(id -g)" "$cache" || log "could not take ownership of $cache"
+}
+
+# run_hooks runs the executable hooks for a lifecycle stage
+run_hooks() {
+    dir="$HOOKS_DIR/$1.d"
+    [ -d "$dir" ] || return 0
+    for hook in "$dir"/*; do
+        [ -f "$hook" ] && [ -x "$hook" ] || continue
+        log "running $1 hook $(basename "$hook")"
+        "$hook" || log "$1 hook $(basename "$hook") failed with status $?"
+    done
+}
+
+rm -f "$READY_FILE"
+setup_directories
+seed_config
+fix_ownership
+fix_cache_ownership
+run_hooks start
+touch "$READY_FILE"
+
+[ $# -gt 0 ] || set -- tail -f /dev/null
+
+stopping=""
+forward() {
+    if [ -z "$stopping" ] && [ "$1" != "HUP" ]; then
+        stopping=1
+        run_hooks stop
+    fi
+    kill -s "$1" "$child" 2>/dev/null
+}
+trap 'forward TERM' TERM
+trap 'forward INT' INT
+trap 'forward HUP' HUP
+
+"$@" &
+child=$!
+
+# wait returns early when a trapped signal arrives, so keep waiting until the child is gone
+while :; do
+    wait "$child"
+    status=$?
+    kill -0 "$child" 2>/dev/null || break
+done
+exit "$status"
@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// claudeCLIPackage is the npm package that provides the claude CLI in claude-reactor images
+const claudeCLIPackage = "@anthropic-ai/claude-code"
+
+// claudeCLIVersionPattern matches an exact npm version such as 1.0.58 or 2.0.0-beta.1
+var claudeCLIVersionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.]+)?$`)
+
+// NPMCacheTarget is where containers mount the account's shared npm cache volume
+const NPMCacheTarget = "/home/claude/.npm"
+
+// npmCachePrefix names the npm cache volumes, one per account
+const npmCachePrefix = "claude-reactor-npm-cache-"
+
+// NPMCacheVolume returns the name of the npm cache volume shared by an account's containers,
+// so Claude CLI installs and upgrades download each package version once for all variants
+func NPMCacheVolume(account string) string {
+	if account == "" {
+		account = "default"
+	}
+	return npmCachePrefix + account
+}
+
+// ClaudeVersionCommand prints the claude CLI version inside a container
+var ClaudeVersionCommand = []string{"claude", "--version"}
+
+// ValidClaudeCLIVersion reports whether version is an exact claude CLI version
+func ValidClaudeCLIVersion(version string) bool {
+	return claudeCLIVersionPattern.MatchString(version)
+}
+
+// ClaudeInstallCommand installs an exact claude CLI version inside a container
+func ClaudeInstallCommand(version string) []string {
+	return []string{"npm", "install", "-g", "--no-fund", "--no-audit", claudeCLIPackage + "@" + version}
+}
+
+// ParseClaudeVersion extracts the version from 'claude --version' output, e.g. "1.0.58 (Claude Code)"
+func ParseClaudeVersion(output string) string {
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// installClaudeCLI pins the claude CLI in a started container to version. It waits for the
+// install so every session starts on the same version, and skips it when already installed.
+func (m *manager) installClaudeCLI(ctx context.Context, containerName, version string) error {
+	output, exitCode, err := m.ExecCommand(ctx, containerName, ClaudeVersionCommand)
+	if err == nil && exitCode == 0 && ParseClaudeVersion(output) == version {
+		m.logger.Debugf("Claude CLI %s already installed", version)
+		return nil
+	}
+	current := "not installed"
+	if err == nil && exitCode == 0 {
+		current = ParseClaudeVersion(output)
+	}
+
+	m.logger.Infof("📌 Installing pinned Claude CLI %s (currently %s)...", version, current)
+	output, exitCode, err = m.ExecCommand(ctx, containerName, ClaudeInstallCommand(version))
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("npm install exited with code %d: %s", exitCode, strings.TrimSpace(output))
+	}
+	m.logger.Infof("✅ Claude CLI pinned to %s", version)
+	return nil
+}
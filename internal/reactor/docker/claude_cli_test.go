@@ -0,0 +1,43 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"claude-reactor/pkg"
+)
+
+func TestValidClaudeCLIVersion(t *testing.T) {
+	for _, v := range []string{"1.0.58", "2.0.0-beta.1", "10.20.30"} {
+		assert.True(t, ValidClaudeCLIVersion(v), v)
+	}
+	for _, v := range []string{"", "latest", "v1.0.58", "1.0", "1.0.58; rm -rf /", "^1.0.0"} {
+		assert.False(t, ValidClaudeCLIVersion(v), v)
+	}
+}
+
+func TestParseClaudeVersion(t *testing.T) {
+	assert.Equal(t, "1.0.58", ParseClaudeVersion("1.0.58 (Claude Code)\n"))
+	assert.Equal(t, "1.0.58", ParseClaudeVersion("1.0.58"))
+	assert.Equal(t, "", ParseClaudeVersion("  \n"))
+}
+
+func TestClaudeInstallCommand(t *testing.T) {
+	cmd := ClaudeInstallCommand("1.0.58")
+	assert.Equal(t, "npm", cmd[0])
+	assert.Equal(t, "@anthropic-ai/claude-code@1.0.58", cmd[len(cmd)-1])
+}
+
+func TestNPMCacheVolume(t *testing.T) {
+	assert.Equal(t, "claude-reactor-npm-cache-work", NPMCacheVolume("work"))
+	assert.Equal(t, "claude-reactor-npm-cache-default", NPMCacheVolume(""))
+}
+
+func TestConfigHash_ClaudeCLIVersion(t *testing.T) {
+	base := &pkg.ContainerConfig{Image: "claude-reactor-base-amd64"}
+	pinned := &pkg.ContainerConfig{Image: "claude-reactor-base-amd64", ClaudeCLIVersion: "1.0.58"}
+
+	assert.NotEqual(t, ConfigHash(base), ConfigHash(pinned), "pinning a version recreates the container")
+	assert.Equal(t, ConfigHash(base), ConfigHash(&pkg.ContainerConfig{Image: "claude-reactor-base-amd64"}))
+}
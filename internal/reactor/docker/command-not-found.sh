@@ -0,0 +1,14 @@
+# command-not-found: sourced by bash in built-in images, through BASH_ENV for scripts and
+# commands run by Claude and through /etc/bash.bashrc for interactive shells.
+#
+# Reports commands that are not found to the host through claude-reactor-agent, so
+# 'claude-reactor advise' can point out tools the variant is missing. Nothing is reported
+# while no session is attached.
+
+command_not_found_handle() {
+    if [ -x /usr/local/bin/claude-reactor-agent ]; then
+        (/usr/local/bin/claude-reactor-agent missing "$1" >/dev/null 2>&1 &)
+    fi
+    printf 'bash: %s: command not found\n' "$1" >&2
+    return 127
+}
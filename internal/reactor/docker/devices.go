@@ -0,0 +1,93 @@
+package docker
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// ParseDeviceSpec parses a --device value of the form host[:container[:permissions]],
+// where permissions is any combination of r, w, and m (default rwm)
+func ParseDeviceSpec(spec string) (container.DeviceMapping, error) {
+	parts := strings.Split(spec, ":")
+	if spec == "" || len(parts) > 3 {
+		return container.DeviceMapping{}, fmt.Errorf("invalid device '%s': use host[:container[:permissions]]", spec)
+	}
+
+	mapping := container.DeviceMapping{
+		PathOnHost:        parts[0],
+		PathInContainer:   parts[0],
+		CgroupPermissions: "rwm",
+	}
+	if len(parts) > 1 && parts[1] != "" {
+		mapping.PathInContainer = parts[1]
+	}
+	if len(parts) > 2 {
+		mapping.CgroupPermissions = parts[2]
+	}
+
+	if !filepath.IsAbs(mapping.PathOnHost) || !filepath.IsAbs(mapping.PathInContainer) {
+		return container.DeviceMapping{}, fmt.Errorf("invalid device '%s': paths must be absolute", spec)
+	}
+	if mapping.CgroupPermissions == "" || strings.Trim(mapping.CgroupPermissions, "rwm") != "" {
+		return container.DeviceMapping{}, fmt.Errorf("invalid device '%s': permissions must be a combination of r, w, and m", spec)
+	}
+	return mapping, nil
+}
+
+// deviceNode is a character or block device found on the host
+type deviceNode struct {
+	kind  string // "c" or "b"
+	major uint64
+	gid   uint32
+}
+
+// linuxMajor extracts the major number from a Linux device number
+func linuxMajor(dev uint64) uint64 {
+	return ((dev >> 8) & 0xfff) | ((dev >> 32) & 0xfffff000)
+}
+
+// deviceAccess builds cgroup rules and supplementary groups for mapped devices. Rules cover
+// every minor of each device's major number, so devices that are re-plugged while the
+// container runs (e.g. a USB webcam) stay accessible. Groups let the non-root container
+// user open devices owned by groups such as audio and video.
+func deviceAccess(mappings []container.DeviceMapping) (rules []string, groups []string, err error) {
+	if runtime.GOOS != "linux" {
+		// Devices live in the Docker VM on other hosts; the daemon applies its own rules
+		return nil, nil, nil
+	}
+
+	ruleSet := make(map[string]bool)
+	groupSet := make(map[string]bool)
+	for _, mapping := range mappings {
+		nodes, err := hostDeviceNodes(mapping.PathOnHost)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read device %s: %w", mapping.PathOnHost, err)
+		}
+		if len(nodes) == 0 {
+			return nil, nil, fmt.Errorf("%s is not a device", mapping.PathOnHost)
+		}
+		for _, node := range nodes {
+			ruleSet[fmt.Sprintf("%s %d:* %s", node.kind, node.major, mapping.CgroupPermissions)] = true
+			if node.gid != 0 {
+				groupSet[strconv.FormatUint(uint64(node.gid), 10)] = true
+			}
+		}
+	}
+
+	return sortedKeys(ruleSet), sortedKeys(groupSet), nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
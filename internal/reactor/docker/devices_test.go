@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeviceSpec(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    container.DeviceMapping
+		wantErr bool
+	}{
+		{spec: "/dev/snd", want: container.DeviceMapping{PathOnHost: "/dev/snd", PathInContainer: "/dev/snd", CgroupPermissions: "rwm"}},
+		{spec: "/dev/video2:/dev/video0", want: container.DeviceMapping{PathOnHost: "/dev/video2", PathInContainer: "/dev/video0", CgroupPermissions: "rwm"}},
+		{spec: "/dev/video0::r", want: container.DeviceMapping{PathOnHost: "/dev/video0", PathInContainer: "/dev/video0", CgroupPermissions: "r"}},
+		{spec: "", wantErr: true},
+		{spec: "dev/snd", wantErr: true},
+		{spec: "/dev/snd:/dev/snd:rwx", wantErr: true},
+		{spec: "/dev/a:/dev/b:r:extra", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseDeviceSpec(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLinuxMajor(t *testing.T) {
+	assert.Equal(t, uint64(1), linuxMajor(0x0103))              // /dev/null is 1:3
+	assert.Equal(t, uint64(116), linuxMajor(0x7401))            // ALSA
+	assert.Equal(t, uint64(0x1234), linuxMajor(0x100000023400)) // major above 4095
+}
+
+func TestDeviceAccess(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("cgroup rules are only computed on Linux hosts")
+	}
+
+	t.Run("character device", func(t *testing.T) {
+		rules, _, err := deviceAccess([]container.DeviceMapping{{PathOnHost: "/dev/null", CgroupPermissions: "rw"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"c 1:* rw"}, rules)
+	})
+
+	t.Run("not a device", func(t *testing.T) {
+		_, _, err := deviceAccess([]container.DeviceMapping{{PathOnHost: t.TempDir(), CgroupPermissions: "rwm"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("missing device", func(t *testing.T) {
+		_, _, err := deviceAccess([]container.DeviceMapping{{PathOnHost: "/dev/does-not-exist", CgroupPermissions: "rwm"}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,35 @@
+//go:build !windows
+
+package docker
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// hostDeviceNodes returns the device nodes at path, walking it if it is a directory such as /dev/snd
+func hostDeviceNodes(path string) ([]deviceNode, error) {
+	var nodes []deviceNode
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(p)
+		if err != nil || info.Mode()&os.ModeDevice == 0 {
+			return nil
+		}
+		st, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return nil
+		}
+		kind := "b"
+		if info.Mode()&os.ModeCharDevice != 0 {
+			kind = "c"
+		}
+		nodes = append(nodes, deviceNode{kind: kind, major: linuxMajor(uint64(st.Rdev)), gid: st.Gid})
+		return nil
+	})
+	return nodes, err
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package docker
+
+import "fmt"
+
+// hostDeviceNodes is not supported on Windows, where devices are not exposed as device files
+func hostDeviceNodes(path string) ([]deviceNode, error) {
+	return nil, fmt.Errorf("device passthrough is not supported on Windows hosts")
+}
@@ -0,0 +1,45 @@
+package docker
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// HostGateway is the --add-host address Docker replaces with the host's gateway IP
+const HostGateway = "host-gateway"
+
+// ValidateDNSServer checks a --dns value, which must be an IP address
+func ValidateDNSServer(server string) error {
+	if net.ParseIP(server) == nil {
+		return fmt.Errorf("invalid DNS server '%s': use an IP address such as 10.0.0.2", server)
+	}
+	return nil
+}
+
+// ValidateDNSSearch checks a --dns-search value: a domain, or "." for no search domains
+func ValidateDNSSearch(domain string) error {
+	if domain == "." {
+		return nil
+	}
+	if domain == "" || len(domain) > 253 || strings.HasPrefix(domain, ".") || strings.ContainsAny(domain, " \t/:") {
+		return fmt.Errorf("invalid DNS search domain '%s': use a domain such as corp.example.com", domain)
+	}
+	return nil
+}
+
+// ParseHostSpec parses an --add-host value of the form host:ip or host=ip, where ip may be
+// host-gateway, and returns it in the host:ip form Docker expects
+func ParseHostSpec(spec string) (string, error) {
+	host, ip, ok := strings.Cut(spec, "=")
+	if !ok {
+		host, ip, ok = strings.Cut(spec, ":")
+	}
+	if !ok || host == "" || strings.ContainsAny(host, " \t") {
+		return "", fmt.Errorf("invalid host '%s': use host:ip, e.g. git.corp.example.com:10.1.2.3", spec)
+	}
+	if ip != HostGateway && net.ParseIP(strings.Trim(ip, "[]")) == nil {
+		return "", fmt.Errorf("invalid host '%s': '%s' is not an IP address or %s", spec, ip, HostGateway)
+	}
+	return host + ":" + strings.Trim(ip, "[]"), nil
+}
@@ -0,0 +1,38 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDNS(t *testing.T) {
+	assert.NoError(t, ValidateDNSServer("10.0.0.2"))
+	assert.NoError(t, ValidateDNSServer("fd00::53"))
+	assert.Error(t, ValidateDNSServer("dns.corp.example.com"))
+
+	assert.NoError(t, ValidateDNSSearch("corp.example.com"))
+	assert.NoError(t, ValidateDNSSearch("."))
+	for _, domain := range []string{"", ".corp", "corp example", "corp:53"} {
+		assert.Error(t, ValidateDNSSearch(domain), domain)
+	}
+}
+
+func TestParseHostSpec(t *testing.T) {
+	for spec, expected := range map[string]string{
+		"git.corp:10.1.2.3":     "git.corp:10.1.2.3",
+		"git.corp=10.1.2.3":     "git.corp:10.1.2.3",
+		"registry:host-gateway": "registry:host-gateway",
+		"v6host:::1":            "v6host:::1",
+		"v6host=[fd00::1]":      "v6host:fd00::1",
+	} {
+		parsed, err := ParseHostSpec(spec)
+		require.NoError(t, err, spec)
+		assert.Equal(t, expected, parsed, spec)
+	}
+	for _, spec := range []string{"", "git.corp", ":10.1.2.3", "git.corp:not-an-ip"} {
+		_, err := ParseHostSpec(spec)
+		assert.Error(t, err, spec)
+	}
+}
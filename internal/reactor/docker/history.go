@@ -0,0 +1,13 @@
+package docker
+
+// ShellHistoryDir is the directory of a project's session directory that keeps the shell
+// history of its containers, so it survives the containers being recreated
+const ShellHistoryDir = "shell-history"
+
+// ShellHistoryEnv points bash at the shell history in the session directory, mounted at
+// ~/.claude, and appends each command as it runs: sessions often end with the container being
+// stopped rather than the shell exiting.
+var ShellHistoryEnv = map[string]string{
+	"HISTFILE":       "/home/claude/.claude/" + ShellHistoryDir + "/bash_history",
+	"PROMPT_COMMAND": "history -a",
+}
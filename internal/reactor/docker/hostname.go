@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PromptEnv is the environment variable the built-in images show at the start of the shell
+// prompt, so a shell tells which project and account it belongs to
+const PromptEnv = "CLAUDE_REACTOR_PROMPT"
+
+// DefaultHostname and DefaultPrompt are used unless a project configures its own. {project},
+// {variant} and {account} are replaced with the container's.
+const (
+	DefaultHostname = "{project}-{variant}-{account}"
+	DefaultPrompt   = "claude-reactor:{project}@{account}"
+)
+
+// maxHostnameLength is the longest hostname Linux accepts
+const maxHostnameLength = 63
+
+var (
+	hostnamePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]*[A-Za-z0-9])?$`)
+	hostnameInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Branding expands a hostname or prompt template for a container's project, variant and account
+func Branding(template, projectPath, variant, account string) string {
+	if account == "" {
+		account = "default"
+	}
+	return strings.NewReplacer("{project}", filepath.Base(projectPath), "{variant}", variant, "{account}", account).Replace(template)
+}
+
+// Hostname returns a valid hostname from an expanded template: lower case, with runs of other
+// characters turned into a dash and cut to the length Linux allows
+func Hostname(name string) string {
+	name = strings.Trim(hostnameInvalid.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(name) > maxHostnameLength {
+		name = strings.TrimRight(name[:maxHostnameLength], "-")
+	}
+	return name
+}
+
+// ValidateHostname checks a configured hostname template. Its placeholders are expanded and
+// made valid by Hostname, so only the rest must already be.
+func ValidateHostname(template string) error {
+	name := Branding(template, "project", "variant", "account")
+	if len(name) > maxHostnameLength || !hostnamePattern.MatchString(name) {
+		return fmt.Errorf("invalid hostname '%s': use letters, digits and dashes, up to %d characters, optionally with {project}, {variant} and {account}", template, maxHostnameLength)
+	}
+	return nil
+}
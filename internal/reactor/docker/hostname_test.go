@@ -0,0 +1,32 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranding(t *testing.T) {
+	assert.Equal(t, "my_app-go-work", Branding(DefaultHostname, "/home/me/src/my_app", "go", "work"))
+	assert.Equal(t, "claude-reactor:my_app@default", Branding(DefaultPrompt, "/home/me/src/my_app", "go", ""))
+	assert.Equal(t, "dev-box", Branding("dev-box", "/home/me/src/my_app", "go", "work"))
+}
+
+func TestHostname(t *testing.T) {
+	assert.Equal(t, "my-app-go-work", Hostname("My_App-go-work"))
+	assert.Equal(t, "api-ghcr-io-org-dev-1-0-work", Hostname("api-ghcr.io_org_dev_1.0-work"))
+	assert.Equal(t, "app", Hostname("--app--"))
+
+	long := Hostname(strings.Repeat("a", 62) + "-b")
+	assert.Equal(t, strings.Repeat("a", 62), long)
+}
+
+func TestValidateHostname(t *testing.T) {
+	for _, template := range []string{DefaultHostname, "dev-box", "{project}", "work-{account}"} {
+		assert.NoError(t, ValidateHostname(template), template)
+	}
+	for _, template := range []string{"", "dev box", "-dev", "dev_box", "{project}.local", strings.Repeat("a", 64)} {
+		assert.Error(t, ValidateHostname(template), template)
+	}
+}
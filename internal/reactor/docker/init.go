@@ -0,0 +1,121 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// InitPath is where the claude-reactor init lives in containers. Built-in images use it as their
+// entrypoint; it is copied into containers of other images.
+const InitPath = "/usr/local/bin/claude-reactor-init"
+
+// initReadyFile is created by the init once container setup and start hooks have finished
+const initReadyFile = "/tmp/claude-reactor-init.ready"
+
+// initReadyTimeout bounds how long StartContainer waits for the init's setup
+const initReadyTimeout = 60 * time.Second
+
+// initScript is the init injected into containers of images that do not include it
+//
+//go:embed claude-reactor-init.sh
+var initScript []byte
+
+// initCommand returns the entrypoint and command that run an image under the init. inject
+// reports whether the image lacks the init, in which case it must be copied into the container.
+// command overrides the image's default command when set.
+func initCommand(imageEntrypoint, imageCmd, command []string) (entrypoint, cmd []string, inject bool) {
+	if len(command) == 0 {
+		command = imageCmd
+	}
+	if len(imageEntrypoint) > 0 && imageEntrypoint[0] == InitPath {
+		return nil, command, false
+	}
+	cmd = append(append([]string{}, imageEntrypoint...), command...)
+	return []string{InitPath}, cmd, true
+}
+
+// initArchive packs the init script for copying into a container's root
+func initArchive() (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	header := &tar.Header{
+		Name:    InitPath[1:],
+		Mode:    0755,
+		Size:    int64(len(initScript)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write(initScript); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// prepareInit points a container configuration at the init, so every image gets the same
+// setup. useInit is false when the image could not be inspected and starts as it is; inject
+// reports whether the init has to be copied in after the container is created.
+func (m *manager) prepareInit(ctx context.Context, containerConfig *container.Config) (useInit, inject bool) {
+	inspect, err := m.client.ImageInspect(ctx, containerConfig.Image)
+	if err != nil || inspect.Config == nil {
+		m.logger.Warnf("Could not inspect image %s, starting it without claude-reactor-init: %v", containerConfig.Image, err)
+		return false, false
+	}
+
+	entrypoint, cmd, inject := initCommand(inspect.Config.Entrypoint, inspect.Config.Cmd, containerConfig.Cmd)
+	if inject {
+		m.logger.Debugf("Image %s has no claude-reactor-init, wrapping %v", containerConfig.Image, cmd)
+		containerConfig.Entrypoint = entrypoint
+	}
+	containerConfig.Cmd = cmd
+	return true, inject
+}
+
+// injectInit copies the init into a created container
+func (m *manager) injectInit(ctx context.Context, containerID string) error {
+	archive, err := initArchive()
+	if err != nil {
+		return fmt.Errorf("failed to package claude-reactor-init: %w", err)
+	}
+	if err := m.client.CopyToContainer(ctx, containerID, "/", archive, container.CopyToContainerOptions{}); err != nil {
+		return fmt.Errorf("failed to copy claude-reactor-init into container: %w", err)
+	}
+	return nil
+}
+
+// usesInit reports whether a container was created to run under the init. Containers from
+// before the init was introduced never signal readiness.
+func (m *manager) usesInit(ctx context.Context, containerID string) bool {
+	inspect, err := m.client.ContainerInspect(ctx, containerID)
+	return err == nil && inspect.ContainerJSONBase != nil && inspect.Path == InitPath
+}
+
+// waitForInit waits until the init has prepared the container, so sessions never see a
+// half-initialized home directory
+func (m *manager) waitForInit(ctx context.Context, containerName string) error {
+	ctx, cancel := context.WithTimeout(ctx, initReadyTimeout)
+	defer cancel()
+
+	for {
+		_, exitCode, err := m.ExecCommand(ctx, containerName, []string{"test", "-f", initReadyFile})
+		if err == nil && exitCode == 0 {
+			m.logger.Debugf("✅ Container setup completed")
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for claude-reactor-init (see 'docker logs %s')", initReadyTimeout, containerName)
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
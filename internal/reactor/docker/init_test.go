@@ -0,0 +1,122 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// imageConfigClient inspects every image as having the given entrypoint and command
+type imageConfigClient struct {
+	client.APIClient
+	entrypoint, cmd []string
+	err             error
+}
+
+func (c *imageConfigClient) ImageInspect(ctx context.Context, imageID string, opts ...client.ImageInspectOption) (image.InspectResponse, error) {
+	if c.err != nil {
+		return image.InspectResponse{}, c.err
+	}
+	return image.InspectResponse{
+		Config: &dockerspec.DockerOCIImageConfig{ImageConfig: ocispec.ImageConfig{Entrypoint: c.entrypoint, Cmd: c.cmd}},
+	}, nil
+}
+
+func TestInitCommand(t *testing.T) {
+	tests := []struct {
+		name                      string
+		imageEntrypoint, imageCmd []string
+		command                   []string
+		entrypoint, cmd           []string
+		inject                    bool
+	}{
+		{
+			name:            "built-in image keeps its entrypoint",
+			imageEntrypoint: []string{InitPath, "/usr/local/bin/entrypoint.sh"},
+			imageCmd:        []string{"tail", "-f", "/dev/null"},
+			cmd:             []string{"tail", "-f", "/dev/null"},
+		},
+		{
+			name:            "custom image entrypoint and command are wrapped",
+			imageEntrypoint: []string{"/docker-entrypoint.sh"},
+			imageCmd:        []string{"start-notebook.sh"},
+			entrypoint:      []string{InitPath},
+			cmd:             []string{"/docker-entrypoint.sh", "start-notebook.sh"},
+			inject:          true,
+		},
+		{
+			name:       "configured command replaces the image command",
+			imageCmd:   []string{"bash"},
+			command:    []string{"sleep", "infinity"},
+			entrypoint: []string{InitPath},
+			cmd:        []string{"sleep", "infinity"},
+			inject:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entrypoint, cmd, inject := initCommand(tt.imageEntrypoint, tt.imageCmd, tt.command)
+			assert.Equal(t, tt.entrypoint, entrypoint)
+			assert.Equal(t, tt.cmd, cmd)
+			assert.Equal(t, tt.inject, inject)
+		})
+	}
+}
+
+func TestInitArchive(t *testing.T) {
+	archive, err := initArchive()
+	require.NoError(t, err)
+
+	tr := tar.NewReader(archive)
+	header, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "usr/local/bin/claude-reactor-init", header.Name)
+	assert.Equal(t, int64(0755), header.Mode)
+
+	content, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	assert.Equal(t, initScript, content)
+	assert.Contains(t, string(content), initReadyFile, "the script creates the file StartContainer waits for")
+}
+
+func TestManager_PrepareInit(t *testing.T) {
+	t.Run("custom image is wrapped", func(t *testing.T) {
+		logger := &MockLogger{}
+		logger.On("Debugf", mock.Anything, mock.Anything).Return()
+		m := &manager{client: &imageConfigClient{cmd: []string{"python3"}}, logger: logger}
+
+		config := &container.Config{Image: "python:3.12"}
+		useInit, inject := m.prepareInit(context.Background(), config)
+
+		assert.True(t, useInit)
+		assert.True(t, inject)
+		assert.Equal(t, []string{InitPath}, []string(config.Entrypoint))
+		assert.Equal(t, []string{"python3"}, []string(config.Cmd))
+	})
+
+	t.Run("uninspectable image starts unchanged", func(t *testing.T) {
+		logger := &MockLogger{}
+		logger.On("Warnf", mock.Anything, mock.Anything).Return()
+		m := &manager{client: &imageConfigClient{err: errors.New("no such image")}, logger: logger}
+
+		config := &container.Config{Image: "missing", Cmd: []string{"bash"}}
+		useInit, inject := m.prepareInit(context.Background(), config)
+
+		assert.False(t, useInit)
+		assert.False(t, inject)
+		assert.Nil(t, config.Entrypoint)
+		assert.Equal(t, []string{"bash"}, []string(config.Cmd))
+	})
+}
@@ -2,16 +2,16 @@ package docker
 
 import (
 	"archive/tar"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
-	"syscall"
 	"time"
 	"crypto/rand"
 	"math/big"
@@ -20,20 +20,40 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/moby/term"
 	
+	"claude-reactor/internal/reactor/architecture"
+	"claude-reactor/internal/reactor/buildstats"
+	"claude-reactor/internal/reactor/registry"
 	"claude-reactor/pkg"
 )
 
 // manager implements the DockerManager interface
 type manager struct {
-	client client.APIClient
-	logger pkg.Logger
+	client     client.APIClient
+	logger     pkg.Logger
+	clipboard  bool             // Bridge OSC 52 copies in interactive sessions to the host clipboard
+	workdir    string           // Working directory of attached sessions; "" for the image's
+	sessionEnv []string         // Environment of attached sessions only, such as secrets
+	images     pkg.ImageCache   // Remembered local images shared with the image validator; may be nil
+	mirror     *registry.Mirror // Pull-through cache tried before the registry; may be nil
+	history    string           // Build and pull history file; "" records nothing
 }
 
 // NewManager creates a new Docker manager with Docker client
 func NewManager(logger pkg.Logger) (pkg.DockerManager, error) {
-	// Initialize Docker client from environment with API version negotiation
+	return NewManagerContext(context.Background(), logger)
+}
+
+// NewManagerContext creates a Docker manager, giving up on reaching the daemon when ctx ends
+func NewManagerContext(ctx context.Context, logger pkg.Logger) (pkg.DockerManager, error) {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		os.Setenv("DOCKER_HOST", normalizeDockerHost(host))
+	}
+
+	// Initialize Docker client from environment with API version negotiation. Without
+	// DOCKER_HOST this is the unix socket, or the docker_engine named pipe on Windows.
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Docker client: %w", err)
@@ -42,17 +62,30 @@ func NewManager(logger pkg.Logger) (pkg.DockerManager, error) {
 	logger.Debug("Docker client initialized successfully")
 	
 	// Validate Docker connection
-	ctx := context.Background()
 	_, err = cli.Ping(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Docker daemon: %w", err)
+		connectErr := pkg.NewError(pkg.CodeDockerUnreachable, err, "failed to connect to Docker daemon")
+		if steps := dockerConnectSteps(); steps != nil {
+			connectErr.WithRemediation(steps...)
+		}
+		return nil, connectErr
 	}
 	
 	logger.Debug("Docker daemon connection validated")
 	
+	var apiClient client.APIClient = cli
+	if spec := os.Getenv(ChaosEnv); spec != "" {
+		faults, err := parseChaos(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", ChaosEnv, err)
+		}
+		apiClient = newChaosClient(cli, faults, logger)
+	}
+	
 	return &manager{
-		client: cli,
-		logger: logger,
+		client:  apiClient,
+		logger:  logger,
+		history: buildstats.Path(),
 	}, nil
 }
 
@@ -69,9 +102,13 @@ func (m *manager) BuildImage(ctx context.Context, variant string, platform strin
 	}
 	
 	m.logger.Debugf("Using variant: %s (%s)", variant, variantMgr.GetVariantDescription(variant))
+	definition, _ := variantMgr.GetVariantDefinition(variant)
+	if definition.Image != "" {
+		return fmt.Errorf("variant '%s' uses the prebuilt image %s; there is nothing to build", variant, definition.Image)
+	}
 	
-	// Create NamingManager for image name generation
-	archDetector := &basicArchDetector{}
+	// Create NamingManager for image name generation; images are named for the target platform
+	archDetector := &basicArchDetector{arch: architecture.PlatformArch(platform)}
 	namingMgr := NewNamingManager(m.logger, archDetector)
 	
 	imageName, err := namingMgr.GetImageName(variant)
@@ -79,10 +116,24 @@ func (m *manager) BuildImage(ctx context.Context, variant string, platform strin
 		return fmt.Errorf("failed to generate image name: %w", err)
 	}
 	
-	// Find project root directory (where Dockerfile is located)
-	projectRoot, err := m.findProjectRoot()
-	if err != nil {
-		return fmt.Errorf("failed to find project root: %w", err)
+	// The tag moves to the new build
+	if m.images != nil {
+		m.images.ForgetImage(imageName)
+	}
+	
+	// Built-in variants are stages of the main Dockerfile; external variants bring their own,
+	// built with its directory as the context
+	var projectRoot string
+	dockerfile, target := "Dockerfile", variant
+	if definition.Source != "" {
+		projectRoot = filepath.Dir(definition.Dockerfile)
+		dockerfile, target = filepath.Base(definition.Dockerfile), definition.Target
+	} else {
+		// Find project root directory (where Dockerfile is located)
+		projectRoot, err = m.findProjectRoot()
+		if err != nil {
+			return fmt.Errorf("failed to find project root: %w", err)
+		}
 	}
 	
 	// Create build context from project root directory
@@ -95,15 +146,17 @@ func (m *manager) BuildImage(ctx context.Context, variant string, platform strin
 	// Build image with Docker SDK
 	buildOptions := types.ImageBuildOptions{
 		Tags:       []string{imageName + ":latest"},
-		Target:     variant,
+		Target:     target,
 		Platform:   platform,
-		Dockerfile: "Dockerfile", // Use the main Dockerfile
+		Dockerfile: dockerfile,
 		Remove:     true,
 		ForceRemove: true,
+		Labels:     map[string]string{VersionLabel: cliVersion},
 	}
 	
 	m.logger.Debugf("Starting Docker build with options: %+v", buildOptions)
 	
+	started := time.Now()
 	buildResponse, err := m.client.ImageBuild(ctx, buildContext, buildOptions)
 	if err != nil {
 		return fmt.Errorf("failed to build Docker image: %w", err)
@@ -111,18 +164,51 @@ func (m *manager) BuildImage(ctx context.Context, variant string, platform strin
 	defer buildResponse.Body.Close()
 	
 	// Stream build output and check for errors
-	if err := m.streamBuildOutput(buildResponse.Body); err != nil {
+	var tracker buildstats.StepTracker
+	if err := m.streamBuildOutput(buildResponse.Body, &tracker); err != nil {
 		return fmt.Errorf("build failed: %w", err)
 	}
 	
-	m.logger.Infof("Successfully built image: %s", imageName)
+	steps := tracker.Finish(time.Now())
+	record := buildstats.Record{
+		Kind:     buildstats.KindBuild,
+		Variant:  variant,
+		Image:    imageName,
+		Platform: platform,
+		Started:  started,
+		Duration: time.Since(started),
+		Layers:   len(steps),
+		Cached:   buildstats.CountCached(steps),
+		Steps:    steps,
+	}
+	m.logger.Infof("Successfully built image %s: %s", imageName, record.Summary())
+	m.recordBuildStats(record)
 	return nil
 }
 
+// recordBuildStats adds record to the build history for 'claude-reactor stats'
+func (m *manager) recordBuildStats(record buildstats.Record) {
+	if m.history == "" {
+		return
+	}
+	if err := buildstats.Append(m.history, record); err != nil {
+		m.logger.Debugf("Could not record build statistics: %v", err)
+	}
+}
+
 // StartContainer starts a container with the given configuration
 func (m *manager) StartContainer(ctx context.Context, config *pkg.ContainerConfig) (string, error) {
 	m.logger.Infof("Starting container: %s", config.Name)
 	
+	containerID, err := m.CreateContainer(ctx, config)
+	if err != nil {
+		return "", err
+	}
+	return m.StartCreatedContainer(ctx, config, containerID)
+}
+
+// CreateContainer creates a container with the given configuration without starting it
+func (m *manager) CreateContainer(ctx context.Context, config *pkg.ContainerConfig) (string, error) {
 	// Create mount manager for handling mounts
 	mountMgr := NewMountManager(m.logger)
 	
@@ -145,10 +231,10 @@ func (m *manager) StartContainer(ctx context.Context, config *pkg.ContainerConfi
 		}
 	}
 	
-	// Validate mounts before proceeding
-	if err := mountMgr.ValidateMounts(configMounts); err != nil {
-		m.logger.Warnf("Mount validation failed: %v", err)
-		// Continue anyway, as some mounts may be optional
+	// Validate mounts before proceeding: required mounts must be usable, optional ones are dropped
+	configMounts, err := mountMgr.Preflight(configMounts)
+	if err != nil {
+		return "", err
 	}
 	
 	// Convert pkg.Mount to Docker SDK mount.Mount
@@ -168,10 +254,15 @@ func (m *manager) StartContainer(ctx context.Context, config *pkg.ContainerConfi
 	for key, value := range config.Environment {
 		env = append(env, fmt.Sprintf("%s=%s", key, value))
 	}
+	// Lets claude-reactor-init make host paths recorded by Claude CLI resolve in the container
+	if home, err := os.UserHomeDir(); err == nil && strings.HasPrefix(home, "/") {
+		env = append(env, "CLAUDE_REACTOR_HOST_HOME="+home)
+	}
 	
 	// Create container configuration
 	containerConfig := &container.Config{
 		Image:      config.Image,
+		Hostname:   config.Hostname,
 		Env:        env,
 		Cmd:        config.Command,
 		Tty:        config.TTY,
@@ -180,7 +271,15 @@ func (m *manager) StartContainer(ctx context.Context, config *pkg.ContainerConfi
 		AttachStdin: config.Interactive,
 		AttachStdout: true,
 		AttachStderr: true,
+		Labels: map[string]string{
+			PlatformLabel: config.Platform,
+			ConfigLabel:   ConfigHash(config),
+		},
 	}
+	for key, value := range config.Labels {
+		containerConfig.Labels[key] = value
+	}
+	_, injectInit := m.prepareInit(ctx, containerConfig)
 	
 	// Create host configuration
 	hostConfig := &container.HostConfig{
@@ -188,10 +287,35 @@ func (m *manager) StartContainer(ctx context.Context, config *pkg.ContainerConfi
 		AutoRemove:  false, // We'll manage removal manually
 		NetworkMode: "bridge", // Default network mode
 	}
+
+	// Map host devices (audio, video, etc.) with cgroup rules and groups that let the container user open them
+	if len(config.Devices) > 0 {
+		devices := make([]container.DeviceMapping, 0, len(config.Devices))
+		for _, spec := range config.Devices {
+			mapping, err := ParseDeviceSpec(spec)
+			if err != nil {
+				return "", err
+			}
+			devices = append(devices, mapping)
+		}
+		rules, groups, err := deviceAccess(devices)
+		if err != nil {
+			return "", fmt.Errorf("failed to configure devices: %w", err)
+		}
+		hostConfig.Resources.Devices = devices
+		hostConfig.Resources.DeviceCgroupRules = rules
+		hostConfig.GroupAdd = groups
+		m.logger.Debugf("Container devices: %v (cgroup rules: %v, groups: %v)", config.Devices, rules, groups)
+	}
+
+	// Custom name resolution, e.g. for internal hostnames in corporate networks
+	hostConfig.DNS = config.DNS
+	hostConfig.DNSSearch = config.DNSSearch
+	hostConfig.ExtraHosts = config.ExtraHosts
 	
 	// Create container
 	m.logger.Debugf("Creating container with image: %s", config.Image)
-	resp, err := m.client.ContainerCreate(ctx, containerConfig, hostConfig, nil, nil, config.Name)
+	resp, err := m.client.ContainerCreate(ctx, containerConfig, hostConfig, nil, foreignPlatform(config.Platform), config.Name)
 	if err != nil {
 		// Check for SSH agent socket mounting issues (common with Docker Desktop on macOS)
 		if strings.Contains(err.Error(), "socket_mnt") && strings.Contains(err.Error(), "bind source path does not exist") {
@@ -204,32 +328,49 @@ func (m *manager) StartContainer(ctx context.Context, config *pkg.ContainerConfi
 		return "", fmt.Errorf("failed to create container: %w", err)
 	}
 	
-	// Start container
-	m.logger.Debugf("Starting container with ID: %s", resp.ID)
-	if err := m.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+	if injectInit {
+		if err := m.injectInit(ctx, resp.ID); err != nil {
+			m.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{Force: true})
+			return "", err
+		}
+	}
+	
+	return resp.ID, nil
+}
+
+// StartCreatedContainer starts a container made by CreateContainer and finishes its setup:
+// waiting for claude-reactor-init and installing or upgrading Claude CLI
+func (m *manager) StartCreatedContainer(ctx context.Context, config *pkg.ContainerConfig, containerID string) (string, error) {
+	m.logger.Debugf("Starting container with ID: %s", containerID)
+	if err := m.client.ContainerStart(ctx, containerID, container.StartOptions{}); err != nil {
 		// Clean up the created container if start fails
-		m.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{Force: true})
+		m.client.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true})
 		return "", fmt.Errorf("failed to start container: %w", err)
 	}
 	
-	m.logger.Infof("Successfully started container: %s (ID: %s)", config.Name, resp.ID[:12])
+	m.logger.Infof("Successfully started container: %s (ID: %s)", config.Name, containerID[:12])
 	
-	// Ensure required directories exist in container
-	if err := m.ensureContainerDirectories(ctx, resp.ID); err != nil {
-		m.logger.Warnf("Failed to create container directories (non-fatal): %v", err)
-		// Don't fail container startup for directory creation issues
+	// Wait for claude-reactor-init to prepare directories, config, and ownership
+	if m.usesInit(ctx, containerID) {
+		if err := m.waitForInit(ctx, config.Name); err != nil {
+			m.logger.Warnf("Container setup incomplete (non-fatal): %v", err)
+		}
 	}
 	
-	// Run claude upgrade if requested and container has claude CLI
-	if config.RunClaudeUpgrade {
+	// Install the pinned claude CLI version, or run claude upgrade if requested
+	if config.ClaudeCLIVersion != "" {
+		if err := m.installClaudeCLI(ctx, config.Name, config.ClaudeCLIVersion); err != nil {
+			m.logger.Warnf("Failed to install Claude CLI %s (non-fatal): %v", config.ClaudeCLIVersion, err)
+		}
+	} else if config.RunClaudeUpgrade {
 		m.logger.Info("Running claude upgrade in container...")
-		if err := m.runClaudeUpgrade(ctx, resp.ID); err != nil {
+		if err := m.runClaudeUpgrade(ctx, containerID); err != nil {
 			m.logger.Warnf("Claude upgrade failed (non-fatal): %v", err)
 			// Don't fail container startup for upgrade issues
 		}
 	}
 	
-	return resp.ID, nil
+	return containerID, nil
 }
 
 // runClaudeUpgrade executes claude upgrade in the container after startup
@@ -264,74 +405,6 @@ func (m *manager) runClaudeUpgrade(ctx context.Context, containerID string) erro
 	return nil
 }
 
-// ensureContainerDirectories creates required directories in the container
-func (m *manager) ensureContainerDirectories(ctx context.Context, containerID string) error {
-	// Create directories to prevent Claude CLI "Path not found" errors
-	// These directories are needed by Claude CLI for additional working directories
-	directories := []string{
-		"/home/claude/.claude-reactor",
-		"/Users/cam/.claude-reactor", 
-		"/Users/cam",
-	}
-	
-	for _, dir := range directories {
-		execConfig := container.ExecOptions{
-			Cmd:    []string{"sudo", "mkdir", "-p", dir},
-			Detach: false,
-		}
-		
-		// Create exec instance
-		execResp, err := m.client.ContainerExecCreate(ctx, containerID, execConfig)
-		if err != nil {
-			m.logger.Warnf("Failed to create directory creation exec for %s: %v", dir, err)
-			continue
-		}
-		
-		// Start exec and wait for completion
-		err = m.client.ContainerExecStart(ctx, execResp.ID, container.ExecStartOptions{
-			Detach: false,
-		})
-		if err != nil {
-			m.logger.Warnf("Failed to create directory %s: %v", dir, err)
-			continue
-		}
-		
-		m.logger.Debugf("✅ Created directory: %s", dir)
-	}
-	
-	// Create symlinks to map problematic paths to correct locations
-	symlinks := map[string]string{
-		"/Users/cam/.claude-reactor": "/home/claude/.claude",
-		"/Users/cam":                 "/home/claude",
-	}
-	
-	for source, target := range symlinks {
-		execConfig := container.ExecOptions{
-			Cmd:    []string{"sudo", "ln", "-sf", target, source},
-			Detach: false,
-		}
-		
-		execResp, err := m.client.ContainerExecCreate(ctx, containerID, execConfig)
-		if err != nil {
-			m.logger.Warnf("Failed to create symlink exec for %s: %v", source, err)
-			continue
-		}
-		
-		err = m.client.ContainerExecStart(ctx, execResp.ID, container.ExecStartOptions{
-			Detach: false,
-		})
-		if err != nil {
-			m.logger.Warnf("Failed to create symlink %s -> %s: %v", source, target, err)
-			continue
-		}
-		
-		m.logger.Debugf("✅ Created symlink: %s -> %s", source, target)
-	}
-	
-	m.logger.Debugf("✅ Container directory setup completed")
-	return nil
-}
-
 // StopContainer stops a running container
 func (m *manager) StopContainer(ctx context.Context, containerID string) error {
 	m.logger.Infof("Stopping container: %s", containerID[:12])
@@ -415,9 +488,16 @@ func (m *manager) IsContainerRunning(ctx context.Context, containerName string)
 
 // GetContainerLogs retrieves logs from a container
 func (m *manager) GetContainerLogs(ctx context.Context, containerID string) (io.ReadCloser, error) {
-	// TODO: Implement container log retrieval with Docker SDK
 	m.logger.Debugf("Getting logs for container: %s", containerID)
-	return nil, nil // Stub implementation
+	logs, err := m.client.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       "500",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container logs: %w", err)
+	}
+	return logs, nil
 }
 
 // createBuildContext creates a tar archive of the build context
@@ -530,8 +610,9 @@ func (m *manager) shouldSkipPath(path string) bool {
 	return false
 }
 
-// streamBuildOutput streams Docker build output and parses for errors
-func (m *manager) streamBuildOutput(reader io.Reader) error {
+// streamBuildOutput streams Docker build output and parses for errors, timing each step with
+// tracker unless it is nil
+func (m *manager) streamBuildOutput(reader io.Reader, tracker *buildstats.StepTracker) error {
 	decoder := json.NewDecoder(reader)
 	
 	for decoder.More() {
@@ -547,6 +628,12 @@ func (m *manager) streamBuildOutput(reader io.Reader) error {
 		
 		// Log stream messages
 		if stream, ok := message["stream"].(string); ok {
+			if tracker != nil {
+				now := time.Now()
+				for _, line := range strings.Split(stream, "\n") {
+					tracker.Observe(line, now)
+				}
+			}
 			stream = strings.TrimSpace(stream)
 			if stream != "" {
 				m.logger.Debugf("Build: %s", stream)
@@ -557,10 +644,16 @@ func (m *manager) streamBuildOutput(reader io.Reader) error {
 	return nil
 }
 
-// basicArchDetector is a simple implementation for internal use
-type basicArchDetector struct{}
+// basicArchDetector is a simple implementation for internal use. arch, when set, overrides
+// the host architecture so names follow a requested platform.
+type basicArchDetector struct {
+	arch string
+}
 
 func (d *basicArchDetector) GetHostArchitecture() (string, error) {
+	if d.arch != "" {
+		return d.arch, nil
+	}
 	// Use the same logic as architecture package
 	arch := runtime.GOARCH
 	switch arch {
@@ -589,7 +682,10 @@ func (d *basicArchDetector) IsMultiArchSupported() bool {
 func (m *manager) RebuildImage(ctx context.Context, variant string, platform string, force bool) error {
 	if force {
 		// Remove existing image first
-		imageName := m.GetImageName(variant, "")
+		imageName := m.GetImageName(variant, architecture.PlatformArch(platform))
+		if m.images != nil {
+			m.images.ForgetImage(imageName)
+		}
 		_, err := m.client.ImageRemove(ctx, imageName, image.RemoveOptions{
 			Force:         true,
 			PruneChildren: true,
@@ -613,12 +709,23 @@ func (m *manager) GetContainerStatus(ctx context.Context, containerName string)
 		for _, name := range container.Names {
 			// Docker container names start with '/'
 			if strings.TrimPrefix(name, "/") == containerName {
+				var ports []pkg.PortMapping
+				for _, port := range container.Ports {
+					if port.PublicPort != 0 && port.Type == "tcp" {
+						ports = append(ports, pkg.PortMapping{HostPort: int(port.PublicPort), ContainerPort: int(port.PrivatePort)})
+					}
+				}
 				return &pkg.ContainerStatus{
-					Exists:  true,
-					Running: container.State == "running",
-					Name:    containerName,
-					Image:   container.Image,
-					ID:      container.ID,
+					Exists:     true,
+					Running:    container.State == "running",
+					Fresh:      container.State == "created",
+					Name:       containerName,
+					Image:      container.Image,
+					ID:         container.ID,
+					Ports:      ports,
+					ConfigHash: container.Labels[ConfigLabel],
+					Health:     healthState(container.Status),
+					Labels:     container.Labels,
 				}, nil
 			}
 		}
@@ -631,6 +738,17 @@ func (m *manager) GetContainerStatus(ctx context.Context, containerName string)
 	}, nil
 }
 
+// healthState extracts the health check state from a container status such as
+// "Up 5 minutes (unhealthy)"
+func healthState(status string) string {
+	for _, state := range []string{"unhealthy", "healthy", "health: starting"} {
+		if strings.Contains(status, "("+state+")") {
+			return strings.TrimPrefix(state, "health: ")
+		}
+	}
+	return ""
+}
+
 // CleanContainer removes specific project/account container
 func (m *manager) CleanContainer(ctx context.Context, containerName string) error {
 	status, err := m.GetContainerStatus(ctx, containerName)
@@ -696,151 +814,6 @@ func (m *manager) AttachToContainer(ctx context.Context, containerName string, c
 	}
 }
 
-// attachInteractive handles interactive container attachment with TTY
-func (m *manager) attachInteractive(ctx context.Context, containerID string, command []string) error {
-	m.logger.Debugf("Creating interactive exec for container %s", containerID[:12])
-	
-	// Create exec configuration
-	execConfig := container.ExecOptions{
-		Cmd:          command,
-		AttachStdin:  true,
-		AttachStdout: true,
-		AttachStderr: true,
-		Tty:          true,
-	}
-	
-	// Create exec instance
-	execResp, err := m.client.ContainerExecCreate(ctx, containerID, execConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create exec instance: %w", err)
-	}
-	
-	// Start exec with hijacked connection for interactive I/O
-	execStartCheck := container.ExecStartOptions{
-		Tty: true,
-	}
-	
-	hijackedResp, err := m.client.ContainerExecAttach(ctx, execResp.ID, execStartCheck)
-	if err != nil {
-		return fmt.Errorf("failed to attach to exec instance: %w", err)
-	}
-	defer hijackedResp.Close()
-	
-	// Start the exec instance
-	if err := m.client.ContainerExecStart(ctx, execResp.ID, execStartCheck); err != nil {
-		return fmt.Errorf("failed to start exec instance: %w", err)
-	}
-	
-	m.logger.Info("✅ Successfully attached to container - press Ctrl+C to disconnect")
-	
-	// Set up proper terminal handling for keystroke interpretation
-	fd := os.Stdin.Fd()
-	var oldState *term.State
-	
-	// Check if stdin is a terminal and set raw mode
-	if term.IsTerminal(fd) {
-		oldState, err = term.MakeRaw(fd)
-		if err != nil {
-			m.logger.Warnf("Failed to set terminal to raw mode: %v", err)
-		} else {
-			// Ensure we restore terminal state on exit
-			defer func() {
-				if oldState != nil {
-					term.RestoreTerminal(fd, oldState)
-				}
-			}()
-		}
-		
-		// Sync terminal size to prevent display issues
-		if err := m.resizeContainerTTY(ctx, execResp.ID); err != nil {
-			m.logger.Debugf("Failed to resize container TTY: %v", err)
-		}
-	}
-	
-	// Set up signal handling to properly restore terminal on interrupt
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-	
-	// Handle I/O with proper error handling and buffering
-	inputDone := make(chan error, 1)
-	outputDone := make(chan error, 1)
-	
-	// Set up terminal resize handling to prevent display issues
-	if term.IsTerminal(fd) {
-		resizeChan := make(chan os.Signal, 1)
-		signal.Notify(resizeChan, syscall.SIGWINCH)
-		
-		go func() {
-			for range resizeChan {
-				if err := m.resizeContainerTTY(ctx, execResp.ID); err != nil {
-					m.logger.Debugf("Failed to resize container TTY: %v", err)
-				}
-			}
-		}()
-	}
-	
-	// Copy output from container to stdout with improved buffering
-	go func() {
-		// Use a buffered copy to reduce flickering
-		buf := make([]byte, 1024)
-		for {
-			n, err := hijackedResp.Reader.Read(buf)
-			if n > 0 {
-				if _, writeErr := os.Stdout.Write(buf[:n]); writeErr != nil {
-					outputDone <- writeErr
-					return
-				}
-			}
-			if err != nil {
-				outputDone <- err
-				return
-			}
-		}
-	}()
-	
-	// Copy input from stdin to container with improved buffering
-	go func() {
-		_, err := io.Copy(hijackedResp.Conn, os.Stdin)
-		inputDone <- err
-	}()
-	
-	// Wait for completion or signal
-	select {
-	case <-sigChan:
-		m.logger.Debug("Received interrupt signal, disconnecting...")
-		// Restore terminal state before exiting
-		if oldState != nil {
-			term.RestoreTerminal(fd, oldState)
-		}
-		return fmt.Errorf("interrupted by user")
-		
-	case err := <-inputDone:
-		if err != nil {
-			m.logger.Debugf("Input stream ended: %v", err)
-		}
-		
-	case err := <-outputDone:
-		if err != nil {
-			m.logger.Debugf("Output stream ended: %v", err)
-		}
-	}
-	
-	// Check if exec is still running
-	inspectResp, err := m.client.ContainerExecInspect(ctx, execResp.ID)
-	if err != nil {
-		return fmt.Errorf("failed to inspect exec instance: %w", err)
-	}
-	
-	if inspectResp.Running {
-		m.logger.Debug("Exec still running, waiting for completion...")
-		// Give it a moment to complete
-		time.Sleep(1 * time.Second)
-	}
-	
-	m.logger.Debug("Interactive session completed")
-	return nil
-}
-
 // resizeContainerTTY synchronizes the container's TTY size with the host terminal
 func (m *manager) resizeContainerTTY(ctx context.Context, execID string) error {
 	fd := os.Stdin.Fd()
@@ -877,6 +850,8 @@ func (m *manager) attachNonInteractive(ctx context.Context, containerID string,
 		AttachStdout: true,
 		AttachStderr: true,
 		Tty:          false,
+		WorkingDir:   m.workdir,
+		Env:          m.sessionEnv,
 	}
 	
 	// Create exec instance
@@ -901,8 +876,8 @@ func (m *manager) attachNonInteractive(ctx context.Context, containerID string,
 		return fmt.Errorf("failed to start exec instance: %w", err)
 	}
 	
-	// Copy output to stdout/stderr
-	io.Copy(os.Stdout, hijackedResp.Reader)
+	// Output is multiplexed without a TTY; split it back onto stdout/stderr
+	stdcopy.StdCopy(os.Stdout, os.Stderr, hijackedResp.Reader)
 	
 	// Wait for completion and get exit code
 	inspectResp, err := m.client.ContainerExecInspect(ctx, execResp.ID)
@@ -911,12 +886,90 @@ func (m *manager) attachNonInteractive(ctx context.Context, containerID string,
 	}
 	
 	if inspectResp.ExitCode != 0 {
-		return fmt.Errorf("command exited with code %d", inspectResp.ExitCode)
+		return &pkg.ExitError{Code: inspectResp.ExitCode}
 	}
 	
 	return nil
 }
 
+// ExecCommand runs a command in a running container and returns its combined output and exit code
+func (m *manager) ExecCommand(ctx context.Context, containerName string, command []string) (string, int, error) {
+	containerID, err := m.getContainerIDByName(ctx, containerName)
+	if err != nil {
+		return "", -1, err
+	}
+
+	execConfig := container.ExecOptions{
+		Cmd:          command,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          false,
+	}
+
+	execResp, err := m.client.ContainerExecCreate(ctx, containerID, execConfig)
+	if err != nil {
+		return "", -1, fmt.Errorf("failed to create exec instance: %w", err)
+	}
+
+	hijackedResp, err := m.client.ContainerExecAttach(ctx, execResp.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", -1, fmt.Errorf("failed to attach to exec instance: %w", err)
+	}
+	defer hijackedResp.Close()
+
+	// Non-TTY exec output is multiplexed; demultiplex both streams into one buffer
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, &output, hijackedResp.Reader); err != nil {
+		return "", -1, fmt.Errorf("failed to read exec output: %w", err)
+	}
+
+	inspectResp, err := m.client.ContainerExecInspect(ctx, execResp.ID)
+	if err != nil {
+		return output.String(), -1, fmt.Errorf("failed to inspect exec instance: %w", err)
+	}
+
+	return output.String(), inspectResp.ExitCode, nil
+}
+
+// ExecPipe runs a command in a running container, streaming stdin to it and its stdout
+// back to the caller until either side closes. Used for port forwarding over exec.
+func (m *manager) ExecPipe(ctx context.Context, containerName string, command []string, stdin io.Reader, stdout io.Writer) error {
+	containerID, err := m.getContainerIDByName(ctx, containerName)
+	if err != nil {
+		return err
+	}
+
+	execConfig := container.ExecOptions{
+		Cmd:          command,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          false,
+	}
+
+	execResp, err := m.client.ContainerExecCreate(ctx, containerID, execConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create exec instance: %w", err)
+	}
+
+	hijackedResp, err := m.client.ContainerExecAttach(ctx, execResp.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to attach to exec instance: %w", err)
+	}
+	defer hijackedResp.Close()
+
+	go func() {
+		io.Copy(hijackedResp.Conn, stdin)
+		hijackedResp.CloseWrite()
+	}()
+
+	// Stderr is discarded so diagnostics don't corrupt the forwarded stream
+	if _, err := stdcopy.StdCopy(stdout, io.Discard, hijackedResp.Reader); err != nil {
+		return fmt.Errorf("failed to read exec output: %w", err)
+	}
+	return nil
+}
+
 // getContainerIDByName retrieves container ID by name
 func (m *manager) getContainerIDByName(ctx context.Context, containerName string) (string, error) {
 	containers, err := m.client.ContainerList(ctx, container.ListOptions{All: true})
@@ -979,12 +1032,23 @@ func (m *manager) ListVariants() ([]pkg.VariantDefinition, error) {
 		},
 	}
 	
-	return variants, nil
+	// External variants from ~/.claude-reactor/variants.d follow the built-ins
+	variantMgr := NewVariantManager(m.logger)
+	external := make([]pkg.VariantDefinition, 0)
+	for _, name := range variantMgr.GetAvailableVariants() {
+		if variantMgr.IsExternal(name) {
+			definition, _ := variantMgr.GetVariantDefinition(name)
+			external = append(external, *definition)
+		}
+	}
+	sort.Slice(external, func(i, j int) bool { return external[i].Name < external[j].Name })
+	
+	return append(variants, external...), nil
 }
 
 // GenerateContainerName creates unique container name with project hash
 func (m *manager) GenerateContainerName(projectPath, variant, architecture, account string) string {
-	namingMgr := NewNamingManager(m.logger, &basicArchDetector{})
+	namingMgr := NewNamingManager(m.logger, &basicArchDetector{arch: architecture})
 	containerName, err := namingMgr.GetContainerName(variant, account)
 	if err != nil {
 		m.logger.Errorf("Failed to generate container name: %v", err)
@@ -1009,7 +1073,7 @@ func (m *manager) GenerateProjectHash(projectPath string) string {
 
 // GetImageName generates image name with architecture
 func (m *manager) GetImageName(variant, architecture string) string {
-	namingMgr := NewNamingManager(m.logger, &basicArchDetector{})
+	namingMgr := NewNamingManager(m.logger, &basicArchDetector{arch: architecture})
 	imageName, _ := namingMgr.GetImageName(variant)
 	return imageName
 }
@@ -1073,6 +1137,11 @@ func (m *manager) CleanImages(ctx context.Context, all bool) error {
 			}
 		} else {
 			cleanedCount++
+			if m.images != nil {
+				for _, tag := range img.RepoTags {
+					m.images.ForgetImage(tag)
+				}
+			}
 		}
 	}
 	
@@ -1172,27 +1241,31 @@ func (m *manager) getRegistryImageName(variant string) string {
 }
 
 // tryPullFromRegistry attempts to pull an image from registry
-func (m *manager) tryPullFromRegistry(ctx context.Context, variant string) error {
+func (m *manager) tryPullFromRegistry(ctx context.Context, variant, platform string) error {
 	registryImageName := m.getRegistryImageName(variant)
 	
 	m.logger.Infof("📦 Attempting to pull %s variant from registry...", variant)
 	m.logger.Debugf("Registry image: %s", registryImageName)
 	
-	// Pull from registry
-	pullResponse, err := m.client.ImagePull(ctx, registryImageName, image.PullOptions{})
+	// Pull from registry, through the mirror when one is configured
+	started := time.Now()
+	layers, err := registry.PullLayers(ctx, m.client, registryImageName, image.PullOptions{Platform: platform}, m.mirror, m.logger)
 	if err != nil {
 		return fmt.Errorf("failed to pull from registry: %w", err)
 	}
-	defer pullResponse.Close()
-	
-	// Read and discard the pull output (we could stream it if verbose)
-	_, err = io.Copy(io.Discard, pullResponse)
-	if err != nil {
-		return fmt.Errorf("failed to complete registry pull: %w", err)
+	record := buildstats.Record{
+		Kind:     buildstats.KindPull,
+		Variant:  variant,
+		Image:    registryImageName,
+		Platform: platform,
+		Started:  started,
+		Duration: time.Since(started),
+		Layers:   layers.Total,
+		Cached:   layers.Present,
 	}
 	
 	// Get the local image name
-	namingMgr := NewNamingManager(m.logger, &basicArchDetector{})
+	namingMgr := NewNamingManager(m.logger, &basicArchDetector{arch: architecture.PlatformArch(platform)})
 	localImageName, err := namingMgr.GetImageName(variant)
 	if err != nil {
 		return fmt.Errorf("failed to generate local image name: %w", err)
@@ -1209,26 +1282,50 @@ func (m *manager) tryPullFromRegistry(ctx context.Context, variant string) error
 		return fmt.Errorf("failed to tag pulled image: %w", err)
 	}
 	
-	m.logger.Infof("✅ Successfully pulled %s variant from registry", variant)
+	m.logger.Infof("✅ Successfully pulled %s variant from registry: %s", variant, record.Summary())
+	m.recordBuildStats(record)
 	return nil
 }
 
 // imageExistsLocally checks if an image exists locally
 func (m *manager) imageExistsLocally(ctx context.Context, imageName string) (bool, error) {
-	_, _, err := m.client.ImageInspectWithRaw(ctx, imageName)
+	if m.images != nil {
+		if _, ok := m.images.LookupImage(imageName); ok {
+			return true, nil
+		}
+	}
+
+	info, _, err := m.client.ImageInspectWithRaw(ctx, imageName)
 	if err != nil {
 		if client.IsErrNotFound(err) {
 			return false, nil
 		}
 		return false, err
 	}
+	if m.images != nil && info.ID != "" {
+		m.images.RememberImage(imageName, info.ID)
+	}
 	return true, nil
 }
 
+// SetImageCache shares remembered image lookups so BuildImageWithRegistry can skip the daemon
+func (m *manager) SetImageCache(cache pkg.ImageCache) {
+	m.images = cache
+}
+
+// SetRegistryMirror pulls published images through mirror; "" pulls from the registry directly
+func (m *manager) SetRegistryMirror(mirror string) {
+	parsed, err := registry.ParseMirror(mirror)
+	if err != nil {
+		m.logger.Debugf("Ignoring registry mirror: %v", err)
+	}
+	m.mirror = parsed
+}
+
 // BuildImageWithRegistry builds an image with registry support
 func (m *manager) BuildImageWithRegistry(ctx context.Context, variant, platform string, devMode, registryOff, pullLatest bool) error {
 	// Get image name
-	namingMgr := NewNamingManager(m.logger, &basicArchDetector{})
+	namingMgr := NewNamingManager(m.logger, &basicArchDetector{arch: architecture.PlatformArch(platform)})
 	imageName, err := namingMgr.GetImageName(variant)
 	if err != nil {
 		return fmt.Errorf("failed to generate image name: %w", err)
@@ -1238,6 +1335,9 @@ func (m *manager) BuildImageWithRegistry(ctx context.Context, variant, platform
 	if pullLatest {
 		m.logger.Info("⬇️ Force pulling latest image from registry...")
 		// Remove local image first
+		if m.images != nil {
+			m.images.ForgetImage(imageName)
+		}
 		_, err := m.client.ImageRemove(ctx, imageName, image.RemoveOptions{Force: true})
 		if err != nil && !client.IsErrNotFound(err) {
 			m.logger.Debugf("Could not remove local image for force pull: %v", err)
@@ -1258,12 +1358,13 @@ func (m *manager) BuildImageWithRegistry(ctx context.Context, variant, platform
 	
 	// Try registry first if enabled
 	if m.shouldUseRegistry(devMode, registryOff) {
-		err := m.tryPullFromRegistry(ctx, variant)
+		err := m.tryPullFromRegistry(ctx, variant, platform)
 		if err != nil {
 			m.logger.Infof("❌ Failed to pull from registry: %v", err)
 			m.logger.Info("🔨 Falling back to local build...")
 		} else {
 			// Successfully pulled from registry
+			m.checkImageVersion(ctx, imageName)
 			return nil
 		}
 	} else if devMode {
@@ -1278,7 +1379,24 @@ func (m *manager) BuildImageWithRegistry(ctx context.Context, variant, platform
 
 // GetClient returns the underlying Docker client for advanced operations
 func (m *manager) GetClient() *client.Client {
-	if c, ok := m.client.(*client.Client); ok {
+	apiClient := m.client
+	// Advanced operations are not covered by chaos mode
+	if chaos, ok := apiClient.(*chaosClient); ok {
+		apiClient = chaos.APIClient
+	}
+	if c, ok := apiClient.(*client.Client); ok {
+		return c
+	}
+	return nil
+}
+
+// APIClient returns the client mgr's requests go through, so other components such as the
+// image validator see chaos mode's faults too. Managers from elsewhere get GetClient.
+func APIClient(mgr pkg.DockerManager) client.APIClient {
+	if m, ok := mgr.(*manager); ok {
+		return m.client
+	}
+	if c := mgr.GetClient(); c != nil {
 		return c
 	}
 	return nil
@@ -1328,6 +1446,11 @@ func (m *manager) StartOrRecoverContainer(ctx context.Context, config *pkg.Conta
 				m.client.ContainerRemove(ctx, status.ID, container.RemoveOptions{Force: true})
 			} else {
 				m.logger.Infof("✅ Successfully started existing container: %s", config.Name)
+				if m.usesInit(ctx, status.ID) {
+					if err := m.waitForInit(ctx, config.Name); err != nil {
+						m.logger.Warnf("Container setup incomplete (non-fatal): %v", err)
+					}
+				}
 				// Update session config with the container ID
 				sessionConfig.ContainerID = status.ID
 				return status.ID, nil
@@ -1411,45 +1534,6 @@ func (m *manager) IsContainerHealthy(ctx context.Context, containerID string) (b
 	return true, nil
 }
 
-// initializeClaudeConfig copies the Claude configuration files from temporary mounts to proper locations
-func (m *manager) initializeClaudeConfig(ctx context.Context, containerID string) error {
-	// Copy Claude config from temporary mount to proper location
-	copyConfigCmd := []string{"sh", "-c", `
-		# Copy Claude config if the seed file exists
-		if [ -f /tmp/claude-config-seed.json ]; then
-			cp /tmp/claude-config-seed.json /home/claude/.claude.json
-			chmod 644 /home/claude/.claude.json
-			echo "Copied Claude config to /home/claude/.claude.json"
-		fi
-		
-		# Copy credentials file if it exists
-		if [ -f /home/claude/.credentials.json ]; then
-			chmod 600 /home/claude/.credentials.json
-			echo "Set credentials file permissions"
-		fi
-	`}
-	
-	// Create exec configuration
-	execConfig := container.ExecOptions{
-		Cmd:    copyConfigCmd,
-		Detach: false,
-	}
-	
-	// Create exec instance
-	execResp, err := m.client.ContainerExecCreate(ctx, containerID, execConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create config copy exec: %w", err)
-	}
-	
-	// Start the exec instance
-	if err := m.client.ContainerExecStart(ctx, execResp.ID, container.ExecStartOptions{}); err != nil {
-		return fmt.Errorf("failed to copy config files: %w", err)
-	}
-	
-	m.logger.Debug("Claude configuration files initialized")
-	return nil
-}
-
 // generateSessionID creates a unique session identifier
 func generateSessionID() (string, error) {
 	// Generate a random 16-character hex string
@@ -479,7 +479,7 @@ func TestManager_GenerateContainerName(t *testing.T) {
 		assert.Contains(t, containerName, "claude-reactor")
 		assert.Contains(t, containerName, "go")
 		assert.Contains(t, containerName, "testuser")
-		// The function uses naming manager which detects actual host architecture, not the passed parameter
+		assert.Contains(t, containerName, "-amd64-")
 	})
 	
 	t.Run("handles empty project path", func(t *testing.T) {
@@ -582,3 +582,10 @@ func TestManager_FindProjectRoot(t *testing.T) {
 	})
 }
 
+
+func TestHealthState(t *testing.T) {
+	assert.Equal(t, "unhealthy", healthState("Up 5 minutes (unhealthy)"))
+	assert.Equal(t, "healthy", healthState("Up 2 hours (healthy)"))
+	assert.Equal(t, "starting", healthState("Up 3 seconds (health: starting)"))
+	assert.Equal(t, "", healthState("Up 5 minutes"))
+}
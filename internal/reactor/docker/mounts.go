@@ -3,11 +3,14 @@ package docker
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/docker/docker/api/types/mount"
 	
+	"claude-reactor/internal/reactor/wsl"
 	"claude-reactor/pkg"
 )
 
@@ -43,25 +46,28 @@ func (mm *MountManager) CreateDefaultMounts(account string) ([]pkg.Mount, error)
 	mm.logger.Debugf("Added project mount: %s -> /app", currentDir)
 
 	// 2. Kubernetes config mount (read-only)
-	kubeConfig := filepath.Join(os.Getenv("HOME"), ".kube")
+	homeDir, _ := os.UserHomeDir()
+	kubeConfig := filepath.Join(homeDir, ".kube")
 	if _, err := os.Stat(kubeConfig); err == nil {
 		mounts = append(mounts, pkg.Mount{
 			Source: kubeConfig,
 			Target: "/home/claude/.kube",
 			Type:   "bind",
 			ReadOnly: true,
+			Optional: true,
 		})
 		mm.logger.Debugf("Added Kubernetes config mount")
 	}
 	
 	// 3. Git config mount (read-only)
-	gitConfig := filepath.Join(os.Getenv("HOME"), ".gitconfig")
+	gitConfig := filepath.Join(homeDir, ".gitconfig")
 	if _, err := os.Stat(gitConfig); err == nil {
 		mounts = append(mounts, pkg.Mount{
 			Source: gitConfig,
 			Target: "/home/claude/.gitconfig",
 			Type:   "bind",
 			ReadOnly: true,
+			Optional: true,
 		})
 		mm.logger.Debugf("Added Git config mount")
 	}
@@ -82,6 +88,9 @@ func (mm *MountManager) AddUserMounts(mounts []pkg.Mount, mountPaths []string) (
 	for _, mountPath := range mountPaths {
 		// Expand tilde if present
 		expandedPath := mm.expandPath(mountPath)
+		if env := wsl.Detect(); env != nil {
+			expandedPath = wsl.TranslatePath(expandedPath, env.Distro)
+		}
 		
 		// Convert to absolute path if relative
 		if !filepath.IsAbs(expandedPath) {
@@ -100,7 +109,7 @@ func (mm *MountManager) AddUserMounts(mounts []pkg.Mount, mountPaths []string) (
 		
 		// Create mount point using basename
 		mountName := filepath.Base(expandedPath)
-		targetPath := filepath.Join("/mnt", mountName)
+		targetPath := path.Join("/mnt", mountName)
 		
 		mount := pkg.Mount{
 			Source: expandedPath,
@@ -116,36 +125,88 @@ func (mm *MountManager) AddUserMounts(mounts []pkg.Mount, mountPaths []string) (
 	return mounts, nil
 }
 
-// ValidateMounts checks that all mount sources exist and are accessible
+// maxParallelMountChecks bounds concurrent stat calls, which can each block on slow network filesystems
+const maxParallelMountChecks = 8
+
+// MountCheck is the pre-flight result for one mount
+type MountCheck struct {
+	Mount pkg.Mount
+	Err   error // nil when the source is usable
+}
+
+// CheckMounts checks mount sources concurrently, returning one result per mount in order
+func (mm *MountManager) CheckMounts(mounts []pkg.Mount) []MountCheck {
+	checks := make([]MountCheck, len(mounts))
+	limit := make(chan struct{}, maxParallelMountChecks)
+	var wg sync.WaitGroup
+	for i, mount := range mounts {
+		checks[i].Mount = mount
+		wg.Add(1)
+		go func(i int, mount pkg.Mount) {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+			checks[i].Err = checkMountSource(mount)
+		}(i, mount)
+	}
+	wg.Wait()
+	return checks
+}
+
+// checkMountSource reports why a mount's source cannot be used, with a hint on fixing it
+func checkMountSource(mount pkg.Mount) error {
+	// Skip validation for Docker socket and other system mounts
+	if mount.Source == "/var/run/docker.sock" {
+		return nil
+	}
+
+	// Named volumes are created by Docker on demand, and tmpfs mounts have no source
+	if mount.Type == "volume" || mount.Type == "tmpfs" {
+		return nil
+	}
+
+	info, err := os.Stat(mount.Source)
+	if os.IsNotExist(err) {
+		return pkg.NewError(pkg.CodeMountMissing, nil, "mount source does not exist: %s", mount.Source).WithRemediation("Create it or remove the mount for " + mount.Target)
+	}
+	if err != nil {
+		return pkg.NewError(pkg.CodeMountInaccessible, err, "mount source is not accessible: %s", mount.Source)
+	}
+
+	// Skip accessibility check for sockets as they can't be opened with os.Open()
+	if info.Mode()&os.ModeSocket != 0 {
+		return nil
+	}
+
+	file, err := os.Open(mount.Source)
+	if err != nil {
+		return pkg.NewError(pkg.CodeMountInaccessible, err, "mount source is not accessible: %s", mount.Source).WithRemediation("Check that your user can read it")
+	}
+	file.Close()
+	return nil
+}
+
+// ValidateMounts checks that all required mount sources exist and are accessible
 func (mm *MountManager) ValidateMounts(mounts []pkg.Mount) error {
-	for _, mount := range mounts {
-		// Skip validation for Docker socket and other system mounts
-		if mount.Source == "/var/run/docker.sock" {
+	_, err := mm.Preflight(mounts)
+	return err
+}
+
+// Preflight checks mounts before a container is created. A failing required mount is an error;
+// failing optional mounts are left out with a warning, so the session starts without them.
+func (mm *MountManager) Preflight(mounts []pkg.Mount) ([]pkg.Mount, error) {
+	usable := make([]pkg.Mount, 0, len(mounts))
+	for _, check := range mm.CheckMounts(mounts) {
+		if check.Err == nil {
+			usable = append(usable, check.Mount)
 			continue
 		}
-		
-		// Check if source exists
-		if _, err := os.Stat(mount.Source); os.IsNotExist(err) {
-			return fmt.Errorf("mount source does not exist: %s", mount.Source)
+		if !check.Mount.Optional {
+			return nil, check.Err
 		}
-		
-		// Check if source is readable (skip for sockets as they can't be opened)
-		if info, err := os.Stat(mount.Source); err == nil {
-			// Skip accessibility check for sockets as they can't be opened with os.Open()
-			if info.Mode()&os.ModeSocket != 0 {
-				// This is a socket file, skip the open test
-				continue
-			}
-		}
-		
-		file, err := os.Open(mount.Source)
-		if err != nil {
-			return fmt.Errorf("mount source is not accessible: %s (%v)", mount.Source, err)
-		}
-		file.Close()
+		mm.logger.Warnf("Skipping optional mount %s: %v", check.Mount.Target, strings.SplitN(check.Err.Error(), "\n", 2)[0])
 	}
-	
-	return nil
+	return usable, nil
 }
 
 // ConvertToDockerMounts converts pkg.Mount to Docker SDK mount.Mount
@@ -153,11 +214,16 @@ func (mm *MountManager) ConvertToDockerMounts(pkgMounts []pkg.Mount) []mount.Mou
 	dockerMounts := make([]mount.Mount, len(pkgMounts))
 	
 	for i, pkgMount := range pkgMounts {
+		source := pkgMount.Source
+		if pkgMount.Type == "bind" {
+			source = HostPathForDocker(source)
+		}
 		dockerMounts[i] = mount.Mount{
-			Type:     mount.Type(pkgMount.Type),
-			Source:   pkgMount.Source,
-			Target:   pkgMount.Target,
-			ReadOnly: pkgMount.ReadOnly,
+			Type:        mount.Type(pkgMount.Type),
+			Source:      source,
+			Target:      pkgMount.Target,
+			ReadOnly:    pkgMount.ReadOnly,
+			Consistency: mount.Consistency(pkgMount.Consistency),
 		}
 	}
 	
@@ -183,9 +249,9 @@ func (mm *MountManager) GetMountSummary(mounts []pkg.Mount) []string {
 func (mm *MountManager) createClaudeConfigMounts(account string) ([]pkg.Mount, error) {
 	mounts := []pkg.Mount{}
 	
-	homeDir := os.Getenv("HOME")
-	if homeDir == "" {
-		return mounts, fmt.Errorf("HOME environment variable not set")
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return mounts, fmt.Errorf("failed to get home directory: %w", err)
 	}
 	
 	// Determine Claude config directory based on account
@@ -266,8 +332,7 @@ func (mm *MountManager) createClaudeConfigMounts(account string) ([]pkg.Mount, e
 // expandPath expands tilde (~) to home directory
 func (mm *MountManager) expandPath(path string) string {
 	if strings.HasPrefix(path, "~/") {
-		homeDir := os.Getenv("HOME")
-		if homeDir != "" {
+		if homeDir, err := os.UserHomeDir(); err == nil {
 			return filepath.Join(homeDir, path[2:])
 		}
 	}
@@ -199,6 +199,61 @@ func TestMountManager_ValidateMounts(t *testing.T) {
 	})
 }
 
+func TestMountManager_CheckMounts(t *testing.T) {
+	mm := NewMountManager(&MockLogger{})
+	tempDir := t.TempDir()
+
+	var mounts []pkg.Mount
+	for i := 0; i < 20; i++ {
+		source := filepath.Join(tempDir, "missing")
+		if i%2 == 0 {
+			source = tempDir
+		}
+		mounts = append(mounts, pkg.Mount{Source: source, Target: filepath.Join("/mnt", string(rune('a'+i))), Type: "bind"})
+	}
+
+	checks := mm.CheckMounts(mounts)
+	assert.Len(t, checks, len(mounts))
+	for i, check := range checks {
+		assert.Equal(t, mounts[i], check.Mount, "results keep the order of the mounts")
+		if i%2 == 0 {
+			assert.NoError(t, check.Err)
+		} else {
+			assert.NotEmpty(t, pkg.Remediation(check.Err))
+		}
+	}
+}
+
+func TestMountManager_Preflight(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("Warnf", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	mm := NewMountManager(mockLogger)
+	tempDir := t.TempDir()
+
+	t.Run("optional failures are dropped", func(t *testing.T) {
+		mounts := []pkg.Mount{
+			{Source: tempDir, Target: "/app", Type: "bind"},
+			{Source: "/non-existent-credentials", Target: "/home/claude/.credentials.json", Type: "bind", Optional: true},
+			{Source: "project-sync", Target: "/workspace", Type: "volume"},
+		}
+
+		usable, err := mm.Preflight(mounts)
+		assert.NoError(t, err)
+		assert.Equal(t, []pkg.Mount{mounts[0], mounts[2]}, usable)
+	})
+
+	t.Run("required failures stop with a hint", func(t *testing.T) {
+		mounts := []pkg.Mount{
+			{Source: "/non-existent-data", Target: "/mnt/data", Type: "bind"},
+		}
+
+		_, err := mm.Preflight(mounts)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "mount source does not exist: /non-existent-data")
+		assert.Contains(t, pkg.Remediation(err), "Create it or remove the mount for /mnt/data")
+	})
+}
+
 func TestMountManager_ConvertToDockerMounts(t *testing.T) {
 	mockLogger := &MockLogger{}
 	mm := NewMountManager(mockLogger)
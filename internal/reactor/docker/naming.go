@@ -52,7 +52,7 @@ func (nm *NamingManager) GetContainerName(variant, account string) (string, erro
 	}
 	
 	// Build container name parts
-	parts := []string{"claude-reactor", variant, arch, projectHash}
+	parts := []string{"claude-reactor", containerVariant(variant), arch, projectHash}
 	
 	// Add account if specified, otherwise use "default"
 	if account != "" {
@@ -68,6 +68,20 @@ func (nm *NamingManager) GetContainerName(variant, account string) (string, erro
 	return containerName, nil
 }
 
+// containerVariant makes a custom image reference valid in container names without adding
+// dashes: ghcr.io/org/dev:1.0 becomes ghcr.io_org_dev_1.0, and digests are shortened to 12
+// characters
+func containerVariant(variant string) string {
+	if name, digest, pinned := strings.Cut(variant, "@"); pinned {
+		digest = strings.TrimPrefix(digest, "sha256:")
+		if len(digest) > 12 {
+			digest = digest[:12]
+		}
+		variant = name + "@" + digest
+	}
+	return strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(variant)
+}
+
 // getProjectHash generates a hash based on current working directory
 // Replicates: PROJECT_HASH=$(echo "$(pwd)" | shasum -a 256 | cut -c1-8)
 func (nm *NamingManager) getProjectHash() (string, error) {
@@ -178,6 +178,13 @@ func TestNamingManager_GetContainerName(t *testing.T) {
 	}
 }
 
+func TestContainerVariant(t *testing.T) {
+	assert.Equal(t, "go", containerVariant("go"))
+	assert.Equal(t, "ubuntu_22.04", containerVariant("ubuntu:22.04"))
+	assert.Equal(t, "ghcr.io_org_dev_1.0", containerVariant("ghcr.io/org/dev:1.0"))
+	assert.Equal(t, "ubuntu_0123456789ab", containerVariant("ubuntu@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"))
+}
+
 func TestNamingManager_GetProjectHash(t *testing.T) {
 	mockLogger := &MockLogger{}
 	mockLogger.On("Debugf", mock.AnythingOfType("string"), mock.Anything).Return()
@@ -0,0 +1,155 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+
+	"claude-reactor/pkg"
+)
+
+// PackagesRepository names the images that add a project's packages to its variant. Each is
+// tagged with a fingerprint of its base image and package lists.
+const PackagesRepository = "claude-reactor-packages"
+
+// PackagesBaseLabel records the image a packages image was built on
+const PackagesBaseLabel = "claude-reactor.packages-base"
+
+// packageNamePattern allows package names with versions and scopes, such as protobuf-compiler,
+// grpcio==1.62.0, @bufbuild/buf@1.30.0 or cargo-nextest@0.9.67, but nothing the shell would
+// interpret
+var packageNamePattern = regexp.MustCompile(`^[A-Za-z0-9@][A-Za-z0-9@._+:=<>~/-]*$`)
+
+// ValidatePackage checks that name can be installed as a package
+func ValidatePackage(name string) error {
+	if !packageNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid package name '%s'", name)
+	}
+	return nil
+}
+
+// packagesSteps returns the Dockerfile instructions that install packages on an image whose
+// user is user. npm packages are installed as that user, so they share the ownership of the
+// Claude CLI's global packages.
+func packagesSteps(user string, packages pkg.Packages) (string, error) {
+	for _, list := range [][]string{packages.Apt, packages.Pip, packages.Npm, packages.Cargo} {
+		for _, name := range list {
+			if err := ValidatePackage(name); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	var steps strings.Builder
+	steps.WriteString("USER root\n")
+	if len(packages.Apt) > 0 {
+		fmt.Fprintf(&steps, "RUN apt-get update && DEBIAN_FRONTEND=noninteractive apt-get install -y --no-install-recommends %s && rm -rf /var/lib/apt/lists/*\n", quotePackages(packages.Apt))
+	}
+	if len(packages.Pip) > 0 {
+		fmt.Fprintf(&steps, "RUN python3 -m pip install --no-cache-dir %s\n", quotePackages(packages.Pip))
+	}
+	if len(packages.Cargo) > 0 {
+		// Installed to /usr/local/bin, where every user finds them
+		fmt.Fprintf(&steps, "RUN CARGO_TARGET_DIR=/tmp/cargo-target cargo install --locked --root /usr/local %s && rm -rf /tmp/cargo-target \"${CARGO_HOME:-$HOME/.cargo}/registry\"\n", quotePackages(packages.Cargo))
+	}
+	if user != "" && user != "root" {
+		fmt.Fprintf(&steps, "USER %s\n", user)
+	}
+	if len(packages.Npm) > 0 {
+		fmt.Fprintf(&steps, "RUN npm install -g %s && npm cache clean --force\n", quotePackages(packages.Npm))
+	}
+	return steps.String(), nil
+}
+
+// quotePackages single-quotes validated package names, so version ranges such as >=1.0 are not
+// taken as redirections
+func quotePackages(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + name + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// packagesImageName names the packages image for a base image ID and the steps that install
+// the packages on it
+func packagesImageName(baseImageID, steps string) string {
+	sum := sha256.Sum256([]byte(baseImageID + "\n" + steps))
+	return PackagesRepository + ":" + hex.EncodeToString(sum[:])[:12]
+}
+
+// packagesImage returns the name of the packages image for baseImage and the Dockerfile
+// that builds it
+func (m *manager) packagesImage(ctx context.Context, baseImage string, packages pkg.Packages) (string, string, error) {
+	inspect, err := m.client.ImageInspect(ctx, baseImage)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to inspect base image %s: %w", baseImage, err)
+	}
+	user := ""
+	if inspect.Config != nil {
+		user = inspect.Config.User
+	}
+	steps, err := packagesSteps(user, packages)
+	if err != nil {
+		return "", "", err
+	}
+	return packagesImageName(inspect.ID, steps), fmt.Sprintf("FROM %s\n%s", baseImage, steps), nil
+}
+
+// PackagesImageName returns the name of the image deriving from the local baseImage with
+// packages installed
+func (m *manager) PackagesImageName(ctx context.Context, baseImage string, packages pkg.Packages) (string, error) {
+	name, _, err := m.packagesImage(ctx, baseImage, packages)
+	return name, err
+}
+
+// BuildPackagesImage returns the image deriving from the local baseImage with packages
+// installed, building it unless an image for the same base and packages exists
+func (m *manager) BuildPackagesImage(ctx context.Context, baseImage string, packages pkg.Packages, platform string) (string, error) {
+	name, dockerfile, err := m.packagesImage(ctx, baseImage, packages)
+	if err != nil {
+		return "", err
+	}
+	if _, err := m.client.ImageInspect(ctx, name); err == nil {
+		m.logger.Debugf("Using packages image %s", name)
+		return name, nil
+	}
+
+	m.logger.Infof("📦 Building %s: project packages on %s...", name, baseImage)
+	var buildContext bytes.Buffer
+	tw := tar.NewWriter(&buildContext)
+	if err := tw.WriteHeader(&tar.Header{Name: "Dockerfile", Mode: 0644, Size: int64(len(dockerfile))}); err != nil {
+		return "", err
+	}
+	if _, err := tw.Write([]byte(dockerfile)); err != nil {
+		return "", err
+	}
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+
+	buildResponse, err := m.client.ImageBuild(ctx, &buildContext, types.ImageBuildOptions{
+		Tags:        []string{name},
+		Platform:    platform,
+		Dockerfile:  "Dockerfile",
+		Remove:      true,
+		ForceRemove: true,
+		Labels:      map[string]string{VersionLabel: cliVersion, PackagesBaseLabel: baseImage},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to build packages image: %w", err)
+	}
+	defer buildResponse.Body.Close()
+	if err := m.streamBuildOutput(buildResponse.Body, nil); err != nil {
+		return "", fmt.Errorf("failed to install project packages: %w", err)
+	}
+	m.logger.Infof("✅ Built packages image %s", name)
+	return name, nil
+}
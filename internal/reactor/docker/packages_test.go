@@ -0,0 +1,61 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func TestValidatePackage(t *testing.T) {
+	for _, name := range []string{"protobuf-compiler", "libssl-dev=1.1.1", "grpcio==1.62.0", "numpy>=1.26", "@bufbuild/buf@1.30.0", "cargo-nextest@0.9.67", "g++"} {
+		assert.NoError(t, ValidatePackage(name), name)
+	}
+	for _, name := range []string{"", "jq; rm -rf /", "a b", "$(whoami)", "it's", "-y"} {
+		assert.Error(t, ValidatePackage(name), name)
+	}
+}
+
+func TestPackagesSteps(t *testing.T) {
+	steps, err := packagesSteps("claude", pkg.Packages{
+		Apt:   []string{"protobuf-compiler", "jq"},
+		Pip:   []string{"grpcio-tools>=1.62"},
+		Npm:   []string{"@bufbuild/buf"},
+		Cargo: []string{"cargo-nextest"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"USER root",
+		"RUN apt-get update && DEBIAN_FRONTEND=noninteractive apt-get install -y --no-install-recommends 'protobuf-compiler' 'jq' && rm -rf /var/lib/apt/lists/*",
+		"RUN python3 -m pip install --no-cache-dir 'grpcio-tools>=1.62'",
+		`RUN CARGO_TARGET_DIR=/tmp/cargo-target cargo install --locked --root /usr/local 'cargo-nextest' && rm -rf /tmp/cargo-target "${CARGO_HOME:-$HOME/.cargo}/registry"`,
+		"USER claude",
+		"RUN npm install -g '@bufbuild/buf' && npm cache clean --force",
+	}, strings.Split(strings.TrimSpace(steps), "\n"))
+
+	// Images that run as root need no user switch
+	steps, err = packagesSteps("", pkg.Packages{Apt: []string{"jq"}})
+	require.NoError(t, err)
+	assert.NotContains(t, steps, "USER claude")
+	assert.NotContains(t, steps, "pip")
+
+	_, err = packagesSteps("claude", pkg.Packages{Npm: []string{"left-pad && curl evil"}})
+	assert.ErrorContains(t, err, "invalid package name")
+}
+
+func TestPackagesImageName(t *testing.T) {
+	steps, err := packagesSteps("claude", pkg.Packages{Apt: []string{"jq"}})
+	require.NoError(t, err)
+	other, err := packagesSteps("claude", pkg.Packages{Apt: []string{"jq", "make"}})
+	require.NoError(t, err)
+
+	name := packagesImageName("sha256:base1", steps)
+	assert.Regexp(t, `^claude-reactor-packages:[0-9a-f]{12}$`, name)
+	assert.Equal(t, name, packagesImageName("sha256:base1", steps))
+	// A new base image or package list gets a new image
+	assert.NotEqual(t, name, packagesImageName("sha256:base2", steps))
+	assert.NotEqual(t, name, packagesImageName("sha256:base1", other))
+}
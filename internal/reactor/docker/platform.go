@@ -0,0 +1,32 @@
+package docker
+
+import (
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// PlatformLabel records the platform a container was created for
+const PlatformLabel = "claude-reactor.platform"
+
+// foreignPlatform returns the platform to request when creating a container, or nil for the
+// host platform. Native containers leave it unset so older daemons without platform
+// support keep working.
+func foreignPlatform(platform string) *ocispec.Platform {
+	if platform == "" {
+		return nil
+	}
+	if native, _ := (&basicArchDetector{}).GetDockerPlatform(); platform == native {
+		return nil
+	}
+
+	parts := strings.SplitN(platform, "/", 3)
+	if len(parts) < 2 {
+		return nil
+	}
+	spec := &ocispec.Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		spec.Variant = parts[2]
+	}
+	return spec
+}
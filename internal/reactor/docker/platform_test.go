@@ -0,0 +1,29 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForeignPlatform(t *testing.T) {
+	native, _ := (&basicArchDetector{}).GetDockerPlatform()
+	assert.Nil(t, foreignPlatform(""))
+	assert.Nil(t, foreignPlatform(native), "host platform is left to the daemon")
+
+	foreign := "linux/amd64"
+	if native == foreign {
+		foreign = "linux/arm64"
+	}
+	spec := foreignPlatform(foreign)
+	if assert.NotNil(t, spec) {
+		assert.Equal(t, "linux", spec.OS)
+		assert.Equal(t, foreign[len("linux/"):], spec.Architecture)
+	}
+
+	spec = foreignPlatform("linux/arm/v7")
+	if assert.NotNil(t, spec) {
+		assert.Equal(t, "arm", spec.Architecture)
+		assert.Equal(t, "v7", spec.Variant)
+	}
+}
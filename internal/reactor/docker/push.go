@@ -0,0 +1,105 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+
+	"claude-reactor/internal/reactor/architecture"
+	"claude-reactor/internal/reactor/registry"
+	"claude-reactor/pkg"
+)
+
+// createManifest points ref at a multi-architecture manifest of sources. The Docker API
+// cannot write manifest lists, so this uses the docker CLI, which also brings its logins.
+var createManifest = func(ctx context.Context, ref string, sources []string) error {
+	args := append([]string{"buildx", "imagetools", "create", "--tag", ref}, sources...)
+	output, err := exec.CommandContext(ctx, "docker", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("docker buildx imagetools create failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ValidateRepository checks that repository can prefix pushed variant names, e.g.
+// my.registry/claude-reactor; tags and digests are added by the push
+func ValidateRepository(repository string) error {
+	last := repository[strings.LastIndex(repository, "/")+1:]
+	if repository == "" || strings.Contains(last, ":") || strings.Contains(repository, "@") ||
+		strings.HasSuffix(repository, "/") || repository != strings.ToLower(repository) {
+		return fmt.Errorf("invalid registry '%s': use a lowercase repository prefix without a tag, e.g. my.registry/claude-reactor", repository)
+	}
+	return nil
+}
+
+// PushImage pushes the local build of variant for platform as <repository>/<variant>:<tag>-<arch>
+// and points <repository>/<variant>:<tag> at every architecture pushed under that tag so far.
+// It returns the references pushed.
+func (m *manager) PushImage(ctx context.Context, variant, platform, repository, tag string) ([]string, error) {
+	if err := ValidateRepository(repository); err != nil {
+		return nil, err
+	}
+	arch := architecture.PlatformArch(platform)
+	localImage := m.GetImageName(variant, arch)
+	exists, err := m.imageExistsLocally(ctx, localImage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for image %s: %w", localImage, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("image %s not found; build it first", localImage)
+	}
+
+	target := fmt.Sprintf("%s/%s", repository, variant)
+	archRef := fmt.Sprintf("%s:%s-%s", target, tag, arch)
+	auth, err := registry.Auth(archRef)
+	if err != nil {
+		m.logger.Warnf("⚠️  Could not read registry credentials, pushing anonymously: %v", err)
+	}
+
+	if err := m.client.ImageTag(ctx, localImage, archRef); err != nil {
+		return nil, fmt.Errorf("failed to tag %s as %s: %w", localImage, archRef, err)
+	}
+	m.logger.Infof("⬆️  Pushing %s...", archRef)
+	if err := m.push(ctx, archRef, auth); err != nil {
+		return nil, err
+	}
+	pushed := []string{archRef}
+
+	// Other architectures may have been pushed from other machines; include them all
+	var sources []string
+	for _, p := range architecture.Platforms() {
+		ref := fmt.Sprintf("%s:%s-%s", target, tag, architecture.PlatformArch(p))
+		if ref == archRef {
+			sources = append(sources, ref)
+			continue
+		}
+		if _, err := m.client.DistributionInspect(ctx, ref, auth); err == nil {
+			sources = append(sources, ref)
+		}
+	}
+
+	manifestRef := fmt.Sprintf("%s:%s", target, tag)
+	m.logger.Infof("🗂️  Updating manifest %s (%s)...", manifestRef, strings.Join(sources, ", "))
+	if err := createManifest(ctx, manifestRef, sources); err != nil {
+		return pushed, fmt.Errorf("pushed %s but failed to update manifest %s: %w", archRef, manifestRef, err)
+	}
+	return append(pushed, manifestRef), nil
+}
+
+// push pushes ref, surfacing errors the registry reports in the progress stream
+func (m *manager) push(ctx context.Context, ref, auth string) error {
+	response, err := m.client.ImagePush(ctx, ref, image.PushOptions{RegistryAuth: auth})
+	if err != nil {
+		return fmt.Errorf("failed to push %s: %w", ref, err)
+	}
+	defer response.Close()
+	if err := jsonmessage.DisplayJSONMessagesStream(response, io.Discard, 0, false, nil); err != nil {
+		return pkg.NewError(pkg.CodeRegistryPush, err, "failed to push %s", ref).WithRemediation("Log in first with: docker login " + registry.Host(ref))
+	}
+	return nil
+}
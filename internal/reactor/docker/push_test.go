@@ -0,0 +1,123 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+// pushClient has local images in local and remote tags in remote, and records pushes
+type pushClient struct {
+	client.APIClient
+	local   map[string]bool
+	remote  map[string]bool
+	tagged  map[string]string
+	pushed  []string
+	pushErr string
+}
+
+func (c *pushClient) ImageInspectWithRaw(ctx context.Context, ref string) (image.InspectResponse, []byte, error) {
+	if !c.local[ref] {
+		return image.InspectResponse{}, nil, errNotFound{}
+	}
+	return image.InspectResponse{ID: "sha256:abc"}, nil, nil
+}
+
+func (c *pushClient) ImageTag(ctx context.Context, source, target string) error {
+	c.tagged[target] = source
+	return nil
+}
+
+func (c *pushClient) ImagePush(ctx context.Context, ref string, options image.PushOptions) (io.ReadCloser, error) {
+	c.pushed = append(c.pushed, ref)
+	stream := `{"status":"Pushed"}`
+	if c.pushErr != "" {
+		stream = `{"errorDetail":{"message":"` + c.pushErr + `"},"error":"` + c.pushErr + `"}`
+	}
+	return io.NopCloser(strings.NewReader(stream)), nil
+}
+
+func (c *pushClient) DistributionInspect(ctx context.Context, ref, auth string) (registrytypes.DistributionInspect, error) {
+	if !c.remote[ref] {
+		return registrytypes.DistributionInspect{}, errors.New("manifest unknown")
+	}
+	return registrytypes.DistributionInspect{}, nil
+}
+
+// errNotFound satisfies client.IsErrNotFound
+type errNotFound struct{}
+
+func (errNotFound) Error() string { return "No such image" }
+func (errNotFound) NotFound()     {}
+
+func TestValidateRepository(t *testing.T) {
+	assert.NoError(t, ValidateRepository("my.registry/claude-reactor"))
+	assert.NoError(t, ValidateRepository("localhost:5000/team"))
+	assert.Error(t, ValidateRepository(""))
+	assert.Error(t, ValidateRepository("my.registry/claude-reactor:v1"))
+	assert.Error(t, ValidateRepository("my.registry/Claude"))
+	assert.Error(t, ValidateRepository("my.registry/claude-reactor/"))
+}
+
+func TestManager_PushImage(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	logger := &MockLogger{}
+	logger.On("Infof", mock.Anything, mock.Anything).Return()
+	logger.On("Debugf", mock.Anything, mock.Anything).Return()
+
+	var manifests map[string][]string
+	original := createManifest
+	createManifest = func(ctx context.Context, ref string, sources []string) error {
+		manifests[ref] = sources
+		return nil
+	}
+	defer func() { createManifest = original }()
+
+	t.Run("pushes the architecture tag and a manifest of every pushed architecture", func(t *testing.T) {
+		manifests = map[string][]string{}
+		m := &manager{logger: logger}
+		local := m.GetImageName("go", "arm64")
+		cli := &pushClient{
+			local:  map[string]bool{local: true},
+			remote: map[string]bool{"my.registry/team/go:v1-amd64": true},
+			tagged: map[string]string{},
+		}
+		m.client = cli
+
+		pushed, err := m.PushImage(context.Background(), "go", "linux/arm64", "my.registry/team", "v1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"my.registry/team/go:v1-arm64", "my.registry/team/go:v1"}, pushed)
+		assert.Equal(t, local, cli.tagged["my.registry/team/go:v1-arm64"])
+		assert.Equal(t, []string{"my.registry/team/go:v1-arm64"}, cli.pushed)
+		assert.Equal(t, []string{"my.registry/team/go:v1-amd64", "my.registry/team/go:v1-arm64"}, manifests["my.registry/team/go:v1"])
+	})
+
+	t.Run("fails without a local build", func(t *testing.T) {
+		m := &manager{client: &pushClient{tagged: map[string]string{}}, logger: logger}
+		_, err := m.PushImage(context.Background(), "go", "linux/arm64", "my.registry/team", "v1")
+		assert.ErrorContains(t, err, "build it first")
+	})
+
+	t.Run("reports registry errors from the push stream", func(t *testing.T) {
+		manifests = map[string][]string{}
+		m := &manager{logger: logger}
+		cli := &pushClient{local: map[string]bool{m.GetImageName("go", "amd64"): true}, tagged: map[string]string{}, pushErr: "denied: requested access to the resource is denied"}
+		m.client = cli
+
+		_, err := m.PushImage(context.Background(), "go", "linux/amd64", "my.registry/team", "latest")
+		assert.ErrorContains(t, err, "denied")
+		assert.Contains(t, pkg.Remediation(err), "Log in first with: docker login my.registry")
+		assert.Empty(t, manifests)
+	})
+}
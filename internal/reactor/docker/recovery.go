@@ -11,14 +11,20 @@ import (
 	"claude-reactor/pkg"
 )
 
+// RecoveryServices are the parts of pkg.DockerManager whose operations RecoveryManager retries
+type RecoveryServices interface {
+	pkg.ContainerService
+	pkg.ImageService
+}
+
 // RecoveryManager handles error recovery and retry logic for Docker operations
 type RecoveryManager struct {
 	logger    pkg.Logger
-	dockerMgr pkg.DockerManager
+	dockerMgr RecoveryServices
 }
 
 // NewRecoveryManager creates a new recovery manager
-func NewRecoveryManager(logger pkg.Logger, dockerMgr pkg.DockerManager) *RecoveryManager {
+func NewRecoveryManager(logger pkg.Logger, dockerMgr RecoveryServices) *RecoveryManager {
 	return &RecoveryManager{
 		logger:    logger,
 		dockerMgr: dockerMgr,
@@ -3,143 +3,18 @@ package docker
 import (
 	"context"
 	"errors"
-	"io"
 	"testing"
 	"time"
 
-	"github.com/docker/docker/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
-	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
 )
 
-// MockDockerManager for testing recovery functionality
-type MockDockerManager struct {
-	mock.Mock
-}
-
-func (m *MockDockerManager) BuildImage(ctx context.Context, variant string, platform string) error {
-	args := m.Called(ctx, variant, platform)
-	return args.Error(0)
-}
-
-func (m *MockDockerManager) StartContainer(ctx context.Context, config *pkg.ContainerConfig) (string, error) {
-	args := m.Called(ctx, config)
-	return args.String(0), args.Error(1)
-}
-
-func (m *MockDockerManager) StopContainer(ctx context.Context, containerID string) error {
-	args := m.Called(ctx, containerID)
-	return args.Error(0)
-}
-
-func (m *MockDockerManager) RemoveContainer(ctx context.Context, containerID string) error {
-	args := m.Called(ctx, containerID)
-	return args.Error(0)
-}
-
-func (m *MockDockerManager) IsContainerRunning(ctx context.Context, containerName string) (bool, error) {
-	args := m.Called(ctx, containerName)
-	return args.Bool(0), args.Error(1)
-}
-
-func (m *MockDockerManager) GetContainerLogs(ctx context.Context, containerID string) (io.ReadCloser, error) {
-	args := m.Called(ctx, containerID)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(io.ReadCloser), args.Error(1)
-}
-
-// New interface methods
-func (m *MockDockerManager) RebuildImage(ctx context.Context, variant string, platform string, force bool) error {
-	args := m.Called(ctx, variant, platform, force)
-	return args.Error(0)
-}
-
-func (m *MockDockerManager) GetContainerStatus(ctx context.Context, containerName string) (*pkg.ContainerStatus, error) {
-	args := m.Called(ctx, containerName)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*pkg.ContainerStatus), args.Error(1)
-}
-
-func (m *MockDockerManager) CleanContainer(ctx context.Context, containerName string) error {
-	args := m.Called(ctx, containerName)
-	return args.Error(0)
-}
-
-func (m *MockDockerManager) CleanAllContainers(ctx context.Context) error {
-	args := m.Called(ctx)
-	return args.Error(0)
-}
-
-func (m *MockDockerManager) AttachToContainer(ctx context.Context, containerName string, command []string, interactive bool) error {
-	args := m.Called(ctx, containerName, command, interactive)
-	return args.Error(0)
-}
-
-func (m *MockDockerManager) HealthCheck(ctx context.Context, containerName string, maxRetries int) error {
-	args := m.Called(ctx, containerName, maxRetries)
-	return args.Error(0)
-}
-
-func (m *MockDockerManager) ListVariants() ([]pkg.VariantDefinition, error) {
-	args := m.Called()
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).([]pkg.VariantDefinition), args.Error(1)
-}
-
-func (m *MockDockerManager) GenerateContainerName(projectPath, variant, architecture, account string) string {
-	args := m.Called(projectPath, variant, architecture, account)
-	return args.String(0)
-}
-
-func (m *MockDockerManager) GenerateProjectHash(projectPath string) string {
-	args := m.Called(projectPath)
-	return args.String(0)
-}
-
-func (m *MockDockerManager) GetImageName(variant, architecture string) string {
-	args := m.Called(variant, architecture)
-	return args.String(0)
-}
-
-func (m *MockDockerManager) CleanImages(ctx context.Context, all bool) error {
-	args := m.Called(ctx, all)
-	return args.Error(0)
-}
-
-func (m *MockDockerManager) BuildImageWithRegistry(ctx context.Context, variant, platform string, devMode, registryOff, pullLatest bool) error {
-	args := m.Called(ctx, variant, platform, devMode, registryOff, pullLatest)
-	return args.Error(0)
-}
-
-func (m *MockDockerManager) GetClient() *client.Client {
-	args := m.Called()
-	if args.Get(0) == nil {
-		return nil
-	}
-	return args.Get(0).(*client.Client)
-}
-
-func (m *MockDockerManager) IsContainerHealthy(ctx context.Context, containerID string) (bool, error) {
-	args := m.Called(ctx, containerID)
-	return args.Bool(0), args.Error(1)
-}
-
-func (m *MockDockerManager) StartOrRecoverContainer(ctx context.Context, config *pkg.ContainerConfig, sessionConfig *pkg.Config) (string, error) {
-	args := m.Called(ctx, config, sessionConfig)
-	return args.String(0), args.Error(1)
-}
-
 func TestNewRecoveryManager(t *testing.T) {
 	mockLogger := &MockLogger{}
-	mockDockerMgr := &MockDockerManager{}
+	mockDockerMgr := &mocks.MockDockerManager{}
 
 	rm := NewRecoveryManager(mockLogger, mockDockerMgr)
 
@@ -162,7 +37,7 @@ func TestDefaultRecoveryConfig(t *testing.T) {
 
 func TestRecoveryManager_IsRetryableError(t *testing.T) {
 	mockLogger := &MockLogger{}
-	mockDockerMgr := &MockDockerManager{}
+	mockDockerMgr := &mocks.MockDockerManager{}
 	rm := NewRecoveryManager(mockLogger, mockDockerMgr)
 
 	tests := []struct {
@@ -227,7 +102,7 @@ func TestRecoveryManager_IsRetryableError(t *testing.T) {
 
 func TestRecoveryManager_IsBuildRetryableError(t *testing.T) {
 	mockLogger := &MockLogger{}
-	mockDockerMgr := &MockDockerManager{}
+	mockDockerMgr := &mocks.MockDockerManager{}
 	rm := NewRecoveryManager(mockLogger, mockDockerMgr)
 
 	tests := []struct {
@@ -282,7 +157,7 @@ func TestRecoveryManager_IsBuildRetryableError(t *testing.T) {
 
 func TestRecoveryManager_IsStopAcceptableError(t *testing.T) {
 	mockLogger := &MockLogger{}
-	mockDockerMgr := &MockDockerManager{}
+	mockDockerMgr := &mocks.MockDockerManager{}
 	rm := NewRecoveryManager(mockLogger, mockDockerMgr)
 
 	tests := []struct {
@@ -337,7 +212,7 @@ func TestRecoveryManager_HandleExistingContainer(t *testing.T) {
 	mockLogger.On("Warnf", mock.AnythingOfType("string"), mock.Anything).Maybe()
 
 	t.Run("no existing container", func(t *testing.T) {
-		mockDockerMgr := &MockDockerManager{}
+		mockDockerMgr := &mocks.MockDockerManager{}
 		mockDockerMgr.On("IsContainerRunning", mock.Anything, "test-container").Return(false, nil)
 
 		rm := NewRecoveryManager(mockLogger, mockDockerMgr)
@@ -351,7 +226,7 @@ func TestRecoveryManager_HandleExistingContainer(t *testing.T) {
 	})
 
 	t.Run("running container exists", func(t *testing.T) {
-		mockDockerMgr := &MockDockerManager{}
+		mockDockerMgr := &mocks.MockDockerManager{}
 		mockDockerMgr.On("IsContainerRunning", mock.Anything, "test-container").Return(true, nil)
 
 		rm := NewRecoveryManager(mockLogger, mockDockerMgr)
@@ -372,7 +247,7 @@ func TestRecoveryManager_PerformHealthCheck(t *testing.T) {
 	mockLogger.On("Debugf", mock.AnythingOfType("string"), mock.Anything).Maybe()
 
 	t.Run("health check passes immediately", func(t *testing.T) {
-		mockDockerMgr := &MockDockerManager{}
+		mockDockerMgr := &mocks.MockDockerManager{}
 		mockDockerMgr.On("IsContainerRunning", mock.Anything, "test-container").Return(true, nil)
 
 		rm := NewRecoveryManager(mockLogger, mockDockerMgr)
@@ -386,7 +261,7 @@ func TestRecoveryManager_PerformHealthCheck(t *testing.T) {
 	})
 
 	t.Run("health check fails", func(t *testing.T) {
-		mockDockerMgr := &MockDockerManager{}
+		mockDockerMgr := &mocks.MockDockerManager{}
 		mockDockerMgr.On("IsContainerRunning", mock.Anything, "test-container").Return(false, nil)
 
 		rm := NewRecoveryManager(mockLogger, mockDockerMgr)
@@ -411,7 +286,7 @@ func TestRecoveryManager_BuildImageWithRecovery(t *testing.T) {
 	mockLogger.On("Warnf", mock.AnythingOfType("string"), mock.Anything).Maybe()
 
 	t.Run("build succeeds on first attempt", func(t *testing.T) {
-		mockDockerMgr := &MockDockerManager{}
+		mockDockerMgr := &mocks.MockDockerManager{}
 		mockDockerMgr.On("BuildImage", mock.Anything, "go", "linux/arm64").Return(nil)
 
 		rm := NewRecoveryManager(mockLogger, mockDockerMgr)
@@ -425,7 +300,7 @@ func TestRecoveryManager_BuildImageWithRecovery(t *testing.T) {
 	})
 
 	t.Run("build fails with non-retryable error", func(t *testing.T) {
-		mockDockerMgr := &MockDockerManager{}
+		mockDockerMgr := &mocks.MockDockerManager{}
 		buildErr := errors.New("dockerfile syntax error")
 		mockDockerMgr.On("BuildImage", mock.Anything, "go", "linux/arm64").Return(buildErr)
 
@@ -442,7 +317,7 @@ func TestRecoveryManager_BuildImageWithRecovery(t *testing.T) {
 	})
 
 	t.Run("build succeeds on retry", func(t *testing.T) {
-		mockDockerMgr := &MockDockerManager{}
+		mockDockerMgr := &mocks.MockDockerManager{}
 		buildErr := errors.New("network connection failed")
 		mockDockerMgr.On("BuildImage", mock.Anything, "go", "linux/arm64").Return(buildErr).Once()
 		mockDockerMgr.On("BuildImage", mock.Anything, "go", "linux/arm64").Return(nil).Once()
@@ -464,7 +339,7 @@ func TestRecoveryManager_StopContainerWithRecovery(t *testing.T) {
 	mockLogger.On("Warnf", mock.AnythingOfType("string"), mock.Anything).Maybe()
 
 	t.Run("stop succeeds immediately", func(t *testing.T) {
-		mockDockerMgr := &MockDockerManager{}
+		mockDockerMgr := &mocks.MockDockerManager{}
 		mockDockerMgr.On("StopContainer", mock.Anything, "container123").Return(nil)
 
 		rm := NewRecoveryManager(mockLogger, mockDockerMgr)
@@ -478,7 +353,7 @@ func TestRecoveryManager_StopContainerWithRecovery(t *testing.T) {
 	})
 
 	t.Run("stop fails with acceptable error", func(t *testing.T) {
-		mockDockerMgr := &MockDockerManager{}
+		mockDockerMgr := &mocks.MockDockerManager{}
 		stopErr := errors.New("container already stopped")
 		mockDockerMgr.On("StopContainer", mock.Anything, "container123").Return(stopErr)
 
@@ -493,7 +368,7 @@ func TestRecoveryManager_StopContainerWithRecovery(t *testing.T) {
 	})
 
 	t.Run("stop succeeds on retry", func(t *testing.T) {
-		mockDockerMgr := &MockDockerManager{}
+		mockDockerMgr := &mocks.MockDockerManager{}
 		stopErr := errors.New("temporary failure")
 		mockDockerMgr.On("StopContainer", mock.Anything, "container123").Return(stopErr).Once()
 		mockDockerMgr.On("StopContainer", mock.Anything, "container123").Return(nil).Once()
@@ -511,7 +386,7 @@ func TestRecoveryManager_StopContainerWithRecovery(t *testing.T) {
 
 func BenchmarkRecoveryManager_IsRetryableError(b *testing.B) {
 	mockLogger := &MockLogger{}
-	mockDockerMgr := &MockDockerManager{}
+	mockDockerMgr := &mocks.MockDockerManager{}
 	rm := NewRecoveryManager(mockLogger, mockDockerMgr)
 	
 	testError := errors.New("network connection failed")
@@ -524,7 +399,7 @@ func BenchmarkRecoveryManager_IsRetryableError(b *testing.B) {
 
 func BenchmarkRecoveryManager_IsBuildRetryableError(b *testing.B) {
 	mockLogger := &MockLogger{}
-	mockDockerMgr := &MockDockerManager{}
+	mockDockerMgr := &mocks.MockDockerManager{}
 	rm := NewRecoveryManager(mockLogger, mockDockerMgr)
 	
 	testError := errors.New("dockerfile syntax error")
@@ -0,0 +1,38 @@
+//go:build !windows
+
+package docker
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchResize reports terminal size changes, signalled by SIGWINCH
+func watchResize(fd uintptr) (<-chan struct{}, func()) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGWINCH)
+
+	resized := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(resized)
+		for {
+			select {
+			case <-sigChan:
+				select {
+				case resized <- struct{}{}:
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return resized, func() {
+		signal.Stop(sigChan)
+		close(done)
+	}
+}
@@ -0,0 +1,43 @@
+//go:build windows
+
+package docker
+
+import (
+	"time"
+
+	"github.com/moby/term"
+)
+
+// watchResize reports terminal size changes. Windows consoles have no resize signal, so
+// the size is polled.
+func watchResize(fd uintptr) (<-chan struct{}, func()) {
+	resized := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(resized)
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+
+		last, _ := term.GetWinsize(fd)
+		for {
+			select {
+			case <-ticker.C:
+				size, err := term.GetWinsize(fd)
+				if err != nil || last == nil || *size == *last {
+					last = size
+					continue
+				}
+				last = size
+				select {
+				case resized <- struct{}{}:
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return resized, func() { close(done) }
+}
@@ -0,0 +1,155 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+
+	"claude-reactor/pkg"
+)
+
+const (
+	// ProjectLabel records the host project directory a container was started for
+	ProjectLabel = "claude-reactor.project"
+
+	// AccountLabel records the Claude account a container was started for
+	AccountLabel = "claude-reactor.account"
+)
+
+// projectHashPattern matches the 8-character project hash in resource names
+var projectHashPattern = regexp.MustCompile(`^[0-9a-f]{8}$`)
+
+// parseContainerName extracts the project hash and account from a container name of the form
+// claude-reactor-{variant}-{arch}-{projectHash}-{account}. Variants and accounts may contain
+// dashes, so the hash is found as the first 8-hex-digit part after an architecture.
+func parseContainerName(name string) (projectHash, account string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(name, "claude-reactor-"), "-")
+	for i := 1; i+2 < len(parts); i++ {
+		switch parts[i] {
+		case "amd64", "arm64", "arm", "386", "ppc64le", "s390x", "riscv64":
+		default:
+			continue
+		}
+		if projectHashPattern.MatchString(parts[i+1]) {
+			return parts[i+1], strings.Join(parts[i+2:], "-"), true
+		}
+	}
+	return "", "", false
+}
+
+// isClaudeReactorImage reports whether an image tag was built locally (claude-reactor-{variant}-{arch})
+// or pulled from a claude-reactor registry ({registry}/claude-reactor/{variant})
+func isClaudeReactorImage(tag string) bool {
+	return strings.HasPrefix(tag, "claude-reactor-") || strings.Contains(tag, "/claude-reactor-") ||
+		strings.Contains(tag, "/claude-reactor/")
+}
+
+// ListResources returns claude-reactor containers, sync volumes, and images with their disk usage
+func (m *manager) ListResources(ctx context.Context) ([]pkg.Resource, error) {
+	usage, err := m.client.DiskUsage(ctx, types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{types.ContainerObject, types.VolumeObject, types.ImageObject},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Docker disk usage: %w", err)
+	}
+
+	var resources []pkg.Resource
+	for _, c := range usage.Containers {
+		if c == nil || len(c.Names) == 0 {
+			continue
+		}
+		name := strings.TrimPrefix(c.Names[0], "/")
+		if !strings.HasPrefix(name, "claude-reactor-") {
+			continue
+		}
+		resource := pkg.Resource{
+			Kind:        pkg.ResourceContainer,
+			ID:          c.ID,
+			Name:        name,
+			ProjectPath: c.Labels[ProjectLabel],
+			Account:     c.Labels[AccountLabel],
+			Created:     time.Unix(c.Created, 0),
+			Size:        c.SizeRw,
+			Running:     c.State == container.StateRunning,
+		}
+		if hash, account, ok := parseContainerName(name); ok {
+			resource.ProjectHash = hash
+			if resource.Account == "" {
+				resource.Account = account
+			}
+		}
+		resources = append(resources, resource)
+	}
+
+	for _, v := range usage.Volumes {
+		if v == nil || !strings.HasPrefix(v.Name, "claude-reactor-") {
+			continue
+		}
+		resource := pkg.Resource{
+			Kind: pkg.ResourceVolume,
+			ID:   v.Name,
+			Name: v.Name,
+			Size: -1,
+		}
+		if v.UsageData != nil {
+			resource.Size = v.UsageData.Size
+		}
+		if created, err := time.Parse(time.RFC3339, v.CreatedAt); err == nil {
+			resource.Created = created
+		}
+		// Sync and sandbox volumes are named after their container
+		owner := strings.TrimSuffix(strings.TrimSuffix(v.Name, "-sync"), "-sandbox")
+		if hash, account, ok := parseContainerName(owner); ok {
+			resource.ProjectHash, resource.Account = hash, account
+		} else if account, ok := strings.CutPrefix(v.Name, npmCachePrefix); ok {
+			// Shared by the account's projects, so only cleaned globally or per account
+			resource.Account = account
+		}
+		resources = append(resources, resource)
+	}
+
+	for _, img := range usage.Images {
+		if img == nil {
+			continue
+		}
+		for _, tag := range img.RepoTags {
+			if !isClaudeReactorImage(tag) {
+				continue
+			}
+			resources = append(resources, pkg.Resource{
+				Kind:    pkg.ResourceImage,
+				ID:      img.ID,
+				Name:    tag,
+				Created: time.Unix(img.Created, 0),
+				Size:    img.Size,
+			})
+			break
+		}
+	}
+
+	return resources, nil
+}
+
+// RemoveResource removes a container, volume, or image returned by ListResources
+func (m *manager) RemoveResource(ctx context.Context, resource pkg.Resource) error {
+	switch resource.Kind {
+	case pkg.ResourceContainer:
+		return m.CleanContainer(ctx, resource.Name)
+	case pkg.ResourceVolume:
+		return m.client.VolumeRemove(ctx, resource.ID, false)
+	case pkg.ResourceImage:
+		if m.images != nil {
+			m.images.ForgetImage(resource.Name)
+		}
+		_, err := m.client.ImageRemove(ctx, resource.ID, image.RemoveOptions{PruneChildren: true})
+		return err
+	default:
+		return fmt.Errorf("cannot remove %s resources through Docker", resource.Kind)
+	}
+}
@@ -0,0 +1,112 @@
+package docker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+// diskUsageClient serves a fixed disk usage report
+type diskUsageClient struct {
+	client.APIClient
+	usage types.DiskUsage
+}
+
+func (c *diskUsageClient) DiskUsage(ctx context.Context, options types.DiskUsageOptions) (types.DiskUsage, error) {
+	return c.usage, nil
+}
+
+func TestParseContainerName(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		account string
+		ok      bool
+	}{
+		{"claude-reactor-go-arm64-1a2b3c4d-default", "1a2b3c4d", "default", true},
+		{"claude-reactor-my-variant-amd64-deadbeef-work-account", "deadbeef", "work-account", true},
+		{"claude-reactor-base-amd64-notahash-default", "", "", false},
+		{"claude-reactor-base", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, account, ok := parseContainerName(tt.name)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.hash, hash)
+			assert.Equal(t, tt.account, account)
+		})
+	}
+}
+
+func TestManager_ListResources(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	fake := &diskUsageClient{usage: types.DiskUsage{
+		Containers: []*container.Summary{
+			{
+				ID:      "c1",
+				Names:   []string{"/claude-reactor-go-amd64-1a2b3c4d-default"},
+				Created: created.Unix(),
+				SizeRw:  2048,
+				State:   container.StateRunning,
+				Labels:  map[string]string{ProjectLabel: "/work/app", AccountLabel: "default"},
+			},
+			{ID: "c2", Names: []string{"/unrelated"}},
+		},
+		Volumes: []*volume.Volume{
+			{
+				Name:      "claude-reactor-go-amd64-1a2b3c4d-default-sync",
+				CreatedAt: created.Format(time.RFC3339),
+				UsageData: &volume.UsageData{Size: 4096},
+			},
+			{Name: "claude-reactor-go-amd64-5e6f7a8b-default-sandbox"},
+			{Name: "claude-reactor-npm-cache-work"},
+			{Name: "postgres-data"},
+		},
+		Images: []*image.Summary{
+			{ID: "sha256:img", RepoTags: []string{"claude-reactor-go:latest"}, Size: 1 << 20, Created: created.Unix()},
+			{ID: "sha256:other", RepoTags: []string{"ubuntu:22.04"}},
+			{ID: "sha256:pulled", RepoTags: []string{"ghcr.io/dyluth/claude-reactor/go:latest"}},
+		},
+	}}
+	m := &manager{client: fake}
+
+	resources, err := m.ListResources(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resources, 6)
+
+	assert.Equal(t, pkg.Resource{
+		Kind:        pkg.ResourceContainer,
+		ID:          "c1",
+		Name:        "claude-reactor-go-amd64-1a2b3c4d-default",
+		ProjectPath: "/work/app",
+		ProjectHash: "1a2b3c4d",
+		Account:     "default",
+		Created:     time.Unix(created.Unix(), 0),
+		Size:        2048,
+		Running:     true,
+	}, resources[0])
+
+	assert.Equal(t, pkg.ResourceVolume, resources[1].Kind)
+	assert.Equal(t, "1a2b3c4d", resources[1].ProjectHash)
+	assert.Equal(t, int64(4096), resources[1].Size)
+	assert.True(t, resources[1].Created.Equal(created))
+	assert.Equal(t, "5e6f7a8b", resources[2].ProjectHash)
+	assert.Empty(t, resources[3].ProjectHash, "npm cache is shared by the account's projects")
+	assert.Equal(t, "work", resources[3].Account)
+
+	assert.Equal(t, pkg.ResourceImage, resources[4].Kind)
+	assert.Equal(t, "claude-reactor-go:latest", resources[4].Name)
+	assert.Empty(t, resources[4].ProjectHash)
+	assert.Equal(t, "ghcr.io/dyluth/claude-reactor/go:latest", resources[5].Name)
+}
@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"claude-reactor/pkg"
+)
+
+// ConfigLabel records a fingerprint of the configuration a container was created with
+const ConfigLabel = "claude-reactor.config"
+
+// ConfigHash fingerprints the parts of a container configuration that are fixed when the
+// container is created. A running container can be reused exactly when a new run would
+// create a container with the same hash; the order of mounts and devices does not matter.
+func ConfigHash(config *pkg.ContainerConfig) string {
+	mounts := make([]string, 0, len(config.Mounts))
+	for _, mount := range config.Mounts {
+		// The npm cache only speeds up installs, so containers created before it keep their hash
+		if mount.Type == "volume" && mount.Target == NPMCacheTarget {
+			continue
+		}
+		key := mount.Type + ":" + mount.Source + ":" + mount.Target
+		if mount.ReadOnly {
+			key += ":ro"
+		}
+		if mount.Consistency != "" {
+			key += ":" + mount.Consistency
+		}
+		mounts = append(mounts, key)
+	}
+	sort.Strings(mounts)
+
+	// The default hostname and prompt label only name the project, variant and account the
+	// container name already identifies, so containers created before them keep their hash
+	defaultHostname, defaultPrompt := defaultBranding(config)
+
+	env := make([]string, 0, len(config.Environment))
+	for key, value := range config.Environment {
+		// Shell history only affects interactive shells, so containers created before it keep their hash
+		if history, ok := ShellHistoryEnv[key]; ok && value == history {
+			continue
+		}
+		if key == PromptEnv && value == defaultPrompt {
+			continue
+		}
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+
+	devices := append([]string(nil), config.Devices...)
+	sort.Strings(devices)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "image=%s\nplatform=%s\nhost_docker=%t\nssh_agent=%t\nsync=%t\n",
+		config.Image, config.Platform, config.HostDocker, config.SSHAgent, config.SyncMode)
+	fmt.Fprintf(h, "mounts=%s\nenv=%s\ndevices=%s\n",
+		strings.Join(mounts, "\x00"), strings.Join(env, "\x00"), strings.Join(devices, "\x00"))
+	// Only hashed when set, so existing unpinned containers keep their hash
+	if config.ClaudeCLIVersion != "" {
+		fmt.Fprintf(h, "claude_cli=%s\n", config.ClaudeCLIVersion)
+	}
+	// Only a configured hostname is hashed
+	if config.Hostname != "" && config.Hostname != defaultHostname {
+		fmt.Fprintf(h, "hostname=%s\n", config.Hostname)
+	}
+	// DNS servers are tried in order, so only search domains and hosts are sorted
+	if len(config.DNS) > 0 || len(config.DNSSearch) > 0 || len(config.ExtraHosts) > 0 {
+		search := append([]string(nil), config.DNSSearch...)
+		sort.Strings(search)
+		hosts := append([]string(nil), config.ExtraHosts...)
+		sort.Strings(hosts)
+		fmt.Fprintf(h, "dns=%s\ndns_search=%s\nextra_hosts=%s\n",
+			strings.Join(config.DNS, "\x00"), strings.Join(search, "\x00"), strings.Join(hosts, "\x00"))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// defaultBranding returns the hostname and prompt label a container gets when its project
+// configures neither, from its project and account labels
+func defaultBranding(config *pkg.ContainerConfig) (string, string) {
+	project, account := config.Labels[ProjectLabel], config.Labels[AccountLabel]
+	return Hostname(Branding(DefaultHostname, project, config.Variant, account)),
+		Branding(DefaultPrompt, project, config.Variant, account)
+}
@@ -0,0 +1,68 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"claude-reactor/pkg"
+)
+
+func TestConfigHash(t *testing.T) {
+	base := func() *pkg.ContainerConfig {
+		return &pkg.ContainerConfig{
+			Name:        "claude-reactor-go-arm64-abc123-work",
+			Image:       "claude-reactor-go-arm64",
+			Platform:    "linux/arm64",
+			Mounts:      []pkg.Mount{{Source: "/src/app", Target: "/app"}, {Source: "/home/me/data", Target: "/mnt/data"}},
+			Environment: map[string]string{"TZ": "Europe/London", "ANTHROPIC_API_KEY": "sk-ant"},
+			Devices:     []string{"/dev/snd", "/dev/video0"},
+			Variant:     "go",
+			Labels:      map[string]string{ProjectLabel: "/src/app", AccountLabel: "work"},
+		}
+	}
+	hash := ConfigHash(base())
+	assert.Len(t, hash, 16)
+
+	t.Run("order and runtime-only settings do not matter", func(t *testing.T) {
+		config := base()
+		config.Mounts[0], config.Mounts[1] = config.Mounts[1], config.Mounts[0]
+		config.Devices = []string{"/dev/video0", "/dev/snd"}
+		config.Name = "other"
+		config.HostDockerTimeout = "15m"
+		config.RunClaudeUpgrade = true
+		config.Mounts = append(config.Mounts, pkg.Mount{Source: NPMCacheVolume("work"), Target: NPMCacheTarget, Type: "volume"})
+		for key, value := range ShellHistoryEnv {
+			config.Environment[key] = value
+		}
+		config.Hostname = "app-go-work"
+		config.Environment[PromptEnv] = "claude-reactor:app@work"
+		assert.Equal(t, hash, ConfigHash(config), "the default hostname and prompt are not hashed")
+	})
+
+	changes := map[string]func(*pkg.ContainerConfig){
+		"image":       func(c *pkg.ContainerConfig) { c.Image = "claude-reactor-full-arm64" },
+		"platform":    func(c *pkg.ContainerConfig) { c.Platform = "linux/amd64" },
+		"mount added": func(c *pkg.ContainerConfig) { c.Mounts = append(c.Mounts, pkg.Mount{Source: "/tmp", Target: "/mnt/tmp"}) },
+		"mount mode":  func(c *pkg.ContainerConfig) { c.Mounts[1].ReadOnly = true },
+		"consistency": func(c *pkg.ContainerConfig) { c.Mounts[1].Consistency = "cached" },
+		"tmpfs added": func(c *pkg.ContainerConfig) { c.Mounts = append(c.Mounts, pkg.Mount{Type: "tmpfs", Target: "/tmp/work"}) },
+		"environment": func(c *pkg.ContainerConfig) { c.Environment["TZ"] = "UTC" },
+		"device":      func(c *pkg.ContainerConfig) { c.Devices = c.Devices[:1] },
+		"host docker": func(c *pkg.ContainerConfig) { c.HostDocker = true },
+		"ssh agent":   func(c *pkg.ContainerConfig) { c.SSHAgent = true },
+		"sync":        func(c *pkg.ContainerConfig) { c.SyncMode = true },
+		"dns":         func(c *pkg.ContainerConfig) { c.DNS = []string{"10.0.0.2"} },
+		"dns search":  func(c *pkg.ContainerConfig) { c.DNSSearch = []string{"corp.example.com"} },
+		"extra host":  func(c *pkg.ContainerConfig) { c.ExtraHosts = []string{"git.corp:10.1.2.3"} },
+		"hostname":    func(c *pkg.ContainerConfig) { c.Hostname = "dev-work" },
+		"prompt":      func(c *pkg.ContainerConfig) { c.Environment[PromptEnv] = "app (go)" },
+	}
+	for name, change := range changes {
+		t.Run(name+" changes the hash", func(t *testing.T) {
+			config := base()
+			change(config)
+			assert.NotEqual(t, hash, ConfigHash(config))
+		})
+	}
+}
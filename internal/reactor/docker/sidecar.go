@@ -0,0 +1,166 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+
+	"claude-reactor/internal/reactor/registry"
+	"claude-reactor/pkg"
+)
+
+// SidecarLabel marks sidecar containers with the project container they belong to
+const SidecarLabel = "claude-reactor.sidecar-of"
+
+// sidecarCommand keeps a sidecar idle; its work runs as execs. tail is in every base image.
+var sidecarCommand = []string{"tail", "-f", "/dev/null"}
+
+// SidecarName returns the container name of a sidecar of containerName
+func SidecarName(containerName, name string) string {
+	return containerName + "-" + name
+}
+
+// NetworkName returns the network a project container shares with its sidecars
+func NetworkName(containerName string) string {
+	return containerName + "-net"
+}
+
+// StartSidecar starts sidecar next to containerName on their shared network, where it can be
+// reached by its name. A running sidecar is reused; a stopped one is replaced.
+func (m *manager) StartSidecar(ctx context.Context, containerName string, sidecar *pkg.Sidecar) (string, error) {
+	name := SidecarName(containerName, sidecar.Name)
+	status, err := m.GetContainerStatus(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get sidecar status: %w", err)
+	}
+	if status.Running {
+		return name, nil
+	}
+	if status.Exists {
+		if err := m.RemoveContainer(ctx, status.ID); err != nil {
+			return "", fmt.Errorf("failed to replace stopped sidecar %s: %w", name, err)
+		}
+	}
+
+	networkName, err := m.ensureNetwork(ctx, containerName)
+	if err != nil {
+		return "", err
+	}
+	if err := m.ensureSidecarImage(ctx, sidecar.Image); err != nil {
+		return "", err
+	}
+
+	env := make([]string, 0, len(sidecar.Environment))
+	for key, value := range sidecar.Environment {
+		env = append(env, fmt.Sprintf("%s=%s", key, value))
+	}
+	labels := map[string]string{SidecarLabel: containerName}
+	for key, value := range sidecar.Labels {
+		labels[key] = value
+	}
+	containerConfig := &container.Config{
+		Image:      sidecar.Image,
+		Env:        env,
+		Entrypoint: sidecarCommand,
+		WorkingDir: sidecar.WorkingDir,
+		Labels:     labels,
+	}
+	hostConfig := &container.HostConfig{
+		Mounts:      NewMountManager(m.logger).ConvertToDockerMounts(sidecar.Mounts),
+		NetworkMode: container.NetworkMode(networkName),
+	}
+	networkingConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{
+			networkName: {Aliases: []string{sidecar.Name}},
+		},
+	}
+
+	m.logger.Debugf("Creating sidecar %s from %s", name, sidecar.Image)
+	resp, err := m.client.ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, nil, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to create sidecar %s: %w", name, err)
+	}
+	if err := m.client.ContainerStart(ctx, resp.ID, container.StartOptions{}); err != nil {
+		m.client.ContainerRemove(ctx, resp.ID, container.RemoveOptions{Force: true})
+		return "", fmt.Errorf("failed to start sidecar %s: %w", name, err)
+	}
+	return name, nil
+}
+
+// RemoveSidecar removes a sidecar of containerName, and the shared network once no sidecars
+// are left on it
+func (m *manager) RemoveSidecar(ctx context.Context, containerName, name string) error {
+	if err := m.CleanContainer(ctx, SidecarName(containerName, name)); err != nil {
+		return err
+	}
+
+	networkName := NetworkName(containerName)
+	info, err := m.client.NetworkInspect(ctx, networkName, network.InspectOptions{})
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to inspect network %s: %w", networkName, err)
+	}
+	for _, endpoint := range info.Containers {
+		if endpoint.Name != containerName {
+			return nil
+		}
+	}
+	m.client.NetworkDisconnect(ctx, networkName, containerName, true)
+	if err := m.client.NetworkRemove(ctx, networkName); err != nil && !client.IsErrNotFound(err) {
+		return fmt.Errorf("failed to remove network %s: %w", networkName, err)
+	}
+	return nil
+}
+
+// ensureNetwork creates the network shared by containerName and its sidecars, and connects
+// the project container to it when it exists
+func (m *manager) ensureNetwork(ctx context.Context, containerName string) (string, error) {
+	networkName := NetworkName(containerName)
+	info, err := m.client.NetworkInspect(ctx, networkName, network.InspectOptions{})
+	if client.IsErrNotFound(err) {
+		_, err = m.client.NetworkCreate(ctx, networkName, network.CreateOptions{
+			Driver: "bridge",
+			Labels: map[string]string{SidecarLabel: containerName},
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to create network %s: %w", networkName, err)
+		}
+	} else if err != nil {
+		return "", fmt.Errorf("failed to inspect network %s: %w", networkName, err)
+	}
+
+	for _, endpoint := range info.Containers {
+		if endpoint.Name == containerName {
+			return networkName, nil
+		}
+	}
+	if err := m.client.NetworkConnect(ctx, networkName, containerName, nil); err != nil &&
+		!client.IsErrNotFound(err) && !strings.Contains(err.Error(), "already exists") {
+		return "", fmt.Errorf("failed to connect %s to network %s: %w", containerName, networkName, err)
+	}
+	return networkName, nil
+}
+
+// ensureSidecarImage pulls a sidecar image that is not available locally
+func (m *manager) ensureSidecarImage(ctx context.Context, imageName string) error {
+	exists, err := m.imageExistsLocally(ctx, imageName)
+	if err != nil {
+		return fmt.Errorf("failed to check for image %s: %w", imageName, err)
+	}
+	if exists {
+		return nil
+	}
+	m.logger.Infof("⬇️  Pulling sidecar image %s...", imageName)
+	auth, _ := registry.Auth(imageName)
+	if err := registry.Pull(ctx, m.client, imageName, image.PullOptions{RegistryAuth: auth}, m.mirror, m.logger); err != nil {
+		return fmt.Errorf("failed to pull sidecar image %s: %w", imageName, err)
+	}
+	return nil
+}
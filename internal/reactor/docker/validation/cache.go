@@ -0,0 +1,316 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/image"
+
+	"claude-reactor/pkg"
+)
+
+const (
+	// DefaultCacheTTL is how long a validation result is trusted. Results are keyed by image
+	// digest, which never changes content, so this only bounds how stale the checks can get.
+	DefaultCacheTTL = 30 * 24 * time.Hour
+
+	// DefaultCacheSize is how many validation results are kept before the oldest are evicted
+	DefaultCacheSize = 200
+
+	// DefaultExistenceTTL is how long a remembered image name -> ID lookup is trusted. It is
+	// short because images can be removed or re-tagged outside claude-reactor.
+	DefaultExistenceTTL = 10 * time.Minute
+
+	// indexFile holds remembered image lookups alongside the digest-keyed results
+	indexFile = "images.index"
+)
+
+// imageEntry is a remembered local image
+type imageEntry struct {
+	ID        string    `json:"id"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// SetCachePolicy changes how long results are trusted and how many are kept
+func (v *ImageValidator) SetCachePolicy(policy pkg.ImageCachePolicy) {
+	v.policy = policy
+}
+
+// SetRevalidate ignores cached results and lookups, refreshing them from the daemon
+func (v *ImageValidator) SetRevalidate(revalidate bool) {
+	v.revalidate = revalidate
+}
+
+// effectivePolicy fills in defaults for unset policy values
+func (v *ImageValidator) effectivePolicy() pkg.ImageCachePolicy {
+	policy := v.policy
+	if policy.TTL <= 0 {
+		policy.TTL = DefaultCacheTTL
+	}
+	if policy.MaxEntries <= 0 {
+		policy.MaxEntries = DefaultCacheSize
+	}
+	if policy.ExistenceTTL <= 0 {
+		policy.ExistenceTTL = DefaultExistenceTTL
+	}
+	return policy
+}
+
+// imageKey normalizes an image reference so "name" and "name:latest" share an entry
+func imageKey(imageName string) string {
+	if strings.Contains(imageName, "@") {
+		return imageName
+	}
+	if !strings.Contains(imageName[strings.LastIndex(imageName, "/")+1:], ":") {
+		return imageName + ":latest"
+	}
+	return imageName
+}
+
+// loadIndex reads remembered image lookups on first use
+func (v *ImageValidator) loadIndex() map[string]imageEntry {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.images == nil {
+		v.images = make(map[string]imageEntry)
+		if data, err := os.ReadFile(filepath.Join(v.cacheDir, indexFile)); err == nil {
+			if err := json.Unmarshal(data, &v.images); err != nil {
+				v.images = make(map[string]imageEntry)
+			}
+		}
+	}
+	return v.images
+}
+
+// saveIndex writes remembered image lookups, dropping expired ones
+func (v *ImageValidator) saveIndex() error {
+	v.loadIndex()
+	ttl := v.effectivePolicy().ExistenceTTL
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for name, entry := range v.images {
+		if time.Since(entry.CheckedAt) > ttl {
+			delete(v.images, name)
+		}
+	}
+	data, err := json.Marshal(v.images)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(v.cacheDir, 0755); err != nil {
+		return err
+	}
+	tmpPath := filepath.Join(v.cacheDir, indexFile+".tmp")
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filepath.Join(v.cacheDir, indexFile))
+}
+
+// LookupImage returns the ID of a recently seen local image
+func (v *ImageValidator) LookupImage(imageName string) (string, bool) {
+	if v.revalidate {
+		return "", false
+	}
+	images := v.loadIndex()
+
+	v.mu.Lock()
+	entry, ok := images[imageKey(imageName)]
+	v.mu.Unlock()
+	if !ok || time.Since(entry.CheckedAt) > v.effectivePolicy().ExistenceTTL {
+		return "", false
+	}
+	return entry.ID, true
+}
+
+// RememberImage records that imageName exists locally with the given ID
+func (v *ImageValidator) RememberImage(imageName, imageID string) {
+	images := v.loadIndex()
+	v.mu.Lock()
+	images[imageKey(imageName)] = imageEntry{ID: imageID, CheckedAt: time.Now()}
+	v.mu.Unlock()
+	if err := v.saveIndex(); err != nil && v.logger != nil {
+		v.logger.Debugf("Failed to save image index: %v", err)
+	}
+}
+
+// ForgetImage drops imageName, e.g. after it was removed or replaced
+func (v *ImageValidator) ForgetImage(imageName string) {
+	images := v.loadIndex()
+	v.mu.Lock()
+	_, ok := images[imageKey(imageName)]
+	delete(images, imageKey(imageName))
+	v.mu.Unlock()
+	if !ok {
+		return
+	}
+	if err := v.saveIndex(); err != nil && v.logger != nil {
+		v.logger.Debugf("Failed to save image index: %v", err)
+	}
+}
+
+// cacheEntry is a validation result file in the cache directory
+type cacheEntry struct {
+	digest  string
+	modTime time.Time
+	size    int64
+}
+
+// cacheEntries lists validation results, oldest first. Scan results for a digest belong to
+// its validation entry and are counted with it.
+func (v *ImageValidator) cacheEntries() ([]cacheEntry, error) {
+	files, err := os.ReadDir(v.cacheDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []cacheEntry
+	scanSizes := make(map[string]int64)
+	for _, file := range files {
+		info, err := file.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		name := file.Name()
+		switch {
+		case strings.HasSuffix(name, ".scan.json"):
+			scanSizes[strings.TrimSuffix(name, ".scan.json")] += info.Size()
+		case strings.HasSuffix(name, ".json"):
+			entries = append(entries, cacheEntry{
+				digest:  strings.TrimSuffix(name, ".json"),
+				modTime: info.ModTime(),
+				size:    info.Size(),
+			})
+		}
+	}
+	for i := range entries {
+		entries[i].size += scanSizes[entries[i].digest]
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modTime.Before(entries[j].modTime) })
+	return entries, nil
+}
+
+// pruneCache removes expired results and evicts the oldest beyond the size limit
+func (v *ImageValidator) pruneCache() error {
+	entries, err := v.cacheEntries()
+	if err != nil {
+		return err
+	}
+	policy := v.effectivePolicy()
+
+	excess := len(entries) - policy.MaxEntries
+	for _, entry := range entries {
+		if excess <= 0 && time.Since(entry.modTime) <= policy.TTL {
+			continue
+		}
+		v.removeEntry(entry.digest)
+		excess--
+	}
+	return nil
+}
+
+// removeEntry deletes the validation and scan results for a digest
+func (v *ImageValidator) removeEntry(digest string) {
+	for _, suffix := range []string{".json", ".scan.json"} {
+		if err := os.Remove(filepath.Join(v.cacheDir, digest+suffix)); err != nil && !os.IsNotExist(err) && v.logger != nil {
+			v.logger.Debugf("Failed to evict cache entry %s: %v", digest, err)
+		}
+	}
+}
+
+// recordLookup counts a validation answered from the cache, or one that was not
+func (v *ImageValidator) recordLookup(hit bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if hit {
+		v.hits++
+	} else {
+		v.misses++
+	}
+}
+
+// CacheStats describes the validation cache and how it was used by this process
+func (v *ImageValidator) CacheStats() (*pkg.ImageCacheStats, error) {
+	entries, err := v.cacheEntries()
+	if err != nil {
+		return nil, err
+	}
+	policy := v.effectivePolicy()
+
+	stats := &pkg.ImageCacheStats{
+		Dir:          v.cacheDir,
+		Entries:      len(entries),
+		TTL:          policy.TTL,
+		MaxEntries:   policy.MaxEntries,
+		ExistenceTTL: policy.ExistenceTTL,
+	}
+	v.mu.Lock()
+	stats.Hits, stats.Misses = v.hits, v.misses
+	v.mu.Unlock()
+	for _, entry := range entries {
+		stats.Bytes += entry.size
+	}
+	if len(entries) > 0 {
+		stats.Oldest = entries[0].modTime
+		stats.Newest = entries[len(entries)-1].modTime
+	}
+
+	for _, entry := range v.loadIndex() {
+		if time.Since(entry.CheckedAt) <= policy.ExistenceTTL {
+			stats.Images++
+		}
+	}
+	return stats, nil
+}
+
+// OrphanedCacheEntries lists cached validation results for images that no longer exist locally
+func (v *ImageValidator) OrphanedCacheEntries(ctx context.Context) ([]pkg.Resource, error) {
+	entries, err := v.cacheEntries()
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+
+	images, err := v.dockerClient.ImageList(ctx, image.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	local := make(map[string]bool, len(images))
+	for _, img := range images {
+		local[img.ID] = true
+	}
+
+	var orphans []pkg.Resource
+	for _, entry := range entries {
+		if local[entry.digest] {
+			continue
+		}
+		orphans = append(orphans, pkg.Resource{
+			Kind:    pkg.ResourceCache,
+			ID:      entry.digest,
+			Name:    entry.digest,
+			Created: entry.modTime,
+			Size:    entry.size,
+		})
+	}
+	return orphans, nil
+}
+
+// RemoveCacheEntry deletes the cached validation and scan results for an image digest
+func (v *ImageValidator) RemoveCacheEntry(digest string) error {
+	if strings.ContainsAny(digest, `/\`) {
+		return fmt.Errorf("invalid cache entry %q", digest)
+	}
+	v.removeEntry(digest)
+	return nil
+}
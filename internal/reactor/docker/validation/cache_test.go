@@ -0,0 +1,180 @@
+package validation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func TestImageKey(t *testing.T) {
+	assert.Equal(t, "ubuntu:latest", imageKey("ubuntu"))
+	assert.Equal(t, "ubuntu:22.04", imageKey("ubuntu:22.04"))
+	assert.Equal(t, "localhost:5000/dev:latest", imageKey("localhost:5000/dev"))
+	assert.Equal(t, "ghcr.io/user/app@sha256:abc", imageKey("ghcr.io/user/app@sha256:abc"))
+}
+
+func TestImageIndex(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("remembered images persist across validators", func(t *testing.T) {
+		first := &ImageValidator{cacheDir: dir}
+		first.RememberImage("claude-reactor-go", "sha256:go")
+
+		second := &ImageValidator{cacheDir: dir}
+		id, ok := second.LookupImage("claude-reactor-go:latest")
+		assert.True(t, ok)
+		assert.Equal(t, "sha256:go", id)
+	})
+
+	t.Run("forgotten images are looked up again", func(t *testing.T) {
+		validator := &ImageValidator{cacheDir: dir}
+		validator.RememberImage("ubuntu:22.04", "sha256:ubuntu")
+		validator.ForgetImage("ubuntu:22.04")
+
+		_, ok := (&ImageValidator{cacheDir: dir}).LookupImage("ubuntu:22.04")
+		assert.False(t, ok)
+	})
+
+	t.Run("stale lookups are ignored", func(t *testing.T) {
+		validator := &ImageValidator{cacheDir: t.TempDir()}
+		validator.SetCachePolicy(pkg.ImageCachePolicy{ExistenceTTL: time.Millisecond})
+		validator.RememberImage("alpine", "sha256:alpine")
+		time.Sleep(5 * time.Millisecond)
+
+		_, ok := validator.LookupImage("alpine")
+		assert.False(t, ok)
+	})
+
+	t.Run("revalidate bypasses lookups", func(t *testing.T) {
+		validator := &ImageValidator{cacheDir: dir}
+		validator.RememberImage("debian", "sha256:debian")
+		validator.SetRevalidate(true)
+
+		_, ok := validator.LookupImage("debian")
+		assert.False(t, ok)
+	})
+}
+
+func TestCachePolicy(t *testing.T) {
+	writeResult := func(t *testing.T, validator *ImageValidator, digest string, validatedAt time.Time) {
+		t.Helper()
+		result := &pkg.ImageValidationResult{Digest: digest, ValidatedAt: validatedAt.Format(time.RFC3339)}
+		require.NoError(t, validator.cacheResult(digest, result))
+		path := filepath.Join(validator.cacheDir, digest+".json")
+		require.NoError(t, os.Chtimes(path, validatedAt, validatedAt))
+	}
+
+	t.Run("defaults apply to unset values", func(t *testing.T) {
+		policy := (&ImageValidator{}).effectivePolicy()
+		assert.Equal(t, DefaultCacheTTL, policy.TTL)
+		assert.Equal(t, DefaultCacheSize, policy.MaxEntries)
+		assert.Equal(t, DefaultExistenceTTL, policy.ExistenceTTL)
+	})
+
+	t.Run("results older than the TTL are not used", func(t *testing.T) {
+		validator := &ImageValidator{cacheDir: t.TempDir()}
+		validator.SetCachePolicy(pkg.ImageCachePolicy{TTL: time.Hour})
+		writeResult(t, validator, "sha256:old", time.Now().Add(-2*time.Hour))
+		writeResult(t, validator, "sha256:new", time.Now())
+
+		_, err := validator.getCachedResult("sha256:old")
+		assert.Error(t, err)
+		_, err = validator.getCachedResult("sha256:new")
+		assert.NoError(t, err)
+	})
+
+	t.Run("revalidate ignores cached results", func(t *testing.T) {
+		validator := &ImageValidator{cacheDir: t.TempDir()}
+		writeResult(t, validator, "sha256:fresh", time.Now())
+		validator.SetRevalidate(true)
+
+		_, err := validator.getCachedResult("sha256:fresh")
+		assert.Error(t, err)
+	})
+
+	t.Run("pruning evicts expired and oldest results", func(t *testing.T) {
+		validator := &ImageValidator{cacheDir: t.TempDir()}
+		validator.SetCachePolicy(pkg.ImageCachePolicy{TTL: 24 * time.Hour, MaxEntries: 2})
+		writeResult(t, validator, "sha256:expired", time.Now().Add(-48*time.Hour))
+		writeResult(t, validator, "sha256:a", time.Now().Add(-3*time.Hour))
+		writeResult(t, validator, "sha256:b", time.Now().Add(-2*time.Hour))
+		writeResult(t, validator, "sha256:c", time.Now().Add(-1*time.Hour))
+		require.NoError(t, validator.cacheScan("sha256:a", &pkg.ImageScanResult{Digest: "sha256:a"}))
+
+		require.NoError(t, validator.pruneCache())
+
+		entries, err := validator.cacheEntries()
+		require.NoError(t, err)
+		var digests []string
+		for _, entry := range entries {
+			digests = append(digests, entry.digest)
+		}
+		assert.Equal(t, []string{"sha256:b", "sha256:c"}, digests)
+		assert.NoFileExists(t, filepath.Join(validator.cacheDir, "sha256:a.scan.json"))
+	})
+}
+
+func TestCacheStats(t *testing.T) {
+	validator := &ImageValidator{cacheDir: t.TempDir()}
+
+	t.Run("empty cache", func(t *testing.T) {
+		stats, err := validator.CacheStats()
+		require.NoError(t, err)
+		assert.Zero(t, stats.Entries)
+		assert.Equal(t, DefaultCacheSize, stats.MaxEntries)
+	})
+
+	t.Run("entries, images, and size are counted", func(t *testing.T) {
+		result := &pkg.ImageValidationResult{Digest: "sha256:x", ValidatedAt: time.Now().Format(time.RFC3339)}
+		require.NoError(t, validator.cacheResult(result.Digest, result))
+		validator.RememberImage("app", "sha256:x")
+
+		stats, err := validator.CacheStats()
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.Entries)
+		assert.Equal(t, 1, stats.Images)
+		assert.Positive(t, stats.Bytes)
+		assert.False(t, stats.Oldest.IsZero())
+	})
+}
+
+// imageListClient reports a fixed set of local images
+type imageListClient struct {
+	client.APIClient
+	images []image.Summary
+}
+
+func (c *imageListClient) ImageList(ctx context.Context, options image.ListOptions) ([]image.Summary, error) {
+	return c.images, nil
+}
+
+func TestOrphanedCacheEntries(t *testing.T) {
+	validator := &ImageValidator{
+		cacheDir:     t.TempDir(),
+		dockerClient: &imageListClient{images: []image.Summary{{ID: "sha256:present"}}},
+	}
+	for _, digest := range []string{"sha256:present", "sha256:removed"} {
+		result := &pkg.ImageValidationResult{Digest: digest, ValidatedAt: time.Now().Format(time.RFC3339)}
+		require.NoError(t, validator.cacheResult(digest, result))
+	}
+
+	orphans, err := validator.OrphanedCacheEntries(context.Background())
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+	assert.Equal(t, pkg.ResourceCache, orphans[0].Kind)
+	assert.Equal(t, "sha256:removed", orphans[0].ID)
+
+	require.NoError(t, validator.RemoveCacheEntry("sha256:removed"))
+	assert.NoFileExists(t, filepath.Join(validator.cacheDir, "sha256:removed.json"))
+	assert.FileExists(t, filepath.Join(validator.cacheDir, "sha256:present.json"))
+	assert.Error(t, validator.RemoveCacheEntry("../escape"))
+}
@@ -0,0 +1,197 @@
+package validation
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"claude-reactor/internal/reactor/detection"
+	"claude-reactor/pkg"
+)
+
+// scriptTimeout bounds the containers running validation scripts
+const scriptTimeout = 30 * time.Second
+
+// containerHome is where claude-reactor mounts Claude's config and credentials
+const containerHome = "/home/claude"
+
+// minNodeMajor is the oldest Node.js the Claude CLI npm package supports
+const minNodeMajor = 18
+
+// runtimeScript reports, as key=value lines, what sessions will find in a container of the
+// image: the user it runs as, its home directory, and the shells and runtimes available
+const runtimeScript = `echo "uid=$(id -u 2>/dev/null)"
+echo "user=$(id -un 2>/dev/null)"
+echo "home=$HOME"
+[ -n "$HOME" ] && [ -d "$HOME" ] && [ -w "$HOME" ] && echo "home_writable=yes"
+[ -x /bin/bash ] && echo "bash=yes"
+{ command -v useradd || command -v adduser; } >/dev/null 2>&1 && echo "useradd=yes"
+command -v node >/dev/null 2>&1 && echo "node=$(node --version 2>/dev/null)"
+exit 0
+`
+
+// longRunning are main commands that keep a container up for sessions to exec into
+var longRunning = map[string]bool{
+	"bash": true, "sh": true, "zsh": true, "fish": true, "ash": true, "dash": true,
+	"sleep": true, "tail": true, "tini": true, "dumb-init": true, "supervisord": true,
+	"claude-reactor-init": true,
+}
+
+// checkImageConfig checks that the image's entrypoint and command keep a container running,
+// since sessions are exec'd into it rather than being its main process
+func checkImageConfig(entrypoint, cmd []string, result *pkg.ImageValidationResult) {
+	command := append(append([]string{}, entrypoint...), cmd...)
+	if len(command) == 0 {
+		result.Errors = append(result.Errors,
+			"Image has no ENTRYPOINT or CMD, so its container would exit at once. Add CMD [\"sleep\", \"infinity\"] to the image")
+		return
+	}
+	if !longRunning[path.Base(command[0])] {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"Image runs '%s' as its main process; sessions end when it exits. If it does not keep running, add CMD [\"sleep\", \"infinity\"] to the image",
+			strings.Join(command, " ")))
+	}
+}
+
+// checkRuntime probes a container of the image for what sessions need: a shell for
+// claude-reactor-init, a non-root user with a writable home at /home/claude, bash, and a
+// Node.js recent enough for Claude CLI
+func (v *ImageValidator) checkRuntime(ctx context.Context, imageID string, result *pkg.ImageValidationResult) {
+	output, err := v.runScript(ctx, imageID, runtimeScript)
+	if err != nil {
+		if strings.Contains(err.Error(), "no such file") || strings.Contains(err.Error(), "not found") {
+			result.Errors = append(result.Errors,
+				"Image has no /bin/sh, which claude-reactor-init needs. Use an image with a shell; distroless and scratch images are not supported")
+		} else {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Could not check the image's user and runtime: %v", err))
+		}
+		return
+	}
+	probe := parseRuntimeProbe(output)
+	evaluateRuntime(probe, result)
+
+	runtime := make(map[string]interface{}, len(probe))
+	for key, value := range probe {
+		runtime[key] = value
+	}
+	result.Metadata["runtime"] = runtime
+}
+
+// parseRuntimeProbe reads the key=value lines of runtimeScript
+func parseRuntimeProbe(output string) map[string]string {
+	probe := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "="); ok {
+			probe[key] = value
+		}
+	}
+	return probe
+}
+
+// evaluateRuntime turns a runtime probe into errors and warnings with remediation
+func evaluateRuntime(probe map[string]string, result *pkg.ImageValidationResult) {
+	user := probe["user"]
+	if user == "" {
+		user = "uid " + probe["uid"]
+	}
+
+	if probe["uid"] == "0" {
+		message := "Image runs as root; Claude CLI refuses danger mode as root. Add a user to the image: RUN useradd -m -s /bin/bash claude, then USER claude"
+		if probe["useradd"] != "yes" {
+			message += " (the image has neither useradd nor adduser, so install one first)"
+		}
+		result.Warnings = append(result.Warnings, message)
+	}
+
+	home := probe["home"]
+	if home != containerHome {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"Home directory of %s is %s, but Claude config and credentials are mounted in %s. Give the user that home: useradd -d %s, or ENV HOME=%s",
+			user, valueOr(home, "unset"), containerHome, containerHome, containerHome))
+	}
+	if probe["home_writable"] != "yes" {
+		result.Errors = append(result.Errors, fmt.Sprintf(
+			"Home directory %s is not writable by %s, so Claude CLI cannot save its state. Create it owned by the user: RUN mkdir -p %s && chown %s %s",
+			valueOr(home, "(unset)"), user, containerHome, user, containerHome))
+	}
+
+	if probe["bash"] != "yes" {
+		result.Warnings = append(result.Warnings, "Image has no /bin/bash, which --shell sessions use. Install bash in the image")
+	}
+
+	node := probe["node"]
+	major := 0
+	if version := detection.ParseVersion(node); version != "" {
+		fmt.Sscanf(version, "%d", &major)
+	}
+	switch {
+	case node == "" && !result.HasClaude:
+		result.Errors = append(result.Errors, fmt.Sprintf(
+			"Node.js not found, which Claude CLI needs. Install Node.js %d or newer, then: npm install -g @anthropic-ai/claude-code", minNodeMajor))
+	case node != "" && major < minNodeMajor:
+		message := fmt.Sprintf("Node.js %s is older than %d, which Claude CLI needs. Install Node.js %d or newer", node, minNodeMajor, minNodeMajor)
+		if result.HasClaude {
+			result.Warnings = append(result.Warnings, message)
+		} else {
+			result.Errors = append(result.Errors, message)
+		}
+	}
+}
+
+// runScript runs a shell script in a throwaway container of the image and returns its output
+func (v *ImageValidator) runScript(ctx context.Context, imageID, script string) (string, error) {
+	containerConfig := &container.Config{
+		Image:      imageID,
+		Entrypoint: []string{"/bin/sh", "-c", script},
+		Tty:        false,
+	}
+	containerResp, err := v.dockerClient.ContainerCreate(ctx, containerConfig, nil, nil, nil, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to create test container: %w", err)
+	}
+	defer func() {
+		v.dockerClient.ContainerRemove(ctx, containerResp.ID, container.RemoveOptions{Force: true})
+	}()
+
+	if err := v.dockerClient.ContainerStart(ctx, containerResp.ID, container.StartOptions{}); err != nil {
+		return "", fmt.Errorf("failed to start test container: %w", err)
+	}
+	waitCh, errCh := v.dockerClient.ContainerWait(ctx, containerResp.ID, container.WaitConditionNotRunning)
+	select {
+	case waitResp := <-waitCh:
+		if waitResp.StatusCode != 0 {
+			return "", fmt.Errorf("test script exited with code %d", waitResp.StatusCode)
+		}
+	case err := <-errCh:
+		return "", err
+	case <-time.After(scriptTimeout):
+		return "", fmt.Errorf("timed out after %s", scriptTimeout)
+	}
+
+	logs, err := v.dockerClient.ContainerLogs(ctx, containerResp.ID, container.LogsOptions{ShowStdout: true})
+	if err != nil {
+		return "", fmt.Errorf("failed to read test container output: %w", err)
+	}
+	defer logs.Close()
+	var output bytes.Buffer
+	if _, err := stdcopy.StdCopy(&output, io.Discard, logs); err != nil {
+		return "", fmt.Errorf("failed to read test container output: %w", err)
+	}
+	return output.String(), nil
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func newResult(hasClaude bool) *pkg.ImageValidationResult {
+	return &pkg.ImageValidationResult{HasClaude: hasClaude, Warnings: []string{}, Errors: []string{}}
+}
+
+func TestCheckImageConfig(t *testing.T) {
+	result := newResult(true)
+	checkImageConfig([]string{"/usr/local/bin/claude-reactor-init"}, []string{"bash"}, result)
+	checkImageConfig(nil, []string{"/bin/bash"}, result)
+	checkImageConfig([]string{"tini", "--"}, []string{"python3", "app.py"}, result)
+	assert.Empty(t, result.Errors)
+	assert.Empty(t, result.Warnings)
+
+	result = newResult(true)
+	checkImageConfig([]string{"docker-entrypoint.sh"}, []string{"node", "server.js"}, result)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "'docker-entrypoint.sh node server.js'")
+	assert.Contains(t, result.Warnings[0], `CMD ["sleep", "infinity"]`)
+
+	result = newResult(true)
+	checkImageConfig(nil, nil, result)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0], "no ENTRYPOINT or CMD")
+}
+
+func TestEvaluateRuntime(t *testing.T) {
+	t.Run("ready image", func(t *testing.T) {
+		result := newResult(true)
+		evaluateRuntime(parseRuntimeProbe("uid=1000\nuser=claude\nhome=/home/claude\nhome_writable=yes\nbash=yes\nuseradd=yes\nnode=v22.20.0\n"), result)
+		assert.Empty(t, result.Errors)
+		assert.Empty(t, result.Warnings)
+	})
+
+	t.Run("root without a user", func(t *testing.T) {
+		result := newResult(true)
+		evaluateRuntime(parseRuntimeProbe("uid=0\nuser=root\nhome=/root\nhome_writable=yes\nbash=yes\nnode=v20.1.0\n"), result)
+		assert.Empty(t, result.Errors)
+		require.Len(t, result.Warnings, 2)
+		assert.Contains(t, result.Warnings[0], "runs as root")
+		assert.Contains(t, result.Warnings[0], "neither useradd nor adduser")
+		assert.Contains(t, result.Warnings[1], "Home directory of root is /root")
+	})
+
+	t.Run("unwritable home and no bash", func(t *testing.T) {
+		result := newResult(true)
+		evaluateRuntime(parseRuntimeProbe("uid=1000\nuser=app\nhome=/home/claude\n"), result)
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors[0], "not writable by app")
+		assert.Contains(t, result.Errors[0], "chown app /home/claude")
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], "no /bin/bash")
+	})
+
+	t.Run("no Claude CLI and no Node.js", func(t *testing.T) {
+		result := newResult(false)
+		evaluateRuntime(parseRuntimeProbe("uid=1000\nuser=claude\nhome=/home/claude\nhome_writable=yes\nbash=yes\n"), result)
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors[0], "npm install -g @anthropic-ai/claude-code")
+	})
+
+	t.Run("old Node.js", func(t *testing.T) {
+		probe := "uid=1000\nuser=claude\nhome=/home/claude\nhome_writable=yes\nbash=yes\nnode=v16.20.2\n"
+		result := newResult(true)
+		evaluateRuntime(parseRuntimeProbe(probe), result)
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], "Node.js v16.20.2 is older than 18")
+
+		result = newResult(false)
+		evaluateRuntime(parseRuntimeProbe(probe), result)
+		require.Len(t, result.Errors, 1)
+		assert.Contains(t, result.Errors[0], "Node.js v16.20.2 is older than 18")
+	})
+}
+
+func TestRuntimeScript(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the script runs in a Linux container")
+	}
+	output, err := exec.Command("sh", "-c", runtimeScript).Output()
+	require.NoError(t, err)
+
+	probe := parseRuntimeProbe(string(output))
+	assert.NotEmpty(t, probe["uid"])
+	assert.Contains(t, probe, "home")
+	for key := range probe {
+		assert.Contains(t, []string{"uid", "user", "home", "home_writable", "bash", "useradd", "node"}, key)
+	}
+	assert.False(t, strings.Contains(string(output), "not found"), "every command is guarded")
+}
@@ -0,0 +1,212 @@
+package validation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"claude-reactor/pkg"
+)
+
+// severityOrder ranks vulnerability severities from least to most severe
+var severityOrder = []string{"negligible", "low", "medium", "high", "critical"}
+
+// scanCacheDuration is shorter than the validation cache because vulnerability
+// databases change even when the image digest does not
+const scanCacheDuration = 24 * time.Hour
+
+// scanners are tried in order; the first one found on PATH is used
+var scanners = []struct {
+	name string
+	args func(imageName string) []string
+}{
+	{name: "trivy", args: func(imageName string) []string {
+		return []string{"image", "--quiet", "--format", "json", imageName}
+	}},
+	{name: "grype", args: func(imageName string) []string {
+		return []string{imageName, "-o", "json", "-q"}
+	}},
+}
+
+// trivyReport is the subset of trivy's JSON output used for counting findings
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			Severity string `json:"Severity"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// grypeReport is the subset of grype's JSON output used for counting findings
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			Severity string `json:"severity"`
+		} `json:"vulnerability"`
+	} `json:"matches"`
+}
+
+// ScanImage runs a CVE scan on an image using trivy or grype from the host.
+// Results are cached by image digest next to the validation cache.
+func (v *ImageValidator) ScanImage(ctx context.Context, imageName string) (*pkg.ImageScanResult, error) {
+	imageInfo, err := v.dockerClient.ImageInspect(ctx, imageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect image: %w", err)
+	}
+	digest := v.getImageDigest(imageInfo)
+
+	if cached, err := v.getCachedScan(digest); err == nil && cached != nil {
+		v.logger.Debugf("Using cached vulnerability scan for image %s (digest: %s)", imageName, digest)
+		return cached, nil
+	}
+
+	scanner, output, err := v.runScanner(ctx, imageName)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := parseScanOutput(scanner, output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s output: %w", scanner, err)
+	}
+
+	result := &pkg.ImageScanResult{
+		Digest:    digest,
+		Scanner:   scanner,
+		Counts:    counts,
+		ScannedAt: time.Now().Format(time.RFC3339),
+	}
+
+	if err := v.cacheScan(digest, result); err != nil {
+		v.logger.Warnf("Failed to cache scan result: %v", err)
+	}
+
+	return result, nil
+}
+
+// runScanner executes the first available scanner and returns its name and JSON output
+func (v *ImageValidator) runScanner(ctx context.Context, imageName string) (string, []byte, error) {
+	for _, s := range scanners {
+		path, err := exec.LookPath(s.name)
+		if err != nil {
+			continue
+		}
+
+		v.logger.Debugf("Scanning image %s with %s", imageName, s.name)
+		output, err := exec.CommandContext(ctx, path, s.args(imageName)...).Output()
+		if err != nil {
+			return s.name, nil, fmt.Errorf("%s scan failed: %w", s.name, err)
+		}
+		return s.name, output, nil
+	}
+
+	return "", nil, pkg.NewError(pkg.CodeHostToolMissing, nil, "no vulnerability scanner found").
+		WithRemediation("Install trivy (https://trivy.dev) or grype (https://github.com/anchore/grype)")
+}
+
+// parseScanOutput counts findings per lowercase severity from scanner JSON output
+func parseScanOutput(scanner string, output []byte) (map[string]int, error) {
+	counts := make(map[string]int)
+
+	switch scanner {
+	case "trivy":
+		var report trivyReport
+		if err := json.Unmarshal(output, &report); err != nil {
+			return nil, err
+		}
+		for _, r := range report.Results {
+			for _, vuln := range r.Vulnerabilities {
+				counts[strings.ToLower(vuln.Severity)]++
+			}
+		}
+	case "grype":
+		var report grypeReport
+		if err := json.Unmarshal(output, &report); err != nil {
+			return nil, err
+		}
+		for _, match := range report.Matches {
+			counts[strings.ToLower(match.Vulnerability.Severity)]++
+		}
+	default:
+		return nil, fmt.Errorf("unsupported scanner: %s", scanner)
+	}
+
+	return counts, nil
+}
+
+// ValidSeverity reports whether a severity threshold name is recognised
+func ValidSeverity(severity string) bool {
+	return severityRank(severity) >= 0
+}
+
+// ExceedsThreshold returns the number of findings at or above the given severity
+func ExceedsThreshold(result *pkg.ImageScanResult, threshold string) int {
+	minRank := severityRank(threshold)
+	if result == nil || minRank < 0 {
+		return 0
+	}
+
+	total := 0
+	for severity, count := range result.Counts {
+		if severityRank(severity) >= minRank {
+			total += count
+		}
+	}
+	return total
+}
+
+// severityRank returns the position of a severity in severityOrder, or -1 if unknown
+func severityRank(severity string) int {
+	severity = strings.ToLower(severity)
+	for i, s := range severityOrder {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// getCachedScan retrieves a cached scan result if it is still fresh
+func (v *ImageValidator) getCachedScan(digest string) (*pkg.ImageScanResult, error) {
+	if v.revalidate {
+		return nil, fmt.Errorf("revalidation requested")
+	}
+	data, err := os.ReadFile(filepath.Join(v.cacheDir, digest+".scan.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var result pkg.ImageScanResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	scannedAt, err := time.Parse(time.RFC3339, result.ScannedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp in cache")
+	}
+	if time.Since(scannedAt) > scanCacheDuration {
+		return nil, fmt.Errorf("cache expired")
+	}
+
+	return &result, nil
+}
+
+// cacheScan stores a scan result in the validation cache directory
+func (v *ImageValidator) cacheScan(digest string, result *pkg.ImageScanResult) error {
+	if err := os.MkdirAll(v.cacheDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(v.cacheDir, digest+".scan.json"), data, 0644)
+}
@@ -0,0 +1,86 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func TestParseScanOutput(t *testing.T) {
+	t.Run("trivy", func(t *testing.T) {
+		output := []byte(`{"Results":[{"Vulnerabilities":[{"Severity":"HIGH"},{"Severity":"LOW"}]},{"Vulnerabilities":[{"Severity":"HIGH"}]}]}`)
+
+		counts, err := parseScanOutput("trivy", output)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"high": 2, "low": 1}, counts)
+	})
+
+	t.Run("grype", func(t *testing.T) {
+		output := []byte(`{"matches":[{"vulnerability":{"severity":"Critical"}},{"vulnerability":{"severity":"Medium"}}]}`)
+
+		counts, err := parseScanOutput("grype", output)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"critical": 1, "medium": 1}, counts)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := parseScanOutput("trivy", []byte("not json"))
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown scanner", func(t *testing.T) {
+		_, err := parseScanOutput("clair", []byte("{}"))
+		assert.Error(t, err)
+	})
+}
+
+func TestExceedsThreshold(t *testing.T) {
+	result := &pkg.ImageScanResult{Counts: map[string]int{"critical": 1, "high": 2, "medium": 5, "low": 10}}
+
+	assert.Equal(t, 1, ExceedsThreshold(result, "critical"))
+	assert.Equal(t, 3, ExceedsThreshold(result, "high"))
+	assert.Equal(t, 3, ExceedsThreshold(result, "HIGH"))
+	assert.Equal(t, 18, ExceedsThreshold(result, "low"))
+	assert.Equal(t, 0, ExceedsThreshold(result, "bogus"))
+	assert.Equal(t, 0, ExceedsThreshold(nil, "high"))
+}
+
+func TestValidSeverity(t *testing.T) {
+	assert.True(t, ValidSeverity("high"))
+	assert.True(t, ValidSeverity("Critical"))
+	assert.False(t, ValidSeverity("severe"))
+}
+
+func TestScanCache(t *testing.T) {
+	validator := &ImageValidator{cacheDir: t.TempDir()}
+
+	t.Run("fresh result is returned", func(t *testing.T) {
+		result := &pkg.ImageScanResult{
+			Digest:    "sha256:fresh",
+			Scanner:   "trivy",
+			Counts:    map[string]int{"high": 1},
+			ScannedAt: time.Now().Format(time.RFC3339),
+		}
+		require.NoError(t, validator.cacheScan(result.Digest, result))
+
+		cached, err := validator.getCachedScan(result.Digest)
+		require.NoError(t, err)
+		assert.Equal(t, result, cached)
+	})
+
+	t.Run("expired result is ignored", func(t *testing.T) {
+		result := &pkg.ImageScanResult{
+			Digest:    "sha256:stale",
+			Scanner:   "grype",
+			ScannedAt: time.Now().Add(-2 * scanCacheDuration).Format(time.RFC3339),
+		}
+		require.NoError(t, validator.cacheScan(result.Digest, result))
+
+		_, err := validator.getCachedScan(result.Digest)
+		assert.Error(t, err)
+	})
+}
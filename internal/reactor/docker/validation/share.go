@@ -0,0 +1,242 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"claude-reactor/pkg"
+)
+
+// sharedTimeout bounds each request to the shared cache, which is only worth waiting for
+// while it is faster than validating the image
+const sharedTimeout = 10 * time.Second
+
+// digestPattern matches the image IDs results are keyed by, so they are safe as file names
+var digestPattern = regexp.MustCompile(`^([a-z0-9]+:)?[0-9a-f]+$`)
+
+// errNotShared is returned when the shared cache has no result for a digest
+var errNotShared = errors.New("no shared result")
+
+// cacheBundle is the file format of exported validation results
+type cacheBundle struct {
+	ExportedAt string                       `json:"exported_at"`
+	Results    []*pkg.ImageValidationResult `json:"results"`
+}
+
+// ValidateSharedCache checks a shared cache location: an http:// or https:// URL served by any
+// web server accepting GET and PUT, or an s3://bucket/prefix accessed with the aws CLI
+func ValidateSharedCache(location string) error {
+	if location == "" {
+		return nil
+	}
+	parsed, err := url.Parse(location)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("invalid image cache URL '%s': use https://host/path or s3://bucket/prefix", location)
+	}
+	switch parsed.Scheme {
+	case "http", "https", "s3":
+		return nil
+	default:
+		return fmt.Errorf("unsupported image cache URL '%s': use https://, http:// or s3://", location)
+	}
+}
+
+// ExportCache writes the fresh validation results to w and returns how many
+func (v *ImageValidator) ExportCache(w io.Writer) (int, error) {
+	entries, err := v.cacheEntries()
+	if err != nil {
+		return 0, err
+	}
+
+	bundle := cacheBundle{ExportedAt: time.Now().Format(time.RFC3339), Results: []*pkg.ImageValidationResult{}}
+	ttl := v.effectivePolicy().TTL
+	for _, entry := range entries {
+		result, err := v.readCacheFile(entry.digest)
+		if err != nil || !fresh(result, ttl) {
+			continue
+		}
+		bundle.Results = append(bundle.Results, result)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(bundle); err != nil {
+		return 0, err
+	}
+	return len(bundle.Results), nil
+}
+
+// ImportCache adds validation results exported by ExportCache, keeping newer local ones,
+// and returns how many were added
+func (v *ImageValidator) ImportCache(r io.Reader) (int, error) {
+	var bundle cacheBundle
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return 0, fmt.Errorf("invalid validation cache export: %w", err)
+	}
+
+	imported := 0
+	ttl := v.effectivePolicy().TTL
+	for _, result := range bundle.Results {
+		if result == nil || !digestPattern.MatchString(result.Digest) || !fresh(result, ttl) {
+			continue
+		}
+		if local, err := v.readCacheFile(result.Digest); err == nil && local.ValidatedAt >= result.ValidatedAt {
+			continue
+		}
+		if err := v.cacheResult(result.Digest, result); err != nil {
+			return imported, err
+		}
+		imported++
+	}
+	if err := v.pruneCache(); err != nil && v.logger != nil {
+		v.logger.Debugf("Failed to prune validation cache: %v", err)
+	}
+	return imported, nil
+}
+
+// readCacheFile reads the cached validation result for a digest, however old
+func (v *ImageValidator) readCacheFile(digest string) (*pkg.ImageValidationResult, error) {
+	data, err := os.ReadFile(filepath.Join(v.cacheDir, digest+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var result pkg.ImageValidationResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// fresh reports whether a result was validated within ttl
+func fresh(result *pkg.ImageValidationResult, ttl time.Duration) bool {
+	validatedAt, err := time.Parse(time.RFC3339, result.ValidatedAt)
+	return err == nil && time.Since(validatedAt) <= ttl
+}
+
+// fetchShared looks a digest up in the shared cache, keeping a usable result in the local
+// cache. It returns nil when there is no shared cache or no usable result in it.
+func (v *ImageValidator) fetchShared(ctx context.Context, digest string) *pkg.ImageValidationResult {
+	location := v.policy.Shared
+	if location == "" || v.revalidate || !digestPattern.MatchString(digest) {
+		return nil
+	}
+
+	data, err := readShared(ctx, location, digest+".json")
+	if err != nil {
+		if !errors.Is(err, errNotShared) {
+			v.logger.Debugf("Failed to read shared validation cache: %v", err)
+		}
+		return nil
+	}
+	var result pkg.ImageValidationResult
+	if err := json.Unmarshal(data, &result); err != nil || result.Digest != digest {
+		v.logger.Debugf("Ignoring invalid shared validation result for %s", digest)
+		return nil
+	}
+	if err := v.checkCached(&result); err != nil {
+		v.logger.Debugf("Ignoring shared validation result for %s: %v", digest, err)
+		return nil
+	}
+
+	if err := v.cacheResult(digest, &result); err != nil {
+		v.logger.Debugf("Failed to cache shared validation result: %v", err)
+	}
+	return &result
+}
+
+// publishShared uploads a fresh validation result to the shared cache, so teammates can skip
+// validating the same image. Failures only cost them the validation, so they are not reported.
+func (v *ImageValidator) publishShared(ctx context.Context, digest string, result *pkg.ImageValidationResult) {
+	location := v.policy.Shared
+	if location == "" || !digestPattern.MatchString(digest) {
+		return
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := writeShared(ctx, location, digest+".json", data); err != nil {
+		v.logger.Debugf("Failed to publish validation result to the shared cache: %v", err)
+	}
+}
+
+// readShared reads name from a shared cache location
+func readShared(ctx context.Context, location, name string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, sharedTimeout)
+	defer cancel()
+	target := strings.TrimSuffix(location, "/") + "/" + name
+
+	if strings.HasPrefix(location, "s3://") {
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "aws", "s3", "cp", "--quiet", target, "-")
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			if strings.Contains(stderr.String(), "Not Found") || strings.Contains(stderr.String(), "does not exist") {
+				return nil, errNotShared
+			}
+			return nil, fmt.Errorf("aws s3 cp %s: %w: %s", target, err, strings.TrimSpace(stderr.String()))
+		}
+		return output, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errNotShared
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("GET %s: %s", req.URL.Redacted(), resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// writeShared writes name to a shared cache location
+func writeShared(ctx context.Context, location, name string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, sharedTimeout)
+	defer cancel()
+	target := strings.TrimSuffix(location, "/") + "/" + name
+
+	if strings.HasPrefix(location, "s3://") {
+		cmd := exec.CommandContext(ctx, "aws", "s3", "cp", "--quiet", "-", target)
+		cmd.Stdin = bytes.NewReader(data)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("aws s3 cp %s: %w: %s", target, err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("PUT %s: %s", req.URL.Redacted(), resp.Status)
+	}
+	return nil
+}
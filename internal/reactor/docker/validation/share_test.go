@@ -0,0 +1,164 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+	"claude-reactor/pkg/mocks"
+)
+
+func quietLogger() *mocks.MockLogger {
+	logger := &mocks.MockLogger{}
+	logger.On("Debugf", mock.Anything, mock.Anything).Maybe()
+	return logger
+}
+
+func TestValidateSharedCache(t *testing.T) {
+	assert.NoError(t, ValidateSharedCache(""))
+	assert.NoError(t, ValidateSharedCache("https://cache.example.com/reactor"))
+	assert.NoError(t, ValidateSharedCache("http://10.0.0.5:8080"))
+	assert.NoError(t, ValidateSharedCache("s3://team-bucket/reactor"))
+	assert.Error(t, ValidateSharedCache("cache.example.com/reactor"))
+	assert.Error(t, ValidateSharedCache("ftp://cache.example.com"))
+}
+
+func TestExportImportCache(t *testing.T) {
+	now := time.Now()
+	result := func(digest string, validatedAt time.Time) *pkg.ImageValidationResult {
+		return &pkg.ImageValidationResult{Digest: digest, Compatible: true, ValidatedAt: validatedAt.Format(time.RFC3339)}
+	}
+
+	source := &ImageValidator{cacheDir: t.TempDir()}
+	require.NoError(t, source.cacheResult("sha256:aa", result("sha256:aa", now.Add(-time.Hour))))
+	require.NoError(t, source.cacheResult("sha256:bb", result("sha256:bb", now.Add(-time.Hour))))
+	require.NoError(t, source.cacheResult("sha256:cc", result("sha256:cc", now.Add(-60*24*time.Hour))))
+
+	var exported bytes.Buffer
+	count, err := source.ExportCache(&exported)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "expired results are not exported")
+
+	target := &ImageValidator{cacheDir: t.TempDir()}
+	require.NoError(t, target.cacheResult("sha256:bb", result("sha256:bb", now)))
+	count, err = target.ImportCache(&exported)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "the newer local result is kept")
+
+	cached, err := target.getCachedResult("sha256:aa")
+	require.NoError(t, err)
+	assert.True(t, cached.Compatible)
+	local, err := target.readCacheFile("sha256:bb")
+	require.NoError(t, err)
+	assert.Equal(t, now.Format(time.RFC3339), local.ValidatedAt)
+
+	t.Run("unsafe digests are skipped", func(t *testing.T) {
+		bundle := `{"results":[{"digest":"../../evil","validated_at":"` + now.Format(time.RFC3339) + `"}]}`
+		count, err := target.ImportCache(strings.NewReader(bundle))
+		require.NoError(t, err)
+		assert.Zero(t, count)
+		assert.NoFileExists(t, filepath.Join(filepath.Dir(target.cacheDir), "evil.json"))
+	})
+
+	t.Run("invalid exports are rejected", func(t *testing.T) {
+		_, err := target.ImportCache(strings.NewReader("not json"))
+		assert.ErrorContains(t, err, "invalid validation cache export")
+	})
+}
+
+func TestSharedCacheHTTP(t *testing.T) {
+	var mu sync.Mutex
+	stored := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			stored[r.URL.Path], _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodGet:
+			if data, ok := stored[r.URL.Path]; ok {
+				w.Write(data)
+				return
+			}
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	digest := "sha256:0123abcd"
+	policy := pkg.ImageCachePolicy{Shared: server.URL + "/team/"}
+
+	publisher := &ImageValidator{cacheDir: t.TempDir(), logger: quietLogger(), policy: policy}
+	assert.Nil(t, publisher.fetchShared(ctx, digest), "nothing is shared yet")
+	publisher.publishShared(ctx, digest, &pkg.ImageValidationResult{Digest: digest, HasClaude: true, ValidatedAt: time.Now().Format(time.RFC3339)})
+	assert.Contains(t, stored, "/team/"+digest+".json")
+
+	teammate := &ImageValidator{cacheDir: t.TempDir(), logger: quietLogger(), policy: policy}
+	shared := teammate.fetchShared(ctx, digest)
+	require.NotNil(t, shared)
+	assert.True(t, shared.HasClaude)
+	cached, err := teammate.getCachedResult(digest)
+	require.NoError(t, err, "shared results are kept locally")
+	assert.True(t, cached.HasClaude)
+
+	t.Run("results checked for other tools are ignored", func(t *testing.T) {
+		other := &ImageValidator{cacheDir: t.TempDir(), logger: quietLogger(), policy: policy, tools: []pkg.ToolRequirement{{Name: "jq"}}}
+		assert.Nil(t, other.fetchShared(ctx, digest))
+	})
+
+	t.Run("revalidate ignores the shared cache", func(t *testing.T) {
+		other := &ImageValidator{cacheDir: t.TempDir(), logger: quietLogger(), policy: policy, revalidate: true}
+		assert.Nil(t, other.fetchShared(ctx, digest))
+	})
+
+	t.Run("results for another digest are ignored", func(t *testing.T) {
+		mu.Lock()
+		stored["/team/sha256:ffff.json"] = stored["/team/"+digest+".json"]
+		mu.Unlock()
+		other := &ImageValidator{cacheDir: t.TempDir(), logger: quietLogger(), policy: policy}
+		assert.Nil(t, other.fetchShared(ctx, "sha256:ffff"))
+	})
+}
+
+func TestSharedCacheS3(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake aws CLI is a shell script")
+	}
+	bucket := t.TempDir()
+	bin := t.TempDir()
+	// A fake aws CLI copying between stdin/stdout and the bucket directory
+	script := `#!/bin/sh
+key="${5#s3://team/}"
+if [ "$4" = "-" ]; then cat > "` + bucket + `/$(echo "${5#s3://team/}" | tr / _)"; exit 0; fi
+key="$(echo "${4#s3://team/}" | tr / _)"
+if [ ! -f "` + bucket + `/$key" ]; then echo "An error occurred (404) when calling the HeadObject operation: Not Found" >&2; exit 1; fi
+cat "` + bucket + `/$key"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "aws"), []byte(script), 0755))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	ctx := context.Background()
+	_, err := readShared(ctx, "s3://team/reactor", "sha256:aa.json")
+	assert.ErrorIs(t, err, errNotShared)
+
+	require.NoError(t, writeShared(ctx, "s3://team/reactor", "sha256:aa.json", []byte(`{"digest":"sha256:aa"}`)))
+	data, err := readShared(ctx, "s3://team/reactor", "sha256:aa.json")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"digest":"sha256:aa"}`, string(data))
+}
@@ -0,0 +1,100 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"claude-reactor/internal/reactor/registry"
+	"claude-reactor/pkg"
+)
+
+// cosignCommand is the cosign binary run from the host; a var so tests can substitute a fake
+var cosignCommand = "cosign"
+
+// signer is one way of verifying a signature, as cosign arguments
+type signer struct {
+	name string
+	args []string
+}
+
+// VerifySignature checks an image's cosign signature against each configured signer in turn,
+// pulling the image if needed. The digest the registry served is verified, not the tag, so the
+// image checked is the image that runs. It returns the signer that verified the image.
+func (v *ImageValidator) VerifySignature(ctx context.Context, imageName string, signatures pkg.SignaturePolicy) (string, error) {
+	if !signatures.Configured() {
+		return "", fmt.Errorf("no signature keys or identities configured")
+	}
+	cosign, err := exec.LookPath(cosignCommand)
+	if err != nil {
+		return "", pkg.NewError(pkg.CodeHostToolMissing, nil, "cosign not found").
+			WithRemediation("Install cosign (https://docs.sigstore.dev/cosign/system_config/installation/) to verify image signatures")
+	}
+
+	imageID, err := v.ensureImageExists(ctx, imageName, true)
+	if err != nil {
+		return "", err
+	}
+	imageInfo, err := v.dockerClient.ImageInspect(ctx, imageID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect image: %w", err)
+	}
+	ref, err := signedReference(imageName, imageInfo.RepoDigests)
+	if err != nil {
+		return "", err
+	}
+
+	var failures []string
+	for _, s := range signers(signatures) {
+		args := append(append([]string{"verify", "--output", "json"}, s.args...), ref)
+		v.logger.Debugf("Verifying %s signed by %s", ref, s.name)
+		output, err := exec.CommandContext(ctx, cosign, args...).CombinedOutput()
+		if err == nil {
+			return s.name, nil
+		}
+		failures = append(failures, fmt.Sprintf("%s: %s", s.name, lastLine(output, err)))
+	}
+	return "", fmt.Errorf("signature verification failed for %s:\n  %s", ref, strings.Join(failures, "\n  "))
+}
+
+// signers lists the configured keys and keyless identities, keys first
+func signers(signatures pkg.SignaturePolicy) []signer {
+	var list []signer
+	for _, key := range signatures.Keys {
+		list = append(list, signer{name: "key " + key, args: []string{"--key", key}})
+	}
+	for _, identity := range signatures.Identities {
+		list = append(list, signer{
+			name: fmt.Sprintf("%s (%s)", identity.Subject, identity.Issuer),
+			args: []string{"--certificate-identity-regexp", identity.Subject, "--certificate-oidc-issuer", identity.Issuer},
+		})
+	}
+	return list
+}
+
+// signedReference returns imageName's repository at the manifest digest the registry served.
+// Images pulled through a registry mirror only carry the mirror's digest, which is the same
+// content-addressed digest, so signatures are still looked up in the image's own repository.
+func signedReference(imageName string, repoDigests []string) (string, error) {
+	repository := registry.Repository(imageName)
+	for _, repoDigest := range repoDigests {
+		if registry.Repository(repoDigest) == repository {
+			return repository + "@" + registry.Digest(repoDigest), nil
+		}
+	}
+	if len(repoDigests) > 0 {
+		return repository + "@" + registry.Digest(repoDigests[0]), nil
+	}
+	return "", fmt.Errorf("image %s was not pulled from a registry, so it has no signature to verify", imageName)
+}
+
+// lastLine returns the last line of a command's output, which is where cosign explains a
+// failure, or the error itself when there was no output
+func lastLine(output []byte, err error) string {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if line := strings.TrimSpace(lines[len(lines)-1]); line != "" {
+		return line
+	}
+	return err.Error()
+}
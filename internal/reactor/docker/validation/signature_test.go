@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+const signedDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestSigners(t *testing.T) {
+	list := signers(pkg.SignaturePolicy{
+		Keys:       []string{"cosign.pub"},
+		Identities: []pkg.SignatureIdentity{{Issuer: "https://token.actions.githubusercontent.com", Subject: "^https://github.com/myorg/"}},
+	})
+
+	require.Len(t, list, 2)
+	assert.Equal(t, []string{"--key", "cosign.pub"}, list[0].args)
+	assert.Equal(t, []string{
+		"--certificate-identity-regexp", "^https://github.com/myorg/",
+		"--certificate-oidc-issuer", "https://token.actions.githubusercontent.com",
+	}, list[1].args)
+	assert.Equal(t, "^https://github.com/myorg/ (https://token.actions.githubusercontent.com)", list[1].name)
+}
+
+func TestSignedReference(t *testing.T) {
+	ref, err := signedReference("ghcr.io/myorg/dev:1.0", []string{"ghcr.io/myorg/dev@" + signedDigest})
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/myorg/dev@"+signedDigest, ref)
+
+	// Pulled through a mirror: same digest, verified in the image's own repository
+	ref, err = signedReference("ghcr.io/dyluth/claude-reactor-go:latest", []string{"mirror.local/dyluth/claude-reactor-go@" + signedDigest})
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/dyluth/claude-reactor-go@"+signedDigest, ref)
+
+	_, err = signedReference("claude-reactor-go:latest", nil)
+	assert.ErrorContains(t, err, "was not pulled from a registry")
+}
+
+func TestLastLine(t *testing.T) {
+	assert.Equal(t, "Error: no matching signatures", lastLine([]byte("Verifying...\nError: no matching signatures\n"), errors.New("exit status 1")))
+	assert.Equal(t, "exit status 1", lastLine(nil, errors.New("exit status 1")))
+}
+
+func TestVerifySignatureRequiresCosign(t *testing.T) {
+	original := cosignCommand
+	cosignCommand = "cosign-not-installed"
+	defer func() { cosignCommand = original }()
+
+	validator, _ := createTestValidatorSimple()
+	_, err := validator.VerifySignature(context.Background(), "ghcr.io/myorg/dev:1.0", pkg.SignaturePolicy{Keys: []string{"cosign.pub"}})
+	assert.ErrorContains(t, err, "cosign not found")
+
+	_, err = validator.VerifySignature(context.Background(), "ghcr.io/myorg/dev:1.0", pkg.SignaturePolicy{})
+	assert.ErrorContains(t, err, "no signature keys or identities configured")
+}
@@ -0,0 +1,147 @@
+package validation
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"claude-reactor/internal/reactor/detection"
+	"claude-reactor/pkg"
+)
+
+var (
+	toolNamePattern    = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*$`)
+	toolPackagePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.+-]*$`)
+	toolVersionPattern = regexp.MustCompile(`^\d+(\.\d+)*$`)
+)
+
+// ParseToolSpec parses a tool requirement written as name[:package][>=version], such as git,
+// jq>=1.6 or protoc:protobuf-compiler>=3.21. The package is the apt package providing the tool.
+func ParseToolSpec(spec string, required bool) (pkg.ToolRequirement, error) {
+	tool := pkg.ToolRequirement{Required: required}
+	rest, version, hasVersion := strings.Cut(strings.TrimSpace(spec), ">=")
+	tool.Name, tool.Package, _ = strings.Cut(strings.TrimSpace(rest), ":")
+	tool.MinVersion = strings.TrimSpace(version)
+
+	if !toolNamePattern.MatchString(tool.Name) {
+		return tool, fmt.Errorf("invalid tool '%s': use name[:package][>=version], e.g. protoc:protobuf-compiler>=3.21", spec)
+	}
+	if tool.Package != "" && !toolPackagePattern.MatchString(tool.Package) {
+		return tool, fmt.Errorf("invalid apt package '%s' for tool %s", tool.Package, tool.Name)
+	}
+	if hasVersion && !toolVersionPattern.MatchString(tool.MinVersion) {
+		return tool, fmt.Errorf("invalid version '%s' for tool %s: use digits and dots, e.g. 1.6", tool.MinVersion, tool.Name)
+	}
+	return tool, nil
+}
+
+// SetToolRequirements checks images for tools in place of the built-in recommended tools;
+// none restores the built-in check
+func (v *ImageValidator) SetToolRequirements(tools []pkg.ToolRequirement) {
+	v.tools = tools
+}
+
+// toolSet fingerprints the configured tool requirements, so cached results checked against
+// other requirements are not reused. It is "" for the built-in check.
+func (v *ImageValidator) toolSet() string {
+	if len(v.tools) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(v.tools)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// checkTools checks the image for the configured tools in one container, recording a check
+// per tool. Missing or outdated required tools are errors, recommended ones warnings.
+func (v *ImageValidator) checkTools(ctx context.Context, imageID string, result *pkg.ImageValidationResult) {
+	v.logger.Debugf("Checking %d configured tools for image", len(v.tools))
+	result.ToolSet = v.toolSet()
+
+	output, err := v.runScript(ctx, imageID, toolScript(v.tools))
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Failed to check tools: %v", err))
+		return
+	}
+	result.Tools = evaluateTools(v.tools, parseToolOutput(output))
+
+	available := 0
+	for _, check := range result.Tools {
+		if check.Status == pkg.ToolOK {
+			available++
+			continue
+		}
+		message := fmt.Sprintf("%s tool %s is %s", requirementLevel(check.Required), check.Name, check.Status)
+		if check.Status == pkg.ToolOutdated {
+			message = fmt.Sprintf("%s tool %s %s is older than %s", requirementLevel(check.Required), check.Name, versionOrUnknown(check.Version), check.MinVersion)
+		}
+		if check.Required {
+			result.Errors = append(result.Errors, message)
+		} else {
+			result.Warnings = append(result.Warnings, message)
+		}
+	}
+	result.Metadata["packages"] = map[string]interface{}{
+		"total_checked":   len(result.Tools),
+		"total_available": available,
+	}
+}
+
+// toolScript prints a line per tool: its name, and for tools on the PATH a tab and the first
+// line of its --version output. Names are validated by ParseToolSpec, so they need no quoting.
+func toolScript(tools []pkg.ToolRequirement) string {
+	var script strings.Builder
+	for _, tool := range tools {
+		fmt.Fprintf(&script, "if command -v %[1]s >/dev/null 2>&1; then printf '%%s\\t%%s\\n' %[1]s \"$(%[1]s --version 2>&1 </dev/null | head -n 1)\"; else echo %[1]s; fi\n", tool.Name)
+	}
+	return script.String()
+}
+
+// parseToolOutput maps each tool found by toolScript to its version line
+func parseToolOutput(output string) map[string]string {
+	found := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		if name, version, ok := strings.Cut(scanner.Text(), "\t"); ok {
+			found[name] = version
+		}
+	}
+	return found
+}
+
+// evaluateTools checks each requirement against the tools found and their version lines
+func evaluateTools(tools []pkg.ToolRequirement, found map[string]string) []pkg.ToolCheck {
+	checks := make([]pkg.ToolCheck, 0, len(tools))
+	for _, tool := range tools {
+		check := pkg.ToolCheck{ToolRequirement: tool, Status: pkg.ToolMissing}
+		if versionLine, ok := found[tool.Name]; ok {
+			check.Version = detection.ParseVersion(versionLine)
+			check.Status = pkg.ToolOK
+			minimum := detection.ToolchainRequirement{Tool: tool.Name, Version: tool.MinVersion, Minimum: true}
+			if tool.MinVersion != "" && (check.Version == "" || !minimum.Satisfies(check.Version)) {
+				check.Status = pkg.ToolOutdated
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func requirementLevel(required bool) string {
+	if required {
+		return "Required"
+	}
+	return "Recommended"
+}
+
+func versionOrUnknown(version string) string {
+	if version == "" {
+		return "(unknown version)"
+	}
+	return version
+}
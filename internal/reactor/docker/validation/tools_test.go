@@ -0,0 +1,92 @@
+package validation
+
+import (
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func TestParseToolSpec(t *testing.T) {
+	tool, err := ParseToolSpec("git", true)
+	require.NoError(t, err)
+	assert.Equal(t, pkg.ToolRequirement{Name: "git", Required: true}, tool)
+	assert.Equal(t, "git", tool.AptPackage())
+
+	tool, err = ParseToolSpec(" protoc:protobuf-compiler>=3.21 ", false)
+	require.NoError(t, err)
+	assert.Equal(t, pkg.ToolRequirement{Name: "protoc", Package: "protobuf-compiler", MinVersion: "3.21"}, tool)
+	assert.Equal(t, "protobuf-compiler", tool.AptPackage())
+
+	for _, spec := range []string{"", "jq; rm -rf /", "jq>=", "jq>=latest", "protoc:Protobuf", "$(id)"} {
+		_, err := ParseToolSpec(spec, true)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestToolScript(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the script runs in a Linux container")
+	}
+	tools := []pkg.ToolRequirement{{Name: "sh"}, {Name: "claude-reactor-missing-tool"}}
+	output, err := exec.Command("sh", "-c", toolScript(tools)).Output()
+	require.NoError(t, err)
+
+	found := parseToolOutput(string(output))
+	assert.Contains(t, found, "sh")
+	assert.NotContains(t, found, "claude-reactor-missing-tool")
+}
+
+func TestEvaluateTools(t *testing.T) {
+	tools := []pkg.ToolRequirement{
+		{Name: "git", Required: true},
+		{Name: "jq", MinVersion: "1.6"},
+		{Name: "protoc", MinVersion: "3.21", Required: true},
+		{Name: "yq"},
+		{Name: "make", MinVersion: "4"},
+	}
+	found := parseToolOutput("git\tgit version 2.39.2\n" +
+		"jq\tjq-1.7.1\n" +
+		"protoc\tlibprotoc 3.12.4\n" +
+		"make\t\n")
+
+	checks := evaluateTools(tools, found)
+	require.Len(t, checks, 5)
+	assert.Equal(t, pkg.ToolCheck{ToolRequirement: tools[0], Version: "2.39.2", Status: pkg.ToolOK}, checks[0])
+	assert.Equal(t, pkg.ToolOK, checks[1].Status)
+	assert.Equal(t, pkg.ToolCheck{ToolRequirement: tools[2], Version: "3.12.4", Status: pkg.ToolOutdated}, checks[2])
+	assert.Equal(t, pkg.ToolMissing, checks[3].Status)
+	assert.Equal(t, pkg.ToolOutdated, checks[4].Status, "a version is required but none was reported")
+}
+
+func TestToolSetCaching(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Maybe()
+	validator := &ImageValidator{logger: mockLogger, cacheDir: t.TempDir(), sessionWarnings: make(map[string]bool)}
+	assert.Empty(t, validator.toolSet(), "the built-in check has no fingerprint")
+
+	result := &pkg.ImageValidationResult{Compatible: true, ValidatedAt: time.Now().Format(time.RFC3339)}
+	require.NoError(t, validator.cacheResult("sha256:abc", result))
+	_, err := validator.getCachedResult("sha256:abc")
+	require.NoError(t, err)
+
+	// Results checked without the configured tools are checked again
+	validator.SetToolRequirements([]pkg.ToolRequirement{{Name: "git", Required: true}})
+	_, err = validator.getCachedResult("sha256:abc")
+	assert.Error(t, err)
+
+	result.ToolSet = validator.toolSet()
+	require.NoError(t, validator.cacheResult("sha256:abc", result))
+	_, err = validator.getCachedResult("sha256:abc")
+	assert.NoError(t, err)
+
+	validator.SetToolRequirements([]pkg.ToolRequirement{{Name: "git"}})
+	_, err = validator.getCachedResult("sha256:abc")
+	assert.Error(t, err, "a requirement changed")
+}
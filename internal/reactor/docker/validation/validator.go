@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -16,6 +17,7 @@ import (
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/client"
 
+	"claude-reactor/internal/reactor/registry"
 	"claude-reactor/pkg"
 )
 
@@ -57,6 +59,16 @@ type ImageValidator struct {
 	logger       pkg.Logger
 	cacheDir     string
 	sessionWarnings map[string]bool // Track warnings shown in this session
+
+	policy     pkg.ImageCachePolicy
+	revalidate bool
+	mirror     *registry.Mirror
+	tools      []pkg.ToolRequirement // checked in place of recommendedPackages when set
+
+	mu     sync.Mutex
+	images map[string]imageEntry // remembered name -> image ID lookups, loaded on first use
+	hits   int
+	misses int
 }
 
 
@@ -73,10 +85,29 @@ func NewImageValidator(dockerClient client.APIClient, logger pkg.Logger) *ImageV
 	}
 }
 
-// ValidateImage validates a Docker image for claude-reactor compatibility
+// SetRegistryMirror pulls published images through mirror; "" pulls from the registry directly
+func (v *ImageValidator) SetRegistryMirror(mirror string) {
+	parsed, err := registry.ParseMirror(mirror)
+	if err != nil {
+		v.logger.Debugf("Ignoring registry mirror: %v", err)
+	}
+	v.mirror = parsed
+}
+
+// ValidateImage validates a Docker image for claude-reactor compatibility. It is safe to call
+// for several images at once.
 func (v *ImageValidator) ValidateImage(ctx context.Context, imageName string, pullIfNeeded bool) (*pkg.ImageValidationResult, error) {
 	v.logger.Debugf("Validating image: %s", imageName)
 	
+	// A recently seen image's ID is its digest, so a cached result needs no daemon calls at all
+	if imageID, ok := v.LookupImage(imageName); ok {
+		if cached, err := v.getCachedResult(imageID); err == nil && cached != nil {
+			v.recordLookup(true)
+			v.logger.Debugf("Using cached validation result for image %s (digest: %s)", imageName, imageID)
+			return cached, nil
+		}
+	}
+	
 	// Step 1: Ensure image exists locally (pull if needed)
 	imageID, err := v.ensureImageExists(ctx, imageName, pullIfNeeded)
 	if err != nil {
@@ -90,12 +121,22 @@ func (v *ImageValidator) ValidateImage(ctx context.Context, imageName string, pu
 	}
 	
 	digest := v.getImageDigest(imageInfo)
+	if imageInfo.ID != "" {
+		v.RememberImage(imageName, imageInfo.ID)
+	}
 	
-	// Step 3: Check cache first
+	// Step 3: Check cache first, then the cache shared with the team
 	if cached, err := v.getCachedResult(digest); err == nil && cached != nil {
+		v.recordLookup(true)
 		v.logger.Debugf("Using cached validation result for image %s (digest: %s)", imageName, digest)
 		return cached, nil
 	}
+	if shared := v.fetchShared(ctx, digest); shared != nil {
+		v.recordLookup(true)
+		v.logger.Debugf("Using shared validation result for image %s (digest: %s)", imageName, digest)
+		return shared, nil
+	}
+	v.recordLookup(false)
 	
 	// Step 4: Perform validation
 	result := &pkg.ImageValidationResult{
@@ -114,9 +155,19 @@ func (v *ImageValidator) ValidateImage(ctx context.Context, imageName string, pu
 	
 	// Step 6: Check for Claude CLI
 	v.validateClaudeCLI(ctx, imageID, result)
+
+	// Step 6.5: Check the entrypoint, user, home directory, shells and Node.js sessions rely on
+	if imageInfo.Config != nil {
+		checkImageConfig(imageInfo.Config.Entrypoint, imageInfo.Config.Cmd, result)
+	}
+	v.checkRuntime(ctx, imageID, result)
 	
-	// Step 7: Check for recommended packages
-	v.checkRecommendedPackages(ctx, imageID, result)
+	// Step 7: Check for configured tools, or the recommended packages
+	if len(v.tools) > 0 {
+		v.checkTools(ctx, imageID, result)
+	} else {
+		v.checkRecommendedPackages(ctx, imageID, result)
+	}
 	
 	// Step 8: Determine overall compatibility
 	result.Compatible = result.IsLinux && result.HasClaude && len(result.Errors) == 0
@@ -124,7 +175,10 @@ func (v *ImageValidator) ValidateImage(ctx context.Context, imageName string, pu
 	// Step 9: Cache the result
 	if err := v.cacheResult(digest, result); err != nil {
 		v.logger.Warnf("Failed to cache validation result: %v", err)
+	} else if err := v.pruneCache(); err != nil {
+		v.logger.Debugf("Failed to prune validation cache: %v", err)
 	}
+	v.publishShared(ctx, digest, result)
 	
 	v.logger.Debugf("Image validation complete: compatible=%t, linux=%t, claude=%t", 
 		result.Compatible, result.IsLinux, result.HasClaude)
@@ -142,11 +196,9 @@ func (v *ImageValidator) ensureImageExists(ctx context.Context, imageName string
 	
 	// Look for matching image
 	for _, image := range images {
-		for _, tag := range image.RepoTags {
-			if tag == imageName {
-				v.logger.Debugf("Image %s found locally (ID: %s)", imageName, image.ID)
-				return image.ID, nil
-			}
+		if matchesImage(image, imageName) {
+			v.logger.Debugf("Image %s found locally (ID: %s)", imageName, image.ID)
+			return image.ID, nil
 		}
 	}
 	
@@ -157,21 +209,9 @@ func (v *ImageValidator) ensureImageExists(ctx context.Context, imageName string
 	
 	// Pull the image
 	v.logger.Infof("📦 Pulling image: %s", imageName)
-	pullResponse, err := v.dockerClient.ImagePull(ctx, imageName, image.PullOptions{})
-	if err != nil {
+	if err := registry.Pull(ctx, v.dockerClient, imageName, image.PullOptions{}, v.mirror, v.logger); err != nil {
 		return "", fmt.Errorf("failed to pull image %s: %w", imageName, err)
 	}
-	defer pullResponse.Close()
-	
-	// Wait for pull to complete (simplified - in production we'd stream progress)
-	// For now, just read the response to completion
-	buf := make([]byte, 1024)
-	for {
-		_, err := pullResponse.Read(buf)
-		if err != nil {
-			break // EOF or error, either way we're done
-		}
-	}
 	
 	// Get the pulled image ID
 	images, err = v.dockerClient.ImageList(ctx, image.ListOptions{})
@@ -180,17 +220,35 @@ func (v *ImageValidator) ensureImageExists(ctx context.Context, imageName string
 	}
 	
 	for _, image := range images {
-		for _, tag := range image.RepoTags {
-			if tag == imageName {
-				v.logger.Infof("✅ Image pulled successfully: %s", imageName)
-				return image.ID, nil
-			}
+		if matchesImage(image, imageName) {
+			v.logger.Infof("✅ Image pulled successfully: %s", imageName)
+			return image.ID, nil
 		}
 	}
 	
 	return "", fmt.Errorf("image %s not found after pull", imageName)
 }
 
+// matchesImage reports whether a local image is imageName. Images pinned by digest match only
+// an image the registry served under that digest, which verifies its content.
+func matchesImage(summary image.Summary, imageName string) bool {
+	if digest := registry.Digest(imageName); digest != "" {
+		repository := registry.Repository(imageName)
+		for _, repoDigest := range summary.RepoDigests {
+			if registry.Repository(repoDigest) == repository && registry.Digest(repoDigest) == digest {
+				return true
+			}
+		}
+		return false
+	}
+	for _, tag := range summary.RepoTags {
+		if tag == imageName {
+			return true
+		}
+	}
+	return false
+}
+
 // validatePlatform checks if the image is Linux-based
 func (v *ImageValidator) validatePlatform(result *pkg.ImageValidationResult) {
 	result.IsLinux = strings.ToLower(result.Platform) == "linux"
@@ -271,8 +329,8 @@ func (v *ImageValidator) getImageDigest(imageInfo types.ImageInspect) string {
 // Docker image digests are immutable SHA256 hashes - once an image has a specific digest,
 // its content will never change. Therefore we can cache validation results for a long time.
 func (v *ImageValidator) getCachedResult(digest string) (*pkg.ImageValidationResult, error) {
-	if err := os.MkdirAll(v.cacheDir, 0755); err != nil {
-		return nil, err
+	if v.revalidate {
+		return nil, fmt.Errorf("revalidation requested")
 	}
 	
 	cacheFile := filepath.Join(v.cacheDir, digest+".json")
@@ -285,18 +343,28 @@ func (v *ImageValidator) getCachedResult(digest string) (*pkg.ImageValidationRes
 	if err := json.Unmarshal(data, &result); err != nil {
 		return nil, err
 	}
+	if err := v.checkCached(&result); err != nil {
+		return nil, err
+	}
 	
-	// Check if cache is still fresh (30 days)
+	return &result, nil
+}
+
+// checkCached returns why a cached result cannot be used, or nil if it can
+func (v *ImageValidator) checkCached(result *pkg.ImageValidationResult) error {
+	// Check if cache is still fresh (30 days unless the policy says otherwise)
 	// Docker image digests are immutable - once validated, they don't change
 	validatedAt, err := time.Parse(time.RFC3339, result.ValidatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("invalid timestamp in cache")
+		return fmt.Errorf("invalid timestamp in cache")
 	}
-	if time.Since(validatedAt) > 30*24*time.Hour {
-		return nil, fmt.Errorf("cache expired")
+	if time.Since(validatedAt) > v.effectivePolicy().TTL {
+		return fmt.Errorf("cache expired")
 	}
-	
-	return &result, nil
+	if result.ToolSet != v.toolSet() {
+		return fmt.Errorf("cached result checked other tools")
+	}
+	return nil
 }
 
 // cacheResult stores validation result in cache
@@ -400,13 +468,14 @@ func (v *ImageValidator) addPackageWarnings(result *pkg.ImageValidationResult, m
 		sessionKey := fmt.Sprintf("missing-packages-%s", strings.Join(missingHighPriority, ","))
 		
 		// Only show warning once per session
-		if !v.sessionWarnings[sessionKey] {
+		v.mu.Lock()
+		shown := v.sessionWarnings[sessionKey]
+		v.sessionWarnings[sessionKey] = true
+		v.mu.Unlock()
+		if !shown {
 			warningMsg := fmt.Sprintf("Missing recommended tools: %s. These enhance the development experience", 
 				strings.Join(missingHighPriority, ", "))
 			result.Warnings = append(result.Warnings, warningMsg)
-			
-			// Mark this warning as shown for this session
-			v.sessionWarnings[sessionKey] = true
 		}
 	}
 	
@@ -420,11 +489,17 @@ func (v *ImageValidator) addPackageWarnings(result *pkg.ImageValidationResult, m
 
 // ClearSessionWarnings resets session warning tracking
 func (v *ImageValidator) ClearSessionWarnings() {
+	v.mu.Lock()
 	v.sessionWarnings = make(map[string]bool)
+	v.mu.Unlock()
 }
 
 // ClearCache removes all cached validation results
 func (v *ImageValidator) ClearCache() error {
+	v.mu.Lock()
+	v.images = nil
+	v.mu.Unlock()
+	
 	if _, err := os.Stat(v.cacheDir); os.IsNotExist(err) {
 		return nil // Cache directory doesn't exist, nothing to clear
 	}
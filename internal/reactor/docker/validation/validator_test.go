@@ -1,37 +1,125 @@
 package validation
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"claude-reactor/pkg"
 )
 
-// TODO: Add Docker client mocking - currently commented out due to interface complexity
-/*
-// DockerClientInterface defines only the methods we need for testing
-type DockerClientInterface interface {
-	ImageList(ctx context.Context, options image.ListOptions) ([]image.Summary, error)
-	ImageInspect(ctx context.Context, imageID string) (types.ImageInspect, error)
-	ImagePull(ctx context.Context, refStr string, options image.PullOptions) (io.ReadCloser, error)
-	ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *ocispec.Platform, containerName string) (container.CreateResponse, error)
-	ContainerStart(ctx context.Context, containerID string, options container.StartOptions) error
-	ContainerWait(ctx context.Context, containerID string, condition container.WaitCondition) (<-chan container.WaitResponse, <-chan error)
-	ContainerRemove(ctx context.Context, containerID string, options container.RemoveOptions) error
-}
-
-// MockDockerClient for testing - simplified approach using composition
+// MockDockerClient mocks the image calls the validator makes to the Docker API. Its throwaway
+// containers don't start: waiting for one runs run with the container's command, whose exit
+// code and output the container then reports. Other API calls panic on the nil APIClient.
 type MockDockerClient struct {
+	client.APIClient
 	mock.Mock
+
+	run func(command []string) (int64, string)
+
+	mu         sync.Mutex
+	containers map[string][]string // container ID -> command
+	outputs    map[string]string   // container ID -> output
+	commands   [][]string          // every command run, in order
+}
+
+func newMockDockerClient(run func(command []string) (int64, string)) *MockDockerClient {
+	return &MockDockerClient{run: run, containers: map[string][]string{}, outputs: map[string]string{}}
+}
+
+func (m *MockDockerClient) ImageList(ctx context.Context, options image.ListOptions) ([]image.Summary, error) {
+	args := m.Called(ctx, options)
+	return args.Get(0).([]image.Summary), args.Error(1)
+}
+
+func (m *MockDockerClient) ImageInspect(ctx context.Context, imageID string, _ ...client.ImageInspectOption) (image.InspectResponse, error) {
+	args := m.Called(ctx, imageID)
+	return args.Get(0).(image.InspectResponse), args.Error(1)
+}
+
+func (m *MockDockerClient) ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error) {
+	args := m.Called(ctx, ref, options)
+	reader, _ := args.Get(0).(io.ReadCloser)
+	return reader, args.Error(1)
+}
+
+func (m *MockDockerClient) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *ocispec.Platform, containerName string) (container.CreateResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := fmt.Sprintf("container-%d", len(m.containers)+1)
+	m.containers[id] = append(append([]string{}, config.Entrypoint...), config.Cmd...)
+	return container.CreateResponse{ID: id}, nil
+}
+
+func (m *MockDockerClient) ContainerStart(ctx context.Context, containerID string, options container.StartOptions) error {
+	return nil
+}
+
+func (m *MockDockerClient) ContainerWait(ctx context.Context, containerID string, condition container.WaitCondition) (<-chan container.WaitResponse, <-chan error) {
+	m.mu.Lock()
+	command := m.containers[containerID]
+	m.commands = append(m.commands, command)
+	m.mu.Unlock()
+
+	exitCode, output := m.run(command)
+	m.mu.Lock()
+	m.outputs[containerID] = output
+	m.mu.Unlock()
+
+	waitCh := make(chan container.WaitResponse, 1)
+	waitCh <- container.WaitResponse{StatusCode: exitCode}
+	return waitCh, make(chan error)
+}
+
+func (m *MockDockerClient) ContainerLogs(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error) {
+	m.mu.Lock()
+	output := m.outputs[containerID]
+	m.mu.Unlock()
+
+	var logs bytes.Buffer
+	stdcopy.NewStdWriter(&logs, stdcopy.Stdout).Write([]byte(output))
+	return io.NopCloser(&logs), nil
+}
+
+func (m *MockDockerClient) ContainerRemove(ctx context.Context, containerID string, options container.RemoveOptions) error {
+	return nil
+}
+
+// createTestValidator creates a validator using a MockDockerClient and a temporary cache
+func createTestValidator(t *testing.T, run func(command []string) (int64, string)) (*ImageValidator, *MockDockerClient) {
+	mockClient := newMockDockerClient(run)
+	mockLogger := &MockLogger{}
+	mockLogger.On("Debugf", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Infof", mock.Anything, mock.Anything).Maybe()
+	mockLogger.On("Warnf", mock.Anything, mock.Anything).Maybe()
+
+	validator := &ImageValidator{
+		dockerClient:    mockClient,
+		logger:          mockLogger,
+		cacheDir:        t.TempDir(),
+		sessionWarnings: make(map[string]bool),
+	}
+	return validator, mockClient
 }
-*/
 
 // MockLogger for testing
 type MockLogger struct {
@@ -550,214 +638,149 @@ func TestGetImageDigest(t *testing.T) {
 	})
 }
 
-// Test ensureImageExists - TODO: Add Docker client mocking
-/*
 func TestEnsureImageExists(t *testing.T) {
+	ctx := context.Background()
+	noContainers := func([]string) (int64, string) { return 0, "" }
+
 	t.Run("finds existing image locally", func(t *testing.T) {
-		validator, mockClient, mockLogger := createTestValidator()
-		
-		// Mock successful image list
-		mockImages := []image.Summary{
-			{
-				ID: "sha256:existing123",
-				RepoTags: []string{"test:latest", "test:1.0"},
-			},
-		}
-		
-		mockClient.On("ImageList", mock.AnythingOfType("*context.emptyCtx"), mock.AnythingOfType("image.ListOptions")).Return(mockImages, nil)
-		mockLogger.On("Debugf", mock.AnythingOfType("string"), mock.Anything)
-		
-		ctx := context.Background()
+		validator, mockClient := createTestValidator(t, noContainers)
+		mockClient.On("ImageList", ctx, image.ListOptions{}).Return([]image.Summary{
+			{ID: "sha256:existing123", RepoTags: []string{"test:latest", "test:1.0"}},
+		}, nil)
+
 		imageID, err := validator.ensureImageExists(ctx, "test:latest", false)
-		
+
 		assert.NoError(t, err)
 		assert.Equal(t, "sha256:existing123", imageID)
-		
-		mockClient.AssertExpectations(t)
-		mockLogger.AssertExpectations(t)
+		mockClient.AssertNotCalled(t, "ImagePull", mock.Anything, mock.Anything, mock.Anything)
 	})
-	
+
 	t.Run("returns error when image not found and pull not requested", func(t *testing.T) {
-		validator, mockClient, _ := createTestValidator()
-		
-		// Mock empty image list
-		mockImages := []image.Summary{}
-		mockClient.On("ImageList", mock.AnythingOfType("*context.emptyCtx"), mock.AnythingOfType("image.ListOptions")).Return(mockImages, nil)
-		
-		ctx := context.Background()
+		validator, mockClient := createTestValidator(t, noContainers)
+		mockClient.On("ImageList", ctx, image.ListOptions{}).Return([]image.Summary{}, nil)
+
 		imageID, err := validator.ensureImageExists(ctx, "nonexistent:latest", false)
-		
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "not found locally and pull not requested")
+
+		assert.ErrorContains(t, err, "not found locally and pull not requested")
 		assert.Empty(t, imageID)
-		
+	})
+
+	t.Run("pulls missing image", func(t *testing.T) {
+		validator, mockClient := createTestValidator(t, noContainers)
+		mockClient.On("ImageList", ctx, image.ListOptions{}).Return([]image.Summary{}, nil).Once()
+		mockClient.On("ImagePull", ctx, "ubuntu:24.04", image.PullOptions{}).Return(io.NopCloser(strings.NewReader(`{"status":"done"}`)), nil)
+		mockClient.On("ImageList", ctx, image.ListOptions{}).Return([]image.Summary{
+			{ID: "sha256:ubuntu", RepoTags: []string{"ubuntu:24.04"}},
+		}, nil)
+
+		imageID, err := validator.ensureImageExists(ctx, "ubuntu:24.04", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "sha256:ubuntu", imageID)
 		mockClient.AssertExpectations(t)
 	})
-	
+
 	t.Run("handles image list error", func(t *testing.T) {
-		validator, mockClient, _ := createTestValidator()
-		
-		mockClient.On("ImageList", mock.AnythingOfType("*context.emptyCtx"), mock.AnythingOfType("image.ListOptions")).Return([]image.Summary{}, fmt.Errorf("docker error"))
-		
-		ctx := context.Background()
+		validator, mockClient := createTestValidator(t, noContainers)
+		mockClient.On("ImageList", ctx, image.ListOptions{}).Return([]image.Summary{}, fmt.Errorf("docker error"))
+
 		imageID, err := validator.ensureImageExists(ctx, "test:latest", false)
-		
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to list images")
+
+		assert.ErrorContains(t, err, "failed to list images")
 		assert.Empty(t, imageID)
-		
-		mockClient.AssertExpectations(t)
 	})
 }
-*/
-
-// Mock PullReader for testing - TODO: Re-enable with Docker client mocking
-/*
-type mockPullReader struct {
-	data []byte
-	pos  int
-}
 
-func (m *mockPullReader) Read(p []byte) (n int, err error) {
-	if m.pos >= len(m.data) {
-		return 0, io.EOF
+func TestValidateImage(t *testing.T) {
+	ctx := context.Background()
+	inspect := image.InspectResponse{
+		ID: "sha256:0123abcd", Os: "linux", Architecture: "amd64", Size: 1024,
+		Config: &dockerspec.DockerOCIImageConfig{ImageConfig: ocispec.ImageConfig{Cmd: []string{"bash"}}},
 	}
-	n = copy(p, m.data[m.pos:])
-	m.pos += n
-	return n, nil
-}
-
-func (m *mockPullReader) Close() error {
-	return nil
-}
-*/
-
-// Test cache functionality - TODO: Re-enable after Docker client mocking is fixed
-/*
-func TestCacheOperations(t *testing.T) {
-	mockClient := &MockDockerClient{}
-	mockLogger := &MockLogger{}
-	
-	// Create validator with temporary cache directory
-	tempDir := t.TempDir()
-	validator := &ImageValidator{
-		dockerClient: mockClient,
-		logger: mockLogger,
-		cacheDir: tempDir,
-		sessionWarnings: make(map[string]bool),
+	withImage := func(mockClient *MockDockerClient) {
+		mockClient.On("ImageList", ctx, image.ListOptions{}).Return([]image.Summary{
+			{ID: inspect.ID, RepoTags: []string{"dev:latest"}},
+		}, nil)
+		mockClient.On("ImageInspect", ctx, inspect.ID).Return(inspect, nil)
 	}
-	
-	t.Run("cache and retrieve result", func(t *testing.T) {
-		result := &pkg.ImageValidationResult{
-			Digest: "sha256:test123",
-			Compatible: true,
-			IsLinux: true,
-			HasClaude: true,
-			Architecture: "amd64",
-			Platform: "linux",
-			Size: 1234567,
-			ValidatedAt: time.Now().Format(time.RFC3339),
-			Warnings: []string{"test warning"},
-			Errors: []string{},
-			Metadata: map[string]interface{}{
-				"test": "data",
-			},
-		}
-		
-		// Cache the result
-		err := validator.cacheResult("sha256:test123", result)
-		assert.NoError(t, err)
-		
-		// Retrieve from cache
-		cached, err := validator.getCachedResult("sha256:test123")
-		assert.NoError(t, err)
-		assert.NotNil(t, cached)
-		
-		// Verify cached data
-		assert.Equal(t, result.Digest, cached.Digest)
-		assert.Equal(t, result.Compatible, cached.Compatible)
-		assert.Equal(t, result.IsLinux, cached.IsLinux)
-		assert.Equal(t, result.HasClaude, cached.HasClaude)
-		assert.Equal(t, result.Architecture, cached.Architecture)
-		assert.Equal(t, result.Platform, cached.Platform)
-		assert.Equal(t, result.Size, cached.Size)
-		assert.Equal(t, result.Warnings, cached.Warnings)
-		assert.Equal(t, result.Errors, cached.Errors)
-		assert.Equal(t, result.Metadata, cached.Metadata)
+
+	t.Run("compatible image", func(t *testing.T) {
+		validator, mockClient := createTestValidator(t, func(command []string) (int64, string) {
+			if command[0] == "/bin/sh" {
+				return 0, "uid=1000\nuser=claude\nhome=/home/claude\nhome_writable=yes\nbash=yes\nnode=v22.1.0\n"
+			}
+			return 0, ""
+		})
+		withImage(mockClient)
+
+		result, err := validator.ValidateImage(ctx, "dev:latest", false)
+		require.NoError(t, err)
+		assert.True(t, result.Compatible)
+		assert.True(t, result.HasClaude)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, []string{"claude", "--version"}, mockClient.commands[0])
+
+		runs := len(mockClient.commands)
+		cached, err := validator.ValidateImage(ctx, "dev:latest", false)
+		require.NoError(t, err)
+		assert.True(t, cached.Compatible)
+		assert.Len(t, mockClient.commands, runs, "the cached result needs no containers")
 	})
-	
-	t.Run("returns error for non-existent cache entry", func(t *testing.T) {
-		cached, err := validator.getCachedResult("sha256:nonexistent")
-		assert.Error(t, err)
-		assert.Nil(t, cached)
+
+	t.Run("image without Claude CLI or Node.js", func(t *testing.T) {
+		validator, mockClient := createTestValidator(t, func(command []string) (int64, string) {
+			switch command[0] {
+			case "claude":
+				return 127, ""
+			case "/bin/sh":
+				return 0, "uid=0\nuser=root\nhome=/root\nhome_writable=yes\nbash=yes\n"
+			}
+			return 0, ""
+		})
+		withImage(mockClient)
+
+		result, err := validator.ValidateImage(ctx, "dev:latest", false)
+		require.NoError(t, err)
+		assert.False(t, result.Compatible)
+		assert.False(t, result.HasClaude)
+		assert.Contains(t, strings.Join(result.Errors, "\n"), "Node.js not found")
+		assert.Contains(t, strings.Join(result.Warnings, "\n"), "runs as root")
 	})
-	
-	t.Run("handles invalid cache file", func(t *testing.T) {
-		// Create invalid cache file
-		cacheFile := filepath.Join(tempDir, "sha256_invalid123.json")
-		err := os.WriteFile(cacheFile, []byte("invalid json"), 0644)
-		assert.NoError(t, err)
-		
-		cached, err := validator.getCachedResult("sha256:invalid123")
-		assert.Error(t, err)
-		assert.Nil(t, cached)
-		assert.Contains(t, err.Error(), "failed to unmarshal")
+
+	t.Run("configured tools", func(t *testing.T) {
+		validator, mockClient := createTestValidator(t, func(command []string) (int64, string) {
+			if command[0] == "/bin/sh" && strings.Contains(command[2], "command -v git") {
+				return 0, "git\tgit version 2.39.2\njq\n"
+			}
+			if command[0] == "/bin/sh" {
+				return 0, "uid=1000\nuser=claude\nhome=/home/claude\nhome_writable=yes\nbash=yes\n"
+			}
+			return 0, ""
+		})
+		withImage(mockClient)
+		validator.SetToolRequirements([]pkg.ToolRequirement{{Name: "git", Required: true}, {Name: "jq", Required: true}})
+
+		result, err := validator.ValidateImage(ctx, "dev:latest", false)
+		require.NoError(t, err)
+		assert.False(t, result.Compatible)
+		require.Len(t, result.Tools, 2)
+		assert.Equal(t, pkg.ToolOK, result.Tools[0].Status)
+		assert.Equal(t, pkg.ToolMissing, result.Tools[1].Status)
+		assert.Contains(t, result.Errors, "Required tool jq is missing")
 	})
 }
-*/
 
-// Test ClearCache - TODO: Re-enable after Docker client mocking is fixed
-/*
-func TestClearCache(t *testing.T) {
-	mockClient := &MockDockerClient{}
-	mockLogger := &MockLogger{}
-	
-	// Create validator with temporary cache directory
-	tempDir := t.TempDir()
-	validator := &ImageValidator{
-		dockerClient: mockClient,
-		logger: mockLogger,
-		cacheDir: tempDir,
-		sessionWarnings: make(map[string]bool),
+func TestMatchesImage(t *testing.T) {
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	local := image.Summary{
+		RepoTags:    []string{"ubuntu:22.04"},
+		RepoDigests: []string{"ubuntu@" + digest},
 	}
-	
-	t.Run("clears cache directory", func(t *testing.T) {
-		// Create some cache files
-		testFile1 := filepath.Join(tempDir, "test1.json")
-		testFile2 := filepath.Join(tempDir, "test2.json")
-		
-		err := os.WriteFile(testFile1, []byte("{}"), 0644)
-		assert.NoError(t, err)
-		err = os.WriteFile(testFile2, []byte("{}"), 0644)
-		assert.NoError(t, err)
-		
-		// Verify files exist
-		_, err = os.Stat(testFile1)
-		assert.NoError(t, err)
-		_, err = os.Stat(testFile2)
-		assert.NoError(t, err)
-		
-		// Clear cache
-		err = validator.ClearCache()
-		assert.NoError(t, err)
-		
-		// Verify files are gone
-		_, err = os.Stat(testFile1)
-		assert.True(t, os.IsNotExist(err))
-		_, err = os.Stat(testFile2)
-		assert.True(t, os.IsNotExist(err))
-		
-		// Verify directory still exists
-		_, err = os.Stat(tempDir)
-		assert.NoError(t, err)
-	})
-	
-	t.Run("handles non-existent cache directory", func(t *testing.T) {
-		validator.cacheDir = "/nonexistent/path"
-		
-		err := validator.ClearCache()
-		// Should not error - clearing non-existent cache is fine
-		assert.NoError(t, err)
-	})
+
+	assert.True(t, matchesImage(local, "ubuntu:22.04"))
+	assert.False(t, matchesImage(local, "ubuntu:24.04"))
+	assert.True(t, matchesImage(local, "ubuntu@"+digest))
+	assert.True(t, matchesImage(local, "docker.io/library/ubuntu@"+digest), "repositories are compared fully qualified")
+	assert.False(t, matchesImage(local, "ubuntu@sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"), "a different digest is a different image")
+	assert.False(t, matchesImage(local, "debian@"+digest))
 }
-*/
\ No newline at end of file
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	
+	"claude-reactor/internal/reactor/variants"
 	"claude-reactor/pkg"
 )
 
@@ -13,7 +14,8 @@ type VariantManager struct {
 	variants map[string]*pkg.VariantDefinition
 }
 
-// NewVariantManager creates a new variant manager with built-in variant definitions
+// NewVariantManager creates a new variant manager with built-in variant definitions and any
+// external definitions in ~/.claude-reactor/variants.d
 func NewVariantManager(logger pkg.Logger) *VariantManager {
 	vm := &VariantManager{
 		logger: logger,
@@ -22,10 +24,32 @@ func NewVariantManager(logger pkg.Logger) *VariantManager {
 	
 	// Initialize built-in variants (replicating the bash script's variants)
 	vm.initializeBuiltinVariants()
+	vm.LoadExternalVariants(variants.Dir())
 	
 	return vm
 }
 
+// LoadExternalVariants adds the valid variant definitions found in dir. Invalid
+// definitions are logged and skipped so one broken file does not block the rest.
+func (vm *VariantManager) LoadExternalVariants(dir string) {
+	definitions, errs := variants.LoadDir(dir)
+	for _, err := range errs {
+		vm.logger.Warnf("Skipping external variant: %v", err)
+	}
+	for _, definition := range definitions {
+		vm.variants[definition.Name] = definition
+	}
+	if len(definitions) > 0 {
+		vm.logger.Debugf("Loaded %d external variants from %s", len(definitions), dir)
+	}
+}
+
+// IsExternal reports whether a variant comes from an external definition file
+func (vm *VariantManager) IsExternal(variant string) bool {
+	definition, exists := vm.variants[variant]
+	return exists && definition.Source != ""
+}
+
 // ValidateVariant checks if a variant name is valid and supported
 func (vm *VariantManager) ValidateVariant(variant string) error {
 	if variant == "" {
@@ -55,11 +79,11 @@ func (vm *VariantManager) GetVariantDefinition(variant string) (*pkg.VariantDefi
 
 // GetAvailableVariants returns a list of all available variant names
 func (vm *VariantManager) GetAvailableVariants() []string {
-	variants := make([]string, 0, len(vm.variants))
+	names := make([]string, 0, len(vm.variants))
 	for name := range vm.variants {
-		variants = append(variants, name)
+		names = append(names, name)
 	}
-	return variants
+	return names
 }
 
 // GetVariantDescription returns a human-readable description of a variant
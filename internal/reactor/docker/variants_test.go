@@ -1,10 +1,13 @@
 package docker
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	
 	"claude-reactor/pkg"
 )
@@ -375,4 +378,23 @@ func BenchmarkVariantManager_GetVariantDefinition(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = vm.GetVariantDefinition("full")
 	}
-}
\ No newline at end of file
+}
+func TestVariantManager_LoadExternalVariants(t *testing.T) {
+	mockLogger := &MockLogger{}
+	mockLogger.On("Debugf", mock.AnythingOfType("string"), mock.Anything).Return()
+	mockLogger.On("Warnf", mock.AnythingOfType("string"), mock.Anything).Return()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zig.yaml"), []byte("name: zig\ndescription: Zig toolchain\nimage: ghcr.io/acme/zig-dev\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.yaml"), []byte("name: base\nimage: debian\n"), 0644))
+
+	vm := NewVariantManager(mockLogger)
+	vm.LoadExternalVariants(dir)
+
+	assert.NoError(t, vm.ValidateVariant("zig"))
+	assert.Equal(t, "Zig toolchain", vm.GetVariantDescription("zig"))
+	assert.True(t, vm.IsExternal("zig"))
+	assert.False(t, vm.IsExternal("base"))
+	assert.Equal(t, "debian:bullseye-slim", vm.variants["base"].BaseImage, "built-in variants cannot be replaced")
+	mockLogger.AssertCalled(t, "Warnf", mock.AnythingOfType("string"), mock.Anything)
+}
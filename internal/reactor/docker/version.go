@@ -0,0 +1,143 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VersionLabel records the claude-reactor version an image was built by
+const VersionLabel = "claude-reactor.version"
+
+// maxMinorSkew is how many minor versions an image may be ahead of or behind the CLI before
+// a skew warning is shown
+const maxMinorSkew = 3
+
+// cliVersion is the running claude-reactor version, stamped into built images
+var cliVersion = "dev"
+
+// SetVersion sets the claude-reactor version stamped into built images and compared against
+// pulled ones
+func SetVersion(version string) {
+	cliVersion = version
+}
+
+// version is a parsed release version; pre-release and build suffixes are ignored
+type version struct {
+	major, minor, patch int
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+func (v version) less(other version) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+	return v.patch < other.patch
+}
+
+// parseVersion parses v1.2.3 or 1.2 style versions. Development builds ("dev", commit
+// hashes) are not versions and return false.
+func parseVersion(s string) (version, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return version{}, false
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		numbers[i] = n
+	}
+	return version{numbers[0], numbers[1], numbers[2]}, true
+}
+
+// imageContract is a change to what the CLI expects of an image (entrypoint behaviour,
+// users, paths). Images built before a contract cannot be relied on by CLIs from it onwards.
+type imageContract struct {
+	since  version
+	change string
+}
+
+// imageContracts is the compatibility matrix, oldest first
+var imageContracts = []imageContract{
+	{since: version{0, 1, 0}, change: "entrypoint.sh runs commands as the claude user and proxies the host Docker socket to /home/claude/docker.sock"},
+}
+
+// contractIndex returns the contract in force for a version
+func contractIndex(v version) int {
+	index := -1
+	for i, contract := range imageContracts {
+		if !v.less(contract.since) {
+			index = i
+		}
+	}
+	return index
+}
+
+// versionSkewWarnings compares the version an image was built by with the running CLI.
+// Nothing is reported when either is a development build.
+func versionSkewWarnings(imageVersion, runningVersion string) []string {
+	image, ok := parseVersion(imageVersion)
+	if !ok {
+		return nil
+	}
+	running, ok := parseVersion(runningVersion)
+	if !ok {
+		return nil
+	}
+
+	var warnings []string
+	older, newer := image, running
+	relation := "older"
+	if running.less(image) {
+		older, newer = running, image
+		relation = "newer"
+	}
+	if newer.major != older.major || newer.minor-older.minor > maxMinorSkew {
+		warnings = append(warnings, fmt.Sprintf("image was built by claude-reactor %s, which is much %s than this claude-reactor (%s)", image, relation, running))
+	}
+
+	from, to := contractIndex(older), contractIndex(newer)
+	for i := from + 1; i <= to; i++ {
+		warnings = append(warnings, fmt.Sprintf("since %s: %s", imageContracts[i].since, imageContracts[i].change))
+	}
+	return warnings
+}
+
+// checkImageVersion warns when an image was built by a claude-reactor version that this one
+// may not work with
+func (m *manager) checkImageVersion(ctx context.Context, imageName string) {
+	inspect, err := m.client.ImageInspect(ctx, imageName)
+	if err != nil || inspect.Config == nil {
+		m.logger.Debugf("Could not read version label of %s: %v", imageName, err)
+		return
+	}
+	imageVersion := inspect.Config.Labels[VersionLabel]
+	if imageVersion == "" {
+		m.logger.Debugf("Image %s has no %s label", imageName, VersionLabel)
+		return
+	}
+
+	warnings := versionSkewWarnings(imageVersion, cliVersion)
+	if len(warnings) == 0 {
+		return
+	}
+	m.logger.Warnf("⚠️ Image %s may not be compatible with this claude-reactor:", imageName)
+	for _, warning := range warnings {
+		m.logger.Warnf("   • %s", warning)
+	}
+	m.logger.Warnf("💡 Pull a matching image with CLAUDE_REACTOR_TAG=%s, or build locally with --dev", cliVersion)
+}
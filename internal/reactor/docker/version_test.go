@@ -0,0 +1,105 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// labeledImageClient inspects every image as having the given labels
+type labeledImageClient struct {
+	client.APIClient
+	labels map[string]string
+}
+
+func (c *labeledImageClient) ImageInspect(ctx context.Context, imageID string, opts ...client.ImageInspectOption) (image.InspectResponse, error) {
+	return image.InspectResponse{
+		Config: &dockerspec.DockerOCIImageConfig{ImageConfig: ocispec.ImageConfig{Labels: c.labels}},
+	}, nil
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected version
+		ok       bool
+	}{
+		{"v1.2.3", version{1, 2, 3}, true},
+		{"0.4", version{0, 4, 0}, true},
+		{"v2.0.0-rc.1", version{2, 0, 0}, true},
+		{"1.2.3+build.5", version{1, 2, 3}, true},
+		{"dev", version{}, false},
+		{"main", version{}, false},
+		{"v1", version{}, false},
+		{"1.x.0", version{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			v, ok := parseVersion(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestVersionSkewWarnings(t *testing.T) {
+	t.Run("development builds are not compared", func(t *testing.T) {
+		assert.Empty(t, versionSkewWarnings("dev", "v0.1.0"))
+		assert.Empty(t, versionSkewWarnings("v0.1.0", "dev"))
+	})
+
+	t.Run("nearby versions are compatible", func(t *testing.T) {
+		assert.Empty(t, versionSkewWarnings("v0.1.0", "v0.3.2"))
+		assert.Empty(t, versionSkewWarnings("v0.4.0", "v0.1.0"))
+	})
+
+	t.Run("large skew is reported in both directions", func(t *testing.T) {
+		warnings := versionSkewWarnings("v0.1.0", "v0.9.0")
+		assert.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "much older")
+
+		warnings = versionSkewWarnings("v2.0.0", "v1.9.0")
+		assert.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "much newer")
+	})
+
+	t.Run("contract changes between versions are listed", func(t *testing.T) {
+		warnings := versionSkewWarnings("v0.0.9", "v0.1.0")
+		assert.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "since v0.1.0")
+		assert.Contains(t, warnings[0], "entrypoint.sh")
+	})
+}
+
+func TestManager_CheckImageVersion(t *testing.T) {
+	previous := cliVersion
+	t.Cleanup(func() { SetVersion(previous) })
+	SetVersion("v1.5.0")
+
+	t.Run("skewed image warns with a hint", func(t *testing.T) {
+		logger := &MockLogger{}
+		logger.On("Warnf", mock.Anything, mock.Anything).Return()
+		m := &manager{client: &labeledImageClient{labels: map[string]string{VersionLabel: "v0.1.0"}}, logger: logger}
+
+		m.checkImageVersion(context.Background(), "claude-reactor-go-amd64")
+
+		logger.AssertCalled(t, "Warnf", "💡 Pull a matching image with CLAUDE_REACTOR_TAG=%s, or build locally with --dev", []interface{}{"v1.5.0"})
+	})
+
+	t.Run("unlabeled image is only logged at debug level", func(t *testing.T) {
+		logger := &MockLogger{}
+		logger.On("Debugf", mock.Anything, mock.Anything).Return()
+		m := &manager{client: &labeledImageClient{}, logger: logger}
+
+		m.checkImageVersion(context.Background(), "claude-reactor-go-amd64")
+
+		logger.AssertNotCalled(t, "Warnf", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"os"
+	"runtime"
+	"strings"
+
+	"claude-reactor/internal/reactor/wsl"
+)
+
+// defaultWindowsPipe is where Docker Desktop serves its API on Windows hosts
+const defaultWindowsPipe = "npipe:////./pipe/docker_engine"
+
+// HostPathForDocker converts a host path to the form the Docker daemon expects as a bind
+// mount source. Docker Desktop on Windows runs containers in a WSL 2 or Hyper-V VM and
+// shares drives into it, so C:\Users\me\project becomes /c/Users/me/project.
+func HostPathForDocker(path string) string {
+	return translateHostPath(path, runtime.GOOS)
+}
+
+func translateHostPath(path, goos string) string {
+	if goos != "windows" {
+		return path
+	}
+
+	slashed := strings.ReplaceAll(path, `\`, "/")
+	if len(slashed) >= 2 && slashed[1] == ':' && isDriveLetter(slashed[0]) {
+		rest := slashed[2:]
+		if !strings.HasPrefix(rest, "/") {
+			rest = "/" + rest
+		}
+		return "/" + strings.ToLower(slashed[:1]) + rest
+	}
+	// UNC paths (\\server\share) are passed through as //server/share
+	return slashed
+}
+
+func isDriveLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// normalizeDockerHost turns a bare Windows pipe path such as \\.\pipe\docker_engine, which
+// is easy to copy from Docker Desktop, into the npipe:// URL the client requires
+func normalizeDockerHost(host string) string {
+	slashed := strings.ReplaceAll(host, `\`, "/")
+	if strings.HasPrefix(slashed, "//./pipe/") {
+		return "npipe://" + slashed
+	}
+	return host
+}
+
+// dockerConnectSteps explains how to reach the daemon on this host; nil leaves the general
+// steps of the error catalog
+func dockerConnectSteps() []string {
+	if env := wsl.Detect(); env != nil && !env.DockerDesktop {
+		return []string{"Enable Docker Desktop's WSL integration for this distribution (Settings → Resources → WSL integration)"}
+	}
+	if runtime.GOOS != "windows" {
+		return nil
+	}
+	host := os.Getenv("DOCKER_HOST")
+	if host == "" {
+		host = defaultWindowsPipe
+	}
+	return []string{"Is Docker Desktop running? Connecting via " + host + " (set DOCKER_HOST to override)"}
+}
@@ -0,0 +1,38 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateHostPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		goos string
+		want string
+	}{
+		{"unix unchanged", "/home/me/project", "linux", "/home/me/project"},
+		{"darwin unchanged", "/Users/me/project", "darwin", "/Users/me/project"},
+		{"drive path", `C:\Users\me\project`, "windows", "/c/Users/me/project"},
+		{"lowercase drive", `d:\work`, "windows", "/d/work"},
+		{"drive root", `C:\`, "windows", "/c/"},
+		{"forward slashes", "C:/Users/me", "windows", "/c/Users/me"},
+		{"unc path", `\\server\share\project`, "windows", "//server/share/project"},
+		{"container socket", "/var/run/docker.sock", "windows", "/var/run/docker.sock"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, translateHostPath(tt.path, tt.goos))
+		})
+	}
+}
+
+func TestNormalizeDockerHost(t *testing.T) {
+	assert.Equal(t, "npipe:////./pipe/docker_engine", normalizeDockerHost(`\\.\pipe\docker_engine`))
+	assert.Equal(t, "npipe:////./pipe/docker_engine", normalizeDockerHost("npipe:////./pipe/docker_engine"))
+	assert.Equal(t, "unix:///var/run/docker.sock", normalizeDockerHost("unix:///var/run/docker.sock"))
+	assert.Equal(t, "tcp://localhost:2375", normalizeDockerHost("tcp://localhost:2375"))
+}
@@ -0,0 +1,86 @@
+// Package dotfiles applies a user's dotfiles in project containers, as devcontainers and
+// Codespaces do: the dotfiles repository is cloned (or a local directory mounted) into the
+// container home, then its install command is run, or its dotfiles are linked into the home
+// when it has none.
+package dotfiles
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Target is where the dotfiles repository is cloned or mounted in the container
+const Target = "/home/claude/.dotfiles"
+
+// marker records which dotfiles were applied in the container, so a reused container is only
+// set up again when the dotfiles configuration changes
+const marker = "/home/claude/.dotfiles-applied"
+
+// InstallScripts are the scripts run from the repository root, in order of preference, when no
+// install command is configured. They are the ones Codespaces looks for.
+var InstallScripts = []string{"install.sh", "install", "bootstrap.sh", "bootstrap", "script/bootstrap", "setup.sh", "setup", "script/setup"}
+
+// scpLikeURL matches git's scp-like syntax for SSH remotes, such as git@github.com:me/dotfiles
+var scpLikeURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:`)
+
+// IsRemote reports whether source is a git URL to clone rather than a local directory to mount
+func IsRemote(source string) bool {
+	return strings.Contains(source, "://") || scpLikeURL.MatchString(source)
+}
+
+// fingerprint identifies a dotfiles configuration in the container's marker
+func fingerprint(source, install string) string {
+	sum := sha256.Sum256([]byte(source + "\n" + install))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// AppliedCommand exits 0 when the container already has these dotfiles applied
+func AppliedCommand(source, install string) []string {
+	return []string{"sh", "-c", fmt.Sprintf(`[ "$(cat %s 2>/dev/null)" = %s ]`, marker, fingerprint(source, install))}
+}
+
+// applyScript clones a remote source ($1 when $4 is set), then runs the install command ($2) or
+// the first install script found, or links the repository's dotfiles into the home. The Claude
+// session files mounted in the home are never replaced. The fingerprint ($3) is recorded last,
+// so a failed install is retried on the next start.
+const applyScript = `set -e
+if [ -n "$4" ]; then
+	rm -rf %[1]s
+	git clone --quiet --depth 1 -- "$1" %[1]s
+fi
+cd %[1]s
+if [ -n "$2" ]; then
+	sh -c "$2"
+else
+	found=
+	for script in %[2]s; do
+		[ -f "$script" ] || continue
+		found=1
+		if [ -x "$script" ]; then "./$script"; else bash "$script"; fi
+		break
+	done
+	if [ -z "$found" ]; then
+		for file in .[!.]*; do
+			case "$file" in
+			.git|.gitignore|.gitmodules|.github|.claude|.claude.json) continue ;;
+			esac
+			if [ -e "$file" ]; then ln -sfn "$PWD/$file" "$HOME/$file"; fi
+		done
+	fi
+fi
+echo "$3" > %[3]s
+`
+
+// ApplyCommand applies the dotfiles from source, cloned into Target when it is remote and
+// mounted there otherwise, running install from the repository root
+func ApplyCommand(source, install string) []string {
+	remote := ""
+	if IsRemote(source) {
+		remote = "1"
+	}
+	script := fmt.Sprintf(applyScript, Target, strings.Join(InstallScripts, " "), marker)
+	return []string{"sh", "-c", script, "sh", source, install, fingerprint(source, install), remote}
+}
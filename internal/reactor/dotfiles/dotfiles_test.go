@@ -0,0 +1,94 @@
+package dotfiles
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRemote(t *testing.T) {
+	for _, source := range []string{"https://github.com/me/dotfiles", "ssh://git@github.com/me/dotfiles.git", "git@github.com:me/dotfiles.git", "file:///srv/dotfiles"} {
+		assert.True(t, IsRemote(source), source)
+	}
+	for _, source := range []string{"~/dotfiles", "/home/me/dotfiles", "dotfiles", "C:/Users/me/dotfiles"} {
+		assert.False(t, IsRemote(source), source)
+	}
+}
+
+func TestAppliedCommand(t *testing.T) {
+	command := AppliedCommand("https://github.com/me/dotfiles", "")
+	assert.Equal(t, command, AppliedCommand("https://github.com/me/dotfiles", ""))
+	// A new source or install command applies the dotfiles again
+	assert.NotEqual(t, command, AppliedCommand("https://github.com/me/other", ""))
+	assert.NotEqual(t, command, AppliedCommand("https://github.com/me/dotfiles", "make install"))
+}
+
+// runApply runs ApplyCommand's script with the container paths moved under home
+func runApply(t *testing.T, home, source, install string) string {
+	t.Helper()
+	command := ApplyCommand(source, install)
+	command[2] = strings.NewReplacer(marker, filepath.Join(home, ".dotfiles-applied"), Target, filepath.Join(home, ".dotfiles")).Replace(command[2])
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), "HOME="+home)
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return string(output)
+}
+
+func TestApplyCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("applied in Linux containers")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".bashrc"), []byte("alias ll='ls -l'\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".claude.json"), []byte("{}"), 0644))
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "dotfiles")
+
+	t.Run("links dotfiles without an install script", func(t *testing.T) {
+		home := t.TempDir()
+		runApply(t, home, "file://"+repo, "")
+
+		link, err := os.Readlink(filepath.Join(home, ".bashrc"))
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(home, ".dotfiles", ".bashrc"), link)
+		// Claude's session files are never replaced
+		assert.NoFileExists(t, filepath.Join(home, ".claude.json"))
+		assert.FileExists(t, filepath.Join(home, ".dotfiles-applied"))
+	})
+
+	t.Run("runs the install command", func(t *testing.T) {
+		home := t.TempDir()
+		runApply(t, home, "file://"+repo, `cp .bashrc "$HOME/.bash_aliases"`)
+
+		assert.FileExists(t, filepath.Join(home, ".bash_aliases"))
+		assert.NoFileExists(t, filepath.Join(home, ".bashrc"))
+	})
+
+	t.Run("runs an install script", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "install.sh"), []byte(`echo installed > "$HOME/installed"`+"\n"), 0644))
+		git("add", "-A")
+		git("commit", "-q", "-m", "install script")
+		home := t.TempDir()
+		runApply(t, home, "file://"+repo, "")
+
+		assert.FileExists(t, filepath.Join(home, "installed"))
+		assert.NoFileExists(t, filepath.Join(home, ".bashrc"))
+	})
+}
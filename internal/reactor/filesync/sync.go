@@ -0,0 +1,109 @@
+package filesync
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"claude-reactor/pkg"
+)
+
+// IgnoreFile is the project file listing extra sync ignore patterns, one per line
+const IgnoreFile = ".claude-reactor-syncignore"
+
+// defaultIgnores are large, container-generated directories that stay in the volume only
+var defaultIgnores = []string{
+	"node_modules",
+	"target",
+	".venv",
+	"__pycache__",
+	".next",
+	"dist",
+	"build",
+}
+
+// Available reports whether the mutagen binary is installed on the host
+func Available() bool {
+	_, err := exec.LookPath("mutagen")
+	return err == nil
+}
+
+// VolumeName returns the named volume that holds the synced project files
+func VolumeName(containerName string) string {
+	return containerName + "-sync"
+}
+
+// SessionName returns the mutagen session name for a container
+func SessionName(containerName string) string {
+	return containerName
+}
+
+// IgnorePatterns returns the default ignore rules plus any listed in the project's ignore file
+func IgnorePatterns(projectDir string) []string {
+	patterns := append([]string{}, defaultIgnores...)
+
+	file, err := os.Open(filepath.Join(projectDir, IgnoreFile))
+	if err != nil {
+		return patterns
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns
+}
+
+// CreateArgs builds the mutagen arguments for a two-way sync session
+func CreateArgs(projectDir, containerName, target string, ignores []string) []string {
+	args := []string{
+		"sync", "create",
+		"--name", SessionName(containerName),
+		"--sync-mode", "two-way-resolved",
+		"--ignore-vcs",
+	}
+	for _, pattern := range ignores {
+		args = append(args, "--ignore", pattern)
+	}
+	return append(args, projectDir, fmt.Sprintf("docker://%s%s", containerName, target))
+}
+
+// Start creates a two-way mutagen session between the project directory and the container's
+// sync volume, replacing any stale session for the same container, and waits for the
+// initial sync to complete. Keeping files in a volume avoids slow Docker Desktop bind mounts.
+func Start(ctx context.Context, projectDir, containerName, target string) error {
+	if !Available() {
+		return pkg.NewError(pkg.CodeHostToolMissing, nil, "sync mode requires mutagen on the host").
+			WithRemediation("Install it from https://mutagen.io or run without --sync")
+	}
+
+	// A leftover session from a previous run would point at the old container
+	_ = exec.CommandContext(ctx, "mutagen", "sync", "terminate", SessionName(containerName)).Run()
+
+	args := CreateArgs(projectDir, containerName, target, IgnorePatterns(projectDir))
+	if output, err := exec.CommandContext(ctx, "mutagen", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create sync session: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+
+	if output, err := exec.CommandContext(ctx, "mutagen", "sync", "flush", SessionName(containerName)).CombinedOutput(); err != nil {
+		return fmt.Errorf("initial sync failed: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Stop flushes pending changes back to the host and terminates the sync session
+func Stop(ctx context.Context, containerName string) error {
+	_ = exec.CommandContext(ctx, "mutagen", "sync", "flush", SessionName(containerName)).Run()
+	if output, err := exec.CommandContext(ctx, "mutagen", "sync", "terminate", SessionName(containerName)).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to terminate sync session: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
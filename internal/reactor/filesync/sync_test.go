@@ -0,0 +1,50 @@
+package filesync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnorePatterns(t *testing.T) {
+	t.Run("defaults without ignore file", func(t *testing.T) {
+		patterns := IgnorePatterns(t.TempDir())
+
+		assert.Equal(t, defaultIgnores, patterns)
+	})
+
+	t.Run("ignore file patterns are appended", func(t *testing.T) {
+		dir := t.TempDir()
+		content := "# generated assets\ncoverage\n\n*.log\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, IgnoreFile), []byte(content), 0644))
+
+		patterns := IgnorePatterns(dir)
+
+		assert.Contains(t, patterns, "node_modules")
+		assert.Contains(t, patterns, "coverage")
+		assert.Contains(t, patterns, "*.log")
+		assert.NotContains(t, patterns, "# generated assets")
+		assert.Len(t, patterns, len(defaultIgnores)+2)
+	})
+}
+
+func TestCreateArgs(t *testing.T) {
+	args := CreateArgs("/home/user/project", "claude-reactor-go-arm64-abc12345-user", "/app", []string{"node_modules"})
+
+	assert.Equal(t, []string{
+		"sync", "create",
+		"--name", "claude-reactor-go-arm64-abc12345-user",
+		"--sync-mode", "two-way-resolved",
+		"--ignore-vcs",
+		"--ignore", "node_modules",
+		"/home/user/project",
+		"docker://claude-reactor-go-arm64-abc12345-user/app",
+	}, args)
+}
+
+func TestVolumeName(t *testing.T) {
+	assert.Equal(t, "claude-reactor-base-amd64-abc12345-user-sync", VolumeName("claude-reactor-base-amd64-abc12345-user"))
+}
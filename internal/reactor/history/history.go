@@ -0,0 +1,78 @@
+// Package history records the commands run in a project's container with 'claude-reactor exec',
+// so repetitive ones such as tests and linters are quick to repeat. The history is stored in
+// the project's session directory.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileName holds a project's exec history, in its session directory
+const FileName = "exec-history.json"
+
+// MaxEntries is how many commands are kept; older ones are dropped
+const MaxEntries = 200
+
+// Entry is a command run in the project container
+type Entry struct {
+	Command  []string      `json:"command"`
+	Workdir  string        `json:"workdir,omitempty"` // relative to the project root; "" at the root
+	Task     string        `json:"task,omitempty"`    // set when run by 'claude-reactor task'
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	ExitCode int           `json:"exit_code"`
+}
+
+// Load returns the exec history of the project whose session directory is sessionDir, oldest
+// first
+func Load(sessionDir string) ([]Entry, error) {
+	data, err := os.ReadFile(filepath.Join(sessionDir, FileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exec history: %w", err)
+	}
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(sessionDir, FileName), err)
+	}
+	return entries, nil
+}
+
+// Record appends entry to the project's exec history, keeping the newest MaxEntries
+func Record(sessionDir string, entry Entry) error {
+	entries, err := Load(sessionDir)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	if len(entries) > MaxEntries {
+		entries = entries[len(entries)-MaxEntries:]
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode exec history: %w", err)
+	}
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	return os.WriteFile(filepath.Join(sessionDir, FileName), data, 0644)
+}
+
+// Last returns the most recent command in the project's exec history
+func Last(sessionDir string) (*Entry, error) {
+	entries, err := Load(sessionDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no commands have been run in this project yet")
+	}
+	return &entries[len(entries)-1], nil
+}
@@ -0,0 +1,49 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+
+	entries, err := Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	_, err = Last(dir)
+	assert.ErrorContains(t, err, "no commands")
+
+	started := time.Now().Truncate(time.Second)
+	require.NoError(t, Record(dir, Entry{Command: []string{"go", "test", "./..."}, Started: started, Duration: 3 * time.Second}))
+	require.NoError(t, Record(dir, Entry{Command: []string{"golangci-lint", "run"}, Workdir: "api", Started: started.Add(time.Minute), ExitCode: 1}))
+
+	entries, err = Load(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, []string{"go", "test", "./..."}, entries[0].Command)
+	assert.Equal(t, 3*time.Second, entries[0].Duration)
+	assert.True(t, started.Equal(entries[0].Started))
+
+	last, err := Last(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"golangci-lint", "run"}, last.Command)
+	assert.Equal(t, "api", last.Workdir)
+	assert.Equal(t, 1, last.ExitCode)
+}
+
+func TestRecordKeepsNewest(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < MaxEntries+5; i++ {
+		require.NoError(t, Record(dir, Entry{Command: []string{"echo", string(rune('a' + i%26))}, ExitCode: i}))
+	}
+
+	entries, err := Load(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, MaxEntries)
+	assert.Equal(t, 5, entries[0].ExitCode)
+	assert.Equal(t, MaxEntries+4, entries[len(entries)-1].ExitCode)
+}
@@ -0,0 +1,366 @@
+package i18n
+
+// en is the English catalog. Every message ID is defined here; other languages may leave
+// messages out, which are then shown in English.
+var en = map[string]string{
+	"root.short": "A simple, safe way to run Claude CLI in Docker containers with account isolation",
+	"root.long": `Claude-Reactor provides a secure way to run Claude CLI in Docker containers
+with proper account isolation. It offers multiple pre-built container variants
+for different development needs while maintaining security and simplicity.`,
+
+	"flag.debug":     "Enable debug mode",
+	"flag.verbose":   "Enable verbose output",
+	"flag.log_level": "Set log level (debug, info, warn, error)",
+	"flag.lang":      "Language of messages: en, ja (default from LANG)",
+	"flag.no_emoji":  "Print output without emoji",
+
+	"removed.list_variants": "❌ The --list-variants flag has been removed. Use:",
+	"removed.show_config":   "❌ The --show-config flag has been removed. Use:",
+	"removed.variant":       "❌ The --variant flag has been removed. Use:",
+
+	"run.existing_config":          "🚀 Found existing configuration, running container...",
+	"run.failed":                   "Run failed: %v",
+	"run.working_directory":        "📂 Working directory: %s",
+	"run.command":                  "Running command in container: %s",
+	"run.launching_shell":          "🐚 Launching interactive shell in container...",
+	"run.shell_hint":               "💡 Type 'claude' to start Claude CLI, or 'exit' to leave the container",
+	"run.launching_danger":         "🤖 Launching Claude CLI in DANGER MODE...",
+	"run.danger_warning":           "⚠️  Danger mode bypasses permission checks - use with caution!",
+	"run.launching_claude":         "🤖 Launching Claude CLI in container...",
+	"run.conversation":             "💬 Conversation '%s' (session %s)",
+	"run.resuming_session":         "💬 Resuming session %s",
+	"run.clipboard_active":         "📋 Clipboard bridge active - use 'cr-copy' in the container to copy to the host",
+	"run.detachable":               "🪟 Running in %s session '%s' - reattach with: claude-reactor session attach",
+	"run.sync_stop_failed":         "Failed to stop file sync: %v",
+	"run.stopping":                 "🧹 Stopping container due to --persist=false...",
+	"run.stop_failed":              "Failed to stop container: %v",
+	"run.remains_running":          "💾 Container will remain running (use 'claude-reactor clean' to stop)",
+	"run.starting":                 "🚀 Starting Claude CLI container...",
+	"run.loading_configuration":    "📋 Loading configuration...",
+	"run.account_config_failed":    "Failed to ensure account config exists: %v",
+	"run.danger_enabled":           "🔥 Danger mode enabled and will be persisted",
+	"run.danger_disabled":          "🛡️  Danger mode disabled and will be persisted",
+	"run.danger_persistent":        "🔥 Using persistent danger mode setting",
+	"run.host_docker_enabled":      "🐳 Host Docker access enabled and will be persisted",
+	"run.host_docker_disabled":     "🔒 Host Docker access disabled and will be persisted",
+	"run.host_docker_persistent":   "🐳 Using persistent host Docker setting",
+	"run.sync_enabled":             "🔄 Sync mode enabled and will be persisted",
+	"run.sync_disabled":            "📁 Sync mode disabled and will be persisted",
+	"run.sync_persistent":          "🔄 Using persistent sync mode setting",
+	"run.windows_drive":            "⚠️  %s is on a Windows drive; file access from the container will be slow",
+	"run.windows_drive_hint":       "💡 Move it to the WSL filesystem with: claude-reactor wsl relocate",
+	"run.platform_persisted":       "🖥️  Platform %s will be persisted",
+	"run.host_platform_persisted":  "🖥️  Host platform will be persisted",
+	"run.platform_persistent":      "🖥️  Using persistent platform setting: %s",
+	"run.clipboard_enabled":        "📋 Clipboard bridge enabled and will be persisted",
+	"run.clipboard_disabled":       "📋 Clipboard bridge disabled and will be persisted",
+	"run.ssh_detected":             "🔑 SSH agent auto-detected and will be persisted",
+	"run.ssh_socket":               "🔑 SSH agent socket specified: %s (will be persisted)",
+	"run.ssh_valid":                "✅ SSH agent validation passed",
+	"run.ssh_redetect_failed":      "Failed to re-detect SSH agent, disabling: %v",
+	"run.ssh_persistent_detected":  "🔑 Using persistent SSH agent setting (auto-detected)",
+	"run.ssh_persistent":           "🔑 Using persistent SSH agent setting: %s",
+	"run.ssh_persistent_invalid":   "Persistent SSH agent socket invalid, disabling: %v",
+	"run.api_key":                  "🔑 Setting up API key for account: %s",
+	"run.api_key_done":             "✅ API key authentication configured",
+	"run.interactive_login":        "🔐 Forcing interactive login for account: %s",
+	"run.detecting":                "🔍 Auto-detecting project type...",
+	"run.detect_failed":            "Failed to auto-detect image: %v",
+	"run.detect_default":           "💡 Defaulting to 'base' image. Use --image flag to specify manually",
+	"run.detected":                 "✅ Auto-detected image: %s",
+	"run.validating_configuration": "✅ Validating configuration...",
+	"run.validating_custom_image":  "🔍 Validating custom Docker image: %s (compatibility + package analysis)",
+	"run.custom_image_invalid":     "❌ Custom image validation failed:",
+	"run.custom_image_warnings":    "⚠️ Custom image warnings:",
+	"run.package_analysis":         "📦 Package analysis: %d/%d recommended tools available",
+	"run.claude_config":            "🔑 Claude config: %s",
+	"run.detecting_architecture":   "🔧 Detecting system architecture...",
+	"run.container_name":           "🏷️ Container name: %s",
+	"run.local_image":              "✅ Found local image: %s",
+	"run.registry_image":           "📦 Local image '%s' not found, using registry: %s",
+	"run.preparing_docker":         "🐳 Preparing Docker environment...",
+	"run.docker_timeout":           "🕒 Docker operations timeout set to: %s",
+	"run.configuring_mounts":       "📁 Configuring container mounts...",
+	"run.sync_secret_files":        "⚠️  The project is copied into a volume, so secret files can't be hidden; list them in %s instead",
+	"run.save_failed":              "Failed to save configuration: %v",
+	"run.save_session_failed":      "Failed to save session configuration: %v",
+	"run.started":                  "✅ Container started successfully!",
+	"run.syncing":                  "🔄 Syncing project files into container volume...",
+	"run.sync_active":              "✅ Two-way sync active",
+	"run.project_volume":           "🔄 Project volume: %s -> %s (synced from %s)",
+	"run.project_sandbox":          "🧪 Project sandbox: %s -> %s (copied from %s)",
+	"run.project_mount":            "📁 Project mount: %s -> %s",
+	"run.git_directory":            "🌿 Repository git directory: %s",
+	"run.protected":                "🔒 Protected: %s",
+	"run.protected_missing":        "⚠️  Protected path %s does not exist, so the session could create it",
+	"run.session_dir_failed":       "Failed to create Claude session directory: %v",
+	"run.session_mount_failed":     "Failed to add Claude session mount: %v",
+	"run.session_mount":            "📁 Claude session mount: %s -> /home/claude/.claude",
+	"run.history_dir_failed":       "Failed to create shell history directory: %v",
+	"run.read_only":                "🔒 Project is read-only; Claude can write to %s (on the host: %s)",
+	"run.project_config_failed":    "Failed to create project-specific .claude.json: %v",
+	"run.project_config_created":   "📄 Created project-specific config: %s",
+	"run.account_template_failed":  "Failed to read account config template: %v",
+	"run.config_mount_failed":      "Failed to add project Claude config mount: %v",
+	"run.config_mount":             "🔑 Claude config mount: %s -> /home/claude/.claude.json",
+	"run.credentials_mount_failed": "Failed to add credentials mount: %v",
+	"run.credentials_mount":        "🔐 Credentials mount: %s -> /home/claude/.claude/.credentials.json",
+	"run.docker_socket_desktop":    "🐳 Host Docker socket mount: Docker Desktop -> %s",
+	"run.docker_socket":            "🐳 Host Docker socket mount: %s -> /var/run/docker.sock",
+	"run.ssh_mount_failed":         "Failed to add SSH mount %s -> %s: %v",
+	"run.ssh_mount":                "🔑 SSH mount: %s -> %s",
+	"run.ssh_configured":           "🔑 SSH keys configured for Git operations",
+	"run.subagents_dir_failed":     "Failed to create session subagents directory: %v",
+	"run.subagents_mount_failed":   "Failed to add global subagents mount: %v",
+	"run.subagents_mount":          "🤖 Global subagents mount: %s -> /home/claude/.claude/agents",
+	"run.project_subagents":        "🤖 Project subagents detected: %s (available via project mount)",
+	"run.added_mount":              "📁 Added mount: %s -> %s%s",
+	"run.added_tmpfs":              "📁 Added tmpfs mount: %s",
+	"run.settings_failed":          "Failed to copy Claude settings: %v",
+	"run.claude_settings":          "⚙️  Claude settings: %s",
+	"run.mcp_read_failed":          "Failed to read managed MCP servers: %v",
+	"run.mcp_copy_failed":          "Failed to copy MCP servers: %v",
+	"run.mcp_servers":              "🔌 MCP servers: %s",
+	"run.system_prompt_failed":     "⚠️  System prompt not refreshed: %v",
+	"run.system_prompt":            "📜 System prompt: %s",
+	"run.waiting_pid":              "⏳ Another claude-reactor (pid %s) is starting this project; waiting for it to finish...",
+	"run.waiting":                  "⏳ Another claude-reactor is starting this project; waiting for it to finish...",
+	"run.reusing":                  "♻️ Reusing existing container (%s)",
+	"run.starting_precreated":      "⚡ Starting pre-created container (%s)...",
+	"run.recreating":               "🔁 Recreating container (%s)...",
+	"run.starting_persistent":      "🔄 Starting/Resuming container with session persistence...",
+	"run.starting_ephemeral":       "🏗️ Starting ephemeral container...",
+	"run.verifying_signature":      "🔏 Verifying signature of %s...",
+	"run.signature_failed":         "⚠️  Signature verification failed, continuing: %v",
+	"run.signature_verified":       "✅ Signature verified: %s",
+	"run.mirror_default":           "%v; using the default",
+	"run.mirror_direct":            "%v; pulling directly from %s",
+	"run.variant_image":            "📦 Using image %s for variant %s",
+	"run.building_variant":         "🔨 Building variant %s from %s...",
+	"run.host_docker_warning":      "⚠️  WARNING: HOST DOCKER ACCESS ENABLED",
+	"run.host_docker_grants":       "🔒 This grants claude-reactor container HOST-LEVEL Docker privileges:",
+	"run.host_docker_containers":   "   • Can create/manage ANY container on the host",
+	"run.host_docker_directories":  "   • Can mount/access ANY host directory",
+	"run.host_docker_network":      "   • Can access host network and other containers",
+	"run.host_docker_root":         "   • Equivalent to ROOT access on the host system",
+	"run.host_docker_unlimited":    "⏰ Docker operations: UNLIMITED TIMEOUT (no timeout protection)",
+	"run.host_docker_hint":         "💡 Only enable for trusted workflows requiring Docker management",
+	"run.toolchain_missing":        "⚠️  %s pins %s %s but the image has no %s toolchain",
+	"run.toolchain_mismatch":       "⚠️  %s pins %s %s but the image provides %s",
+	"run.toolchain_install_hint":   "💡 Enable automatic installation with: claude-reactor config set toolchain_install true",
+	"run.toolchain_installing":     "🧰 Installing %s %s...",
+	"run.toolchain_install_failed": "Failed to install %s %s: %s",
+	"run.toolchain_manager_hint":   "💡 Use an image with mise or asdf installed, or pick an image with the required version via --image",
+	"run.toolchain_installed":      "✅ Installed %s %s",
+	"run.scanning":                 "🛡️  Scanning image for vulnerabilities (threshold: %s)...",
+	"run.scan_failed_allowed":      "⚠️  Vulnerability scan failed (allowed by --allow-vulnerable): %v",
+	"run.scan_clean":               "✅ No %s-or-higher vulnerabilities found (%s)",
+	"run.vulnerable_allowed":       "⚠️  Image has %d vulnerabilities at %s or above (allowed by --allow-vulnerable)",
+	"run.custom_image_valid":       "✅ Custom image validated successfully: %s (digest: %.12s)",
+	"run.configuration_sessions":   "📋 Configuration: image=%s, account=%s, danger=%t, shell=%t, persist=%t, session_persistence=%t",
+	"run.configuration":            "📋 Configuration: image=%s, account=%s, danger=%t, shell=%t, persist=%t",
+	"run.host_docker_timeout":      "⏰ Docker operations timeout: %s",
+
+	"summary.title":       "📋 Session summary",
+	"summary.image":       "Image:",
+	"summary.pulled":      "pulled when starting",
+	"summary.account":     "Account:",
+	"summary.danger":      "Danger mode:",
+	"summary.host_docker": "Host Docker:",
+	"summary.mounts":      "Mounts:",
+	"summary.outside":     "⚠️  %s (outside the project)",
+	"summary.riskier":     "⚠️  Riskier than the last run in this project:",
+	"summary.confirm":     "Start the session? (y/N): ",
+
+	"stats.empty":        "No images have been built or pulled yet",
+	"stats.history_hint": "💡 List individual builds with: claude-reactor stats --history builds",
+
+	"list.no_directory":       "No ~/.claude-reactor directory found",
+	"list.account_unreadable": "Failed to read account directory %s: %v",
+	"list.empty":              "No projects found in ~/.claude-reactor/",
+	"list.summary":            "Summary: %d accounts, %d projects, %d containers",
+	"list.never":              "never",
+
+	"time.just_now":    "just now",
+	"time.minutes_ago": "%dm ago",
+	"time.hours_ago":   "%dh ago",
+	"time.days_ago":    "%dd ago",
+
+	"prewarm.none":     "No recently used projects to prepare",
+	"prewarm.next":     "💤 Next prewarm in %s (Ctrl+C to stop)",
+	"prewarm.no_cwd":   "Failed to get current directory: %v",
+	"prewarm.project":  "🔥 Prewarming %s (%s)",
+	"prewarm.skipping": "Skipping %s: %v",
+	"prewarm.failed":   "Failed to prewarm %s: %v",
+	"prewarm.done":     "✅ Prewarmed %d of %d projects",
+	"prewarm.running":  "♻️ Container %s is running, leaving it as it is",
+	"prewarm.warm":     "✅ Container %s is already warm",
+	"prewarm.created":  "✅ Pre-created container %s",
+
+	"clean.dry_run":            "🔍 Dry run: nothing was removed",
+	"clean.confirm":            "Do you want to continue? (y/N): ",
+	"clean.cancelled":          "🚫 Cleanup cancelled",
+	"clean.starting":           "🧹 Starting cleanup (level: %s)...",
+	"clean.remove_failed":      "Failed to remove %s %s: %v",
+	"clean.removed":            "✅ Removed %d of %d resources, freeing %s",
+	"clean.done":               "✅ Cleanup completed successfully!",
+	"clean.plan":               "🧹 Cleanup Plan:",
+	"clean.scope_project":      "  📍 Scope: Project %s (account: %s)",
+	"clean.scope_account":      "  📍 Scope: All projects for account %s",
+	"clean.scope_all":          "  📍 Scope: All projects and accounts",
+	"clean.older_than":         "  ⏳ Only resources older than %s",
+	"clean.plan_empty":         "  • No matching containers, volumes, sessions, or images",
+	"clean.running":            " (running)",
+	"clean.plan_remove":        "  • %s Remove %s %s%s — %s",
+	"clean.plan_auth":          "  • 🔑 Remove authentication data (Claude configs, API keys)",
+	"clean.plan_cache":         "  • 🗄️ Clear validation cache",
+	"clean.plan_reclaimable":   "  💽 Reclaimable: %s",
+	"clean.auth_all":           "🔑 Removing all authentication data...",
+	"clean.auth_unreadable":    "Failed to read claude-reactor directory: %v",
+	"clean.auth_file":          "🔑 Removing auth file: %s",
+	"clean.auth_file_failed":   "Failed to remove auth file %s: %v",
+	"clean.auth_all_done":      "✅ All authentication data removed",
+	"clean.auth_account":       "🔑 Removing auth for account: %s",
+	"clean.auth_config_failed": "Failed to remove auth config: %v",
+	"clean.auth_key_failed":    "Failed to remove API key file: %v",
+	"clean.auth_account_done":  "✅ Account authentication data removed",
+	"clean.cache":              "🗄️ Clearing validation cache...",
+	"clean.cache_done":         "✅ Validation cache cleared",
+	"clean.report_empty":       "No claude-reactor containers, volumes, sessions, or images found",
+	"clean.report_up":          "%d (%d up)",
+	"clean.report_images":      "Shared images (%s):",
+	"clean.report_reclaimable": "Reclaimable: %s across %d projects and %d images",
+	"clean.report_hint":        "💡 Preview a cleanup with 'claude-reactor clean --global --dry-run'",
+
+	"info.title":              "=== Claude-Reactor Debug Info ===",
+	"info.arch_failed":        "Failed to detect architecture: %v",
+	"info.host_arch":          "Host Architecture: %s",
+	"info.platform_failed":    "Failed to get Docker platform: %v",
+	"info.docker_platform":    "Docker Platform: %s",
+	"info.multi_arch":         "Multi-arch Support: %t",
+	"info.detect_failed":      "Failed to detect project type: %v",
+	"info.version":            "Version: %s",
+	"info.git_commit":         "Git Commit: %s",
+	"info.build_date":         "Build Date: %s",
+	"info.docker_failed":      "Docker Connection: ❌ Failed (%v)",
+	"info.docker_connected":   "Docker Connection: ✅ Connected",
+	"info.debug_mode":         "Debug Mode: %v",
+	"info.log_level":          "Log Level: %s",
+	"info.log_level_unknown":  "Log Level: unable to determine",
+	"info.timing":             "Startup Timing:",
+	"info.on_demand":          " (on demand)",
+	"info.startup_total":      "startup total",
+	"info.detection_none":     "Project Detection: no project markers found (using %s)",
+	"info.detection":          "Project Detection: %s (confidence %.2f) -> %s image",
+	"info.languages":          "  Languages: %s",
+	"info.frameworks":         "  Frameworks: %s",
+	"info.tools":              "  Tools: %s",
+	"info.missing_toolchains": "  ⚠️  Not included in built-in images: %s (use a custom image)",
+	"info.uncovered_variants": "  ⚠️  No built-in variant includes all of %s; using %s",
+	"info.docker_unavailable": "❌ Docker not available: %v",
+	"info.testing_image":      "🔍 Testing image compatibility: %s",
+	"info.validation_failed":  "❌ Validation failed: %v",
+	"info.image_results":      "=== Image Validation Results ===",
+	"info.image":              "Image: %s",
+	"info.digest":             "Digest: %s",
+	"info.architecture":       "Architecture: %s",
+	"info.platform":           "Platform: %s",
+	"info.size":               "Size: %.2f MB",
+	"info.compatible":         "Compatible: %t",
+	"info.has_claude":         "Has Claude CLI: %t",
+	"info.is_linux":           "Is Linux: %t",
+	"info.warnings":           "⚠️ Warnings:",
+	"info.errors":             "❌ Errors:",
+	"info.packages":           "📦 Package Analysis:",
+	"info.available_tools":    "Available tools (%d): %s",
+	"info.missing_tools":      "Missing high-priority tools: %s",
+	"info.coverage":           "Coverage: %d/%d recommended tools available",
+	"info.image_compatible":   "✅ Image is compatible with claude-reactor!",
+	"info.image_incompatible": "❌ Image is not compatible. See errors above.",
+	"info.scan_unavailable":   "⚠️ Vulnerability scan unavailable: %v",
+	"info.scan":               "🛡️  Vulnerability Scan (%s):",
+	"info.scan_clean":         "  No known vulnerabilities",
+
+	"cache.dir":        "Cache directory: %s",
+	"cache.results":    "Validation results: %d of %d (%.1f KB)",
+	"cache.oldest":     "Oldest result: %s",
+	"cache.newest":     "Newest result: %s",
+	"cache.ttl":        "Result TTL: %s",
+	"cache.images":     "Remembered images: %d (trusted for %s)",
+	"cache.clear_hint": "To clear cache, use: claude-reactor clean --cache",
+	"cache.exported":   "✅ Exported %d validation results to %s",
+	"cache.imported":   "✅ Imported %d validation results",
+
+	"config.load_failed":             "Could not load config: %v",
+	"config.title":                   "📋 Claude-Reactor Configuration",
+	"config.auto_detect":             "auto-detect",
+	"config.none":                    "none",
+	"config.dotfiles_default":        "install script, or linked into the home",
+	"config.any_image":               "any image",
+	"config.unknown":                 "unknown",
+	"config.valid":                   "Configuration is valid ✓",
+	"config.set":                     "Set %s = %s",
+	"config.mirror_invalid":          "%s (invalid: %v)",
+	"config.mirror_unreachable":      "%s (unreachable, pulls fall back to %s)",
+	"config.mirror_healthy":          "%s (healthy)",
+	"config.image_variant":           "🖼️  Image/Variant: %s",
+	"config.account":                 "👤 Account: %s",
+	"config.danger_mode":             "🔥 Danger Mode: %t",
+	"config.host_docker":             "🐳 Host Docker: %t",
+	"config.host_docker_timeout":     "⏰ Host Docker Timeout: %s",
+	"config.ssh_agent":               "🔑 SSH Agent: %t",
+	"config.ssh_socket":              "🔌 SSH Socket: %s",
+	"config.session_persistence":     "💾 Session Persistence: %t",
+	"config.last_session_id":         "🔗 Last Session ID: %s",
+	"config.container_id":            "📦 Container ID: %s",
+	"config.toolchain_install":       "🧰 Toolchain Install: %t",
+	"config.required_tools":          "🧰 Required Tools: %s",
+	"config.recommended_tools":       "🧰 Recommended Tools: %s",
+	"config.tools_install":           "🧰 Tools Install: %t",
+	"config.vulnerability_threshold": "🛡️  Vulnerability Threshold: %s",
+	"config.sync_mode":               "🔄 Sync Mode: %t",
+	"config.clipboard_bridge":        "📋 Clipboard Bridge: %t",
+	"config.platform":                "🖥️  Platform: %s",
+	"config.image_cache_ttl":         "🗄️  Image Cache TTL: %s",
+	"config.image_cache_size":        "🗄️  Image Cache Size: %d",
+	"config.shared_image_cache":      "🤝 Shared Image Cache: %s",
+	"config.claude_cli_version":      "📌 Claude CLI Version: %s",
+	"config.notifications":           "🔔 Notifications: %s",
+	"config.notify_after":            "⏱️  Notify After: %s",
+	"config.registry_mirror":         "🪞 Registry Mirror: %s",
+	"config.claude_args":             "🧩 Claude Args: %s",
+	"config.system_prompt":           "📜 System Prompt: %s",
+	"config.mounts":                  "📁 Mounts: %s",
+	"config.tmpfs":                   "📁 Tmpfs: %s",
+	"config.checkpoints":             "📸 Checkpoints: %s",
+	"config.protected_paths":         "🔒 Protected Paths: %s",
+	"config.secret_scan":             "🕵️  Secret Scan: %s",
+	"config.dns":                     "🌐 DNS: %s",
+	"config.dns_search":              "🌐 DNS Search: %s",
+	"config.extra_hosts":             "🌐 Extra Hosts: %s",
+	"config.dotfiles":                "🏠 Dotfiles: %s (%s)",
+	"config.hostname":                "🏷️  Hostname: %s",
+	"config.prompt":                  "💲 Prompt: %s",
+	"config.secrets":                 "🔐 Secrets: %s (see 'claude-reactor secrets list')",
+	"config.tasks":                   "🧰 Tasks: %s (see 'claude-reactor task list')",
+	"config.anthropic_api_key":       "🔑 ANTHROPIC_API_KEY: %s (passed to containers)",
+	"config.image_signatures":        "🔏 Image Signatures: %d keys, %d identities (required: %t)",
+	"config.policy_tools":            "🧰 Policy Tools: required %s; recommended %s",
+	"config.current_directory":       "📁 Current Directory: %s",
+	"config.configured_project_path": "📂 Configured Project Path: %s",
+	"config.auto_detected":           "🔍 Auto-detected: %s",
+	"config.packages":                "📦 Packages (%s): %s",
+	"config.image_policy_error":      "🛡️  Image Policy: %v",
+	"config.image_policy":            "🛡️  Image Policy: %s (allowed: %s, digest required: %t)",
+
+	"version.short":      "Print version information",
+	"version.version":    "claude-reactor version %s",
+	"version.git_commit": "Git commit: %s",
+	"version.build_date": "Build date: %s",
+	"version.go_version": "Go version: %s",
+	"version.os_arch":    "OS/Arch: %s/%s",
+
+	"error.prefix": "Error: %v",
+	"error.hint":   "💡 %s",
+	"error.docs":   "📖 %s: %s",
+}
@@ -0,0 +1,224 @@
+// Package i18n holds the CLI's user-facing messages in each supported language, picks the
+// language from --lang or the locale environment, and renders messages without emoji for
+// terminals that cannot display them.
+package i18n
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// LangEnv overrides the language detected from the locale environment
+const LangEnv = "CLAUDE_REACTOR_LANG"
+
+// NoEmojiEnv disables emoji in output when set to a non-empty value, like NO_COLOR
+const NoEmojiEnv = "NO_EMOJI"
+
+// DefaultLanguage is used when the locale names no supported language, and for messages
+// missing from another language's catalog
+const DefaultLanguage = "en"
+
+// catalogs maps each supported language to its messages, keyed by message ID
+var catalogs = map[string]map[string]string{
+	"en": en,
+	"ja": ja,
+}
+
+var (
+	mu       sync.RWMutex
+	language = DefaultLanguage
+	plain    bool
+)
+
+// Languages returns the supported languages
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Setup selects the language and output style. An empty lang is detected from the
+// environment; an explicit one must be supported. Emoji are left out when noEmoji is set or
+// the terminal is unlikely to render them.
+func Setup(lang string, noEmoji bool) error {
+	if lang == "" {
+		lang = Detect()
+	} else {
+		parsed := ParseLocale(lang)
+		if _, ok := catalogs[parsed]; !ok {
+			return fmt.Errorf("unsupported language '%s': use %s", lang, strings.Join(Languages(), ", "))
+		}
+		lang = parsed
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	language = lang
+	plain = noEmoji || !emojiCapable()
+	return nil
+}
+
+// Detect returns the supported language named by CLAUDE_REACTOR_LANG, LC_ALL, LC_MESSAGES or
+// LANG, in that order, or DefaultLanguage
+func Detect() string {
+	for _, env := range []string{LangEnv, "LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		// The first locale variable that is set decides, as in the C library
+		if lang := ParseLocale(value); catalogs[lang] != nil {
+			return lang
+		}
+		return DefaultLanguage
+	}
+	return DefaultLanguage
+}
+
+// ParseLocale returns the language part of a locale such as ja_JP.UTF-8 or en-US
+func ParseLocale(locale string) string {
+	lang := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// Language returns the selected language
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return language
+}
+
+// PlainOutput reports whether emoji are left out of output
+func PlainOutput() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return plain
+}
+
+// T returns the message with id in the selected language, formatted with args. Messages
+// missing from the language fall back to English, and unknown IDs are returned unchanged.
+func T(id string, args ...interface{}) string {
+	mu.RLock()
+	lang, noEmoji := language, plain
+	mu.RUnlock()
+
+	message, ok := catalogs[lang][id]
+	if !ok {
+		message, ok = catalogs[DefaultLanguage][id]
+	}
+	if !ok {
+		message = id
+	}
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	if noEmoji {
+		message = plainKeepingSpace(message)
+	}
+	return message
+}
+
+// emojiCapable reports whether the terminal is likely to render emoji: not a dumb terminal,
+// not NO_EMOJI, and a UTF-8 locale when one is set
+func emojiCapable() bool {
+	if os.Getenv(NoEmojiEnv) != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		value = strings.ToLower(value)
+		return strings.Contains(value, "utf-8") || strings.Contains(value, "utf8")
+	}
+	return true
+}
+
+// plainSymbols replaces the symbols that carry meaning with ASCII equivalents; other emoji
+// are decoration and are removed
+var plainSymbols = strings.NewReplacer(
+	"✅", "[ok]",
+	"✓", "[ok]",
+	"❌", "[x]",
+	"✗", "[x]",
+	"⚠️", "[!]",
+	"⚠", "[!]",
+	"💡", "Hint:",
+	"📖", "Docs:",
+	"→", "->",
+	"•", "-",
+)
+
+// Plain returns text without emoji. The space after a removed emoji goes with it, so
+// indentation and column alignment are kept.
+func Plain(text string) string {
+	var b strings.Builder
+	dropSpace := false
+	for _, r := range plainSymbols.Replace(text) {
+		switch {
+		case r == '\u200d' || r == '\ufe0f' || r == '\ufe0e':
+			continue
+		case unicode.Is(unicode.So, r) || r >= 0x1f000:
+			dropSpace = true
+			continue
+		case r == ' ' && dropSpace:
+			dropSpace = false
+			continue
+		}
+		dropSpace = false
+		b.WriteRune(r)
+	}
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Stdout and Stderr write to the process's standard output and error, without emoji while
+// they are off. They look up os.Stdout and os.Stderr on every write, so redirecting those
+// still works.
+var (
+	Stdout io.Writer = plainWriter{func() io.Writer { return os.Stdout }}
+	Stderr io.Writer = plainWriter{func() io.Writer { return os.Stderr }}
+)
+
+// Writer returns w with its output passed through Plain while emoji are off, for writers
+// such as a command's output that are chosen at runtime
+func Writer(w io.Writer) io.Writer {
+	return plainWriter{func() io.Writer { return w }}
+}
+
+// plainWriter applies Plain to what is written to the writer target returns
+type plainWriter struct {
+	target func() io.Writer
+}
+
+func (w plainWriter) Write(p []byte) (int, error) {
+	if !PlainOutput() {
+		return w.target().Write(p)
+	}
+	if _, err := io.WriteString(w.target(), plainKeepingSpace(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// plainKeepingSpace is Plain keeping the text's trailing spaces, such as the one after a
+// question
+func plainKeepingSpace(text string) string {
+	trimmed := strings.TrimRight(text, " ")
+	return Plain(trimmed) + text[len(trimmed):]
+}
@@ -0,0 +1,134 @@
+package i18n
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setLocale clears the environment Setup reads, then sets env
+func setLocale(t *testing.T, env map[string]string) {
+	t.Helper()
+	for _, name := range []string{LangEnv, NoEmojiEnv, "LC_ALL", "LC_MESSAGES", "LC_CTYPE", "LANG", "TERM"} {
+		t.Setenv(name, "")
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+	t.Cleanup(func() {
+		language, plain = DefaultLanguage, false
+	})
+}
+
+func TestParseLocale(t *testing.T) {
+	for locale, expected := range map[string]string{"ja_JP.UTF-8": "ja", "en-US": "en", "JA": "ja", "C": "c", "de@euro": "de"} {
+		assert.Equal(t, expected, ParseLocale(locale), locale)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	setLocale(t, map[string]string{"LANG": "ja_JP.UTF-8"})
+	assert.Equal(t, "ja", Detect())
+
+	// LC_ALL takes precedence, even for an unsupported language
+	t.Setenv("LC_ALL", "fr_FR.UTF-8")
+	assert.Equal(t, DefaultLanguage, Detect())
+
+	t.Setenv(LangEnv, "ja")
+	assert.Equal(t, "ja", Detect())
+
+	setLocale(t, nil)
+	assert.Equal(t, DefaultLanguage, Detect())
+}
+
+func TestSetup(t *testing.T) {
+	setLocale(t, map[string]string{"LANG": "en_US.UTF-8"})
+	require.NoError(t, Setup("ja", false))
+	assert.Equal(t, "ja", Language())
+	assert.Equal(t, "エラー: boom", T("error.prefix", "boom"))
+	assert.False(t, PlainOutput())
+
+	assert.ErrorContains(t, Setup("xx", false), "unsupported language 'xx': use en, ja")
+	assert.Equal(t, "ja", Language())
+}
+
+func TestT(t *testing.T) {
+	setLocale(t, map[string]string{"LANG": "ja_JP.UTF-8"})
+	require.NoError(t, Setup("", false))
+
+	// Messages missing from a catalog fall back to English, unknown IDs to themselves
+	delete(ja, "run.failed")
+	t.Cleanup(func() { ja["run.failed"] = "実行に失敗しました: %v" })
+	assert.Equal(t, "Run failed: boom", T("run.failed", "boom"))
+	assert.Equal(t, "no.such.message", T("no.such.message"))
+}
+
+func TestPlainOutput(t *testing.T) {
+	for name, env := range map[string]map[string]string{
+		"flag":           nil,
+		"NO_EMOJI":       {NoEmojiEnv: "1"},
+		"dumb terminal":  {"TERM": "dumb"},
+		"non-UTF-8 LANG": {"LANG": "C"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			setLocale(t, env)
+			require.NoError(t, Setup("", name == "flag"))
+			assert.True(t, PlainOutput())
+			assert.Equal(t, "Docs: CR-USAGE-001: url", T("error.docs", "CR-USAGE-001", "url"))
+		})
+	}
+
+	setLocale(t, map[string]string{"LANG": "en_GB.UTF-8", "TERM": "xterm-256color"})
+	require.NoError(t, Setup("", false))
+	assert.False(t, PlainOutput())
+	assert.Equal(t, "🚀 Found existing configuration, running container...", T("run.existing_config"))
+}
+
+func TestPlain(t *testing.T) {
+	assert.Equal(t, "Found existing configuration, running container...", Plain("🚀 Found existing configuration, running container..."))
+	assert.Equal(t, "[x] failed\nHint: Start Docker\n  [!] indented", Plain("❌ failed\n💡 Start Docker\n  ⚠️ indented"))
+	assert.Equal(t, "[ok] Done", Plain("✅ Done"))
+	assert.Equal(t, "Tag:          latest", Plain("Tag:          latest 🏷️"))
+}
+
+func TestWriter(t *testing.T) {
+	setLocale(t, map[string]string{"LANG": "en_GB.UTF-8", "TERM": "xterm-256color"})
+	var out bytes.Buffer
+	w := Writer(&out)
+
+	require.NoError(t, Setup("", false))
+	fmt.Fprintln(w, "✅ Done")
+	assert.Equal(t, "✅ Done\n", out.String())
+
+	out.Reset()
+	require.NoError(t, Setup("", true))
+	fmt.Fprintln(w, "✅ Done")
+	fmt.Fprint(w, "⚠️  Continue? (y/N): ")
+	assert.Equal(t, "[ok] Done\n[!]  Continue? (y/N): ", out.String())
+}
+
+func TestCatalogs(t *testing.T) {
+	verb := regexp.MustCompile(`%[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+	for lang, catalog := range catalogs {
+		for id, message := range catalog {
+			english, ok := en[id]
+			require.True(t, ok, "%s message %s is not in the English catalog", lang, id)
+			assert.Equal(t, verb.FindAllString(english, -1), verb.FindAllString(message, -1), "%s message %s has different format verbs", lang, id)
+		}
+	}
+
+	// Every English message is translated
+	var missing []string
+	for id := range en {
+		if _, ok := ja[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	sort.Strings(missing)
+	assert.Empty(t, missing, "missing from the Japanese catalog")
+}
@@ -0,0 +1,365 @@
+package i18n
+
+// ja is the Japanese catalog
+var ja = map[string]string{
+	"root.short": "アカウントを分離した Docker コンテナで Claude CLI をシンプルかつ安全に実行します",
+	"root.long": `Claude-Reactor は、アカウントを適切に分離した Docker コンテナで Claude CLI を
+安全に実行する方法を提供します。用途に合わせた複数のビルド済みコンテナ
+バリアントを用意し、安全性とシンプルさを両立します。`,
+
+	"flag.debug":     "デバッグモードを有効にする",
+	"flag.verbose":   "詳細な出力を有効にする",
+	"flag.log_level": "ログレベルを設定する (debug, info, warn, error)",
+	"flag.lang":      "メッセージの言語: en, ja (既定値は LANG から判定)",
+	"flag.no_emoji":  "絵文字を使わずに出力する",
+
+	"removed.list_variants": "❌ --list-variants フラグは廃止されました。代わりに次を使用してください:",
+	"removed.show_config":   "❌ --show-config フラグは廃止されました。代わりに次を使用してください:",
+	"removed.variant":       "❌ --variant フラグは廃止されました。代わりに次を使用してください:",
+
+	"run.existing_config":          "🚀 既存の設定が見つかりました。コンテナを起動します...",
+	"run.failed":                   "実行に失敗しました: %v",
+	"run.working_directory":        "📂 作業ディレクトリ: %s",
+	"run.command":                  "コンテナでコマンドを実行しています: %s",
+	"run.launching_shell":          "🐚 コンテナで対話シェルを起動しています...",
+	"run.shell_hint":               "💡 'claude' で Claude CLI を起動、'exit' でコンテナを終了します",
+	"run.launching_danger":         "🤖 危険モードで Claude CLI を起動しています...",
+	"run.danger_warning":           "⚠️  危険モードは権限チェックを省略します。注意して使用してください",
+	"run.launching_claude":         "🤖 コンテナで Claude CLI を起動しています...",
+	"run.conversation":             "💬 会話 '%s' (セッション %s)",
+	"run.resuming_session":         "💬 セッション %s を再開しています",
+	"run.clipboard_active":         "📋 クリップボード連携が有効です。コンテナで 'cr-copy' を使うとホストにコピーできます",
+	"run.detachable":               "🪟 %s セッション '%s' で実行しています。再接続するには: claude-reactor session attach",
+	"run.sync_stop_failed":         "ファイル同期を停止できませんでした: %v",
+	"run.stopping":                 "🧹 --persist=false のためコンテナを停止しています...",
+	"run.stop_failed":              "コンテナを停止できませんでした: %v",
+	"run.remains_running":          "💾 コンテナは実行したままになります (停止するには 'claude-reactor clean')",
+	"run.starting":                 "🚀 Claude CLI コンテナを起動しています...",
+	"run.loading_configuration":    "📋 設定を読み込んでいます...",
+	"run.account_config_failed":    "アカウント設定を用意できませんでした: %v",
+	"run.danger_enabled":           "🔥 危険モードを有効にし、設定に保存します",
+	"run.danger_disabled":          "🛡️  危険モードを無効にし、設定に保存します",
+	"run.danger_persistent":        "🔥 保存済みの危険モード設定を使用します",
+	"run.host_docker_enabled":      "🐳 ホスト Docker へのアクセスを有効にし、設定に保存します",
+	"run.host_docker_disabled":     "🔒 ホスト Docker へのアクセスを無効にし、設定に保存します",
+	"run.host_docker_persistent":   "🐳 保存済みのホスト Docker 設定を使用します",
+	"run.sync_enabled":             "🔄 同期モードを有効にし、設定に保存します",
+	"run.sync_disabled":            "📁 同期モードを無効にし、設定に保存します",
+	"run.sync_persistent":          "🔄 保存済みの同期モード設定を使用します",
+	"run.windows_drive":            "⚠️  %s は Windows ドライブ上にあるため、コンテナからのファイルアクセスが遅くなります",
+	"run.windows_drive_hint":       "💡 WSL のファイルシステムに移動するには: claude-reactor wsl relocate",
+	"run.platform_persisted":       "🖥️  プラットフォーム %s を設定に保存します",
+	"run.host_platform_persisted":  "🖥️  ホストのプラットフォームを設定に保存します",
+	"run.platform_persistent":      "🖥️  保存済みのプラットフォーム設定を使用します: %s",
+	"run.clipboard_enabled":        "📋 クリップボード連携を有効にし、設定に保存します",
+	"run.clipboard_disabled":       "📋 クリップボード連携を無効にし、設定に保存します",
+	"run.ssh_detected":             "🔑 SSH エージェントを自動検出し、設定に保存します",
+	"run.ssh_socket":               "🔑 SSH エージェントのソケットが指定されました: %s (設定に保存します)",
+	"run.ssh_valid":                "✅ SSH エージェントの検証に成功しました",
+	"run.ssh_redetect_failed":      "SSH エージェントを再検出できないため無効にします: %v",
+	"run.ssh_persistent_detected":  "🔑 保存済みの SSH エージェント設定を使用します (自動検出)",
+	"run.ssh_persistent":           "🔑 保存済みの SSH エージェント設定を使用します: %s",
+	"run.ssh_persistent_invalid":   "保存済みの SSH エージェントのソケットが無効なため無効にします: %v",
+	"run.api_key":                  "🔑 アカウント %s の API キーを設定しています",
+	"run.api_key_done":             "✅ API キー認証を設定しました",
+	"run.interactive_login":        "🔐 アカウント %s で対話ログインを強制します",
+	"run.detecting":                "🔍 プロジェクトの種類を自動検出しています...",
+	"run.detect_failed":            "イメージを自動検出できませんでした: %v",
+	"run.detect_default":           "💡 'base' イメージを使用します。指定するには --image フラグを使ってください",
+	"run.detected":                 "✅ 自動検出したイメージ: %s",
+	"run.validating_configuration": "✅ 設定を検証しています...",
+	"run.validating_custom_image":  "🔍 カスタム Docker イメージ %s を検証しています (互換性とパッケージ分析)",
+	"run.custom_image_invalid":     "❌ カスタムイメージの検証に失敗しました:",
+	"run.custom_image_warnings":    "⚠️ カスタムイメージの警告:",
+	"run.package_analysis":         "📦 パッケージ分析: 推奨ツール %d/%d 件が利用可能",
+	"run.claude_config":            "🔑 Claude の設定: %s",
+	"run.detecting_architecture":   "🔧 システムのアーキテクチャを検出しています...",
+	"run.container_name":           "🏷️ コンテナ名: %s",
+	"run.local_image":              "✅ ローカルイメージが見つかりました: %s",
+	"run.registry_image":           "📦 ローカルイメージ '%s' が見つからないため、レジストリのイメージを使用します: %s",
+	"run.preparing_docker":         "🐳 Docker 環境を準備しています...",
+	"run.docker_timeout":           "🕒 Docker 操作のタイムアウト: %s",
+	"run.configuring_mounts":       "📁 コンテナのマウントを設定しています...",
+	"run.sync_secret_files":        "⚠️  プロジェクトはボリュームにコピーされるため、シークレットファイルを隠せません。代わりに %s に記載してください",
+	"run.save_failed":              "設定を保存できませんでした: %v",
+	"run.save_session_failed":      "セッションの設定を保存できませんでした: %v",
+	"run.started":                  "✅ コンテナを起動しました",
+	"run.syncing":                  "🔄 プロジェクトのファイルをコンテナのボリュームに同期しています...",
+	"run.sync_active":              "✅ 双方向同期が有効です",
+	"run.project_volume":           "🔄 プロジェクトボリューム: %s -> %s (%s から同期)",
+	"run.project_sandbox":          "🧪 プロジェクトのサンドボックス: %s -> %s (%s からコピー)",
+	"run.project_mount":            "📁 プロジェクトのマウント: %s -> %s",
+	"run.git_directory":            "🌿 リポジトリの git ディレクトリ: %s",
+	"run.protected":                "🔒 保護: %s",
+	"run.protected_missing":        "⚠️  保護パス %s が存在しないため、セッションが作成できてしまいます",
+	"run.session_dir_failed":       "Claude のセッションディレクトリを作成できませんでした: %v",
+	"run.session_mount_failed":     "Claude のセッションのマウントを追加できませんでした: %v",
+	"run.session_mount":            "📁 Claude のセッションのマウント: %s -> /home/claude/.claude",
+	"run.history_dir_failed":       "シェル履歴のディレクトリを作成できませんでした: %v",
+	"run.read_only":                "🔒 プロジェクトは読み取り専用です。Claude は %s に書き込めます (ホスト上: %s)",
+	"run.project_config_failed":    "プロジェクト用の .claude.json を作成できませんでした: %v",
+	"run.project_config_created":   "📄 プロジェクト用の設定を作成しました: %s",
+	"run.account_template_failed":  "アカウント設定のテンプレートを読み取れませんでした: %v",
+	"run.config_mount_failed":      "プロジェクトの Claude 設定のマウントを追加できませんでした: %v",
+	"run.config_mount":             "🔑 Claude 設定のマウント: %s -> /home/claude/.claude.json",
+	"run.credentials_mount_failed": "認証情報のマウントを追加できませんでした: %v",
+	"run.credentials_mount":        "🔐 認証情報のマウント: %s -> /home/claude/.claude/.credentials.json",
+	"run.docker_socket_desktop":    "🐳 ホスト Docker ソケットのマウント: Docker Desktop -> %s",
+	"run.docker_socket":            "🐳 ホスト Docker ソケットのマウント: %s -> /var/run/docker.sock",
+	"run.ssh_mount_failed":         "SSH のマウント %s -> %s を追加できませんでした: %v",
+	"run.ssh_mount":                "🔑 SSH のマウント: %s -> %s",
+	"run.ssh_configured":           "🔑 Git 操作用に SSH キーを設定しました",
+	"run.subagents_dir_failed":     "セッションのサブエージェントディレクトリを作成できませんでした: %v",
+	"run.subagents_mount_failed":   "グローバルなサブエージェントのマウントを追加できませんでした: %v",
+	"run.subagents_mount":          "🤖 グローバルなサブエージェントのマウント: %s -> /home/claude/.claude/agents",
+	"run.project_subagents":        "🤖 プロジェクトのサブエージェントを検出しました: %s (プロジェクトのマウントから利用可能)",
+	"run.added_mount":              "📁 マウントを追加しました: %s -> %s%s",
+	"run.added_tmpfs":              "📁 tmpfs マウントを追加しました: %s",
+	"run.settings_failed":          "Claude の設定をコピーできませんでした: %v",
+	"run.claude_settings":          "⚙️  Claude の設定: %s",
+	"run.mcp_read_failed":          "管理対象の MCP サーバーを読み取れませんでした: %v",
+	"run.mcp_copy_failed":          "MCP サーバーをコピーできませんでした: %v",
+	"run.mcp_servers":              "🔌 MCP サーバー: %s",
+	"run.system_prompt_failed":     "⚠️  システムプロンプトを更新できませんでした: %v",
+	"run.system_prompt":            "📜 システムプロンプト: %s",
+	"run.waiting_pid":              "⏳ 別の claude-reactor (pid %s) がこのプロジェクトを起動中です。完了を待っています...",
+	"run.waiting":                  "⏳ 別の claude-reactor がこのプロジェクトを起動中です。完了を待っています...",
+	"run.reusing":                  "♻️ 既存のコンテナを再利用します (%s)",
+	"run.starting_precreated":      "⚡ 事前作成したコンテナを起動しています (%s)...",
+	"run.recreating":               "🔁 コンテナを作り直しています (%s)...",
+	"run.starting_persistent":      "🔄 セッションを保持してコンテナを起動/再開しています...",
+	"run.starting_ephemeral":       "🏗️ 一時コンテナを起動しています...",
+	"run.verifying_signature":      "🔏 %s の署名を検証しています...",
+	"run.signature_failed":         "⚠️  署名の検証に失敗しましたが続行します: %v",
+	"run.signature_verified":       "✅ 署名を検証しました: %s",
+	"run.mirror_default":           "%v。既定値を使用します",
+	"run.mirror_direct":            "%v。%s から直接プルします",
+	"run.variant_image":            "📦 イメージ %s をバリアント %s に使用します",
+	"run.building_variant":         "🔨 バリアント %s を %s からビルドしています...",
+	"run.host_docker_warning":      "⚠️  警告: ホスト Docker へのアクセスが有効です",
+	"run.host_docker_grants":       "🔒 claude-reactor コンテナにホストレベルの Docker 権限を与えます:",
+	"run.host_docker_containers":   "   • ホスト上のあらゆるコンテナを作成・管理できます",
+	"run.host_docker_directories":  "   • ホストのあらゆるディレクトリをマウント・参照できます",
+	"run.host_docker_network":      "   • ホストのネットワークや他のコンテナにアクセスできます",
+	"run.host_docker_root":         "   • ホストシステムの ROOT 権限と同等です",
+	"run.host_docker_unlimited":    "⏰ Docker 操作: タイムアウトなし (タイムアウトによる保護なし)",
+	"run.host_docker_hint":         "💡 Docker の管理が必要な信頼できる作業でのみ有効にしてください",
+	"run.toolchain_missing":        "⚠️  %s は %s %s を指定していますが、イメージに %s のツールチェーンがありません",
+	"run.toolchain_mismatch":       "⚠️  %s は %s %s を指定していますが、イメージが提供するのは %s です",
+	"run.toolchain_install_hint":   "💡 自動インストールを有効にするには: claude-reactor config set toolchain_install true",
+	"run.toolchain_installing":     "🧰 %s %s をインストールしています...",
+	"run.toolchain_install_failed": "%s %s をインストールできませんでした: %s",
+	"run.toolchain_manager_hint":   "💡 mise または asdf を含むイメージを使うか、--image で必要なバージョンのイメージを選んでください",
+	"run.toolchain_installed":      "✅ %s %s をインストールしました",
+	"run.scanning":                 "🛡️  イメージの脆弱性をスキャンしています (しきい値: %s)...",
+	"run.scan_failed_allowed":      "⚠️  脆弱性スキャンに失敗しました (--allow-vulnerable により許可): %v",
+	"run.scan_clean":               "✅ %s 以上の脆弱性は見つかりませんでした (%s)",
+	"run.vulnerable_allowed":       "⚠️  イメージに %d 件の脆弱性 (%s 以上) があります (--allow-vulnerable により許可)",
+	"run.custom_image_valid":       "✅ カスタムイメージの検証に成功しました: %s (ダイジェスト: %.12s)",
+	"run.configuration_sessions":   "📋 設定: image=%s, account=%s, danger=%t, shell=%t, persist=%t, session_persistence=%t",
+	"run.configuration":            "📋 設定: image=%s, account=%s, danger=%t, shell=%t, persist=%t",
+	"run.host_docker_timeout":      "⏰ Docker 操作のタイムアウト: %s",
+
+	"summary.title":       "📋 セッションの概要",
+	"summary.image":       "イメージ:",
+	"summary.pulled":      "起動時に取得",
+	"summary.account":     "アカウント:",
+	"summary.danger":      "危険モード:",
+	"summary.host_docker": "ホスト Docker:",
+	"summary.mounts":      "マウント:",
+	"summary.outside":     "⚠️  %s (プロジェクト外)",
+	"summary.riskier":     "⚠️  このプロジェクトの前回の実行よりリスクの高い設定があります:",
+	"summary.confirm":     "セッションを開始しますか? (y/N): ",
+
+	"stats.empty":        "まだイメージをビルドまたはプルしていません",
+	"stats.history_hint": "💡 個々のビルドを一覧表示するには: claude-reactor stats --history builds",
+
+	"list.no_directory":       "~/.claude-reactor ディレクトリが見つかりません",
+	"list.account_unreadable": "アカウントディレクトリ %s を読み取れませんでした: %v",
+	"list.empty":              "~/.claude-reactor/ にプロジェクトが見つかりません",
+	"list.summary":            "合計: アカウント %d 件、プロジェクト %d 件、コンテナ %d 件",
+	"list.never":              "未使用",
+
+	"time.just_now":    "たった今",
+	"time.minutes_ago": "%d 分前",
+	"time.hours_ago":   "%d 時間前",
+	"time.days_ago":    "%d 日前",
+
+	"prewarm.none":     "準備する最近のプロジェクトはありません",
+	"prewarm.next":     "💤 次の事前準備は %s 後です (Ctrl+C で停止)",
+	"prewarm.no_cwd":   "カレントディレクトリを取得できませんでした: %v",
+	"prewarm.project":  "🔥 %s (%s) を事前準備しています",
+	"prewarm.skipping": "%s をスキップします: %v",
+	"prewarm.failed":   "%s の事前準備に失敗しました: %v",
+	"prewarm.done":     "✅ %d / %d 件のプロジェクトを事前準備しました",
+	"prewarm.running":  "♻️ コンテナ %s は実行中のため、そのままにします",
+	"prewarm.warm":     "✅ コンテナ %s は準備済みです",
+	"prewarm.created":  "✅ コンテナ %s を事前作成しました",
+
+	"clean.dry_run":            "🔍 ドライラン: 何も削除していません",
+	"clean.confirm":            "続行しますか? (y/N): ",
+	"clean.cancelled":          "🚫 クリーンアップを中止しました",
+	"clean.starting":           "🧹 クリーンアップを開始します (レベル: %s)...",
+	"clean.remove_failed":      "%s %s を削除できませんでした: %v",
+	"clean.removed":            "✅ %d / %d 件のリソースを削除し、%s を解放しました",
+	"clean.done":               "✅ クリーンアップが完了しました",
+	"clean.plan":               "🧹 クリーンアップ計画:",
+	"clean.scope_project":      "  📍 対象: プロジェクト %s (アカウント: %s)",
+	"clean.scope_account":      "  📍 対象: アカウント %s のすべてのプロジェクト",
+	"clean.scope_all":          "  📍 対象: すべてのプロジェクトとアカウント",
+	"clean.older_than":         "  ⏳ %s より古いリソースのみ",
+	"clean.plan_empty":         "  • 該当するコンテナ、ボリューム、セッション、イメージはありません",
+	"clean.running":            " (実行中)",
+	"clean.plan_remove":        "  • %s %s %s%s を削除 — %s",
+	"clean.plan_auth":          "  • 🔑 認証データ (Claude の設定、API キー) を削除",
+	"clean.plan_cache":         "  • 🗄️ 検証キャッシュを消去",
+	"clean.plan_reclaimable":   "  💽 解放できる容量: %s",
+	"clean.auth_all":           "🔑 すべての認証データを削除しています...",
+	"clean.auth_unreadable":    "claude-reactor ディレクトリを読み取れませんでした: %v",
+	"clean.auth_file":          "🔑 認証ファイルを削除しています: %s",
+	"clean.auth_file_failed":   "認証ファイル %s を削除できませんでした: %v",
+	"clean.auth_all_done":      "✅ すべての認証データを削除しました",
+	"clean.auth_account":       "🔑 アカウント %s の認証データを削除しています",
+	"clean.auth_config_failed": "認証設定を削除できませんでした: %v",
+	"clean.auth_key_failed":    "API キーファイルを削除できませんでした: %v",
+	"clean.auth_account_done":  "✅ アカウントの認証データを削除しました",
+	"clean.cache":              "🗄️ 検証キャッシュを消去しています...",
+	"clean.cache_done":         "✅ 検証キャッシュを消去しました",
+	"clean.report_empty":       "claude-reactor のコンテナ、ボリューム、セッション、イメージは見つかりません",
+	"clean.report_up":          "%d (%d 件実行中)",
+	"clean.report_images":      "共有イメージ (%s):",
+	"clean.report_reclaimable": "解放できる容量: %s (プロジェクト %d 件、イメージ %d 件)",
+	"clean.report_hint":        "💡 クリーンアップを事前確認するには: claude-reactor clean --global --dry-run",
+
+	"info.title":              "=== Claude-Reactor デバッグ情報 ===",
+	"info.arch_failed":        "アーキテクチャを検出できませんでした: %v",
+	"info.host_arch":          "ホストのアーキテクチャ: %s",
+	"info.platform_failed":    "Docker プラットフォームを取得できませんでした: %v",
+	"info.docker_platform":    "Docker プラットフォーム: %s",
+	"info.multi_arch":         "マルチアーキテクチャ対応: %t",
+	"info.detect_failed":      "プロジェクトの種類を検出できませんでした: %v",
+	"info.version":            "バージョン: %s",
+	"info.git_commit":         "Git コミット: %s",
+	"info.build_date":         "ビルド日時: %s",
+	"info.docker_failed":      "Docker 接続: ❌ 失敗 (%v)",
+	"info.docker_connected":   "Docker 接続: ✅ 接続済み",
+	"info.debug_mode":         "デバッグモード: %v",
+	"info.log_level":          "ログレベル: %s",
+	"info.log_level_unknown":  "ログレベル: 判定できません",
+	"info.timing":             "起動時間:",
+	"info.on_demand":          " (必要時)",
+	"info.startup_total":      "起動合計",
+	"info.detection_none":     "プロジェクト検出: プロジェクトの目印が見つかりません (%s を使用)",
+	"info.detection":          "プロジェクト検出: %s (信頼度 %.2f) -> %s イメージ",
+	"info.languages":          "  言語: %s",
+	"info.frameworks":         "  フレームワーク: %s",
+	"info.tools":              "  ツール: %s",
+	"info.missing_toolchains": "  ⚠️  ビルド済みイメージに含まれていません: %s (カスタムイメージを使用してください)",
+	"info.uncovered_variants": "  ⚠️  %s をすべて含むビルド済みバリアントはありません。%s を使用します",
+	"info.docker_unavailable": "❌ Docker を利用できません: %v",
+	"info.testing_image":      "🔍 イメージの互換性をテストしています: %s",
+	"info.validation_failed":  "❌ 検証に失敗しました: %v",
+	"info.image_results":      "=== イメージの検証結果 ===",
+	"info.image":              "イメージ: %s",
+	"info.digest":             "ダイジェスト: %s",
+	"info.architecture":       "アーキテクチャ: %s",
+	"info.platform":           "プラットフォーム: %s",
+	"info.size":               "サイズ: %.2f MB",
+	"info.compatible":         "互換性あり: %t",
+	"info.has_claude":         "Claude CLI あり: %t",
+	"info.is_linux":           "Linux: %t",
+	"info.warnings":           "⚠️ 警告:",
+	"info.errors":             "❌ エラー:",
+	"info.packages":           "📦 パッケージ分析:",
+	"info.available_tools":    "利用できるツール (%d): %s",
+	"info.missing_tools":      "不足している優先度の高いツール: %s",
+	"info.coverage":           "カバー率: 推奨ツール %d/%d 件が利用可能",
+	"info.image_compatible":   "✅ イメージは claude-reactor と互換性があります",
+	"info.image_incompatible": "❌ イメージに互換性がありません。上のエラーを確認してください。",
+	"info.scan_unavailable":   "⚠️ 脆弱性スキャンを実行できません: %v",
+	"info.scan":               "🛡️  脆弱性スキャン (%s):",
+	"info.scan_clean":         "  既知の脆弱性はありません",
+
+	"cache.dir":        "キャッシュディレクトリ: %s",
+	"cache.results":    "検証結果: %d / %d 件 (%.1f KB)",
+	"cache.oldest":     "最も古い結果: %s",
+	"cache.newest":     "最も新しい結果: %s",
+	"cache.ttl":        "結果の有効期間: %s",
+	"cache.images":     "記憶しているイメージ: %d 件 (%s 有効)",
+	"cache.clear_hint": "キャッシュを消去するには: claude-reactor clean --cache",
+	"cache.exported":   "✅ %d 件の検証結果を %s に書き出しました",
+	"cache.imported":   "✅ %d 件の検証結果を読み込みました",
+
+	"config.load_failed":             "設定を読み込めませんでした: %v",
+	"config.title":                   "📋 Claude-Reactor の設定",
+	"config.auto_detect":             "自動検出",
+	"config.none":                    "なし",
+	"config.dotfiles_default":        "インストールスクリプト、またはホームにリンク",
+	"config.any_image":               "すべてのイメージ",
+	"config.unknown":                 "不明",
+	"config.valid":                   "設定は有効です ✓",
+	"config.set":                     "%s = %s を設定しました",
+	"config.mirror_invalid":          "%s (無効: %v)",
+	"config.mirror_unreachable":      "%s (接続できません。プルは %s にフォールバックします)",
+	"config.mirror_healthy":          "%s (正常)",
+	"config.image_variant":           "🖼️  イメージ/バリアント: %s",
+	"config.account":                 "👤 アカウント: %s",
+	"config.danger_mode":             "🔥 危険モード: %t",
+	"config.host_docker":             "🐳 ホスト Docker: %t",
+	"config.host_docker_timeout":     "⏰ ホスト Docker のタイムアウト: %s",
+	"config.ssh_agent":               "🔑 SSH エージェント: %t",
+	"config.ssh_socket":              "🔌 SSH ソケット: %s",
+	"config.session_persistence":     "💾 セッションの保持: %t",
+	"config.last_session_id":         "🔗 前回のセッション ID: %s",
+	"config.container_id":            "📦 コンテナ ID: %s",
+	"config.toolchain_install":       "🧰 ツールチェーンのインストール: %t",
+	"config.required_tools":          "🧰 必須ツール: %s",
+	"config.recommended_tools":       "🧰 推奨ツール: %s",
+	"config.tools_install":           "🧰 ツールのインストール: %t",
+	"config.vulnerability_threshold": "🛡️  脆弱性のしきい値: %s",
+	"config.sync_mode":               "🔄 同期モード: %t",
+	"config.clipboard_bridge":        "📋 クリップボード連携: %t",
+	"config.platform":                "🖥️  プラットフォーム: %s",
+	"config.image_cache_ttl":         "🗄️  イメージキャッシュの有効期間: %s",
+	"config.image_cache_size":        "🗄️  イメージキャッシュのサイズ: %d",
+	"config.shared_image_cache":      "🤝 共有イメージキャッシュ: %s",
+	"config.claude_cli_version":      "📌 Claude CLI のバージョン: %s",
+	"config.notifications":           "🔔 通知: %s",
+	"config.notify_after":            "⏱️  通知までの時間: %s",
+	"config.registry_mirror":         "🪞 レジストリミラー: %s",
+	"config.claude_args":             "🧩 Claude の引数: %s",
+	"config.system_prompt":           "📜 システムプロンプト: %s",
+	"config.mounts":                  "📁 マウント: %s",
+	"config.tmpfs":                   "📁 tmpfs: %s",
+	"config.checkpoints":             "📸 チェックポイント: %s",
+	"config.protected_paths":         "🔒 保護パス: %s",
+	"config.secret_scan":             "🕵️  シークレットスキャン: %s",
+	"config.dns":                     "🌐 DNS: %s",
+	"config.dns_search":              "🌐 DNS 検索ドメイン: %s",
+	"config.extra_hosts":             "🌐 追加ホスト: %s",
+	"config.dotfiles":                "🏠 dotfiles: %s (%s)",
+	"config.hostname":                "🏷️  ホスト名: %s",
+	"config.prompt":                  "💲 プロンプト: %s",
+	"config.secrets":                 "🔐 シークレット: %s ('claude-reactor secrets list' を参照)",
+	"config.tasks":                   "🧰 タスク: %s ('claude-reactor task list' を参照)",
+	"config.anthropic_api_key":       "🔑 ANTHROPIC_API_KEY: %s (コンテナに渡されます)",
+	"config.image_signatures":        "🔏 イメージ署名: 鍵 %d 個、ID %d 件 (必須: %t)",
+	"config.policy_tools":            "🧰 ポリシーのツール: 必須 %s、推奨 %s",
+	"config.current_directory":       "📁 カレントディレクトリ: %s",
+	"config.configured_project_path": "📂 設定されたプロジェクトパス: %s",
+	"config.auto_detected":           "🔍 自動検出: %s",
+	"config.packages":                "📦 パッケージ (%s): %s",
+	"config.image_policy_error":      "🛡️  イメージポリシー: %v",
+	"config.image_policy":            "🛡️  イメージポリシー: %s (許可: %s、ダイジェスト必須: %t)",
+
+	"version.short":      "バージョン情報を表示する",
+	"version.version":    "claude-reactor バージョン %s",
+	"version.git_commit": "Git コミット: %s",
+	"version.build_date": "ビルド日時: %s",
+	"version.go_version": "Go バージョン: %s",
+	"version.os_arch":    "OS/アーキテクチャ: %s/%s",
+
+	"error.prefix": "エラー: %v",
+	"error.hint":   "💡 %s",
+	"error.docs":   "📖 %s: %s",
+}
@@ -0,0 +1,319 @@
+// Package layers analyzes the layers of a Docker image from a 'docker save' archive: what each
+// layer adds, where the space goes, and space wasted on files that later layers overwrite or
+// remove.
+package layers
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+)
+
+// maxConfigSize is the largest archive entry read as JSON; layers are streamed
+const maxConfigSize = 8 << 20
+
+// Layer is one filesystem layer of an image
+type Layer struct {
+	Index     int    `json:"index"`                // 0 for the bottom layer
+	CreatedBy string `json:"created_by,omitempty"` // build step that created it, when recorded
+	Size      int64  `json:"size"`                 // uncompressed size of the files it adds
+	Files     int    `json:"files"`
+}
+
+// Waste is a file whose space is wasted because a later layer overwrites or removes it
+type Waste struct {
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`    // space taken by the copies no longer visible
+	Layers  []int  `json:"layers"`  // layers holding copies of the file
+	Removed bool   `json:"removed"` // removed by a later layer rather than overwritten
+}
+
+// Report is the analysis of an image
+type Report struct {
+	Layers []Layer
+	Size   int64 // sum of layer sizes
+	Wasted []Waste
+	files  map[string]int64 // size of each file in the final filesystem
+}
+
+// WastedSize returns the total space taken by files that are not visible in the image
+func (r *Report) WastedSize() int64 {
+	var total int64
+	for _, waste := range r.Wasted {
+		total += waste.Size
+	}
+	return total
+}
+
+// PathSize is the size of the files under a path in the final filesystem
+type PathSize struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// Largest returns the n largest directories depth levels deep in the final filesystem, such
+// as /usr/lib for depth 2. Files closer to the root count as their own paths.
+func (r *Report) Largest(depth, n int) []PathSize {
+	sizes := map[string]int64{}
+	for file, size := range r.files {
+		parts := strings.Split(file, "/")
+		if len(parts) > depth {
+			parts = parts[:depth]
+		}
+		sizes["/"+strings.Join(parts, "/")] += size
+	}
+	largest := make([]PathSize, 0, len(sizes))
+	for p, size := range sizes {
+		largest = append(largest, PathSize{Path: p, Size: size})
+	}
+	sort.Slice(largest, func(i, j int) bool {
+		if largest[i].Size != largest[j].Size {
+			return largest[i].Size > largest[j].Size
+		}
+		return largest[i].Path < largest[j].Path
+	})
+	if len(largest) > n {
+		largest = largest[:n]
+	}
+	return largest
+}
+
+// SizeUnder returns the size of the files at or under p in the final filesystem
+func (r *Report) SizeUnder(p string) int64 {
+	p = strings.Trim(p, "/")
+	var total int64
+	for file, size := range r.files {
+		if file == p || strings.HasPrefix(file, p+"/") {
+			total += size
+		}
+	}
+	return total
+}
+
+// manifest is an entry of the manifest.json written by 'docker save'
+type manifest struct {
+	Config string
+	Layers []string
+}
+
+// imageConfig is the part of the image configuration that records build steps
+type imageConfig struct {
+	History []struct {
+		CreatedBy  string `json:"created_by"`
+		EmptyLayer bool   `json:"empty_layer"`
+	} `json:"history"`
+}
+
+// layerFiles is what a layer archive adds and removes
+type layerFiles struct {
+	files   []PathSize
+	removed []string // paths removed with .wh. whiteouts
+	opaque  []string // directories whose lower contents are hidden
+}
+
+// Analyze reads an image archive as written by 'docker save' for a single image, in either the
+// legacy or the OCI layout
+func Analyze(archive io.Reader) (*Report, error) {
+	var manifests []manifest
+	configs := map[string][]byte{}
+	layerData := map[string]*layerFiles{}
+	links := map[string]string{}
+
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read image archive: %w", err)
+		}
+		name := path.Clean(header.Name)
+		switch header.Typeflag {
+		case tar.TypeSymlink:
+			// The legacy layout links layers shared with another entry
+			links[name] = path.Join(path.Dir(name), header.Linkname)
+			continue
+		case tar.TypeReg:
+		default:
+			continue
+		}
+
+		if name == "manifest.json" {
+			if err := json.NewDecoder(tr).Decode(&manifests); err != nil {
+				return nil, fmt.Errorf("failed to parse image manifest: %w", err)
+			}
+			continue
+		}
+		reader := bufio.NewReader(tr)
+		start, _ := reader.Peek(2)
+		if len(start) > 0 && start[0] == '{' && header.Size <= maxConfigSize {
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			configs[name] = data
+			continue
+		}
+		if files, err := readLayer(reader, len(start) == 2 && start[0] == 0x1f && start[1] == 0x8b); err == nil {
+			layerData[name] = files
+		}
+	}
+
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("image archive has no manifest.json")
+	}
+	if len(manifests) > 1 {
+		return nil, fmt.Errorf("image archive holds %d images; analyze one at a time", len(manifests))
+	}
+
+	var config imageConfig
+	if data, ok := configs[path.Clean(manifests[0].Config)]; ok {
+		json.Unmarshal(data, &config)
+	}
+	var steps []string
+	for _, entry := range config.History {
+		if !entry.EmptyLayer {
+			steps = append(steps, cleanCreatedBy(entry.CreatedBy))
+		}
+	}
+
+	var layers []*layerFiles
+	for _, name := range manifests[0].Layers {
+		name = path.Clean(name)
+		if target, ok := links[name]; ok {
+			name = target
+		}
+		files, ok := layerData[name]
+		if !ok {
+			return nil, fmt.Errorf("image archive is missing layer %s", name)
+		}
+		layers = append(layers, files)
+	}
+	return analyzeLayers(layers, steps), nil
+}
+
+// readLayer lists the files a layer archive adds and removes
+func readLayer(r io.Reader, compressed bool) (*layerFiles, error) {
+	if compressed {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	files := &layerFiles{}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		dir, base := path.Split(name)
+		switch {
+		case base == ".wh..wh..opq":
+			files.opaque = append(files.opaque, strings.TrimSuffix(dir, "/"))
+		case strings.HasPrefix(base, ".wh."):
+			files.removed = append(files.removed, dir+strings.TrimPrefix(base, ".wh."))
+		case header.Typeflag == tar.TypeReg:
+			files.files = append(files.files, PathSize{Path: name, Size: header.Size})
+		}
+	}
+	return files, nil
+}
+
+// analyzeLayers applies the layers in order, tracking which copies of files end up hidden
+func analyzeLayers(layers []*layerFiles, steps []string) *Report {
+	type owner struct {
+		size  int64
+		layer int
+	}
+	report := &Report{}
+	final := map[string]owner{}
+	wasted := map[string]*Waste{}
+
+	waste := func(file string, previous owner, layer int, removed bool) {
+		w, ok := wasted[file]
+		if !ok {
+			w = &Waste{Path: "/" + file, Layers: []int{previous.layer}}
+			wasted[file] = w
+		}
+		w.Size += previous.size
+		w.Removed = removed
+		if !removed {
+			w.Layers = append(w.Layers, layer)
+		}
+	}
+	hide := func(prefix string, layer int, exact bool) {
+		for file, previous := range final {
+			if (exact && file == prefix) || prefix == "" || strings.HasPrefix(file, prefix+"/") {
+				waste(file, previous, layer, true)
+				delete(final, file)
+			}
+		}
+	}
+
+	for i, files := range layers {
+		for _, dir := range files.opaque {
+			hide(dir, i, false)
+		}
+		for _, file := range files.removed {
+			hide(file, i, true)
+		}
+
+		layer := Layer{Index: i, Files: len(files.files)}
+		if len(steps) == len(layers) {
+			layer.CreatedBy = steps[i]
+		}
+		for _, file := range files.files {
+			layer.Size += file.Size
+			if previous, ok := final[file.Path]; ok {
+				waste(file.Path, previous, i, false)
+			}
+			final[file.Path] = owner{size: file.Size, layer: i}
+		}
+		report.Layers = append(report.Layers, layer)
+		report.Size += layer.Size
+	}
+
+	report.files = make(map[string]int64, len(final))
+	for file, o := range final {
+		report.files[file] = o.size
+	}
+	for _, w := range wasted {
+		if w.Size > 0 {
+			report.Wasted = append(report.Wasted, *w)
+		}
+	}
+	sort.Slice(report.Wasted, func(i, j int) bool {
+		if report.Wasted[i].Size != report.Wasted[j].Size {
+			return report.Wasted[i].Size > report.Wasted[j].Size
+		}
+		return report.Wasted[i].Path < report.Wasted[j].Path
+	})
+	return report
+}
+
+// cleanCreatedBy shortens a recorded build step to the instruction that ran
+func cleanCreatedBy(createdBy string) string {
+	step := strings.TrimSpace(createdBy)
+	step = strings.TrimPrefix(step, "/bin/sh -c #(nop) ")
+	if rest, ok := strings.CutPrefix(step, "/bin/sh -c "); ok {
+		step = "RUN " + rest
+	}
+	step = strings.TrimSuffix(step, " # buildkit")
+	step = strings.ReplaceAll(step, "RUN /bin/sh -c ", "RUN ")
+	return strings.Join(strings.Fields(step), " ")
+}
@@ -0,0 +1,174 @@
+package layers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// file is an archive entry; a link target makes it a symlink
+type file struct {
+	name string
+	size int
+	link string
+}
+
+func tarball(t *testing.T, files ...file) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range files {
+		if f.link != "" {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: f.name, Typeflag: tar.TypeSymlink, Linkname: f.link}))
+			continue
+		}
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: f.name, Typeflag: tar.TypeReg, Size: int64(f.size), Mode: 0644}))
+		_, err := tw.Write(bytes.Repeat([]byte("x"), f.size))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func gzipped(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// archive builds a 'docker save' archive from named entries
+func archive(t *testing.T, entries map[string][]byte, links map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, data := range entries {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Size: int64(len(data)), Mode: 0644}))
+		_, err := tw.Write(data)
+		require.NoError(t, err)
+	}
+	for name, target := range links {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeSymlink, Linkname: target}))
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func jsonData(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
+
+func TestAnalyze(t *testing.T) {
+	config := map[string]any{"history": []map[string]any{
+		{"created_by": "/bin/sh -c #(nop) ADD file:abc in / "},
+		{"created_by": "/bin/sh -c #(nop)  ENV PATH=/usr/local/go/bin", "empty_layer": true},
+		{"created_by": "RUN /bin/sh -c apt-get update && apt-get install -y git # buildkit"},
+		{"created_by": "RUN /bin/sh -c rm -rf /var/lib/apt/lists/* # buildkit"},
+	}}
+	base := tarball(t,
+		file{name: "usr/bin/bash", size: 1000},
+		file{name: "etc/os-release", size: 10},
+	)
+	install := tarball(t,
+		file{name: "usr/bin/git", size: 3000},
+		file{name: "usr/lib/git-core/git-remote", size: 2000},
+		file{name: "var/lib/apt/lists/main", size: 500},
+		file{name: "etc/os-release", size: 12},
+	)
+	cleanup := tarball(t,
+		file{name: "var/lib/apt/lists/.wh..wh..opq", size: 0},
+		file{name: "usr/bin/.wh.bash", size: 0},
+	)
+
+	data := archive(t, map[string][]byte{
+		"manifest.json": jsonData(t, []map[string]any{{
+			"Config": "config.json",
+			"Layers": []string{"base/layer.tar", "install/layer.tar", "cleanup/layer.tar"},
+		}}),
+		"config.json":       jsonData(t, config),
+		"base/layer.tar":    base,
+		"install/layer.tar": gzipped(t, install),
+		"cleanup/layer.tar": cleanup,
+	}, nil)
+
+	report, err := Analyze(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	require.Len(t, report.Layers, 3)
+	assert.Equal(t, Layer{Index: 0, CreatedBy: "ADD file:abc in /", Size: 1010, Files: 2}, report.Layers[0])
+	assert.Equal(t, Layer{Index: 1, CreatedBy: "RUN apt-get update && apt-get install -y git", Size: 5512, Files: 4}, report.Layers[1])
+	assert.Equal(t, Layer{Index: 2, CreatedBy: "RUN rm -rf /var/lib/apt/lists/*", Size: 0, Files: 0}, report.Layers[2])
+	assert.Equal(t, int64(6522), report.Size)
+
+	assert.Equal(t, []Waste{
+		{Path: "/usr/bin/bash", Size: 1000, Layers: []int{0}, Removed: true},
+		{Path: "/var/lib/apt/lists/main", Size: 500, Layers: []int{1}, Removed: true},
+		{Path: "/etc/os-release", Size: 10, Layers: []int{0, 1}},
+	}, report.Wasted)
+	assert.Equal(t, int64(1510), report.WastedSize())
+
+	assert.Equal(t, []PathSize{
+		{Path: "/usr/bin", Size: 3000},
+		{Path: "/usr/lib", Size: 2000},
+		{Path: "/etc/os-release", Size: 12},
+	}, report.Largest(2, 10))
+	assert.Equal(t, []PathSize{{Path: "/usr", Size: 5000}}, report.Largest(1, 1))
+	assert.Equal(t, int64(2000), report.SizeUnder("/usr/lib/"))
+	assert.Equal(t, int64(0), report.SizeUnder("/var"))
+}
+
+func TestAnalyzeSharedLayer(t *testing.T) {
+	layer := tarball(t, file{name: "app/data", size: 100})
+	data := archive(t, map[string][]byte{
+		"manifest.json": jsonData(t, []map[string]any{{
+			"Config": "blobs/sha256/cfg",
+			"Layers": []string{"blobs/sha256/aaa", "second/layer.tar"},
+		}}),
+		"blobs/sha256/aaa": layer,
+	}, map[string]string{"second/layer.tar": "../blobs/sha256/aaa"})
+
+	report, err := Analyze(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, report.Layers, 2)
+	// Without a recorded history the layers have no build steps
+	assert.Empty(t, report.Layers[0].CreatedBy)
+	assert.Equal(t, []Waste{{Path: "/app/data", Size: 100, Layers: []int{0, 1}}}, report.Wasted)
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	_, err := Analyze(bytes.NewReader(archive(t, map[string][]byte{"index.json": []byte("{}")}, nil)))
+	assert.ErrorContains(t, err, "no manifest.json")
+
+	data := archive(t, map[string][]byte{
+		"manifest.json": jsonData(t, []map[string]any{{"Config": "c.json", "Layers": []string{"missing/layer.tar"}}}),
+	}, nil)
+	_, err = Analyze(bytes.NewReader(data))
+	assert.ErrorContains(t, err, "missing layer missing/layer.tar")
+
+	data = archive(t, map[string][]byte{
+		"manifest.json": jsonData(t, []map[string]any{{"Config": "a.json"}, {"Config": "b.json"}}),
+	}, nil)
+	_, err = Analyze(bytes.NewReader(data))
+	assert.ErrorContains(t, err, "holds 2 images")
+
+	_, err = Analyze(strings.NewReader("not an archive"))
+	assert.Error(t, err)
+}
+
+func TestCleanCreatedBy(t *testing.T) {
+	assert.Equal(t, "WORKDIR /app", cleanCreatedBy("/bin/sh -c #(nop) WORKDIR /app"))
+	assert.Equal(t, "RUN make install", cleanCreatedBy("/bin/sh -c make   install"))
+	assert.Equal(t, "COPY . /src", cleanCreatedBy("COPY . /src # buildkit"))
+}
@@ -0,0 +1,112 @@
+// Package lock provides per-project advisory file locks, so concurrent claude-reactor
+// invocations in the same project take turns writing its configuration and creating
+// its container instead of racing.
+package lock
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pollInterval is how often a held lock is retried
+const pollInterval = 100 * time.Millisecond
+
+// ErrTimeout is returned when another process holds the lock for longer than the caller waits
+var ErrTimeout = errors.New("timed out waiting for lock")
+
+// Lock is a held advisory lock. The operating system releases it if the process exits
+// without calling Release, so locks never go stale.
+type Lock struct {
+	file *os.File
+}
+
+// ProjectPath returns the lock file for a project directory, under ~/.claude-reactor/locks
+func ProjectPath(projectDir string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	sum := sha256.Sum256([]byte(filepath.Clean(projectDir)))
+	name := filepath.Base(projectDir) + "-" + hex.EncodeToString(sum[:])[:8] + ".lock"
+	return filepath.Join(homeDir, ".claude-reactor", "locks", name), nil
+}
+
+// Acquire takes the lock at path, waiting up to timeout while another process holds it.
+// onWait, if set, is called once with the holder's PID (or "" if unknown) when the lock
+// is not immediately available.
+func Acquire(ctx context.Context, path string, timeout time.Duration, onWait func(holder string)) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	waited := false
+	for {
+		locked, err := tryLock(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			break
+		}
+
+		if !waited {
+			waited = true
+			if onWait != nil {
+				onWait(Holder(path))
+			}
+		}
+		if time.Now().After(deadline) {
+			file.Close()
+			return nil, ErrTimeout
+		}
+		select {
+		case <-ctx.Done():
+			file.Close()
+			return nil, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+
+	// Record the holder so waiting processes can say who they are waiting for
+	if err := file.Truncate(0); err == nil {
+		file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+	return &Lock{file: file}, nil
+}
+
+// Release unlocks and closes the lock file
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	unlockErr := unlock(l.file)
+	closeErr := l.file.Close()
+	l.file = nil
+	if unlockErr != nil {
+		return unlockErr
+	}
+	return closeErr
+}
+
+// Holder returns the PID recorded by the process that last took the lock at path
+func Holder(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,72 @@
+package lock
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectPath(t *testing.T) {
+	t.Setenv("HOME", "/home/me")
+	t.Setenv("USERPROFILE", "/home/me")
+
+	path, err := ProjectPath("/src/app")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/home/me", ".claude-reactor", "locks"), filepath.Dir(path))
+	assert.True(t, strings.HasPrefix(filepath.Base(path), "app-"))
+
+	other, err := ProjectPath("/work/app")
+	require.NoError(t, err)
+	assert.NotEqual(t, path, other, "projects with the same name get different locks")
+
+	again, err := ProjectPath("/src/app/")
+	require.NoError(t, err)
+	assert.Equal(t, path, again)
+}
+
+func TestAcquire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locks", "project.lock")
+	ctx := context.Background()
+
+	first, err := Acquire(ctx, path, time.Second, nil)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid()), Holder(path))
+
+	t.Run("held lock times out and reports the holder", func(t *testing.T) {
+		var holder string
+		calls := 0
+		_, err := Acquire(ctx, path, 150*time.Millisecond, func(h string) {
+			holder = h
+			calls++
+		})
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, strconv.Itoa(os.Getpid()), holder)
+	})
+
+	t.Run("cancelled wait", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := Acquire(cancelled, path, time.Minute, nil)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("waiter gets the lock once it is released", func(t *testing.T) {
+		go func() {
+			time.Sleep(150 * time.Millisecond)
+			first.Release()
+		}()
+		second, err := Acquire(ctx, path, 5*time.Second, nil)
+		require.NoError(t, err)
+		assert.NoError(t, second.Release())
+	})
+
+	assert.NoError(t, first.Release(), "releasing twice is harmless")
+}
@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+
+	"claude-reactor/internal/reactor/i18n"
+	"claude-reactor/pkg"
+)
+
+// ciFormatter emits one JSON object per line with emoji and decorative symbols removed,
+// so CI systems can parse claude-reactor's own log output
+type ciFormatter struct {
+	logrus.JSONFormatter
+}
+
+// Format strips symbols from the message before JSON encoding
+func (f *ciFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	clean := *entry
+	clean.Message = StripEmoji(entry.Message)
+	return f.JSONFormatter.Format(&clean)
+}
+
+// EnableCIMode switches a logger created by this package to machine-readable JSON on
+// stderr, leaving stdout to the command running in the container. All components share
+// the underlying logrus instance, so the change applies application-wide.
+func EnableCIMode(l pkg.Logger) bool {
+	wrapped, ok := l.(*logger)
+	if !ok {
+		return false
+	}
+
+	wrapped.Logger.SetFormatter(&redactingFormatter{Formatter: &ciFormatter{JSONFormatter: logrus.JSONFormatter{
+		TimestampFormat: "2006-01-02T15:04:05Z07:00",
+	}}})
+	wrapped.Logger.SetOutput(os.Stderr)
+	return true
+}
+
+// UseStderr moves a logger created by this package to stderr, so stdout carries only a
+// command's result
+func UseStderr(l pkg.Logger) bool {
+	wrapped, ok := l.(*logger)
+	if !ok {
+		return false
+	}
+	wrapped.Logger.SetOutput(os.Stderr)
+	return true
+}
+
+// StripEmoji removes emoji, pictographs, and their joiners/variation selectors from a message
+func StripEmoji(message string) string {
+	var b strings.Builder
+	for _, r := range message {
+		switch {
+		case r == '\u200d' || r == '\ufe0f' || r == '\ufe0e':
+			continue
+		case unicode.Is(unicode.So, r) || r >= 0x1f000:
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return strings.TrimSpace(strings.Join(strings.Fields(b.String()), " "))
+}
+
+// plainFormatter renders messages without emoji for terminals that cannot display them
+type plainFormatter struct {
+	logrus.Formatter
+}
+
+// Format replaces symbols in the message with ASCII and drops other emoji
+func (f *plainFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	clean := *entry
+	clean.Message = i18n.Plain(entry.Message)
+	return f.Formatter.Format(&clean)
+}
+
+// EnablePlainOutput makes a logger created by this package write messages without emoji,
+// keeping its current format and output
+func EnablePlainOutput(l pkg.Logger) bool {
+	wrapped, ok := l.(*logger)
+	if !ok {
+		return false
+	}
+	wrapped.Logger.SetFormatter(&plainFormatter{Formatter: wrapped.Logger.Formatter})
+	return true
+}
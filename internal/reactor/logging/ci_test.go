@@ -0,0 +1,46 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripEmoji(t *testing.T) {
+	assert.Equal(t, "Starting Claude CLI container...", StripEmoji("🚀 Starting Claude CLI container..."))
+	assert.Equal(t, "Container name: x", StripEmoji("🏷️ Container name: x"))
+	assert.Equal(t, "Danger mode disabled", StripEmoji("🛡️  Danger mode disabled"))
+	assert.Equal(t, "plain message", StripEmoji("plain message"))
+}
+
+func TestEnableCIMode(t *testing.T) {
+	l := NewLogger()
+	require.True(t, EnableCIMode(l))
+
+	var buf bytes.Buffer
+	l.(*logger).Logger.SetOutput(&buf)
+	l.Info("✅ Container started successfully!")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "Container started successfully!", entry["msg"])
+	assert.Equal(t, "info", entry["level"])
+}
+
+func TestEnableCIMode_ForeignLogger(t *testing.T) {
+	assert.False(t, EnableCIMode(nil))
+}
+
+func TestEnablePlainOutput(t *testing.T) {
+	l := NewLogger()
+	require.True(t, EnablePlainOutput(l))
+
+	var buf bytes.Buffer
+	l.(*logger).Logger.SetOutput(&buf)
+	l.Info("✅ Container started successfully!")
+	assert.Contains(t, buf.String(), "[ok] Container started successfully!")
+	assert.NotContains(t, buf.String(), "✅")
+}
@@ -18,12 +18,12 @@ func NewLoggerWithLevel(level logrus.Level) pkg.Logger {
 	l := logrus.New()
 
 	// Set output format
-	l.SetFormatter(&logrus.TextFormatter{
+	l.SetFormatter(&redactingFormatter{Formatter: &logrus.TextFormatter{
 		FullTimestamp:   true,
 		TimestampFormat: "15:04:05",
 		ForceColors:     true,
 		DisableQuote:    true,
-	})
+	}})
 	l.SetLevel(level)
 
 	// Set output to stdout (not stderr) for better CLI experience
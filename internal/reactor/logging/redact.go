@@ -0,0 +1,20 @@
+package logging
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"claude-reactor/internal/reactor/secrets"
+)
+
+// redactingFormatter removes secrets from messages before another formatter writes them, so
+// tokens and the values of secret environment variables never reach the terminal or CI logs
+type redactingFormatter struct {
+	logrus.Formatter
+}
+
+// Format redacts the message and hands the entry on
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	clean := *entry
+	clean.Message = secrets.Redact(entry.Message)
+	return f.Formatter.Format(&clean)
+}
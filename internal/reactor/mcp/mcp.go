@@ -0,0 +1,122 @@
+// Package mcp keeps the MCP servers claude-reactor manages for a project, and tells Claude CLI
+// in the container how to reach each one.
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"claude-reactor/internal/reactor/agent"
+)
+
+// FileName holds a project's managed MCP servers, in its session directory
+const FileName = "mcp-servers.json"
+
+// Where a server runs
+const (
+	ModeSidecar   = "sidecar"   // its own container on a network shared with the project container
+	ModeHost      = "host"      // on the host, connected through the attached session
+	ModeContainer = "container" // in the project container itself
+)
+
+// namePattern keeps server names usable as container names and network aliases
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// defaultImages are the sidecar images for common stdio server launchers
+var defaultImages = map[string]string{
+	"npx":     "node:22-slim",
+	"node":    "node:22-slim",
+	"npm":     "node:22-slim",
+	"uvx":     "ghcr.io/astral-sh/uv:python3.12-bookworm-slim",
+	"uv":      "ghcr.io/astral-sh/uv:python3.12-bookworm-slim",
+	"python":  "python:3.12-slim",
+	"python3": "python:3.12-slim",
+}
+
+// Server is a stdio MCP server managed by claude-reactor
+type Server struct {
+	Name    string            `json:"name"`
+	Mode    string            `json:"mode"`
+	Image   string            `json:"image,omitempty"` // sidecar image
+	Command []string          `json:"command"`
+	Env     map[string]string `json:"env,omitempty"`
+}
+
+// ValidateName checks a server name can name a sidecar
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid MCP server name '%s': use lowercase letters, digits, - and _", name)
+	}
+	return nil
+}
+
+// DefaultImage returns the sidecar image for a server started with command, such as npx or
+// uvx, or "" when there is no obvious one
+func DefaultImage(command string) string {
+	return defaultImages[filepath.Base(command)]
+}
+
+// ClaudeEntry returns the server's definition for Claude CLI in the container. Servers in
+// sidecars and on the host are reached through claude-reactor-agent.
+func (s *Server) ClaudeEntry() map[string]interface{} {
+	if s.Mode == ModeContainer {
+		entry := map[string]interface{}{"type": "stdio", "command": s.Command[0], "args": s.Command[1:]}
+		if len(s.Env) > 0 {
+			entry["env"] = s.Env
+		}
+		return entry
+	}
+	return map[string]interface{}{"type": "stdio", "command": agent.BinaryPath, "args": []string{"mcp", s.Name}}
+}
+
+// ClaudeEntries returns the Claude CLI definitions of servers, by name
+func ClaudeEntries(servers []Server) map[string]interface{} {
+	entries := make(map[string]interface{}, len(servers))
+	for i := range servers {
+		entries[servers[i].Name] = servers[i].ClaudeEntry()
+	}
+	return entries
+}
+
+// Load returns the servers managed for the project whose session directory is sessionDir
+func Load(sessionDir string) ([]Server, error) {
+	data, err := os.ReadFile(filepath.Join(sessionDir, FileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MCP servers: %w", err)
+	}
+	var servers []Server
+	if err := json.Unmarshal(data, &servers); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(sessionDir, FileName), err)
+	}
+	return servers, nil
+}
+
+// Save stores servers for the project, sorted by name
+func Save(sessionDir string, servers []Server) error {
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+	data, err := json.MarshalIndent(servers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode MCP servers: %w", err)
+	}
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	return os.WriteFile(filepath.Join(sessionDir, FileName), data, 0644)
+}
+
+// Find returns the server called name, or nil
+func Find(servers []Server, name string) *Server {
+	for i := range servers {
+		if servers[i].Name == name {
+			return &servers[i]
+		}
+	}
+	return nil
+}
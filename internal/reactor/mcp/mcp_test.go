@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateName(t *testing.T) {
+	assert.NoError(t, ValidateName("github"))
+	assert.NoError(t, ValidateName("my_server-2"))
+	assert.Error(t, ValidateName("GitHub"))
+	assert.Error(t, ValidateName("-leading"))
+	assert.Error(t, ValidateName("has space"))
+}
+
+func TestDefaultImage(t *testing.T) {
+	assert.Equal(t, "node:22-slim", DefaultImage("npx"))
+	assert.Equal(t, "node:22-slim", DefaultImage("/usr/bin/node"))
+	assert.Equal(t, "ghcr.io/astral-sh/uv:python3.12-bookworm-slim", DefaultImage("uvx"))
+	assert.Equal(t, "", DefaultImage("./my-server"))
+}
+
+func TestClaudeEntry(t *testing.T) {
+	sidecar := Server{Name: "github", Mode: ModeSidecar, Image: "node:22-slim", Command: []string{"npx", "server-github"}}
+	assert.Equal(t, map[string]interface{}{
+		"type": "stdio", "command": "/usr/local/bin/claude-reactor-agent", "args": []string{"mcp", "github"},
+	}, sidecar.ClaudeEntry())
+
+	local := Server{Name: "git", Mode: ModeContainer, Command: []string{"uvx", "mcp-server-git"}, Env: map[string]string{"A": "b"}}
+	assert.Equal(t, map[string]interface{}{
+		"type": "stdio", "command": "uvx", "args": []string{"mcp-server-git"}, "env": map[string]string{"A": "b"},
+	}, local.ClaudeEntry())
+}
+
+func TestLoadSave(t *testing.T) {
+	dir := t.TempDir()
+
+	servers, err := Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, servers)
+
+	require.NoError(t, Save(dir, []Server{
+		{Name: "notes", Mode: ModeHost, Command: []string{"notes-mcp"}},
+		{Name: "github", Mode: ModeSidecar, Image: "node:22-slim", Command: []string{"npx", "server-github"}},
+	}))
+	servers, err = Load(dir)
+	require.NoError(t, err)
+	require.Len(t, servers, 2)
+	assert.Equal(t, "github", servers[0].Name, "saved sorted by name")
+	assert.Equal(t, ModeHost, Find(servers, "notes").Mode)
+	assert.Nil(t, Find(servers, "missing"))
+}
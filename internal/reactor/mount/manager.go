@@ -3,9 +3,12 @@ package mount
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"claude-reactor/internal/reactor/wsl"
 	"claude-reactor/pkg"
 )
 
@@ -30,6 +33,11 @@ func (m *manager) ValidateMountPath(path string) (string, error) {
 	
 	// Expand home directory if present
 	expandedPath := expandPath(path)
+
+	// Under WSL 2, accept Windows paths such as C:\Users\me or \\wsl$\Ubuntu\home\me
+	if env := wsl.Detect(); env != nil {
+		expandedPath = wsl.TranslatePath(expandedPath, env.Distro)
+	}
 	
 	// Check if path is absolute
 	if !isAbsolutePath(expandedPath) {
@@ -99,6 +107,123 @@ func (m *manager) AddMountToConfig(config *pkg.ContainerConfig, sourcePath, targ
 	return nil
 }
 
+// Mount modes accepted after the paths in a mount spec
+var (
+	accessModes      = map[string]bool{"ro": true, "rw": true}
+	consistencyModes = map[string]bool{"cached": true, "delegated": true, "consistent": true}
+)
+
+// windowsDrive matches a Windows path at the start of a spec, whose colon is not a separator
+var windowsDrive = regexp.MustCompile(`^[A-Za-z]:[\\/]`)
+
+// ParseMountSpec parses and validates a mount given as src[:dst][:ro|rw][:cached|delegated|consistent].
+// A relative src is in the current directory. dst defaults to /mnt/<basename of src>; mounts
+// are read-write unless ro is given.
+func (m *manager) ParseMountSpec(spec string) (*pkg.Mount, error) {
+	source, rest := spec, ""
+	offset := 0
+	if windowsDrive.MatchString(spec) {
+		offset = 2
+	}
+	if i := strings.Index(spec[offset:], ":"); i >= 0 {
+		source, rest = spec[:offset+i], spec[offset+i+1:]
+	}
+	if source == "" {
+		return nil, fmt.Errorf("invalid mount '%s': the source path is missing", spec)
+	}
+
+	if !filepath.IsAbs(source) && !strings.HasPrefix(source, "~") && offset == 0 {
+		absolute, err := filepath.Abs(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mount '%s': %w", spec, err)
+		}
+		source = absolute
+	}
+	validated, err := m.ValidateMountPath(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mount '%s': %w", spec, err)
+	}
+	mount := &pkg.Mount{
+		Source: validated,
+		Target: path.Join("/mnt", filepath.Base(validated)),
+		Type:   "bind",
+	}
+
+	var options []string
+	if rest != "" {
+		options = strings.Split(rest, ":")
+	}
+	if len(options) > 0 && strings.HasPrefix(options[0], "/") {
+		if mount.Target, err = containerPath(options[0]); err != nil {
+			return nil, fmt.Errorf("invalid mount '%s': %w", spec, err)
+		}
+		options = options[1:]
+	}
+
+	access := ""
+	for _, option := range options {
+		switch {
+		case accessModes[option] && access == "":
+			access = option
+			mount.ReadOnly = option == "ro"
+		case consistencyModes[option] && mount.Consistency == "":
+			mount.Consistency = option
+		default:
+			return nil, fmt.Errorf("invalid mount '%s': unexpected '%s' (use src[:dst][:ro|rw][:cached|delegated|consistent])", spec, option)
+		}
+	}
+	return mount, nil
+}
+
+// ParseTmpfsSpec parses and validates a tmpfs mount given as its container path
+func (m *manager) ParseTmpfsSpec(target string) (*pkg.Mount, error) {
+	target, err := containerPath(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tmpfs mount: %w", err)
+	}
+	return &pkg.Mount{Target: target, Type: "tmpfs"}, nil
+}
+
+// AddMount adds a parsed mount to container config, refusing a target that is already mounted
+// from elsewhere. Repeating a mount exactly is skipped.
+func (m *manager) AddMount(config *pkg.ContainerConfig, mount *pkg.Mount) error {
+	if config == nil {
+		return fmt.Errorf("container config is nil")
+	}
+	for _, existing := range config.Mounts {
+		if existing == *mount {
+			m.logger.Warnf("Mount already exists: %s -> %s", mountSource(*mount), mount.Target)
+			return nil
+		}
+		if existing.Target == mount.Target {
+			return fmt.Errorf("%s is already mounted from %s", mount.Target, mountSource(existing))
+		}
+	}
+	config.Mounts = append(config.Mounts, *mount)
+	m.logger.Debugf("Added mount: %s -> %s", mountSource(*mount), mount.Target)
+	return nil
+}
+
+// containerPath checks a mount target is an absolute container path other than the root
+func containerPath(target string) (string, error) {
+	if !strings.HasPrefix(target, "/") {
+		return "", fmt.Errorf("container path must be absolute: %s", target)
+	}
+	target = path.Clean(target)
+	if target == "/" {
+		return "", fmt.Errorf("cannot mount over the container root")
+	}
+	return target, nil
+}
+
+// mountSource describes where a mount comes from
+func mountSource(mount pkg.Mount) string {
+	if mount.Type == "tmpfs" {
+		return "tmpfs"
+	}
+	return mount.Source
+}
+
 // GetMountSummary returns formatted summary of mounts
 func (m *manager) GetMountSummary(mounts []pkg.Mount) string {
 	if len(mounts) == 0 {
@@ -626,4 +626,89 @@ func TestNormalizePath(t *testing.T) {
 			assert.Equal(t, tt.expected, result)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestManager_ParseMountSpec(t *testing.T) {
+	mockLogger := &mocks.MockLogger{}
+	mockLogger.On("Debugf", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	mgr := NewManager(mockLogger)
+
+	dataDir := t.TempDir()
+	base := filepath.Base(dataDir)
+
+	tests := []struct {
+		name     string
+		spec     string
+		expected pkg.Mount
+	}{
+		{"source only", dataDir, pkg.Mount{Source: dataDir, Target: "/mnt/" + base, Type: "bind"}},
+		{"target", dataDir + ":/data", pkg.Mount{Source: dataDir, Target: "/data", Type: "bind"}},
+		{"read-only", dataDir + ":/data:ro", pkg.Mount{Source: dataDir, Target: "/data", Type: "bind", ReadOnly: true}},
+		{"mode without target", dataDir + ":ro", pkg.Mount{Source: dataDir, Target: "/mnt/" + base, Type: "bind", ReadOnly: true}},
+		{"consistency", dataDir + ":/data:rw:cached", pkg.Mount{Source: dataDir, Target: "/data", Type: "bind", Consistency: "cached"}},
+		{"consistency before access", dataDir + ":/data/:delegated:ro", pkg.Mount{Source: dataDir, Target: "/data", Type: "bind", ReadOnly: true, Consistency: "delegated"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mount, err := mgr.ParseMountSpec(tt.spec)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, *mount)
+		})
+	}
+
+	invalid := map[string]string{
+		"missing source":   ":/data",
+		"unknown option":   dataDir + ":/data:rx",
+		"repeated access":  dataDir + ":/data:ro:rw",
+		"relative target":  dataDir + ":data",
+		"container root":   dataDir + ":/",
+		"nonexistent path": filepath.Join(dataDir, "missing") + ":/data",
+	}
+	for name, spec := range invalid {
+		t.Run(name+" is rejected", func(t *testing.T) {
+			_, err := mgr.ParseMountSpec(spec)
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("relative source is in the current directory", func(t *testing.T) {
+		originalDir, _ := os.Getwd()
+		defer os.Chdir(originalDir)
+		os.Chdir(filepath.Dir(dataDir))
+
+		mount, err := mgr.ParseMountSpec(base + ":/data")
+		assert.NoError(t, err)
+		resolved, _ := filepath.EvalSymlinks(mount.Source)
+		expected, _ := filepath.EvalSymlinks(dataDir)
+		assert.Equal(t, expected, resolved)
+	})
+}
+
+func TestManager_ParseTmpfsSpec(t *testing.T) {
+	mgr := NewManager(&mocks.MockLogger{})
+
+	mount, err := mgr.ParseTmpfsSpec("/tmp/work/")
+	assert.NoError(t, err)
+	assert.Equal(t, pkg.Mount{Target: "/tmp/work", Type: "tmpfs"}, *mount)
+
+	_, err = mgr.ParseTmpfsSpec("tmp")
+	assert.Error(t, err)
+	_, err = mgr.ParseTmpfsSpec("/")
+	assert.Error(t, err)
+}
+
+func TestManager_AddMount(t *testing.T) {
+	mockLogger := &mocks.MockLogger{}
+	mockLogger.On("Debugf", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	mockLogger.On("Warnf", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	mgr := NewManager(mockLogger)
+
+	config := &pkg.ContainerConfig{Mounts: []pkg.Mount{{Source: "/src/project", Target: "/app", Type: "bind"}}}
+
+	assert.NoError(t, mgr.AddMount(config, &pkg.Mount{Target: "/tmp/work", Type: "tmpfs"}))
+	assert.NoError(t, mgr.AddMount(config, &pkg.Mount{Target: "/tmp/work", Type: "tmpfs"}), "repeating a mount is skipped")
+	assert.Len(t, config.Mounts, 2)
+
+	err := mgr.AddMount(config, &pkg.Mount{Source: "/home/me/data", Target: "/app", Type: "bind"})
+	assert.ErrorContains(t, err, "/app is already mounted from /src/project")
+	assert.Error(t, mgr.AddMount(nil, &pkg.Mount{Target: "/data"}))
+}
@@ -0,0 +1,75 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Event types that can be notified, enabled with the notifications setting
+const (
+	EventBuild     = "build"     // an image build finished or failed
+	EventTask      = "task"      // Claude finished a long task
+	EventUnhealthy = "unhealthy" // the session's container stopped or became unhealthy
+)
+
+// Events lists every event type
+var Events = []string{EventBuild, EventTask, EventUnhealthy}
+
+// ParseEvents parses a comma-separated list of event types. "all" enables every type and
+// "" or "none" disables notifications.
+func ParseEvents(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "", "none":
+		return nil, nil
+	case "all":
+		return Events, nil
+	}
+
+	var events []string
+	for _, event := range strings.Split(value, ",") {
+		event = strings.TrimSpace(event)
+		if !knownEvent(event) {
+			return nil, fmt.Errorf("unknown notification event '%s' (use %s, all, or none)", event, strings.Join(Events, ", "))
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func knownEvent(event string) bool {
+	for _, known := range Events {
+		if event == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Notifier sends desktop notifications for the enabled event types
+type Notifier struct {
+	enabled map[string]bool
+	send    func(title, message string) error
+}
+
+// NewNotifier creates a notifier for events, which may be empty
+func NewNotifier(events []string) *Notifier {
+	enabled := make(map[string]bool, len(events))
+	for _, event := range events {
+		enabled[event] = true
+	}
+	return &Notifier{enabled: enabled, send: Send}
+}
+
+// Enabled reports whether notifications are sent for event
+func (n *Notifier) Enabled(event string) bool {
+	return n != nil && n.enabled[event]
+}
+
+// Notify shows a notification if event is enabled
+func (n *Notifier) Notify(event, title, message string) error {
+	if !n.Enabled(event) {
+		return nil
+	}
+	return n.send(title, message)
+}
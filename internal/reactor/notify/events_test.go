@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvents(t *testing.T) {
+	events, err := ParseEvents("")
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = ParseEvents("none")
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = ParseEvents("all")
+	require.NoError(t, err)
+	assert.Equal(t, Events, events)
+
+	events, err = ParseEvents("build, task")
+	require.NoError(t, err)
+	assert.Equal(t, []string{EventBuild, EventTask}, events)
+
+	_, err = ParseEvents("build,deploy")
+	assert.ErrorContains(t, err, "unknown notification event 'deploy'")
+}
+
+func TestNotifierSendsOnlyEnabledEvents(t *testing.T) {
+	var sent []string
+	notifier := NewNotifier([]string{EventBuild})
+	notifier.send = func(title, message string) error {
+		sent = append(sent, title+": "+message)
+		return nil
+	}
+
+	require.NoError(t, notifier.Notify(EventBuild, "claude-reactor build", "Built go"))
+	require.NoError(t, notifier.Notify(EventTask, "claude-reactor", "Claude finished"))
+
+	assert.Equal(t, []string{"claude-reactor build: Built go"}, sent)
+	assert.True(t, notifier.Enabled(EventBuild))
+	assert.False(t, notifier.Enabled(EventUnhealthy))
+
+	var disabled *Notifier
+	assert.False(t, disabled.Enabled(EventBuild))
+	assert.NoError(t, disabled.Notify(EventBuild, "title", "message"))
+}
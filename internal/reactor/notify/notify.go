@@ -0,0 +1,67 @@
+// Package notify shows desktop notifications on the host.
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Send shows a desktop notification using the platform's notification tool
+func Send(title, message string) error {
+	name, args, err := hostNotifyCommand(title, message)
+	if err != nil {
+		return err
+	}
+	if output, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// hostNotifyCommand picks the first available notification tool for this host
+func hostNotifyCommand(title, message string) (string, []string, error) {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		candidates = [][]string{{"osascript", "-e", script}}
+	case "windows":
+		candidates = [][]string{{"powershell", "-NoProfile", "-Command", toastScript(title, message)}}
+	default:
+		candidates = [][]string{
+			{"notify-send", "--app-name=claude-reactor", title, message},
+			{"powershell.exe", "-NoProfile", "-Command", toastScript(title, message)}, // WSL
+		}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err == nil {
+			return candidate[0], candidate[1:], nil
+		}
+	}
+	return "", nil, fmt.Errorf("no notification tool found (install libnotify's notify-send)")
+}
+
+// toastScript shows a Windows toast notification from PowerShell
+func toastScript(title, message string) string {
+	return fmt.Sprintf(`[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode(%s)) > $null
+$text.Item(1).AppendChild($template.CreateTextNode(%s)) > $null
+$app = '{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe'
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($app).Show([Windows.UI.Notifications.ToastNotification]::new($template))`,
+		powerShellString(title), powerShellString(message))
+}
+
+// powerShellString quotes s as a PowerShell single-quoted string literal
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
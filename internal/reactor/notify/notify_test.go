@@ -0,0 +1,12 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppleScriptString(t *testing.T) {
+	assert.Equal(t, `"build done"`, appleScriptString("build done"))
+	assert.Equal(t, `"say \"hi\" \\ bye"`, appleScriptString(`say "hi" \ bye`))
+}
@@ -0,0 +1,179 @@
+// Package plugins discovers kubectl-style claude-reactor-<name> executables on PATH, so teams
+// can ship their own subcommands without forking the CLI.
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Prefix is the executable name prefix that marks a plugin
+const Prefix = "claude-reactor-"
+
+// Manifest is an optional <executable>.yaml next to a plugin that describes it for help
+// and shell completion
+type Manifest struct {
+	Short   string   `yaml:"short"`
+	Long    string   `yaml:"long"`
+	Example string   `yaml:"example"`
+	Args    []string `yaml:"args"`  // completions for the first argument
+	Flags   []string `yaml:"flags"` // completions for arguments starting with '-'
+}
+
+// Plugin is an executable found on PATH
+type Plugin struct {
+	Name     string
+	Path     string
+	Manifest *Manifest
+	// ManifestError is set when a manifest exists but could not be read
+	ManifestError error
+	// Shadowed lists executables with the same name later on PATH, which are never run
+	Shadowed []string
+}
+
+// Discover finds plugins in the directories of pathList (a PATH value), sorted by name.
+// As with command lookup, the first executable for a name wins.
+func Discover(pathList string) []*Plugin {
+	byName := make(map[string]*Plugin)
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+
+			if existing, found := byName[name]; found {
+				if existing.Path != path {
+					existing.Shadowed = append(existing.Shadowed, path)
+				}
+				continue
+			}
+			plugin := &Plugin{Name: name, Path: path}
+			plugin.Manifest, plugin.ManifestError = LoadManifest(path)
+			byName[name] = plugin
+		}
+	}
+
+	discovered := make([]*Plugin, 0, len(byName))
+	for _, plugin := range byName {
+		discovered = append(discovered, plugin)
+	}
+	sort.Slice(discovered, func(i, j int) bool { return discovered[i].Name < discovered[j].Name })
+	return discovered
+}
+
+// pluginName returns the subcommand name for an executable file name
+func pluginName(fileName string) (string, bool) {
+	if !strings.HasPrefix(fileName, Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(fileName, Prefix)
+
+	ext := strings.ToLower(filepath.Ext(name))
+	switch ext {
+	case ".yaml", ".yml":
+		// Manifests live alongside plugins
+		return "", false
+	case ".exe", ".bat", ".cmd":
+		if runtime.GOOS == "windows" {
+			name = strings.TrimSuffix(name, filepath.Ext(name))
+		}
+	}
+
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return "", false
+	}
+	return name, true
+}
+
+// isExecutable reports whether path is a regular file that can be run
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// Windows decides by extension, which pluginName has already checked
+		return true
+	}
+	return info.Mode().Perm()&0111 != 0
+}
+
+// LoadManifest reads the manifest for a plugin executable, returning nil if it has none
+func LoadManifest(executable string) (*Manifest, error) {
+	base := executable
+	if runtime.GOOS == "windows" {
+		base = strings.TrimSuffix(executable, filepath.Ext(executable))
+	}
+
+	for _, ext := range []string{".yaml", ".yml"} {
+		data, err := os.ReadFile(base + ext)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read plugin manifest: %w", err)
+		}
+		var manifest Manifest
+		if err := yaml.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse plugin manifest %s: %w", base+ext, err)
+		}
+		return &manifest, nil
+	}
+	return nil, nil
+}
+
+// Complete returns completions for the argument being typed, given the arguments before it
+func (m *Manifest) Complete(args []string, toComplete string) []string {
+	if m == nil {
+		return nil
+	}
+	candidates := m.Flags
+	if !strings.HasPrefix(toComplete, "-") {
+		if len(args) > 0 {
+			return nil
+		}
+		candidates = m.Args
+	}
+
+	var completions []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) {
+			completions = append(completions, candidate)
+		}
+	}
+	return completions
+}
+
+// Command builds the process for running the plugin with args. The plugin can find the
+// CLI that launched it through CLAUDE_REACTOR_BIN.
+func (p *Plugin) Command(args []string) *exec.Cmd {
+	cmd := exec.Command(p.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if self, err := os.Executable(); err == nil {
+		cmd.Env = append(cmd.Env, "CLAUDE_REACTOR_BIN="+self)
+	}
+	cmd.Env = append(cmd.Env, "CLAUDE_REACTOR_PLUGIN="+p.Name)
+	return cmd
+}
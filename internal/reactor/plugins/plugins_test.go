@@ -0,0 +1,104 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string, mode os.FileMode) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), mode))
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin executables are detected by permission bits")
+	}
+
+	first := t.TempDir()
+	second := t.TempDir()
+
+	writeFile(t, filepath.Join(first, "claude-reactor-deploy"), "#!/bin/sh\n", 0755)
+	writeFile(t, filepath.Join(first, "claude-reactor-deploy.yaml"), "short: Deploy the project\nargs: [staging, production]\n", 0644)
+	writeFile(t, filepath.Join(first, "claude-reactor-notes"), "not executable", 0644)
+	writeFile(t, filepath.Join(first, "other-tool"), "#!/bin/sh\n", 0755)
+	writeFile(t, filepath.Join(second, "claude-reactor-deploy"), "#!/bin/sh\n", 0755)
+	writeFile(t, filepath.Join(second, "claude-reactor-audit"), "#!/bin/sh\n", 0755)
+	writeFile(t, filepath.Join(second, "claude-reactor-audit.yml"), "short: [unclosed", 0644)
+	require.NoError(t, os.Mkdir(filepath.Join(second, "claude-reactor-dir"), 0755))
+
+	pathList := first + string(os.PathListSeparator) + filepath.Join(first, "missing") + string(os.PathListSeparator) + second
+	discovered := Discover(pathList)
+	require.Len(t, discovered, 2)
+
+	audit, deploy := discovered[0], discovered[1]
+	assert.Equal(t, "audit", audit.Name)
+	assert.Nil(t, audit.Manifest)
+	assert.Error(t, audit.ManifestError)
+
+	assert.Equal(t, "deploy", deploy.Name)
+	assert.Equal(t, filepath.Join(first, "claude-reactor-deploy"), deploy.Path)
+	assert.Equal(t, []string{filepath.Join(second, "claude-reactor-deploy")}, deploy.Shadowed)
+	require.NotNil(t, deploy.Manifest)
+	assert.NoError(t, deploy.ManifestError)
+	assert.Equal(t, "Deploy the project", deploy.Manifest.Short)
+	assert.Equal(t, []string{"staging", "production"}, deploy.Manifest.Args)
+
+	assert.Empty(t, Discover(""))
+}
+
+func TestPluginName(t *testing.T) {
+	name, ok := pluginName("claude-reactor-deploy")
+	assert.True(t, ok)
+	assert.Equal(t, "deploy", name)
+
+	for _, fileName := range []string{"claude-reactor-", "claude-reactor-deploy.yaml", "claude-reactor-deploy.yml", "kubectl-foo", "claude-reactor-a b"} {
+		_, ok := pluginName(fileName)
+		assert.False(t, ok, fileName)
+	}
+}
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "claude-reactor-lint")
+
+	manifest, err := LoadManifest(executable)
+	assert.NoError(t, err)
+	assert.Nil(t, manifest)
+
+	writeFile(t, executable+".yml", "short: Lint\nflags: [--fix]\n", 0644)
+	manifest, err = LoadManifest(executable)
+	require.NoError(t, err)
+	assert.Equal(t, "Lint", manifest.Short)
+	assert.Equal(t, []string{"--fix"}, manifest.Flags)
+}
+
+func TestManifestComplete(t *testing.T) {
+	manifest := &Manifest{
+		Args:  []string{"staging", "production"},
+		Flags: []string{"--dry-run", "--force"},
+	}
+
+	assert.Equal(t, []string{"staging", "production"}, manifest.Complete(nil, ""))
+	assert.Equal(t, []string{"production"}, manifest.Complete(nil, "pr"))
+	assert.Nil(t, manifest.Complete([]string{"staging"}, ""))
+	assert.Equal(t, []string{"--dry-run"}, manifest.Complete([]string{"staging"}, "--d"))
+	assert.Equal(t, []string{"--dry-run", "--force"}, manifest.Complete(nil, "-"))
+
+	var none *Manifest
+	assert.Nil(t, none.Complete(nil, ""))
+}
+
+func TestPluginCommand(t *testing.T) {
+	plugin := &Plugin{Name: "deploy", Path: "/usr/local/bin/claude-reactor-deploy"}
+	cmd := plugin.Command([]string{"staging", "--dry-run"})
+
+	assert.Equal(t, []string{plugin.Path, "staging", "--dry-run"}, cmd.Args)
+	assert.Contains(t, cmd.Env, "CLAUDE_REACTOR_PLUGIN=deploy")
+	assert.Equal(t, os.Stdout, cmd.Stdout)
+}
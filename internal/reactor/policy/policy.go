@@ -0,0 +1,169 @@
+// Package policy restricts the custom images claude-reactor runs. Containers get the
+// project, Claude credentials, and optionally the host Docker socket, so organisations may
+// want to allow only vetted images.
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"claude-reactor/internal/reactor/docker/validation"
+	"claude-reactor/internal/reactor/registry"
+	"claude-reactor/pkg"
+)
+
+// FileName is the name of policy files
+const FileName = "policy.yaml"
+
+// systemDir holds the policy administrators manage; a var so tests can move it
+var systemDir = defaultSystemDir()
+
+// Policy limits which custom images may be run
+type Policy struct {
+	// AllowedImages lists repositories custom images may come from. An entry ending in / or
+	// naming only a registry allows everything under it: ghcr.io/myorg/, registry.corp.
+	// Other entries allow one repository: ubuntu, ghcr.io/myorg/dev. Empty allows any image.
+	AllowedImages []string `yaml:"allowed_images"`
+
+	// RequireDigest only allows custom images pinned by digest, e.g. ubuntu@sha256:...
+	RequireDigest bool `yaml:"require_digest"`
+
+	// Signatures lists the cosign signers pulled images are verified against
+	Signatures pkg.SignaturePolicy `yaml:"signatures"`
+
+	// RequiredTools and RecommendedTools list tools images must and should provide, as
+	// name[:package][>=version]. Projects can add their own but not drop these.
+	RequiredTools    []string `yaml:"required_tools"`
+	RecommendedTools []string `yaml:"recommended_tools"`
+
+	// Source is the file the policy was read from, "" when no policy is configured
+	Source string `yaml:"-"`
+}
+
+func defaultSystemDir() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("ProgramData"), "claude-reactor")
+	}
+	return "/etc/claude-reactor"
+}
+
+// SystemPath returns the policy file administrators manage. When it exists it is the only
+// policy applied, so users cannot loosen it.
+func SystemPath() string {
+	return filepath.Join(systemDir, FileName)
+}
+
+// UserPath returns the policy file in ~/.claude-reactor, used when there is no system policy
+func UserPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".claude-reactor", FileName)
+}
+
+// Load returns the system policy, else the user policy, else an empty policy allowing any image
+func Load() (*Policy, error) {
+	for _, path := range []string{SystemPath(), UserPath()} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		return LoadFile(path)
+	}
+	return &Policy{}, nil
+}
+
+// LoadFile reads a policy file
+func LoadFile(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image policy: %w", err)
+	}
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid image policy %s: %w", path, err)
+	}
+	p.Source = path
+	if p.Signatures.Required && !p.Signatures.Configured() {
+		return nil, fmt.Errorf("invalid image policy %s: signatures.required needs keys or identities to verify with", path)
+	}
+	for _, identity := range p.Signatures.Identities {
+		if identity.Issuer == "" || identity.Subject == "" {
+			return nil, fmt.Errorf("invalid image policy %s: signature identities need an issuer and a subject", path)
+		}
+	}
+	if _, err := p.Tools(); err != nil {
+		return nil, fmt.Errorf("invalid image policy %s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// Tools returns the policy's required and recommended tools
+func (p *Policy) Tools() ([]pkg.ToolRequirement, error) {
+	var tools []pkg.ToolRequirement
+	for _, list := range []struct {
+		specs    []string
+		required bool
+	}{{p.RequiredTools, true}, {p.RecommendedTools, false}} {
+		for _, spec := range list.specs {
+			tool, err := validation.ParseToolSpec(spec, list.required)
+			if err != nil {
+				return nil, err
+			}
+			tools = append(tools, tool)
+		}
+	}
+	return tools, nil
+}
+
+// Check returns an error if the policy does not allow running image
+func (p *Policy) Check(image string) error {
+	if p.RequireDigest && registry.Digest(image) == "" {
+		return pkg.NewError(pkg.CodeImageNotPinned, nil, "image '%s' must be pinned by digest (image@sha256:...) by the policy in %s", image, p.Source).
+			WithRemediation("Find the digest with: docker buildx imagetools inspect " + image)
+	}
+	if len(p.AllowedImages) == 0 || p.allows(registry.Repository(image)) {
+		return nil
+	}
+	return fmt.Errorf("image '%s' is not allowed by the policy in %s (allowed: %s)", image, p.Source, strings.Join(p.AllowedImages, ", "))
+}
+
+// allows reports whether a fully qualified repository matches an allowed_images entry
+func (p *Policy) allows(repository string) bool {
+	for _, entry := range p.AllowedImages {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+			continue
+		case isRegistry(entry):
+			if strings.HasPrefix(repository, entry+"/") {
+				return true
+			}
+		case strings.HasSuffix(entry, "/"):
+			// Namespaces without a registry are on Docker Hub, like the images in them
+			prefix := strings.TrimSuffix(entry, "/")
+			if registry.Host(prefix+"/image") == "docker.io" && prefix != "docker.io" && !strings.HasPrefix(prefix, "docker.io/") {
+				prefix = "docker.io/" + prefix
+			}
+			if strings.HasPrefix(repository, prefix+"/") {
+				return true
+			}
+		case registry.Repository(entry) == repository:
+			return true
+		}
+	}
+	return false
+}
+
+// isRegistry reports whether entry names only a registry host, such as registry.corp:5000
+func isRegistry(entry string) bool {
+	return !strings.Contains(entry, "/") && (strings.ContainsAny(entry, ".:") || entry == "localhost")
+}
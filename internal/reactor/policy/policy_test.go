@@ -0,0 +1,128 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+const pinned = "ubuntu@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestPolicyCheck(t *testing.T) {
+	t.Run("empty policy allows any image", func(t *testing.T) {
+		assert.NoError(t, (&Policy{}).Check("anything/goes:latest"))
+	})
+
+	t.Run("allowed images", func(t *testing.T) {
+		p := &Policy{Source: "policy.yaml", AllowedImages: []string{"ghcr.io/myorg/", "registry.corp:5000", "ubuntu", "bitnami/"}}
+
+		for _, image := range []string{
+			"ghcr.io/myorg/dev:1.0",
+			"ghcr.io/myorg/team/tools",
+			"registry.corp:5000/anything",
+			"ubuntu:22.04",
+			"docker.io/library/ubuntu",
+			pinned,
+			"bitnami/redis:7",
+		} {
+			assert.NoError(t, p.Check(image), image)
+		}
+
+		for _, image := range []string{
+			"ghcr.io/other/dev",
+			"ghcr.io/myorganisation/dev",
+			"debian:12",
+			"evil.io/ubuntu",
+		} {
+			assert.ErrorContains(t, p.Check(image), "is not allowed by the policy in policy.yaml", image)
+		}
+	})
+
+	t.Run("digest pinning", func(t *testing.T) {
+		p := &Policy{Source: "policy.yaml", RequireDigest: true}
+		assert.NoError(t, p.Check(pinned))
+		assert.ErrorContains(t, p.Check("ubuntu:22.04"), "must be pinned by digest")
+	})
+}
+
+func TestLoad(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	original := systemDir
+	systemDir = t.TempDir()
+	defer func() { systemDir = original }()
+
+	p, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, p.Source, "no policy files means no policy")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".claude-reactor"), 0755))
+	require.NoError(t, os.WriteFile(UserPath(), []byte("allowed_images: [ubuntu]\n"), 0644))
+	p, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, UserPath(), p.Source)
+	assert.Equal(t, []string{"ubuntu"}, p.AllowedImages)
+
+	require.NoError(t, os.WriteFile(SystemPath(), []byte("allowed_images:\n  - ghcr.io/myorg/\nrequire_digest: true\n"), 0644))
+	p, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, SystemPath(), p.Source, "the system policy replaces the user's")
+	assert.True(t, p.RequireDigest)
+
+	require.NoError(t, os.WriteFile(SystemPath(), []byte("allowed_images: {"), 0644))
+	_, err = Load()
+	assert.ErrorContains(t, err, "invalid image policy")
+}
+
+func TestLoadFileSignatures(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, FileName)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	p, err := LoadFile(write(`signatures:
+  required: true
+  keys: [/etc/claude-reactor/cosign.pub]
+  identities:
+    - issuer: https://token.actions.githubusercontent.com
+      subject: ^https://github.com/myorg/
+`))
+	require.NoError(t, err)
+	assert.True(t, p.Signatures.Required)
+	assert.Equal(t, []string{"/etc/claude-reactor/cosign.pub"}, p.Signatures.Keys)
+	require.Len(t, p.Signatures.Identities, 1)
+	assert.Equal(t, "^https://github.com/myorg/", p.Signatures.Identities[0].Subject)
+
+	_, err = LoadFile(write("signatures:\n  required: true\n"))
+	assert.ErrorContains(t, err, "needs keys or identities")
+
+	_, err = LoadFile(write("signatures:\n  identities:\n    - subject: me@example.com\n"))
+	assert.ErrorContains(t, err, "need an issuer and a subject")
+}
+
+func TestLoadFileTools(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	require.NoError(t, os.WriteFile(path, []byte("required_tools: [git, \"protoc:protobuf-compiler>=3.21\"]\nrecommended_tools: [jq]\n"), 0644))
+
+	p, err := LoadFile(path)
+	require.NoError(t, err)
+	tools, err := p.Tools()
+	require.NoError(t, err)
+	assert.Equal(t, []pkg.ToolRequirement{
+		{Name: "git", Required: true},
+		{Name: "protoc", Package: "protobuf-compiler", MinVersion: "3.21", Required: true},
+		{Name: "jq"},
+	}, tools)
+
+	require.NoError(t, os.WriteFile(path, []byte("required_tools: [\"git>=latest\"]\n"), 0644))
+	_, err = LoadFile(path)
+	assert.ErrorContains(t, err, "invalid version 'latest' for tool git")
+}
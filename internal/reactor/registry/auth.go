@@ -0,0 +1,127 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	registrytypes "github.com/docker/docker/api/types/registry"
+)
+
+// dockerHubAuthKey is where docker login stores Docker Hub credentials
+const dockerHubAuthKey = "https://index.docker.io/v1/"
+
+// dockerConfig is the part of ~/.docker/config.json holding registry credentials
+type dockerConfig struct {
+	Auths       map[string]registrytypes.AuthConfig `json:"auths"`
+	CredsStore  string                              `json:"credsStore"`
+	CredHelpers map[string]string                   `json:"credHelpers"`
+}
+
+// credential is what a docker-credential-* helper returns
+type credential struct {
+	Username string `json:"Username"`
+	Secret   string `json:"Secret"`
+}
+
+// Host returns the registry host of an image reference, docker.io for Docker Hub images
+func Host(ref string) string {
+	host, _, found := strings.Cut(ref, "/")
+	if !found || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		return "docker.io"
+	}
+	return host
+}
+
+// Auth returns the credentials docker login stored for the registry hosting ref, encoded for
+// the Docker API. Without stored credentials it returns "" and requests go anonymously.
+func Auth(ref string) (string, error) {
+	config, err := loadDockerConfig()
+	if err != nil || config == nil {
+		return "", err
+	}
+
+	host := Host(ref)
+	key := host
+	if host == "docker.io" {
+		key = dockerHubAuthKey
+	}
+
+	helper := config.CredsStore
+	if h, ok := config.CredHelpers[host]; ok {
+		helper = h
+	}
+	if helper != "" {
+		if auth, ok := helperAuth(helper, key); ok {
+			return registrytypes.EncodeAuthConfig(auth)
+		}
+	}
+
+	for server, auth := range config.Auths {
+		if normalizeServer(server) == normalizeServer(key) {
+			auth.ServerAddress = key
+			return registrytypes.EncodeAuthConfig(auth)
+		}
+	}
+	return "", nil
+}
+
+// loadDockerConfig reads the docker CLI config, honouring DOCKER_CONFIG; nil if there is none
+func loadDockerConfig() (*dockerConfig, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read docker config: %w", err)
+	}
+	var config dockerConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config: %w", err)
+	}
+	return &config, nil
+}
+
+// helperAuth asks a docker-credential-* helper for the credentials of server
+func helperAuth(helper, server string) (registrytypes.AuthConfig, bool) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return registrytypes.AuthConfig{}, false
+	}
+
+	var cred credential
+	if err := json.Unmarshal(stdout.Bytes(), &cred); err != nil || cred.Secret == "" {
+		return registrytypes.AuthConfig{}, false
+	}
+	auth := registrytypes.AuthConfig{ServerAddress: server}
+	// Helpers return identity tokens under this username
+	if cred.Username == "<token>" {
+		auth.IdentityToken = cred.Secret
+	} else {
+		auth.Username, auth.Password = cred.Username, cred.Secret
+	}
+	return auth, true
+}
+
+// normalizeServer strips the scheme and path docker login sometimes stores with a host
+func normalizeServer(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	host, _, _ := strings.Cut(server, "/")
+	return host
+}
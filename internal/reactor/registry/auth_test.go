@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	registrytypes "github.com/docker/docker/api/types/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHost(t *testing.T) {
+	assert.Equal(t, "ghcr.io", Host("ghcr.io/dyluth/claude-reactor/go:latest"))
+	assert.Equal(t, "localhost:5000", Host("localhost:5000/team/go"))
+	assert.Equal(t, "localhost", Host("localhost/go"))
+	assert.Equal(t, "docker.io", Host("library/ubuntu"))
+	assert.Equal(t, "docker.io", Host("ubuntu:22.04"))
+}
+
+func TestAuth(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", dir)
+
+	auth, err := Auth("my.registry/team/go:v1")
+	require.NoError(t, err)
+	assert.Empty(t, auth, "no docker config means anonymous requests")
+
+	config := `{"auths": {
+		"https://my.registry/v2/": {"auth": "dXNlcjpwYXNz"},
+		"https://index.docker.io/v1/": {"auth": "aHViOnNlY3JldA=="}
+	}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(config), 0600))
+
+	decode := func(encoded string) registrytypes.AuthConfig {
+		data, err := base64.URLEncoding.DecodeString(encoded)
+		require.NoError(t, err)
+		var auth registrytypes.AuthConfig
+		require.NoError(t, json.Unmarshal(data, &auth))
+		return auth
+	}
+
+	auth, err = Auth("my.registry/team/go:v1")
+	require.NoError(t, err)
+	assert.Equal(t, "dXNlcjpwYXNz", decode(auth).Auth)
+	assert.Equal(t, "my.registry", decode(auth).ServerAddress)
+
+	auth, err = Auth("someone/image")
+	require.NoError(t, err)
+	assert.Equal(t, "aHViOnNlY3JldA==", decode(auth).Auth)
+
+	auth, err = Auth("other.registry/image")
+	require.NoError(t, err)
+	assert.Empty(t, auth)
+}
@@ -0,0 +1,221 @@
+// Package registry pulls published images, through a registry mirror when one is configured.
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+
+	"claude-reactor/pkg"
+)
+
+// DefaultRegistry hosts the published claude-reactor images; only its images are mirrored
+const DefaultRegistry = "ghcr.io"
+
+// MirrorEnv overrides the registry_mirror setting, e.g. for a whole team's shell profile
+const MirrorEnv = "CLAUDE_REACTOR_REGISTRY_MIRROR"
+
+// healthTimeout bounds the mirror health check so an unreachable mirror costs little
+const healthTimeout = 3 * time.Second
+
+// health remembers mirror health check results for the life of the process
+var health sync.Map // base URL -> error (nil when healthy)
+
+// Mirror is a pull-through cache of DefaultRegistry, such as a registry:2 proxy or a Harbor
+// proxy cache project
+type Mirror struct {
+	scheme string // https, or http for mirrors without TLS
+	host   string // host[:port]
+	prefix string // path under which the mirror serves DefaultRegistry, e.g. a Harbor project
+
+	check func(ctx context.Context) error
+}
+
+// Resolve returns the mirror to use: MirrorEnv if set, otherwise the configured one
+func Resolve(configured string) string {
+	if mirror := os.Getenv(MirrorEnv); mirror != "" {
+		return mirror
+	}
+	return configured
+}
+
+// ParseMirror parses a mirror such as harbor.example.com/ghcr-proxy, registry.local:5000,
+// or http://10.0.0.5:5000. An empty value means no mirror and returns nil.
+func ParseMirror(value string) (*Mirror, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") || u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("invalid registry mirror '%s': use host[:port][/path], optionally with http://", strings.TrimPrefix(value, "https://"))
+	}
+
+	m := &Mirror{
+		scheme: u.Scheme,
+		host:   u.Host,
+		prefix: strings.Trim(u.Path, "/"),
+	}
+	m.check = m.ping
+	return m, nil
+}
+
+// String returns the mirror as configured, without the default https scheme
+func (m *Mirror) String() string {
+	base := m.host
+	if m.prefix != "" {
+		base += "/" + m.prefix
+	}
+	if m.scheme == "http" {
+		return "http://" + base
+	}
+	return base
+}
+
+// Rewrite returns ref as served by the mirror. Images from registries other than
+// DefaultRegistry are not mirrored and return false.
+func (m *Mirror) Rewrite(ref string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	registry, path, found := strings.Cut(ref, "/")
+	if !found || registry != DefaultRegistry {
+		return "", false
+	}
+
+	mirrored := m.host
+	if m.prefix != "" {
+		mirrored += "/" + m.prefix
+	}
+	return mirrored + "/" + path, true
+}
+
+// Check verifies the mirror answers the registry API. The result is remembered, so only the
+// first pull of a run waits for it.
+func (m *Mirror) Check(ctx context.Context) error {
+	key := m.scheme + "://" + m.host
+	if result, ok := health.Load(key); ok {
+		err, _ := result.(error)
+		return err
+	}
+	err := m.check(ctx)
+	health.Store(key, err)
+	return err
+}
+
+// ping requests the registry API root, which answers 200, or 401 when it requires a login
+func (m *Mirror) ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.scheme+"://"+m.host+"/v2/", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("mirror %s is unreachable: %w", m.host, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnauthorized {
+		return fmt.Errorf("mirror %s is not a registry: /v2/ returned %s", m.host, resp.Status)
+	}
+	return nil
+}
+
+// Pull pulls ref, through mirror when it is set, healthy, and mirrors ref. A mirrored image is
+// tagged as ref, so callers never see the mirror's name. Any mirror failure falls back to
+// pulling ref directly.
+func Pull(ctx context.Context, cli client.ImageAPIClient, ref string, options image.PullOptions, mirror *Mirror, logger pkg.Logger) error {
+	_, err := PullLayers(ctx, cli, ref, options, mirror, logger)
+	return err
+}
+
+// Layers counts the layers of a pulled image, and those of them that were already present
+type Layers struct {
+	Total   int
+	Present int
+}
+
+// PullLayers pulls ref like Pull, counting the image's layers from the pull progress
+func PullLayers(ctx context.Context, cli client.ImageAPIClient, ref string, options image.PullOptions, mirror *Mirror, logger pkg.Logger) (Layers, error) {
+	if mirrored, ok := mirror.Rewrite(ref); ok {
+		if err := mirror.Check(ctx); err != nil {
+			logger.Warnf("⚠️  Skipping registry mirror: %v", err)
+		} else if layers, err := pullMirrored(ctx, cli, ref, mirrored, options); err != nil {
+			logger.Warnf("⚠️  Pull from registry mirror failed, falling back to %s: %v", DefaultRegistry, err)
+		} else {
+			logger.Debugf("Pulled %s from mirror as %s", ref, mirrored)
+			return layers, nil
+		}
+	}
+	return pull(ctx, cli, ref, options)
+}
+
+// pullMirrored pulls mirrored and retags it as ref
+func pullMirrored(ctx context.Context, cli client.ImageAPIClient, ref, mirrored string, options image.PullOptions) (Layers, error) {
+	layers, err := pull(ctx, cli, mirrored, options)
+	if err != nil {
+		return layers, err
+	}
+	if err := cli.ImageTag(ctx, mirrored, ref); err != nil {
+		return layers, fmt.Errorf("failed to tag %s as %s: %w", mirrored, ref, err)
+	}
+	// Only the mirror's tag is removed; the image stays under ref
+	cli.ImageRemove(ctx, mirrored, image.RemoveOptions{})
+	return layers, nil
+}
+
+// pull pulls ref and waits for the pull to finish
+func pull(ctx context.Context, cli client.ImageAPIClient, ref string, options image.PullOptions) (Layers, error) {
+	response, err := cli.ImagePull(ctx, ref, options)
+	if err != nil {
+		return Layers{}, err
+	}
+	defer response.Close()
+	layers, err := countLayers(response)
+	if err != nil {
+		return layers, fmt.Errorf("failed to complete pull of %s: %w", ref, err)
+	}
+	return layers, nil
+}
+
+// countLayers reads pull progress to the end. Each layer finishes as "Already exists" or
+// "Pull complete"; other progress is ignored.
+func countLayers(progress io.Reader) (Layers, error) {
+	var layers Layers
+	decoder := json.NewDecoder(progress)
+	for {
+		var message struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		}
+		if err := decoder.Decode(&message); err == io.EOF {
+			return layers, nil
+		} else if err != nil {
+			// Not progress we understand; still wait for the pull to finish
+			_, err = io.Copy(io.Discard, progress)
+			return layers, err
+		}
+		switch message.Status {
+		case "Already exists":
+			layers.Total++
+			layers.Present++
+		case "Pull complete":
+			layers.Total++
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/logging"
+)
+
+func TestParseMirror(t *testing.T) {
+	mirror, err := ParseMirror("")
+	require.NoError(t, err)
+	assert.Nil(t, mirror)
+
+	mirror, err = ParseMirror("harbor.example.com/ghcr-proxy/")
+	require.NoError(t, err)
+	assert.Equal(t, "harbor.example.com/ghcr-proxy", mirror.String())
+
+	mirror, err = ParseMirror("http://10.0.0.5:5000")
+	require.NoError(t, err)
+	assert.Equal(t, "http://10.0.0.5:5000", mirror.String())
+
+	_, err = ParseMirror("ftp://mirror.local")
+	assert.ErrorContains(t, err, "invalid registry mirror")
+}
+
+func TestMirrorRewrite(t *testing.T) {
+	mirror, err := ParseMirror("harbor.example.com/ghcr-proxy")
+	require.NoError(t, err)
+
+	mirrored, ok := mirror.Rewrite("ghcr.io/dyluth/claude-reactor/go:latest")
+	assert.True(t, ok)
+	assert.Equal(t, "harbor.example.com/ghcr-proxy/dyluth/claude-reactor/go:latest", mirrored)
+
+	_, ok = mirror.Rewrite("docker.io/library/ubuntu:22.04")
+	assert.False(t, ok, "only the default registry is mirrored")
+	_, ok = mirror.Rewrite("ubuntu")
+	assert.False(t, ok)
+
+	var none *Mirror
+	_, ok = none.Rewrite("ghcr.io/dyluth/claude-reactor/go:latest")
+	assert.False(t, ok)
+}
+
+func TestResolvePrefersEnvironment(t *testing.T) {
+	t.Setenv(MirrorEnv, "")
+	assert.Equal(t, "mirror.local", Resolve("mirror.local"))
+
+	t.Setenv(MirrorEnv, "team-mirror.local")
+	assert.Equal(t, "team-mirror.local", Resolve("mirror.local"))
+}
+
+func TestMirrorCheck(t *testing.T) {
+	for status, healthy := range map[int]bool{http.StatusOK: true, http.StatusUnauthorized: true, http.StatusNotFound: false} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v2/", r.URL.Path)
+			w.WriteHeader(status)
+		}))
+		mirror, err := ParseMirror(server.URL)
+		require.NoError(t, err)
+
+		err = mirror.Check(context.Background())
+		assert.Equal(t, healthy, err == nil, "status %d", status)
+		server.Close()
+	}
+}
+
+// pullClient records pulls, failing those of refs in failing
+type pullClient struct {
+	client.ImageAPIClient
+	failing map[string]bool
+	pulled  []string
+	tagged  map[string]string
+}
+
+func (c *pullClient) ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error) {
+	c.pulled = append(c.pulled, ref)
+	if c.failing[ref] {
+		return nil, errors.New("manifest unknown")
+	}
+	return io.NopCloser(strings.NewReader(`{"status":"Downloaded"}`)), nil
+}
+
+func (c *pullClient) ImageTag(ctx context.Context, source, target string) error {
+	c.tagged[target] = source
+	return nil
+}
+
+func (c *pullClient) ImageRemove(ctx context.Context, ref string, options image.RemoveOptions) ([]image.DeleteResponse, error) {
+	return nil, nil
+}
+
+func TestPull(t *testing.T) {
+	logger := logging.NewLogger()
+	ref := "ghcr.io/dyluth/claude-reactor/go:latest"
+	mirrored := "mirror.local/dyluth/claude-reactor/go:latest"
+
+	newMirror := func(checkErr error) *Mirror {
+		mirror, err := ParseMirror("mirror.local")
+		require.NoError(t, err)
+		mirror.check = func(context.Context) error { return checkErr }
+		return mirror
+	}
+
+	t.Run("pulls through a healthy mirror and tags the original name", func(t *testing.T) {
+		health.Delete("https://mirror.local")
+		cli := &pullClient{tagged: map[string]string{}}
+		require.NoError(t, Pull(context.Background(), cli, ref, image.PullOptions{}, newMirror(nil), logger))
+		assert.Equal(t, []string{mirrored}, cli.pulled)
+		assert.Equal(t, mirrored, cli.tagged[ref])
+	})
+
+	t.Run("falls back when the mirror pull fails", func(t *testing.T) {
+		health.Delete("https://mirror.local")
+		cli := &pullClient{tagged: map[string]string{}, failing: map[string]bool{mirrored: true}}
+		require.NoError(t, Pull(context.Background(), cli, ref, image.PullOptions{}, newMirror(nil), logger))
+		assert.Equal(t, []string{mirrored, ref}, cli.pulled)
+	})
+
+	t.Run("skips an unhealthy mirror", func(t *testing.T) {
+		health.Delete("https://mirror.local")
+		cli := &pullClient{tagged: map[string]string{}}
+		require.NoError(t, Pull(context.Background(), cli, ref, image.PullOptions{}, newMirror(errors.New("unreachable")), logger))
+		assert.Equal(t, []string{ref}, cli.pulled)
+	})
+
+	t.Run("pulls directly without a mirror", func(t *testing.T) {
+		cli := &pullClient{tagged: map[string]string{}}
+		require.NoError(t, Pull(context.Background(), cli, ref, image.PullOptions{}, nil, logger))
+		assert.Equal(t, []string{ref}, cli.pulled)
+	})
+}
+
+func TestCountLayers(t *testing.T) {
+	progress := `{"status":"Pulling from dyluth/claude-reactor/go","id":"latest"}
+{"status":"Already exists","progressDetail":{},"id":"a1"}
+{"status":"Pulling fs layer","progressDetail":{},"id":"b2"}
+{"status":"Already exists","progressDetail":{},"id":"c3"}
+{"status":"Downloading","progressDetail":{"current":512,"total":1024},"id":"b2"}
+{"status":"Pull complete","progressDetail":{},"id":"b2"}
+{"status":"Digest: sha256:0123"}
+{"status":"Status: Downloaded newer image for ghcr.io/dyluth/claude-reactor/go:latest"}
+`
+	layers, err := countLayers(strings.NewReader(progress))
+	require.NoError(t, err)
+	assert.Equal(t, Layers{Total: 3, Present: 2}, layers)
+
+	layers, err = countLayers(strings.NewReader("not json"))
+	require.NoError(t, err, "unexpected progress is drained, not an error")
+	assert.Equal(t, Layers{}, layers)
+}
@@ -0,0 +1,27 @@
+package registry
+
+import "strings"
+
+// Repository returns the fully qualified repository of an image reference, without tag or
+// digest: ubuntu:22.04 is docker.io/library/ubuntu
+func Repository(ref string) string {
+	name, _, _ := strings.Cut(ref, "@")
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+
+	host := Host(name)
+	if host != "docker.io" || strings.HasPrefix(name, "docker.io/") {
+		return name
+	}
+	if !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	return "docker.io/" + name
+}
+
+// Digest returns the digest an image reference is pinned to, such as sha256:..., or ""
+func Digest(ref string) string {
+	_, digest, _ := strings.Cut(ref, "@")
+	return digest
+}
@@ -0,0 +1,21 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	assert.Equal(t, "docker.io/library/ubuntu", Repository("ubuntu"))
+	assert.Equal(t, "docker.io/library/ubuntu", Repository("ubuntu:22.04"))
+	assert.Equal(t, "docker.io/bitnami/redis", Repository("bitnami/redis:7"))
+	assert.Equal(t, "docker.io/library/ubuntu", Repository("docker.io/library/ubuntu@sha256:abc"))
+	assert.Equal(t, "ghcr.io/org/dev", Repository("ghcr.io/org/dev:1.2@sha256:abc"))
+	assert.Equal(t, "localhost:5000/dev", Repository("localhost:5000/dev"))
+}
+
+func TestDigest(t *testing.T) {
+	assert.Equal(t, "sha256:abc", Digest("ubuntu@sha256:abc"))
+	assert.Equal(t, "", Digest("ubuntu:22.04"))
+}
@@ -0,0 +1,240 @@
+// Package rpc serves a JSON-RPC 2.0 API over a unix socket, one JSON message per line.
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"claude-reactor/pkg"
+)
+
+// APIVersion is bumped whenever a method or result shape changes incompatibly
+const APIVersion = 1
+
+// Standard JSON-RPC 2.0 error codes, plus ServerError for failures inside a handler
+const (
+	ParseError     = -32700
+	InvalidRequest = -32600
+	MethodNotFound = -32601
+	InvalidParams  = -32602
+	ServerError    = -32000
+)
+
+// maxMessageSize bounds a single request line
+const maxMessageSize = 4 * 1024 * 1024
+
+// Handler runs one method. A returned *Error is sent as-is; any other error becomes a ServerError.
+type Handler func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+// Error is a JSON-RPC error object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("rpc error %d: %s", e.Code, e.Message)
+}
+
+// request is an incoming JSON-RPC message. ID is absent for notifications.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is an outgoing JSON-RPC message
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Server dispatches JSON-RPC requests to registered handlers
+type Server struct {
+	logger   pkg.Logger
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewServer creates a server with no methods registered
+func NewServer(logger pkg.Logger) *Server {
+	return &Server{logger: logger, handlers: make(map[string]Handler)}
+}
+
+// Register adds a method, replacing any existing handler with the same name
+func (s *Server) Register(method string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// Listen creates a unix socket listener at path, replacing a stale socket left by a server
+// that did not shut down cleanly. Anything else already at path is left alone and reported.
+// The socket is only accessible to the current user.
+func Listen(path string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s already exists and is not a socket", path)
+		}
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another server is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	// Windows sockets are protected by the directory ACL; mode bits do not apply
+	if err := os.Chmod(path, 0600); err != nil && runtime.GOOS != "windows" {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// Serve accepts connections until ctx is cancelled or the listener is closed
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.ServeConn(ctx, conn)
+		}()
+	}
+}
+
+// ServeConn handles requests on one connection until the client disconnects. Requests are
+// handled concurrently, so a slow method does not block a quick status check.
+func (s *Server) ServeConn(ctx context.Context, conn net.Conn) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer conn.Close()
+
+	// Unblock the reader when the server shuts down
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	var writeMu sync.Mutex
+	encoder := json.NewEncoder(conn)
+	send := func(resp *response) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		if err := encoder.Encode(resp); err != nil {
+			s.logger.Debugf("Failed to write RPC response: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			send(&response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &Error{Code: ParseError, Message: "invalid JSON"}})
+			continue
+		}
+
+		wg.Add(1)
+		go func(req request) {
+			defer wg.Done()
+			resp := s.handle(ctx, &req)
+			if len(req.ID) > 0 {
+				send(resp)
+			}
+		}(req)
+	}
+}
+
+// handle validates a request and runs its handler
+func (s *Server) handle(ctx context.Context, req *request) *response {
+	resp := &response{JSONRPC: "2.0", ID: req.ID}
+	if len(resp.ID) == 0 {
+		resp.ID = json.RawMessage("null")
+	}
+
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &Error{Code: InvalidRequest, Message: "expected a JSON-RPC 2.0 request with a method"}
+		return resp
+	}
+
+	s.mu.RLock()
+	handler, ok := s.handlers[req.Method]
+	s.mu.RUnlock()
+	if !ok {
+		resp.Error = &Error{Code: MethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+		return resp
+	}
+
+	s.logger.Debugf("RPC %s", req.Method)
+	result, err := handler(ctx, req.Params)
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &Error{Code: ServerError, Message: err.Error()}
+		}
+		resp.Error = rpcErr
+		return resp
+	}
+	if result == nil {
+		result = struct{}{}
+	}
+	resp.Result = result
+	return resp
+}
+
+// DecodeParams unmarshals params into v, reporting failures as InvalidParams. Missing
+// params leave v unchanged so handlers can pre-fill defaults.
+func DecodeParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &Error{Code: InvalidParams, Message: fmt.Sprintf("invalid params: %v", err)}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/logging"
+)
+
+// startServer serves on a temporary socket and returns a connected client
+func startServer(t *testing.T, server *Server) (net.Conn, *bufio.Scanner) {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "rpc.sock")
+	listener, err := Listen(socket)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		server.Serve(ctx, listener)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	conn, err := net.Dial("unix", socket)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, bufio.NewScanner(conn)
+}
+
+// call sends a raw request line and decodes the response line
+func call(t *testing.T, conn net.Conn, scanner *bufio.Scanner, line string) map[string]interface{} {
+	t.Helper()
+	_, err := conn.Write([]byte(line + "\n"))
+	require.NoError(t, err)
+	require.True(t, scanner.Scan())
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &resp))
+	return resp
+}
+
+func TestServer(t *testing.T) {
+	server := NewServer(logging.NewLogger())
+	server.Register("echo", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var p struct {
+			Text string `json:"text"`
+		}
+		if err := DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return map[string]string{"text": p.Text}, nil
+	})
+	server.Register("fail", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		return nil, errors.New("container is not running")
+	})
+	conn, scanner := startServer(t, server)
+
+	t.Run("successful call", func(t *testing.T) {
+		resp := call(t, conn, scanner, `{"jsonrpc":"2.0","id":1,"method":"echo","params":{"text":"hi"}}`)
+		assert.Equal(t, float64(1), resp["id"])
+		assert.Equal(t, map[string]interface{}{"text": "hi"}, resp["result"])
+		assert.Nil(t, resp["error"])
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		resp := call(t, conn, scanner, `{"jsonrpc":"2.0","id":"a","method":"fail"}`)
+		assert.Equal(t, "a", resp["id"])
+		errObj := resp["error"].(map[string]interface{})
+		assert.Equal(t, float64(ServerError), errObj["code"])
+		assert.Equal(t, "container is not running", errObj["message"])
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		resp := call(t, conn, scanner, `{"jsonrpc":"2.0","id":2,"method":"echo","params":{"text":5}}`)
+		assert.Equal(t, float64(InvalidParams), resp["error"].(map[string]interface{})["code"])
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		resp := call(t, conn, scanner, `{"jsonrpc":"2.0","id":3,"method":"nope"}`)
+		assert.Equal(t, float64(MethodNotFound), resp["error"].(map[string]interface{})["code"])
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		resp := call(t, conn, scanner, `{"id":4,"method":"echo"}`)
+		assert.Equal(t, float64(InvalidRequest), resp["error"].(map[string]interface{})["code"])
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		resp := call(t, conn, scanner, `{not json`)
+		assert.Nil(t, resp["id"])
+		assert.Equal(t, float64(ParseError), resp["error"].(map[string]interface{})["code"])
+	})
+
+	t.Run("notifications get no response", func(t *testing.T) {
+		_, err := conn.Write([]byte(`{"jsonrpc":"2.0","method":"echo","params":{"text":"quiet"}}` + "\n"))
+		require.NoError(t, err)
+
+		resp := call(t, conn, scanner, `{"jsonrpc":"2.0","id":5,"method":"echo","params":{"text":"loud"}}`)
+		assert.Equal(t, float64(5), resp["id"])
+	})
+}
+
+func TestListen(t *testing.T) {
+	t.Run("replaces stale socket", func(t *testing.T) {
+		socket := filepath.Join(t.TempDir(), "rpc.sock")
+		stale, err := net.Listen("unix", socket)
+		require.NoError(t, err)
+		stale.(*net.UnixListener).SetUnlinkOnClose(false)
+		stale.Close()
+
+		listener, err := Listen(socket)
+		require.NoError(t, err)
+		defer listener.Close()
+
+		info, err := os.Stat(socket)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("leaves other files alone", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notes.txt")
+		require.NoError(t, os.WriteFile(path, []byte("keep me"), 0600))
+
+		_, err := Listen(path)
+		assert.ErrorContains(t, err, "is not a socket")
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "keep me", string(data))
+	})
+
+	t.Run("refuses socket in use", func(t *testing.T) {
+		socket := filepath.Join(t.TempDir(), "rpc.sock")
+		listener, err := Listen(socket)
+		require.NoError(t, err)
+		defer listener.Close()
+
+		_, err = Listen(socket)
+		assert.Error(t, err)
+	})
+}
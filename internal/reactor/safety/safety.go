@@ -0,0 +1,107 @@
+// Package safety records the run settings that decide what a session can reach, so run can
+// point out risky changes since a project's last session before attaching to a new one: a
+// different account, danger mode or host Docker access turned on, or new mounts of host paths
+// outside the project.
+package safety
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Settings are a session's risky settings
+type Settings struct {
+	Account        string   `json:"account"`
+	Image          string   `json:"image"`
+	Digest         string   `json:"digest,omitempty"`
+	Danger         bool     `json:"danger"`
+	HostDocker     bool     `json:"host_docker"`
+	ExternalMounts []string `json:"external_mounts,omitempty"` // host paths outside the project, as source -> target
+}
+
+// Change is a setting that became riskier since the last run
+type Change struct {
+	Setting string
+	From    string
+	To      string
+}
+
+// Path returns where the settings of a project's last run are kept. They are recorded per
+// project rather than in a session directory, which is per account, so switching account is
+// noticed.
+func Path(projectDir string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	sum := sha256.Sum256([]byte(filepath.Clean(projectDir)))
+	name := filepath.Base(projectDir) + "-" + hex.EncodeToString(sum[:])[:8] + ".json"
+	return filepath.Join(homeDir, ".claude-reactor", "last-run", name), nil
+}
+
+// Load returns the settings recorded at path, or nil if the project has not been run
+func Load(path string) (*Settings, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var settings Settings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &settings, nil
+}
+
+// Save records settings at path as the project's last run
+func Save(path string, settings *Settings) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// IsExternal reports whether a host path is outside the project directory
+func IsExternal(source, projectDir string) bool {
+	rel, err := filepath.Rel(projectDir, source)
+	return err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel)
+}
+
+// RiskyChanges lists the settings of current that are riskier than those of previous. Turning
+// something off, a new image and removed mounts are not risky; nothing is for a first run.
+func RiskyChanges(previous, current *Settings) []Change {
+	if previous == nil {
+		return nil
+	}
+	var changes []Change
+	if current.Account != previous.Account {
+		changes = append(changes, Change{Setting: "account", From: previous.Account, To: current.Account})
+	}
+	if current.Danger && !previous.Danger {
+		changes = append(changes, Change{Setting: "danger mode", From: "off", To: "on"})
+	}
+	if current.HostDocker && !previous.HostDocker {
+		changes = append(changes, Change{Setting: "host Docker", From: "off", To: "on"})
+	}
+	known := make(map[string]bool, len(previous.ExternalMounts))
+	for _, mount := range previous.ExternalMounts {
+		known[mount] = true
+	}
+	for _, mount := range current.ExternalMounts {
+		if !known[mount] {
+			changes = append(changes, Change{Setting: "mount outside the project", From: "none", To: mount})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,65 @@
+package safety
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath(t *testing.T) {
+	t.Setenv("HOME", "/home/me")
+	path, err := Path("/home/me/src/app")
+	require.NoError(t, err)
+	assert.Regexp(t, `^/home/me/\.claude-reactor/last-run/app-[0-9a-f]{8}\.json$`, path)
+
+	other, err := Path("/home/me/work/app")
+	require.NoError(t, err)
+	assert.NotEqual(t, path, other)
+}
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "last-run", "app.json")
+	settings, err := Load(path)
+	require.NoError(t, err)
+	assert.Nil(t, settings)
+
+	saved := &Settings{Account: "work", Image: "claude-reactor-go-amd64", Digest: "sha256:abc", Danger: true, ExternalMounts: []string{"/data -> /mnt/data"}}
+	require.NoError(t, Save(path, saved))
+	settings, err = Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, saved, settings)
+}
+
+func TestIsExternal(t *testing.T) {
+	assert.False(t, IsExternal("/src/app", "/src/app"))
+	assert.False(t, IsExternal("/src/app/docs", "/src/app"))
+	assert.False(t, IsExternal("/src/app/..data", "/src/app"))
+	assert.True(t, IsExternal("/src/app-data", "/src/app"))
+	assert.True(t, IsExternal("/src", "/src/app"))
+	assert.True(t, IsExternal("/etc", "/src/app"))
+}
+
+func TestRiskyChanges(t *testing.T) {
+	previous := &Settings{Account: "personal", Image: "claude-reactor-go-amd64", ExternalMounts: []string{"/data -> /mnt/data"}}
+
+	assert.Empty(t, RiskyChanges(nil, &Settings{Account: "work", Danger: true}), "first run")
+	assert.Empty(t, RiskyChanges(previous, &Settings{Account: "personal", Image: "claude-reactor-full-amd64"}), "new image, mount removed")
+
+	changes := RiskyChanges(previous, &Settings{
+		Account:        "work",
+		Danger:         true,
+		HostDocker:     true,
+		ExternalMounts: []string{"/data -> /mnt/data", "/etc -> /mnt/etc"},
+	})
+	assert.Equal(t, []Change{
+		{Setting: "account", From: "personal", To: "work"},
+		{Setting: "danger mode", From: "off", To: "on"},
+		{Setting: "host Docker", From: "off", To: "on"},
+		{Setting: "mount outside the project", From: "none", To: "/etc -> /mnt/etc"},
+	}, changes)
+
+	// Turning things off is not risky
+	assert.Empty(t, RiskyChanges(&Settings{Account: "work", Danger: true, HostDocker: true}, &Settings{Account: "work"}))
+}
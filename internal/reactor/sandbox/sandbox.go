@@ -0,0 +1,166 @@
+// Package sandbox keeps a copy of the project in a container-private volume, so Claude's edits
+// stay out of the host working tree until they are reviewed and applied as a patch.
+//
+// The copy is tracked by a separate git directory in the session directory. It is not the
+// project's own repository, so Claude's commits and branches in the sandbox don't affect the
+// baseline: the baseline commit records the files as they were copied in, and the sandbox
+// changes are whatever differs from it.
+package sandbox
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Label marks containers whose project is a sandbox, with the name of its volume
+const Label = "claude-reactor.sandbox"
+
+// sessionMount is where the project's session directory is mounted in the container
+const sessionMount = "/home/claude/.claude"
+
+// VolumeName returns the named volume that holds the container's sandbox copy of the project
+func VolumeName(containerName string) string {
+	return containerName + "-sandbox"
+}
+
+// GitDir returns the container path of the git directory tracking the container's sandbox.
+// It is kept in the session directory, so it outlives a recreated container like the volume.
+func GitDir(containerName string) string {
+	return path.Join(sessionMount, "sandbox", VolumeName(containerName)+".git")
+}
+
+// git returns a shell git invocation for the sandbox at target, tracked in gitDir. Ownership of
+// the session directory differs between hosts, so it is trusted explicitly.
+func git(gitDir, target string) string {
+	return fmt.Sprintf("git -c safe.directory='*' -c user.name=claude-reactor -c user.email=claude-reactor@localhost --git-dir=%s --work-tree=%s", gitDir, target)
+}
+
+// EmptyCommand exits 0 when the sandbox at target has not been filled yet
+func EmptyCommand(target string) []string {
+	return []string{"sh", "-c", fmt.Sprintf(`[ -z "$(ls -A %s)" ]`, target)}
+}
+
+// UnpackCommand extracts a project archive from stdin into target, printing tar's errors
+// only when it fails
+func UnpackCommand(target string) []string {
+	return []string{"sh", "-c", fmt.Sprintf(`err=$(tar -xf - -C %s 2>&1) || echo "$err"`, target)}
+}
+
+// BaselineCommand starts tracking the sandbox at target from its current files, replacing any
+// earlier tracking
+func BaselineCommand(gitDir, target string) []string {
+	g := git(gitDir, target)
+	return []string{"sh", "-c", fmt.Sprintf(`set -e; rm -rf %[1]s; mkdir -p %[1]s; %[2]s init -q; %[2]s add -A; %[2]s commit -q --allow-empty -m "sandbox baseline"`, gitDir, g)}
+}
+
+// StageCommand records the sandbox's current files in its git index, ready for DiffCommand
+func StageCommand(gitDir, target string) []string {
+	return []string{"sh", "-c", fmt.Sprintf(`set -e; [ -d %s ] || { echo "the sandbox has no baseline"; exit 1; }; %s add -A`, gitDir, git(gitDir, target))}
+}
+
+// DiffCommand writes the staged sandbox changes as a binary-safe patch against the baseline,
+// with paths relative to the project root, to the patch file read by PatchCommand. Only git's
+// diagnostics are printed, so they cannot end up in the patch.
+func DiffCommand(gitDir, target string) []string {
+	return []string{"sh", "-c", fmt.Sprintf("%s diff --cached --binary --no-color --no-ext-diff --output=%s HEAD", git(gitDir, target), patchFile(gitDir))}
+}
+
+// PatchCommand prints the patch written by DiffCommand
+func PatchCommand(gitDir string) []string {
+	return []string{"cat", patchFile(gitDir)}
+}
+
+// patchFile is where DiffCommand writes the patch, inside the git directory so it is never
+// part of the sandbox's own changes
+func patchFile(gitDir string) string {
+	return gitDir + "/sandbox.patch"
+}
+
+// AcceptCommand moves the baseline to the staged files, once their changes have been applied
+// to the host, so later diffs only show what changed since
+func AcceptCommand(gitDir, target string) []string {
+	return []string{"sh", "-c", git(gitDir, target) + ` commit -q --allow-empty -m "applied to host"`}
+}
+
+// Archive writes the project directory to w as a tar stream, skipping files and directories
+// whose name matches one of ignores (the sync mode ignore patterns: generated directories such
+// as node_modules are rebuilt in the container rather than copied from the host)
+func Archive(projectDir string, ignores []string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := filepath.WalkDir(projectDir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if file == projectDir {
+			return nil
+		}
+		if ignored(entry.Name(), ignores) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		var link string
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			if link, err = os.Readlink(file); err != nil {
+				return err
+			}
+		case !info.Mode().IsRegular() && !info.IsDir():
+			return nil // sockets, pipes and devices don't belong in the copy
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(projectDir, file)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		// Ownership comes from the container user extracting the archive
+		header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive project: %w", err)
+	}
+	return tw.Close()
+}
+
+// ignored reports whether a file or directory name matches one of the ignore patterns
+func ignored(name string, ignores []string) bool {
+	for _, pattern := range ignores {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package sandbox
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeName(t *testing.T) {
+	assert.Equal(t, "claude-reactor-go-abc-sandbox", VolumeName("claude-reactor-go-abc"))
+	assert.Equal(t, "/home/claude/.claude/sandbox/claude-reactor-go-abc-sandbox.git", GitDir("claude-reactor-go-abc"))
+}
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "node_modules", "dep"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "lib.go"), []byte("package src\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "node_modules", "dep", "index.js"), []byte("{}"), 0644))
+	if runtime.GOOS != "windows" {
+		require.NoError(t, os.Symlink("main.go", filepath.Join(dir, "link.go")))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Archive(dir, []string{"node_modules"}, &buf))
+
+	entries := map[string]string{}
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[header.Name] = string(content) + header.Linkname
+	}
+
+	assert.Equal(t, "package main\n", entries["main.go"])
+	assert.Equal(t, "package src\n", entries["src/lib.go"])
+	assert.Contains(t, entries, "src/")
+	assert.NotContains(t, entries, "src/node_modules/")
+	assert.NotContains(t, entries, "src/node_modules/dep/index.js")
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, "main.go", entries["link.go"])
+	}
+}
+
+// run runs one of the sandbox shell commands on the host, as the container would
+func run(t *testing.T, command []string) string {
+	t.Helper()
+	output, err := exec.Command(command[0], command[1:]...).CombinedOutput()
+	require.NoError(t, err, string(output))
+	return string(output)
+}
+
+// diff stages and diffs the sandbox, returning the patch
+func diff(t *testing.T, gitDir, target string) string {
+	t.Helper()
+	run(t, StageCommand(gitDir, target))
+	run(t, DiffCommand(gitDir, target))
+	return run(t, PatchCommand(gitDir))
+}
+
+func TestBaselineAndDiff(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not installed")
+	}
+	target := t.TempDir()
+	gitDir := filepath.Join(t.TempDir(), "sandbox.git")
+	require.NoError(t, os.WriteFile(filepath.Join(target, "keep.txt"), []byte("keep\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "edit.txt"), []byte("before\n"), 0644))
+
+	// Empty until filled
+	empty := t.TempDir()
+	assert.NoError(t, exec.Command(EmptyCommand(empty)[0], EmptyCommand(empty)[1:]...).Run())
+	assert.Error(t, exec.Command(EmptyCommand(target)[0], EmptyCommand(target)[1:]...).Run())
+
+	// Staging needs a baseline
+	output, err := exec.Command(StageCommand(gitDir, target)[0], StageCommand(gitDir, target)[1:]...).CombinedOutput()
+	assert.Error(t, err)
+	assert.Contains(t, string(output), "no baseline")
+
+	run(t, BaselineCommand(gitDir, target))
+	assert.Empty(t, diff(t, gitDir, target))
+
+	require.NoError(t, os.WriteFile(filepath.Join(target, "edit.txt"), []byte("after\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "new.txt"), []byte("new\n"), 0644))
+	patch := diff(t, gitDir, target)
+	assert.Contains(t, patch, "diff --git a/edit.txt b/edit.txt")
+	assert.Contains(t, patch, "+after")
+	assert.Contains(t, patch, "diff --git a/new.txt b/new.txt")
+	assert.NotContains(t, patch, "keep.txt")
+	// The patch goes to a file rather than the command's output, which carries git's warnings
+	assert.Empty(t, run(t, DiffCommand(gitDir, target)))
+
+	// Once accepted, only later changes show
+	run(t, AcceptCommand(gitDir, target))
+	assert.Empty(t, diff(t, gitDir, target))
+
+	// A new baseline replaces the old tracking
+	run(t, BaselineCommand(gitDir, target))
+	assert.Empty(t, diff(t, gitDir, target))
+}
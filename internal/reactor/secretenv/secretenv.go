@@ -0,0 +1,181 @@
+// Package secretenv fetches a project's environment secrets from secret stores on the host:
+// the OS keychain, through macOS's security tool or libsecret's secret-tool, and HashiCorp
+// Vault, through the vault CLI. Values are fetched when a session starts and handed to its
+// processes only, so they never end up in the container, an image layer or the configuration.
+package secretenv
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"claude-reactor/pkg"
+)
+
+// Keychain is the source of secrets kept in the host keychain
+const Keychain = "keychain"
+
+// vaultPrefix starts the source of secrets read from Vault, as vault:PATH#FIELD
+const vaultPrefix = "vault:"
+
+// service names claude-reactor's keychain entries
+const service = "claude-reactor"
+
+// namePattern is a valid environment variable name
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// runCommand runs a host tool with stdin and returns its trimmed output; tests replace it
+var runCommand = func(stdin string, name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("%s not found on the host", name)
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
+
+// ValidateName checks that name can be used as an environment variable
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid secret name '%s': use letters, digits and '_', not starting with a digit", name)
+	}
+	return nil
+}
+
+// ParseVault splits a vault:PATH#FIELD source into its path and field
+func ParseVault(source string) (path, field string, err error) {
+	ref, ok := strings.CutPrefix(source, vaultPrefix)
+	if !ok {
+		return "", "", fmt.Errorf("not a Vault secret: %s", source)
+	}
+	path, field, _ = strings.Cut(ref, "#")
+	if path == "" || field == "" {
+		return "", "", fmt.Errorf("invalid Vault secret '%s': use vault:PATH#FIELD", ref)
+	}
+	return path, field, nil
+}
+
+// Vault returns the source of the secret in field of the Vault secret at path
+func Vault(path, field string) string {
+	return vaultPrefix + path + "#" + field
+}
+
+// ValidateSource checks a secret's source
+func ValidateSource(source string) error {
+	if source == Keychain {
+		return nil
+	}
+	if strings.HasPrefix(source, vaultPrefix) {
+		_, _, err := ParseVault(source)
+		return err
+	}
+	return fmt.Errorf("unknown secret source '%s' (must be keychain or vault:PATH#FIELD)", source)
+}
+
+// Fetch returns the value of a project's secret from its source
+func Fetch(projectPath string, secret pkg.Secret) (string, error) {
+	if secret.Source == Keychain {
+		return keychainLookup(keychainAccount(projectPath, secret.Name))
+	}
+	path, field, err := ParseVault(secret.Source)
+	if err != nil {
+		return "", err
+	}
+	// The vault CLI reads VAULT_ADDR and the token from the host as usual
+	return runCommand("", "vault", "kv", "get", "-field="+field, path)
+}
+
+// Env fetches a project's secrets as KEY=VALUE environment entries
+func Env(projectPath string, secrets []pkg.Secret) ([]string, error) {
+	env := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		value, err := Fetch(projectPath, secret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch secret %s from %s: %w", secret.Name, secret.Source, err)
+		}
+		env = append(env, secret.Name+"="+value)
+	}
+	return env, nil
+}
+
+// Store keeps value as a project's secret in the host keychain
+func Store(projectPath, name, value string) error {
+	account := keychainAccount(projectPath, name)
+	switch runtime.GOOS {
+	case "darwin":
+		// The command is read from stdin, so the password never appears in ps; -U replaces an
+		// existing entry
+		command, err := securityAddCommand(account, value)
+		if err != nil {
+			return err
+		}
+		_, err = runCommand(command, "security", "-i")
+		return err
+	case "linux":
+		_, err := runCommand(value, "secret-tool", "store", "--label="+service+" "+account, "service", service, "account", account)
+		return err
+	}
+	return errNoKeychain()
+}
+
+// securityAddCommand returns the line for 'security -i' storing value as account's password.
+// Arguments are double quoted, escaping backslashes and quotes.
+func securityAddCommand(account, value string) (string, error) {
+	if strings.ContainsAny(value, "\r\n") {
+		return "", fmt.Errorf("secrets with line breaks cannot be stored in the macOS keychain; use a Vault secret instead")
+	}
+	quote := func(arg string) string {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+	}
+	return fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", quote(service), quote(account), quote(value)), nil
+}
+
+// Delete removes a project's secret from the host keychain
+func Delete(projectPath, name string) error {
+	account := keychainAccount(projectPath, name)
+	switch runtime.GOOS {
+	case "darwin":
+		_, err := runCommand("", "security", "delete-generic-password", "-s", service, "-a", account)
+		return err
+	case "linux":
+		_, err := runCommand("", "secret-tool", "clear", "service", service, "account", account)
+		return err
+	}
+	return errNoKeychain()
+}
+
+// keychainLookup reads the keychain entry of account
+func keychainLookup(account string) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return runCommand("", "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux":
+		value, err := runCommand("", "secret-tool", "lookup", "service", service, "account", account)
+		if err == nil && value == "" {
+			// secret-tool exits cleanly without output when nothing matches
+			return "", fmt.Errorf("not in the keychain")
+		}
+		return value, err
+	}
+	return "", errNoKeychain()
+}
+
+// keychainAccount names a project's secret in the keychain, so projects can use the same
+// variable names for different values
+func keychainAccount(projectPath, name string) string {
+	return filepath.Clean(projectPath) + "#" + name
+}
+
+func errNoKeychain() error {
+	return fmt.Errorf("keychain secrets need macOS or Linux with secret-tool; use a Vault secret instead")
+}
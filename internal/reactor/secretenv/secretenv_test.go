@@ -0,0 +1,101 @@
+package secretenv
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+// fakeCommands replaces the host tools with fn for the duration of a test
+func fakeCommands(t *testing.T, fn func(stdin, name string, args ...string) (string, error)) {
+	original := runCommand
+	runCommand = fn
+	t.Cleanup(func() { runCommand = original })
+}
+
+func TestValidateName(t *testing.T) {
+	assert.NoError(t, ValidateName("GITHUB_TOKEN"))
+	assert.NoError(t, ValidateName("_private"))
+	assert.Error(t, ValidateName("1TOKEN"))
+	assert.Error(t, ValidateName("MY-TOKEN"))
+	assert.Error(t, ValidateName(""))
+}
+
+func TestValidateSource(t *testing.T) {
+	assert.NoError(t, ValidateSource("keychain"))
+	assert.NoError(t, ValidateSource("vault:secret/data/app#password"))
+	assert.ErrorContains(t, ValidateSource("vault:secret/data/app"), "vault:PATH#FIELD")
+	assert.ErrorContains(t, ValidateSource("vault:#password"), "vault:PATH#FIELD")
+	assert.ErrorContains(t, ValidateSource("env:TOKEN"), "unknown secret source")
+
+	path, field, err := ParseVault(Vault("kv/ci", "token"))
+	require.NoError(t, err)
+	assert.Equal(t, "kv/ci", path)
+	assert.Equal(t, "token", field)
+}
+
+func TestEnv_Vault(t *testing.T) {
+	fakeCommands(t, func(stdin, name string, args ...string) (string, error) {
+		assert.Equal(t, "vault", name)
+		assert.Equal(t, []string{"kv", "get", "-field=password", "secret/data/app"}, args)
+		return "s3cret", nil
+	})
+
+	env, err := Env("/src/app", []pkg.Secret{{Name: "DB_PASSWORD", Source: "vault:secret/data/app#password"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DB_PASSWORD=s3cret"}, env)
+}
+
+func TestEnv_Keychain(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("no keychain tool on this platform")
+	}
+	fakeCommands(t, func(stdin, name string, args ...string) (string, error) {
+		assert.Contains(t, args, "/src/app#GITHUB_TOKEN")
+		return "ghp_token", nil
+	})
+
+	env, err := Env("/src/app", []pkg.Secret{{Name: "GITHUB_TOKEN", Source: "keychain"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GITHUB_TOKEN=ghp_token"}, env)
+}
+
+func TestEnv_Error(t *testing.T) {
+	fakeCommands(t, func(stdin, name string, args ...string) (string, error) {
+		return "", errors.New("permission denied")
+	})
+
+	_, err := Env("/src/app", []pkg.Secret{{Name: "DB_PASSWORD", Source: "vault:secret/data/app#password"}})
+	assert.ErrorContains(t, err, "failed to fetch secret DB_PASSWORD")
+	assert.ErrorContains(t, err, "permission denied")
+}
+
+func TestStore_Linux(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("secret-tool is used on Linux")
+	}
+	var stdin string
+	var args []string
+	fakeCommands(t, func(in, name string, a ...string) (string, error) {
+		stdin, args = in, a
+		return "", nil
+	})
+
+	require.NoError(t, Store("/src/app", "GITHUB_TOKEN", "ghp_token"))
+	assert.Equal(t, "ghp_token", stdin, "the value is passed on stdin, not the command line")
+	assert.Equal(t, []string{"service", "claude-reactor", "account", "/src/app#GITHUB_TOKEN"}, args[len(args)-4:])
+}
+
+func TestSecurityAddCommand(t *testing.T) {
+	command, err := securityAddCommand("/src/app#API_KEY", `pa"ss\word`)
+	require.NoError(t, err)
+	assert.Equal(t, `add-generic-password -U -s "claude-reactor" -a "/src/app#API_KEY" -w "pa\"ss\\word"`+"\n", command)
+
+	_, err = securityAddCommand("/src/app#API_KEY", "line one\nline two")
+	assert.ErrorContains(t, err, "line breaks")
+}
@@ -0,0 +1,223 @@
+// Package secrets finds files in a project that likely hold secrets, so they can be kept out of
+// containers, and redacts secret values from text shown to the user.
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Scan modes, set with the secret_scan configuration key
+const (
+	ModeOff         = "off"
+	ModeWarn        = "warn"        // report secret files before mounting
+	ModeExclude     = "exclude"     // hide secret files behind empty read-only files
+	ModePlaceholder = "placeholder" // hide secret files behind read-only placeholders
+)
+
+// Redacted replaces secret values in redacted text
+const Redacted = "[REDACTED]"
+
+// maxScanSize is the largest file whose content is scanned; bigger files are checked by name only
+const maxScanSize = 1 << 20
+
+// ParseMode interprets the secret_scan setting; an empty value means off
+func ParseMode(value string) (string, error) {
+	switch value {
+	case "", "none", ModeOff, "false":
+		return ModeOff, nil
+	case ModeWarn, ModeExclude, ModePlaceholder:
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid secret_scan value '%s': use warn, exclude, placeholder or off", value)
+}
+
+// Finding is a project file that likely holds secrets
+type Finding struct {
+	Path   string // relative to the project
+	Reason string // what gave it away, e.g. "environment file"
+}
+
+// pattern is a kind of secret recognized by its content
+type pattern struct {
+	name string
+	re   *regexp.Regexp
+}
+
+var patterns = []pattern{
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----(?s:.*?-----END [A-Z ]*PRIVATE KEY-----)?`)},
+	{"AWS access key", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{"Anthropic API key", regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{20,}`)},
+	{"OpenAI API key", regexp.MustCompile(`\bsk-(?:proj-)?[A-Za-z0-9_-]{32,}`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"Stripe key", regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{16,}\b`)},
+}
+
+// Scan returns the files under projectDir that likely hold secrets, by name or by content,
+// skipping .git and names matching ignores
+func Scan(projectDir string, ignores []string) ([]Finding, error) {
+	var findings []Finding
+	err := filepath.WalkDir(projectDir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrPermission) {
+				return nil
+			}
+			return err
+		}
+		if file == projectDir {
+			return nil
+		}
+		if entry.Name() == ".git" || ignored(entry.Name(), ignores) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(projectDir, file)
+		if err != nil {
+			return err
+		}
+		if reason := nameReason(entry.Name()); reason != "" {
+			findings = append(findings, Finding{Path: rel, Reason: reason})
+		} else if reason := contentReason(file); reason != "" {
+			findings = append(findings, Finding{Path: rel, Reason: reason})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan project for secrets: %w", err)
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Path < findings[j].Path })
+	return findings, nil
+}
+
+// nameReason returns why a file name suggests secrets, or "" when it doesn't
+func nameReason(name string) string {
+	switch {
+	case isEnvFile(name):
+		return "environment file"
+	case name == "id_rsa" || name == "id_dsa" || name == "id_ecdsa" || name == "id_ed25519":
+		return "SSH private key"
+	case name == ".netrc" || name == ".pgpass" || name == ".git-credentials":
+		return "credentials file"
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".key", ".p12", ".pfx", ".jks", ".keystore":
+		return "key file"
+	}
+	return ""
+}
+
+// isEnvFile reports whether name is a dotenv file other than a committed example
+func isEnvFile(name string) bool {
+	if name != ".env" && !strings.HasPrefix(name, ".env.") {
+		return false
+	}
+	switch path.Ext(name) {
+	case ".example", ".sample", ".template", ".dist", ".defaults":
+		return false
+	}
+	return true
+}
+
+// contentReason returns the kind of secret found in a text file, or "" when there is none
+func contentReason(file string) string {
+	info, err := os.Stat(file)
+	if err != nil || info.Size() > maxScanSize {
+		return ""
+	}
+	data, err := os.ReadFile(file)
+	if err != nil || bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return ""
+	}
+	for _, p := range patterns {
+		if p.re.Match(data) {
+			return p.name
+		}
+	}
+	return ""
+}
+
+func ignored(name string, ignores []string) bool {
+	for _, pattern := range ignores {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Placeholder returns what a secret file is replaced with in placeholder mode. Environment
+// files keep their variable names so tools that read them still work; other files get a note.
+func Placeholder(name string, content io.Reader) []byte {
+	if !isEnvFile(name) {
+		return []byte("# Replaced by claude-reactor: this file likely contains secrets\n")
+	}
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(content)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if key, _, ok := strings.Cut(line, "="); ok && trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			line = key + "=" + Redacted
+		}
+		out.WriteString(line + "\n")
+	}
+	return out.Bytes()
+}
+
+// IsSecretName reports whether an environment variable name suggests a secret value
+func IsSecretName(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, word := range []string{"SECRET", "TOKEN", "PASSWORD", "PASSWD", "CREDENTIAL", "PRIVATE_KEY", "API_KEY", "APIKEY", "ACCESS_KEY"} {
+		if strings.Contains(upper, word) {
+			return true
+		}
+	}
+	return strings.HasSuffix(upper, "_KEY")
+}
+
+// envSecrets are the values of the host's secret environment variables, long enough to redact
+// without mangling unrelated text
+var envSecrets = sync.OnceValue(func() []string {
+	var values []string
+	for _, entry := range os.Environ() {
+		name, value, _ := strings.Cut(entry, "=")
+		if IsSecretName(name) && len(value) >= 8 {
+			values = append(values, value)
+		}
+	}
+	return values
+})
+
+// Redact replaces recognized secrets, and the values of the host's secret environment
+// variables, in text
+func Redact(text string) string {
+	return redact(text, envSecrets())
+}
+
+func redact(text string, values []string) string {
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, Redacted)
+	}
+	for _, p := range patterns {
+		text = p.re.ReplaceAllString(text, Redacted)
+	}
+	return text
+}
@@ -0,0 +1,156 @@
+// Package targets discovers the automation a project already has — Makefile targets, justfile
+// recipes and package.json scripts — so it can be run in the project container as tasks named
+// make:TARGET, just:RECIPE and npm:SCRIPT.
+package targets
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Target is a runnable target found in the project
+type Target struct {
+	Name    string   // e.g. make:test
+	Command []string // e.g. make test
+	Source  string   // file it was found in
+}
+
+var (
+	// makeTarget matches an explicit rule such as "test:" or "build: deps", but not
+	// assignments such as "VAR := value"
+	makeTarget = regexp.MustCompile(`^([A-Za-z0-9_][A-Za-z0-9_./-]*)\s*:([^=]|$)`)
+	// justRecipe matches a recipe header such as "test:", "@build target='x':" or "deploy env:"
+	justRecipe = regexp.MustCompile(`^@?([A-Za-z0-9][A-Za-z0-9_-]*)(\s+[^:]*)?:([^=]|$)`)
+)
+
+// Discover returns the targets of the project in projectDir, sorted by name
+func Discover(projectDir string) []Target {
+	var found []Target
+	found = append(found, makeTargets(projectDir)...)
+	found = append(found, justRecipes(projectDir)...)
+	found = append(found, npmScripts(projectDir)...)
+	sort.Slice(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+	return found
+}
+
+// Find returns the target called name in the project in projectDir, or nil
+func Find(projectDir, name string) *Target {
+	for _, target := range Discover(projectDir) {
+		if target.Name == name {
+			return &target
+		}
+	}
+	return nil
+}
+
+// makeTargets returns the explicit targets of the project's Makefile
+func makeTargets(projectDir string) []Target {
+	for _, name := range []string{"GNUmakefile", "makefile", "Makefile"} {
+		lines, err := readLines(filepath.Join(projectDir, name))
+		if err != nil {
+			continue
+		}
+		var found []Target
+		seen := map[string]bool{}
+		for _, line := range lines {
+			match := makeTarget.FindStringSubmatch(line)
+			if match == nil || seen[match[1]] {
+				continue
+			}
+			seen[match[1]] = true
+			found = append(found, Target{Name: "make:" + match[1], Command: []string{"make", match[1]}, Source: name})
+		}
+		// make only reads the first of these it finds
+		return found
+	}
+	return nil
+}
+
+// justRecipes returns the public recipes of the project's justfile
+func justRecipes(projectDir string) []Target {
+	for _, name := range []string{"justfile", "Justfile", ".justfile"} {
+		lines, err := readLines(filepath.Join(projectDir, name))
+		if err != nil {
+			continue
+		}
+		var found []Target
+		private := false
+		for _, line := range lines {
+			// Attributes such as [private] apply to the recipe that follows
+			if strings.HasPrefix(line, "[") {
+				private = private || strings.Contains(line, "private")
+				continue
+			}
+			match := justRecipe.FindStringSubmatch(line)
+			hidden := private
+			private = false
+			if match == nil || hidden {
+				continue
+			}
+			switch match[1] {
+			case "set", "alias", "export", "import", "mod":
+				continue
+			}
+			found = append(found, Target{Name: "just:" + match[1], Command: []string{"just", match[1]}, Source: name})
+		}
+		return found
+	}
+	return nil
+}
+
+// npmScripts returns the scripts of the project's package.json, run with the package manager
+// its lockfile belongs to
+func npmScripts(projectDir string) []Target {
+	data, err := os.ReadFile(filepath.Join(projectDir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var manifest struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if json.Unmarshal(data, &manifest) != nil {
+		return nil
+	}
+
+	runner := "npm"
+	for _, lock := range []struct{ file, manager string }{
+		{"pnpm-lock.yaml", "pnpm"}, {"yarn.lock", "yarn"}, {"bun.lock", "bun"}, {"bun.lockb", "bun"},
+	} {
+		if _, err := os.Stat(filepath.Join(projectDir, lock.file)); err == nil {
+			runner = lock.manager
+			break
+		}
+	}
+
+	var found []Target
+	for script := range manifest.Scripts {
+		found = append(found, Target{Name: "npm:" + script, Command: []string{runner, "run", script}, Source: "package.json"})
+	}
+	return found
+}
+
+// readLines returns the lines of file, skipping indented and comment lines, which never start
+// a target
+func readLines(file string) ([]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == ' ' || line[0] == '\t' || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,95 @@
+package targets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func names(found []Target) []string {
+	var names []string
+	for _, target := range found {
+		names = append(names, target.Name)
+	}
+	return names
+}
+
+func TestDiscover(t *testing.T) {
+	project := t.TempDir()
+	write(t, project, "Makefile", `GO ?= go
+VERSION := 1.0
+.PHONY: test build
+
+# Run the tests
+test:
+	$(GO) test ./...
+
+build: test
+	$(GO) build -o bin/app .
+
+%.o: %.c
+	cc -c $<
+
+test:
+	@echo again
+`)
+	write(t, project, "justfile", `set shell := ["bash", "-c"]
+alias t := test
+
+default: test
+
+# Run the tests
+test *args:
+    go test {{args}}
+
+@deploy env='staging':
+    ./deploy.sh {{env}}
+
+_helper:
+    echo private
+
+[private]
+[no-cd]
+other:
+    echo private by attribute
+
+lint:
+    golangci-lint run
+`)
+	write(t, project, "package.json", `{"name": "app", "scripts": {"dev": "vite", "lint": "eslint ."}}`)
+
+	found := Discover(project)
+	assert.Equal(t, []string{
+		"just:default", "just:deploy", "just:lint", "just:test",
+		"make:build", "make:test",
+		"npm:dev", "npm:lint",
+	}, names(found))
+
+	test := Find(project, "make:test")
+	require.NotNil(t, test)
+	assert.Equal(t, []string{"make", "test"}, test.Command)
+	assert.Equal(t, "Makefile", test.Source)
+	assert.Equal(t, []string{"npm", "run", "lint"}, Find(project, "npm:lint").Command)
+	assert.Nil(t, Find(project, "make:missing"))
+}
+
+func TestNPMScriptsUseLockfileManager(t *testing.T) {
+	project := t.TempDir()
+	write(t, project, "package.json", `{"scripts": {"test": "vitest"}}`)
+	write(t, project, "pnpm-lock.yaml", "")
+	assert.Equal(t, []string{"pnpm", "run", "test"}, Find(project, "npm:test").Command)
+}
+
+func TestDiscoverEmptyProject(t *testing.T) {
+	project := t.TempDir()
+	write(t, project, "package.json", `not json`)
+	assert.Empty(t, Discover(project))
+}
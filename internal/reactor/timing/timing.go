@@ -0,0 +1,72 @@
+// Package timing records how long each phase of startup takes, so slow invocations can be
+// diagnosed with 'claude-reactor info info --timing'.
+package timing
+
+import (
+	"sync"
+	"time"
+)
+
+// Phase is a named step and how long it took
+type Phase struct {
+	Name     string
+	Duration time.Duration
+	// OnDemand phases run only when a command needs them, outside the startup sequence
+	OnDemand bool
+}
+
+var (
+	mu     sync.Mutex
+	start  = time.Now() // package initialisation, as close to process start as Go code gets
+	last   = start
+	phases []Phase
+)
+
+// Mark records a phase ending now, which began when the previous phase ended
+func Mark(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	now := time.Now()
+	phases = append(phases, Phase{Name: name, Duration: now.Sub(last)})
+	last = now
+}
+
+// Track records a phase that began at from and ends now, independent of other phases.
+// Use it for work that happens on demand, such as connecting to Docker.
+func Track(name string, from time.Time) {
+	mu.Lock()
+	defer mu.Unlock()
+	phases = append(phases, Phase{Name: name, Duration: time.Since(from), OnDemand: true})
+}
+
+// Phases returns the recorded phases in order
+func Phases() []Phase {
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]Phase(nil), phases...)
+}
+
+// Startup returns the total duration of the startup sequence, excluding on-demand phases
+func Startup() time.Duration {
+	var total time.Duration
+	for _, phase := range Phases() {
+		if !phase.OnDemand {
+			total += phase.Duration
+		}
+	}
+	return total
+}
+
+// Elapsed returns the time since the process started
+func Elapsed() time.Duration {
+	return time.Since(start)
+}
+
+// reset clears recorded phases; for tests
+func reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	start = time.Now()
+	last = start
+	phases = nil
+}
@@ -0,0 +1,31 @@
+package timing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkAndTrack(t *testing.T) {
+	reset()
+
+	time.Sleep(5 * time.Millisecond)
+	Mark("flags")
+	Track("docker connect", time.Now().Add(-20*time.Millisecond))
+	Mark("commands")
+
+	phases := Phases()
+	require.Len(t, phases, 3)
+	assert.Equal(t, "flags", phases[0].Name)
+	assert.GreaterOrEqual(t, phases[0].Duration, 5*time.Millisecond)
+	assert.Equal(t, "docker connect", phases[1].Name)
+	assert.GreaterOrEqual(t, phases[1].Duration, 20*time.Millisecond)
+	assert.Equal(t, "commands", phases[2].Name)
+	assert.Less(t, phases[2].Duration, 20*time.Millisecond, "marks measure from the previous mark, not tracked phases")
+	assert.True(t, phases[1].OnDemand)
+	assert.False(t, phases[0].OnDemand)
+	assert.Equal(t, phases[0].Duration+phases[2].Duration, Startup())
+	assert.GreaterOrEqual(t, Elapsed(), 5*time.Millisecond)
+}
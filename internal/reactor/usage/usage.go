@@ -0,0 +1,130 @@
+// Package usage records which tools are run in a project's containers, and which commands
+// could not be found, as reported by the claude-reactor-agent. 'claude-reactor advise' uses it
+// to recommend a variant. The record is stored in the project's session directory and never
+// leaves the machine.
+package usage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FileName holds a project's tool usage, in its session directory
+const FileName = "tool-usage.json"
+
+// Tool is how often a tool was run, or looked for when missing
+type Tool struct {
+	Name     string    `json:"-"`
+	Count    int       `json:"count"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// Usage is the tool usage recorded for a project
+type Usage struct {
+	Since   time.Time       `json:"since"`
+	Tools   map[string]Tool `json:"tools"`
+	Missing map[string]Tool `json:"missing,omitempty"`
+}
+
+// mu serializes updates, which the agent host makes from concurrent requests
+var mu sync.Mutex
+
+// Load returns the tool usage of the project whose session directory is sessionDir; nothing
+// recorded yet is an empty usage
+func Load(sessionDir string) (*Usage, error) {
+	usage := &Usage{Tools: map[string]Tool{}, Missing: map[string]Tool{}}
+	data, err := os.ReadFile(filepath.Join(sessionDir, FileName))
+	if os.IsNotExist(err) {
+		return usage, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool usage: %w", err)
+	}
+	if err := json.Unmarshal(data, usage); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(sessionDir, FileName), err)
+	}
+	if usage.Tools == nil {
+		usage.Tools = map[string]Tool{}
+	}
+	if usage.Missing == nil {
+		usage.Missing = map[string]Tool{}
+	}
+	return usage, nil
+}
+
+// RecordRuns adds the runs of each tool in counts. A tool that runs is no longer missing.
+func RecordRuns(sessionDir string, counts map[string]int, now time.Time) error {
+	return update(sessionDir, now, func(usage *Usage) {
+		for name, count := range counts {
+			tool := usage.Tools[name]
+			tool.Count += count
+			tool.LastUsed = now
+			usage.Tools[name] = tool
+			delete(usage.Missing, name)
+		}
+	})
+}
+
+// RecordMissing adds a lookup of a command that was not found
+func RecordMissing(sessionDir, name string, now time.Time) error {
+	return update(sessionDir, now, func(usage *Usage) {
+		tool := usage.Missing[name]
+		tool.Count++
+		tool.LastUsed = now
+		usage.Missing[name] = tool
+	})
+}
+
+// Reset removes the project's recorded usage
+func Reset(sessionDir string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if err := os.Remove(filepath.Join(sessionDir, FileName)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to reset tool usage: %w", err)
+	}
+	return nil
+}
+
+// Sorted returns tools most used first
+func Sorted(tools map[string]Tool) []Tool {
+	sorted := make([]Tool, 0, len(tools))
+	for name, tool := range tools {
+		tool.Name = name
+		sorted = append(sorted, tool)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// update applies change to the project's usage and saves it
+func update(sessionDir string, now time.Time, change func(*Usage)) error {
+	mu.Lock()
+	defer mu.Unlock()
+	usage, err := Load(sessionDir)
+	if err != nil {
+		return err
+	}
+	if usage.Since.IsZero() {
+		usage.Since = now
+	}
+	change(usage)
+
+	data, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode tool usage: %w", err)
+	}
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	return os.WriteFile(filepath.Join(sessionDir, FileName), data, 0644)
+}
@@ -0,0 +1,51 @@
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+
+	usage, err := Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, usage.Tools)
+	assert.True(t, usage.Since.IsZero())
+
+	start := time.Now().Truncate(time.Second)
+	require.NoError(t, RecordRuns(dir, map[string]int{"go": 3, "git": 5}, start))
+	require.NoError(t, RecordMissing(dir, "cargo", start.Add(time.Minute)))
+	require.NoError(t, RecordMissing(dir, "cargo", start.Add(2*time.Minute)))
+	require.NoError(t, RecordMissing(dir, "helm", start.Add(2*time.Minute)))
+	require.NoError(t, RecordRuns(dir, map[string]int{"go": 2, "helm": 1}, start.Add(time.Hour)))
+
+	usage, err = Load(dir)
+	require.NoError(t, err)
+	assert.True(t, start.Equal(usage.Since))
+	assert.Equal(t, 5, usage.Tools["go"].Count)
+	assert.True(t, start.Add(time.Hour).Equal(usage.Tools["go"].LastUsed))
+	assert.Equal(t, 5, usage.Tools["git"].Count)
+	// helm ran after it was looked for, so it is no longer missing
+	assert.Equal(t, []string{"cargo"}, names(Sorted(usage.Missing)))
+	assert.Equal(t, 2, usage.Missing["cargo"].Count)
+
+	assert.Equal(t, []string{"git", "go", "helm"}, names(Sorted(usage.Tools)))
+
+	require.NoError(t, Reset(dir))
+	require.NoError(t, Reset(dir))
+	usage, err = Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, usage.Tools)
+}
+
+func names(tools []Tool) []string {
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
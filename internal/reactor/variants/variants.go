@@ -0,0 +1,195 @@
+// Package variants loads externally defined container variants from YAML files, so third
+// parties can ship images beyond the built-in base, go, full, cloud, and k8s variants.
+package variants
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"claude-reactor/pkg"
+)
+
+// Builtin lists the variants built from the claude-reactor Dockerfile
+var Builtin = []string{"base", "go", "full", "cloud", "k8s"}
+
+// builtinParents is the variant each built-in variant builds on
+var builtinParents = map[string]string{"go": "base", "full": "go", "cloud": "full", "k8s": "full"}
+
+// BuiltinSizes are the approximate image sizes of the built-in variants
+var BuiltinSizes = map[string]int64{
+	"base":  500 << 20,
+	"go":    800 << 20,
+	"full":  1200 << 20,
+	"cloud": 1500 << 20,
+	"k8s":   1400 << 20,
+}
+
+// builtinTools are the notable tools each built-in variant adds to the one it builds on. Tools
+// of the base variant and of the system are not listed.
+var builtinTools = map[string][]string{
+	"go": {"go", "gofmt", "gopls", "dlv", "staticcheck", "golangci-lint"},
+	"full": {
+		"cargo", "rustc", "rustup", "rustfmt", "rustdoc", "cargo-watch", "cargo-audit",
+		"java", "javac", "jar", "jshell", "mvn", "gradle",
+		"mysql", "mysqldump", "psql", "pg_dump", "redis-cli", "sqlite3",
+		"tree", "rsync", "nc", "telnet", "yq",
+	},
+	"cloud": {"aws", "gcloud", "gsutil", "bq", "az", "terraform"},
+	"k8s":   {"helm", "k9s", "kubectx", "kubens", "stern", "kustomize"},
+}
+
+// ToolVariant returns the built-in variant that adds tool, or "" if the base variant has it
+// or no built-in variant does
+func ToolVariant(tool string) string {
+	for variant, tools := range builtinTools {
+		for _, name := range tools {
+			if name == tool {
+				return variant
+			}
+		}
+	}
+	return ""
+}
+
+// Includes reports whether the built-in variant has everything other has, because it is other
+// or builds on it
+func Includes(variant, other string) bool {
+	for ; variant != ""; variant = builtinParents[variant] {
+		if variant == other {
+			return true
+		}
+	}
+	return false
+}
+
+// namePattern restricts variant names to what is valid in image and container names
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Dir returns the directory external variant definitions are read from
+func Dir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".claude-reactor", "variants.d")
+}
+
+// IsBuiltin reports whether name is a built-in variant
+func IsBuiltin(name string) bool {
+	for _, builtin := range Builtin {
+		if name == builtin {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadDir reads every *.yaml and *.yml definition in dir, sorted by file name. Invalid
+// definitions are skipped and reported in the returned errors; a missing dir is not an error.
+func LoadDir(dir string) ([]*pkg.VariantDefinition, []error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to read %s: %w", dir, err)}
+	}
+
+	var files []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	var definitions []*pkg.VariantDefinition
+	var errs []error
+	seen := make(map[string]string)
+	for _, file := range files {
+		definition, err := Load(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if previous, ok := seen[definition.Name]; ok {
+			errs = append(errs, fmt.Errorf("%s: variant '%s' is already defined in %s", file, definition.Name, previous))
+			continue
+		}
+		seen[definition.Name] = file
+		definitions = append(definitions, definition)
+	}
+	return definitions, errs
+}
+
+// Load reads and validates one variant definition. A relative dockerfile is resolved
+// against the definition's directory.
+func Load(path string) (*pkg.VariantDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var definition pkg.VariantDefinition
+	if err := yaml.Unmarshal(data, &definition); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	definition.Source = path
+	if definition.Dockerfile != "" && !filepath.IsAbs(definition.Dockerfile) {
+		definition.Dockerfile = filepath.Join(filepath.Dir(path), definition.Dockerfile)
+	}
+
+	if err := Validate(&definition); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &definition, nil
+}
+
+// Validate checks an external variant definition
+func Validate(definition *pkg.VariantDefinition) error {
+	if !namePattern.MatchString(definition.Name) {
+		return fmt.Errorf("invalid variant name '%s': use lowercase letters, digits, '-' and '_'", definition.Name)
+	}
+	if IsBuiltin(definition.Name) {
+		return fmt.Errorf("variant '%s' is built in and cannot be redefined", definition.Name)
+	}
+
+	switch {
+	case definition.Dockerfile == "" && definition.Image == "":
+		return fmt.Errorf("variant '%s' needs a dockerfile or an image", definition.Name)
+	case definition.Dockerfile != "" && definition.Image != "":
+		return fmt.Errorf("variant '%s' sets both dockerfile and image; use one", definition.Name)
+	case definition.Dockerfile != "":
+		if _, err := os.Stat(definition.Dockerfile); err != nil {
+			return fmt.Errorf("variant '%s' dockerfile not found: %s", definition.Name, definition.Dockerfile)
+		}
+	}
+
+	if detect := definition.Detect; detect != nil {
+		if len(detect.Markers) == 0 {
+			return fmt.Errorf("variant '%s' detect section has no markers", definition.Name)
+		}
+		for i, marker := range detect.Markers {
+			if strings.TrimSpace(marker.Pattern) == "" {
+				return fmt.Errorf("variant '%s' detect marker %d has no pattern", definition.Name, i+1)
+			}
+			if _, err := filepath.Match(marker.Pattern, ""); err != nil {
+				return fmt.Errorf("variant '%s' detect marker %d has an invalid pattern: %w", definition.Name, i+1, err)
+			}
+			if marker.Confidence <= 0 || marker.Confidence > 1 {
+				return fmt.Errorf("variant '%s' detect marker %d confidence must be between 0 and 1", definition.Name, i+1)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package variants
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/pkg"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "elixir", "Dockerfile"), "FROM elixir:1.16\n")
+	writeFile(t, filepath.Join(dir, "elixir.yaml"), `name: elixir
+description: Elixir and Erlang/OTP
+dockerfile: elixir/Dockerfile
+target: dev
+tools: [elixir, mix]
+detect:
+  language: elixir
+  markers:
+    - pattern: mix.exs
+      confidence: 0.9
+`)
+	writeFile(t, filepath.Join(dir, "zig.yml"), "name: zig\nimage: ghcr.io/acme/zig-dev:latest\n")
+	writeFile(t, filepath.Join(dir, "broken.yaml"), "name: go\nimage: golang\n")
+	writeFile(t, filepath.Join(dir, "notes.txt"), "ignored")
+
+	definitions, errs := LoadDir(dir)
+	require.Len(t, definitions, 2)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "built in")
+
+	elixir := definitions[0]
+	assert.Equal(t, "elixir", elixir.Name)
+	assert.Equal(t, filepath.Join(dir, "elixir", "Dockerfile"), elixir.Dockerfile)
+	assert.Equal(t, "dev", elixir.Target)
+	assert.Equal(t, filepath.Join(dir, "elixir.yaml"), elixir.Source)
+	require.NotNil(t, elixir.Detect)
+	assert.Equal(t, "mix.exs", elixir.Detect.Markers[0].Pattern)
+
+	assert.Equal(t, "zig", definitions[1].Name)
+	assert.Equal(t, "ghcr.io/acme/zig-dev:latest", definitions[1].Image)
+}
+
+func TestLoadDirMissing(t *testing.T) {
+	definitions, errs := LoadDir(filepath.Join(t.TempDir(), "variants.d"))
+	assert.Empty(t, definitions)
+	assert.Empty(t, errs)
+}
+
+func TestLoadDirDuplicateNames(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.yaml"), "name: zig\nimage: zig:1\n")
+	writeFile(t, filepath.Join(dir, "b.yaml"), "name: zig\nimage: zig:2\n")
+
+	definitions, errs := LoadDir(dir)
+	require.Len(t, definitions, 1)
+	assert.Equal(t, "zig:1", definitions[0].Image)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "already defined")
+}
+
+func TestValidate(t *testing.T) {
+	dockerfile := filepath.Join(t.TempDir(), "Dockerfile")
+	writeFile(t, dockerfile, "FROM debian\n")
+	marker := func(pattern string, confidence float64) *pkg.VariantDetection {
+		return &pkg.VariantDetection{Markers: []pkg.VariantMarker{{Pattern: pattern, Confidence: confidence}}}
+	}
+
+	tests := []struct {
+		name       string
+		definition pkg.VariantDefinition
+		wantErr    string
+	}{
+		{"dockerfile variant", pkg.VariantDefinition{Name: "elixir", Dockerfile: dockerfile}, ""},
+		{"image variant", pkg.VariantDefinition{Name: "zig", Image: "zig:latest"}, ""},
+		{"with detection", pkg.VariantDefinition{Name: "zig", Image: "zig", Detect: marker("build.zig", 0.9)}, ""},
+		{"missing name", pkg.VariantDefinition{Image: "zig"}, "invalid variant name"},
+		{"uppercase name", pkg.VariantDefinition{Name: "Zig", Image: "zig"}, "invalid variant name"},
+		{"builtin name", pkg.VariantDefinition{Name: "base", Image: "debian"}, "built in"},
+		{"no source", pkg.VariantDefinition{Name: "zig"}, "dockerfile or an image"},
+		{"both sources", pkg.VariantDefinition{Name: "zig", Image: "zig", Dockerfile: dockerfile}, "use one"},
+		{"missing dockerfile", pkg.VariantDefinition{Name: "zig", Dockerfile: "/nonexistent/Dockerfile"}, "not found"},
+		{"empty detection", pkg.VariantDefinition{Name: "zig", Image: "zig", Detect: &pkg.VariantDetection{}}, "no markers"},
+		{"bad pattern", pkg.VariantDefinition{Name: "zig", Image: "zig", Detect: marker("[", 0.5)}, "invalid pattern"},
+		{"bad confidence", pkg.VariantDefinition{Name: "zig", Image: "zig", Detect: marker("build.zig", 1.5)}, "confidence"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(&tt.definition)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsBuiltin(t *testing.T) {
+	assert.True(t, IsBuiltin("go"))
+	assert.False(t, IsBuiltin("elixir"))
+}
+
+func TestToolVariant(t *testing.T) {
+	assert.Equal(t, "go", ToolVariant("go"))
+	assert.Equal(t, "full", ToolVariant("cargo"))
+	assert.Equal(t, "cloud", ToolVariant("terraform"))
+	assert.Equal(t, "k8s", ToolVariant("helm"))
+	assert.Empty(t, ToolVariant("git"))
+	assert.Empty(t, ToolVariant("no-such-tool"))
+
+	for _, builtin := range Builtin {
+		assert.Contains(t, BuiltinSizes, builtin)
+	}
+}
+
+func TestIncludes(t *testing.T) {
+	assert.True(t, Includes("full", "full"))
+	assert.True(t, Includes("full", "go"))
+	assert.True(t, Includes("k8s", "base"))
+	assert.True(t, Includes("cloud", "full"))
+	assert.False(t, Includes("go", "full"))
+	assert.False(t, Includes("cloud", "k8s"))
+	assert.False(t, Includes("my-variant", "base"))
+}
@@ -0,0 +1,159 @@
+// Package worktree gives Claude sessions their own git branch, checked out in a linked worktree
+// next to the repository, so each session's changes stay isolated and are easy to turn into a PR.
+package worktree
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ConfigFile is the claude-reactor project configuration. Runs rewrite it, so Commit leaves it
+// out wherever it is in the worktree.
+const ConfigFile = ".claude-reactor"
+
+// Path returns where the worktree for branch of the repository at root is kept: a directory
+// beside the repository, named after it
+func Path(root, branch string) string {
+	return filepath.Join(filepath.Dir(root), filepath.Base(root)+".worktrees", strings.ReplaceAll(branch, "/", "-"))
+}
+
+// CommonDir returns the git directory the linked worktree at dir shares with its repository,
+// or "" when dir is not a linked worktree. Git in a container finds it at the same path, so
+// it has to be mounted there.
+func CommonDir(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, ".git"))
+	if err != nil {
+		return "" // no .git, or a directory: a main working tree
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return ""
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(dir, gitDir)
+	}
+	common, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return "" // a submodule, which keeps its git directory inside the parent's
+	}
+	commonDir := strings.TrimSpace(string(common))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+	return filepath.Clean(commonDir)
+}
+
+// MainRoot returns the main working tree of the repository containing dir, which may be one
+// of its linked worktrees
+func MainRoot(dir string) (string, error) {
+	commonDir, err := git(dir, "rev-parse", "--path-format=absolute", "--git-common-dir")
+	if err != nil {
+		return "", fmt.Errorf("%s is not in a git repository: %w", dir, err)
+	}
+	if filepath.Base(commonDir) != ".git" {
+		return "", fmt.Errorf("the repository at %s has no working tree", filepath.Dir(commonDir))
+	}
+	return filepath.Dir(commonDir), nil
+}
+
+// Prefix returns the path of dir within the working tree it belongs to, main or linked, so
+// the same place can be found in another worktree of the repository
+func Prefix(dir string) (string, error) {
+	prefix, err := git(dir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", fmt.Errorf("%s is not in a git repository: %w", dir, err)
+	}
+	return filepath.FromSlash(strings.TrimSuffix(prefix, "/")), nil
+}
+
+// Add checks out branch in its worktree for the repository at root, creating the branch from
+// the current HEAD when it does not exist. An existing worktree is reused. It returns the
+// worktree directory and whether it was created.
+func Add(root, branch string) (string, bool, error) {
+	if _, err := git(root, "check-ref-format", "--branch", branch); err != nil {
+		return "", false, fmt.Errorf("invalid branch name '%s'", branch)
+	}
+	dir := Path(root, branch)
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		current, err := git(dir, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return "", false, err
+		}
+		if current != branch {
+			return "", false, fmt.Errorf("worktree %s has %s checked out, not %s", dir, current, branch)
+		}
+		return dir, false, nil
+	}
+
+	args := []string{"worktree", "add"}
+	if _, err := git(root, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err != nil {
+		args = append(args, "-b", branch, dir)
+	} else {
+		args = append(args, dir, branch)
+	}
+	if _, err := git(root, args...); err != nil {
+		return "", false, fmt.Errorf("failed to create worktree for %s: %w", branch, err)
+	}
+
+	return dir, true, nil
+}
+
+// Commit commits everything changed in the worktree at dir except claude-reactor
+// configuration, and reports whether there was anything to commit
+func Commit(dir, message string) (bool, error) {
+	if _, err := git(dir, "add", "-A", "--", ".", ":(exclude,glob)**/"+ConfigFile); err != nil {
+		return false, fmt.Errorf("failed to stage changes: %w", err)
+	}
+	if _, err := git(dir, "diff", "--cached", "--quiet"); err == nil {
+		return false, nil
+	}
+	if _, err := git(dir, "commit", "-q", "-m", message); err != nil {
+		return false, fmt.Errorf("failed to commit changes: %w", err)
+	}
+	return true, nil
+}
+
+// Push pushes branch from the worktree at dir to origin, setting it as the upstream, and
+// returns the remote, or "" when the repository has no origin to push to
+func Push(dir, branch string) (string, error) {
+	if _, err := git(dir, "remote", "get-url", "origin"); err != nil {
+		return "", nil
+	}
+	if _, err := git(dir, "push", "-u", "origin", branch); err != nil {
+		return "", fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	return "origin", nil
+}
+
+// Remove deletes the worktree at dir from the repository at root. The branch is kept. Only
+// what Commit leaves behind is lost: the configuration and ignored files.
+func Remove(root, dir string) error {
+	if _, err := git(root, "worktree", "remove", "--force", dir); err != nil {
+		return fmt.Errorf("failed to remove worktree %s: %w", dir, err)
+	}
+	// Drop the directory holding the repository's worktrees once the last one is gone
+	os.Remove(filepath.Dir(dir))
+	return nil
+}
+
+// git runs a git command in dir and returns its trimmed output, or an error with what git
+// printed on failure
+func git(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", errors.New(message)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
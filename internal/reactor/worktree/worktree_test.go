@@ -0,0 +1,134 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRepo creates a repository with one commit
+func newRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	root := filepath.Join(t.TempDir(), "project")
+	require.NoError(t, os.MkdirAll(root, 0755))
+	for _, args := range [][]string{{"init", "-q", "-b", "main"}, {"commit", "-q", "--allow-empty", "-m", "initial"}} {
+		_, err := git(root, args...)
+		require.NoError(t, err)
+	}
+	return root
+}
+
+func TestPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("/work", "app.worktrees", "feature-claude-1"), Path(filepath.Join("/work", "app"), "feature/claude-1"))
+}
+
+func TestAdd(t *testing.T) {
+	root := newRepo(t)
+
+	dir, created, err := Add(root, "feature/claude-1")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, Path(root, "feature/claude-1"), dir)
+	branch, err := git(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, "feature/claude-1", branch)
+
+	// Reused on the next run
+	again, created, err := Add(root, "feature/claude-1")
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, dir, again)
+
+	// An existing branch is checked out rather than created
+	_, err = git(root, "branch", "existing")
+	require.NoError(t, err)
+	_, created, err = Add(root, "existing")
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	_, _, err = Add(root, "bad..name")
+	assert.Error(t, err)
+}
+
+func TestCommonDir(t *testing.T) {
+	root := newRepo(t)
+	assert.Equal(t, "", CommonDir(root), "main working tree")
+	assert.Equal(t, "", CommonDir(t.TempDir()), "not a repository")
+
+	dir, _, err := Add(root, "feature")
+	require.NoError(t, err)
+	expected, err := filepath.EvalSymlinks(filepath.Join(root, ".git"))
+	require.NoError(t, err)
+	actual, err := filepath.EvalSymlinks(CommonDir(dir))
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "services", "api"), 0755))
+	prefix, err := Prefix(filepath.Join(dir, "services", "api"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("services", "api"), prefix)
+	prefix, err = Prefix(root)
+	require.NoError(t, err)
+	assert.Equal(t, "", prefix)
+
+	main, err := MainRoot(dir)
+	require.NoError(t, err)
+	expectedRoot, _ := filepath.EvalSymlinks(root)
+	actualRoot, _ := filepath.EvalSymlinks(main)
+	assert.Equal(t, expectedRoot, actualRoot)
+}
+
+func TestCommitPushRemove(t *testing.T) {
+	root := newRepo(t)
+	dir, _, err := Add(root, "feature")
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "service"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ConfigFile), []byte("variant=go\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "service", ConfigFile), []byte("variant=go\n"), 0644))
+	committed, err := Commit(dir, "nothing yet")
+	require.NoError(t, err)
+	assert.False(t, committed, "configuration is not a change to commit")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644))
+	committed, err = Commit(dir, "Add main")
+	require.NoError(t, err)
+	assert.True(t, committed)
+	files, err := git(root, "ls-tree", "--name-only", "feature")
+	require.NoError(t, err)
+	assert.Equal(t, "main.go", files)
+
+	// Without an origin there is nowhere to push
+	remote, err := Push(dir, "feature")
+	require.NoError(t, err)
+	assert.Equal(t, "", remote)
+
+	origin := filepath.Join(t.TempDir(), "origin.git")
+	_, err = git(root, "init", "-q", "--bare", origin)
+	require.NoError(t, err)
+	_, err = git(root, "remote", "add", "origin", origin)
+	require.NoError(t, err)
+	remote, err = Push(dir, "feature")
+	require.NoError(t, err)
+	assert.Equal(t, "origin", remote)
+	_, err = git(origin, "rev-parse", "--verify", "refs/heads/feature")
+	assert.NoError(t, err)
+
+	require.NoError(t, Remove(root, dir))
+	assert.NoDirExists(t, dir)
+	assert.NoDirExists(t, filepath.Dir(dir))
+	_, err = git(root, "rev-parse", "--verify", "refs/heads/feature")
+	assert.NoError(t, err, "the branch is kept")
+}
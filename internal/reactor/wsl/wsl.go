@@ -0,0 +1,162 @@
+// Package wsl detects Windows Subsystem for Linux 2 hosts and translates paths between
+// Windows and the WSL filesystem.
+package wsl
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Environment describes the WSL 2 distribution claude-reactor is running in
+type Environment struct {
+	Distro        string // e.g. Ubuntu
+	DockerDesktop bool   // Docker Desktop's WSL integration is enabled for the distro
+}
+
+// Detect returns the WSL 2 environment, or nil when not running under WSL 2
+func Detect() *Environment {
+	release, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil || !isWSL2Kernel(string(release)) {
+		return nil
+	}
+
+	env := &Environment{Distro: os.Getenv("WSL_DISTRO_NAME")}
+	if _, err := os.Stat("/mnt/wsl/docker-desktop"); err == nil {
+		env.DockerDesktop = true
+	}
+	return env
+}
+
+// isWSL2Kernel reports whether a kernel release string is a WSL 2 kernel, such as
+// 5.15.153.1-microsoft-standard-WSL2. WSL 1 reports e.g. 4.4.0-19041-Microsoft.
+func isWSL2Kernel(release string) bool {
+	release = strings.ToLower(release)
+	return strings.Contains(release, "microsoft") && strings.Contains(release, "wsl2")
+}
+
+// TranslatePath converts a Windows path, as copied from Explorer or a Windows terminal,
+// to its WSL equivalent: C:\Users\me becomes /mnt/c/Users/me, and \\wsl$\<distro>\home\me
+// becomes /home/me. Paths in other distributions and non-Windows paths are unchanged.
+func TranslatePath(path, distro string) string {
+	slashed := strings.ReplaceAll(path, `\`, "/")
+
+	for _, prefix := range []string{"//wsl$/", "//wsl.localhost/"} {
+		if len(slashed) < len(prefix) || !strings.EqualFold(slashed[:len(prefix)], prefix) {
+			continue
+		}
+		name, rest, _ := strings.Cut(slashed[len(prefix):], "/")
+		if distro != "" && !strings.EqualFold(name, distro) {
+			return path
+		}
+		return "/" + rest
+	}
+
+	if len(slashed) >= 2 && slashed[1] == ':' && isDriveLetter(slashed[0]) {
+		rest := strings.TrimPrefix(slashed[2:], "/")
+		return filepath.Join("/mnt", strings.ToLower(slashed[:1]), rest)
+	}
+	return path
+}
+
+func isDriveLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// OnWindowsDrive reports whether path lives on a Windows drive shared into WSL over 9p,
+// where file access is many times slower than on the distribution's own filesystem
+func OnWindowsDrive(path string) bool {
+	mounts, err := os.ReadFile("/proc/mounts")
+	if err != nil {
+		return false
+	}
+	return onWindowsDrive(path, string(mounts))
+}
+
+// onWindowsDrive finds the mount containing path in /proc/mounts content
+func onWindowsDrive(path, mounts string) bool {
+	var bestPoint, bestType string
+	for _, line := range strings.Split(mounts, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		point, fsType := fields[1], fields[2]
+		if !within(path, point) || len(point) < len(bestPoint) {
+			continue
+		}
+		bestPoint, bestType = point, fsType
+	}
+	return bestType == "9p" || bestType == "drvfs"
+}
+
+// within reports whether path is dir or below it
+func within(path, dir string) bool {
+	if dir == "/" {
+		return strings.HasPrefix(path, "/")
+	}
+	return path == dir || strings.HasPrefix(path, dir+"/")
+}
+
+// RelocationTarget returns where a project on a Windows drive is copied to in the WSL filesystem
+func RelocationTarget(projectDir, homeDir string) string {
+	return filepath.Join(homeDir, "projects", filepath.Base(projectDir))
+}
+
+// Relocate copies the project tree at src to dst, preserving permissions and symlinks.
+// dst must not exist; src is left in place.
+func Relocate(src, dst string) error {
+	if _, err := os.Lstat(dst); err == nil {
+		return fmt.Errorf("%s already exists", dst)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(dst), err)
+	}
+
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		case info.Mode().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		default:
+			// Sockets, pipes, and devices are not part of a project tree
+			return nil
+		}
+	})
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
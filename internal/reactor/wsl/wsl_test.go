@@ -0,0 +1,88 @@
+package wsl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsWSL2Kernel(t *testing.T) {
+	assert.True(t, isWSL2Kernel("5.15.153.1-microsoft-standard-WSL2\n"))
+	assert.False(t, isWSL2Kernel("4.4.0-19041-Microsoft"), "WSL 1")
+	assert.False(t, isWSL2Kernel("6.8.0-45-generic"))
+}
+
+func TestTranslatePath(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		distro string
+		want   string
+	}{
+		{"drive path", `C:\Users\me\project`, "Ubuntu", "/mnt/c/Users/me/project"},
+		{"drive path with forward slashes", "D:/work/app", "Ubuntu", "/mnt/d/work/app"},
+		{"drive root", `C:\`, "Ubuntu", "/mnt/c"},
+		{"wsl$ share", `\\wsl$\Ubuntu\home\me\project`, "Ubuntu", "/home/me/project"},
+		{"wsl.localhost share", `\\wsl.localhost\Ubuntu\home\me`, "Ubuntu", "/home/me"},
+		{"distro name is case insensitive", `\\wsl$\ubuntu\home\me`, "Ubuntu", "/home/me"},
+		{"other distro unchanged", `\\wsl$\Debian\home\me`, "Ubuntu", `\\wsl$\Debian\home\me`},
+		{"linux path unchanged", "/home/me/project", "Ubuntu", "/home/me/project"},
+		{"mnt path unchanged", "/mnt/c/Users/me", "Ubuntu", "/mnt/c/Users/me"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, TranslatePath(tt.path, tt.distro))
+		})
+	}
+}
+
+func TestOnWindowsDrive(t *testing.T) {
+	mounts := `/dev/sdc / ext4 rw,relatime 0 0
+C:\134 /mnt/c 9p rw,noatime,aname=drvfs;path=C:\;uid=1000 0 0
+D:\134 /mnt/d drvfs rw,noatime 0 0
+none /mnt/wsl tmpfs rw,relatime 0 0
+`
+	assert.True(t, onWindowsDrive("/mnt/c/Users/me/project", mounts))
+	assert.True(t, onWindowsDrive("/mnt/c", mounts))
+	assert.True(t, onWindowsDrive("/mnt/d/work", mounts))
+	assert.False(t, onWindowsDrive("/home/me/project", mounts))
+	assert.False(t, onWindowsDrive("/mnt/wsl/shared", mounts))
+	assert.False(t, onWindowsDrive("/mnt/cache", mounts), "prefix of a mount point is not inside it")
+}
+
+func TestRelocationTarget(t *testing.T) {
+	assert.Equal(t, "/home/me/projects/app", RelocationTarget("/mnt/c/Users/me/code/app", "/home/me"))
+}
+
+func TestRelocate(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "cmd"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "cmd", "main.go"), []byte("package main\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "run.sh"), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, os.Symlink("run.sh", filepath.Join(src, "start")))
+
+	dst := filepath.Join(t.TempDir(), "projects", "app")
+	require.NoError(t, Relocate(src, dst))
+
+	data, err := os.ReadFile(filepath.Join(dst, "cmd", "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(data))
+
+	info, err := os.Stat(filepath.Join(dst, "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	link, err := os.Readlink(filepath.Join(dst, "start"))
+	require.NoError(t, err)
+	assert.Equal(t, "run.sh", link)
+
+	t.Run("existing destination is refused", func(t *testing.T) {
+		err := Relocate(src, dst)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+	})
+}
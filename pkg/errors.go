@@ -0,0 +1,260 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorDocsURL is the page documenting every error code, with one section per code
+const ErrorDocsURL = "https://github.com/dyluth/claude-reactor/blob/main/docs/ERRORS.md"
+
+// Error codes, grouped by area as CR-<AREA>-<NUMBER>. Codes are stable: a code is never reused
+// for a different problem, so scripts and documentation can rely on them.
+const (
+	CodeDockerUnreachable   = "CR-DOCKER-001"
+	CodeHostDockerSocket    = "CR-DOCKER-002"
+	CodeDockerTimeout       = "CR-DOCKER-003"
+	CodeRegistryPush        = "CR-REGISTRY-001"
+	CodeContainerNotRunning = "CR-CONTAINER-001"
+	CodeSessionLost         = "CR-CONTAINER-002"
+	CodeProjectBusy         = "CR-CONTAINER-003"
+	CodeSessionMissing      = "CR-CONTAINER-004"
+	CodeMultiplexerMissing  = "CR-CONTAINER-005"
+	CodeImageNotBuilt       = "CR-IMAGE-001"
+	CodeMountMissing        = "CR-MOUNT-001"
+	CodeMountInaccessible   = "CR-MOUNT-002"
+	CodeSSHAgentMissing     = "CR-SSH-001"
+	CodeSSHAgentSocket      = "CR-SSH-002"
+	CodeImageNotPinned      = "CR-POLICY-001"
+	CodeImageVulnerable     = "CR-POLICY-002"
+	CodeImageScanFailed     = "CR-POLICY-003"
+	CodeHostToolMissing     = "CR-HOST-001"
+	CodeBrowserFailed       = "CR-HOST-002"
+	CodeEmulatorMissing     = "CR-PLATFORM-001"
+	CodePortUnreachable     = "CR-PORT-001"
+	CodePortInUse           = "CR-PORT-002"
+	CodeNotDefined          = "CR-CONFIG-001"
+	CodeSecretUnavailable   = "CR-SECRET-001"
+	CodeSandboxMissing      = "CR-SANDBOX-001"
+	CodeSandboxConflict     = "CR-SANDBOX-002"
+	CodeWorktreeMissing     = "CR-WORKTREE-001"
+	CodeWorktreePush        = "CR-WORKTREE-002"
+	CodeBatchFailed         = "CR-BATCH-001"
+	CodeInvalidUsage        = "CR-USAGE-001"
+	CodeNeedsConfirmation   = "CR-USAGE-002"
+)
+
+// ErrorInfo describes an error code in the remediation catalog
+type ErrorInfo struct {
+	Code  string `json:"code"`
+	Title string `json:"title"`
+	// Remediation lists the steps that usually fix the problem, most likely first
+	Remediation []string `json:"remediation"`
+}
+
+// ErrorCatalog describes every error code. Errors carry their own remediation when it
+// depends on the situation; these are the general steps shown otherwise.
+var ErrorCatalog = []ErrorInfo{
+	{CodeDockerUnreachable, "Docker daemon unreachable", []string{
+		"Start Docker (Docker Desktop, Colima or the docker service) and check it with: docker info",
+		"Set DOCKER_HOST if the daemon does not listen on the default socket",
+	}},
+	{CodeHostDockerSocket, "Host Docker socket unavailable", []string{
+		"Mount the Docker socket: -v /var/run/docker.sock:/var/run/docker.sock",
+		"Add the docker group: --group-add docker",
+	}},
+	{CodeDockerTimeout, "Docker operation timed out", []string{
+		"For complex builds, increase the timeout: --host-docker-timeout 15m",
+	}},
+	{CodeRegistryPush, "Image push failed", []string{
+		"Log in to the registry first with: docker login REGISTRY",
+	}},
+	{CodeContainerNotRunning, "Container not running", []string{
+		"Start it with: claude-reactor run",
+	}},
+	{CodeSessionLost, "Connection to the session lost", []string{
+		"Use 'claude-reactor run --tmux' so sessions survive disconnects",
+	}},
+	{CodeProjectBusy, "Project busy", []string{
+		"Try again once the other claude-reactor has finished starting the project",
+	}},
+	{CodeSessionMissing, "No detachable session", []string{
+		"Start one with: claude-reactor run --tmux",
+	}},
+	{CodeMultiplexerMissing, "No terminal multiplexer in the container", []string{
+		"Install tmux in the image or run without --tmux",
+	}},
+	{CodeImageNotBuilt, "Image not built", []string{
+		"Build it with: claude-reactor build --image VARIANT",
+	}},
+	{CodeMountMissing, "Mount source missing", []string{
+		"Create the source or remove the mount",
+	}},
+	{CodeMountInaccessible, "Mount source inaccessible", []string{
+		"Check the path's permissions and that its filesystem is mounted",
+	}},
+	{CodeSSHAgentMissing, "No SSH agent", []string{
+		"Start SSH agent: eval $(ssh-agent)",
+		"Add keys: ssh-add ~/.ssh/id_ed25519",
+		"Verify: ssh-add -l",
+	}},
+	{CodeSSHAgentSocket, "SSH agent socket inaccessible", []string{
+		"Ensure SSH agent is running: eval $(ssh-agent)",
+		"Check socket permissions",
+	}},
+	{CodeImageNotPinned, "Image not pinned by digest", []string{
+		"Find the digest with: docker buildx imagetools inspect IMAGE",
+	}},
+	{CodeImageVulnerable, "Image has vulnerabilities", []string{
+		"Run anyway with: claude-reactor run --allow-vulnerable",
+		"Change the threshold with: claude-reactor config set vuln_threshold <severity>",
+	}},
+	{CodeImageScanFailed, "Vulnerability scan failed", []string{
+		"Install trivy (https://trivy.dev) or grype (https://github.com/anchore/grype)",
+		"Run without a scan with: claude-reactor run --allow-vulnerable",
+		"Stop scanning with: claude-reactor config set vuln_threshold none",
+	}},
+	{CodeHostToolMissing, "Host tool missing", []string{
+		"Install the tool named in the error, or run without the feature that needs it",
+	}},
+	{CodeBrowserFailed, "Browser could not be opened", []string{
+		"Open the URL in the error manually",
+	}},
+	{CodeEmulatorMissing, "No emulator for the platform", []string{
+		"Install QEMU handlers with: docker run --privileged --rm tonistiigi/binfmt --install all",
+	}},
+	{CodePortUnreachable, "Container port unreachable", []string{
+		"Forward it with: claude-reactor forward PORT",
+	}},
+	{CodePortInUse, "Host port unavailable", []string{
+		"Choose a different host port: claude-reactor forward HOST-PORT:CONTAINER-PORT",
+	}},
+	{CodeNotDefined, "Not defined in this project", []string{
+		"See what the project defines with the command's list subcommand, such as: claude-reactor task list",
+	}},
+	{CodeSecretUnavailable, "Secret unavailable", []string{
+		"Update it with: claude-reactor secrets set NAME",
+	}},
+	{CodeSandboxMissing, "No running sandbox", []string{
+		"Start one with: claude-reactor run --sandbox",
+	}},
+	{CodeSandboxConflict, "Sandbox changes do not apply", []string{
+		"Review them with: claude-reactor sandbox diff",
+	}},
+	{CodeWorktreeMissing, "Worktree missing", []string{
+		"Start one with: claude-reactor run --branch BRANCH",
+	}},
+	{CodeWorktreePush, "Worktree push failed", []string{
+		"Push the branch yourself, then run: claude-reactor session finish BRANCH --no-push",
+	}},
+	{CodeBatchFailed, "Batch jobs failed", []string{
+		"See the batch report for each job's output",
+	}},
+	{CodeInvalidUsage, "Invalid command line", []string{
+		"See the command's usage with: claude-reactor COMMAND --help",
+	}},
+	{CodeNeedsConfirmation, "Confirmation needed", []string{
+		"Start anyway with --yes",
+	}},
+}
+
+// LookupError returns the catalog entry for an error code
+func LookupError(code string) (ErrorInfo, bool) {
+	for _, info := range ErrorCatalog {
+		if info.Code == code {
+			return info, true
+		}
+	}
+	return ErrorInfo{}, false
+}
+
+// ErrorDocs returns the link to the documentation of an error code
+func ErrorDocs(code string) string {
+	return ErrorDocsURL + "#" + strings.ToLower(code)
+}
+
+// Error is an error with a stable code from the catalog and the steps that fix it, so the CLI
+// can render it consistently, as text or JSON, and link to its documentation
+type Error struct {
+	Code        string
+	Message     string
+	Remediation []string
+	// Err is the underlying cause; may be nil
+	Err error
+}
+
+// NewError creates an error with code and the catalog's remediation steps. cause may be nil.
+func NewError(code string, cause error, format string, args ...interface{}) *Error {
+	info, _ := LookupError(code)
+	return &Error{
+		Code:        code,
+		Message:     fmt.Sprintf(format, args...),
+		Remediation: info.Remediation,
+		Err:         cause,
+	}
+}
+
+// WithRemediation replaces the catalog's remediation with steps specific to this error
+func (e *Error) WithRemediation(steps ...string) *Error {
+	e.Remediation = steps
+	return e
+}
+
+// Error returns the message and cause. The remediation is left out, so callers can wrap the
+// error with %w; the CLI shows it after the whole message.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Remediation returns the remediation steps of the error with a code in err's chain, if any
+func Remediation(err error) []string {
+	var coded *Error
+	if !errors.As(err, &coded) {
+		return nil
+	}
+	return coded.Remediation
+}
+
+// ErrorReport is the JSON form of an error
+type ErrorReport struct {
+	Code        string   `json:"code,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Message     string   `json:"message"`
+	Cause       string   `json:"cause,omitempty"`
+	Remediation []string `json:"remediation,omitempty"`
+	Docs        string   `json:"docs,omitempty"`
+}
+
+// NewErrorReport describes err for JSON output. Errors without a code only have a message.
+func NewErrorReport(err error) ErrorReport {
+	var coded *Error
+	if !errors.As(err, &coded) {
+		return ErrorReport{Message: err.Error()}
+	}
+
+	report := ErrorReport{
+		Code:        coded.Code,
+		Message:     coded.Message,
+		Remediation: coded.Remediation,
+		Docs:        ErrorDocs(coded.Code),
+	}
+	if info, ok := LookupError(coded.Code); ok {
+		report.Title = info.Title
+	}
+	if coded.Err != nil {
+		report.Cause = coded.Err.Error()
+	}
+	// Context added by callers wrapping the coded error belongs to the message
+	if outer := err.Error(); strings.HasSuffix(outer, coded.Error()) {
+		report.Message = strings.TrimSuffix(outer, coded.Error()) + coded.Message
+	}
+	return report
+}
@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	cause := errors.New("permission denied")
+
+	err := NewError(CodeMountInaccessible, cause, "mount source is not accessible: %s", "/data")
+	assert.Equal(t, "mount source is not accessible: /data: permission denied", err.Error())
+	assert.Equal(t, []string{"Check the path's permissions and that its filesystem is mounted"}, err.Remediation)
+	assert.ErrorIs(t, err, cause)
+
+	err = NewError(CodeMountMissing, nil, "mount source does not exist: %s", "/data").WithRemediation("Create it or remove the mount for /app")
+	assert.Equal(t, "mount source does not exist: /data", err.Error())
+	assert.Equal(t, []string{"Create it or remove the mount for /app"}, err.Remediation)
+
+	var coded *Error
+	require.True(t, errors.As(fmt.Errorf("failed to start: %w", err), &coded))
+	assert.Equal(t, CodeMountMissing, coded.Code)
+	assert.Equal(t, []string{"Create it or remove the mount for /app"}, Remediation(fmt.Errorf("failed to start: %w", err)))
+	assert.Nil(t, Remediation(cause))
+}
+
+func TestNewErrorReport(t *testing.T) {
+	t.Run("coded error", func(t *testing.T) {
+		err := fmt.Errorf("failed to create manager: %w", NewError(CodeDockerUnreachable, errors.New("no socket"), "failed to connect to Docker daemon"))
+		report := NewErrorReport(err)
+		assert.Equal(t, CodeDockerUnreachable, report.Code)
+		assert.Equal(t, "Docker daemon unreachable", report.Title)
+		assert.Equal(t, "failed to create manager: failed to connect to Docker daemon", report.Message)
+		assert.Equal(t, "no socket", report.Cause)
+		assert.NotEmpty(t, report.Remediation)
+		assert.Equal(t, ErrorDocsURL+"#cr-docker-001", report.Docs)
+	})
+
+	t.Run("plain error", func(t *testing.T) {
+		assert.Equal(t, ErrorReport{Message: "boom"}, NewErrorReport(errors.New("boom")))
+	})
+}
+
+func TestErrorCatalog(t *testing.T) {
+	docs, err := os.ReadFile("../docs/ERRORS.md")
+	require.NoError(t, err)
+
+	codePattern := regexp.MustCompile(`^CR-[A-Z]+-\d{3}$`)
+	seen := make(map[string]bool)
+	for _, info := range ErrorCatalog {
+		assert.Regexp(t, codePattern, info.Code)
+		assert.False(t, seen[info.Code], "%s is in the catalog twice", info.Code)
+		seen[info.Code] = true
+		assert.NotEmpty(t, info.Title, info.Code)
+		assert.NotEmpty(t, info.Remediation, info.Code)
+		assert.True(t, strings.Contains(string(docs), "### "+info.Code+"\n"), "%s is not documented in docs/ERRORS.md", info.Code)
+	}
+}
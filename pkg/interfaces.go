@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/docker/docker/client"
 )
@@ -25,6 +26,9 @@ type ConfigManager interface {
 	// LoadConfig loads configuration from file or creates default
 	LoadConfig() (*Config, error)
 
+	// LoadProjectConfig loads the configuration of the project in projectDir or creates default
+	LoadProjectConfig(projectDir string) (*Config, error)
+
 	// SaveConfig persists configuration to file
 	SaveConfig(config *Config) error
 
@@ -37,6 +41,9 @@ type ConfigManager interface {
 	// AutoDetectVariant detects project type from files in directory
 	AutoDetectVariant(projectPath string) (string, error)
 
+	// DetectProject returns detailed detection results (languages, tools, confidence) for a project
+	DetectProject(projectPath string) (*ProjectDetectionResult, error)
+
 	// ListAccounts returns available Claude accounts
 	ListAccounts() ([]string, error)
 
@@ -50,17 +57,43 @@ type ConfigManager interface {
 	PrepareSSHMounts(sshAgent bool, socketPath string) ([]Mount, error)
 }
 
-// DockerManager handles Docker container lifecycle and operations
-type DockerManager interface {
+// ImageService builds, names and removes claude-reactor images
+type ImageService interface {
 	// BuildImage builds a Docker image for the specified variant
 	BuildImage(ctx context.Context, variant string, platform string) error
 
 	// RebuildImage forces rebuild of Docker image
 	RebuildImage(ctx context.Context, variant string, platform string, force bool) error
 
+	// PackagesImageName returns the name of the image deriving from the local baseImage with
+	// packages installed; it changes whenever the base image or the package lists do
+	PackagesImageName(ctx context.Context, baseImage string, packages Packages) (string, error)
+
+	// BuildPackagesImage returns the image deriving from the local baseImage with packages
+	// installed, building it if it does not exist yet
+	BuildPackagesImage(ctx context.Context, baseImage string, packages Packages, platform string) (string, error)
+
+	// CleanImages removes claude-reactor images
+	CleanImages(ctx context.Context, all bool) error
+
+	// ListVariants returns available container variants
+	ListVariants() ([]VariantDefinition, error)
+
+	// GetImageName generates image name with architecture
+	GetImageName(variant, architecture string) string
+}
+
+// ContainerService manages the lifecycle of project containers and their sidecars
+type ContainerService interface {
 	// StartContainer starts a container with the given configuration
 	StartContainer(ctx context.Context, config *ContainerConfig) (string, error)
 
+	// CreateContainer creates a container with the given configuration without starting it
+	CreateContainer(ctx context.Context, config *ContainerConfig) (string, error)
+
+	// StartCreatedContainer starts a container made by CreateContainer and finishes its setup
+	StartCreatedContainer(ctx context.Context, config *ContainerConfig, containerID string) (string, error)
+
 	// StopContainer stops a running container
 	StopContainer(ctx context.Context, containerID string) error
 
@@ -88,30 +121,73 @@ type DockerManager interface {
 	// CleanAllContainers removes all claude-reactor containers
 	CleanAllContainers(ctx context.Context) error
 
-	// CleanImages removes claude-reactor images
-	CleanImages(ctx context.Context, all bool) error
+	// ListResources returns claude-reactor containers, sync volumes, and images with their disk usage
+	ListResources(ctx context.Context) ([]Resource, error)
 
-	// AttachToContainer executes commands in a running container
-	AttachToContainer(ctx context.Context, containerName string, command []string, interactive bool) error
+	// RemoveResource removes a container, volume, or image returned by ListResources
+	RemoveResource(ctx context.Context, resource Resource) error
+
+	// StartSidecar starts a helper container next to containerName on a network they share,
+	// reusing it while it runs, and returns the sidecar's container name
+	StartSidecar(ctx context.Context, containerName string, sidecar *Sidecar) (string, error)
+
+	// RemoveSidecar removes a sidecar of containerName; a missing sidecar is not an error
+	RemoveSidecar(ctx context.Context, containerName, name string) error
 
 	// HealthCheck verifies container is healthy and responsive
 	HealthCheck(ctx context.Context, containerName string, maxRetries int) error
 
-	// ListVariants returns available container variants
-	ListVariants() ([]VariantDefinition, error)
-
 	// GenerateContainerName creates unique container name with project hash
 	GenerateContainerName(projectPath, variant, architecture, account string) string
 
 	// GenerateProjectHash creates hash for project directory
 	GenerateProjectHash(projectPath string) string
+}
 
-	// GetImageName generates image name with architecture
-	GetImageName(variant, architecture string) string
+// ExecService runs sessions and commands in running containers
+type ExecService interface {
+	// AttachToContainer executes commands in a running container
+	AttachToContainer(ctx context.Context, containerName string, command []string, interactive bool) error
+
+	// ExecCommand runs a command in a running container and returns its output and exit code
+	ExecCommand(ctx context.Context, containerName string, command []string) (string, int, error)
+
+	// ExecPipe runs a command in a running container, streaming stdin in and stdout out
+	ExecPipe(ctx context.Context, containerName string, command []string, stdin io.Reader, stdout io.Writer) error
+
+	// EnableClipboardBridge copies OSC 52 clipboard requests from interactive sessions to the host clipboard
+	EnableClipboardBridge(enabled bool)
+
+	// SetWorkingDir starts attached sessions in dir, a container path; "" uses the image's working directory
+	SetWorkingDir(dir string)
 
+	// SetSessionEnv adds env, as KEY=VALUE, to attached sessions without recording it in the container
+	SetSessionEnv(env []string)
+}
+
+// RegistryService pulls and pushes published images
+type RegistryService interface {
 	// BuildImageWithRegistry builds an image with registry support (Phase 0.1)
 	BuildImageWithRegistry(ctx context.Context, variant, platform string, devMode, registryOff, pullLatest bool) error
 
+	// PushImage pushes a locally built variant to repository with architecture and manifest tags
+	PushImage(ctx context.Context, variant, platform, repository, tag string) ([]string, error)
+
+	// SetRegistryMirror pulls published images through a mirror; "" pulls them directly
+	SetRegistryMirror(mirror string)
+
+	// SetImageCache shares remembered image lookups so BuildImageWithRegistry can skip the daemon
+	SetImageCache(cache ImageCache)
+}
+
+// DockerManager handles Docker container lifecycle and operations. Code needing only part of
+// it can depend on one of the services it is made of.
+type DockerManager interface {
+	ImageService
+	ContainerService
+	ExecService
+	RegistryService
+
 	// GetClient returns the underlying Docker client for advanced operations
 	GetClient() *client.Client
 }
@@ -183,13 +259,107 @@ type Config struct {
 	SessionPersistence bool              `yaml:"session_persistence,omitempty"`
 	LastSessionID      string            `yaml:"last_session_id,omitempty"`
 	ContainerID        string            `yaml:"container_id,omitempty"`
+	ToolchainInstall   bool              `yaml:"toolchain_install,omitempty"`
+	RequiredTools      []string          `yaml:"required_tools,omitempty"`    // tools images must provide, as name[:package][>=version]
+	RecommendedTools   []string          `yaml:"recommended_tools,omitempty"` // tools images should provide, in place of the built-in list
+	ToolsInstall       bool              `yaml:"tools_install,omitempty"`     // install missing tools' apt packages in a derived image
+	VulnThreshold      string            `yaml:"vuln_threshold,omitempty"`
+	SyncMode           bool              `yaml:"sync_mode,omitempty"`
+	Clipboard          bool              `yaml:"clipboard,omitempty"`
+	Platform           string            `yaml:"platform,omitempty"`
+	ImageCacheTTL      string            `yaml:"image_cache_ttl,omitempty"`
+	ImageCacheSize     int               `yaml:"image_cache_size,omitempty"`
+	ImageCacheURL      string            `yaml:"image_cache_url,omitempty"`
+	ClaudeCLIVersion   string            `yaml:"claude_cli_version,omitempty"`
+	Notifications      string            `yaml:"notifications,omitempty"` // comma-separated event types
+	NotifyAfter        string            `yaml:"notify_after,omitempty"`
+	RegistryMirror     string            `yaml:"registry_mirror,omitempty"`
+	ClaudeArgs         string            `yaml:"claude_args,omitempty"` // extra Claude CLI arguments, shell-quoted
+	SystemPrompt       string            `yaml:"system_prompt,omitempty"` // comma-separated prompt files seeded into CLAUDE.md
+	Mounts             string            `yaml:"mounts,omitempty"`        // comma-separated src[:dst][:options] mounts
+	Tmpfs              string            `yaml:"tmpfs,omitempty"`         // comma-separated tmpfs container paths
+	Checkpoints        string            `yaml:"checkpoints,omitempty"`   // off, start, on, or an interval
+	ProtectedPaths     string            `yaml:"protected_paths,omitempty"` // comma-separated project paths mounted read-only
+	SecretScan         string            `yaml:"secret_scan,omitempty"`     // off, warn, exclude or placeholder
+	DNS                string            `yaml:"dns,omitempty"`             // comma-separated DNS servers
+	DNSSearch          string            `yaml:"dns_search,omitempty"`      // comma-separated DNS search domains
+	AddHosts           string            `yaml:"add_hosts,omitempty"`       // comma-separated host:ip /etc/hosts entries
+	Tasks              []Task            `yaml:"tasks,omitempty"`           // named commands for 'claude-reactor task'
+	Packages           Packages          `yaml:"packages,omitempty"`        // extra packages installed in a derived image
+	Secrets            []Secret          `yaml:"secrets,omitempty"`         // environment secrets fetched at each session
+	Dotfiles           string            `yaml:"dotfiles,omitempty"`         // dotfiles git URL or local directory
+	DotfilesInstall    string            `yaml:"dotfiles_install,omitempty"` // command installing the dotfiles
+	Hostname           string            `yaml:"hostname,omitempty"`         // container hostname template
+	Prompt             string            `yaml:"prompt,omitempty"`           // shell prompt label template, or off
 	Metadata           map[string]string `yaml:"metadata,omitempty"`
 }
 
+// Task is a named command run in the project container with 'claude-reactor task', stored as
+// task.NAME, task.NAME.env and task.NAME.workdir configuration keys
+type Task struct {
+	Name    string   `yaml:"name"`
+	Command string   `yaml:"command"`           // run with sh -c
+	Env     []string `yaml:"env,omitempty"`     // KEY=VALUE
+	Workdir string   `yaml:"workdir,omitempty"` // relative to the project root
+}
+
+// Secret is an environment variable whose value is fetched from a secret store on the host
+// each time a session starts and given only to the session's processes, never to the
+// container, its image or the configuration file. It is stored as a secret.NAME key.
+type Secret struct {
+	Name   string `yaml:"name"`
+	Source string `yaml:"source"` // keychain, or vault:PATH#FIELD
+}
+
+// Packages are extra packages installed on top of a project's variant, in a derived image
+// built once and reused until the lists change. They are stored as packages.apt,
+// packages.pip, packages.npm and packages.cargo configuration keys.
+type Packages struct {
+	Apt   []string `yaml:"apt,omitempty"`
+	Pip   []string `yaml:"pip,omitempty"`
+	Npm   []string `yaml:"npm,omitempty"`
+	Cargo []string `yaml:"cargo,omitempty"`
+}
+
+// Empty reports whether no packages are listed
+func (p Packages) Empty() bool {
+	return len(p.Apt) == 0 && len(p.Pip) == 0 && len(p.Npm) == 0 && len(p.Cargo) == 0
+}
+
+// PackageManagers are the package managers Packages lists packages for
+var PackageManagers = []string{"apt", "pip", "npm", "cargo"}
+
+// List returns the packages installed with manager, or nil for an unknown manager
+func (p *Packages) List(manager string) *[]string {
+	switch manager {
+	case "apt":
+		return &p.Apt
+	case "pip":
+		return &p.Pip
+	case "npm":
+		return &p.Npm
+	case "cargo":
+		return &p.Cargo
+	}
+	return nil
+}
+
+// Sidecar is a helper container that runs alongside a project container, such as a
+// containerized MCP server. It idles until commands are run in it.
+type Sidecar struct {
+	Name        string            // unique per project container, also its network alias
+	Image       string
+	Mounts      []Mount
+	WorkingDir  string
+	Environment map[string]string
+	Labels      map[string]string
+}
+
 // ContainerConfig represents Docker container configuration
 type ContainerConfig struct {
 	Image            string            `yaml:"image"`
 	Name             string            `yaml:"name"`
+	Hostname         string            `yaml:"hostname,omitempty"` // Docker's default, the container ID, if empty
 	Variant          string            `yaml:"variant"`
 	Platform         string            `yaml:"platform"`
 	Mounts           []Mount           `yaml:"mounts,omitempty"`
@@ -200,10 +370,42 @@ type ContainerConfig struct {
 	TTY              bool              `yaml:"tty"`
 	Remove           bool              `yaml:"remove"`
 	RunClaudeUpgrade bool              `yaml:"run_claude_upgrade,omitempty"`
+	ClaudeCLIVersion string            `yaml:"claude_cli_version,omitempty"` // installed at start instead of upgrading
 	HostDocker       bool              `yaml:"host_docker,omitempty"`
 	HostDockerTimeout string           `yaml:"host_docker_timeout,omitempty"`
 	SSHAgent         bool              `yaml:"ssh_agent,omitempty"`
 	SSHAgentSocket   string            `yaml:"ssh_agent_socket,omitempty"`
+	SyncMode         bool              `yaml:"sync_mode,omitempty"`
+	ReadOnlyProject  bool              `yaml:"read_only_project,omitempty"` // project mounted read-only, with a writable scratch directory
+	Sandbox          bool              `yaml:"sandbox,omitempty"`           // project copied into a private volume, applied back as a patch
+	ProtectedPaths   []string          `yaml:"protected_paths,omitempty"`   // project-relative paths mounted read-only over the project
+	Devices          []string          `yaml:"devices,omitempty"` // host[:container[:permissions]]
+	DNS              []string          `yaml:"dns,omitempty"`               // DNS servers, in priority order
+	DNSSearch        []string          `yaml:"dns_search,omitempty"`        // DNS search domains
+	ExtraHosts       []string          `yaml:"extra_hosts,omitempty"`       // host:ip entries added to /etc/hosts
+	Labels           map[string]string `yaml:"labels,omitempty"`  // identify the project and account for cleanup
+}
+
+// Resource kinds
+const (
+	ResourceContainer = "container"
+	ResourceVolume    = "volume"
+	ResourceImage     = "image"
+	ResourceSession   = "session" // conversation history under ~/.claude-reactor/{account}/
+	ResourceCache     = "cache"   // validation results for an image that no longer exists
+)
+
+// Resource is something claude-reactor created that takes up disk space
+type Resource struct {
+	Kind        string    `json:"kind"`
+	ID          string    `json:"id"`   // container/image ID, volume name, session directory, or cached image digest
+	Name        string    `json:"name"`
+	ProjectPath string    `json:"project_path,omitempty"`
+	ProjectHash string    `json:"project_hash,omitempty"` // empty for images, which projects share
+	Account     string    `json:"account,omitempty"`
+	Created     time.Time `json:"created"`
+	Size        int64     `json:"size"` // bytes; -1 when unknown
+	Running     bool      `json:"running,omitempty"`
 }
 
 // Mount represents a container mount point
@@ -212,6 +414,10 @@ type Mount struct {
 	Target   string `yaml:"target"`
 	Type     string `yaml:"type"` // bind, volume, tmpfs
 	ReadOnly bool   `yaml:"read_only,omitempty"`
+	// Optional mounts are skipped with a warning when their source is unusable
+	Optional bool `yaml:"optional,omitempty"`
+	// Consistency is the bind mount consistency for Docker Desktop: cached, delegated or consistent
+	Consistency string `yaml:"consistency,omitempty"`
 }
 
 // AuthConfig represents authentication configuration
@@ -232,6 +438,26 @@ type VariantDefinition struct {
 	Environment  map[string]string `yaml:"environment,omitempty"`
 	Dependencies []string          `yaml:"dependencies,omitempty"`
 	Size         string            `yaml:"size,omitempty"`
+	Target       string            `yaml:"target,omitempty"`
+	Image        string            `yaml:"image,omitempty"`
+	Detect       *VariantDetection `yaml:"detect,omitempty"`
+	// Source is the definition file for external variants; empty for built-in variants
+	Source string `yaml:"-"`
+}
+
+// VariantDetection describes the projects an external variant is offered for in auto-detection
+type VariantDetection struct {
+	Language string          `yaml:"language"`
+	Markers  []VariantMarker `yaml:"markers"`
+}
+
+// VariantMarker is a file whose presence suggests an external variant
+type VariantMarker struct {
+	Pattern    string  `yaml:"pattern"`
+	Contains   string  `yaml:"contains,omitempty"`
+	Confidence float64 `yaml:"confidence"`
+	Framework  string  `yaml:"framework,omitempty"`
+	Tool       string  `yaml:"tool,omitempty"`
 }
 
 // MountManager handles container mount operations
@@ -247,6 +473,15 @@ type MountManager interface {
 
 	// UpdateMountSettings updates Claude settings for mounted directories
 	UpdateMountSettings(mountPaths []string) error
+
+	// ParseMountSpec parses and validates a mount given as src[:dst][:ro|rw][:cached|delegated|consistent]
+	ParseMountSpec(spec string) (*Mount, error)
+
+	// ParseTmpfsSpec parses and validates a tmpfs mount given as its container path
+	ParseTmpfsSpec(target string) (*Mount, error)
+
+	// AddMount adds a parsed mount to container config, refusing a target already mounted from elsewhere
+	AddMount(config *ContainerConfig, mount *Mount) error
 }
 
 // ContainerStatus represents container state information
@@ -256,6 +491,34 @@ type ContainerStatus struct {
 	Name    string `yaml:"name"`
 	Image   string `yaml:"image"`
 	ID      string `yaml:"id,omitempty"`
+	// Fresh is set for containers that were created but never started, e.g. by 'prewarm'
+	Fresh bool `yaml:"fresh,omitempty"`
+	// Ports lists TCP ports published to the host
+	Ports []PortMapping `yaml:"ports,omitempty"`
+	// ConfigHash fingerprints the configuration the container was created with; empty for
+	// containers created before it was recorded
+	ConfigHash string `yaml:"config_hash,omitempty"`
+	// Health is the Docker health check state (starting, healthy, unhealthy); empty for
+	// images without a health check
+	Health string `yaml:"health,omitempty"`
+	// Labels are the container's Docker labels
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+// PortMapping is a host port mapped to a port inside a container
+type PortMapping struct {
+	HostPort      int `yaml:"host_port"`
+	ContainerPort int `yaml:"container_port"`
+}
+
+// ExitError reports a non-zero exit code from a command run inside a container,
+// so callers can propagate it as the process exit code
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command exited with code %d", e.Code)
 }
 
 
@@ -284,11 +547,81 @@ type ImageValidator interface {
 	// ValidateImage validates a Docker image for claude-reactor compatibility
 	ValidateImage(ctx context.Context, imageName string, pullIfNeeded bool) (*ImageValidationResult, error)
 
+	// ScanImage runs a CVE scan on an image and returns findings by severity
+	ScanImage(ctx context.Context, imageName string) (*ImageScanResult, error)
+
 	// ClearCache removes all cached validation results
 	ClearCache() error
 
 	// ClearSessionWarnings resets session warning tracking
 	ClearSessionWarnings()
+
+	// SetCachePolicy changes how long results are trusted and how many are kept
+	SetCachePolicy(policy ImageCachePolicy)
+
+	// SetRevalidate ignores cached results and lookups, refreshing them from the daemon
+	SetRevalidate(revalidate bool)
+
+	// SetRegistryMirror pulls published images through a mirror; "" pulls them directly
+	SetRegistryMirror(mirror string)
+
+	// SetToolRequirements checks images for tools in place of the built-in recommended tools
+	SetToolRequirements(tools []ToolRequirement)
+
+	// CacheStats describes the validation cache and how it was used by this process
+	CacheStats() (*ImageCacheStats, error)
+
+	// OrphanedCacheEntries lists cached validation results for images that no longer exist locally
+	OrphanedCacheEntries(ctx context.Context) ([]Resource, error)
+
+	// RemoveCacheEntry deletes the cached validation and scan results for an image digest
+	RemoveCacheEntry(digest string) error
+
+	// ExportCache writes the fresh validation results to w and returns how many
+	ExportCache(w io.Writer) (int, error)
+
+	// ImportCache adds validation results exported by ExportCache, keeping newer local ones,
+	// and returns how many were added
+	ImportCache(r io.Reader) (int, error)
+
+	// VerifySignature checks an image's cosign signature against the configured signers,
+	// pulling the image if needed, and describes the signer that verified it
+	VerifySignature(ctx context.Context, imageName string, signatures SignaturePolicy) (string, error)
+}
+
+// ImageCache remembers which image names exist locally, so repeated lookups can skip the daemon
+type ImageCache interface {
+	// LookupImage returns the ID of a recently seen local image
+	LookupImage(imageName string) (string, bool)
+
+	// RememberImage records that imageName exists locally with the given ID
+	RememberImage(imageName, imageID string)
+
+	// ForgetImage drops imageName, e.g. after it was removed or replaced
+	ForgetImage(imageName string)
+}
+
+// ImageCachePolicy controls validation cache lifetime and size. Zero values use the defaults.
+type ImageCachePolicy struct {
+	TTL          time.Duration // how long a validation result is trusted
+	MaxEntries   int           // results kept before the oldest are evicted
+	ExistenceTTL time.Duration // how long a name -> image ID lookup is trusted
+	Shared       string        // http(s):// or s3:// location where a team shares results
+}
+
+// ImageCacheStats describes the image validation cache
+type ImageCacheStats struct {
+	Dir          string        `json:"dir"`
+	Entries      int           `json:"entries"`
+	Images       int           `json:"images"` // remembered name -> image ID lookups
+	Bytes        int64         `json:"bytes"`
+	Oldest       time.Time     `json:"oldest,omitempty"`
+	Newest       time.Time     `json:"newest,omitempty"`
+	TTL          time.Duration `json:"ttl"`
+	MaxEntries   int           `json:"max_entries"`
+	ExistenceTTL time.Duration `json:"existence_ttl"`
+	Hits         int           `json:"hits"`
+	Misses       int           `json:"misses"`
 }
 
 // ImageValidationResult represents the result of image validation
@@ -304,6 +637,66 @@ type ImageValidationResult struct {
 	Errors       []string               `json:"errors"`
 	ValidatedAt  string                 `json:"validated_at"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Tools        []ToolCheck            `json:"tools,omitempty"`    // configured tool requirements, in order
+	ToolSet      string                 `json:"tool_set,omitempty"` // fingerprint of the requirements checked
+}
+
+// ToolRequirement is a tool images must or should provide, from the tools.required and
+// tools.recommended configuration keys or the image policy
+type ToolRequirement struct {
+	Name       string `json:"name"`                  // command, run with --version
+	Package    string `json:"package,omitempty"`     // apt package providing it; "" for Name
+	MinVersion string `json:"min_version,omitempty"` // lowest acceptable version; "" for any
+	Required   bool   `json:"required,omitempty"`    // images without it are incompatible
+}
+
+// AptPackage returns the apt package that provides the tool
+func (t ToolRequirement) AptPackage() string {
+	if t.Package != "" {
+		return t.Package
+	}
+	return t.Name
+}
+
+// ToolCheck is how an image fares against a ToolRequirement
+type ToolCheck struct {
+	ToolRequirement
+	Version string `json:"version,omitempty"` // reported version; "" when missing or unknown
+	Status  string `json:"status"`            // ok, missing or outdated
+}
+
+// Tool check statuses
+const (
+	ToolOK       = "ok"
+	ToolMissing  = "missing"
+	ToolOutdated = "outdated"
+)
+
+// SignaturePolicy says which cosign signers images must be signed by
+type SignaturePolicy struct {
+	Required   bool                `yaml:"required"`   // refuse images that fail verification instead of warning
+	Keys       []string            `yaml:"keys"`       // public key files or KMS URIs
+	Identities []SignatureIdentity `yaml:"identities"` // keyless (Fulcio certificate) signers
+}
+
+// SignatureIdentity is a keyless signer: the OIDC issuer and a regular expression for the
+// certificate identity, such as a CI workflow URL
+type SignatureIdentity struct {
+	Issuer  string `yaml:"issuer"`
+	Subject string `yaml:"subject"`
+}
+
+// Configured reports whether any signer is configured
+func (p SignaturePolicy) Configured() bool {
+	return len(p.Keys) > 0 || len(p.Identities) > 0
+}
+
+// ImageScanResult represents the result of an image vulnerability scan
+type ImageScanResult struct {
+	Digest    string         `json:"digest"`
+	Scanner   string         `json:"scanner"`
+	Counts    map[string]int `json:"counts"` // findings per lowercase severity
+	ScannedAt string         `json:"scanned_at"`
 }
 
 // AppContainer holds all application dependencies
@@ -20,6 +20,11 @@ func (m *MockConfigManager) LoadConfig() (*pkg.Config, error) {
 	return args.Get(0).(*pkg.Config), args.Error(1)
 }
 
+func (m *MockConfigManager) LoadProjectConfig(projectDir string) (*pkg.Config, error) {
+	args := m.Called(projectDir)
+	return args.Get(0).(*pkg.Config), args.Error(1)
+}
+
 func (m *MockConfigManager) SaveConfig(config *pkg.Config) error {
 	args := m.Called(config)
 	return args.Error(0)
@@ -35,6 +40,14 @@ func (m *MockConfigManager) AutoDetectVariant(projectPath string) (string, error
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockConfigManager) DetectProject(projectPath string) (*pkg.ProjectDetectionResult, error) {
+	args := m.Called(projectPath)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pkg.ProjectDetectionResult), args.Error(1)
+}
+
 func (m *MockConfigManager) GetConfigPath() (string, error) {
 	args := m.Called()
 	return args.String(0), args.Error(1)
@@ -80,6 +93,16 @@ func (m *MockDockerManager) StartContainer(ctx context.Context, config *pkg.Cont
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockDockerManager) CreateContainer(ctx context.Context, config *pkg.ContainerConfig) (string, error) {
+	args := m.Called(ctx, config)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockDockerManager) StartCreatedContainer(ctx context.Context, config *pkg.ContainerConfig, containerID string) (string, error) {
+	args := m.Called(ctx, config, containerID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockDockerManager) StopContainer(ctx context.Context, containerID string) error {
 	args := m.Called(ctx, containerID)
 	return args.Error(0)
@@ -105,6 +128,16 @@ func (m *MockDockerManager) RebuildImage(ctx context.Context, variant string, pl
 	return args.Error(0)
 }
 
+func (m *MockDockerManager) PackagesImageName(ctx context.Context, baseImage string, packages pkg.Packages) (string, error) {
+	args := m.Called(ctx, baseImage, packages)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockDockerManager) BuildPackagesImage(ctx context.Context, baseImage string, packages pkg.Packages, platform string) (string, error) {
+	args := m.Called(ctx, baseImage, packages, platform)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockDockerManager) GetContainerStatus(ctx context.Context, containerName string) (*pkg.ContainerStatus, error) {
 	args := m.Called(ctx, containerName)
 	return args.Get(0).(*pkg.ContainerStatus), args.Error(1)
@@ -120,11 +153,66 @@ func (m *MockDockerManager) CleanAllContainers(ctx context.Context) error {
 	return args.Error(0)
 }
 
+func (m *MockDockerManager) ExecPipe(ctx context.Context, containerName string, command []string, stdin io.Reader, stdout io.Writer) error {
+	args := m.Called(ctx, containerName, command, stdin, stdout)
+	return args.Error(0)
+}
+
+func (m *MockDockerManager) EnableClipboardBridge(enabled bool) {
+	m.Called(enabled)
+}
+
+func (m *MockDockerManager) SetWorkingDir(dir string) {
+	m.Called(dir)
+}
+
+func (m *MockDockerManager) SetSessionEnv(env []string) {
+	m.Called(env)
+}
+
+func (m *MockDockerManager) SetImageCache(cache pkg.ImageCache) {
+	m.Called(cache)
+}
+
+func (m *MockDockerManager) SetRegistryMirror(mirror string) {
+	m.Called(mirror)
+}
+
+func (m *MockDockerManager) StartSidecar(ctx context.Context, containerName string, sidecar *pkg.Sidecar) (string, error) {
+	args := m.Called(ctx, containerName, sidecar)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockDockerManager) RemoveSidecar(ctx context.Context, containerName, name string) error {
+	args := m.Called(ctx, containerName, name)
+	return args.Error(0)
+}
+
+func (m *MockDockerManager) PushImage(ctx context.Context, variant, platform, repository, tag string) ([]string, error) {
+	args := m.Called(ctx, variant, platform, repository, tag)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockDockerManager) ListResources(ctx context.Context) ([]pkg.Resource, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]pkg.Resource), args.Error(1)
+}
+
+func (m *MockDockerManager) RemoveResource(ctx context.Context, resource pkg.Resource) error {
+	args := m.Called(ctx, resource)
+	return args.Error(0)
+}
+
 func (m *MockDockerManager) AttachToContainer(ctx context.Context, containerName string, command []string, interactive bool) error {
 	args := m.Called(ctx, containerName, command, interactive)
 	return args.Error(0)
 }
 
+func (m *MockDockerManager) ExecCommand(ctx context.Context, containerName string, command []string) (string, int, error) {
+	args := m.Called(ctx, containerName, command)
+	return args.String(0), args.Int(1), args.Error(2)
+}
+
 func (m *MockDockerManager) HealthCheck(ctx context.Context, containerName string, maxRetries int) error {
 	args := m.Called(ctx, containerName, maxRetries)
 	return args.Error(0)
@@ -258,6 +346,23 @@ func (m *MockMountManager) GetMountSummary(mounts []pkg.Mount) string {
 	return args.String(0)
 }
 
+func (m *MockMountManager) ParseMountSpec(spec string) (*pkg.Mount, error) {
+	args := m.Called(spec)
+	mount, _ := args.Get(0).(*pkg.Mount)
+	return mount, args.Error(1)
+}
+
+func (m *MockMountManager) ParseTmpfsSpec(target string) (*pkg.Mount, error) {
+	args := m.Called(target)
+	mount, _ := args.Get(0).(*pkg.Mount)
+	return mount, args.Error(1)
+}
+
+func (m *MockMountManager) AddMount(config *pkg.ContainerConfig, mount *pkg.Mount) error {
+	args := m.Called(config, mount)
+	return args.Error(0)
+}
+
 func (m *MockMountManager) UpdateMountSettings(mountPaths []string) error {
 	args := m.Called(mountPaths)
 	return args.Error(0)
@@ -283,6 +388,80 @@ func (m *MockArchDetector) IsMultiArchSupported() bool {
 	return args.Bool(0)
 }
 
+// MockImageValidator is a mock implementation of ImageValidator
+type MockImageValidator struct {
+	mock.Mock
+}
+
+func (m *MockImageValidator) ValidateImage(ctx context.Context, imageName string, pullIfNeeded bool) (*pkg.ImageValidationResult, error) {
+	args := m.Called(ctx, imageName, pullIfNeeded)
+	result, _ := args.Get(0).(*pkg.ImageValidationResult)
+	return result, args.Error(1)
+}
+
+func (m *MockImageValidator) ScanImage(ctx context.Context, imageName string) (*pkg.ImageScanResult, error) {
+	args := m.Called(ctx, imageName)
+	result, _ := args.Get(0).(*pkg.ImageScanResult)
+	return result, args.Error(1)
+}
+
+func (m *MockImageValidator) ClearCache() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockImageValidator) ClearSessionWarnings() {
+	m.Called()
+}
+
+func (m *MockImageValidator) SetCachePolicy(policy pkg.ImageCachePolicy) {
+	m.Called(policy)
+}
+
+func (m *MockImageValidator) SetRevalidate(revalidate bool) {
+	m.Called(revalidate)
+}
+
+func (m *MockImageValidator) SetRegistryMirror(mirror string) {
+	m.Called(mirror)
+}
+
+func (m *MockImageValidator) SetToolRequirements(tools []pkg.ToolRequirement) {
+	m.Called(tools)
+}
+
+func (m *MockImageValidator) CacheStats() (*pkg.ImageCacheStats, error) {
+	args := m.Called()
+	stats, _ := args.Get(0).(*pkg.ImageCacheStats)
+	return stats, args.Error(1)
+}
+
+func (m *MockImageValidator) OrphanedCacheEntries(ctx context.Context) ([]pkg.Resource, error) {
+	args := m.Called(ctx)
+	resources, _ := args.Get(0).([]pkg.Resource)
+	return resources, args.Error(1)
+}
+
+func (m *MockImageValidator) RemoveCacheEntry(digest string) error {
+	args := m.Called(digest)
+	return args.Error(0)
+}
+
+func (m *MockImageValidator) ExportCache(w io.Writer) (int, error) {
+	args := m.Called(w)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockImageValidator) ImportCache(r io.Reader) (int, error) {
+	args := m.Called(r)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockImageValidator) VerifySignature(ctx context.Context, imageName string, signatures pkg.SignaturePolicy) (string, error) {
+	args := m.Called(ctx, imageName, signatures)
+	return args.String(0), args.Error(1)
+}
+
 // MockLogger is a mock implementation of Logger
 type MockLogger struct {
 	mock.Mock
@@ -0,0 +1,215 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+
+	"claude-reactor/pkg"
+)
+
+// The services making up pkg.DockerManager, for tests of code that depends on only one of
+// them. MockDockerManager implements them all.
+var (
+	_ pkg.DockerManager    = (*MockDockerManager)(nil)
+	_ pkg.ImageService     = (*MockImageService)(nil)
+	_ pkg.ContainerService = (*MockContainerService)(nil)
+	_ pkg.ExecService      = (*MockExecService)(nil)
+	_ pkg.RegistryService  = (*MockRegistryService)(nil)
+)
+
+// MockImageService is a mock implementation of ImageService
+type MockImageService struct {
+	mock.Mock
+}
+
+func (m *MockImageService) BuildImage(ctx context.Context, variant string, platform string) error {
+	args := m.Called(ctx, variant, platform)
+	return args.Error(0)
+}
+
+func (m *MockImageService) RebuildImage(ctx context.Context, variant string, platform string, force bool) error {
+	args := m.Called(ctx, variant, platform, force)
+	return args.Error(0)
+}
+
+func (m *MockImageService) PackagesImageName(ctx context.Context, baseImage string, packages pkg.Packages) (string, error) {
+	args := m.Called(ctx, baseImage, packages)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockImageService) BuildPackagesImage(ctx context.Context, baseImage string, packages pkg.Packages, platform string) (string, error) {
+	args := m.Called(ctx, baseImage, packages, platform)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockImageService) CleanImages(ctx context.Context, all bool) error {
+	args := m.Called(ctx, all)
+	return args.Error(0)
+}
+
+func (m *MockImageService) ListVariants() ([]pkg.VariantDefinition, error) {
+	args := m.Called()
+	return args.Get(0).([]pkg.VariantDefinition), args.Error(1)
+}
+
+func (m *MockImageService) GetImageName(variant, architecture string) string {
+	args := m.Called(variant, architecture)
+	return args.String(0)
+}
+
+// MockContainerService is a mock implementation of ContainerService
+type MockContainerService struct {
+	mock.Mock
+}
+
+func (m *MockContainerService) StartContainer(ctx context.Context, config *pkg.ContainerConfig) (string, error) {
+	args := m.Called(ctx, config)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockContainerService) CreateContainer(ctx context.Context, config *pkg.ContainerConfig) (string, error) {
+	args := m.Called(ctx, config)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockContainerService) StartCreatedContainer(ctx context.Context, config *pkg.ContainerConfig, containerID string) (string, error) {
+	args := m.Called(ctx, config, containerID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockContainerService) StopContainer(ctx context.Context, containerID string) error {
+	args := m.Called(ctx, containerID)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) RemoveContainer(ctx context.Context, containerID string) error {
+	args := m.Called(ctx, containerID)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) IsContainerRunning(ctx context.Context, containerName string) (bool, error) {
+	args := m.Called(ctx, containerName)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockContainerService) GetContainerLogs(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	args := m.Called(ctx, containerID)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockContainerService) StartOrRecoverContainer(ctx context.Context, config *pkg.ContainerConfig, sessionConfig *pkg.Config) (string, error) {
+	args := m.Called(ctx, config, sessionConfig)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockContainerService) IsContainerHealthy(ctx context.Context, containerID string) (bool, error) {
+	args := m.Called(ctx, containerID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockContainerService) GetContainerStatus(ctx context.Context, containerName string) (*pkg.ContainerStatus, error) {
+	args := m.Called(ctx, containerName)
+	return args.Get(0).(*pkg.ContainerStatus), args.Error(1)
+}
+
+func (m *MockContainerService) CleanContainer(ctx context.Context, containerName string) error {
+	args := m.Called(ctx, containerName)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) CleanAllContainers(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) ListResources(ctx context.Context) ([]pkg.Resource, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]pkg.Resource), args.Error(1)
+}
+
+func (m *MockContainerService) RemoveResource(ctx context.Context, resource pkg.Resource) error {
+	args := m.Called(ctx, resource)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) StartSidecar(ctx context.Context, containerName string, sidecar *pkg.Sidecar) (string, error) {
+	args := m.Called(ctx, containerName, sidecar)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockContainerService) RemoveSidecar(ctx context.Context, containerName, name string) error {
+	args := m.Called(ctx, containerName, name)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) HealthCheck(ctx context.Context, containerName string, maxRetries int) error {
+	args := m.Called(ctx, containerName, maxRetries)
+	return args.Error(0)
+}
+
+func (m *MockContainerService) GenerateContainerName(projectPath, variant, architecture, account string) string {
+	args := m.Called(projectPath, variant, architecture, account)
+	return args.String(0)
+}
+
+func (m *MockContainerService) GenerateProjectHash(projectPath string) string {
+	args := m.Called(projectPath)
+	return args.String(0)
+}
+
+// MockExecService is a mock implementation of ExecService
+type MockExecService struct {
+	mock.Mock
+}
+
+func (m *MockExecService) AttachToContainer(ctx context.Context, containerName string, command []string, interactive bool) error {
+	args := m.Called(ctx, containerName, command, interactive)
+	return args.Error(0)
+}
+
+func (m *MockExecService) ExecCommand(ctx context.Context, containerName string, command []string) (string, int, error) {
+	args := m.Called(ctx, containerName, command)
+	return args.String(0), args.Int(1), args.Error(2)
+}
+
+func (m *MockExecService) ExecPipe(ctx context.Context, containerName string, command []string, stdin io.Reader, stdout io.Writer) error {
+	args := m.Called(ctx, containerName, command, stdin, stdout)
+	return args.Error(0)
+}
+
+func (m *MockExecService) EnableClipboardBridge(enabled bool) {
+	m.Called(enabled)
+}
+
+func (m *MockExecService) SetWorkingDir(dir string) {
+	m.Called(dir)
+}
+
+func (m *MockExecService) SetSessionEnv(env []string) {
+	m.Called(env)
+}
+
+// MockRegistryService is a mock implementation of RegistryService
+type MockRegistryService struct {
+	mock.Mock
+}
+
+func (m *MockRegistryService) BuildImageWithRegistry(ctx context.Context, variant, platform string, devMode, registryOff, pullLatest bool) error {
+	args := m.Called(ctx, variant, platform, devMode, registryOff, pullLatest)
+	return args.Error(0)
+}
+
+func (m *MockRegistryService) PushImage(ctx context.Context, variant, platform, repository, tag string) ([]string, error) {
+	args := m.Called(ctx, variant, platform, repository, tag)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockRegistryService) SetRegistryMirror(mirror string) {
+	m.Called(mirror)
+}
+
+func (m *MockRegistryService) SetImageCache(cache pkg.ImageCache) {
+	m.Called(cache)
+}
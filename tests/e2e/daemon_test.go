@@ -0,0 +1,174 @@
+//go:build e2e
+
+// Package e2e runs claude-reactor against a real Docker daemon that exists only for the test
+// run, so builds, containers and cleanup can be exercised without touching the developer's own
+// images and containers. Run it with 'make e2e'.
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+)
+
+// dindImage runs the throwaway daemon. Without TLS it listens on plain TCP port 2375.
+const dindImage = "docker:27-dind"
+
+// daemonTimeout bounds how long the throwaway daemon may take to accept requests
+const daemonTimeout = 90 * time.Second
+
+// workDir holds the test HOME and bind mount sources. The throwaway daemon sees it at the
+// same path, so bind mounts created through it resolve to the same files.
+var workDir string
+
+// TestMain starts a Docker-in-Docker daemon through the host's daemon and points DOCKER_HOST at
+// it for the tests. REACTOR_E2E_DOCKER_HOST uses an existing throwaway daemon instead, e.g. a
+// CI service container; it must see REACTOR_E2E_WORKDIR at the same path for bind mounts.
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	ctx := context.Background()
+
+	var err error
+	if workDir = os.Getenv("REACTOR_E2E_WORKDIR"); workDir == "" {
+		if workDir, err = os.MkdirTemp("", "claude-reactor-e2e-"); err != nil {
+			fmt.Fprintf(os.Stderr, "e2e: failed to create work directory: %v\n", err)
+			return 1
+		}
+		// Files written by containers may belong to root; whatever can be removed is
+		defer os.RemoveAll(workDir)
+	}
+	if workDir, err = filepath.EvalSymlinks(workDir); err != nil {
+		fmt.Fprintf(os.Stderr, "e2e: invalid work directory: %v\n", err)
+		return 1
+	}
+
+	host := os.Getenv("REACTOR_E2E_DOCKER_HOST")
+	if host == "" {
+		var stop func()
+		host, stop, err = startDaemon(ctx)
+		if err != nil {
+			// The e2e tag asks for these tests, so a missing daemon fails the run rather than
+			// passing without running anything
+			fmt.Fprintf(os.Stderr, "e2e: no throwaway Docker daemon: %v\n", err)
+			return 1
+		}
+		defer stop()
+	}
+
+	// Everything the tests run talks to the throwaway daemon and reads config from the test HOME
+	os.Setenv("DOCKER_HOST", host)
+	os.Setenv("HOME", filepath.Join(workDir, "home"))
+	os.Setenv("CLAUDE_REACTOR_USE_REGISTRY", "false")
+	if err := waitForDaemon(ctx, host); err != nil {
+		fmt.Fprintf(os.Stderr, "e2e: %v\n", err)
+		return 1
+	}
+	if err := writeVariant(); err != nil {
+		fmt.Fprintf(os.Stderr, "e2e: failed to define the e2e variant: %v\n", err)
+		return 1
+	}
+	return m.Run()
+}
+
+// startDaemon runs dindImage on the host's daemon and returns its DOCKER_HOST and a function
+// removing it again
+func startDaemon(ctx context.Context) (string, func(), error) {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := cli.Ping(ctx); err != nil {
+		cli.Close()
+		return "", nil, fmt.Errorf("the host Docker daemon is not reachable: %w", err)
+	}
+
+	pull, err := cli.ImagePull(ctx, dindImage, image.PullOptions{})
+	if err != nil {
+		cli.Close()
+		return "", nil, fmt.Errorf("failed to pull %s: %w", dindImage, err)
+	}
+	io.Copy(io.Discard, pull)
+	pull.Close()
+
+	port := nat.Port("2375/tcp")
+	created, err := cli.ContainerCreate(ctx,
+		&container.Config{
+			Image:        dindImage,
+			Env:          []string{"DOCKER_TLS_CERTDIR="},
+			ExposedPorts: nat.PortSet{port: struct{}{}},
+			Labels:       map[string]string{"claude-reactor.e2e": "daemon"},
+		},
+		&container.HostConfig{
+			Privileged:   true,
+			PortBindings: nat.PortMap{port: {{HostIP: "127.0.0.1"}}},
+			Mounts:       []mount.Mount{{Type: mount.TypeBind, Source: workDir, Target: workDir}},
+		}, nil, nil, "")
+	if err != nil {
+		cli.Close()
+		return "", nil, fmt.Errorf("failed to create the Docker-in-Docker container: %w", err)
+	}
+	stop := func() {
+		cli.ContainerRemove(context.Background(), created.ID, container.RemoveOptions{Force: true, RemoveVolumes: true})
+		cli.Close()
+	}
+
+	if err := cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		stop()
+		return "", nil, fmt.Errorf("failed to start the Docker-in-Docker container: %w", err)
+	}
+	inspect, err := cli.ContainerInspect(ctx, created.ID)
+	if err != nil || len(inspect.NetworkSettings.Ports[port]) == 0 {
+		stop()
+		return "", nil, fmt.Errorf("failed to find the Docker-in-Docker port: %v", err)
+	}
+	return "tcp://127.0.0.1:" + inspect.NetworkSettings.Ports[port][0].HostPort, stop, nil
+}
+
+// waitForDaemon waits until the daemon at host answers
+func waitForDaemon(ctx context.Context, host string) error {
+	cli, err := client.NewClientWithOpts(client.WithHost(host), client.WithAPIVersionNegotiation())
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	deadline := time.Now().Add(daemonTimeout)
+	for {
+		_, err := cli.Ping(ctx)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the Docker daemon at %s did not start within %s: %w", host, daemonTimeout, err)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// writeVariant defines the small external variant the tests build, so they need not build
+// the built-in images
+func writeVariant() error {
+	dir := filepath.Join(os.Getenv("HOME"), ".claude-reactor", "variants.d")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	dockerfile := "FROM alpine:3.20\nRUN adduser -D claude\nCMD [\"sleep\", \"infinity\"]\n"
+	if err := os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte(dockerfile), 0644); err != nil {
+		return err
+	}
+	definition := "name: " + variant + "\ndescription: Throwaway image for end-to-end tests\ndockerfile: Dockerfile\n"
+	return os.WriteFile(filepath.Join(dir, variant+".yaml"), []byte(definition), 0644)
+}
@@ -0,0 +1,342 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-reactor/internal/reactor/architecture"
+	"claude-reactor/internal/reactor/docker"
+	"claude-reactor/internal/reactor/logging"
+	"claude-reactor/pkg"
+)
+
+// variant is the external variant defined by writeVariant
+const variant = "e2e"
+
+// newManager connects a DockerManager to the throwaway daemon and returns it with the
+// platform and name of the e2e image
+func newManager(t *testing.T) (pkg.DockerManager, string, string) {
+	t.Helper()
+	logger := logging.NewLoggerWithLevel(logrus.WarnLevel)
+	dockerMgr, err := docker.NewManager(logger)
+	require.NoError(t, err)
+
+	platform, err := architecture.NewDetector(logger).GetDockerPlatform()
+	require.NoError(t, err)
+	return dockerMgr, platform, dockerMgr.GetImageName(variant, architecture.PlatformArch(platform))
+}
+
+// ensureImage builds the e2e image unless an earlier test left it behind
+func ensureImage(t *testing.T, dockerMgr pkg.DockerManager, platform, imageName string) {
+	t.Helper()
+	if imageExists(t, dockerMgr, imageName) {
+		return
+	}
+	require.NoError(t, dockerMgr.BuildImage(context.Background(), variant, platform))
+}
+
+func imageExists(t *testing.T, dockerMgr pkg.DockerManager, imageName string) bool {
+	t.Helper()
+	_, err := dockerMgr.GetClient().ImageInspect(context.Background(), imageName)
+	if client.IsErrNotFound(err) {
+		return false
+	}
+	require.NoError(t, err)
+	return true
+}
+
+// containerConfig returns the configuration of a container of the e2e image, removed when the
+// test ends
+func containerConfig(t *testing.T, dockerMgr pkg.DockerManager, platform, imageName string) *pkg.ContainerConfig {
+	t.Helper()
+	config := &pkg.ContainerConfig{
+		Name:     "claude-reactor-" + variant + "-" + strings.ToLower(strings.ReplaceAll(t.Name(), "/", "-")),
+		Image:    imageName,
+		Variant:  variant,
+		Platform: platform,
+		Mounts:   []pkg.Mount{},
+	}
+	t.Cleanup(func() { dockerMgr.CleanContainer(context.Background(), config.Name) })
+	return config
+}
+
+// execOK runs command in a container and returns its trimmed output, failing unless it succeeds
+func execOK(t *testing.T, dockerMgr pkg.DockerManager, containerName string, command ...string) string {
+	t.Helper()
+	output, exitCode, err := dockerMgr.ExecCommand(context.Background(), containerName, command)
+	require.NoError(t, err)
+	require.Equal(t, 0, exitCode, "%v: %s", command, output)
+	return strings.TrimSpace(output)
+}
+
+func TestBuild(t *testing.T) {
+	dockerMgr, platform, imageName := newManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, dockerMgr.BuildImage(ctx, variant, platform))
+	assert.True(t, imageExists(t, dockerMgr, imageName))
+
+	t.Run("forced rebuild removes and builds the image", func(t *testing.T) {
+		require.NoError(t, dockerMgr.RebuildImage(ctx, variant, platform, true))
+		assert.True(t, imageExists(t, dockerMgr, imageName))
+	})
+
+	t.Run("unknown variants are rejected", func(t *testing.T) {
+		assert.ErrorContains(t, dockerMgr.BuildImage(ctx, "no-such-variant", platform), "invalid variant")
+	})
+}
+
+func TestRegistryFallback(t *testing.T) {
+	dockerMgr, platform, imageName := newManager(t)
+	ctx := context.Background()
+	// Nothing listens on port 1, so every pull fails
+	t.Setenv("CLAUDE_REACTOR_USE_REGISTRY", "true")
+	t.Setenv("CLAUDE_REACTOR_REGISTRY", "127.0.0.1:1/claude-reactor")
+
+	_, err := dockerMgr.GetClient().ImageRemove(ctx, imageName, image.RemoveOptions{Force: true})
+	if err != nil && !client.IsErrNotFound(err) {
+		require.NoError(t, err)
+	}
+
+	t.Run("unreachable registry falls back to a local build", func(t *testing.T) {
+		require.NoError(t, dockerMgr.BuildImageWithRegistry(ctx, variant, platform, false, false, false))
+		assert.True(t, imageExists(t, dockerMgr, imageName))
+	})
+
+	t.Run("existing images are used as they are", func(t *testing.T) {
+		before, err := dockerMgr.GetClient().ImageInspect(ctx, imageName)
+		require.NoError(t, err)
+		require.NoError(t, dockerMgr.BuildImageWithRegistry(ctx, variant, platform, false, false, false))
+		after, err := dockerMgr.GetClient().ImageInspect(ctx, imageName)
+		require.NoError(t, err)
+		assert.Equal(t, before.ID, after.ID)
+	})
+
+	t.Run("pull latest rebuilds when the registry is unreachable", func(t *testing.T) {
+		require.NoError(t, dockerMgr.BuildImageWithRegistry(ctx, variant, platform, false, false, true))
+		assert.True(t, imageExists(t, dockerMgr, imageName))
+	})
+
+	t.Run("registry off builds locally", func(t *testing.T) {
+		_, err := dockerMgr.GetClient().ImageRemove(ctx, imageName, image.RemoveOptions{Force: true})
+		require.NoError(t, err)
+		require.NoError(t, dockerMgr.BuildImageWithRegistry(ctx, variant, platform, false, true, false))
+		assert.True(t, imageExists(t, dockerMgr, imageName))
+	})
+}
+
+func TestRunReuseRecreate(t *testing.T) {
+	dockerMgr, platform, imageName := newManager(t)
+	ensureImage(t, dockerMgr, platform, imageName)
+	ctx := context.Background()
+	config := containerConfig(t, dockerMgr, platform, imageName)
+	session := &pkg.Config{SessionPersistence: true}
+
+	containerID, err := dockerMgr.StartOrRecoverContainer(ctx, config, session)
+	require.NoError(t, err)
+	assert.Equal(t, containerID, session.ContainerID)
+	assert.NotEmpty(t, session.LastSessionID)
+
+	status, err := dockerMgr.GetContainerStatus(ctx, config.Name)
+	require.NoError(t, err)
+	assert.True(t, status.Running)
+	assert.Equal(t, docker.ConfigHash(config), status.ConfigHash)
+	// The image has no init of its own, so claude-reactor copied it in and waited for it
+	execOK(t, dockerMgr, config.Name, "test", "-f", "/tmp/claude-reactor-init.ready")
+
+	t.Run("running container is reused", func(t *testing.T) {
+		reusedID, err := dockerMgr.StartOrRecoverContainer(ctx, config, session)
+		require.NoError(t, err)
+		assert.Equal(t, containerID, reusedID)
+	})
+
+	t.Run("stopped container is restarted", func(t *testing.T) {
+		require.NoError(t, dockerMgr.StopContainer(ctx, containerID))
+		running, err := dockerMgr.IsContainerRunning(ctx, config.Name)
+		require.NoError(t, err)
+		require.False(t, running)
+
+		// A lost container ID is found again by name
+		restartedID, err := dockerMgr.StartOrRecoverContainer(ctx, config, &pkg.Config{SessionPersistence: true})
+		require.NoError(t, err)
+		assert.Equal(t, containerID, restartedID)
+		running, err = dockerMgr.IsContainerRunning(ctx, config.Name)
+		require.NoError(t, err)
+		assert.True(t, running)
+	})
+
+	t.Run("changed configuration recreates the container", func(t *testing.T) {
+		changed := *config
+		changed.Environment = map[string]string{"E2E_SETTING": "changed"}
+		status, err := dockerMgr.GetContainerStatus(ctx, config.Name)
+		require.NoError(t, err)
+		require.NotEqual(t, docker.ConfigHash(&changed), status.ConfigHash)
+
+		require.NoError(t, dockerMgr.CleanContainer(ctx, config.Name))
+		recreatedID, err := dockerMgr.StartContainer(ctx, &changed)
+		require.NoError(t, err)
+		assert.NotEqual(t, containerID, recreatedID)
+		assert.Equal(t, "changed", execOK(t, dockerMgr, config.Name, "printenv", "E2E_SETTING"))
+	})
+
+	t.Run("without session persistence a new container is started", func(t *testing.T) {
+		require.NoError(t, dockerMgr.CleanContainer(ctx, config.Name))
+		freshID, err := dockerMgr.StartOrRecoverContainer(ctx, config, &pkg.Config{})
+		require.NoError(t, err)
+		assert.NotEqual(t, containerID, freshID)
+	})
+}
+
+func TestMounts(t *testing.T) {
+	dockerMgr, platform, imageName := newManager(t)
+	ensureImage(t, dockerMgr, platform, imageName)
+	ctx := context.Background()
+
+	project := filepath.Join(workDir, "project")
+	shared := filepath.Join(workDir, "shared")
+	require.NoError(t, os.MkdirAll(project, 0777))
+	require.NoError(t, os.MkdirAll(shared, 0777))
+	// The container user is not the test user, so it needs write access
+	require.NoError(t, os.Chmod(project, 0777))
+	require.NoError(t, os.WriteFile(filepath.Join(project, "hello.txt"), []byte("hello from the host\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "shared.txt"), []byte("read only\n"), 0644))
+
+	volume := "claude-reactor-e2e-volume"
+	t.Cleanup(func() { dockerMgr.GetClient().VolumeRemove(context.Background(), volume, true) })
+
+	config := containerConfig(t, dockerMgr, platform, imageName)
+	config.Mounts = []pkg.Mount{
+		{Type: "bind", Source: project, Target: "/app"},
+		{Type: "bind", Source: shared, Target: "/shared", ReadOnly: true},
+		{Type: "volume", Source: volume, Target: "/data"},
+		{Type: "bind", Source: filepath.Join(workDir, "missing"), Target: "/missing", Optional: true},
+	}
+	_, err := dockerMgr.StartContainer(ctx, config)
+	require.NoError(t, err)
+
+	t.Run("bind mounts share files with the host", func(t *testing.T) {
+		assert.Equal(t, "hello from the host", execOK(t, dockerMgr, config.Name, "cat", "/app/hello.txt"))
+		execOK(t, dockerMgr, config.Name, "sh", "-c", "echo from the container > /app/created.txt")
+		data, err := os.ReadFile(filepath.Join(project, "created.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "from the container\n", string(data))
+	})
+
+	t.Run("read-only mounts cannot be written", func(t *testing.T) {
+		assert.Equal(t, "read only", execOK(t, dockerMgr, config.Name, "cat", "/shared/shared.txt"))
+		_, exitCode, err := dockerMgr.ExecCommand(ctx, config.Name, []string{"touch", "/shared/new.txt"})
+		require.NoError(t, err)
+		assert.NotEqual(t, 0, exitCode)
+		assert.NoFileExists(t, filepath.Join(shared, "new.txt"))
+	})
+
+	t.Run("optional mounts with missing sources are skipped", func(t *testing.T) {
+		_, exitCode, err := dockerMgr.ExecCommand(ctx, config.Name, []string{"test", "-e", "/missing"})
+		require.NoError(t, err)
+		assert.NotEqual(t, 0, exitCode)
+	})
+
+	t.Run("volumes outlive the container", func(t *testing.T) {
+		execOK(t, dockerMgr, config.Name, "sh", "-c", "echo kept > /data/state")
+		require.NoError(t, dockerMgr.CleanContainer(ctx, config.Name))
+		_, err := dockerMgr.StartContainer(ctx, config)
+		require.NoError(t, err)
+		assert.Equal(t, "kept", execOK(t, dockerMgr, config.Name, "cat", "/data/state"))
+	})
+
+	t.Run("required mounts with missing sources fail", func(t *testing.T) {
+		broken := containerConfig(t, dockerMgr, platform, imageName)
+		broken.Name += "-broken"
+		broken.Mounts = []pkg.Mount{{Type: "bind", Source: filepath.Join(workDir, "missing"), Target: "/missing"}}
+		_, err := dockerMgr.StartContainer(ctx, broken)
+		assert.Error(t, err)
+	})
+}
+
+func TestExec(t *testing.T) {
+	dockerMgr, platform, imageName := newManager(t)
+	ensureImage(t, dockerMgr, platform, imageName)
+	ctx := context.Background()
+	config := containerConfig(t, dockerMgr, platform, imageName)
+	_, err := dockerMgr.StartContainer(ctx, config)
+	require.NoError(t, err)
+
+	t.Run("output and exit code", func(t *testing.T) {
+		output, exitCode, err := dockerMgr.ExecCommand(ctx, config.Name, []string{"sh", "-c", "echo out; echo err >&2; exit 3"})
+		require.NoError(t, err)
+		assert.Equal(t, 3, exitCode)
+		assert.Contains(t, output, "out")
+		assert.Contains(t, output, "err")
+	})
+
+	t.Run("pipe", func(t *testing.T) {
+		var stdout bytes.Buffer
+		require.NoError(t, dockerMgr.ExecPipe(ctx, config.Name, []string{"tr", "a-z", "A-Z"}, strings.NewReader("piped through\n"), &stdout))
+		assert.Equal(t, "PIPED THROUGH\n", stdout.String())
+	})
+
+	t.Run("missing containers are reported", func(t *testing.T) {
+		_, _, err := dockerMgr.ExecCommand(ctx, config.Name+"-missing", []string{"true"})
+		assert.Error(t, err)
+	})
+}
+
+func TestClean(t *testing.T) {
+	dockerMgr, platform, imageName := newManager(t)
+	ensureImage(t, dockerMgr, platform, imageName)
+	ctx := context.Background()
+
+	config := containerConfig(t, dockerMgr, platform, imageName)
+	_, err := dockerMgr.StartContainer(ctx, config)
+	require.NoError(t, err)
+	stopped := containerConfig(t, dockerMgr, platform, imageName)
+	stopped.Name += "-stopped"
+	stoppedID, err := dockerMgr.StartContainer(ctx, stopped)
+	require.NoError(t, err)
+	require.NoError(t, dockerMgr.StopContainer(ctx, stoppedID))
+
+	t.Run("resources list the containers and image", func(t *testing.T) {
+		resources, err := dockerMgr.ListResources(ctx)
+		require.NoError(t, err)
+		names := map[string]bool{}
+		for _, resource := range resources {
+			names[resource.Kind+":"+resource.Name] = true
+		}
+		assert.True(t, names[pkg.ResourceContainer+":"+config.Name], "resources: %v", names)
+		assert.True(t, names[pkg.ResourceContainer+":"+stopped.Name], "resources: %v", names)
+	})
+
+	t.Run("clean removes one container", func(t *testing.T) {
+		require.NoError(t, dockerMgr.CleanContainer(ctx, stopped.Name))
+		status, err := dockerMgr.GetContainerStatus(ctx, stopped.Name)
+		require.NoError(t, err)
+		assert.False(t, status.Exists)
+		// Cleaning twice is fine
+		assert.NoError(t, dockerMgr.CleanContainer(ctx, stopped.Name))
+	})
+
+	t.Run("clean all removes running containers", func(t *testing.T) {
+		require.NoError(t, dockerMgr.CleanAllContainers(ctx))
+		status, err := dockerMgr.GetContainerStatus(ctx, config.Name)
+		require.NoError(t, err)
+		assert.False(t, status.Exists)
+	})
+
+	t.Run("clean images removes claude-reactor images only", func(t *testing.T) {
+		require.NoError(t, dockerMgr.CleanImages(ctx, false))
+		assert.False(t, imageExists(t, dockerMgr, imageName))
+		assert.True(t, imageExists(t, dockerMgr, "alpine:3.20"), "the base image is not claude-reactor's")
+	})
+}